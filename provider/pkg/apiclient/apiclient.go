@@ -137,6 +137,49 @@ func createRequest(
 	return http.NewRequestWithContext(ctx, method, u.String(), body)
 }
 
+// Do calls routePattern through the same executor, default headers and
+// error handling as the generated methods. It exists for routes (or query
+// parameters) the OpenAPI spec does not model yet; prefer a generated method
+// whenever one exists. A nil body sends no request body, and a nil result
+// (or an empty response body) leaves result untouched.
+func (p *CloudClient) Do(
+	ctx context.Context,
+	method string,
+	routePattern string,
+	pathParams map[string]any,
+	queryParams map[string]any,
+	body any,
+	result any,
+	extraHeaders ...http.Header,
+) error {
+	var req *http.Request
+	var err error
+	if body != nil {
+		req, err = p.createRequestWithBody(ctx, method, routePattern, pathParams, queryParams, body)
+	} else {
+		req, err = p.createRequest(ctx, method, routePattern, pathParams, queryParams)
+	}
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		resp, err := p.invokeRaw(req, extraHeaders)
+		if err != nil {
+			return err
+		}
+		contract.IgnoreClose(resp.Body)
+		return nil
+	}
+	respBody, err := p.invokeWithResponse(req, extraHeaders)
+	if err != nil {
+		return err
+	}
+	if len(respBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(respBody, result)
+}
+
 func (p *CloudClient) invokeRaw(req *http.Request, headers []http.Header) (*http.Response, error) {
 	for _, header := range headers {
 		for key, vals := range header {
@@ -183,6 +226,12 @@ func (p *CloudClient) invokeRaw(req *http.Request, headers []http.Header) (*http
 			parsed.Message = strings.TrimSpace(string(respBody))
 		}
 
+		if parsed.Code == 0 && parsed.Message != "" {
+			// Some endpoints send an ErrorResponse without its code; the
+			// HTTP status stands in for it.
+			return nil, NewAPIError(resp.StatusCode, parsed.Message, resp.Header.Clone())
+		}
+
 		if parsed.Code == 0 {
 			// Our error parsed as JSON but it doesn't match the schema
 			// returned by API. Use the HTTP status code and raw body.
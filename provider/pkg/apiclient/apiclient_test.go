@@ -207,6 +207,22 @@ func TestCloudClient_Invoke_JSONErrorMissingCode(t *testing.T) {
 	assert.Equal(t, http.StatusTeapot, apiErr.HTTPStatusCode())
 }
 
+func TestCloudClient_Invoke_JSONErrorMissingCodeKeepsMessage(t *testing.T) {
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"code":0,"message":"unauthorized"}`))
+	}))
+
+	req, err := c.createRequest(context.Background(), http.MethodGet, "/x", nil, nil)
+	require.NoError(t, err)
+
+	err = c.invoke(req, nil)
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnauthorized, apiErr.HTTPStatusCode())
+	assert.Equal(t, "unauthorized", apiErr.ResponseMessage())
+}
+
 func TestCloudClient_InvokeWithResponse(t *testing.T) {
 	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		_, _ = io.WriteString(w, "hello body")
@@ -258,3 +274,50 @@ func TestCloudClient_CreateRequest_PathUnescapeError(t *testing.T) {
 	assert.True(t, strings.Contains(err.Error(), "invalid URL escape") ||
 		strings.Contains(err.Error(), "%ZZ"))
 }
+
+func TestCloudClient_Do(t *testing.T) {
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/orgs/acme/things", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("page"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, AcceptMediaType, r.Header.Get("Accept"))
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"name":"a"}`, string(body))
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+
+	page := 2
+	var res struct {
+		ID string `json:"id"`
+	}
+	err := c.Do(context.Background(), http.MethodPost, "/api/orgs/{org}/things",
+		map[string]any{"org": "acme"}, map[string]any{"page": &page},
+		map[string]string{"name": "a"}, &res)
+	require.NoError(t, err)
+	assert.Equal(t, "1", res.ID)
+}
+
+func TestCloudClient_Do_NoResult(t *testing.T) {
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	err := c.Do(context.Background(), http.MethodDelete, "/api/x", nil, nil, nil, nil)
+	require.NoError(t, err)
+}
+
+func TestCloudClient_Do_Error(t *testing.T) {
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":404,"message":"nope"}`))
+	}))
+
+	var res map[string]any
+	err := c.Do(context.Background(), http.MethodGet, "/api/x", nil, nil, nil, &res)
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.True(t, apiErr.IsNotFound())
+	assert.Equal(t, "HTTP 404: nope", err.Error())
+}
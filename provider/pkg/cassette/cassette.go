@@ -0,0 +1,449 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cassette records Pulumi Cloud HTTP exchanges to a file once and
+// replays them offline.
+//
+// A Cassette is both an http.RoundTripper, for the pulumiapi.Client executor
+// (wrap it in an http.Client), and a rest.Transport, for the metadata-driven
+// api resources (attach it with rest.WithTransport). In ModeRecord it
+// forwards every request upstream and appends the exchange; in ModeReplay it
+// answers from the file and never touches the network.
+//
+// Credentials never reach the file: request headers are not recorded at all,
+// and JSON fields named in Options.RedactFields are replaced with Redacted in
+// both request and response bodies. Replay redacts the incoming request the
+// same way before matching, so a test that sends a real secret still matches
+// the redacted recording.
+package cassette
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Mode selects whether a Cassette talks to a real backend or to its file.
+type Mode int
+
+const (
+	// ModeReplay answers requests from recorded interactions only.
+	ModeReplay Mode = iota
+	// ModeRecord forwards requests upstream and records each exchange.
+	ModeRecord
+)
+
+// formatVersion is written to every cassette so an incompatible layout
+// change fails loudly on load instead of replaying garbage.
+const formatVersion = 1
+
+// Redacted replaces the value of every redacted JSON field.
+const Redacted = "[REDACTED]"
+
+// DefaultRedactFields are the JSON field names whose values are credentials
+// somewhere in the Pulumi Cloud API: token values returned on create, webhook
+// and OIDC secrets, and deployment credentials.
+var DefaultRedactFields = []string{
+	"accessToken",
+	"clientSecret",
+	"password",
+	"privateKey",
+	"secret",
+	"token",
+	"tokenValue",
+}
+
+// recordedResponseHeaders are the response headers worth replaying. The rest
+// (dates, request IDs, cookies) vary per run or identify the recording
+// session, and no client in this provider reads them.
+var recordedResponseHeaders = []string{"Content-Type", "Location", "Retry-After"}
+
+// Options configures a Cassette.
+type Options struct {
+	Mode Mode
+	// Strict fails every request with no unplayed matching interaction and
+	// makes Unplayed report interactions the test never reached. Without it,
+	// an unmatched request gets a 404 and an interaction can replay more
+	// than once, which suits polling loops whose call count varies.
+	Strict bool
+	// Upstream sends requests in ModeRecord. Defaults to
+	// http.DefaultTransport; see Authenticated for rest.Transport callers,
+	// whose requests arrive without a host or credentials.
+	Upstream http.RoundTripper
+	// RedactFields overrides DefaultRedactFields.
+	RedactFields []string
+}
+
+// Cassette is a recorded sequence of HTTP interactions.
+type Cassette struct {
+	path     string
+	opts     Options
+	redact   map[string]bool
+	mu       sync.Mutex
+	file     file
+	played   []bool
+	failures []string
+}
+
+type file struct {
+	Version      int           `json:"version"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request and the response it received.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is the matched part of a recorded request. Host and headers are
+// deliberately absent: the rest engine sends to a sentinel host, and headers
+// carry credentials.
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   Body   `json:"body,omitzero"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    Body              `json:"body,omitzero"`
+}
+
+// Body holds a JSON body verbatim, so cassettes stay readable and diffable,
+// and any other body as text.
+type Body struct {
+	JSON json.RawMessage
+	Text string
+}
+
+// IsZero reports whether the body is empty.
+func (b Body) IsZero() bool { return len(b.JSON) == 0 && b.Text == "" }
+
+func (b Body) bytes() []byte {
+	if len(b.JSON) > 0 {
+		return b.JSON
+	}
+	return []byte(b.Text)
+}
+
+// MarshalJSON writes a JSON body inline and anything else as a string.
+func (b Body) MarshalJSON() ([]byte, error) {
+	if len(b.JSON) > 0 {
+		return b.JSON, nil
+	}
+	return json.Marshal(b.Text)
+}
+
+// UnmarshalJSON reverses MarshalJSON. A recorded JSON string body is
+// indistinguishable from a text body; both replay as the same bytes.
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		b.Text = text
+		return nil
+	}
+	b.JSON = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// Open loads the cassette at path for replay, or starts an empty one for
+// recording. A missing file in ModeReplay is an error: replaying nothing
+// would fail every request with a less useful message.
+func Open(path string, opts Options) (*Cassette, error) {
+	c := &Cassette{path: path, opts: opts, file: file{Version: formatVersion}}
+	fields := opts.RedactFields
+	if fields == nil {
+		fields = DefaultRedactFields
+	}
+	c.redact = make(map[string]bool, len(fields))
+	for _, f := range fields {
+		c.redact[f] = true
+	}
+	if opts.Upstream == nil {
+		c.opts.Upstream = http.DefaultTransport
+	}
+	if opts.Mode == ModeRecord {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &c.file); err != nil {
+		return nil, fmt.Errorf("cassette: parse %s: %w", path, err)
+	}
+	if c.file.Version != formatVersion {
+		return nil, fmt.Errorf("cassette: %s has format version %d; want %d",
+			path, c.file.Version, formatVersion)
+	}
+	// Saved bodies are indented, and hand-edited ones may order fields
+	// freely; bring request bodies back to the canonical form replay
+	// compares against.
+	for i := range c.file.Interactions {
+		body := &c.file.Interactions[i].Request.Body
+		if len(body.JSON) > 0 {
+			*body = c.body(body.JSON)
+		}
+	}
+	c.played = make([]bool, len(c.file.Interactions))
+	return c, nil
+}
+
+// Recording reports whether the cassette is in ModeRecord.
+func (c *Cassette) Recording() bool { return c.opts.Mode == ModeRecord }
+
+// Interactions returns a copy of the interactions recorded or loaded so far.
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interaction(nil), c.file.Interactions...)
+}
+
+// Do implements rest.Transport.
+func (c *Cassette) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return c.RoundTrip(req.WithContext(ctx))
+}
+
+// RoundTrip implements http.RoundTripper.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := c.recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if c.opts.Mode == ModeRecord {
+		return c.forward(req, recorded)
+	}
+	return c.replay(req, recorded)
+}
+
+// forward sends req upstream and records the exchange. The request body was
+// consumed by recordRequest and is restored before sending.
+func (c *Cassette) forward(req *http.Request, recorded Request) (*http.Response, error) {
+	resp, err := c.opts.Upstream.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("cassette: read response for %s %s: %w", req.Method, req.URL.Path, err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	response := Response{Status: resp.StatusCode, Body: c.body(data)}
+	for _, name := range recordedResponseHeaders {
+		if v := resp.Header.Get(name); v != "" {
+			if response.Headers == nil {
+				response.Headers = map[string]string{}
+			}
+			response.Headers[name] = v
+		}
+	}
+
+	c.mu.Lock()
+	c.file.Interactions = append(c.file.Interactions, Interaction{Request: recorded, Response: response})
+	c.mu.Unlock()
+	return resp, nil
+}
+
+func (c *Cassette) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	match, reused := -1, -1
+	for i, in := range c.file.Interactions {
+		if !in.Request.matches(recorded) {
+			continue
+		}
+		if !c.played[i] {
+			match = i
+			break
+		}
+		reused = i
+	}
+	if match < 0 && !c.opts.Strict {
+		match = reused
+	}
+	if match < 0 {
+		msg := fmt.Sprintf("no recorded interaction for %s", recorded)
+		if c.opts.Strict {
+			c.failures = append(c.failures, msg)
+			return nil, fmt.Errorf("cassette: %s", msg)
+		}
+		return newResponse(req, Response{
+			Status:  http.StatusNotFound,
+			Headers: map[string]string{"Content-Type": "application/json"},
+			Body:    Body{JSON: json.RawMessage(fmt.Sprintf(`{"code":404,"message":%q}`, "cassette: "+msg))},
+		}), nil
+	}
+	c.played[match] = true
+	return newResponse(req, c.file.Interactions[match].Response), nil
+}
+
+func newResponse(req *http.Request, r Response) *http.Response {
+	header := http.Header{}
+	for k, v := range r.Headers {
+		header.Set(k, v)
+	}
+	body := r.Body.bytes()
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// recordRequest reduces req to its matched form, redacting the body. The
+// body is read and replaced so the request can still be sent.
+func (c *Cassette) recordRequest(req *http.Request) (Request, error) {
+	recorded := Request{
+		Method: req.Method,
+		Path:   req.URL.EscapedPath(),
+		// Encode sorts by key, so query order never affects matching.
+		Query: req.URL.Query().Encode(),
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return recorded, nil
+	}
+	data, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return Request{}, fmt.Errorf("cassette: read request body for %s: %w", recorded, err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	recorded.Body = c.body(data)
+	return recorded, nil
+}
+
+func (r Request) String() string {
+	s := r.Method + " " + r.Path
+	if r.Query != "" {
+		s += "?" + r.Query
+	}
+	return s
+}
+
+func (r Request) matches(other Request) bool {
+	return r.Method == other.Method &&
+		r.Path == other.Path &&
+		r.Query == other.Query &&
+		bytes.Equal(r.Body.bytes(), other.Body.bytes())
+}
+
+// body redacts and canonicalizes a JSON body (sorted keys, no insignificant
+// whitespace) so field order never affects matching. Anything that does not
+// parse as JSON, such as an ESC YAML definition, is kept as text.
+func (c *Cassette) body(data []byte) Body {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return Body{}
+	}
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return Body{Text: string(data)}
+	}
+	if _, ok := v.(string); ok {
+		// A top-level JSON string would round-trip through the file as text
+		// and replay without its quotes.
+		return Body{Text: string(data)}
+	}
+	canonical, err := json.Marshal(c.redactValue(v))
+	if err != nil {
+		return Body{Text: string(data)}
+	}
+	return Body{JSON: canonical}
+}
+
+func (c *Cassette) redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, field := range v {
+			if c.redact[k] {
+				if field != nil {
+					v[k] = Redacted
+				}
+				continue
+			}
+			v[k] = c.redactValue(field)
+		}
+	case []any:
+		for i := range v {
+			v[i] = c.redactValue(v[i])
+		}
+	}
+	return v
+}
+
+// Save writes recorded interactions to the cassette's path, creating parent
+// directories. It does nothing in ModeReplay, so a test can always defer it.
+func (c *Cassette) Save() error {
+	if c.opts.Mode != ModeRecord {
+		return nil
+	}
+	c.mu.Lock()
+	data, err := json.MarshalIndent(c.file, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("cassette: encode %s: %w", c.path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+	if err := os.WriteFile(c.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+	return nil
+}
+
+// Unplayed returns an error naming each interaction a strict replay never
+// reached, and each request it could not match. It returns nil outside
+// strict replay.
+func (c *Cassette) Unplayed() error {
+	if c.opts.Mode != ModeReplay || !c.opts.Strict {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	problems := append([]string(nil), c.failures...)
+	for i, played := range c.played {
+		if !played {
+			problems = append(problems, fmt.Sprintf("interaction %d (%s) was never requested",
+				i, c.file.Interactions[i].Request))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New("cassette " + c.path + ":\n  " + strings.Join(problems, "\n  "))
+}
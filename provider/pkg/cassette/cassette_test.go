@@ -0,0 +1,196 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassette
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secretToken = "pul-0123456789abcdef" //nolint:gosec // G101: test fixture, not a real credential.
+
+func do(t *testing.T, rt http.RoundTripper, method, target, body string) (int, string) {
+	t.Helper()
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(t.Context(), method, target, reader)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "token "+secretToken)
+	resp, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(data)
+}
+
+// record runs fn against a server that echoes a token-bearing JSON body for
+// POSTs and a YAML document for GETs, and returns the saved cassette path.
+func record(t *testing.T, fn func(rt http.RoundTripper, base string)) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Request-Id", "varies-per-run")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, `{"id":"tok-1","tokenValue":"`+secretToken+`"}`)
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/x-yaml")
+			_, _ = io.WriteString(w, "values:\n  a: 1\n")
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+	c, err := Open(path, Options{Mode: ModeRecord})
+	require.NoError(t, err)
+	fn(c, server.URL)
+	require.NoError(t, c.Save())
+	return path
+}
+
+func TestRecordRedactsCredentials(t *testing.T) {
+	path := record(t, func(rt http.RoundTripper, base string) {
+		status, body := do(t, rt, http.MethodPost, base+"/api/user/tokens",
+			`{"description":"ci","secret":"webhook-secret"}`)
+		// The caller still sees the real response while recording.
+		assert.Equal(t, http.StatusCreated, status)
+		assert.Contains(t, body, secretToken)
+	})
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), secretToken)
+	assert.NotContains(t, string(data), "webhook-secret")
+	assert.NotContains(t, string(data), "Authorization")
+	assert.NotContains(t, string(data), "varies-per-run")
+	assert.Contains(t, string(data), Redacted)
+}
+
+func TestReplay(t *testing.T) {
+	path := record(t, func(rt http.RoundTripper, base string) {
+		do(t, rt, http.MethodPost, base+"/api/user/tokens?b=2&a=1", `{"description":"ci","expires":0}`)
+		do(t, rt, http.MethodGet, base+"/api/esc/environments/org/proj/env/yaml", "")
+		do(t, rt, http.MethodDelete, base+"/api/user/tokens/tok-1", "")
+	})
+
+	c, err := Open(path, Options{Mode: ModeReplay, Strict: true})
+	require.NoError(t, err)
+
+	// Host, query order and JSON field order do not affect matching.
+	status, body := do(t, c, http.MethodPost, "https://elsewhere.invalid/api/user/tokens?a=1&b=2",
+		`{"expires":0, "description":"ci"}`)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.JSONEq(t, `{"id":"tok-1","tokenValue":"`+Redacted+`"}`, body)
+
+	status, body = do(t, c, http.MethodGet, "https://elsewhere.invalid/api/esc/environments/org/proj/env/yaml", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "values:\n  a: 1\n", body)
+
+	status, _ = do(t, c, http.MethodDelete, "https://elsewhere.invalid/api/user/tokens/tok-1", "")
+	assert.Equal(t, http.StatusNoContent, status)
+
+	assert.NoError(t, c.Unplayed())
+}
+
+func TestReplayMatchesRedactedRequestBodies(t *testing.T) {
+	path := record(t, func(rt http.RoundTripper, base string) {
+		do(t, rt, http.MethodPost, base+"/api/orgs/org/hooks", `{"name":"h","secret":"recorded-secret"}`)
+	})
+
+	c, err := Open(path, Options{Mode: ModeReplay, Strict: true})
+	require.NoError(t, err)
+	status, _ := do(t, c, http.MethodPost, "http://x/api/orgs/org/hooks", `{"name":"h","secret":"another-secret"}`)
+	assert.Equal(t, http.StatusCreated, status)
+}
+
+func TestStrictReplay(t *testing.T) {
+	path := record(t, func(rt http.RoundTripper, base string) {
+		do(t, rt, http.MethodDelete, base+"/api/user/tokens/tok-1", "")
+		do(t, rt, http.MethodDelete, base+"/api/user/tokens/tok-2", "")
+	})
+
+	c, err := Open(path, Options{Mode: ModeReplay, Strict: true})
+	require.NoError(t, err)
+
+	do(t, c, http.MethodDelete, "http://x/api/user/tokens/tok-1", "")
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodDelete, "http://x/api/user/tokens/tok-1", nil)
+	require.NoError(t, err)
+	_, err = c.RoundTrip(req)
+	assert.ErrorContains(t, err, "no recorded interaction for DELETE /api/user/tokens/tok-1",
+		"strict replay plays each interaction once")
+
+	err = c.Unplayed()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no recorded interaction for DELETE /api/user/tokens/tok-1")
+	assert.Contains(t, err.Error(), "interaction 1 (DELETE /api/user/tokens/tok-2) was never requested")
+}
+
+func TestLenientReplay(t *testing.T) {
+	path := record(t, func(rt http.RoundTripper, base string) {
+		do(t, rt, http.MethodDelete, base+"/api/user/tokens/tok-1", "")
+	})
+
+	c, err := Open(path, Options{Mode: ModeReplay})
+	require.NoError(t, err)
+
+	for range 2 {
+		status, _ := do(t, c, http.MethodDelete, "http://x/api/user/tokens/tok-1", "")
+		assert.Equal(t, http.StatusNoContent, status, "interactions replay more than once")
+	}
+	status, body := do(t, c, http.MethodGet, "http://x/api/user/tokens", "")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Contains(t, body, "no recorded interaction for GET /api/user/tokens")
+	assert.NoError(t, c.Unplayed())
+}
+
+func TestOpenMissingCassette(t *testing.T) {
+	_, err := Open(filepath.Join(t.TempDir(), "missing.json"), Options{})
+	assert.Error(t, err)
+}
+
+func TestAuthenticated(t *testing.T) {
+	var got *http.Request
+	rt, err := Authenticated("https://api.example.com", secretToken, roundTripperFunc(
+		func(req *http.Request) (*http.Response, error) {
+			got = req
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}))
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "https://sentinel.invalid/api/user", nil)
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, "api.example.com", got.URL.Host)
+	assert.Equal(t, "token "+secretToken, got.Header.Get("Authorization"))
+	assert.Equal(t, "application/vnd.pulumi+9", got.Header.Get("Accept"))
+	assert.Empty(t, req.Header.Get("Authorization"), "the caller's request is not modified")
+}
@@ -0,0 +1,105 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassette
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apiclient"
+)
+
+// EnvVarRecord switches New into ModeRecord when set to a non-empty value.
+// Recording talks to the backend named by PULUMI_BACKEND_URL (default
+// https://api.pulumi.com) with PULUMI_ACCESS_TOKEN.
+const EnvVarRecord = "PULUMI_CASSETTE_RECORD"
+
+// These mirror config.EnvVarPulumiAccessToken and config.EnvVarPulumiBackendURL;
+// config imports pulumiapi, whose own tests replay cassettes.
+const (
+	envVarAccessToken = "PULUMI_ACCESS_TOKEN"
+	envVarBackendURL  = "PULUMI_BACKEND_URL"
+)
+
+// New opens the cassette at path for a test, in strict replay unless
+// EnvVarRecord is set. In ModeRecord the cassette forwards through
+// Authenticated, so it works as a rest.Transport as well as under a
+// pulumiapi.Client, and the recording is saved when the test ends. In strict
+// replay, interactions the test never reached fail it at cleanup.
+func New(t testing.TB, path string) *Cassette {
+	t.Helper()
+	opts := Options{Mode: ModeReplay, Strict: true}
+	if os.Getenv(EnvVarRecord) != "" {
+		token := os.Getenv(envVarAccessToken)
+		if token == "" {
+			t.Fatalf("cassette: %s is set but %s is empty", EnvVarRecord, envVarAccessToken)
+		}
+		backend := os.Getenv(envVarBackendURL)
+		if backend == "" {
+			backend = "https://api.pulumi.com"
+		}
+		upstream, err := Authenticated(backend, token, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts = Options{Mode: ModeRecord, Upstream: upstream}
+	}
+
+	c, err := Open(path, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := c.Save(); err != nil {
+			t.Error(err)
+		}
+		if err := c.Unplayed(); err != nil {
+			t.Error(err)
+		}
+	})
+	return c
+}
+
+// Authenticated returns a RoundTripper that points each request at baseURL
+// and authenticates it with token before sending it through next (default
+// http.DefaultTransport). It stands in for the provider's own transport when
+// recording rest.Resource traffic, whose requests carry a sentinel host.
+func Authenticated(baseURL, token string, next http.RoundTripper) (http.RoundTripper, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: parse base URL %q: %w", baseURL, err)
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.URL.Scheme = base.Scheme
+		req.URL.Host = base.Host
+		req.Host = base.Host
+		req.Header.Set("Authorization", "token "+token)
+		if req.Header.Get("Accept") == "" {
+			req.Header.Set("Accept", apiclient.AcceptMediaType)
+		}
+		return next.RoundTrip(req)
+	}), nil
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
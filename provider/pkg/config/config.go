@@ -74,30 +74,41 @@ type Client interface {
 	pulumiapi.AccessTokenClient
 	pulumiapi.AgentPoolClient
 	pulumiapi.ApprovalRuleClient
+	pulumiapi.ChangeRequestClient
+	pulumiapi.DeploymentClient
 	pulumiapi.DeploymentSettingsClient
+	pulumiapi.EnvironmentListClient
 	pulumiapi.EnvironmentMetadataClient
+	pulumiapi.EnvironmentRestoreClient
+	pulumiapi.EnvironmentRotationClient
 	pulumiapi.EnvironmentScheduleClient
 	pulumiapi.InsightsAccountClient
 	pulumiapi.MemberClient
 	pulumiapi.OidcClient
 	pulumiapi.OrgAccessTokenClient
+	pulumiapi.PolicyGroupClient
 	pulumiapi.PolicyPackClient
 	pulumiapi.RegistryPolicyPackClient
 	pulumiapi.RoleClient
 	pulumiapi.StackClient
 	pulumiapi.StackScheduleClient
+	pulumiapi.StackStateClient
 	pulumiapi.StackTagClient
 	pulumiapi.TeamAccessTokenClient
 	pulumiapi.TeamClient
 	pulumiapi.TeamRoleClient
 	pulumiapi.TemplateSourceClient
+	pulumiapi.TokenInventoryClient
 	pulumiapi.UserClient
 	pulumiapi.WebhookClient
 }
 
 type Config struct {
-	AccessToken string `pulumi:"accessToken,optional" provider:"secret"`
-	APIURL      string `pulumi:"apiUrl,optional"`
+	AccessToken     string `pulumi:"accessToken,optional" provider:"secret"`
+	APIURL          string `pulumi:"apiUrl,optional"`
+	MaxRetries      *int   `pulumi:"maxRetries,optional"`
+	RetryMaxBackoff *int   `pulumi:"retryMaxBackoff,optional"`
+	RequestTimeout  *int   `pulumi:"requestTimeout,optional"`
 
 	client    *pulumiapi.Client
 	escClient esc_client.Client
@@ -107,6 +118,49 @@ func (c *Config) Annotate(a infer.Annotator) {
 	a.Describe(&c.AccessToken, "Access Token to authenticate with Pulumi Cloud.")
 	a.Describe(&c.APIURL, "Optional override of Pulumi Cloud API endpoint.")
 	a.SetDefault(&c.APIURL, "https://api.pulumi.com", EnvVarPulumiBackendURL, EnvVarPulumiAPI)
+	a.Describe(&c.MaxRetries, fmt.Sprintf("Number of times a request that failed transiently (HTTP 429, 502, 503, "+
+		"504 or a connection error) is retried. Only idempotent requests are retried, plus any request "+
+		"answered with 429. Set to 0 to disable retries. Defaults to %d.", pulumiapi.DefaultMaxRetries))
+	a.Describe(&c.RetryMaxBackoff, fmt.Sprintf("Upper bound, in seconds, on the delay between two retries. "+
+		"A `Retry-After` longer than this is not waited out. Defaults to %d.",
+		int(pulumiapi.DefaultRetryMaxBackoff/time.Second)))
+	a.Describe(&c.RequestTimeout, fmt.Sprintf("Timeout, in seconds, for a single HTTP request to Pulumi Cloud. "+
+		"Each retry gets a fresh timeout. When unset, requests made by a create, update or delete with "+
+		"`customTimeouts` are bounded by that operation timeout alone, and all other requests time out "+
+		"after %d.", int(pulumiapi.DefaultRequestTimeout/time.Second)))
+}
+
+// RetryPolicy returns the retry and per-attempt timeout settings for
+// requests to Pulumi Cloud, with unset options falling back to the pulumiapi
+// defaults.
+func (c *Config) RetryPolicy() pulumiapi.RetryPolicy {
+	policy := pulumiapi.DefaultRetryPolicy()
+	if c.MaxRetries != nil {
+		policy.MaxRetries = *c.MaxRetries
+	}
+	if c.RetryMaxBackoff != nil {
+		policy.MaxBackoff = time.Duration(*c.RetryMaxBackoff) * time.Second
+		policy.MinBackoff = min(policy.MinBackoff, policy.MaxBackoff)
+	}
+	if c.RequestTimeout != nil {
+		policy.AttemptTimeout = time.Duration(*c.RequestTimeout) * time.Second
+	}
+	return policy
+}
+
+// Validate rejects retry and timeout values the HTTP layer can't
+// honor.
+func (c *Config) Validate() error {
+	if c.MaxRetries != nil && *c.MaxRetries < 0 {
+		return fmt.Errorf("maxRetries must not be negative, got %d", *c.MaxRetries)
+	}
+	if c.RetryMaxBackoff != nil && *c.RetryMaxBackoff < 0 {
+		return fmt.Errorf("retryMaxBackoff must not be negative, got %d", *c.RetryMaxBackoff)
+	}
+	if c.RequestTimeout != nil && *c.RequestTimeout <= 0 {
+		return fmt.Errorf("requestTimeout must be positive, got %d", *c.RequestTimeout)
+	}
+	return nil
 }
 
 func (c *Config) Configure(context.Context) error {
@@ -141,10 +195,16 @@ func (c *Config) Configure(context.Context) error {
 		c.APIURL = os.Getenv(EnvVarPulumiAPI)
 	}
 
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
 	var err error
-	c.client, err = pulumiapi.NewClient(&http.Client{
-		Timeout: 60 * time.Second,
-	}, c.AccessToken, c.APIURL)
+	// No http.Client.Timeout: the retry policy bounds each attempt, so an
+	// operation's customTimeouts can outlast the per-request default.
+	c.client, err = pulumiapi.NewClient(
+		&http.Client{}, c.AccessToken, c.APIURL, pulumiapi.WithRetryPolicy(c.RetryPolicy()),
+	)
 	if err != nil {
 		return err
 	}
@@ -188,6 +248,26 @@ func (*Config) Diff(_ context.Context, req infer.DiffRequest[*Config, *Config])
 			InputDiff: true,
 		}
 	}
+	for key, pair := range map[string][2]*int{
+		"maxRetries":      {req.Inputs.MaxRetries, req.State.MaxRetries},
+		"retryMaxBackoff": {req.Inputs.RetryMaxBackoff, req.State.RetryMaxBackoff},
+		"requestTimeout":  {req.Inputs.RequestTimeout, req.State.RequestTimeout},
+	} {
+		input, state := pair[0], pair[1]
+		switch {
+		case input == nil && state == nil:
+			continue
+		case input == nil:
+			detailedDiff[key] = p.PropertyDiff{Kind: p.Delete, InputDiff: true}
+		case state == nil:
+			detailedDiff[key] = p.PropertyDiff{Kind: p.Add, InputDiff: true}
+		case *input != *state:
+			detailedDiff[key] = p.PropertyDiff{Kind: p.Update, InputDiff: true}
+		default:
+			continue
+		}
+		hasChanges = true
+	}
 	return infer.DiffResponse{
 		HasChanges:   hasChanges,
 		DetailedDiff: detailedDiff,
@@ -17,9 +17,14 @@ package config
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
 )
 
 // `pulumi import` persists default-provider inputs as `{}`. On later
@@ -86,6 +91,23 @@ func TestConfigure_BackendURLBeatsPulumiAPI(t *testing.T) {
 		"PULUMI_BACKEND_URL must win over PULUMI_API when both are set")
 }
 
+// With no config and no env var, the token of the CLI's current login is
+// used.
+func TestConfigure_FallsBackToStoredCredentials(t *testing.T) {
+	t.Setenv(EnvVarPulumiAccessToken, "")
+	t.Setenv("PULUMI_HOME", t.TempDir())
+	account := "https://api.pulumi.com"
+	require.NoError(t, workspace.StoreCredentials(workspace.Credentials{
+		Current:      account,
+		AccessTokens: map[string]string{account: "pul-stored-token"},
+	}))
+
+	c := &Config{}
+	require.NoError(t, c.Configure(context.Background()))
+
+	assert.Equal(t, "pul-stored-token", c.AccessToken)
+}
+
 // No config, no env, no stored credentials → ErrAccessTokenNotFound.
 // Skips when ~/.pulumi/credentials.json is populated (dev machines).
 func TestConfigure_EmptyInputsAndNoEnvVarsErrors(t *testing.T) {
@@ -98,3 +120,29 @@ func TestConfigure_EmptyInputsAndNoEnvVarsErrors(t *testing.T) {
 	}
 	assert.ErrorIs(t, err, ErrAccessTokenNotFound)
 }
+
+// Unset retry/timeout options fall back to the pulumiapi defaults; set ones
+// override them, and a backoff cap below the default base pulls the base down.
+func TestConfig_RetryPolicyAndTimeout(t *testing.T) {
+	c := &Config{}
+	assert.Equal(t, pulumiapi.DefaultRetryPolicy(), c.RetryPolicy())
+	assert.Zero(t, c.RetryPolicy().AttemptTimeout, "unset leaves the attempt timeout to the request's deadline")
+
+	zero, five, ninety := 0, 5, 90
+	c = &Config{MaxRetries: &five, RetryMaxBackoff: &zero, RequestTimeout: &ninety}
+	assert.Equal(t, pulumiapi.RetryPolicy{MaxRetries: 5, AttemptTimeout: 90 * time.Second}, c.RetryPolicy())
+}
+
+func TestConfigure_RejectsInvalidHTTPSettings(t *testing.T) {
+	t.Setenv(EnvVarPulumiAccessToken, "pul-test-token")
+	negative, zero := -1, 0
+
+	for name, c := range map[string]*Config{
+		"maxRetries":      {MaxRetries: &negative},
+		"retryMaxBackoff": {RetryMaxBackoff: &negative},
+		"requestTimeout":  {RequestTimeout: &zero},
+	} {
+		err := c.Configure(context.Background())
+		assert.ErrorContains(t, err, name)
+	}
+}
@@ -0,0 +1,506 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakecloud
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// handler serves one operation with the server's lock held.
+type handler func(s *Server, w http.ResponseWriter, req *request)
+
+// overrides replace the generic behaviour for operations whose wire shape it
+// cannot produce, keyed by operationId.
+var overrides = map[string]handler{
+	"GetCurrentUser": getCurrentUser,
+
+	"CreateStack": createStack,
+	"GetStack":    getStack,
+
+	"CreatePulumiTeam": createTeam("pulumi"),
+	"CreateGitHubTeam": createTeam("github"),
+	"UpdateTeam":       updateTeam,
+
+	"NewPolicyGroup":         newPolicyGroup,
+	"BatchUpdatePolicyGroup": batchUpdatePolicyGroup,
+
+	"CreateScheduledDeployment":      createStackSchedule(deploymentDefinition),
+	"UpdateScheduledDeployment":      updateStackSchedule(deploymentDefinition),
+	"CreateScheduledDriftDeployment": createStackSchedule(driftDefinition),
+	"UpdateScheduledDriftDeployment": updateStackSchedule(driftDefinition),
+	"CreateScheduledTTLDeployment":   createStackSchedule(ttlDefinition),
+	"UpdateScheduledTTLDeployment":   updateStackSchedule(ttlDefinition),
+	"CreateEnvironmentSchedule":      createEnvironmentSchedule,
+	"UpdateEnvironmentSchedule":      updateEnvironmentSchedule,
+
+	"CreateEnvironment_esc_environments":        createEnvironment,
+	"ReadEnvironment_esc_environments":          readEnvironment,
+	"DecryptEnvironment_esc_environments":       readEnvironment,
+	"ReadEnvironment_esc_environments_versions": readEnvironment,
+	"UpdateEnvironment_esc_environments":        updateEnvironment,
+	"ListOrgEnvironments_esc":                   listOrgEnvironments,
+	"GetEnvironmentMetadata_esc_environments":   getEnvironmentMetadata,
+	"CheckYAML_esc":                             emptyObject,
+	"CheckEnvironment_esc_environments":         emptyObject,
+}
+
+// aliases serve an operation at a second method, keyed by operationId. The
+// environment schedule update has always been driven with POST.
+var aliases = map[string]string{
+	"UpdateEnvironmentSchedule": http.MethodPost,
+}
+
+// FakeUser is the login every token authenticates as.
+const FakeUser = "fakecloud-user"
+
+func getCurrentUser(_ *Server, w http.ResponseWriter, _ *request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id":            "00000000-0000-4000-8000-000000000000",
+		"githubLogin":   FakeUser,
+		"name":          FakeUser,
+		"email":         FakeUser + "@example.com",
+		"organizations": []any{},
+		"identities":    []any{},
+	})
+}
+
+func emptyObject(_ *Server, w http.ResponseWriter, _ *request) {
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func createStack(s *Server, w http.ResponseWriter, req *request) {
+	doc := clone(req.body)
+	doc["orgName"] = req.params["orgName"]
+	doc["projectName"] = req.params["projectName"]
+	s.create(w, req, req.path, "stackName", doc)
+}
+
+// getStack returns the stack with its tags, which are stored as items of the
+// stack's /tags collection.
+func getStack(s *Server, w http.ResponseWriter, req *request) {
+	it, ok := s.items[req.path]
+	if !ok {
+		writeError(w, http.StatusNotFound, "stack %s not found", strings.TrimPrefix(req.path, "/api/stacks/"))
+		return
+	}
+	doc := clone(it.doc)
+	tags := map[string]any{}
+	for _, tag := range s.children(req.path + "/tags") {
+		if name, _ := tag["name"].(string); name != "" {
+			tags[name] = tag["value"]
+		}
+	}
+	doc["tags"] = tags
+	writeJSON(w, http.StatusOK, doc)
+}
+
+func createTeam(kind string) handler {
+	return func(s *Server, w http.ResponseWriter, req *request) {
+		doc := clone(req.body)
+		if kind == "github" {
+			doc = map[string]any{"name": fmt.Sprintf("github-team-%v", req.body["githubTeamID"])}
+		}
+		doc["kind"] = kind
+		doc["members"] = []any{}
+		doc["stacks"] = []any{}
+		doc["environments"] = []any{}
+		s.create(w, req, parent(req.path), "teamName", doc)
+	}
+}
+
+// updateTeam applies an UpdateTeamRequest, which carries one kind of change
+// per call.
+func updateTeam(s *Server, w http.ResponseWriter, req *request) {
+	it, ok := s.items[req.path]
+	if !ok {
+		writeError(w, http.StatusNotFound, "team %s not found", req.params["teamName"])
+		return
+	}
+	team, body := it.doc, req.body
+	if v, ok := body["newDisplayName"]; ok {
+		team["displayName"] = v
+	}
+	if v, ok := body["newDescription"]; ok {
+		team["description"] = v
+	}
+	if member, _ := body["member"].(string); member != "" {
+		members := list(team, "members")
+		i := indexOf(members, "name", member)
+		switch body["memberAction"] {
+		case "add":
+			if i >= 0 {
+				writeError(w, http.StatusConflict, "%s is already a member of the team", member)
+				return
+			}
+			members = append(members, map[string]any{"name": member, "githubLogin": member, "role": "member"})
+		case "remove":
+			if i >= 0 {
+				members = append(members[:i], members[i+1:]...)
+			}
+		}
+		team["members"] = members
+	}
+	for _, field := range []string{"addStackPermission", "editStackPermission", "removeStack"} {
+		if perm, ok := body[field].(map[string]any); ok {
+			team["stacks"] = upsert(list(team, "stacks"), perm, field != "removeStack", "projectName", "stackName")
+		}
+	}
+	for _, field := range []string{"addEnvironmentPermission", "editEnvironmentPermission", "removeEnvironment"} {
+		if perm, ok := body[field].(map[string]any); ok {
+			team["environments"] = upsert(list(team, "environments"), perm, field != "removeEnvironment",
+				"projectName", "envName")
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newPolicyGroup(s *Server, w http.ResponseWriter, req *request) {
+	doc := clone(req.body)
+	doc["isOrgDefault"] = false
+	doc["stacks"] = []any{}
+	doc["accounts"] = []any{}
+	doc["appliedPolicyPacks"] = []any{}
+	s.create(w, req, req.path, "policyGroup", doc)
+}
+
+// batchUpdatePolicyGroup applies a list of UpdatePolicyGroupRequests in order.
+func batchUpdatePolicyGroup(s *Server, w http.ResponseWriter, req *request) {
+	path := parent(req.path)
+	it, ok := s.items[path]
+	if !ok {
+		writeError(w, http.StatusNotFound, "policy group %s not found", req.params["policyGroup"])
+		return
+	}
+	updates, ok := req.value.([]any)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "expected a list of policy group updates")
+		return
+	}
+	group := it.doc
+	for _, u := range updates {
+		update, _ := u.(map[string]any)
+		if v, ok := update["addStack"].(map[string]any); ok {
+			group["stacks"] = upsert(list(group, "stacks"), v, true, "name", "routingProject")
+		}
+		if v, ok := update["removeStack"].(map[string]any); ok {
+			group["stacks"] = upsert(list(group, "stacks"), v, false, "name", "routingProject")
+		}
+		if v, ok := update["addPolicyPack"].(map[string]any); ok {
+			group["appliedPolicyPacks"] = upsert(list(group, "appliedPolicyPacks"), v, true, "name")
+		}
+		if v, ok := update["removePolicyPack"].(map[string]any); ok {
+			group["appliedPolicyPacks"] = upsert(list(group, "appliedPolicyPacks"), v, false, "name")
+		}
+		accounts := list(group, "accounts")
+		if v, ok := update["addInsightsAccount"].(map[string]any); ok && !containsValue(accounts, v["name"]) {
+			accounts = append(accounts, v["name"])
+		}
+		if v, ok := update["removeInsightsAccount"].(map[string]any); ok {
+			kept := accounts[:0]
+			for _, a := range accounts {
+				if a != v["name"] {
+					kept = append(kept, a)
+				}
+			}
+			accounts = kept
+		}
+		group["accounts"] = accounts
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// definition builds a ScheduledAction's definition from a create request.
+type definition func(body map[string]any) (map[string]any, map[string]any)
+
+// deploymentDefinition keeps the deployment request as sent.
+func deploymentDefinition(body map[string]any) (map[string]any, map[string]any) {
+	return schedule(body), map[string]any{"request": body["request"]}
+}
+
+func driftDefinition(body map[string]any) (map[string]any, map[string]any) {
+	remediate, _ := body["autoRemediate"].(bool)
+	return schedule(body), deploymentRequest("detect-drift", "remediateIfDriftDetected", remediate)
+}
+
+func ttlDefinition(body map[string]any) (map[string]any, map[string]any) {
+	deleteAfter, _ := body["deleteAfterDestroy"].(bool)
+	return map[string]any{"scheduleOnce": body["timestamp"]},
+		deploymentRequest("destroy", "deleteAfterDestroy", deleteAfter)
+}
+
+// schedule picks the timing fields of a schedule request.
+func schedule(body map[string]any) map[string]any {
+	out := map[string]any{}
+	for _, k := range []string{"scheduleCron", "scheduleOnce"} {
+		if v, ok := body[k]; ok {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func deploymentRequest(operation, option string, set bool) map[string]any {
+	options := map[string]any{}
+	if set {
+		options[option] = true
+	}
+	return map[string]any{"request": map[string]any{
+		"operation":        operation,
+		"operationContext": map[string]any{"options": options},
+	}}
+}
+
+// scheduledAction assembles the ScheduledAction the service returns.
+func scheduledAction(id, kind string, timing, def map[string]any) map[string]any {
+	doc := map[string]any{
+		"id":         id,
+		"orgID":      "fakecloud-org",
+		"kind":       kind,
+		"paused":     false,
+		"definition": def,
+	}
+	merge(doc, timing)
+	return doc
+}
+
+// stackSchedules is where every kind of stack schedule is stored; the drift
+// and TTL routes only differ in how they build the definition.
+func stackSchedules(req *request) string {
+	return fmt.Sprintf("/api/stacks/%s/%s/%s/deployments/schedules",
+		req.params["orgName"], req.params["projectName"], req.params["stackName"])
+}
+
+func createStackSchedule(build definition) handler {
+	return func(s *Server, w http.ResponseWriter, req *request) {
+		id := s.newID()
+		timing, def := build(req.body)
+		s.put(stackSchedules(req)+"/"+id, scheduledAction(id, "deployment", timing, def))
+		writeJSON(w, http.StatusOK, s.items[stackSchedules(req)+"/"+id].doc)
+	}
+}
+
+func updateStackSchedule(build definition) handler {
+	return func(s *Server, w http.ResponseWriter, req *request) {
+		path := stackSchedules(req) + "/" + req.params["scheduleID"]
+		it, ok := s.items[path]
+		if !ok {
+			writeError(w, http.StatusNotFound, "schedule %s not found", req.params["scheduleID"])
+			return
+		}
+		timing, def := build(req.body)
+		it.doc = scheduledAction(req.params["scheduleID"], "deployment", timing, def)
+		writeJSON(w, http.StatusOK, it.doc)
+	}
+}
+
+// environmentDefinition flattens the rotation request into the definition
+// and records the environment's ID alongside its path.
+func (s *Server) environmentDefinition(req *request) map[string]any {
+	def := map[string]any{}
+	if rotation, ok := req.body["secretRotationRequest"].(map[string]any); ok {
+		merge(def, rotation)
+	}
+	env := fmt.Sprintf("/api/esc/environments/%s/%s/%s",
+		req.params["orgName"], req.params["projectName"], req.params["envName"])
+	if it, ok := s.items[env]; ok {
+		def["environmentID"] = it.doc["id"]
+	}
+	if _, ok := def["environmentPath"]; !ok {
+		def["environmentPath"] = req.params["projectName"] + "/" + req.params["envName"]
+	}
+	return def
+}
+
+func createEnvironmentSchedule(s *Server, w http.ResponseWriter, req *request) {
+	id := s.newID()
+	doc := scheduledAction(id, "environment_rotation", schedule(req.body), s.environmentDefinition(req))
+	s.put(req.path+"/"+id, doc)
+	writeJSON(w, http.StatusOK, doc)
+}
+
+func updateEnvironmentSchedule(s *Server, w http.ResponseWriter, req *request) {
+	it, ok := s.items[req.path]
+	if !ok {
+		writeError(w, http.StatusNotFound, "schedule %s not found", req.params["scheduleID"])
+		return
+	}
+	it.doc = scheduledAction(req.params["scheduleID"], "environment_rotation", schedule(req.body),
+		s.environmentDefinition(req))
+	writeJSON(w, http.StatusOK, it.doc)
+}
+
+func environmentPath(org, project, name string) string {
+	return fmt.Sprintf("/api/esc/environments/%s/%s/%s", org, project, name)
+}
+
+// createEnvironment stores an empty environment at revision 1.
+func createEnvironment(s *Server, w http.ResponseWriter, req *request) {
+	project, _ := req.body["project"].(string)
+	name, _ := req.body["name"].(string)
+	if project == "" || name == "" {
+		writeError(w, http.StatusBadRequest, "project and name are required")
+		return
+	}
+	org := req.params["orgName"]
+	path := environmentPath(org, project, name)
+	if _, exists := s.items[path]; exists {
+		writeError(w, http.StatusConflict, "environment %s/%s already exists", project, name)
+		return
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	it := s.put(path, map[string]any{
+		"id":           s.newID(),
+		"organization": org,
+		"project":      project,
+		"name":         name,
+		"created":      now,
+		"modified":     now,
+	})
+	it.revisions = []string{""}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// environment returns the environment an /api/esc/environments/{org}/
+// {project}/{env}/... request addresses.
+func (s *Server) environment(w http.ResponseWriter, req *request) (*item, bool) {
+	it, ok := s.items[environmentPath(req.params["orgName"], req.params["projectName"], req.params["envName"])]
+	if !ok {
+		writeError(w, http.StatusNotFound, "environment %s/%s not found",
+			req.params["projectName"], req.params["envName"])
+	}
+	return it, ok
+}
+
+// readEnvironment serves an environment's YAML at its latest revision or at
+// the one named by the request's {version}.
+func readEnvironment(s *Server, w http.ResponseWriter, req *request) {
+	it, ok := s.environment(w, req)
+	if !ok {
+		return
+	}
+	revision := len(it.revisions)
+	if v, ok := req.params["version"]; ok && v != "latest" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > len(it.revisions) {
+			writeError(w, http.StatusNotFound, "revision %s not found", v)
+			return
+		}
+		revision = n
+	}
+	w.Header().Set("Content-Type", contentYAML)
+	w.Header().Set("ETag", etag(revision))
+	w.Header().Set("Pulumi-ESC-Revision", strconv.Itoa(revision))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(it.revisions[revision-1]))
+}
+
+// updateEnvironment stores a new revision. Like the service it rejects a
+// stale ETag, so concurrent edits surface as conflicts.
+func updateEnvironment(s *Server, w http.ResponseWriter, req *request) {
+	it, ok := s.environment(w, req)
+	if !ok {
+		return
+	}
+	if tag := req.Header.Get("ETag"); tag != "" && tag != etag(len(it.revisions)) {
+		writeError(w, http.StatusConflict, "environment has been modified since it was read")
+		return
+	}
+	it.revisions = append(it.revisions, string(req.raw))
+	it.doc["modified"] = time.Now().UTC().Format(time.RFC3339)
+	w.Header().Set("Pulumi-ESC-Revision", strconv.Itoa(len(it.revisions)))
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func etag(revision int) string {
+	return fmt.Sprintf("%q", "rev-"+strconv.Itoa(revision))
+}
+
+func listOrgEnvironments(s *Server, w http.ResponseWriter, req *request) {
+	prefix := req.path + "/"
+	var found []*item
+	for p, it := range s.items {
+		if tail, ok := strings.CutPrefix(p, prefix); ok && strings.Count(tail, "/") == 1 && it.revisions != nil {
+			found = append(found, it)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].seq < found[j].seq })
+	envs := make([]map[string]any, 0, len(found))
+	for _, it := range found {
+		envs = append(envs, it.doc)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"environments": envs})
+}
+
+func getEnvironmentMetadata(s *Server, w http.ResponseWriter, req *request) {
+	it, ok := s.environment(w, req)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id":      it.doc["id"],
+		"ownedBy": map[string]any{"githubLogin": FakeUser, "name": FakeUser},
+	})
+}
+
+// list returns doc[field] as a list, treating anything else as empty.
+func list(doc map[string]any, field string) []any {
+	l, _ := doc[field].([]any)
+	return l
+}
+
+// upsert adds entry to l, replacing any element that agrees with it on keys,
+// or only removes that element when add is false.
+func upsert(l []any, entry map[string]any, add bool, keys ...string) []any {
+	out := make([]any, 0, len(l)+1)
+	for _, e := range l {
+		m, _ := e.(map[string]any)
+		same := true
+		for _, k := range keys {
+			if fmt.Sprint(m[k]) != fmt.Sprint(entry[k]) {
+				same = false
+			}
+		}
+		if !same {
+			out = append(out, e)
+		}
+	}
+	if add {
+		out = append(out, entry)
+	}
+	return out
+}
+
+func indexOf(l []any, key string, value any) int {
+	for i, e := range l {
+		if m, _ := e.(map[string]any); m[key] == value {
+			return i
+		}
+	}
+	return -1
+}
+
+func containsValue(l []any, value any) bool {
+	for _, e := range l {
+		if e == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakecloud
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/rest"
+)
+
+// route is one spec operation, split into path segments for matching.
+type route struct {
+	op       *rest.Operation
+	segments []string
+}
+
+// routeTable indexes spec operations by method and by path pattern.
+type routeTable struct {
+	byMethod map[string][]*route
+	// patterns holds every path pattern in the spec, so a POST to a
+	// collection can find the item route beneath it whatever that route's
+	// method.
+	patterns map[string]bool
+}
+
+func newRouteTable(spec *rest.Spec) *routeTable {
+	t := &routeTable{byMethod: map[string][]*route{}, patterns: map[string]bool{}}
+	ids := make([]string, 0)
+	ops := spec.AllOps()
+	for id := range ops {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		op := ops[id]
+		t.byMethod[op.Method] = append(t.byMethod[op.Method], &route{op: op, segments: split(op.Path)})
+		t.patterns[op.Path] = true
+	}
+	return t
+}
+
+// alias also serves the operation with the given ID at method, for clients
+// that still drive a route with a method the spec no longer lists.
+func (t *routeTable) alias(method, id string) {
+	for _, routes := range t.byMethod {
+		for _, r := range routes {
+			if r.op.ID == id {
+				t.byMethod[method] = append(t.byMethod[method], &route{op: r.op, segments: r.segments})
+				return
+			}
+		}
+	}
+}
+
+// match returns the route for method and path and its path parameters. When
+// several patterns match, the one with a literal segment earliest wins, so
+// /teams/pulumi beats /teams/{teamName} and /roles/scopes beats
+// /roles/{roleID}.
+func (t *routeTable) match(method, path string) (*route, map[string]string) {
+	segments := split(path)
+	var best *route
+	for _, r := range t.byMethod[method] {
+		if len(r.segments) != len(segments) || !r.matches(segments) {
+			continue
+		}
+		if best == nil || r.moreSpecificThan(best) {
+			best = r
+		}
+	}
+	if best == nil {
+		return nil, nil
+	}
+	params := map[string]string{}
+	for i, s := range best.segments {
+		if name, ok := param(s); ok {
+			params[name] = segments[i]
+		}
+	}
+	return best, params
+}
+
+// itemParam reports the parameter naming the items of the collection at
+// pattern, if the spec has a pattern+"/{param}" route.
+func (t *routeTable) itemParam(pattern string) (string, bool) {
+	prefix := pattern + "/{"
+	for p := range t.patterns {
+		if strings.HasPrefix(p, prefix) && strings.Count(p[len(prefix):], "/") == 0 {
+			return strings.TrimSuffix(p[len(prefix):], "}"), true
+		}
+	}
+	return "", false
+}
+
+func (r *route) matches(segments []string) bool {
+	for i, s := range r.segments {
+		if _, ok := param(s); !ok && s != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *route) moreSpecificThan(other *route) bool {
+	for i := range r.segments {
+		_, mine := param(r.segments[i])
+		_, theirs := param(other.segments[i])
+		if mine != theirs {
+			return theirs
+		}
+	}
+	return false
+}
+
+// lastParam reports the route's final segment when it is a parameter.
+func (r *route) lastParam() (string, bool) {
+	return param(r.segments[len(r.segments)-1])
+}
+
+func param(segment string) (string, bool) {
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
+
+func split(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// parent drops the last segment of a concrete path or pattern.
+func parent(path string) string {
+	if i := strings.LastIndex(path, "/"); i > 0 {
+		return path[:i]
+	}
+	return path
+}
@@ -0,0 +1,433 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fakecloud serves a stateful, in-memory fake of the Pulumi Cloud
+// REST API, so provider tests can run full resource lifecycles offline.
+//
+// Routes come from the operations in a rest.Spec, so the fake grows with
+// cloud/spec.json. Every operation gets a generic collection/item behaviour
+// inferred from its method and path: a POST to a collection creates an item
+// under it, GET reads an item or lists a collection, PATCH merges, PUT
+// replaces and DELETE removes an item along with everything beneath it.
+// Operations whose wire shape the generic behaviour cannot produce (teams,
+// ESC environments, schedules, policy groups) have overrides keyed by
+// operationId in handlers.go.
+package fakecloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/cloud"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/rest"
+)
+
+const (
+	contentJSON = "application/json"
+	contentYAML = "application/x-yaml"
+)
+
+// Server is an http.Handler faking the Pulumi Cloud REST API. It accepts any
+// credentials. The zero value is not usable; construct one with New.
+type Server struct {
+	spec   *rest.Spec
+	routes *routeTable
+
+	mu    sync.Mutex
+	seq   int
+	items map[string]*item
+}
+
+// item is one stored document, keyed by the path it is read back from.
+type item struct {
+	seq int
+	doc map[string]any
+	// yaml and revisions back ESC environments, whose definitions are YAML
+	// documents rather than JSON.
+	yaml      string
+	revisions []string
+}
+
+// request is an incoming request resolved against the route table.
+type request struct {
+	*http.Request
+	route  *route
+	params map[string]string
+	path   string
+	raw    []byte
+	// body is the decoded JSON request body when it is an object; value
+	// holds it whatever its shape.
+	body  map[string]any
+	value any
+}
+
+// New returns a fake serving the operations in spec.
+func New(spec *rest.Spec) *Server {
+	routes := newRouteTable(spec)
+	for id, method := range aliases {
+		routes.alias(method, id)
+	}
+	return &Server{
+		spec:   spec,
+		routes: routes,
+		items:  map[string]*item{},
+	}
+}
+
+// Start serves a fake of the embedded cloud spec for the duration of t and
+// returns it along with its base URL, suitable for the provider's apiUrl.
+func Start(t testing.TB) (*Server, string) {
+	t.Helper()
+	s := New(cloud.Spec())
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+	return s, ts.URL
+}
+
+// Put stores doc at path, replacing anything already there. Tests use it to
+// seed state that no provider resource creates, such as stacks.
+func (s *Server) Put(path string, doc map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.put(path, clone(doc))
+}
+
+// Get returns a copy of the document stored at path.
+func (s *Server) Get(path string) (map[string]any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	it, ok := s.items[path]
+	if !ok {
+		return nil, false
+	}
+	return clone(it.doc), true
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rt, params := s.routes.match(r.Method, r.URL.Path)
+	if rt == nil {
+		writeError(w, http.StatusNotFound, "fakecloud: no route for %s %s", r.Method, r.URL.Path)
+		return
+	}
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "reading body: %v", err)
+		return
+	}
+	req := &request{Request: r, route: rt, params: params, path: strings.TrimSuffix(r.URL.Path, "/"), raw: raw}
+	if len(raw) > 0 && rt.op.RequestContentType != contentYAML {
+		if err := json.Unmarshal(raw, &req.value); err != nil {
+			if rt.op.RequestContentType == contentJSON {
+				writeError(w, http.StatusBadRequest, "invalid JSON body: %v", err)
+				return
+			}
+			req.value = nil
+		}
+		req.body, _ = req.value.(map[string]any)
+	}
+	if req.body == nil {
+		req.body = map[string]any{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if h, ok := overrides[rt.op.ID]; ok {
+		h(s, w, req)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		s.get(w, req)
+	case http.MethodHead:
+		if _, ok := s.items[req.path]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodPost:
+		s.post(w, req)
+	case http.MethodPatch, http.MethodPut:
+		s.update(w, req)
+	case http.MethodDelete:
+		s.delete(w, req)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "fakecloud: %s is not supported", r.Method)
+	}
+}
+
+// get reads the item at the request path or, when the path is a collection,
+// lists its items in creation order.
+func (s *Server) get(w http.ResponseWriter, req *request) {
+	if it, ok := s.items[req.path]; ok {
+		writeJSON(w, http.StatusOK, it.doc)
+		return
+	}
+	if _, ok := s.routes.itemParam(req.route.op.Path); ok {
+		s.writeList(w, req.route.op, s.children(req.path))
+		return
+	}
+	writeError(w, http.StatusNotFound, "%s not found", req.path)
+}
+
+// post creates an item when the path is a collection, and otherwise updates
+// the item at the path or performs an action with no stored effect.
+func (s *Server) post(w http.ResponseWriter, req *request) {
+	op := req.route.op
+	if coll, param, ok := s.collection(req); ok {
+		s.create(w, req, coll, param, req.body)
+		return
+	}
+	if it, ok := s.items[req.path]; ok {
+		merge(it.doc, req.body)
+		s.respond(w, op, it.doc)
+		return
+	}
+	if strings.HasPrefix(op.ID, "Update") {
+		writeError(w, http.StatusNotFound, "%s not found", req.path)
+		return
+	}
+	if op.RequestRef != "" {
+		s.respond(w, op, s.put(req.path, clone(req.body)).doc)
+		return
+	}
+	s.respond(w, op, map[string]any{})
+}
+
+// collection reports where a POST creates its item, and the path parameter
+// naming items there. A path with an item route beneath it is a collection;
+// so is the parent of a Create* or New* operation's literal path, which
+// covers routes such as /teams/pulumi that create into /teams.
+func (s *Server) collection(req *request) (string, string, bool) {
+	pattern := req.route.op.Path
+	if param, ok := s.routes.itemParam(pattern); ok {
+		return req.path, param, true
+	}
+	if _, last := req.route.lastParam(); last {
+		return "", "", false
+	}
+	if !strings.HasPrefix(req.route.op.ID, "Create") && !strings.HasPrefix(req.route.op.ID, "New") {
+		return "", "", false
+	}
+	if param, ok := s.routes.itemParam(parent(pattern)); ok {
+		return parent(req.path), param, true
+	}
+	return "", "", false
+}
+
+// create stores doc under coll. Items named by an ID parameter get a
+// generated id; others are keyed by the parameter's body field, or by name.
+func (s *Server) create(w http.ResponseWriter, req *request, coll, param string, doc map[string]any) *item {
+	doc = clone(doc)
+	var key string
+	if strings.HasSuffix(strings.ToLower(param), "id") {
+		key = s.newID()
+		doc["id"] = key
+	} else if v, _ := doc[param].(string); v != "" {
+		key = v
+	} else if v, _ := doc["name"].(string); v != "" {
+		key = v
+	} else {
+		key = fmt.Sprintf("%s-%d", strings.TrimSuffix(param, "Name"), s.seq+1)
+		doc["name"] = key
+	}
+	path := coll + "/" + key
+	if _, exists := s.items[path]; exists {
+		writeError(w, http.StatusConflict, "%s already exists", path)
+		return nil
+	}
+	it := s.put(path, doc)
+
+	resp := it.doc
+	if s.responseHas(req.route.op, "tokenValue") {
+		// Like the service, hand the secret out once and never store it.
+		resp = clone(it.doc)
+		resp["tokenValue"] = fmt.Sprintf("pul-%040x", it.seq)
+	}
+	s.respond(w, req.route.op, resp)
+	return it
+}
+
+// update merges (PATCH) or replaces (PUT) the item at the request path. A
+// missing item is an error when the path names one, and is created when the
+// path is a singleton such as .../deployments/settings.
+func (s *Server) update(w http.ResponseWriter, req *request) {
+	op := req.route.op
+	it, ok := s.items[req.path]
+	switch {
+	case ok && req.Method == http.MethodPut:
+		it.doc = clone(req.body)
+	case ok:
+		merge(it.doc, req.body)
+	case req.Method == http.MethodPatch && isParam(req.route):
+		writeError(w, http.StatusNotFound, "%s not found", req.path)
+		return
+	default:
+		it = s.put(req.path, clone(req.body))
+	}
+	s.respond(w, op, it.doc)
+}
+
+func (s *Server) delete(w http.ResponseWriter, req *request) {
+	if _, ok := s.items[req.path]; !ok && isParam(req.route) {
+		writeError(w, http.StatusNotFound, "%s not found", req.path)
+		return
+	}
+	s.remove(req.path)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func isParam(r *route) bool {
+	_, ok := r.lastParam()
+	return ok
+}
+
+func (s *Server) put(path string, doc map[string]any) *item {
+	s.seq++
+	it := &item{seq: s.seq, doc: doc}
+	if old, ok := s.items[path]; ok {
+		it.seq = old.seq
+	}
+	s.items[path] = it
+	return it
+}
+
+// remove deletes the item at path and everything stored beneath it.
+func (s *Server) remove(path string) {
+	delete(s.items, path)
+	for p := range s.items {
+		if strings.HasPrefix(p, path+"/") {
+			delete(s.items, p)
+		}
+	}
+}
+
+// children returns the documents stored directly beneath path, oldest first.
+func (s *Server) children(path string) []map[string]any {
+	var found []*item
+	for p, it := range s.items {
+		if tail, ok := strings.CutPrefix(p, path+"/"); ok && !strings.Contains(tail, "/") {
+			found = append(found, it)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].seq < found[j].seq })
+	docs := make([]map[string]any, 0, len(found))
+	for _, it := range found {
+		docs = append(docs, it.doc)
+	}
+	return docs
+}
+
+func (s *Server) newID() string {
+	s.seq++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.seq)
+}
+
+// writeList writes docs in the shape of op's response: wrapped in the
+// schema's array property (e.g. {"teams": [...]}) or as a bare array.
+func (s *Server) writeList(w http.ResponseWriter, op *rest.Operation, docs []map[string]any) {
+	schema, ok := s.spec.ResolveSchema(op.ResponseRef)
+	if !ok {
+		writeJSON(w, http.StatusOK, docs)
+		return
+	}
+	props, _ := schema["properties"].(map[string]any)
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if p, _ := props[name].(map[string]any); p["type"] == "array" {
+			writeJSON(w, http.StatusOK, map[string]any{name: docs})
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, docs)
+}
+
+// respond writes doc as op's response, or 204 for operations with no body.
+func (s *Server) respond(w http.ResponseWriter, op *rest.Operation, doc any) {
+	if !hasResponseBody(op) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, doc)
+}
+
+func (s *Server) responseHas(op *rest.Operation, property string) bool {
+	schema, ok := s.spec.ResolveSchema(op.ResponseRef)
+	if !ok {
+		return false
+	}
+	props, _ := schema["properties"].(map[string]any)
+	_, ok = props[property]
+	return ok
+}
+
+func hasResponseBody(op *rest.Operation) bool {
+	if op.ResponseContentType != "" {
+		return true
+	}
+	resps, _ := op.Raw["responses"].(map[string]any)
+	for _, code := range []string{"200", "201"} {
+		if r, ok := resps[code].(map[string]any); ok {
+			if _, ok := r["content"]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", contentJSON)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes the service's error envelope.
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	writeJSON(w, status, map[string]any{"code": status, "message": fmt.Sprintf(format, args...)})
+}
+
+// merge copies src's fields over dst's.
+func merge(dst, src map[string]any) {
+	for k, v := range src {
+		dst[k] = v
+	}
+}
+
+// clone deep-copies a JSON document so stored state never aliases a request
+// or a response.
+func clone(doc map[string]any) map[string]any {
+	if doc == nil {
+		return map[string]any{}
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		panic(err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(data, &out); err != nil {
+		panic(err)
+	}
+	return out
+}
@@ -0,0 +1,260 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakecloud
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const org = "/api/orgs/fake-org"
+
+type client struct {
+	t    *testing.T
+	base string
+}
+
+func start(t *testing.T) (*Server, *client) {
+	s, url := Start(t)
+	return s, &client{t: t, base: url}
+}
+
+// do sends body (JSON-encoded unless it is a string) and returns the status,
+// headers and raw response body.
+func (c *client) do(method, path string, body any, header ...string) (int, http.Header, string) {
+	c.t.Helper()
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case string:
+		reader = strings.NewReader(b)
+	default:
+		data, err := json.Marshal(b)
+		require.NoError(c.t, err)
+		reader = strings.NewReader(string(data))
+	}
+	req, err := http.NewRequestWithContext(c.t.Context(), method, c.base+path, reader)
+	require.NoError(c.t, err)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(c.t, err)
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(c.t, err)
+	return resp.StatusCode, resp.Header, string(data)
+}
+
+func (c *client) json(method, path string, body any) (int, map[string]any) {
+	c.t.Helper()
+	status, _, data := c.do(method, path, body)
+	var out map[string]any
+	if data != "" {
+		require.NoError(c.t, json.Unmarshal([]byte(data), &out), data)
+	}
+	return status, out
+}
+
+func TestUnknownRoute(t *testing.T) {
+	_, c := start(t)
+	status, body := c.json(http.MethodGet, "/api/not/a/route", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Contains(t, body["message"], "no route for GET /api/not/a/route")
+}
+
+func TestRouteSpecificity(t *testing.T) {
+	s, _ := start(t)
+	r, _ := s.routes.match(http.MethodPost, org+"/teams/pulumi")
+	require.NotNil(t, r)
+	assert.Equal(t, "CreatePulumiTeam", r.op.ID)
+
+	r, params := s.routes.match(http.MethodGet, org+"/teams/pulumi")
+	require.NotNil(t, r)
+	assert.Equal(t, "GetTeam", r.op.ID)
+	assert.Equal(t, map[string]string{"orgName": "fake-org", "teamName": "pulumi"}, params)
+}
+
+func TestCollectionLifecycle(t *testing.T) {
+	_, c := start(t)
+
+	status, created := c.json(http.MethodPost, org+"/agent-pools", map[string]any{
+		"name": "runners", "description": "first",
+	})
+	require.Equal(t, http.StatusOK, status)
+	id, _ := created["id"].(string)
+	require.NotEmpty(t, id)
+	assert.True(t, strings.HasPrefix(created["tokenValue"].(string), "pul-"))
+
+	status, got := c.json(http.MethodGet, org+"/agent-pools/"+id, nil)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "runners", got["name"])
+	assert.NotContains(t, got, "tokenValue", "secrets are handed out once and never stored")
+
+	status, _ = c.json(http.MethodPatch, org+"/agent-pools/"+id, map[string]any{"description": "second"})
+	require.Less(t, status, 300)
+	_, got = c.json(http.MethodGet, org+"/agent-pools/"+id, nil)
+	assert.Equal(t, "second", got["description"])
+	assert.Equal(t, "runners", got["name"], "PATCH merges")
+
+	status, _ = c.json(http.MethodDelete, org+"/agent-pools/"+id, nil)
+	assert.Equal(t, http.StatusNoContent, status)
+	status, _ = c.json(http.MethodGet, org+"/agent-pools/"+id, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = c.json(http.MethodDelete, org+"/agent-pools/"+id, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestListAndConflict(t *testing.T) {
+	_, c := start(t)
+	for _, name := range []string{"b-hook", "a-hook"} {
+		status, _ := c.json(http.MethodPost, org+"/hooks", map[string]any{
+			"name": name, "displayName": name, "payloadUrl": "https://example.com",
+		})
+		require.Equal(t, http.StatusOK, status)
+	}
+	status, _ := c.json(http.MethodPost, org+"/hooks", map[string]any{"name": "a-hook"})
+	assert.Equal(t, http.StatusConflict, status)
+
+	status, _, data := c.do(http.MethodGet, org+"/hooks", nil)
+	require.Equal(t, http.StatusOK, status)
+	var hooks []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(data), &hooks))
+	require.Len(t, hooks, 2)
+	assert.Equal(t, "b-hook", hooks[0]["name"], "collections list in creation order")
+
+	// Tokens list under the response schema's array property.
+	c.json(http.MethodPost, org+"/tokens", map[string]any{"name": "ci", "description": "ci"})
+	status, tokens := c.json(http.MethodGet, org+"/tokens", nil)
+	require.Equal(t, http.StatusOK, status)
+	assert.Len(t, tokens["tokens"], 1)
+}
+
+func TestTeams(t *testing.T) {
+	_, c := start(t)
+	status, team := c.json(http.MethodPost, org+"/teams/pulumi", map[string]any{
+		"name": "platform", "displayName": "Platform",
+	})
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "pulumi", team["kind"])
+
+	for _, update := range []map[string]any{
+		{"newDescription": "builds things"},
+		{"memberAction": "add", "member": "alice"},
+		{"addStackPermission": map[string]any{"projectName": "p", "stackName": "s", "permission": 101}},
+		{"editStackPermission": map[string]any{"projectName": "p", "stackName": "s", "permission": 102}},
+	} {
+		status, _ := c.json(http.MethodPatch, org+"/teams/platform", update)
+		require.Equal(t, http.StatusNoContent, status, update)
+	}
+	status, _ = c.json(http.MethodPatch, org+"/teams/platform", map[string]any{"memberAction": "add", "member": "alice"})
+	assert.Equal(t, http.StatusConflict, status)
+
+	_, team = c.json(http.MethodGet, org+"/teams/platform", nil)
+	assert.Equal(t, "builds things", team["description"])
+	assert.Equal(t, "Platform", team["displayName"])
+	assert.Equal(t, []any{map[string]any{"name": "alice", "githubLogin": "alice", "role": "member"}}, team["members"])
+	assert.Equal(t, []any{map[string]any{"projectName": "p", "stackName": "s", "permission": float64(102)}},
+		team["stacks"])
+}
+
+func TestStackTags(t *testing.T) {
+	s, c := start(t)
+	status, _ := c.json(http.MethodPost, "/api/stacks/fake-org/proj", map[string]any{"stackName": "dev"})
+	require.Equal(t, http.StatusOK, status)
+	_, ok := s.Get("/api/stacks/fake-org/proj/dev")
+	require.True(t, ok)
+
+	status, _ = c.json(http.MethodPost, "/api/stacks/fake-org/proj/dev/tags", map[string]any{
+		"name": "owner", "value": "platform",
+	})
+	require.Equal(t, http.StatusNoContent, status)
+
+	_, stack := c.json(http.MethodGet, "/api/stacks/fake-org/proj/dev", nil)
+	assert.Equal(t, map[string]any{"owner": "platform"}, stack["tags"])
+	assert.Equal(t, "proj", stack["projectName"])
+
+	status, _ = c.json(http.MethodDelete, "/api/stacks/fake-org/proj/dev", nil)
+	require.Equal(t, http.StatusNoContent, status)
+	_, ok = s.Get("/api/stacks/fake-org/proj/dev/tags/owner")
+	assert.False(t, ok, "deleting an item deletes everything beneath it")
+}
+
+func TestEnvironmentRevisions(t *testing.T) {
+	_, c := start(t)
+	const env = "/api/esc/environments/fake-org/proj/env"
+	status, _ := c.json(http.MethodPost, "/api/esc/environments/fake-org", map[string]any{
+		"project": "proj", "name": "env",
+	})
+	require.Equal(t, http.StatusNoContent, status)
+
+	status, header, _ := c.do(http.MethodGet, env, nil)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "1", header.Get("Pulumi-ESC-Revision"))
+	tag := header.Get("ETag")
+
+	status, header, _ = c.do(http.MethodPatch, env, "values:\n  a: 1\n", "ETag", tag)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "2", header.Get("Pulumi-ESC-Revision"))
+
+	status, _, _ = c.do(http.MethodPatch, env, "values:\n  a: 2\n", "ETag", tag)
+	assert.Equal(t, http.StatusConflict, status, "a stale ETag is rejected")
+
+	status, header, yaml := c.do(http.MethodGet, env, nil)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "application/x-yaml", header.Get("Content-Type"))
+	assert.Equal(t, "values:\n  a: 1\n", yaml)
+
+	_, _, yaml = c.do(http.MethodGet, env+"/versions/1", nil)
+	assert.Empty(t, yaml)
+
+	status, list := c.json(http.MethodGet, "/api/esc/environments/fake-org", nil)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, list["environments"], 1)
+
+	status, _, _ = c.do(http.MethodDelete, env, nil)
+	require.Equal(t, http.StatusNoContent, status)
+	status, _, _ = c.do(http.MethodHead, env, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestSchedules(t *testing.T) {
+	_, c := start(t)
+	const schedules = "/api/stacks/fake-org/proj/dev/deployments"
+	status, created := c.json(http.MethodPost, schedules+"/drift/schedules", map[string]any{
+		"scheduleCron": "0 * * * *", "autoRemediate": true,
+	})
+	require.Equal(t, http.StatusOK, status)
+	id := created["id"].(string)
+
+	status, got := c.json(http.MethodGet, schedules+"/schedules/"+id, nil)
+	require.Equal(t, http.StatusOK, status, "drift schedules read back through the shared collection")
+	assert.Equal(t, "0 * * * *", got["scheduleCron"])
+	assert.Equal(t, map[string]any{"request": map[string]any{
+		"operation":        "detect-drift",
+		"operationContext": map[string]any{"options": map[string]any{"remediateIfDriftDetected": true}},
+	}}, got["definition"])
+
+	status, _ = c.json(http.MethodPost, schedules+"/drift/schedules/"+id, map[string]any{"scheduleCron": "5 * * * *"})
+	require.Equal(t, http.StatusOK, status)
+	_, got = c.json(http.MethodGet, schedules+"/schedules/"+id, nil)
+	assert.Equal(t, "5 * * * *", got["scheduleCron"])
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/pulumi/pulumi-go-provider/infer"
 
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/resources"
 )
 
 // GetEnvironmentFunction looks up an existing ESC environment by
@@ -112,3 +114,70 @@ func (GetEnvironmentFunction) Invoke(
 		},
 	}, nil
 }
+
+// GetEnvironmentRotationHistoryFunction lists the secret rotations of an ESC
+// environment, whether run on demand, by an `EnvironmentRotation`, or by an
+// `EnvironmentRotationSchedule`.
+type GetEnvironmentRotationHistoryFunction struct{}
+
+type GetEnvironmentRotationHistoryInput struct {
+	OrganizationName string `pulumi:"organizationName"`
+	ProjectName      string `pulumi:"projectName,optional"`
+	Name             string `pulumi:"name"`
+}
+
+type GetEnvironmentRotationHistoryOutput struct {
+	Rotations []resources.EnvironmentRotationEvent `pulumi:"rotations"`
+}
+
+func (GetEnvironmentRotationHistoryFunction) Annotate(a infer.Annotator) {
+	a.Describe(&GetEnvironmentRotationHistoryFunction{}, "Lists the secret rotations of an ESC environment "+
+		"with the outcome of each rotated secret. Use it to audit when secrets last rotated and which "+
+		"rotations failed.")
+	a.SetToken("index", "getEnvironmentRotationHistory")
+}
+
+func (i *GetEnvironmentRotationHistoryInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.OrganizationName, "The Pulumi Cloud organization that owns the environment.")
+	a.Describe(&i.ProjectName, "The ESC project name. Defaults to `default`.")
+	a.Describe(&i.Name, "The environment name.")
+}
+
+func (o *GetEnvironmentRotationHistoryOutput) Annotate(a infer.Annotator) {
+	a.Describe(&o.Rotations, "The environment's rotations, as ordered by Pulumi Cloud.")
+}
+
+func (GetEnvironmentRotationHistoryFunction) Invoke(
+	ctx context.Context,
+	req infer.FunctionRequest[GetEnvironmentRotationHistoryInput],
+) (infer.FunctionResponse[GetEnvironmentRotationHistoryOutput], error) {
+	in := req.Input
+	if in.OrganizationName == "" {
+		return infer.FunctionResponse[GetEnvironmentRotationHistoryOutput]{},
+			fmt.Errorf("`organizationName` must not be empty")
+	}
+	if in.Name == "" {
+		return infer.FunctionResponse[GetEnvironmentRotationHistoryOutput]{}, fmt.Errorf("`name` must not be empty")
+	}
+	project := in.ProjectName
+	if project == "" {
+		project = "default"
+	}
+
+	events, err := config.GetClient(ctx).ListEnvironmentRotationHistory(ctx, pulumiapi.EnvironmentIdentifier{
+		OrgName:     in.OrganizationName,
+		ProjectName: project,
+		EnvName:     in.Name,
+	})
+	if err != nil {
+		return infer.FunctionResponse[GetEnvironmentRotationHistoryOutput]{}, err
+	}
+
+	rotations := make([]resources.EnvironmentRotationEvent, len(events))
+	for i, event := range events {
+		rotations[i] = resources.EnvironmentRotationEventFromAPI(event)
+	}
+	return infer.FunctionResponse[GetEnvironmentRotationHistoryOutput]{
+		Output: GetEnvironmentRotationHistoryOutput{Rotations: rotations},
+	}, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/resources"
+)
+
+type rotationHistoryClientMock struct {
+	config.Client
+	environment pulumiapi.EnvironmentIdentifier
+	events      []pulumiapi.SecretRotationEvent
+}
+
+func (c *rotationHistoryClientMock) ListEnvironmentRotationHistory(
+	_ context.Context, environment pulumiapi.EnvironmentIdentifier,
+) ([]pulumiapi.SecretRotationEvent, error) {
+	c.environment = environment
+	return c.events, nil
+}
+
+func TestGetEnvironmentRotationHistory(t *testing.T) {
+	revision := 8
+	client := &rotationHistoryClientMock{events: []pulumiapi.SecretRotationEvent{{
+		ID:                   "rot-1",
+		Status:               "failed",
+		Created:              "2026-01-01T00:00:00Z",
+		PreRotationRevision:  7,
+		PostRotationRevision: &revision,
+		Rotations: []pulumiapi.SecretRotation{
+			{EnvironmentPath: "db.password", Status: "succeeded"},
+			{EnvironmentPath: "api.key", Status: "failed", ErrorMessage: "access denied"},
+		},
+	}}}
+	ctx := config.WithMockClient(context.Background(), client)
+
+	resp, err := GetEnvironmentRotationHistoryFunction{}.Invoke(ctx,
+		infer.FunctionRequest[GetEnvironmentRotationHistoryInput]{
+			Input: GetEnvironmentRotationHistoryInput{OrganizationName: "my-org", Name: "prod"},
+		})
+	require.NoError(t, err)
+	assert.Equal(t, pulumiapi.EnvironmentIdentifier{OrgName: "my-org", ProjectName: "default", EnvName: "prod"},
+		client.environment)
+	denied := "access denied"
+	assert.Equal(t, []resources.EnvironmentRotationEvent{{
+		RotationID:           "rot-1",
+		Status:               "failed",
+		Created:              "2026-01-01T00:00:00Z",
+		PreRotationRevision:  7,
+		PostRotationRevision: &revision,
+		Secrets: []resources.EnvironmentSecretRotation{
+			{Path: "db.password", Status: "succeeded"},
+			{Path: "api.key", Status: "failed", ErrorMessage: &denied},
+		},
+	}}, resp.Output.Rotations)
+}
@@ -0,0 +1,618 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+// The list data sources below each return every matching object in an
+// organization. Paginated endpoints are drained by the client before the
+// result is returned, so callers never see a partial list.
+
+const organizationNameDescription = "The name of the Pulumi organization."
+
+// GetTeamsFunction lists the teams in an organization.
+type GetTeamsFunction struct{}
+
+type GetTeamsInput struct {
+	OrganizationName string `pulumi:"organizationName"`
+}
+
+type TeamInfo struct {
+	Name        string `pulumi:"name"`
+	DisplayName string `pulumi:"displayName"`
+	Description string `pulumi:"description"`
+	TeamType    string `pulumi:"teamType"`
+}
+
+type GetTeamsOutput struct {
+	Teams []TeamInfo `pulumi:"teams"`
+}
+
+func (GetTeamsFunction) Annotate(a infer.Annotator) {
+	a.Describe(&GetTeamsFunction{}, "Lists all teams in a Pulumi Cloud organization.")
+	a.SetToken("index", "getTeams")
+}
+
+func (i *GetTeamsInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.OrganizationName, organizationNameDescription)
+}
+
+func (t *TeamInfo) Annotate(a infer.Annotator) {
+	a.Describe(&t.Name, "The team's name.")
+	a.Describe(&t.DisplayName, "The team's display name.")
+	a.Describe(&t.Description, "The team's description.")
+	a.Describe(&t.TeamType, "The kind of team: `pulumi` or `github`.")
+}
+
+func (GetTeamsFunction) Invoke(
+	ctx context.Context,
+	req infer.FunctionRequest[GetTeamsInput],
+) (infer.FunctionResponse[GetTeamsOutput], error) {
+	teams, err := config.GetClient(ctx).ListTeams(ctx, req.Input.OrganizationName)
+	if err != nil {
+		return infer.FunctionResponse[GetTeamsOutput]{}, fmt.Errorf("failed to list teams: %w", err)
+	}
+	out := make([]TeamInfo, 0, len(teams))
+	for _, t := range teams {
+		out = append(out, TeamInfo{
+			Name:        t.Name,
+			DisplayName: t.DisplayName,
+			Description: t.Description,
+			TeamType:    t.Type,
+		})
+	}
+	return infer.FunctionResponse[GetTeamsOutput]{Output: GetTeamsOutput{Teams: out}}, nil
+}
+
+// GetStacksFunction lists the stacks in an organization, optionally narrowed
+// by project or tag on the server side.
+type GetStacksFunction struct{}
+
+type GetStacksInput struct {
+	OrganizationName string  `pulumi:"organizationName"`
+	ProjectName      *string `pulumi:"projectName,optional"`
+	TagName          *string `pulumi:"tagName,optional"`
+	TagValue         *string `pulumi:"tagValue,optional"`
+}
+
+type StackInfo struct {
+	OrganizationName string `pulumi:"organizationName"`
+	ProjectName      string `pulumi:"projectName"`
+	StackName        string `pulumi:"stackName"`
+	LastUpdate       *int   `pulumi:"lastUpdate,optional"`
+	ResourceCount    *int   `pulumi:"resourceCount,optional"`
+}
+
+type GetStacksOutput struct {
+	Stacks []StackInfo `pulumi:"stacks"`
+}
+
+func (GetStacksFunction) Annotate(a infer.Annotator) {
+	a.Describe(
+		&GetStacksFunction{},
+		"Lists the stacks in a Pulumi Cloud organization. Filters are applied by Pulumi Cloud, "+
+			"and every page of results is returned.",
+	)
+	a.SetToken("index", "getStacks")
+}
+
+func (i *GetStacksInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.OrganizationName, organizationNameDescription)
+	a.Describe(&i.ProjectName, "Only return stacks in this project.")
+	a.Describe(&i.TagName, "Only return stacks carrying this tag.")
+	a.Describe(&i.TagValue, "Only return stacks whose `tagName` tag has this value. Requires `tagName`.")
+}
+
+func (s *StackInfo) Annotate(a infer.Annotator) {
+	a.Describe(&s.OrganizationName, "The organization that owns the stack.")
+	a.Describe(&s.ProjectName, "The stack's project.")
+	a.Describe(&s.StackName, "The stack's name.")
+	a.Describe(&s.LastUpdate, "Unix timestamp (seconds) of the stack's last update, if any.")
+	a.Describe(&s.ResourceCount, "Number of resources in the stack, if known.")
+}
+
+func (GetStacksFunction) Invoke(
+	ctx context.Context,
+	req infer.FunctionRequest[GetStacksInput],
+) (infer.FunctionResponse[GetStacksOutput], error) {
+	opts := pulumiapi.ListStacksOptions{
+		Organization: req.Input.OrganizationName,
+		Project:      deref(req.Input.ProjectName),
+		TagName:      deref(req.Input.TagName),
+		TagValue:     deref(req.Input.TagValue),
+	}
+	stacks, err := config.GetClient(ctx).ListStacks(ctx, opts)
+	if err != nil {
+		return infer.FunctionResponse[GetStacksOutput]{}, err
+	}
+	out := make([]StackInfo, 0, len(stacks))
+	for _, s := range stacks {
+		info := StackInfo{
+			OrganizationName: s.OrgName,
+			ProjectName:      s.ProjectName,
+			StackName:        s.StackName,
+			ResourceCount:    s.ResourceCount,
+		}
+		if s.LastUpdate != nil {
+			lastUpdate := int(*s.LastUpdate)
+			info.LastUpdate = &lastUpdate
+		}
+		out = append(out, info)
+	}
+	return infer.FunctionResponse[GetStacksOutput]{Output: GetStacksOutput{Stacks: out}}, nil
+}
+
+// GetEnvironmentsFunction lists the ESC environments in an organization.
+type GetEnvironmentsFunction struct{}
+
+type GetEnvironmentsInput struct {
+	OrganizationName string `pulumi:"organizationName"`
+}
+
+type EnvironmentInfo struct {
+	ProjectName   string `pulumi:"projectName"`
+	Name          string `pulumi:"name"`
+	EnvironmentID string `pulumi:"environmentId"`
+	Created       string `pulumi:"created"`
+	Modified      string `pulumi:"modified"`
+}
+
+type GetEnvironmentsOutput struct {
+	Environments []EnvironmentInfo `pulumi:"environments"`
+}
+
+func (GetEnvironmentsFunction) Annotate(a infer.Annotator) {
+	a.Describe(
+		&GetEnvironmentsFunction{},
+		"Lists the ESC environments in a Pulumi Cloud organization that the caller can see. "+
+			"Every page of results is returned.",
+	)
+	a.SetToken("index", "getEnvironments")
+}
+
+func (i *GetEnvironmentsInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.OrganizationName, organizationNameDescription)
+}
+
+func (e *EnvironmentInfo) Annotate(a infer.Annotator) {
+	a.Describe(&e.ProjectName, "The ESC project the environment lives in.")
+	a.Describe(&e.Name, "The environment name.")
+	a.Describe(&e.EnvironmentID, "The environment's UUID.")
+	a.Describe(&e.Created, "When the environment was created.")
+	a.Describe(&e.Modified, "When the environment was last modified.")
+}
+
+func (GetEnvironmentsFunction) Invoke(
+	ctx context.Context,
+	req infer.FunctionRequest[GetEnvironmentsInput],
+) (infer.FunctionResponse[GetEnvironmentsOutput], error) {
+	envs, err := config.GetClient(ctx).ListOrgEnvironments(ctx, req.Input.OrganizationName)
+	if err != nil {
+		return infer.FunctionResponse[GetEnvironmentsOutput]{}, err
+	}
+	out := make([]EnvironmentInfo, 0, len(envs))
+	for _, e := range envs {
+		out = append(out, EnvironmentInfo{
+			ProjectName:   e.Project,
+			Name:          e.Name,
+			EnvironmentID: e.ID,
+			Created:       e.Created,
+			Modified:      e.Modified,
+		})
+	}
+	return infer.FunctionResponse[GetEnvironmentsOutput]{Output: GetEnvironmentsOutput{Environments: out}}, nil
+}
+
+// GetOrgTokensFunction lists an organization's access tokens. Token values
+// are only returned at creation, so they are never part of the result.
+type GetOrgTokensFunction struct{}
+
+type GetOrgTokensInput struct {
+	OrganizationName string  `pulumi:"organizationName"`
+	Filter           *string `pulumi:"filter,optional"`
+}
+
+type OrgTokenInfo struct {
+	TokenID     string `pulumi:"tokenId"`
+	Name        string `pulumi:"name"`
+	Description string `pulumi:"description"`
+	Admin       bool   `pulumi:"admin"`
+	Created     string `pulumi:"created"`
+	CreatedBy   string `pulumi:"createdBy"`
+	Expires     int    `pulumi:"expires"`
+	LastUsed    int    `pulumi:"lastUsed"`
+}
+
+type GetOrgTokensOutput struct {
+	Tokens []OrgTokenInfo `pulumi:"tokens"`
+}
+
+// orgTokenFilters are the values the token list endpoint accepts.
+var orgTokenFilters = []string{"active", "expired", "all"}
+
+func (GetOrgTokensFunction) Annotate(a infer.Annotator) {
+	a.Describe(
+		&GetOrgTokensFunction{},
+		"Lists the access tokens of a Pulumi Cloud organization. Token values are not returned. "+
+			"Every page of results is returned.",
+	)
+	a.SetToken("index", "getOrgTokens")
+}
+
+func (i *GetOrgTokensInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.OrganizationName, organizationNameDescription)
+	a.Describe(&i.Filter, "Which tokens to return: `active` (default), `expired` or `all`.")
+}
+
+func (o *OrgTokenInfo) Annotate(a infer.Annotator) {
+	a.Describe(&o.TokenID, "The token's ID.")
+	a.Describe(&o.Name, "The token's name.")
+	a.Describe(&o.Description, "The token's description.")
+	a.Describe(&o.Admin, "Whether the token has admin privileges.")
+	a.Describe(&o.Created, "When the token was created.")
+	a.Describe(&o.CreatedBy, "The user who created the token.")
+	a.Describe(&o.Expires, "Unix timestamp (seconds) when the token expires; 0 if it never does.")
+	a.Describe(&o.LastUsed, "Unix timestamp (seconds) when the token was last used; 0 if never.")
+}
+
+func (GetOrgTokensFunction) Invoke(
+	ctx context.Context,
+	req infer.FunctionRequest[GetOrgTokensInput],
+) (infer.FunctionResponse[GetOrgTokensOutput], error) {
+	filter := deref(req.Input.Filter)
+	if filter != "" && !slices.Contains(orgTokenFilters, filter) {
+		return infer.FunctionResponse[GetOrgTokensOutput]{}, fmt.Errorf(
+			"`filter` must be one of %v, got %q", orgTokenFilters, filter,
+		)
+	}
+	tokens, err := config.GetClient(ctx).ListOrgAccessTokens(ctx, req.Input.OrganizationName, filter)
+	if err != nil {
+		return infer.FunctionResponse[GetOrgTokensOutput]{}, err
+	}
+	out := make([]OrgTokenInfo, 0, len(tokens))
+	for _, t := range tokens {
+		out = append(out, OrgTokenInfo{
+			TokenID:     t.ID,
+			Name:        t.Name,
+			Description: t.Description,
+			Admin:       t.Admin,
+			Created:     t.Created,
+			CreatedBy:   t.CreatedBy,
+			Expires:     int(t.Expires),
+			LastUsed:    int(t.LastUsed),
+		})
+	}
+	return infer.FunctionResponse[GetOrgTokensOutput]{Output: GetOrgTokensOutput{Tokens: out}}, nil
+}
+
+// GetAccessTokensFunction lists an organization's access tokens of every
+// kind, for auditing stale or non-expiring tokens.
+type GetAccessTokensFunction struct{}
+
+type GetAccessTokensInput struct {
+	OrganizationName string   `pulumi:"organizationName"`
+	Kinds            []string `pulumi:"kinds,optional"`
+}
+
+type AccessTokenInfo struct {
+	Kind        string  `pulumi:"kind"`
+	TokenID     string  `pulumi:"tokenId"`
+	Name        string  `pulumi:"name"`
+	Description string  `pulumi:"description"`
+	TeamName    *string `pulumi:"teamName,optional"`
+	Admin       bool    `pulumi:"admin"`
+	Created     string  `pulumi:"created"`
+	CreatedBy   string  `pulumi:"createdBy"`
+	Expires     int     `pulumi:"expires"`
+	LastUsed    int     `pulumi:"lastUsed"`
+}
+
+type GetAccessTokensOutput struct {
+	Tokens []AccessTokenInfo `pulumi:"tokens"`
+}
+
+func (GetAccessTokensFunction) Annotate(a infer.Annotator) {
+	a.Describe(
+		&GetAccessTokensFunction{},
+		"Lists the organization, team and personal access tokens of a Pulumi Cloud organization, "+
+			"expired ones included, with when they were last used and expire. Token values are not returned. "+
+			"Personal tokens are those of the user the provider authenticates as.",
+	)
+	a.SetToken("index", "getAccessTokens")
+}
+
+func (i *GetAccessTokensInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.OrganizationName, organizationNameDescription)
+	a.Describe(&i.Kinds, "Which kinds of tokens to return: `organization`, `team` and `personal`. "+
+		"Defaults to all of them.")
+}
+
+func (o *AccessTokenInfo) Annotate(a infer.Annotator) {
+	a.Describe(&o.Kind, "The kind of token: `organization`, `team` or `personal`.")
+	a.Describe(&o.TokenID, "The token's ID.")
+	a.Describe(&o.Name, "The token's name.")
+	a.Describe(&o.Description, "The token's description.")
+	a.Describe(&o.TeamName, "The team a team token belongs to.")
+	a.Describe(&o.Admin, "Whether the token has admin privileges.")
+	a.Describe(&o.Created, "When the token was created.")
+	a.Describe(&o.CreatedBy, "The user who created the token.")
+	a.Describe(&o.Expires, "Unix timestamp (seconds) when the token expires; 0 if it never does.")
+	a.Describe(&o.LastUsed, "Unix timestamp (seconds) when the token was last used; 0 if never.")
+}
+
+func (GetAccessTokensFunction) Invoke(
+	ctx context.Context,
+	req infer.FunctionRequest[GetAccessTokensInput],
+) (infer.FunctionResponse[GetAccessTokensOutput], error) {
+	for _, kind := range req.Input.Kinds {
+		if !slices.Contains(pulumiapi.TokenKinds, kind) {
+			return infer.FunctionResponse[GetAccessTokensOutput]{}, fmt.Errorf(
+				"`kinds` must be among %v, got %q", pulumiapi.TokenKinds, kind,
+			)
+		}
+	}
+	tokens, err := config.GetClient(ctx).ListTokenInventory(ctx, req.Input.OrganizationName, req.Input.Kinds)
+	if err != nil {
+		return infer.FunctionResponse[GetAccessTokensOutput]{}, err
+	}
+	out := make([]AccessTokenInfo, 0, len(tokens))
+	for _, t := range tokens {
+		info := AccessTokenInfo{
+			Kind:        t.Kind,
+			TokenID:     t.ID,
+			Name:        t.Name,
+			Description: t.Description,
+			Admin:       t.Admin,
+			Created:     t.Created,
+			CreatedBy:   t.CreatedBy,
+			Expires:     int(t.Expires),
+			LastUsed:    int(t.LastUsed),
+		}
+		if t.TeamName != "" {
+			info.TeamName = &t.TeamName
+		}
+		out = append(out, info)
+	}
+	return infer.FunctionResponse[GetAccessTokensOutput]{Output: GetAccessTokensOutput{Tokens: out}}, nil
+}
+
+// GetWebhooksFunction lists the webhooks on an organization, stack or
+// environment.
+type GetWebhooksFunction struct{}
+
+type GetWebhooksInput struct {
+	OrganizationName string  `pulumi:"organizationName"`
+	ProjectName      *string `pulumi:"projectName,optional"`
+	StackName        *string `pulumi:"stackName,optional"`
+	EnvironmentName  *string `pulumi:"environmentName,optional"`
+}
+
+type WebhookInfo struct {
+	Name        string   `pulumi:"name"`
+	DisplayName string   `pulumi:"displayName"`
+	PayloadURL  string   `pulumi:"payloadUrl"`
+	Active      bool     `pulumi:"active"`
+	Format      string   `pulumi:"format"`
+	Filters     []string `pulumi:"filters"`
+	Groups      []string `pulumi:"groups"`
+	HasSecret   bool     `pulumi:"hasSecret"`
+}
+
+type GetWebhooksOutput struct {
+	Webhooks []WebhookInfo `pulumi:"webhooks"`
+}
+
+func (GetWebhooksFunction) Annotate(a infer.Annotator) {
+	a.Describe(
+		&GetWebhooksFunction{},
+		"Lists webhooks. With only `organizationName` set, returns the organization's webhooks; set "+
+			"`projectName` and `stackName` for a stack's, or `projectName` and `environmentName` for an "+
+			"environment's. Webhook secrets are not returned.",
+	)
+	a.SetToken("index", "getWebhooks")
+}
+
+func (i *GetWebhooksInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.OrganizationName, organizationNameDescription)
+	a.Describe(&i.ProjectName, "The project of the stack or environment whose webhooks to list.")
+	a.Describe(&i.StackName, "The stack whose webhooks to list.")
+	a.Describe(&i.EnvironmentName, "The environment whose webhooks to list.")
+}
+
+func (w *WebhookInfo) Annotate(a infer.Annotator) {
+	a.Describe(&w.Name, "The webhook's name.")
+	a.Describe(&w.DisplayName, "The webhook's display name.")
+	a.Describe(&w.PayloadURL, "The URL events are delivered to.")
+	a.Describe(&w.Active, "Whether the webhook is active.")
+	a.Describe(&w.Format, "The payload format.")
+	a.Describe(&w.Filters, "The events the webhook is subscribed to.")
+	a.Describe(&w.Groups, "The event groups the webhook is subscribed to.")
+	a.Describe(&w.HasSecret, "Whether the webhook signs its payloads with a secret.")
+}
+
+func (GetWebhooksFunction) Invoke(
+	ctx context.Context,
+	req infer.FunctionRequest[GetWebhooksInput],
+) (infer.FunctionResponse[GetWebhooksOutput], error) {
+	in := req.Input
+	if in.StackName != nil && in.EnvironmentName != nil {
+		return infer.FunctionResponse[GetWebhooksOutput]{}, fmt.Errorf(
+			"`stackName` and `environmentName` are mutually exclusive",
+		)
+	}
+	if (in.StackName != nil || in.EnvironmentName != nil) && in.ProjectName == nil {
+		return infer.FunctionResponse[GetWebhooksOutput]{}, fmt.Errorf(
+			"`projectName` is required with `stackName` or `environmentName`",
+		)
+	}
+	webhooks, err := config.GetClient(ctx).ListWebhooks(
+		ctx, in.OrganizationName, in.ProjectName, in.StackName, in.EnvironmentName,
+	)
+	if err != nil {
+		return infer.FunctionResponse[GetWebhooksOutput]{}, err
+	}
+	out := make([]WebhookInfo, 0, len(webhooks))
+	for _, w := range webhooks {
+		out = append(out, WebhookInfo{
+			Name:        w.Name,
+			DisplayName: w.DisplayName,
+			PayloadURL:  w.PayloadURL,
+			Active:      w.Active,
+			Format:      w.Format,
+			Filters:     w.Filters,
+			Groups:      w.Groups,
+			HasSecret:   w.HasSecret,
+		})
+	}
+	return infer.FunctionResponse[GetWebhooksOutput]{Output: GetWebhooksOutput{Webhooks: out}}, nil
+}
+
+// GetAgentPoolsFunction lists an organization's deployment agent pools.
+type GetAgentPoolsFunction struct{}
+
+type GetAgentPoolsInput struct {
+	OrganizationName string `pulumi:"organizationName"`
+}
+
+type AgentPoolInfo struct {
+	AgentPoolID string `pulumi:"agentPoolId"`
+	Name        string `pulumi:"name"`
+	Description string `pulumi:"description"`
+	Status      string `pulumi:"status"`
+	IsDefault   bool   `pulumi:"isDefault"`
+}
+
+type GetAgentPoolsOutput struct {
+	AgentPools []AgentPoolInfo `pulumi:"agentPools"`
+}
+
+func (GetAgentPoolsFunction) Annotate(a infer.Annotator) {
+	a.Describe(&GetAgentPoolsFunction{}, "Lists the deployment agent pools of a Pulumi Cloud organization.")
+	a.SetToken("index", "getAgentPools")
+}
+
+func (i *GetAgentPoolsInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.OrganizationName, organizationNameDescription)
+}
+
+func (p *AgentPoolInfo) Annotate(a infer.Annotator) {
+	a.Describe(&p.AgentPoolID, "The agent pool's ID.")
+	a.Describe(&p.Name, "The agent pool's name.")
+	a.Describe(&p.Description, "The agent pool's description.")
+	a.Describe(&p.Status, "The pool's status as reported by Pulumi Cloud.")
+	a.Describe(&p.IsDefault, "Whether this is the organization's default agent pool.")
+}
+
+func (GetAgentPoolsFunction) Invoke(
+	ctx context.Context,
+	req infer.FunctionRequest[GetAgentPoolsInput],
+) (infer.FunctionResponse[GetAgentPoolsOutput], error) {
+	pools, err := config.GetClient(ctx).ListAgentPools(ctx, req.Input.OrganizationName)
+	if err != nil {
+		return infer.FunctionResponse[GetAgentPoolsOutput]{}, err
+	}
+	out := make([]AgentPoolInfo, 0, len(pools))
+	for _, p := range pools {
+		out = append(out, AgentPoolInfo{
+			AgentPoolID: p.ID,
+			Name:        p.Name,
+			Description: p.Description,
+			Status:      p.Status,
+			IsDefault:   p.IsDefault,
+		})
+	}
+	return infer.FunctionResponse[GetAgentPoolsOutput]{Output: GetAgentPoolsOutput{AgentPools: out}}, nil
+}
+
+// GetOidcIssuersFunction lists the OIDC issuers registered with an
+// organization.
+type GetOidcIssuersFunction struct{}
+
+type GetOidcIssuersInput struct {
+	OrganizationName string `pulumi:"organizationName"`
+}
+
+type OidcIssuerInfo struct {
+	IssuerID      string   `pulumi:"issuerId"`
+	Name          string   `pulumi:"name"`
+	URL           string   `pulumi:"url"`
+	Issuer        string   `pulumi:"issuer"`
+	Thumbprints   []string `pulumi:"thumbprints"`
+	MaxExpiration *int     `pulumi:"maxExpiration,optional"`
+}
+
+type GetOidcIssuersOutput struct {
+	OidcIssuers []OidcIssuerInfo `pulumi:"oidcIssuers"`
+}
+
+func (GetOidcIssuersFunction) Annotate(a infer.Annotator) {
+	a.Describe(&GetOidcIssuersFunction{}, "Lists the OIDC issuers registered with a Pulumi Cloud organization.")
+	a.SetToken("index", "getOidcIssuers")
+}
+
+func (i *GetOidcIssuersInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.OrganizationName, organizationNameDescription)
+}
+
+func (o *OidcIssuerInfo) Annotate(a infer.Annotator) {
+	a.Describe(&o.IssuerID, "The issuer registration's ID.")
+	a.Describe(&o.Name, "The issuer's name.")
+	a.Describe(&o.URL, "The issuer URL.")
+	a.Describe(&o.Issuer, "The `iss` claim tokens from this issuer carry.")
+	a.Describe(&o.Thumbprints, "SHA-1 thumbprints of the issuer's TLS certificates.")
+	a.Describe(&o.MaxExpiration, "Maximum lifetime, in seconds, of tokens exchanged through this issuer.")
+}
+
+func (GetOidcIssuersFunction) Invoke(
+	ctx context.Context,
+	req infer.FunctionRequest[GetOidcIssuersInput],
+) (infer.FunctionResponse[GetOidcIssuersOutput], error) {
+	issuers, err := config.GetClient(ctx).ListOidcIssuers(ctx, req.Input.OrganizationName)
+	if err != nil {
+		return infer.FunctionResponse[GetOidcIssuersOutput]{}, err
+	}
+	out := make([]OidcIssuerInfo, 0, len(issuers))
+	for _, i := range issuers {
+		info := OidcIssuerInfo{
+			IssuerID:    i.ID,
+			Name:        i.Name,
+			URL:         i.URL,
+			Issuer:      i.Issuer,
+			Thumbprints: i.Thumbprints,
+		}
+		if i.MaxExpiration != nil {
+			maxExpiration := int(*i.MaxExpiration)
+			info.MaxExpiration = &maxExpiration
+		}
+		out = append(out, info)
+	}
+	return infer.FunctionResponse[GetOidcIssuersOutput]{Output: GetOidcIssuersOutput{OidcIssuers: out}}, nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,197 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+const testListOrgName = "test-org"
+
+type listClientMock struct {
+	config.Client
+	listStacksFunc          func(opts pulumiapi.ListStacksOptions) ([]pulumiapi.StackSummary, error)
+	listOrgAccessTokensFunc func(orgName, filter string) ([]pulumiapi.AccessTokenSummary, error)
+	listTokenInventoryFunc  func(orgName string, kinds []string) ([]pulumiapi.InventoryToken, error)
+	listWebhooksCalled      bool
+}
+
+func (c *listClientMock) ListStacks(
+	_ context.Context,
+	opts pulumiapi.ListStacksOptions,
+) ([]pulumiapi.StackSummary, error) {
+	return c.listStacksFunc(opts)
+}
+
+func (c *listClientMock) ListOrgAccessTokens(
+	_ context.Context,
+	orgName, filter string,
+) ([]pulumiapi.AccessTokenSummary, error) {
+	return c.listOrgAccessTokensFunc(orgName, filter)
+}
+
+func (c *listClientMock) ListTokenInventory(
+	_ context.Context,
+	orgName string,
+	kinds []string,
+) ([]pulumiapi.InventoryToken, error) {
+	return c.listTokenInventoryFunc(orgName, kinds)
+}
+
+func (c *listClientMock) ListWebhooks(
+	_ context.Context,
+	_ string,
+	_, _, _ *string,
+) ([]pulumiapi.Webhook, error) {
+	c.listWebhooksCalled = true
+	return nil, nil
+}
+
+func TestGetStacksFunction(t *testing.T) {
+	t.Parallel()
+
+	project := "proj"
+	tagName := "env"
+	lastUpdate := int64(1700000000)
+	mockedClient := &listClientMock{
+		listStacksFunc: func(opts pulumiapi.ListStacksOptions) ([]pulumiapi.StackSummary, error) {
+			assert.Equal(t, pulumiapi.ListStacksOptions{
+				Organization: testListOrgName,
+				Project:      project,
+				TagName:      tagName,
+			}, opts)
+			return []pulumiapi.StackSummary{
+				{OrgName: testListOrgName, ProjectName: project, StackName: "dev", LastUpdate: &lastUpdate},
+				{OrgName: testListOrgName, ProjectName: project, StackName: "prod"},
+			}, nil
+		},
+	}
+	ctx := config.WithMockClient(t.Context(), mockedClient)
+
+	resp, err := GetStacksFunction{}.Invoke(ctx, infer.FunctionRequest[GetStacksInput]{
+		Input: GetStacksInput{OrganizationName: testListOrgName, ProjectName: &project, TagName: &tagName},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Output.Stacks, 2)
+	assert.Equal(t, "dev", resp.Output.Stacks[0].StackName)
+	require.NotNil(t, resp.Output.Stacks[0].LastUpdate)
+	assert.Equal(t, 1700000000, *resp.Output.Stacks[0].LastUpdate)
+	assert.Nil(t, resp.Output.Stacks[1].LastUpdate)
+}
+
+func TestGetOrgTokensFunction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("forwards the filter", func(t *testing.T) {
+		t.Parallel()
+		filter := "all"
+		mockedClient := &listClientMock{
+			listOrgAccessTokensFunc: func(orgName, f string) ([]pulumiapi.AccessTokenSummary, error) {
+				assert.Equal(t, testListOrgName, orgName)
+				assert.Equal(t, filter, f)
+				return []pulumiapi.AccessTokenSummary{{ID: "tok-1", Name: "ci", Expires: 42}}, nil
+			},
+		}
+		ctx := config.WithMockClient(t.Context(), mockedClient)
+
+		resp, err := GetOrgTokensFunction{}.Invoke(ctx, infer.FunctionRequest[GetOrgTokensInput]{
+			Input: GetOrgTokensInput{OrganizationName: testListOrgName, Filter: &filter},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []OrgTokenInfo{{TokenID: "tok-1", Name: "ci", Expires: 42}}, resp.Output.Tokens)
+	})
+
+	t.Run("rejects an unknown filter", func(t *testing.T) {
+		t.Parallel()
+		filter := "revoked"
+		ctx := config.WithMockClient(t.Context(), &listClientMock{})
+
+		_, err := GetOrgTokensFunction{}.Invoke(ctx, infer.FunctionRequest[GetOrgTokensInput]{
+			Input: GetOrgTokensInput{OrganizationName: testListOrgName, Filter: &filter},
+		})
+		assert.ErrorContains(t, err, `got "revoked"`)
+	})
+}
+
+func TestGetAccessTokensFunction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("lists every kind", func(t *testing.T) {
+		t.Parallel()
+		mockedClient := &listClientMock{
+			listTokenInventoryFunc: func(orgName string, kinds []string) ([]pulumiapi.InventoryToken, error) {
+				assert.Equal(t, testListOrgName, orgName)
+				assert.Empty(t, kinds)
+				return []pulumiapi.InventoryToken{
+					{AccessTokenSummary: pulumiapi.AccessTokenSummary{ID: "tok-1", LastUsed: 7}, Kind: "organization"},
+					{AccessTokenSummary: pulumiapi.AccessTokenSummary{ID: "tok-2"}, Kind: "team", TeamName: "ops"},
+				}, nil
+			},
+		}
+		ctx := config.WithMockClient(t.Context(), mockedClient)
+
+		resp, err := GetAccessTokensFunction{}.Invoke(ctx, infer.FunctionRequest[GetAccessTokensInput]{
+			Input: GetAccessTokensInput{OrganizationName: testListOrgName},
+		})
+		require.NoError(t, err)
+		team := "ops"
+		assert.Equal(t, []AccessTokenInfo{
+			{Kind: "organization", TokenID: "tok-1", LastUsed: 7},
+			{Kind: "team", TokenID: "tok-2", TeamName: &team},
+		}, resp.Output.Tokens)
+	})
+
+	t.Run("rejects an unknown kind", func(t *testing.T) {
+		t.Parallel()
+		ctx := config.WithMockClient(t.Context(), &listClientMock{})
+
+		_, err := GetAccessTokensFunction{}.Invoke(ctx, infer.FunctionRequest[GetAccessTokensInput]{
+			Input: GetAccessTokensInput{OrganizationName: testListOrgName, Kinds: []string{"robot"}},
+		})
+		assert.ErrorContains(t, err, `got "robot"`)
+	})
+}
+
+func TestGetWebhooksFunctionValidatesScope(t *testing.T) {
+	t.Parallel()
+
+	stack := "dev"
+	env := "prod"
+	project := "proj"
+	for name, in := range map[string]GetWebhooksInput{
+		"stack without project":   {OrganizationName: testListOrgName, StackName: &stack},
+		"stack and environment":   {OrganizationName: testListOrgName, ProjectName: &project, StackName: &stack, EnvironmentName: &env},
+		"environment w/o project": {OrganizationName: testListOrgName, EnvironmentName: &env},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			mockedClient := &listClientMock{}
+			ctx := config.WithMockClient(t.Context(), mockedClient)
+
+			_, err := GetWebhooksFunction{}.Invoke(ctx, infer.FunctionRequest[GetWebhooksInput]{Input: in})
+			assert.Error(t, err)
+			assert.False(t, mockedClient.listWebhooksCalled)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/pulumi/esc"
+	esc_client "github.com/pulumi/esc/cmd/esc/cli/client"
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/util"
+)
+
+// defaultOpenDuration matches the default lifetime of `esc open`.
+const defaultOpenDuration = 2 * time.Hour
+
+// OpenEnvironmentFunction opens an ESC environment, which evaluates it, and
+// returns its resolved values. Secret values are returned as Pulumi secrets,
+// so the function must be registered through WithSecretLeaves.
+type OpenEnvironmentFunction struct{}
+
+type OpenEnvironmentInput struct {
+	OrganizationName string  `pulumi:"organizationName"`
+	ProjectName      string  `pulumi:"projectName,optional"`
+	Name             string  `pulumi:"name"`
+	Version          *string `pulumi:"version,optional"`
+	ChangeRequestID  *string `pulumi:"changeRequestId,optional"`
+	Duration         *string `pulumi:"duration,optional"`
+}
+
+type OpenEnvironmentOutput struct {
+	Values               map[string]any    `pulumi:"values"`
+	EnvironmentVariables map[string]string `pulumi:"environmentVariables"`
+	Files                map[string]string `pulumi:"files"`
+}
+
+func (OpenEnvironmentFunction) Annotate(a infer.Annotator) {
+	a.Describe(
+		&OpenEnvironmentFunction{},
+		"Opens an ESC environment and returns its resolved values, like `esc open`. Opening an environment "+
+			"evaluates it, so dynamic providers such as `fn::open::aws-login` issue fresh credentials on "+
+			"every call. Secret values are returned as Pulumi secrets. Errors when the environment does not "+
+			"evaluate.",
+	)
+	a.SetToken("index", "openEnvironment")
+}
+
+func (i *OpenEnvironmentInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.OrganizationName, "The Pulumi Cloud organization that owns the environment.")
+	a.Describe(&i.ProjectName, "The ESC project name. Defaults to `default`.")
+	a.Describe(&i.Name, "The environment name.")
+	a.Describe(&i.Version, "The revision number or tag to open, e.g. `3` or `stable`. Defaults to the latest "+
+		"revision. Conflicts with `changeRequestId`.")
+	a.Describe(&i.ChangeRequestID, "Opens the draft proposed by this change request instead of a revision.")
+	a.Describe(&i.Duration, "How long the opened environment stays available, as a duration such as `30m` or "+
+		"`1h30m`. Defaults to `2h`.")
+}
+
+func (o *OpenEnvironmentOutput) Annotate(a infer.Annotator) {
+	a.Describe(&o.Values, "The environment's resolved values, by top-level key.")
+	a.Describe(&o.EnvironmentVariables, "The environment variables the environment exports, from its "+
+		"`environmentVariables` key.")
+	a.Describe(&o.Files, "The contents of the files the environment exports, from its `files` key, by the name "+
+		"of the environment variable that holds each file's path.")
+}
+
+func (OpenEnvironmentFunction) Invoke(
+	ctx context.Context,
+	req infer.FunctionRequest[OpenEnvironmentInput],
+) (infer.FunctionResponse[OpenEnvironmentOutput], error) {
+	in := req.Input
+	if in.OrganizationName == "" {
+		return infer.FunctionResponse[OpenEnvironmentOutput]{}, fmt.Errorf("`organizationName` must not be empty")
+	}
+	if in.Name == "" {
+		return infer.FunctionResponse[OpenEnvironmentOutput]{}, fmt.Errorf("`name` must not be empty")
+	}
+	if in.Version != nil && in.ChangeRequestID != nil {
+		return infer.FunctionResponse[OpenEnvironmentOutput]{}, fmt.Errorf(
+			"`version` and `changeRequestId` cannot both be set",
+		)
+	}
+	duration := defaultOpenDuration
+	if in.Duration != nil {
+		d, err := time.ParseDuration(*in.Duration)
+		if err != nil || d <= 0 {
+			return infer.FunctionResponse[OpenEnvironmentOutput]{}, fmt.Errorf(
+				"`duration` must be a positive duration such as `1h30m`, got %q", *in.Duration,
+			)
+		}
+		duration = d
+	}
+	project := in.ProjectName
+	if project == "" {
+		project = "default"
+	}
+	ref := fmt.Sprintf("%s/%s/%s", in.OrganizationName, project, in.Name)
+
+	client := config.GetEscClient(ctx)
+	var openID string
+	var diags []esc_client.EnvironmentDiagnostic
+	var err error
+	if in.ChangeRequestID != nil {
+		openID, diags, err = client.OpenEnvironmentDraft(
+			ctx, in.OrganizationName, project, in.Name, *in.ChangeRequestID, duration)
+	} else {
+		openID, diags, err = client.OpenEnvironment(
+			ctx, in.OrganizationName, project, in.Name, util.OrZero(in.Version), duration)
+	}
+	if err != nil {
+		return infer.FunctionResponse[OpenEnvironmentOutput]{}, fmt.Errorf(
+			"failed to open environment %s: %w", ref, err,
+		)
+	}
+	if len(diags) != 0 {
+		return infer.FunctionResponse[OpenEnvironmentOutput]{}, fmt.Errorf(
+			"failed to open environment %s: %w", ref, diagnosticsError(diags),
+		)
+	}
+	env, err := client.GetOpenEnvironmentWithProject(ctx, in.OrganizationName, project, in.Name, openID)
+	if err != nil {
+		return infer.FunctionResponse[OpenEnvironmentOutput]{}, fmt.Errorf(
+			"failed to read opened environment %s: %w", ref, err,
+		)
+	}
+
+	out := OpenEnvironmentOutput{
+		Values:               map[string]any{},
+		EnvironmentVariables: map[string]string{},
+		Files:                map[string]string{},
+	}
+	for k, v := range env.Properties {
+		out.Values[k] = escValue(ctx, v, "values", k)
+	}
+	for k, v := range env.GetEnvironmentVariables() {
+		out.EnvironmentVariables[k] = escString(ctx, v, "environmentVariables", k)
+	}
+	for k, v := range env.GetTemporaryFiles() {
+		out.Files[k] = escString(ctx, v, "files", k)
+	}
+	return infer.FunctionResponse[OpenEnvironmentOutput]{Output: out}, nil
+}
+
+// escValue converts v to a plain value, marking its secret parts, found at
+// path within the output, with markSecret.
+func escValue(ctx context.Context, v esc.Value, path ...any) any {
+	if v.Secret {
+		markSecret(ctx, path...)
+	}
+	switch v := v.Value.(type) {
+	case map[string]esc.Value:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[k] = escValue(ctx, e, append(slices.Clip(path), k)...)
+		}
+		return m
+	case []esc.Value:
+		a := make([]any, len(v))
+		for i, e := range v {
+			a[i] = escValue(ctx, e, append(slices.Clip(path), i)...)
+		}
+		return a
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	default:
+		return v
+	}
+}
+
+func escString(ctx context.Context, v esc.Value, path ...any) string {
+	if v.Secret {
+		markSecret(ctx, path...)
+	}
+	return v.ToString(false)
+}
+
+func diagnosticsError(diags []esc_client.EnvironmentDiagnostic) error {
+	errs := make([]error, len(diags))
+	for i, d := range diags {
+		if d.Range != nil {
+			errs[i] = fmt.Errorf("%s:%d:%d: %s", d.Range.Environment, d.Range.Begin.Line, d.Range.Begin.Column,
+				d.Summary)
+		} else {
+			errs[i] = errors.New(d.Summary)
+		}
+	}
+	return errors.Join(errs...)
+}
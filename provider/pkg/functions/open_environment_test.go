@@ -0,0 +1,168 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/esc"
+	esc_client "github.com/pulumi/esc/cmd/esc/cli/client"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+)
+
+type openEnvironmentClientMock struct {
+	esc_client.Client
+	env         *esc.Environment
+	diags       []esc_client.EnvironmentDiagnostic
+	openedWith  string
+	draftOpened string
+	duration    time.Duration
+}
+
+func (c *openEnvironmentClientMock) OpenEnvironment(
+	_ context.Context, _, _, _, version string, duration time.Duration,
+) (string, []esc_client.EnvironmentDiagnostic, error) {
+	c.openedWith, c.duration = version, duration
+	return "open-1", c.diags, nil
+}
+
+func (c *openEnvironmentClientMock) OpenEnvironmentDraft(
+	_ context.Context, _, _, _, changeRequestID string, duration time.Duration,
+) (string, []esc_client.EnvironmentDiagnostic, error) {
+	c.draftOpened, c.duration = changeRequestID, duration
+	return "open-1", c.diags, nil
+}
+
+func (c *openEnvironmentClientMock) GetOpenEnvironmentWithProject(
+	_ context.Context, _, _, _, openEnvID string,
+) (*esc.Environment, error) {
+	if openEnvID != "open-1" {
+		return nil, assert.AnError
+	}
+	return c.env, nil
+}
+
+func openedEnvironment() *esc.Environment {
+	return &esc.Environment{Properties: map[string]esc.Value{
+		"region": esc.NewValue("us-west-2"),
+		"db": esc.NewValue(map[string]esc.Value{
+			"port":     esc.NewValue(json.Number("5432")),
+			"password": esc.NewSecret("hunter2"),
+		}),
+		"hosts": esc.NewValue([]esc.Value{esc.NewValue("a"), esc.NewSecret("b")}),
+		"environmentVariables": esc.NewValue(map[string]esc.Value{
+			"REGION": esc.NewValue("us-west-2"),
+			"TOKEN":  esc.NewSecret("t0k3n"),
+		}),
+		"files": esc.NewValue(map[string]esc.Value{
+			"KUBECONFIG": esc.NewSecret("apiVersion: v1"),
+		}),
+	}}
+}
+
+func TestOpenEnvironment(t *testing.T) {
+	invoke := func(ctx context.Context, args map[string]property.Value) (property.Map, error) {
+		resp, err := WithSecretLeaves(infer.Function(&OpenEnvironmentFunction{})).Invoke(ctx, p.InvokeRequest{
+			Args: property.NewMap(args),
+		})
+		return resp.Return, err
+	}
+	args := map[string]property.Value{
+		"organizationName": property.New("my-org"),
+		"projectName":      property.New("my-project"),
+		"name":             property.New("dev"),
+	}
+
+	t.Run("returns resolved values with secret leaves", func(t *testing.T) {
+		client := &openEnvironmentClientMock{env: openedEnvironment()}
+		ctx := config.WithMockEscClient(context.Background(), client)
+
+		out, err := invoke(ctx, args)
+		require.NoError(t, err)
+		assert.Equal(t, defaultOpenDuration, client.duration)
+
+		values := out.Get("values").AsMap()
+		assert.Equal(t, property.New("us-west-2"), values.Get("region"))
+		db := values.Get("db").AsMap()
+		assert.False(t, values.Get("db").Secret())
+		assert.Equal(t, property.New(5432.0), db.Get("port"))
+		assert.Equal(t, property.New("hunter2").WithSecret(true), db.Get("password"))
+		assert.Equal(t, property.New(property.NewArray([]property.Value{
+			property.New("a"), property.New("b").WithSecret(true),
+		})), values.Get("hosts"))
+
+		assert.Equal(t, property.New(map[string]property.Value{
+			"REGION": property.New("us-west-2"),
+			"TOKEN":  property.New("t0k3n").WithSecret(true),
+		}), out.Get("environmentVariables"))
+		assert.Equal(t, property.New(map[string]property.Value{
+			"KUBECONFIG": property.New("apiVersion: v1").WithSecret(true),
+		}), out.Get("files"))
+	})
+
+	t.Run("opens a version for the given duration", func(t *testing.T) {
+		client := &openEnvironmentClientMock{env: &esc.Environment{}}
+		ctx := config.WithMockEscClient(context.Background(), client)
+
+		versioned := maps.Clone(args)
+		versioned["version"] = property.New("stable")
+		versioned["duration"] = property.New("15m")
+		_, err := invoke(ctx, versioned)
+		require.NoError(t, err)
+		assert.Equal(t, "stable", client.openedWith)
+		assert.Equal(t, 15*time.Minute, client.duration)
+	})
+
+	t.Run("opens a draft", func(t *testing.T) {
+		client := &openEnvironmentClientMock{env: &esc.Environment{}}
+		ctx := config.WithMockEscClient(context.Background(), client)
+
+		draft := maps.Clone(args)
+		draft["changeRequestId"] = property.New("cr-1")
+		_, err := invoke(ctx, draft)
+		require.NoError(t, err)
+		assert.Equal(t, "cr-1", client.draftOpened)
+	})
+
+	t.Run("reports evaluation errors", func(t *testing.T) {
+		client := &openEnvironmentClientMock{diags: []esc_client.EnvironmentDiagnostic{{
+			Range:   &esc.Range{Environment: "dev", Begin: esc.Pos{Line: 3, Column: 5}},
+			Summary: "unknown property \"nope\"",
+		}}}
+		ctx := config.WithMockEscClient(context.Background(), client)
+
+		_, err := invoke(ctx, args)
+		assert.ErrorContains(t, err, `dev:3:5: unknown property "nope"`)
+	})
+
+	t.Run("rejects an invalid duration", func(t *testing.T) {
+		ctx := config.WithMockEscClient(context.Background(), &openEnvironmentClientMock{})
+		bad := maps.Clone(args)
+		bad["duration"] = property.New("soon")
+		_, err := invoke(ctx, bad)
+		assert.ErrorContains(t, err, "`duration` must be a positive duration")
+	})
+}
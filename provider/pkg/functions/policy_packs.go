@@ -0,0 +1,353 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/util"
+)
+
+const (
+	policyPackSourceDescription = "Where the policy pack is hosted in the Pulumi Registry: `pulumi` for packs " +
+		"published by Pulumi (for example `cis-aws`), `private` for packs published by an organization. Omitted " +
+		"when the provider could not determine registry metadata for this pack."
+	policyPackPublisherDescription = "The organization or user that published the policy pack. `pulumi` for " +
+		"Pulumi-published packs, otherwise the publishing organization's name. Omitted when the provider could " +
+		"not determine registry metadata for this pack."
+)
+
+// GetPolicyPacksFunction lists the policy packs of an organization.
+type GetPolicyPacksFunction struct{}
+
+type GetPolicyPacksInput struct {
+	OrganizationName string `pulumi:"organizationName"`
+}
+
+type GetPolicyPacksOutput struct {
+	PolicyPacks []PolicyPackSummary `pulumi:"policyPacks"`
+}
+
+type PolicyPackSummary struct {
+	Name        string   `pulumi:"name"`
+	DisplayName string   `pulumi:"displayName"`
+	Versions    []int    `pulumi:"versions"`
+	VersionTags []string `pulumi:"versionTags"`
+	Source      *string  `pulumi:"source,optional"`
+	Publisher   *string  `pulumi:"publisher,optional"`
+}
+
+func (GetPolicyPacksFunction) Annotate(a infer.Annotator) {
+	a.Describe(&GetPolicyPacksFunction{}, "Get a list of all policy packs for an organization.")
+	a.SetToken("index", "getPolicyPacks")
+}
+
+func (i *GetPolicyPacksInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.OrganizationName, "The name of the Pulumi organization.")
+}
+
+func (o *GetPolicyPacksOutput) Annotate(a infer.Annotator) {
+	a.Describe(&o.PolicyPacks, "List of policy packs in the organization.")
+}
+
+func (s *PolicyPackSummary) Annotate(a infer.Annotator) {
+	a.Describe(s, "Summary metadata for a policy pack available to an organization, including its Pulumi "+
+		"Registry provenance.")
+	a.Describe(&s.Name, "The name of the policy pack.")
+	a.Describe(&s.DisplayName, "The display name of the policy pack.")
+	a.Describe(&s.Versions, "List of version numbers for this policy pack.")
+	a.Describe(&s.VersionTags, "List of version tags for this policy pack.")
+	a.Describe(&s.Source, policyPackSourceDescription)
+	a.Describe(&s.Publisher, policyPackPublisherDescription)
+}
+
+func (GetPolicyPacksFunction) Invoke(
+	ctx context.Context,
+	req infer.FunctionRequest[GetPolicyPacksInput],
+) (infer.FunctionResponse[GetPolicyPacksOutput], error) {
+	if req.Input.OrganizationName == "" {
+		return infer.FunctionResponse[GetPolicyPacksOutput]{}, fmt.Errorf("organizationName is required")
+	}
+
+	// Registry provenance (source/publisher) comes from a second endpoint joined
+	// on pack name; any failure other than the route being absent is fatal, so a
+	// program filtering on publisher can't silently match nothing and attach zero
+	// policy packs.
+	policyPacks, registryUnavailable, err := config.GetClient(ctx).
+		ListPolicyPacksWithRegistryMetadata(ctx, req.Input.OrganizationName)
+	if err != nil {
+		return infer.FunctionResponse[GetPolicyPacksOutput]{}, fmt.Errorf("failed to list policy packs: %w", err)
+	}
+	if registryUnavailable {
+		p.GetLogger(ctx).Warning("this Pulumi backend does not serve the policy pack registry; " +
+			"`source` and `publisher` will be absent from getPolicyPacks results")
+	}
+
+	return infer.FunctionResponse[GetPolicyPacksOutput]{
+		Output: GetPolicyPacksOutput{PolicyPacks: newPolicyPackSummaries(policyPacks)},
+	}, nil
+}
+
+// newPolicyPackSummaries renders the packs of an organization. Provenance that
+// couldn't be determined is omitted rather than set to "", so the optional
+// fields surface as absent in typed SDKs instead of as a value that compares
+// equal to nothing.
+func newPolicyPackSummaries(packs []pulumiapi.PolicyPackWithRegistryMetadata) []PolicyPackSummary {
+	result := make([]PolicyPackSummary, len(packs))
+	for i, pack := range packs {
+		result[i] = PolicyPackSummary{
+			Name:        pack.Name,
+			DisplayName: pack.DisplayName,
+			Versions:    pack.Versions,
+			VersionTags: pack.VersionTags,
+			Source:      util.OrNil(pack.Source),
+			Publisher:   util.OrNil(pack.Publisher),
+		}
+		if result[i].Versions == nil {
+			result[i].Versions = []int{}
+		}
+		if result[i].VersionTags == nil {
+			result[i].VersionTags = []string{}
+		}
+	}
+	return result
+}
+
+// GetPolicyPackFunction reads a single version of a policy pack.
+type GetPolicyPackFunction struct{}
+
+type GetPolicyPackInput struct {
+	OrganizationName string `pulumi:"organizationName"`
+	PolicyPackName   string `pulumi:"policyPackName"`
+	Version          *int   `pulumi:"version,optional"`
+}
+
+type GetPolicyPackOutput struct {
+	Name        string             `pulumi:"name"`
+	DisplayName string             `pulumi:"displayName"`
+	Version     int                `pulumi:"version"`
+	VersionTag  *string            `pulumi:"versionTag,optional"`
+	Source      *string            `pulumi:"source,optional"`
+	Publisher   *string            `pulumi:"publisher,optional"`
+	Config      map[string]any     `pulumi:"config,optional"`
+	Policies    []PolicyPackPolicy `pulumi:"policies,optional"`
+}
+
+type PolicyPackPolicy struct {
+	Name             string                     `pulumi:"name"`
+	DisplayName      *string                    `pulumi:"displayName,optional"`
+	Description      *string                    `pulumi:"description,optional"`
+	EnforcementLevel *string                    `pulumi:"enforcementLevel,optional"`
+	Message          *string                    `pulumi:"message,optional"`
+	ConfigSchema     map[string]any             `pulumi:"configSchema,optional"`
+	Severity         *string                    `pulumi:"severity,optional"`
+	Framework        *PolicyPackPolicyFramework `pulumi:"framework,optional"`
+	Tags             []string                   `pulumi:"tags,optional"`
+	RemediationSteps *string                    `pulumi:"remediationSteps,optional"`
+	URL              *string                    `pulumi:"url,optional"`
+}
+
+type PolicyPackPolicyFramework struct {
+	Name          *string `pulumi:"name,optional"`
+	Version       *string `pulumi:"version,optional"`
+	Reference     *string `pulumi:"reference,optional"`
+	Specification *string `pulumi:"specification,optional"`
+}
+
+func (GetPolicyPackFunction) Annotate(a infer.Annotator) {
+	a.Describe(&GetPolicyPackFunction{}, "Get details about a specific version of a policy pack.")
+	a.SetToken("index", "getPolicyPack")
+}
+
+func (i *GetPolicyPackInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.OrganizationName, "The name of the Pulumi organization.")
+	a.Describe(&i.PolicyPackName, "The name of the policy pack.")
+	a.Describe(&i.Version, "The version number of the policy pack. If not specified, returns the latest version.")
+}
+
+func (o *GetPolicyPackOutput) Annotate(a infer.Annotator) {
+	a.Describe(&o.Name, "The name of the policy pack.")
+	a.Describe(&o.DisplayName, "The display name of the policy pack.")
+	a.Describe(&o.Version, "The version number.")
+	a.Describe(&o.VersionTag, "The version tag (if any).")
+	a.Describe(&o.Source, policyPackSourceDescription)
+	a.Describe(&o.Publisher, policyPackPublisherDescription)
+	a.Describe(&o.Config, "Configuration for the policy pack.")
+	a.Describe(&o.Policies, "List of policies in this pack.")
+}
+
+func (p *PolicyPackPolicy) Annotate(a infer.Annotator) {
+	a.Describe(p, "A policy within a policy pack.")
+	a.Describe(&p.Name, "The policy name.")
+	a.Describe(&p.DisplayName, "The display name.")
+	a.Describe(&p.Description, "The policy description.")
+	a.Describe(&p.EnforcementLevel, "The enforcement level (advisory, mandatory, etc.).")
+	a.Describe(&p.Message, "Message shown when policy is violated.")
+	a.Describe(&p.ConfigSchema, "Configuration schema for this policy.")
+	a.Describe(&p.Severity, "The severity of the policy (low, medium, high, critical).")
+	a.Describe(&p.Framework, "The compliance framework that this policy belongs to.")
+	a.Describe(&p.Tags, "Tags associated with the policy.")
+	a.Describe(&p.RemediationSteps, "A description of the steps to take to remediate a policy violation.")
+	a.Describe(&p.URL, "A URL to more information about the policy.")
+}
+
+func (f *PolicyPackPolicyFramework) Annotate(a infer.Annotator) {
+	a.Describe(f, "The compliance framework that a policy belongs to.")
+	a.Describe(&f.Name, "The compliance framework name.")
+	a.Describe(&f.Version, "The compliance framework version.")
+	a.Describe(&f.Reference, "The compliance framework reference.")
+	a.Describe(&f.Specification, "The compliance framework specification.")
+}
+
+func (GetPolicyPackFunction) Invoke(
+	ctx context.Context,
+	req infer.FunctionRequest[GetPolicyPackInput],
+) (infer.FunctionResponse[GetPolicyPackOutput], error) {
+	in := req.Input
+	if in.OrganizationName == "" {
+		return infer.FunctionResponse[GetPolicyPackOutput]{}, fmt.Errorf("organizationName is required")
+	}
+	if in.PolicyPackName == "" {
+		return infer.FunctionResponse[GetPolicyPackOutput]{}, fmt.Errorf("policyPackName is required")
+	}
+
+	client := config.GetClient(ctx)
+
+	// Call the API - either specific version or latest
+	var policyPack *pulumiapi.PolicyPackDetail
+	var err error
+	if in.Version != nil {
+		policyPack, err = client.GetPolicyPack(ctx, in.OrganizationName, in.PolicyPackName, *in.Version)
+	} else {
+		policyPack, err = client.GetLatestPolicyPack(ctx, in.OrganizationName, in.PolicyPackName)
+	}
+	if err != nil {
+		return infer.FunctionResponse[GetPolicyPackOutput]{}, fmt.Errorf("failed to get policy pack: %w", err)
+	}
+	if policyPack == nil {
+		return infer.FunctionResponse[GetPolicyPackOutput]{}, fmt.Errorf("policy pack not found")
+	}
+
+	// Look the pack up by the version tag we just resolved. Provenance is version
+	// independent, but the tag is not optional in practice: omitting it makes the
+	// service look for the literal tag `latest`, which packs do not carry, so
+	// source and publisher would come back empty for effectively every pack.
+	//
+	// Note the deliberate asymmetry with getPolicyPacks, which treats a registry
+	// failure as fatal: there, a failed lookup silently empties a publisher filter
+	// and the program attaches nothing. Here the caller already named the pack, so
+	// that hazard doesn't exist and failing would only deny them a result the
+	// provider could already produce in full before this feature. Don't "fix" one
+	// to match the other.
+	registryPack, err := client.GetRegistryPolicyPack(
+		ctx, in.OrganizationName, in.PolicyPackName, policyPack.VersionTag)
+	if err != nil {
+		p.GetLogger(ctx).Warningf(
+			"could not read registry metadata for policy pack %q: %v", in.PolicyPackName, err)
+		registryPack = nil
+	}
+
+	return infer.FunctionResponse[GetPolicyPackOutput]{
+		Output: newGetPolicyPackOutput(policyPack, registryPack),
+	}, nil
+}
+
+// newGetPolicyPackOutput renders a policy pack. registry carries the pack's
+// Pulumi Registry provenance and may be nil when that lookup was unavailable;
+// source and publisher are then omitted.
+func newGetPolicyPackOutput(
+	pack *pulumiapi.PolicyPackDetail,
+	registry *pulumiapi.RegistryPolicyPack,
+) GetPolicyPackOutput {
+	out := GetPolicyPackOutput{
+		Name:        pack.Name,
+		DisplayName: pack.DisplayName,
+		Version:     pack.Version,
+		VersionTag:  util.OrNil(pack.VersionTag),
+		Config:      pack.Config,
+	}
+
+	if registry != nil {
+		out.Source = util.OrNil(registry.Source)
+		out.Publisher = util.OrNil(registry.Publisher)
+	}
+
+	for _, policy := range pack.Policies {
+		rendered := PolicyPackPolicy{
+			Name:             policy.Name,
+			DisplayName:      util.OrNil(policy.DisplayName),
+			Description:      util.OrNil(policy.Description),
+			EnforcementLevel: util.OrNil(string(policy.EnforcementLevel)),
+			Message:          util.OrNil(policy.Message),
+			Severity:         util.OrNil(string(policy.Severity)),
+			Tags:             policy.Tags,
+			RemediationSteps: util.OrNil(policy.RemediationSteps),
+			URL:              util.OrNil(policy.URL),
+		}
+		if policy.ConfigSchema != nil {
+			rendered.ConfigSchema = newConfigSchema(policy.ConfigSchema)
+		}
+		if f := policy.Framework; f != nil {
+			rendered.Framework = &PolicyPackPolicyFramework{
+				Name:          util.OrNil(f.Name),
+				Version:       util.OrNil(f.Version),
+				Reference:     util.OrNil(f.Reference),
+				Specification: util.OrNil(f.Specification),
+			}
+		}
+		out.Policies = append(out.Policies, rendered)
+	}
+
+	return out
+}
+
+// newConfigSchema renders a policy's JSON config schema as a plain map.
+// Property schemas that fail to decode are skipped.
+func newConfigSchema(cs *apitype.PolicyConfigSchema) map[string]any {
+	schema := map[string]any{}
+	if cs.Type != "" {
+		schema["type"] = string(cs.Type)
+	}
+	if len(cs.Required) > 0 {
+		required := make([]any, len(cs.Required))
+		for i, r := range cs.Required {
+			required[i] = r
+		}
+		schema["required"] = required
+	}
+	if len(cs.Properties) > 0 {
+		properties := map[string]any{}
+		for name, raw := range cs.Properties {
+			if raw == nil {
+				continue
+			}
+			var decoded any
+			if err := json.Unmarshal(*raw, &decoded); err != nil {
+				continue
+			}
+			properties[name] = decoded
+		}
+		schema["properties"] = properties
+	}
+	return schema
+}
@@ -0,0 +1,263 @@
+// Copyright 2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+const (
+	invokeTestOrg      = "anOrg"
+	orgPolicyPacksPath = "/api/orgs/anOrg/policypacks"
+	registryListPath   = "/api/registry/policypacks"
+	orgRegistryPath    = "/api/orgs/anOrg/registry/policypacks/alpha"
+	latestPolicyPack   = "/api/orgs/anOrg/policypacks/alpha/latest"
+	testSourcePulumi   = "pulumi"
+	testSourcePrivate  = "private"
+	cisAwsPack         = "cis-aws"
+	alphaPack          = "alpha"
+)
+
+// newInvokeTestContext wires a client against a test server that serves both
+// the org policy pack endpoints and the registry endpoints, so an invoke can be
+// driven end to end across the two-call join.
+func newInvokeTestContext(t *testing.T, handler http.HandlerFunc) context.Context {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := pulumiapi.NewClient(&http.Client{}, "tok", server.URL)
+	require.NoError(t, err)
+	return config.WithMockClient(context.Background(), client)
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, code int, body any) {
+	t.Helper()
+	w.WriteHeader(code)
+	if body != nil {
+		require.NoError(t, json.NewEncoder(w).Encode(body))
+	}
+}
+
+// policyPacksHandler serves the org list plus a registry list that responds with
+// registryCode. registryPacks is the registry payload when registryCode is 200.
+func policyPacksHandler(
+	t *testing.T,
+	orgPacks []map[string]any,
+	registryCode int,
+	registryPacks []map[string]any,
+) http.HandlerFunc {
+	t.Helper()
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case orgPolicyPacksPath:
+			writeJSON(t, w, http.StatusOK, map[string]any{"policyPacks": orgPacks})
+		case registryListPath:
+			if registryCode != http.StatusOK {
+				writeJSON(t, w, registryCode, map[string]any{"message": "nope"})
+				return
+			}
+			writeJSON(t, w, http.StatusOK, map[string]any{"policyPacks": registryPacks})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
+
+func invokeGetPolicyPacks(ctx context.Context) ([]PolicyPackSummary, error) {
+	resp, err := GetPolicyPacksFunction{}.Invoke(ctx, infer.FunctionRequest[GetPolicyPacksInput]{
+		Input: GetPolicyPacksInput{OrganizationName: invokeTestOrg},
+	})
+	return resp.Output.PolicyPacks, err
+}
+
+func orgPackFixtures() []map[string]any {
+	return []map[string]any{
+		{"name": cisAwsPack, "displayName": "CIS AWS", "versions": []int{1}, "versionTags": []string{"1.0.2"}},
+		{"name": alphaPack, "displayName": "Alpha", "versions": []int{1, 2}, "versionTags": []string{"0.0.5"}},
+	}
+}
+
+func TestGetPolicyPacks_IncludesSourceAndPublisher(t *testing.T) {
+	ctx := newInvokeTestContext(t, policyPacksHandler(t, orgPackFixtures(), http.StatusOK, []map[string]any{
+		{"name": cisAwsPack, "source": testSourcePulumi, "publisher": testSourcePulumi},
+		{"name": alphaPack, "source": testSourcePrivate, "publisher": invokeTestOrg},
+	}))
+
+	packs, err := invokeGetPolicyPacks(ctx)
+	require.NoError(t, err)
+	require.Len(t, packs, 2)
+
+	assert.Equal(t, testSourcePulumi, *packs[0].Source)
+	assert.Equal(t, testSourcePulumi, *packs[0].Publisher)
+
+	alpha := packs[1]
+	assert.Equal(t, testSourcePrivate, *alpha.Source)
+	assert.Equal(t, invokeTestOrg, *alpha.Publisher)
+
+	// Provenance must not disturb the fields that were already there.
+	assert.Equal(t, "Alpha", alpha.DisplayName)
+	assert.Len(t, alpha.Versions, 2)
+}
+
+// A backend without the registry route must keep serving getPolicyPacks exactly
+// as it did before this feature existed.
+func TestGetPolicyPacks_OmitsFieldsWhenRegistryRouteMissing(t *testing.T) {
+	ctx := newInvokeTestContext(t, policyPacksHandler(t, orgPackFixtures(), http.StatusNotFound, nil))
+
+	packs, err := invokeGetPolicyPacks(ctx)
+	require.NoError(t, err)
+	require.Len(t, packs, 2)
+
+	for _, pack := range packs {
+		// Absence, not "": the schema marks these optional so a degraded result
+		// is distinguishable from a real one in typed SDKs.
+		assert.Nil(t, pack.Source, "source should be absent, not empty")
+		assert.Nil(t, pack.Publisher, "publisher should be absent, not empty")
+		assert.NotEmpty(t, pack.Name)
+	}
+}
+
+// The end-to-end guard against the silent compliance hole: a broken (rather than
+// absent) registry lookup must not quietly yield packs with no publisher, or a
+// program filtering on publisher attaches nothing and still succeeds.
+func TestGetPolicyPacks_FailsWhenRegistryErrors(t *testing.T) {
+	for _, code := range []int{http.StatusForbidden, http.StatusInternalServerError} {
+		ctx := newInvokeTestContext(t, policyPacksHandler(t, orgPackFixtures(), code, nil))
+
+		_, err := invokeGetPolicyPacks(ctx)
+		assert.Errorf(t, err, "registry status %d should fail the invoke", code)
+	}
+}
+
+func TestGetPolicyPacks_UnmatchedPackOmitsFields(t *testing.T) {
+	ctx := newInvokeTestContext(t, policyPacksHandler(t, orgPackFixtures(), http.StatusOK, []map[string]any{
+		{"name": cisAwsPack, "source": testSourcePulumi, "publisher": testSourcePulumi},
+	}))
+
+	packs, err := invokeGetPolicyPacks(ctx)
+	require.NoError(t, err)
+	require.Len(t, packs, 2)
+
+	// Degradation is per pack, not all or nothing.
+	assert.Equal(t, testSourcePulumi, *packs[0].Publisher)
+	assert.Nil(t, packs[1].Publisher)
+}
+
+func invokeGetPolicyPack(ctx context.Context) (GetPolicyPackOutput, error) {
+	resp, err := GetPolicyPackFunction{}.Invoke(ctx, infer.FunctionRequest[GetPolicyPackInput]{
+		Input: GetPolicyPackInput{OrganizationName: invokeTestOrg, PolicyPackName: alphaPack},
+	})
+	return resp.Output, err
+}
+
+func singlePolicyPackHandler(t *testing.T, registryCode int) http.HandlerFunc {
+	t.Helper()
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case latestPolicyPack:
+			writeJSON(t, w, http.StatusOK, map[string]any{
+				"name": alphaPack, "displayName": "Alpha", "version": 2, "versionTag": "0.0.5",
+			})
+		case orgRegistryPath:
+			// The resolved tag must be forwarded. Omitting it makes the real
+			// service look for the literal tag `latest`, which a pack tagged
+			// only `0.0.5` does not have, and provenance silently disappears.
+			assert.Equal(t, "0.0.5", r.URL.Query().Get("tag"),
+				"registry lookup must carry the pack's resolved version tag")
+			if registryCode != http.StatusOK {
+				writeJSON(t, w, registryCode, map[string]any{"message": "nope"})
+				return
+			}
+			writeJSON(t, w, http.StatusOK, map[string]any{
+				"policyPack": map[string]any{
+					"name": alphaPack, "source": testSourcePrivate, "publisher": invokeTestOrg,
+				},
+			})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
+
+func TestGetPolicyPack_IncludesSourceAndPublisher(t *testing.T) {
+	ctx := newInvokeTestContext(t, singlePolicyPackHandler(t, http.StatusOK))
+
+	out, err := invokeGetPolicyPack(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, testSourcePrivate, *out.Source)
+	assert.Equal(t, invokeTestOrg, *out.Publisher)
+	assert.Equal(t, alphaPack, out.Name)
+}
+
+// The single-pack path soft-fails by design: the caller already named the pack,
+// so a failed provenance lookup can't empty a filter, and failing would deny a
+// result the provider could fully produce before this feature.
+func TestGetPolicyPack_SucceedsWhenRegistryFails(t *testing.T) {
+	for _, code := range []int{http.StatusNotFound, http.StatusForbidden} {
+		ctx := newInvokeTestContext(t, singlePolicyPackHandler(t, code))
+
+		out, err := invokeGetPolicyPack(ctx)
+		require.NoErrorf(t, err, "registry status %d should not fail the invoke", code)
+
+		assert.Equal(t, alphaPack, out.Name)
+		assert.Equal(t, 2, out.Version)
+		assert.Nil(t, out.Source)
+		assert.Nil(t, out.Publisher)
+	}
+}
+
+func TestNewPolicyPackSummaries_OmitsEmptyRegistryFields(t *testing.T) {
+	got := newPolicyPackSummaries([]pulumiapi.PolicyPackWithRegistryMetadata{
+		{
+			PolicyPackWithVersions: pulumiapi.PolicyPackWithVersions{Name: cisAwsPack, Versions: []int{1}},
+			Source:                 testSourcePulumi,
+			Publisher:              testSourcePulumi,
+		},
+		{
+			PolicyPackWithVersions: pulumiapi.PolicyPackWithVersions{Name: alphaPack, Versions: []int{1}},
+		},
+	})
+	require.Len(t, got, 2)
+
+	assert.Equal(t, testSourcePulumi, *got[0].Source)
+	assert.Equal(t, testSourcePulumi, *got[0].Publisher)
+
+	assert.Nil(t, got[1].Source)
+	assert.Nil(t, got[1].Publisher)
+}
+
+func TestNewGetPolicyPackOutput_NilRegistryOmitsFields(t *testing.T) {
+	got := newGetPolicyPackOutput(&pulumiapi.PolicyPackDetail{Name: alphaPack, Version: 1}, nil)
+
+	assert.Nil(t, got.Source)
+	assert.Nil(t, got.Publisher)
+	assert.Nil(t, got.VersionTag)
+}
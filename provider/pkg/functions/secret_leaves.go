@@ -0,0 +1,95 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"context"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+)
+
+// infer only marks whole output fields as secrets, through the
+// `provider:"secret"` tag. Functions whose secrets are only known once they
+// ran, such as the leaves of an ESC environment, record them with markSecret
+// and are registered through WithSecretLeaves.
+
+type secretLeavesKey struct{}
+
+// secretLeaves holds the paths, made of map keys and array indices, of the
+// output values to return as secrets.
+type secretLeaves struct {
+	paths [][]any
+}
+
+// WithSecretLeaves wraps fn so that the output values its Invoke marks with
+// markSecret are returned as secrets.
+func WithSecretLeaves(fn infer.InferredFunction) infer.InferredFunction {
+	return secretLeavesFunction{fn}
+}
+
+type secretLeavesFunction struct {
+	infer.InferredFunction
+}
+
+func (f secretLeavesFunction) Invoke(ctx context.Context, req p.InvokeRequest) (p.InvokeResponse, error) {
+	leaves := &secretLeaves{}
+	resp, err := f.InferredFunction.Invoke(context.WithValue(ctx, secretLeavesKey{}, leaves), req)
+	if err != nil {
+		return resp, err
+	}
+	ret := property.New(resp.Return)
+	for _, path := range leaves.paths {
+		ret = makeSecretAt(ret, path)
+	}
+	resp.Return = ret.AsMap()
+	return resp, nil
+}
+
+// markSecret records that the output value at path is a secret. It does
+// nothing unless the function is registered through WithSecretLeaves.
+func markSecret(ctx context.Context, path ...any) {
+	if leaves, ok := ctx.Value(secretLeavesKey{}).(*secretLeaves); ok {
+		leaves.paths = append(leaves.paths, path)
+	}
+}
+
+func makeSecretAt(v property.Value, path []any) property.Value {
+	if len(path) == 0 {
+		return v.WithSecret(true)
+	}
+	switch key := path[0].(type) {
+	case string:
+		if !v.IsMap() {
+			return v
+		}
+		m := v.AsMap()
+		elem, ok := m.GetOk(key)
+		if !ok {
+			return v
+		}
+		return property.New(m.Set(key, makeSecretAt(elem, path[1:]))).WithSecret(v.Secret())
+	case int:
+		if !v.IsArray() || key < 0 || key >= v.AsArray().Len() {
+			return v
+		}
+		elems := v.AsArray().AsSlice()
+		elems[key] = makeSecretAt(elems[key], path[1:])
+		return property.New(elems).WithSecret(v.Secret())
+	default:
+		return v
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/sig"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+// The stack state data sources below read a stack's checkpoint through the
+// provider's own credentials, so the stack may live in another organization
+// and its names may be computed at runtime, unlike a StackReference.
+
+// serviceSecretsProvider is the secrets provider of stacks whose secrets are
+// encrypted with a per-stack key held by Pulumi Cloud, the only kind this
+// provider can decrypt.
+const serviceSecretsProvider = "service"
+
+// GetStackOutputsFunction reads the outputs of a stack.
+type GetStackOutputsFunction struct{}
+
+type GetStackOutputsInput struct {
+	OrganizationName string `pulumi:"organizationName"`
+	ProjectName      string `pulumi:"projectName"`
+	StackName        string `pulumi:"stackName"`
+	Version          *int   `pulumi:"version,optional"`
+}
+
+type GetStackOutputsOutput struct {
+	Outputs       map[string]any `pulumi:"outputs"`
+	SecretOutputs map[string]any `pulumi:"secretOutputs" provider:"secret"`
+}
+
+func (GetStackOutputsFunction) Annotate(a infer.Annotator) {
+	a.Describe(
+		&GetStackOutputsFunction{},
+		"Reads the outputs of a stack as of its latest update, or of a given update version. Outputs holding "+
+			"a secret are decrypted and returned in `secretOutputs`, which is itself a secret; this requires the "+
+			"stack to use the Pulumi Cloud secrets provider.",
+	)
+	a.SetToken("index", "getStackOutputs")
+}
+
+func (i *GetStackOutputsInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.OrganizationName, organizationNameDescription)
+	a.Describe(&i.ProjectName, "The stack's project.")
+	a.Describe(&i.StackName, "The stack's name.")
+	a.Describe(&i.Version, "The update version to read. Defaults to the latest update.")
+}
+
+func (o *GetStackOutputsOutput) Annotate(a infer.Annotator) {
+	a.Describe(&o.Outputs, "The stack's outputs that hold no secret, by name.")
+	a.Describe(&o.SecretOutputs, "The stack's outputs that hold a secret, decrypted, by name.")
+}
+
+func (GetStackOutputsFunction) Invoke(
+	ctx context.Context,
+	req infer.FunctionRequest[GetStackOutputsInput],
+) (infer.FunctionResponse[GetStackOutputsOutput], error) {
+	client := config.GetClient(ctx)
+	stack := pulumiapi.StackIdentifier{
+		OrgName:     req.Input.OrganizationName,
+		ProjectName: req.Input.ProjectName,
+		StackName:   req.Input.StackName,
+	}
+	deployment, err := client.ExportStack(ctx, stack, req.Input.Version)
+	if err != nil {
+		return infer.FunctionResponse[GetStackOutputsOutput]{}, fmt.Errorf("failed to get stack outputs: %w", err)
+	}
+
+	var outputs map[string]any
+	for _, r := range deployment.Resources {
+		if r.Type == resource.RootStackType && r.Parent == "" {
+			outputs = r.Outputs
+			break
+		}
+	}
+
+	ciphertexts := map[string]struct{}{}
+	collectCiphertexts(outputs, ciphertexts)
+	plaintexts := map[string][]byte{}
+	if len(ciphertexts) > 0 {
+		if p := deployment.SecretsProviders; p != nil && p.Type != serviceSecretsProvider {
+			return infer.FunctionResponse[GetStackOutputsOutput]{}, fmt.Errorf(
+				"failed to get stack outputs: stack %q encrypts its secrets with the %q secrets provider; "+
+					"only secrets of stacks using the Pulumi Cloud secrets provider can be read", stack, p.Type)
+		}
+		plaintexts, err = client.DecryptStackValues(ctx, stack, slices.Sorted(maps.Keys(ciphertexts)))
+		if err != nil {
+			return infer.FunctionResponse[GetStackOutputsOutput]{}, fmt.Errorf("failed to get stack outputs: %w", err)
+		}
+	}
+
+	result := GetStackOutputsOutput{Outputs: map[string]any{}, SecretOutputs: map[string]any{}}
+	for name, value := range outputs {
+		revealed, secret, err := revealSecrets(value, plaintexts)
+		if err != nil {
+			return infer.FunctionResponse[GetStackOutputsOutput]{}, fmt.Errorf(
+				"failed to get stack outputs: output %q: %w", name, err)
+		}
+		if secret {
+			result.SecretOutputs[name] = revealed
+		} else {
+			result.Outputs[name] = revealed
+		}
+	}
+	return infer.FunctionResponse[GetStackOutputsOutput]{Output: result}, nil
+}
+
+// secretCiphertext reports whether v is a secret as checkpoints encode them,
+// and returns its ciphertext when it has one.
+func secretCiphertext(v any) (obj map[string]any, ciphertext string, ok bool) {
+	obj, ok = v.(map[string]any)
+	if !ok || obj[sig.Key] != sig.Secret {
+		return nil, "", false
+	}
+	ciphertext, _ = obj["ciphertext"].(string)
+	return obj, ciphertext, true
+}
+
+func collectCiphertexts(v any, into map[string]struct{}) {
+	if _, ciphertext, ok := secretCiphertext(v); ok {
+		if ciphertext != "" {
+			into[ciphertext] = struct{}{}
+		}
+		return
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		for _, e := range v {
+			collectCiphertexts(e, into)
+		}
+	case []any:
+		for _, e := range v {
+			collectCiphertexts(e, into)
+		}
+	}
+}
+
+// revealSecrets replaces every secret in v with its decrypted value and
+// reports whether v held any.
+func revealSecrets(v any, plaintexts map[string][]byte) (any, bool, error) {
+	if obj, ciphertext, ok := secretCiphertext(v); ok {
+		// Checkpoints exported with their secrets shown carry the plaintext.
+		plaintext, shown := obj["plaintext"].(string)
+		if !shown {
+			b, ok := plaintexts[ciphertext]
+			if !ok {
+				return nil, true, fmt.Errorf("secret could not be decrypted")
+			}
+			plaintext = string(b)
+		}
+		var value any
+		if err := json.Unmarshal([]byte(plaintext), &value); err != nil {
+			return nil, true, fmt.Errorf("decoding secret: %w", err)
+		}
+		return value, true, nil
+	}
+
+	var secret bool
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			revealed, s, err := revealSecrets(e, plaintexts)
+			if err != nil {
+				return nil, false, err
+			}
+			out[k], secret = revealed, secret || s
+		}
+		return out, secret, nil
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			revealed, s, err := revealSecrets(e, plaintexts)
+			if err != nil {
+				return nil, false, err
+			}
+			out[i], secret = revealed, secret || s
+		}
+		return out, secret, nil
+	default:
+		return v, false, nil
+	}
+}
+
+// GetStackResourcesFunction lists the resources in a stack.
+type GetStackResourcesFunction struct{}
+
+type GetStackResourcesInput struct {
+	OrganizationName string  `pulumi:"organizationName"`
+	ProjectName      string  `pulumi:"projectName"`
+	StackName        string  `pulumi:"stackName"`
+	Version          *int    `pulumi:"version,optional"`
+	Type             *string `pulumi:"type,optional"`
+	UrnPrefix        *string `pulumi:"urnPrefix,optional"`
+}
+
+type StackResourceInfo struct {
+	Urn      string  `pulumi:"urn"`
+	Type     string  `pulumi:"type"`
+	ID       *string `pulumi:"id,optional"`
+	Parent   *string `pulumi:"parent,optional"`
+	Provider *string `pulumi:"provider,optional"`
+	Custom   bool    `pulumi:"custom"`
+	Protect  bool    `pulumi:"protect"`
+	External bool    `pulumi:"external"`
+}
+
+type GetStackResourcesOutput struct {
+	Resources []StackResourceInfo `pulumi:"resources"`
+}
+
+func (GetStackResourcesFunction) Annotate(a infer.Annotator) {
+	a.Describe(
+		&GetStackResourcesFunction{},
+		"Lists the resources in a stack as of its latest update, or of a given update version, optionally "+
+			"filtered by type or URN prefix. Resource inputs and outputs are not returned.",
+	)
+	a.SetToken("index", "getStackResources")
+}
+
+func (i *GetStackResourcesInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.OrganizationName, organizationNameDescription)
+	a.Describe(&i.ProjectName, "The stack's project.")
+	a.Describe(&i.StackName, "The stack's name.")
+	a.Describe(&i.Version, "The update version to read. Defaults to the latest update.")
+	a.Describe(&i.Type, "Only return resources of this type token, e.g. `aws:s3/bucket:Bucket`.")
+	a.Describe(&i.UrnPrefix, "Only return resources whose URN starts with this prefix.")
+}
+
+func (r *StackResourceInfo) Annotate(a infer.Annotator) {
+	a.Describe(&r.Urn, "The resource's URN.")
+	a.Describe(&r.Type, "The resource's type token.")
+	a.Describe(&r.ID, "The resource's provider ID. Unset for component resources.")
+	a.Describe(&r.Parent, "The URN of the resource's parent, if any.")
+	a.Describe(&r.Provider, "The reference to the provider that manages the resource, if any.")
+	a.Describe(&r.Custom, "Whether the resource is managed by a provider rather than a component.")
+	a.Describe(&r.Protect, "Whether the resource is protected from deletion.")
+	a.Describe(&r.External, "Whether the resource is read from outside the stack rather than managed by it.")
+}
+
+func (GetStackResourcesFunction) Invoke(
+	ctx context.Context,
+	req infer.FunctionRequest[GetStackResourcesInput],
+) (infer.FunctionResponse[GetStackResourcesOutput], error) {
+	stack := pulumiapi.StackIdentifier{
+		OrgName:     req.Input.OrganizationName,
+		ProjectName: req.Input.ProjectName,
+		StackName:   req.Input.StackName,
+	}
+	deployment, err := config.GetClient(ctx).ExportStack(ctx, stack, req.Input.Version)
+	if err != nil {
+		return infer.FunctionResponse[GetStackResourcesOutput]{}, fmt.Errorf("failed to get stack resources: %w", err)
+	}
+
+	out := []StackResourceInfo{}
+	for _, r := range deployment.Resources {
+		// Resources pending deletion are no longer part of the stack.
+		if r.Delete || !stackResourceMatches(r, req.Input) {
+			continue
+		}
+		out = append(out, StackResourceInfo{
+			Urn:      string(r.URN),
+			Type:     string(r.Type),
+			ID:       optionalString(string(r.ID)),
+			Parent:   optionalString(string(r.Parent)),
+			Provider: optionalString(r.Provider),
+			Custom:   r.Custom,
+			Protect:  r.Protect,
+			External: r.External,
+		})
+	}
+	return infer.FunctionResponse[GetStackResourcesOutput]{
+		Output: GetStackResourcesOutput{Resources: out},
+	}, nil
+}
+
+func stackResourceMatches(r apitype.ResourceV3, in GetStackResourcesInput) bool {
+	if in.Type != nil && string(r.Type) != *in.Type {
+		return false
+	}
+	return in.UrnPrefix == nil || strings.HasPrefix(string(r.URN), *in.UrnPrefix)
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
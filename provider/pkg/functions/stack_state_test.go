@@ -0,0 +1,209 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package functions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/sig"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+type stackStateClientMock struct {
+	config.Client
+	deployment  apitype.DeploymentV3
+	plaintexts  map[string][]byte
+	decrypted   []string
+	lastVersion *int
+}
+
+func (c *stackStateClientMock) ExportStack(
+	_ context.Context,
+	_ pulumiapi.StackIdentifier,
+	version *int,
+) (*apitype.DeploymentV3, error) {
+	c.lastVersion = version
+	return &c.deployment, nil
+}
+
+func (c *stackStateClientMock) DecryptStackValues(
+	_ context.Context,
+	_ pulumiapi.StackIdentifier,
+	ciphertexts []string,
+) (map[string][]byte, error) {
+	c.decrypted = ciphertexts
+	return c.plaintexts, nil
+}
+
+func secretValue(ciphertext string) map[string]any {
+	return map[string]any{sig.Key: sig.Secret, "ciphertext": ciphertext}
+}
+
+func stackWithOutputs(provider string, outputs map[string]any) apitype.DeploymentV3 {
+	return apitype.DeploymentV3{
+		SecretsProviders: &apitype.SecretsProvidersV1{Type: provider},
+		Resources: []apitype.ResourceV3{{
+			URN:     "urn:pulumi:dev::proj::pulumi:pulumi:Stack::proj-dev",
+			Type:    "pulumi:pulumi:Stack",
+			Outputs: outputs,
+		}},
+	}
+}
+
+func TestGetStackOutputsFunction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("splits secret outputs", func(t *testing.T) {
+		t.Parallel()
+		version := 3
+		client := &stackStateClientMock{
+			deployment: stackWithOutputs("service", map[string]any{
+				"url":      "https://example.com",
+				"password": secretValue("c1"),
+				"db": map[string]any{
+					"host":  "db.internal",
+					"users": []any{"admin", secretValue("c2")},
+				},
+			}),
+			plaintexts: map[string][]byte{"c1": []byte(`"hunter2"`), "c2": []byte(`{"name":"ci"}`)},
+		}
+		ctx := config.WithMockClient(t.Context(), client)
+
+		resp, err := GetStackOutputsFunction{}.Invoke(ctx, infer.FunctionRequest[GetStackOutputsInput]{
+			Input: GetStackOutputsInput{
+				OrganizationName: testListOrgName, ProjectName: "proj", StackName: "dev", Version: &version,
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &version, client.lastVersion)
+		assert.Equal(t, []string{"c1", "c2"}, client.decrypted)
+		assert.Equal(t, map[string]any{"url": "https://example.com"}, resp.Output.Outputs)
+		assert.Equal(t, map[string]any{
+			"password": "hunter2",
+			"db": map[string]any{
+				"host":  "db.internal",
+				"users": []any{"admin", map[string]any{"name": "ci"}},
+			},
+		}, resp.Output.SecretOutputs)
+	})
+
+	t.Run("skips decryption without secrets", func(t *testing.T) {
+		t.Parallel()
+		client := &stackStateClientMock{
+			deployment: stackWithOutputs("passphrase", map[string]any{"count": float64(2)}),
+		}
+		ctx := config.WithMockClient(t.Context(), client)
+
+		resp, err := GetStackOutputsFunction{}.Invoke(ctx, infer.FunctionRequest[GetStackOutputsInput]{
+			Input: GetStackOutputsInput{OrganizationName: testListOrgName, ProjectName: "proj", StackName: "dev"},
+		})
+		require.NoError(t, err)
+		assert.Nil(t, client.decrypted)
+		assert.Equal(t, map[string]any{"count": float64(2)}, resp.Output.Outputs)
+		assert.Empty(t, resp.Output.SecretOutputs)
+	})
+
+	t.Run("rejects secrets of other providers", func(t *testing.T) {
+		t.Parallel()
+		client := &stackStateClientMock{
+			deployment: stackWithOutputs("passphrase", map[string]any{"password": secretValue("c1")}),
+		}
+		ctx := config.WithMockClient(t.Context(), client)
+
+		_, err := GetStackOutputsFunction{}.Invoke(ctx, infer.FunctionRequest[GetStackOutputsInput]{
+			Input: GetStackOutputsInput{OrganizationName: testListOrgName, ProjectName: "proj", StackName: "dev"},
+		})
+		assert.ErrorContains(t, err, `"passphrase" secrets provider`)
+		assert.Nil(t, client.decrypted)
+	})
+}
+
+func TestGetStackResourcesFunction(t *testing.T) {
+	t.Parallel()
+
+	client := &stackStateClientMock{
+		deployment: apitype.DeploymentV3{Resources: []apitype.ResourceV3{
+			{URN: "urn:pulumi:dev::proj::pulumi:pulumi:Stack::proj-dev", Type: "pulumi:pulumi:Stack"},
+			{
+				URN:      "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::logs",
+				Type:     "aws:s3/bucket:Bucket",
+				ID:       "logs-1234",
+				Parent:   "urn:pulumi:dev::proj::pulumi:pulumi:Stack::proj-dev",
+				Provider: "urn:pulumi:dev::proj::pulumi:providers:aws::default::abc",
+				Custom:   true,
+				Protect:  true,
+			},
+			{
+				URN:    "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::old",
+				Type:   "aws:s3/bucket:Bucket",
+				ID:     "old-1234",
+				Custom: true,
+				Delete: true,
+			},
+			{
+				URN:    "urn:pulumi:dev::proj::my:index:Component$aws:s3/bucket:Bucket::assets",
+				Type:   "aws:s3/bucket:Bucket",
+				ID:     "assets-1234",
+				Custom: true,
+			},
+		}},
+	}
+	ctx := config.WithMockClient(t.Context(), client)
+	invoke := func(t *testing.T, typ, urnPrefix *string) []StackResourceInfo {
+		resp, err := GetStackResourcesFunction{}.Invoke(ctx, infer.FunctionRequest[GetStackResourcesInput]{
+			Input: GetStackResourcesInput{
+				OrganizationName: testListOrgName, ProjectName: "proj", StackName: "dev",
+				Type: typ, UrnPrefix: urnPrefix,
+			},
+		})
+		require.NoError(t, err)
+		return resp.Output.Resources
+	}
+
+	t.Run("lists live resources", func(t *testing.T) {
+		t.Parallel()
+		resources := invoke(t, nil, nil)
+		require.Len(t, resources, 3)
+		assert.Nil(t, resources[0].ID)
+		assert.Equal(t, StackResourceInfo{
+			Urn:      "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::logs",
+			Type:     "aws:s3/bucket:Bucket",
+			ID:       optionalString("logs-1234"),
+			Parent:   optionalString("urn:pulumi:dev::proj::pulumi:pulumi:Stack::proj-dev"),
+			Provider: optionalString("urn:pulumi:dev::proj::pulumi:providers:aws::default::abc"),
+			Custom:   true,
+			Protect:  true,
+		}, resources[1])
+	})
+
+	t.Run("filters by type and URN prefix", func(t *testing.T) {
+		t.Parallel()
+		typ := "aws:s3/bucket:Bucket"
+		assert.Len(t, invoke(t, &typ, nil), 2)
+
+		prefix := "urn:pulumi:dev::proj::my:index:Component$"
+		resources := invoke(t, &typ, &prefix)
+		require.Len(t, resources, 1)
+		assert.Equal(t, "assets-1234", *resources[0].ID)
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package poll waits for asynchronous Pulumi Cloud operations (scans,
+// deployments, rotations, exports) to reach a terminal status. It is shared
+// by the spec-driven rest runtime and the hand-written infer resources so
+// both surfaces wait, time out and report failures the same way.
+package poll
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultInterval is the delay between two status reads.
+	DefaultInterval = 2 * time.Second
+	// DefaultTimeout bounds the whole wait when the caller sets none.
+	DefaultTimeout = 10 * time.Minute
+)
+
+// Options controls how often and for how long Status polls. A zero Interval
+// falls back to DefaultInterval. A zero Timeout waits until ctx's deadline
+// (an operation's customTimeouts) when it has one, DefaultTimeout otherwise.
+type Options struct {
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
+// States classifies status values. A status in Success or Failure is
+// terminal; anything else, including the empty string, is still pending.
+// Comparison is case-insensitive, since the service isn't consistent about
+// casing across endpoints.
+type States struct {
+	Success []string
+	Failure []string
+}
+
+func (s States) succeeded(status string) bool { return containsFold(s.Success, status) }
+func (s States) failed(status string) bool    { return containsFold(s.Failure, status) }
+
+func containsFold(values []string, status string) bool {
+	return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, status) })
+}
+
+// FailedError reports an operation that reached a failure status.
+type FailedError struct {
+	Status string
+}
+
+func (e *FailedError) Error() string {
+	return fmt.Sprintf("operation finished with status %q", e.Status)
+}
+
+// TimeoutError reports an operation still pending when the wait ran out.
+// Last is the most recent status observed, empty if none was.
+type TimeoutError struct {
+	Timeout time.Duration
+	Last    string
+}
+
+func (e *TimeoutError) Error() string {
+	if e.Last == "" {
+		return fmt.Sprintf("timed out after %s waiting for the operation to finish", e.Timeout)
+	}
+	return fmt.Sprintf("timed out after %s waiting for the operation to finish (last status %q)", e.Timeout, e.Last)
+}
+
+// Status calls fetch until it returns a terminal status and returns that
+// status. A failure status yields a *FailedError, running out of time a
+// *TimeoutError; an error from fetch or a canceled ctx stops the wait
+// immediately.
+func Status(
+	ctx context.Context, opts Options, states States, fetch func(context.Context) (string, error),
+) (string, error) {
+	interval, timeout := opts.Interval, opts.Timeout
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if _, bounded := ctx.Deadline(); timeout <= 0 && !bounded {
+		timeout = DefaultTimeout
+	}
+	// Without a deadline of its own, the wait ends with ctx, and its cause.
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	var last string
+	for {
+		status, err := fetch(ctx)
+		if err != nil {
+			return last, err
+		}
+		last = status
+		switch {
+		case states.succeeded(status):
+			return status, nil
+		case states.failed(status):
+			return status, &FailedError{Status: status}
+		}
+
+		wait := interval
+		if !deadline.IsZero() {
+			left := time.Until(deadline)
+			if left <= 0 {
+				return last, &TimeoutError{Timeout: timeout, Last: last}
+			}
+			wait = min(wait, left)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return last, context.Cause(ctx)
+		case <-timer.C:
+		}
+	}
+}
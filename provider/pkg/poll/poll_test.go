@@ -0,0 +1,90 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poll
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var scanStates = States{Success: []string{"succeeded"}, Failure: []string{"failed"}}
+
+// sequence returns a fetch func that yields statuses in order, repeating
+// the last one once they run out.
+func sequence(statuses ...string) (func(context.Context) (string, error), *int) {
+	calls := 0
+	return func(context.Context) (string, error) {
+		s := statuses[min(calls, len(statuses)-1)]
+		calls++
+		return s, nil
+	}, &calls
+}
+
+func TestStatus(t *testing.T) {
+	fast := Options{Interval: time.Millisecond, Timeout: time.Second}
+
+	t.Run("waits through pending statuses", func(t *testing.T) {
+		fetch, calls := sequence("", "running", "Succeeded")
+		status, err := Status(t.Context(), fast, scanStates, fetch)
+		require.NoError(t, err)
+		assert.Equal(t, "Succeeded", status)
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("failure status", func(t *testing.T) {
+		fetch, _ := sequence("running", "failed")
+		_, err := Status(t.Context(), fast, scanStates, fetch)
+		var failed *FailedError
+		require.ErrorAs(t, err, &failed)
+		assert.Equal(t, "failed", failed.Status)
+	})
+
+	t.Run("timeout keeps the last status", func(t *testing.T) {
+		fetch, _ := sequence("running")
+		_, err := Status(t.Context(), Options{Interval: time.Millisecond, Timeout: 20 * time.Millisecond},
+			scanStates, fetch)
+		var timeout *TimeoutError
+		require.ErrorAs(t, err, &timeout)
+		assert.Equal(t, "running", timeout.Last)
+	})
+
+	t.Run("fetch error stops the wait", func(t *testing.T) {
+		boom := errors.New("boom")
+		_, err := Status(t.Context(), fast, scanStates, func(context.Context) (string, error) { return "", boom })
+		assert.ErrorIs(t, err, boom)
+	})
+
+	t.Run("a context deadline replaces the default timeout", func(t *testing.T) {
+		cause := errors.New("create timed out")
+		ctx, cancel := context.WithTimeoutCause(t.Context(), 20*time.Millisecond, cause)
+		defer cancel()
+		fetch, _ := sequence("running")
+		_, err := Status(ctx, Options{Interval: time.Millisecond}, scanStates, fetch)
+		assert.ErrorIs(t, err, cause)
+	})
+
+	t.Run("cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		fetch, _ := sequence("running")
+		_, err := Status(ctx, Options{Interval: time.Hour, Timeout: time.Hour}, scanStates, fetch)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,53 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"errors"
+)
+
+// errProviderCanceled is the cancellation cause of every context attached to
+// a canceled cancelScope.
+var errProviderCanceled = errors.New("provider canceled by the engine")
+
+// cancelScope is the provider-wide cancellation context. The engine's Cancel
+// RPC closes it; every RPC context is attached to it, so in-flight Pulumi
+// Cloud calls abort and calls that arrive afterwards fail before reaching
+// the network.
+type cancelScope struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+}
+
+func newCancelScope() *cancelScope {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	return &cancelScope{ctx: ctx, cancel: cancel}
+}
+
+// Cancel cancels every attached context. Safe to call more than once.
+func (s *cancelScope) Cancel() {
+	s.cancel(errProviderCanceled)
+}
+
+// attach returns a child of ctx that is also canceled when the scope is. The
+// link is dropped as soon as the child is done, so finished RPCs don't
+// accumulate on the long-lived scope.
+func (s *cancelScope) attach(ctx context.Context) context.Context {
+	ctx, cancel := context.WithCancelCause(ctx)
+	stop := context.AfterFunc(s.ctx, func() { cancel(context.Cause(s.ctx)) })
+	context.AfterFunc(ctx, func() { stop() })
+	return ctx
+}
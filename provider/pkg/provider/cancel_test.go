@@ -0,0 +1,69 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelScope(t *testing.T) {
+	t.Run("cancels attached contexts", func(t *testing.T) {
+		scope := newCancelScope()
+		inFlight := scope.attach(t.Context())
+		require.NoError(t, inFlight.Err())
+
+		scope.Cancel()
+
+		<-inFlight.Done()
+		assert.ErrorIs(t, context.Cause(inFlight), errProviderCanceled)
+	})
+
+	t.Run("contexts attached after Cancel start canceled", func(t *testing.T) {
+		scope := newCancelScope()
+		scope.Cancel()
+		scope.Cancel() // idempotent
+
+		late := scope.attach(t.Context())
+		<-late.Done()
+		assert.ErrorIs(t, context.Cause(late), errProviderCanceled)
+	})
+
+	t.Run("does not outlive the parent", func(t *testing.T) {
+		scope := newCancelScope()
+		parent, cancel := context.WithCancel(t.Context())
+		ctx := scope.attach(parent)
+		cancel()
+
+		<-ctx.Done()
+		assert.ErrorIs(t, context.Cause(ctx), context.Canceled)
+		require.NoError(t, scope.ctx.Err(), "a finished RPC must not cancel the provider")
+	})
+}
+
+// TestCancelTriggersScope covers the Cancel RPC, which MakeProvider's
+// middleware chain delivers to the base provider.
+func TestCancelTriggersScope(t *testing.T) {
+	k := &pulumiserviceProvider{transportRef: &atomic.Value{}, cancel: newCancelScope()}
+	prov, err := k.provider("pulumiservice", "1.0.0")
+	require.NoError(t, err)
+
+	require.NoError(t, prov.Cancel(t.Context()))
+	assert.ErrorIs(t, context.Cause(k.cancel.ctx), errProviderCanceled)
+}
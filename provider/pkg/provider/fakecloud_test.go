@@ -0,0 +1,212 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/fakecloud"
+)
+
+const fakeOrg = "fake-org"
+
+// newFakeCloudProvider returns a provider configured against a fresh
+// fakecloud server, along with the server for seeding and inspection.
+func newFakeCloudProvider(t *testing.T) (p.Provider, *fakecloud.Server) {
+	t.Helper()
+	t.Setenv(config.EnvVarPulumiAccessToken, "")
+	server, url := fakecloud.Start(t)
+
+	k := &pulumiserviceProvider{transportRef: &atomic.Value{}, cancel: newCancelScope()}
+	prov, err := k.provider("pulumiservice", "1.0.0")
+	require.NoError(t, err)
+	require.NoError(t, prov.Configure(t.Context(), p.ConfigureRequest{
+		Args: property.NewMap(map[string]property.Value{
+			"accessToken": property.New("pul-fake").WithSecret(true),
+			"apiUrl":      property.New(url),
+		}),
+	}))
+	return prov, server
+}
+
+func fakeURN(typ, name string) resource.URN {
+	return resource.NewURN("test", "fakecloud", "", tokens.Type(typ), name)
+}
+
+func stringList(values ...string) property.Value {
+	arr := make([]property.Value, 0, len(values))
+	for _, v := range values {
+		arr = append(arr, property.New(v))
+	}
+	return property.New(arr)
+}
+
+// TestFakeCloudTeamLifecycle drives an infer resource through create,
+// import, update and delete against the fake, with nothing mocked.
+func TestFakeCloudTeamLifecycle(t *testing.T) {
+	prov, server := newFakeCloudProvider(t)
+	ctx := t.Context()
+	urn := fakeURN("pulumiservice:index:Team", "platform")
+
+	inputs := property.NewMap(map[string]property.Value{
+		"organizationName": property.New(fakeOrg),
+		"teamType":         property.New("pulumi"),
+		"name":             property.New("platform"),
+		"displayName":      property.New("Platform"),
+		"description":      property.New("first"),
+		"members":          stringList("alice"),
+	})
+	created, err := prov.Create(ctx, p.CreateRequest{Urn: urn, Properties: inputs})
+	require.NoError(t, err)
+	assert.Equal(t, fakeOrg+"/platform", created.ID)
+	assert.Equal(t, stringList("alice"), created.Properties.Get("members"))
+
+	// A read with only the ID is what `pulumi import` does.
+	imported, err := prov.Read(ctx, p.ReadRequest{ID: created.ID, Urn: urn})
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, imported.ID)
+	assert.Equal(t, "first", imported.Inputs.Get("description").AsString())
+	assert.Equal(t, "pulumi", imported.Properties.Get("teamType").AsString())
+
+	newInputs := inputs.Set("description", property.New("second")).Set("members", stringList("bob"))
+	updated, err := prov.Update(ctx, p.UpdateRequest{
+		ID: created.ID, Urn: urn, State: created.Properties, OldInputs: inputs, Inputs: newInputs,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, stringList("bob"), updated.Properties.Get("members"))
+
+	refreshed, err := prov.Read(ctx, p.ReadRequest{
+		ID: created.ID, Urn: urn, Properties: updated.Properties, Inputs: newInputs,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "second", refreshed.Properties.Get("description").AsString())
+	assert.Equal(t, stringList("bob"), refreshed.Properties.Get("members"))
+
+	require.NoError(t, prov.Delete(ctx, p.DeleteRequest{ID: created.ID, Urn: urn, Properties: updated.Properties}))
+	_, ok := server.Get("/api/orgs/" + fakeOrg + "/teams/platform")
+	assert.False(t, ok)
+
+	gone, err := prov.Read(ctx, p.ReadRequest{ID: created.ID, Urn: urn, Properties: updated.Properties})
+	require.NoError(t, err)
+	assert.Empty(t, gone.ID, "a deleted team reads as gone")
+}
+
+// TestFakeCloudStackTag covers a resource attached to state seeded directly
+// into the fake.
+func TestFakeCloudStackTag(t *testing.T) {
+	prov, server := newFakeCloudProvider(t)
+	ctx := t.Context()
+	server.Put("/api/stacks/"+fakeOrg+"/proj/dev", map[string]any{
+		"orgName": fakeOrg, "projectName": "proj", "stackName": "dev",
+	})
+	urn := fakeURN("pulumiservice:index:StackTag", "owner")
+
+	inputs := property.NewMap(map[string]property.Value{
+		"organization": property.New(fakeOrg),
+		"project":      property.New("proj"),
+		"stack":        property.New("dev"),
+		"name":         property.New("owner"),
+		"value":        property.New("platform"),
+	})
+	created, err := prov.Create(ctx, p.CreateRequest{Urn: urn, Properties: inputs})
+	require.NoError(t, err)
+
+	read, err := prov.Read(ctx, p.ReadRequest{ID: created.ID, Urn: urn})
+	require.NoError(t, err)
+	assert.Equal(t, "platform", read.Properties.Get("value").AsString())
+
+	require.NoError(t, prov.Delete(ctx, p.DeleteRequest{ID: created.ID, Urn: urn, Properties: created.Properties}))
+	read, err = prov.Read(ctx, p.ReadRequest{ID: created.ID, Urn: urn})
+	require.NoError(t, err)
+	assert.Empty(t, read.ID)
+}
+
+// TestFakeCloudAgentPoolLifecycle runs a spec-driven api resource through
+// the same fake.
+func TestFakeCloudAgentPoolLifecycle(t *testing.T) {
+	prov, server := newFakeCloudProvider(t)
+	ctx := t.Context()
+	urn := fakeURN("pulumiservice:api/agents:Pool", "runners")
+
+	inputs := property.NewMap(map[string]property.Value{
+		"orgName":     property.New(fakeOrg),
+		"name":        property.New("runners"),
+		"description": property.New("first"),
+	})
+	created, err := prov.Create(ctx, p.CreateRequest{Urn: urn, Properties: inputs})
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.Properties.Get("tokenValue").AsString())
+
+	newInputs := inputs.Set("description", property.New("second"))
+	updated, err := prov.Update(ctx, p.UpdateRequest{
+		ID: created.ID, Urn: urn, State: created.Properties, OldInputs: inputs, Inputs: newInputs,
+	})
+	require.NoError(t, err)
+
+	read, err := prov.Read(ctx, p.ReadRequest{ID: created.ID, Urn: urn, Properties: updated.Properties, Inputs: newInputs})
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, read.ID)
+	assert.Equal(t, "second", read.Properties.Get("description").AsString())
+
+	require.NoError(t, prov.Delete(ctx, p.DeleteRequest{
+		ID: created.ID, Urn: urn, Properties: updated.Properties, OldInputs: newInputs,
+	}))
+	_, ok := server.Get("/api/orgs/" + fakeOrg + "/agent-pools/" + strings.TrimPrefix(created.ID, fakeOrg+"/"))
+	assert.False(t, ok)
+}
+
+// TestFakeCloudEnvironmentLifecycle covers the ESC client's revisioned,
+// YAML-bodied routes.
+func TestFakeCloudEnvironmentLifecycle(t *testing.T) {
+	prov, _ := newFakeCloudProvider(t)
+	ctx := t.Context()
+	urn := fakeURN("pulumiservice:index:Environment", "env")
+
+	inputs := property.NewMap(map[string]property.Value{
+		"organization": property.New(fakeOrg),
+		"project":      property.New("proj"),
+		"name":         property.New("env"),
+		"yaml":         property.New("values:\n  greeting: hello\n"),
+	})
+	created, err := prov.Create(ctx, p.CreateRequest{Urn: urn, Properties: inputs})
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, created.Properties.Get("revision").AsNumber())
+
+	newInputs := inputs.Set("yaml", property.New("values:\n  greeting: hi\n"))
+	updated, err := prov.Update(ctx, p.UpdateRequest{
+		ID: created.ID, Urn: urn, State: created.Properties, OldInputs: inputs, Inputs: newInputs,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, updated.Properties.Get("revision").AsNumber())
+
+	read, err := prov.Read(ctx, p.ReadRequest{ID: created.ID, Urn: urn})
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, read.ID)
+	assert.Contains(t, fmt.Sprint(read.Properties.Get("yaml")), "greeting: hi")
+
+	require.NoError(t, prov.Delete(ctx, p.DeleteRequest{ID: created.ID, Urn: urn, Properties: updated.Properties}))
+}
@@ -20,30 +20,19 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"runtime"
+	"slices"
 	"sync/atomic"
-	"time"
 
-	_ "embed" // For manualSchema.
+	_ "embed" // For readme.
 
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-	pbempty "google.golang.org/protobuf/types/known/emptypb"
-
-	esc_client "github.com/pulumi/esc/cmd/esc/cli/client"
-	"github.com/pulumi/esc/cmd/esc/cli/version"
 	p "github.com/pulumi/pulumi-go-provider"
 	"github.com/pulumi/pulumi-go-provider/infer"
 	mw "github.com/pulumi/pulumi-go-provider/middleware"
 	ctxmw "github.com/pulumi/pulumi-go-provider/middleware/context"
 	"github.com/pulumi/pulumi-go-provider/middleware/dispatch"
-	"github.com/pulumi/pulumi-go-provider/middleware/rpc"
 	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/provider"
 	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
 
@@ -59,101 +48,130 @@ import (
 const (
 	respectSchemaVersionKey = "respectSchemaVersion"
 	readmeKey               = "readme"
-	nameKey                 = "name"
-	displayNameKey          = "displayName"
-	versionKey              = "version"
-	versionsKey             = "versions"
-	versionTagsKey          = "versionTags"
-	policyPacksKey          = "policyPacks"
-	sourceKey               = "source"
-	publisherKey            = "publisher"
 )
 
 //go:embed README.md
 var readme string
 
-type PulumiServiceResource interface {
-	Diff(req *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error)
-	Create(req *pulumirpc.CreateRequest) (*pulumirpc.CreateResponse, error)
-	Delete(req *pulumirpc.DeleteRequest) (*pbempty.Empty, error)
-	Check(req *pulumirpc.CheckRequest) (*pulumirpc.CheckResponse, error)
-	Update(req *pulumirpc.UpdateRequest) (*pulumirpc.UpdateResponse, error)
-	Read(req *pulumirpc.ReadRequest) (*pulumirpc.ReadResponse, error)
-	Name() string
-}
-
+// pulumiserviceProvider holds the state shared by the layers MakeProvider
+// composes.
 type pulumiserviceProvider struct {
-	pulumirpc.UnimplementedResourceProviderServer
-
-	host            *provider.HostClient
-	name            string
-	version         string
-	schema          string
-	pulumiResources []PulumiServiceResource
-	AccessToken     string
-	client          *pulumiapi.Client
 	// transportRef is the per-provider api transport, populated during
 	// Configure and consumed by the ctxmw.Wrap layer to attach this
 	// provider's transport to every CRUD context — keeps multi-provider
 	// instances from racing on a package-global.
 	transportRef *atomic.Value
+	// cancel is the provider-wide cancellation scope; Cancel triggers it.
+	cancel *cancelScope
 }
 
-// embed manual-schema.json directly into resource binary so that we can properly serve the schema
-// directly from the resource provider.
+// MakeProvider builds the unified Pulumi Cloud Provider. Two layers:
 //
-//go:embed manual-schema.json
-var manualSchema string
-
-// MakeProvider builds the unified Pulumi Cloud Provider. Three layers:
-//
-//  1. legacyRaw — the existing custom gRPC server (pulumiserviceProvider)
-//     handling resources defined in manual-schema.json.
-//  2. dispatch.Wrap — overlays metadata-driven api resources from
-//     provider/pkg/cloud/metadata.json. Schema for these is spliced into
+//  1. dispatch.Wrap — serves metadata-driven api resources and functions
+//     from provider/pkg/cloud/metadata.json. Schema for these is spliced into
 //     GetSchema responses by withCloudApiSchema.
-//  3. infer.NewProviderBuilder — adds modern infer-style resources
-//     (Team, OrganizationRole, etc.) at pulumiservice:index:* and stamps
-//     in package-level metadata (display name, language map, config schema).
+//  2. infer.NewProviderBuilder — serves the pulumiservice:index:* resources
+//     and functions and stamps in package-level metadata (display name,
+//     language map, config schema).
 //
-// Existing user code keeps working unchanged: pulumiservice:index:* tokens
-// resolve through layers 1 and 3; the new api resources at
-// pulumiservice:api:* resolve through layer 2.
+// Every RPC context across both layers is attached to a provider-wide
+// cancelScope, which the engine's Cancel call closes, and Create, Update and
+// Delete contexts carry the deadline of the resource's customTimeouts.
 func MakeProvider(host *provider.HostClient, name, version string) (pulumirpc.ResourceProviderServer, error) {
-	transportRef := &atomic.Value{}
-	legacyRaw := rpc.Provider(&pulumiserviceProvider{
-		host:         host,
-		name:         name,
-		schema:       mustSetSchemaVersion(manualSchema, version),
-		version:      version,
-		transportRef: transportRef,
+	k := &pulumiserviceProvider{
+		transportRef: &atomic.Value{},
+		cancel:       newCancelScope(),
+	}
+	prov, err := k.provider(name, version)
+	if err != nil {
+		return nil, err
+	}
+	return p.RawServer(name, version, prov)(host)
+}
+
+// base is the innermost provider: it serves a bare schema for the layers
+// above to merge into, builds the api transport from the configuration infer
+// has already decoded, and closes the cancellation scope.
+func (k *pulumiserviceProvider) base(name, version string) p.Provider {
+	return p.Provider{
+		GetSchema: func(context.Context, p.GetSchemaRequest) (p.GetSchemaResponse, error) {
+			out, err := json.Marshal(schema.PackageSpec{Name: name, Version: version})
+			if err != nil {
+				return p.GetSchemaResponse{}, err
+			}
+			return p.GetSchemaResponse{Schema: string(out)}, nil
+		},
+		Configure: k.configure,
+		Cancel: func(context.Context) error {
+			k.cancel.Cancel()
+			return nil
+		},
+	}
+}
+
+// configure runs after infer has configured config.Config and points the
+// api layer at the same endpoint and credentials.
+func (k *pulumiserviceProvider) configure(ctx context.Context, _ p.ConfigureRequest) error {
+	cfg := infer.GetConfig[config.Config](ctx)
+	transport := rest.Transport(&authedTransport{
+		baseURL: cfg.APIURL,
+		token:   cfg.AccessToken,
+		client:  &http.Client{},
+		retry:   cfg.RetryPolicy(),
 	})
+	// Two paths: ctxmw.Wrap (set in provider) picks this up and attaches it
+	// to every CRUD context; SetTransportResolver remains as the legacy
+	// global so any code path that bypasses the middleware still resolves a
+	// transport.
+	k.transportRef.Store(transport)
+	rest.SetTransportResolver(func(_ context.Context) (rest.Transport, error) {
+		return transport, nil
+	})
+	return nil
+}
 
+func (k *pulumiserviceProvider) provider(name, version string) (p.Provider, error) {
 	customs := map[tokens.Type]mw.CustomResource{}
 	for tok, h := range rest.Resources(cloud.Spec(), cloud.Metadata()) {
 		customs[tokens.Type(tok)] = h
 	}
-	composed := dispatch.Wrap(legacyRaw, dispatch.Options{Customs: customs})
+	invokes := map[tokens.Type]mw.Invoke{}
+	for tok, h := range rest.Functions(cloud.Spec(), cloud.Metadata()) {
+		invokes[tokens.Type(tok)] = h
+	}
+	composed := dispatch.Wrap(k.base(name, version), dispatch.Options{Customs: customs, Invokes: invokes})
 	composed = withCloudApiSchema(composed, cloud.Spec(), cloud.Metadata(), name)
 	// Attach this provider's transport to every CRUD context. Pairs with
-	// pulumiserviceProvider.Configure storing into transportRef.
+	// configure storing into transportRef.
 	composed = ctxmw.Wrap(composed, func(ctx context.Context) context.Context {
-		if v := transportRef.Load(); v != nil {
+		if v := k.transportRef.Load(); v != nil {
 			return rest.WithTransport(ctx, v.(rest.Transport))
 		}
 		return ctx
 	})
 
-	provider, err := infer.NewProviderBuilder().
+	prov, err := infer.NewProviderBuilder().
 		WithDisplayName("Pulumi Cloud").
+		WithDescription("A native Pulumi package for creating and managing Pulumi Cloud constructs.").
+		WithHomepage("https://pulumi.com").
+		WithRepository("https://github.com/pulumi/pulumi-pulumiservice").
+		WithKeywords("pulumi", "kind/native", "category/infrastructure").
+		WithLicense("Apache-2.0").
+		WithPublisher("Pulumi").
 		WithNamespace("pulumi").
 		WithWrapped(composed).
 		WithResources(
 			infer.Resource(&resources.AccessToken{}),
 			infer.Resource(&resources.AgentPool{}),
 			infer.Resource(&resources.ApprovalRule{}),
+			infer.Resource(&resources.Deployment{}),
 			infer.Resource(&resources.DeploymentSchedule{}),
+			infer.Resource(&resources.DeploymentSettings{}),
+			infer.Resource(&resources.DeploymentsPause{}),
 			infer.Resource(&resources.DriftSchedule{}),
+			infer.Resource(&resources.Environment{}),
+			infer.Resource(&resources.EnvironmentRevisionRetraction{}),
+			infer.Resource(&resources.EnvironmentRotation{}),
 			infer.Resource(&resources.EnvironmentRotationSchedule{}),
 			infer.Resource(&resources.EnvironmentVersionTag{}),
 			infer.Resource(&resources.InsightsAccount{}),
@@ -161,6 +179,7 @@ func MakeProvider(host *provider.HostClient, name, version string) (pulumirpc.Re
 			infer.Resource(&resources.OrgAccessToken{}),
 			infer.Resource(&resources.OrganizationMember{}),
 			infer.Resource(&resources.OrganizationRole{}),
+			infer.Resource(&resources.PolicyGroup{}),
 			infer.Resource(&resources.PolicyPack{}),
 			infer.Resource(&resources.Stack{}),
 			infer.Resource(&resources.StackTag{}),
@@ -169,9 +188,11 @@ func MakeProvider(host *provider.HostClient, name, version string) (pulumirpc.Re
 			infer.Resource(&resources.Team{}),
 			infer.Resource(&resources.TeamAccessToken{}),
 			infer.Resource(&resources.TeamEnvironmentPermission{}),
+			infer.Resource(&resources.TeamMember{}),
 			infer.Resource(&resources.TeamRoleAssignment{}),
 			infer.Resource(&resources.TeamStackPermission{}),
 			infer.Resource(&resources.TemplateSource{}),
+			infer.Resource(&resources.TokenHygienePolicy{}),
 			infer.Resource(&resources.Webhook{}),
 		).
 		WithFunctions(
@@ -179,13 +200,27 @@ func MakeProvider(host *provider.HostClient, name, version string) (pulumirpc.Re
 			infer.Function(&functions.BuildEnvironmentScopedPermissionsFunction{}),
 			infer.Function(&functions.BuildInsightsAccountScopedPermissionsFunction{}),
 			infer.Function(&functions.BuildStackScopedPermissionsFunction{}),
+			infer.Function(&functions.GetAccessTokensFunction{}),
+			infer.Function(&functions.GetAgentPoolsFunction{}),
 			infer.Function(&functions.GetCurrentUserFunction{}),
 			infer.Function(&functions.GetEnvironmentFunction{}),
+			infer.Function(&functions.GetEnvironmentRotationHistoryFunction{}),
+			infer.Function(&functions.GetEnvironmentsFunction{}),
 			infer.Function(&functions.GetInsightsAccountFunction{}),
 			infer.Function(&functions.GetInsightsAccountsFunction{}),
+			infer.Function(&functions.GetOidcIssuersFunction{}),
+			infer.Function(&functions.GetOrgTokensFunction{}),
 			infer.Function(&functions.GetOrganizationMemberFunction{}),
 			infer.Function(&functions.GetOrganizationMembersFunction{}),
 			infer.Function(&functions.GetOrganizationRoleScopesFunction{}),
+			infer.Function(&functions.GetPolicyPackFunction{}),
+			infer.Function(&functions.GetPolicyPacksFunction{}),
+			infer.Function(&functions.GetStackOutputsFunction{}),
+			infer.Function(&functions.GetStackResourcesFunction{}),
+			infer.Function(&functions.GetStacksFunction{}),
+			infer.Function(&functions.GetTeamsFunction{}),
+			infer.Function(&functions.GetWebhooksFunction{}),
+			functions.WithSecretLeaves(infer.Function(&functions.OpenEnvironmentFunction{})),
 		).
 		WithModuleMap(map[tokens.ModuleName]tokens.ModuleName{
 			"resources": "index",
@@ -246,18 +281,25 @@ func MakeProvider(host *provider.HostClient, name, version string) (pulumirpc.Re
 			},
 		}).Build()
 	if err != nil {
-		return nil, err
+		return p.Provider{}, err
 	}
-	return p.RawServer(name, version, provider)(host)
+	prov = withEnvironmentSchema(prov)
+	prov = withRawInputs(prov)
+	prov = withOperationTimeouts(prov)
+	// Outermost, so every RPC — api and infer alike — runs under the
+	// provider-wide cancellation scope, including calls that arrive after
+	// Cancel.
+	return ctxmw.Wrap(prov, k.cancel.attach), nil
 }
 
 type authedTransport struct {
 	baseURL string
 	token   string
 	client  *http.Client
+	retry   pulumiapi.RetryPolicy
 }
 
-func (t *authedTransport) Do(_ context.Context, req *http.Request) (*http.Response, error) {
+func (t *authedTransport) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
 	base, err := url.Parse(t.baseURL)
 	if err != nil {
 		return nil, fmt.Errorf("authedTransport: parse base URL %q: %w", t.baseURL, err)
@@ -276,9 +318,15 @@ func (t *authedTransport) Do(_ context.Context, req *http.Request) (*http.Respon
 	}
 	req.Header.Set("X-Pulumi-Source", "provider")
 
+	// rest.Resource flags POSTs its metadata declared retry-safe; translate
+	// that into the shared retry layer's opt-in.
+	if rest.RetryOptIn(ctx) {
+		req = req.WithContext(pulumiapi.WithRetryableRequest(req.Context()))
+	}
+
 	// Request host is pinned to the operator-configured Pulumi API base URL
 	// (authedTransport.baseURL), not attacker-controlled per-request input.
-	return t.client.Do(req) //nolint:gosec // G704: host pinned to configured API base URL, not request input
+	return t.retry.Do(req, t.client.Do) //nolint:gosec // G704: host pinned to configured API base URL, not request input
 }
 
 func withCloudApiSchema(prov p.Provider, spec *rest.Spec, metadata *rest.Metadata, pkg string) p.Provider {
@@ -334,538 +382,64 @@ func mergeSpec(dst, src *schema.PackageSpec) {
 	}
 }
 
-// Attach implements pulumirpc.ResourceProviderServer
-func (k *pulumiserviceProvider) Attach(_ context.Context, req *pulumirpc.PluginAttach) (*pbempty.Empty, error) {
-	host, err := provider.NewHostClient(req.Address)
-	if err != nil {
-		return nil, err
-	}
-	k.host = host
-	return &pbempty.Empty{}, nil
-}
-
-// Construct creates a new component resource.
-func (k *pulumiserviceProvider) Construct(
-	_ context.Context,
-	_ *pulumirpc.ConstructRequest,
-) (*pulumirpc.ConstructResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "Construct is not yet implemented")
-}
-
-// CheckConfig validates the configuration for this provider.
-func (k *pulumiserviceProvider) CheckConfig(
-	_ context.Context,
-	req *pulumirpc.CheckRequest,
-) (*pulumirpc.CheckResponse, error) {
-	return &pulumirpc.CheckResponse{Inputs: req.GetNews()}, nil
-}
-
-// DiffConfig diffs the configuration for this provider.
-func (k *pulumiserviceProvider) DiffConfig(
-	_ context.Context,
-	_ *pulumirpc.DiffRequest,
-) (*pulumirpc.DiffResponse, error) {
-	return &pulumirpc.DiffResponse{}, nil
-}
-
-// Configure configures the resource provider with "globals" that control its behavior.
-func (k *pulumiserviceProvider) Configure(
-	_ context.Context,
-	req *pulumirpc.ConfigureRequest,
-) (*pulumirpc.ConfigureResponse, error) {
-
-	sc := PulumiServiceConfig{}
-	sc.Config = make(map[string]string)
-	args, err := plugin.UnmarshalProperties(req.GetArgs(), plugin.MarshalOptions{
-		KeepResources: true,
-		SkipNulls:     true,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("unmarshaling configure args: %w", err)
-	}
-	for key, val := range args {
-		// The engine sends the provider "version" alongside the config
-		// properties; only string config values are meaningful here.
-		if key == versionKey || !val.IsString() {
-			continue
-		}
-		sc.Config[string(key)] = val.StringValue()
-	}
-
-	httpClient := http.Client{
-		Timeout: 60 * time.Second,
-	}
-	token, err := sc.getPulumiAccessToken()
-	if err != nil {
-		return nil, err
-	}
-	url, err := sc.getPulumiServiceURL()
-	if err != nil {
-		return nil, err
-	}
-	client, err := pulumiapi.NewClient(&httpClient, *token, *url)
-	if err != nil {
-		return nil, err
-	}
-
-	transport := rest.Transport(&authedTransport{
-		baseURL: *url,
-		token:   *token,
-		client:  &httpClient,
-	})
-	// Two paths: ctxmw.Wrap (set in MakeProvider) picks this up and attaches
-	// it to every CRUD context; SetTransportResolver remains as the legacy
-	// global so any code path that bypasses the middleware still resolves a
-	// transport.
-	if k.transportRef != nil {
-		k.transportRef.Store(transport)
-	}
-	rest.SetTransportResolver(func(_ context.Context) (rest.Transport, error) {
-		return transport, nil
-	})
-
-	escClient := esc_client.New(
-		fmt.Sprintf("provider-pulumiservice/1 (%s; %s)", version.Version, runtime.GOOS),
-		*url,
-		*token,
-		false,
-	)
-
-	// Store the client for use in Invoke functions
-	k.client = client
-
-	k.pulumiResources = []PulumiServiceResource{
-		&resources.PulumiServiceDeploymentSettingsResource{
-			Client: client,
-		},
-		&resources.PulumiServiceEnvironmentResource{
-			Client:         escClient,
-			MetadataClient: client,
-		},
-		&resources.PulumiServicePolicyGroupResource{
-			Client: client,
-		},
-	}
-
-	return &pulumirpc.ConfigureResponse{
-		AcceptSecrets: true,
-	}, nil
-}
-
-// Invoke dynamically executes a built-in function in the provider.
-func (k *pulumiserviceProvider) Invoke(
-	ctx context.Context,
-	req *pulumirpc.InvokeRequest,
-) (*pulumirpc.InvokeResponse, error) {
-	tok := req.GetTok()
-
-	switch tok {
-	case "pulumiservice:index:getPolicyPacks":
-		return k.invokeFunctionGetPolicyPacks(ctx, req)
-	case "pulumiservice:index:getPolicyPack":
-		return k.invokeFunctionGetPolicyPack(ctx, req)
-	default:
-		return nil, fmt.Errorf("unknown Invoke token '%s'", tok)
-	}
-}
-
-// Check validates that the given property bag is valid for a resource of the given type and returns
-// the inputs that should be passed to successive calls to Diff, Create, or Update for this
-// resource. As a rule, the provider inputs returned by a call to Check should preserve the original
-// representation of the properties as present in the program inputs. Though this rule is not
-// required for correctness, violations thereof can negatively impact the end-user experience, as
-// the provider inputs are using for detecting and rendering diffs.
-func (k *pulumiserviceProvider) Check(
-	_ context.Context,
-	req *pulumirpc.CheckRequest,
-) (*pulumirpc.CheckResponse, error) {
-	rn := getResourceNameFromRequest(req)
-	res := k.getPulumiServiceResource(rn)
-	return res.Check(req)
-}
-
-// Diff checks what impacts a hypothetical update will have on the resource's properties.
-func (k *pulumiserviceProvider) Diff(_ context.Context, req *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error) {
-	rn := getResourceNameFromRequest(req)
-	res := k.getPulumiServiceResource(rn)
-	return res.Diff(req)
-}
-
-// Create allocates a new instance of the provided resource and returns its unique ID afterwards.
-func (k *pulumiserviceProvider) Create(
-	_ context.Context,
-	req *pulumirpc.CreateRequest,
-) (*pulumirpc.CreateResponse, error) {
-	rn := getResourceNameFromRequest(req)
-	res := k.getPulumiServiceResource(rn)
-	return res.Create(req)
-}
-
-// Read the current live state associated with a resource.
-func (k *pulumiserviceProvider) Read(_ context.Context, req *pulumirpc.ReadRequest) (*pulumirpc.ReadResponse, error) {
-	rn := getResourceNameFromRequest(req)
-	res := k.getPulumiServiceResource(rn)
-	return res.Read(req)
-}
-
-// Update updates an existing resource with new values.
-func (k *pulumiserviceProvider) Update(
-	_ context.Context,
-	req *pulumirpc.UpdateRequest,
-) (*pulumirpc.UpdateResponse, error) {
-	rn := getResourceNameFromRequest(req)
-	res := k.getPulumiServiceResource(rn)
-	return res.Update(req)
-}
-
-// Delete tears down an existing resource with the given ID.  If it fails, the resource is assumed
-// to still exist.
-func (k *pulumiserviceProvider) Delete(_ context.Context, req *pulumirpc.DeleteRequest) (*pbempty.Empty, error) {
-	rn := getResourceNameFromRequest(req)
-	res := k.getPulumiServiceResource(rn)
-	return res.Delete(req)
-}
-
-// GetPluginInfo returns generic information about this plugin, like its version.
-func (k *pulumiserviceProvider) GetPluginInfo(context.Context, *pbempty.Empty) (*pulumirpc.PluginInfo, error) {
-	return &pulumirpc.PluginInfo{
-		Version: k.version,
-	}, nil
-}
-
-// GetSchema returns the JSON-serialized schema for the provider.
-func (k *pulumiserviceProvider) GetSchema(
-	_ context.Context,
-	_ *pulumirpc.GetSchemaRequest,
-) (*pulumirpc.GetSchemaResponse, error) {
-	return &pulumirpc.GetSchemaResponse{
-		Schema: k.schema,
-	}, nil
-}
-
-// Cancel signals the provider to gracefully shut down and abort any ongoing resource operations.
-// Operations aborted in this way will return an error (e.g., `Update` and `Create` will either a
-// creation error or an initialization error). Since Cancel is advisory and non-blocking, it is up
-// to the host to decide how long to wait after Cancel is called before (e.g.)
-// hard-closing any gRPC connection.
-func (k *pulumiserviceProvider) Cancel(_ context.Context, _ *pbempty.Empty) (*pbempty.Empty, error) {
-	// TODO
-	return &pbempty.Empty{}, nil
-}
-
-func (k *pulumiserviceProvider) getPulumiServiceResource(name string) PulumiServiceResource {
-	for _, r := range k.pulumiResources {
-		if r.Name() == name {
-			return r
-		}
-	}
-
-	return &PulumiServiceUnknownResource{}
-}
-
-func getResourceNameFromRequest(req ResourceBase) string {
-	urn := resource.URN(req.GetUrn())
-	return urn.Type().String()
-}
-
-// mustSetSchemaVersion deserializes schemaStr from json, sets Version field
-// then serializes back to json string
-func mustSetSchemaVersion(schemaStr string, version string) string {
-	var spec schema.PackageSpec
-	if err := json.Unmarshal([]byte(schemaStr), &spec); err != nil {
-		panic(fmt.Errorf("failed to parse schema: %w", err))
-	}
-	spec.Version = version
-	bytes, err := json.Marshal(spec)
-	if err != nil {
-		panic(fmt.Errorf("failed to serialize versioned schema: %w", err))
-	}
-	return string(bytes)
-}
-
-type ResourceBase interface {
-	GetUrn() string
-}
-
-// invokeFunctionGetPolicyPacks implements the getPolicyPacks function
-func (k *pulumiserviceProvider) invokeFunctionGetPolicyPacks(
-	ctx context.Context,
-	req *pulumirpc.InvokeRequest,
-) (*pulumirpc.InvokeResponse, error) {
-	if k.client == nil {
-		return nil, fmt.Errorf("provider not configured")
-	}
-
-	// Parse inputs
-	inputs, err := plugin.UnmarshalProperties(req.GetArgs(), plugin.MarshalOptions{KeepUnknowns: true, SkipNulls: true})
-	if err != nil {
-		return nil, err
-	}
-
-	orgName := inputs["organizationName"].StringValue()
-	if orgName == "" {
-		return nil, fmt.Errorf("organizationName is required")
-	}
-
-	// Call the API. Registry provenance (source/publisher) comes from a second
-	// endpoint joined on pack name; any failure other than the route being absent
-	// is fatal, so a program filtering on publisher can't silently match nothing
-	// and attach zero policy packs.
-	policyPacks, registryUnavailable, err := k.client.ListPolicyPacksWithRegistryMetadata(ctx, orgName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list policy packs: %w", err)
-	}
-	if registryUnavailable && k.host != nil {
-		_ = k.host.Log(ctx, diag.Warning, "",
-			"this Pulumi backend does not serve the policy pack registry; "+
-				"`source` and `publisher` will be absent from getPolicyPacks results")
-	}
-
-	// Build output
-	outputProps := resource.PropertyMap{
-		policyPacksKey: resource.NewPropertyValue(convertPolicyPacksToProperties(policyPacks)),
-	}
-
-	outputProperties, err := plugin.MarshalProperties(outputProps, plugin.MarshalOptions{})
-	if err != nil {
-		return nil, err
-	}
-
-	return &pulumirpc.InvokeResponse{
-		Return: outputProperties,
-	}, nil
-}
-
-// invokeFunctionGetPolicyPack implements the getPolicyPack function
-func (k *pulumiserviceProvider) invokeFunctionGetPolicyPack(
-	ctx context.Context,
-	req *pulumirpc.InvokeRequest,
-) (*pulumirpc.InvokeResponse, error) {
-	if k.client == nil {
-		return nil, fmt.Errorf("provider not configured")
-	}
-
-	// Parse inputs
-	inputs, err := plugin.UnmarshalProperties(req.GetArgs(), plugin.MarshalOptions{KeepUnknowns: true, SkipNulls: true})
-	if err != nil {
-		return nil, err
-	}
-
-	orgName := inputs["organizationName"].StringValue()
-	if orgName == "" {
-		return nil, fmt.Errorf("organizationName is required")
-	}
-
-	policyPackName := inputs["policyPackName"].StringValue()
-	if policyPackName == "" {
-		return nil, fmt.Errorf("policyPackName is required")
-	}
-
-	// Call the API - either specific version or latest
-	var policyPack *pulumiapi.PolicyPackDetail
-	if inputs["version"].HasValue() && inputs["version"].IsNumber() {
-		version := int(inputs["version"].NumberValue())
-		policyPack, err = k.client.GetPolicyPack(ctx, orgName, policyPackName, version)
-	} else {
-		policyPack, err = k.client.GetLatestPolicyPack(ctx, orgName, policyPackName)
-	}
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to get policy pack: %w", err)
-	}
-
-	if policyPack == nil {
-		return nil, fmt.Errorf("policy pack not found")
-	}
-
-	// Look the pack up by the version tag we just resolved. Provenance is version
-	// independent, but the tag is not optional in practice: omitting it makes the
-	// service look for the literal tag `latest`, which packs do not carry, so
-	// source and publisher would come back empty for effectively every pack.
-	//
-	// Note the deliberate asymmetry with getPolicyPacks, which treats a registry
-	// failure as fatal: there, a failed lookup silently empties a publisher filter
-	// and the program attaches nothing. Here the caller already named the pack, so
-	// that hazard doesn't exist and failing would only deny them a result the
-	// provider could already produce in full before this feature. Don't "fix" one
-	// to match the other.
-	registryPack, registryErr := k.client.GetRegistryPolicyPack(ctx, orgName, policyPackName, policyPack.VersionTag)
-	if registryErr != nil {
-		if k.host != nil {
-			_ = k.host.Log(ctx, diag.Warning, "", fmt.Sprintf(
-				"could not read registry metadata for policy pack %q: %v", policyPackName, registryErr))
-		}
-		registryPack = nil
-	}
-
-	// Build output
-	outputProps := convertPolicyPackDetailToProperties(policyPack, registryPack)
-
-	outputProperties, err := plugin.MarshalProperties(outputProps, plugin.MarshalOptions{})
-	if err != nil {
-		return nil, err
-	}
-
-	return &pulumirpc.InvokeResponse{
-		Return: outputProperties,
-	}, nil
-}
-
-// Helper functions to convert API types to property values
-func convertPolicyPacksToProperties(packs []pulumiapi.PolicyPackWithRegistryMetadata) []resource.PropertyValue {
-	result := make([]resource.PropertyValue, len(packs))
-	for i, pack := range packs {
-		versions := make([]resource.PropertyValue, len(pack.Versions))
-		for j, v := range pack.Versions {
-			versions[j] = resource.NewNumberProperty(float64(v))
-		}
-
-		versionTags := make([]resource.PropertyValue, len(pack.VersionTags))
-		for j, vt := range pack.VersionTags {
-			versionTags[j] = resource.NewStringProperty(vt)
-		}
-
-		props := resource.PropertyMap{
-			nameKey:        resource.NewStringProperty(pack.Name),
-			displayNameKey: resource.NewStringProperty(pack.DisplayName),
-			versionsKey:    resource.NewArrayProperty(versions),
-			versionTagsKey: resource.NewArrayProperty(versionTags),
-		}
-
-		// Omit rather than emit "" when provenance is unknown, so the schema's
-		// optional fields surface as absent/nil in typed SDKs. An empty string
-		// would read as a real value and compare equal to nothing.
-		if pack.Source != "" {
-			props[sourceKey] = resource.NewStringProperty(pack.Source)
-		}
-		if pack.Publisher != "" {
-			props[publisherKey] = resource.NewStringProperty(pack.Publisher)
+// withEnvironmentSchema restores the parts of Environment's schema infer can't
+// describe. The yaml property is an Asset: infer can only describe it as a
+// string, but programs have always been able to pass a file asset, which
+// Environment's Check reads down to text. And `project` and `yaml` are always
+// set in state, even though they're optional inputs.
+func withEnvironmentSchema(prov p.Provider) p.Provider {
+	inner := prov.GetSchema
+	prov.GetSchema = func(ctx context.Context, req p.GetSchemaRequest) (p.GetSchemaResponse, error) {
+		resp, err := inner(ctx, req)
+		if err != nil {
+			return resp, err
 		}
-
-		result[i] = resource.NewObjectProperty(props)
-	}
-	return result
-}
-
-// convertPolicyPackDetailToProperties renders a policy pack for the getPolicyPack
-// invoke. registry carries the pack's Pulumi Registry provenance and may be nil
-// when that lookup was unavailable; source and publisher are then omitted.
-func convertPolicyPackDetailToProperties(
-	pack *pulumiapi.PolicyPackDetail,
-	registry *pulumiapi.RegistryPolicyPack,
-) resource.PropertyMap {
-	props := resource.PropertyMap{
-		nameKey:        resource.NewStringProperty(pack.Name),
-		displayNameKey: resource.NewStringProperty(pack.DisplayName),
-		versionKey:     resource.NewNumberProperty(float64(pack.Version)),
-	}
-
-	if pack.VersionTag != "" {
-		props["versionTag"] = resource.NewStringProperty(pack.VersionTag)
-	}
-
-	if registry != nil {
-		if registry.Source != "" {
-			props[sourceKey] = resource.NewStringProperty(registry.Source)
+		var spec schema.PackageSpec
+		if err := json.Unmarshal([]byte(resp.Schema), &spec); err != nil {
+			return resp, fmt.Errorf("withEnvironmentSchema: parse schema: %w", err)
 		}
-		if registry.Publisher != "" {
-			props[publisherKey] = resource.NewStringProperty(registry.Publisher)
+		env, ok := spec.Resources["pulumiservice:index:Environment"]
+		if !ok {
+			return resp, nil
 		}
-	}
-
-	if pack.Config != nil {
-		props["config"] = resource.NewObjectProperty(convertMapToPropertyMap(pack.Config))
-	}
-
-	if len(pack.Policies) > 0 {
-		policies := make([]resource.PropertyValue, len(pack.Policies))
-		for i, policy := range pack.Policies {
-			policyProps := resource.PropertyMap{
-				nameKey: resource.NewStringProperty(policy.Name),
-			}
-			if policy.DisplayName != "" {
-				policyProps[displayNameKey] = resource.NewStringProperty(policy.DisplayName)
-			}
-			if policy.Description != "" {
-				policyProps["description"] = resource.NewStringProperty(policy.Description)
-			}
-			if policy.EnforcementLevel != "" {
-				policyProps["enforcementLevel"] = resource.NewStringProperty(string(policy.EnforcementLevel))
+		for _, props := range []map[string]schema.PropertySpec{env.Properties, env.InputProperties} {
+			if yaml, ok := props["yaml"]; ok {
+				yaml.TypeSpec = schema.TypeSpec{Ref: "pulumi.json#/Asset"}
+				props["yaml"] = yaml
 			}
-			if policy.Message != "" {
-				policyProps["message"] = resource.NewStringProperty(policy.Message)
-			}
-			if policy.ConfigSchema != nil {
-				policyProps["configSchema"] = resource.NewObjectProperty(convertConfigSchemaToPropertyMap(policy.ConfigSchema))
-			}
-			policies[i] = resource.NewObjectProperty(policyProps)
 		}
-		props["policies"] = resource.NewArrayProperty(policies)
-	}
-
-	return props
-}
-
-func convertMapToPropertyMap(m map[string]interface{}) resource.PropertyMap {
-	props := resource.PropertyMap{}
-	for k, v := range m {
-		props[resource.PropertyKey(k)] = convertInterfaceToPropertyValue(v)
-	}
-	return props
-}
-
-func convertConfigSchemaToPropertyMap(cs *apitype.PolicyConfigSchema) resource.PropertyMap {
-	props := resource.PropertyMap{}
-	if cs.Type != "" {
-		props["type"] = resource.NewStringProperty(string(cs.Type))
-	}
-	if len(cs.Required) > 0 {
-		required := make([]resource.PropertyValue, len(cs.Required))
-		for i, r := range cs.Required {
-			required[i] = resource.NewStringProperty(r)
+		if project, ok := env.Properties["project"]; ok {
+			project.Default = nil
+			env.Properties["project"] = project
 		}
-		props["required"] = resource.NewArrayProperty(required)
-	}
-	if len(cs.Properties) > 0 {
-		properties := resource.PropertyMap{}
-		for name, raw := range cs.Properties {
-			if raw == nil {
-				continue
-			}
-			var decoded any
-			if err := json.Unmarshal(*raw, &decoded); err != nil {
-				continue
+		for _, required := range []string{"yaml", "project"} {
+			if _, ok := env.Properties[required]; ok && !slices.Contains(env.Required, required) {
+				env.Required = append(env.Required, required)
 			}
-			properties[resource.PropertyKey(name)] = convertInterfaceToPropertyValue(decoded)
 		}
-		props["properties"] = resource.NewObjectProperty(properties)
+		spec.Resources["pulumiservice:index:Environment"] = env
+		out, err := json.Marshal(spec)
+		if err != nil {
+			return resp, fmt.Errorf("withEnvironmentSchema: re-encode schema: %w", err)
+		}
+		resp.Schema = string(out)
+		return resp, nil
 	}
-	return props
+	return prov
 }
 
-func convertInterfaceToPropertyValue(v interface{}) resource.PropertyValue {
-	if v == nil {
-		return resource.NewNullProperty()
+// withRawInputs hands resources the untyped inputs of Diff, Create and
+// Update; see resources.WithRawInputs.
+func withRawInputs(prov p.Provider) p.Provider {
+	diff, create, update := prov.Diff, prov.Create, prov.Update
+	prov.Diff = func(ctx context.Context, req p.DiffRequest) (p.DiffResponse, error) {
+		return diff(resources.WithRawInputs(ctx, req.OldInputs, req.Inputs), req)
 	}
-
-	switch val := v.(type) {
-	case string:
-		return resource.NewStringProperty(val)
-	case float64:
-		return resource.NewNumberProperty(val)
-	case int:
-		return resource.NewNumberProperty(float64(val))
-	case bool:
-		return resource.NewBoolProperty(val)
-	case []interface{}:
-		arr := make([]resource.PropertyValue, len(val))
-		for i, item := range val {
-			arr[i] = convertInterfaceToPropertyValue(item)
-		}
-		return resource.NewArrayProperty(arr)
-	case map[string]interface{}:
-		return resource.NewObjectProperty(convertMapToPropertyMap(val))
-	default:
-		return resource.NewNullProperty()
+	prov.Create = func(ctx context.Context, req p.CreateRequest) (p.CreateResponse, error) {
+		return create(resources.WithRawInputs(ctx, property.Map{}, req.Properties), req)
+	}
+	prov.Update = func(ctx context.Context, req p.UpdateRequest) (p.UpdateResponse, error) {
+		return update(resources.WithRawInputs(ctx, req.OldInputs, req.Inputs), req)
 	}
+	return prov
 }
@@ -17,16 +17,20 @@ package provider
 import (
 	"context"
 	"encoding/json"
-	"os"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	p "github.com/pulumi/pulumi-go-provider"
 	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
 	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
 )
 
 func TestDiffConfig(t *testing.T) {
@@ -39,36 +43,36 @@ func TestDiffConfig(t *testing.T) {
 		{
 			name: "accessToken changed",
 			oldConfig: resource.PropertyMap{
-				accessTokenKey: resource.NewPropertyValue("old-token-123"),
-				apiURLKey:      resource.NewPropertyValue("https://api.pulumi.com"),
+				"accessToken": resource.NewPropertyValue("old-token-123"),
+				"apiUrl":      resource.NewPropertyValue("https://api.pulumi.com"),
 			},
 			newConfig: resource.PropertyMap{
-				accessTokenKey: resource.NewPropertyValue("new-token-456"),
-				apiURLKey:      resource.NewPropertyValue("https://api.pulumi.com"),
+				"accessToken": resource.NewPropertyValue("new-token-456"),
+				"apiUrl":      resource.NewPropertyValue("https://api.pulumi.com"),
 			},
 			expectedChanges: pulumirpc.DiffResponse_DIFF_SOME,
 		},
 		{
 			name: "apiUrl changed",
 			oldConfig: resource.PropertyMap{
-				accessTokenKey: resource.NewPropertyValue("token-123"),
-				apiURLKey:      resource.NewPropertyValue("https://api.pulumi.com"),
+				"accessToken": resource.NewPropertyValue("token-123"),
+				"apiUrl":      resource.NewPropertyValue("https://api.pulumi.com"),
 			},
 			newConfig: resource.PropertyMap{
-				accessTokenKey: resource.NewPropertyValue("token-123"),
-				apiURLKey:      resource.NewPropertyValue("https://custom.pulumi.example.com"),
+				"accessToken": resource.NewPropertyValue("token-123"),
+				"apiUrl":      resource.NewPropertyValue("https://custom.pulumi.example.com"),
 			},
 			expectedChanges: pulumirpc.DiffResponse_DIFF_SOME,
 		},
 		{
 			name: "no changes",
 			oldConfig: resource.PropertyMap{
-				accessTokenKey: resource.NewPropertyValue("token-123"),
-				apiURLKey:      resource.NewPropertyValue("https://api.pulumi.com"),
+				"accessToken": resource.NewPropertyValue("token-123"),
+				"apiUrl":      resource.NewPropertyValue("https://api.pulumi.com"),
 			},
 			newConfig: resource.PropertyMap{
-				accessTokenKey: resource.NewPropertyValue("token-123"),
-				apiURLKey:      resource.NewPropertyValue("https://api.pulumi.com"),
+				"accessToken": resource.NewPropertyValue("token-123"),
+				"apiUrl":      resource.NewPropertyValue("https://api.pulumi.com"),
 			},
 			expectedChanges: pulumirpc.DiffResponse_DIFF_NONE,
 		},
@@ -111,57 +115,33 @@ func TestDiffConfig(t *testing.T) {
 }
 
 func TestConfigure_SetsAccessToken(t *testing.T) {
-	ctx := context.Background()
-
-	// Ensure PULUMI_ACCESS_TOKEN is not set from environment
-	oldToken := os.Getenv(EnvVarPulumiAccessToken)
-	err := os.Setenv(EnvVarPulumiAccessToken, "")
-	require.NoError(t, err)
-	defer func() {
-		err := os.Setenv(EnvVarPulumiAccessToken, oldToken)
-		assert.NoError(t, err)
-	}()
+	t.Setenv(config.EnvVarPulumiAccessToken, "")
 
-	// Create provider instance
-	provider, err := MakeProvider(nil, "pulumiservice", "1.0.0")
-	require.NoError(t, err)
-
-	// Create config with accessToken
-	config := resource.PropertyMap{
-		accessTokenKey: resource.NewPropertyValue("pul-test0token"),
-	}
-	props, err := plugin.MarshalProperties(config, plugin.MarshalOptions{})
+	k := &pulumiserviceProvider{transportRef: &atomic.Value{}, cancel: newCancelScope()}
+	prov, err := k.provider("pulumiservice", "1.0.0")
 	require.NoError(t, err)
 
-	// Call CheckConfig
-	_, err = provider.CheckConfig(ctx, &pulumirpc.CheckRequest{
-		News: props,
+	err = prov.Configure(context.Background(), p.ConfigureRequest{
+		Args: property.NewMap(map[string]property.Value{
+			"accessToken": property.New("pul-test0token").WithSecret(true),
+			"apiUrl":      property.New("https://api.pulumi.com"),
+		}),
 	})
 	require.NoError(t, err)
 
-	// Build config args as the engine sends them: plain keys, secrets kept.
-	configArgs, err := plugin.MarshalProperties(resource.PropertyMap{
-		accessTokenKey: resource.MakeSecret(resource.NewStringProperty("pul-test0token")),
-	}, plugin.MarshalOptions{KeepSecrets: true})
-	require.NoError(t, err)
-
-	// Configure the raw provider directly: MakeProvider wraps it, which
-	// hides the client field this test asserts on.
-	raw := &pulumiserviceProvider{}
-	_, err = raw.Configure(ctx, &pulumirpc.ConfigureRequest{
-		Args: configArgs,
-	})
-	require.NoError(t, err)
-
-	assert.NotNil(t, raw.client, "client should be initialized after Configure")
+	// The api layer is configured from the same credentials as the infer
+	// resources.
+	transport, ok := k.transportRef.Load().(*authedTransport)
+	require.True(t, ok, "transport should be initialized after Configure")
+	assert.Equal(t, "pul-test0token", transport.token)
+	assert.Equal(t, "https://api.pulumi.com", transport.baseURL)
 }
 
-// TestProvider_LayeredSchema verifies the unified provider serves three
+// TestProvider_LayeredSchema verifies the unified provider serves both
 // resource sources under one pulumiservice schema:
 //
-//  1. legacy raw gRPC server (manual-schema.json) — e.g. Stack
-//  2. modern infer (WithResources)              — e.g. Team
-//  3. metadata-driven api layer (rest.BuildSchema spliced via withCloudApiSchema)
+//  1. infer (WithResources) — e.g. Team
+//  2. metadata-driven api layer (rest.BuildSchema spliced via withCloudApiSchema)
 //     — e.g. OrganizationWebhook at pulumiservice:api:*
 //
 // All three share the pulumiservice package name; v0 surface is implicit
@@ -184,8 +164,8 @@ func TestProvider_LayeredSchema(t *testing.T) {
 		token  string
 		source string
 	}{
-		{"pulumiservice:index:Stack", "v0: legacy raw (manual-schema.json)"},
-		{"pulumiservice:index:Team", "v0: modern infer (WithResources)"},
+		{"pulumiservice:index:Team", "v0: infer (WithResources)"},
+		{"pulumiservice:index:Environment", "v0: infer (WithResources)"},
 		{"pulumiservice:api:OrganizationWebhook", "api: metadata-driven (withCloudApiSchema)"},
 	}
 	for _, c := range mustHave {
@@ -193,6 +173,14 @@ func TestProvider_LayeredSchema(t *testing.T) {
 		assert.Truef(t, ok, "missing %s — expected from %s", c.token, c.source)
 	}
 
+	// Programs pass Environment's yaml as a file asset; infer alone would
+	// advertise a string.
+	env := spec.Resources["pulumiservice:index:Environment"]
+	assert.Equal(t, "pulumi.json#/Asset", env.InputProperties["yaml"].Ref)
+	assert.Equal(t, "pulumi.json#/Asset", env.Properties["yaml"].Ref)
+
+	assert.Equal(t, "Pulumi Cloud", spec.DisplayName)
+	assert.Equal(t, "https://github.com/pulumi/pulumi-pulumiservice", spec.Repository)
 }
 
 // getPolicyPacks' result must be a named type, not an inline object.
@@ -219,7 +207,7 @@ func TestProvider_PolicyPackSummaryIsANamedType(t *testing.T) {
 	require.NotNil(t, fn.ReturnType)
 	require.NotNil(t, fn.ReturnType.ObjectTypeSpec)
 
-	packs, ok := fn.ReturnType.ObjectTypeSpec.Properties[policyPacksKey]
+	packs, ok := fn.ReturnType.ObjectTypeSpec.Properties["policyPacks"]
 	require.True(t, ok, "policyPacks output should be present")
 	require.NotNil(t, packs.Items, "policyPacks items should survive schema generation")
 	assert.Equal(t, "#/types/pulumiservice:index:PolicyPackSummary", packs.Items.Ref)
@@ -227,20 +215,20 @@ func TestProvider_PolicyPackSummaryIsANamedType(t *testing.T) {
 	summary, ok := spec.Types["pulumiservice:index:PolicyPackSummary"]
 	require.True(t, ok, "PolicyPackSummary type should be present")
 
-	for _, field := range []string{nameKey, displayNameKey, versionsKey, versionTagsKey, sourceKey, publisherKey} {
+	for _, field := range []string{"name", "displayName", "versions", "versionTags", "source", "publisher"} {
 		_, ok := summary.Properties[field]
 		assert.Truef(t, ok, "PolicyPackSummary should declare %q", field)
 	}
 
 	// Optional so a degraded lookup is distinguishable from real data in typed SDKs.
-	assert.NotContains(t, summary.Required, sourceKey)
-	assert.NotContains(t, summary.Required, publisherKey)
+	assert.NotContains(t, summary.Required, "source")
+	assert.NotContains(t, summary.Required, "publisher")
 
 	single, ok := spec.Functions["pulumiservice:index:getPolicyPack"]
 	require.True(t, ok, "getPolicyPack should be present")
 	require.NotNil(t, single.ReturnType)
 	require.NotNil(t, single.ReturnType.ObjectTypeSpec)
-	for _, field := range []string{sourceKey, publisherKey} {
+	for _, field := range []string{"source", "publisher"} {
 		_, ok := single.ReturnType.ObjectTypeSpec.Properties[field]
 		assert.Truef(t, ok, "getPolicyPack should declare %q", field)
 		assert.NotContains(t, single.ReturnType.ObjectTypeSpec.Required, field)
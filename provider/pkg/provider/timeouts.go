@@ -0,0 +1,92 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	p "github.com/pulumi/pulumi-go-provider"
+)
+
+// operationTimeoutError reports a create, update or delete that ran past the
+// timeout the engine passed for it (the resource's customTimeouts). It is the
+// cancellation cause of the operation's context, so every layer that reports
+// context.Cause surfaces it as-is.
+type operationTimeoutError struct {
+	Operation string
+	Timeout   time.Duration
+}
+
+func (e *operationTimeoutError) Error() string {
+	return fmt.Sprintf("%s timed out after %s; raise customTimeouts.%s if it needs longer",
+		e.Operation, e.Timeout, e.Operation)
+}
+
+// withOperationTimeouts bounds Create, Update and Delete by the timeout the
+// engine passes with each request. A zero timeout (no customTimeouts) leaves
+// the context as it is. Errors from an operation that hit its deadline are
+// wrapped in operationTimeoutError unless they already carry it, so the
+// timeout reads as such rather than as whatever call it happened to cut
+// short; partial state is passed through untouched.
+func withOperationTimeouts(prov p.Provider) p.Provider {
+	create, update, del := prov.Create, prov.Update, prov.Delete
+	if create != nil {
+		prov.Create = func(ctx context.Context, req p.CreateRequest) (p.CreateResponse, error) {
+			ctx, done := withOperationTimeout(ctx, "create", req.Timeout)
+			resp, err := create(ctx, req)
+			return resp, done(err)
+		}
+	}
+	if update != nil {
+		prov.Update = func(ctx context.Context, req p.UpdateRequest) (p.UpdateResponse, error) {
+			ctx, done := withOperationTimeout(ctx, "update", req.Timeout)
+			resp, err := update(ctx, req)
+			return resp, done(err)
+		}
+	}
+	if del != nil {
+		prov.Delete = func(ctx context.Context, req p.DeleteRequest) error {
+			ctx, done := withOperationTimeout(ctx, "delete", req.Timeout)
+			return done(del(ctx, req))
+		}
+	}
+	return prov
+}
+
+// withOperationTimeout derives the operation's context from its timeout in
+// seconds. done releases the context and attributes err to the timeout when
+// the deadline is what ended the operation.
+func withOperationTimeout(
+	ctx context.Context, operation string, seconds float64,
+) (context.Context, func(error) error) {
+	if seconds <= 0 {
+		return ctx, func(err error) error { return err }
+	}
+	timeoutErr := &operationTimeoutError{
+		Operation: operation,
+		Timeout:   time.Duration(seconds * float64(time.Second)),
+	}
+	ctx, cancel := context.WithTimeoutCause(ctx, timeoutErr.Timeout, timeoutErr)
+	return ctx, func(err error) error {
+		defer cancel()
+		if err == nil || !errors.Is(context.Cause(ctx), timeoutErr) || errors.Is(err, timeoutErr) {
+			return err
+		}
+		return fmt.Errorf("%w: %w", timeoutErr, err)
+	}
+}
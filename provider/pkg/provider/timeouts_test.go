@@ -0,0 +1,104 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+)
+
+// hangingProvider blocks every CRUD call until its context ends, then fails
+// the way a client call cut short by its context does.
+func hangingProvider() p.Provider {
+	hang := func(ctx context.Context) error {
+		<-ctx.Done()
+		return fmt.Errorf("error calling Pulumi Cloud: %w", ctx.Err())
+	}
+	return p.Provider{
+		Create: func(ctx context.Context, _ p.CreateRequest) (p.CreateResponse, error) {
+			return p.CreateResponse{
+				ID:           "partial",
+				PartialState: &p.InitializationFailed{Reasons: []string{"cut short"}},
+			}, hang(ctx)
+		},
+		Update: func(ctx context.Context, _ p.UpdateRequest) (p.UpdateResponse, error) {
+			// Layers that report context.Cause already carry the timeout.
+			<-ctx.Done()
+			return p.UpdateResponse{}, fmt.Errorf("rest: PatchThing: %w", context.Cause(ctx))
+		},
+		Delete: func(ctx context.Context, _ p.DeleteRequest) error {
+			return hang(ctx)
+		},
+	}
+}
+
+func TestOperationTimeouts(t *testing.T) {
+	prov := withOperationTimeouts(hangingProvider())
+	const timeout = 0.02 // seconds, as the engine sends it
+
+	t.Run("create", func(t *testing.T) {
+		resp, err := prov.Create(t.Context(), p.CreateRequest{Timeout: timeout})
+		var timeoutErr *operationTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, "create", timeoutErr.Operation)
+		assert.Equal(t, 20*time.Millisecond, timeoutErr.Timeout)
+		assert.ErrorIs(t, err, context.DeadlineExceeded, "the underlying error is kept")
+		assert.ErrorContains(t, err, "customTimeouts.create")
+		assert.NotNil(t, resp.PartialState, "partial state passes through")
+	})
+
+	t.Run("update is not wrapped twice", func(t *testing.T) {
+		_, err := prov.Update(t.Context(), p.UpdateRequest{Timeout: timeout})
+		var timeoutErr *operationTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, "rest: PatchThing: update timed out after 20ms; raise customTimeouts.update if it needs longer",
+			err.Error())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := prov.Delete(t.Context(), p.DeleteRequest{Timeout: timeout})
+		var timeoutErr *operationTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, "delete", timeoutErr.Operation)
+	})
+
+	t.Run("no timeout leaves the context unbounded", func(t *testing.T) {
+		var bounded bool
+		prov := withOperationTimeouts(p.Provider{
+			Delete: func(ctx context.Context, _ p.DeleteRequest) error {
+				_, bounded = ctx.Deadline()
+				return nil
+			},
+		})
+		require.NoError(t, prov.Delete(t.Context(), p.DeleteRequest{}))
+		assert.False(t, bounded)
+	})
+
+	t.Run("other failures are left alone", func(t *testing.T) {
+		boom := errors.New("boom")
+		prov := withOperationTimeouts(p.Provider{
+			Delete: func(context.Context, p.DeleteRequest) error { return boom },
+		})
+		assert.Equal(t, boom, prov.Delete(t.Context(), p.DeleteRequest{Timeout: 60}))
+	})
+}
@@ -17,56 +17,40 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
-	"path"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
 
 type AccessTokenClient interface {
-	CreateAccessToken(ctx context.Context, description string) (*AccessToken, error)
+	CreateAccessToken(ctx context.Context, description string, expires int64) (*AccessToken, error)
 	DeleteAccessToken(ctx context.Context, tokenID string) error
 	GetAccessToken(ctx context.Context, id string) (*AccessToken, error)
 }
 
+// AccessToken is a personal, organization or team access token. Expires and
+// LastUsed are Unix timestamps in seconds, zero for a token that never
+// expires or was never used.
 type AccessToken struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
 	TokenValue  string `json:"tokenValue"`
 	Description string `json:"description"`
 	Admin       bool   `json:"admin"`
+	Expires     int64  `json:"expires"`
+	LastUsed    int64  `json:"lastUsed"`
 }
 
-type createTokenResponse struct {
-	ID         string `json:"id"`
-	TokenValue string `json:"tokenValue"`
-}
-
-type createTokenRequest struct {
-	Description string `json:"description"`
-}
-
-type accessTokenResponse struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	LastUsed    int    `json:"lastUsed"`
-	Admin       bool   `json:"admin"`
-}
-
-type listTokenResponse struct {
-	Tokens []accessTokenResponse `json:"tokens"`
-}
-
-func (c *Client) CreateAccessToken(ctx context.Context, description string) (*AccessToken, error) {
-	apiPath := path.Join("user", "tokens")
-
-	createReq := createTokenRequest{
-		Description: description,
+// CreateAccessToken creates a personal access token expiring at expires, a
+// Unix timestamp in seconds, or never when it is zero.
+func (c *Client) CreateAccessToken(ctx context.Context, description string, expires int64) (*AccessToken, error) {
+	createReq := apitype.CreatePersonalAccessTokenRequest{
+		BaseCreateAccessTokenRequest: apitype.BaseCreateAccessTokenRequest{
+			Description: description,
+			Expires:     expires,
+		},
 	}
 
-	var createRes createTokenResponse
-
-	_, err := c.do(ctx, http.MethodPost, apiPath, createReq, &createRes)
-
+	createRes, err := c.SDK.CreatePersonalToken(ctx, nil, createReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create access token: %w", err)
 	}
@@ -74,7 +58,8 @@ func (c *Client) CreateAccessToken(ctx context.Context, description string) (*Ac
 	return &AccessToken{
 		ID:          createRes.ID,
 		TokenValue:  createRes.TokenValue,
-		Description: createReq.Description,
+		Description: description,
+		Expires:     expires,
 	}, nil
 
 }
@@ -84,9 +69,7 @@ func (c *Client) DeleteAccessToken(ctx context.Context, tokenID string) error {
 		return errors.New("tokenid length must be greater than zero")
 	}
 
-	apiPath := path.Join("user", "tokens", tokenID)
-
-	_, err := c.do(ctx, http.MethodDelete, apiPath, nil, nil)
+	err := c.SDK.DeletePersonalToken(ctx, tokenID)
 	if err != nil {
 		return fmt.Errorf("failed to delete access token %q: %w", tokenID, err)
 	}
@@ -95,12 +78,7 @@ func (c *Client) DeleteAccessToken(ctx context.Context, tokenID string) error {
 }
 
 func (c *Client) GetAccessToken(ctx context.Context, id string) (*AccessToken, error) {
-	apiPath := path.Join("user", "tokens")
-
-	var listRes listTokenResponse
-
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &listRes)
-
+	listRes, err := c.SDK.ListPersonalTokens(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list access tokens: %w", err)
 	}
@@ -111,6 +89,8 @@ func (c *Client) GetAccessToken(ctx context.Context, id string) (*AccessToken, e
 			return &AccessToken{
 				ID:          token.ID,
 				Description: token.Description,
+				Expires:     token.Expires,
+				LastUsed:    token.LastUsed,
 			}, nil
 		}
 	}
@@ -42,7 +42,7 @@ func TestDeleteAccessToken(t *testing.T) {
 		})
 		assert.EqualError(t,
 			c.DeleteAccessToken(ctx, tokenID),
-			`failed to delete access token "abcdegh": 404 API error: token not found`,
+			`failed to delete access token "abcdegh": HTTP 404: token not found`,
 		)
 	})
 
@@ -64,7 +64,7 @@ func TestCreateAccessToken(t *testing.T) {
 			ResponseCode:    201,
 			ResponseBody:    resp,
 		})
-		token, err := c.CreateAccessToken(ctx, desc)
+		token, err := c.CreateAccessToken(ctx, desc, 0)
 		assert.NoError(t, err)
 		assert.Equal(t, &AccessToken{
 			ID:          resp.ID,
@@ -73,6 +73,22 @@ func TestCreateAccessToken(t *testing.T) {
 		}, token)
 	})
 
+	t.Run("Expiring", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPost,
+			ExpectedReqBody: createTokenRequest{
+				Description: desc,
+				Expires:     1798761600,
+			},
+			ExpectedReqPath: userTokPath,
+			ResponseCode:    201,
+			ResponseBody:    createTokenResponse{ID: tokenIDKey, TokenValue: secretKey},
+		})
+		token, err := c.CreateAccessToken(ctx, desc, 1798761600)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1798761600), token.Expires)
+	})
+
 	t.Run("Error", func(t *testing.T) {
 		c := startTestServer(t, testServerConfig{
 			ExpectedReqMethod: http.MethodPost,
@@ -86,11 +102,11 @@ func TestCreateAccessToken(t *testing.T) {
 				Message:    unauthorizedError,
 			},
 		})
-		token, err := c.CreateAccessToken(ctx, desc)
+		token, err := c.CreateAccessToken(ctx, desc, 0)
 		assert.Nil(t, token, "token should be nil")
 		assert.EqualError(t,
 			err,
-			`failed to create access token: 401 API error: unauthorized`,
+			`failed to create access token: HTTP 401: unauthorized`,
 		)
 	})
 }
@@ -99,12 +115,14 @@ func TestGetAccessToken(t *testing.T) {
 	id := testTokenUUID
 	desc := testTokenDescription
 	lastUsed := 123
+	expires := int64(1798761600)
 	t.Run("Happy Path", func(t *testing.T) {
 		resp := listTokenResponse{
 			Tokens: []accessTokenResponse{
 				{
 					ID:          id,
 					Description: desc,
+					Expires:     expires,
 					LastUsed:    lastUsed,
 				},
 				{
@@ -126,6 +144,8 @@ func TestGetAccessToken(t *testing.T) {
 		assert.Equal(t, &AccessToken{
 			ID:          id,
 			Description: desc,
+			Expires:     expires,
+			LastUsed:    int64(lastUsed),
 		}, token)
 	})
 
@@ -144,7 +164,7 @@ func TestGetAccessToken(t *testing.T) {
 		assert.Nil(t, token, "token should be nil")
 		assert.EqualError(t,
 			err,
-			`failed to list access tokens: 401 API error: unauthorized`,
+			`failed to list access tokens: HTTP 401: unauthorized`,
 		)
 	})
 }
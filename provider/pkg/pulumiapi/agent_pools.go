@@ -18,8 +18,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"net/url"
-	"path"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
 
 const (
@@ -31,23 +31,25 @@ type AgentPoolClient interface {
 	UpdateAgentPool(ctx context.Context, agentPoolID, orgName, name, description string) error
 	DeleteAgentPool(ctx context.Context, agentPoolID, orgName string, forceDestroy bool) error
 	GetAgentPool(ctx context.Context, agentPoolID, orgName string) (*AgentPool, error)
+	ListAgentPools(ctx context.Context, orgName string) ([]AgentPoolSummary, error)
 }
 
-type AgentPool struct {
+// AgentPoolSummary is one entry of an organization's agent pool list.
+type AgentPoolSummary struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
-	TokenValue  string `json:"tokenValue"`
+	Created     int64  `json:"created"`
+	LastSeen    int64  `json:"lastSeen"`
+	Status      string `json:"status"`
+	IsDefault   bool   `json:"isDefault"`
 }
 
-type createAgentPoolResponse struct {
-	ID         string `json:"id"`
-	TokenValue string `json:"tokenValue"`
-}
-
-type createUpdateAgentPoolRequest struct {
+type AgentPool struct {
+	ID          string `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
+	TokenValue  string `json:"tokenValue"`
 }
 
 func (c *Client) CreateAgentPool(ctx context.Context, orgName, name, description string) (*AgentPool, error) {
@@ -60,17 +62,12 @@ func (c *Client) CreateAgentPool(ctx context.Context, orgName, name, description
 		return nil, errors.New("empty name")
 	}
 
-	apiPath := path.Join("orgs", orgName, "agent-pools")
-
-	createReq := createUpdateAgentPoolRequest{
+	createReq := apitype.CreateOrgAgentPoolRequest{
 		Name:        name,
 		Description: description,
 	}
 
-	var createRes createAgentPoolResponse
-
-	_, err := c.do(ctx, http.MethodPost, apiPath, createReq, &createRes)
-
+	createRes, err := c.SDK.CreateOrgAgentPool(ctx, orgName, createReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create agent pool: %w", err)
 	}
@@ -97,15 +94,13 @@ func (c *Client) UpdateAgentPool(ctx context.Context, agentPoolID, orgName, name
 		return errors.New("empty name")
 	}
 
-	apiPath := path.Join("orgs", orgName, "agent-pools", agentPoolID)
-
-	updateReq := createUpdateAgentPoolRequest{
+	updateReq := apitype.CreateOrgAgentPoolRequest{
 		Name:        name,
 		Description: description,
 	}
 
-	_, err := c.do(ctx, http.MethodPatch, apiPath, updateReq, nil)
-	if err != nil {
+	_, err := c.SDK.PatchOrgAgentPool(ctx, orgName, agentPoolID, updateReq)
+	if err := ignoreNoContent(err); err != nil {
 		return fmt.Errorf("failed to update agent pool: %w", err)
 	}
 	return nil
@@ -120,14 +115,11 @@ func (c *Client) DeleteAgentPool(ctx context.Context, agentPoolID, orgName strin
 		return errors.New("orgName length must be greater than zero")
 	}
 
-	apiPath := path.Join("orgs", orgName, "agent-pools", agentPoolID)
-
-	var err error
+	var force *bool
 	if forceDestroy {
-		_, err = c.doWithQuery(ctx, http.MethodDelete, apiPath, url.Values{"force": []string{trueValue}}, nil, nil)
-	} else {
-		_, err = c.do(ctx, http.MethodDelete, apiPath, nil, nil)
+		force = &forceDestroy
 	}
+	err := c.SDK.DeleteOrgAgentPool(ctx, orgName, agentPoolID, force)
 	if err != nil {
 		return fmt.Errorf("failed to delete agent pool %q: %w", agentPoolID, err)
 	}
@@ -136,10 +128,7 @@ func (c *Client) DeleteAgentPool(ctx context.Context, agentPoolID, orgName strin
 }
 
 func (c *Client) GetAgentPool(ctx context.Context, agentPoolID, orgName string) (*AgentPool, error) {
-	apiPath := path.Join("orgs", orgName, "agent-pools", agentPoolID)
-
-	var pool AgentPool
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &pool)
+	pool, err := c.SDK.GetAgentPool(ctx, orgName, agentPoolID)
 	if err != nil {
 		statusCode := GetErrorStatusCode(err)
 		if statusCode == http.StatusNotFound {
@@ -149,5 +138,34 @@ func (c *Client) GetAgentPool(ctx context.Context, agentPoolID, orgName string)
 		return nil, fmt.Errorf("failed to get agent pool: %w", err)
 	}
 
-	return &pool, nil
+	return &AgentPool{
+		ID:          pool.ID,
+		Name:        pool.Name,
+		Description: pool.Description,
+	}, nil
+}
+
+func (c *Client) ListAgentPools(ctx context.Context, orgName string) ([]AgentPoolSummary, error) {
+	if len(orgName) == 0 {
+		return nil, errors.New("empty orgName")
+	}
+
+	listRes, err := c.SDK.ListOrgAgentPool(ctx, orgName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agent pools: %w", err)
+	}
+
+	pools := make([]AgentPoolSummary, 0, len(listRes.AgentPools))
+	for _, pool := range listRes.AgentPools {
+		pools = append(pools, AgentPoolSummary{
+			ID:          pool.ID,
+			Name:        pool.Name,
+			Description: pool.Description,
+			Created:     pool.Created,
+			LastSeen:    pool.LastSeen,
+			Status:      string(pool.Status),
+			IsDefault:   pool.IsDefault,
+		})
+	}
+	return pools, nil
 }
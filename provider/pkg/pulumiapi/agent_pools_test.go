@@ -38,7 +38,7 @@ func TestDeleteAgentPool(t *testing.T) {
 		})
 		assert.EqualError(t,
 			c.DeleteAgentPool(teamCtx, agentPoolID, orgName, false),
-			fmt.Sprintf(`failed to delete agent pool "%s": 404 API error: agent pool not found`, testAgentPoolID),
+			fmt.Sprintf(`failed to delete agent pool "%s": HTTP 404: agent pool not found`, testAgentPoolID),
 		)
 	})
 
@@ -92,7 +92,7 @@ func TestCreateAgentPool(t *testing.T) {
 		assert.Nil(t, token, "agent pool should be nil")
 		assert.EqualError(t,
 			err,
-			`failed to create agent pool: 401 API error: unauthorized`,
+			`failed to create agent pool: HTTP 401: unauthorized`,
 		)
 	})
 }
@@ -139,7 +139,7 @@ func TestGetAgentPool(t *testing.T) {
 		assert.Nil(t, token, "agent pool should be nil")
 		assert.EqualError(t,
 			err,
-			`failed to get agent pool: 401 API error: unauthorized`,
+			`failed to get agent pool: HTTP 401: unauthorized`,
 		)
 	})
 }
@@ -17,7 +17,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"path"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
 
 // In the future we might have different approval rule entities, for now it's only Environments
@@ -157,32 +158,134 @@ type EnvironmentEntity struct {
 	Name    string `json:"name"`
 }
 
+// toAPI converts a rule input into the generated SDK's polymorphic rule type.
+func (in ChangeGateRuleInput) toAPI() (apitype.ChangeGateRuleInput, error) {
+	if in.RuleType != ChangeGateRuleTypeApproval {
+		return nil, fmt.Errorf("unsupported rule type %q", in.RuleType)
+	}
+	approvers := make([]apitype.ApprovalRuleEligibilityInput, 0, len(in.EligibleApprovers))
+	for _, approver := range in.EligibleApprovers {
+		switch approver.EligibilityType {
+		case ApprovalRuleEligibilityTypeTeam:
+			approvers = append(approvers, apitype.ApprovalRuleEligibilityInputTeamBuilder{
+				TeamName: approver.TeamName,
+			}.Build())
+		case ApprovalRuleEligibilityTypeUser:
+			approvers = append(approvers, apitype.ApprovalRuleEligibilityInputUserBuilder{
+				UserLogin: approver.User,
+			}.Build())
+		case ApprovalRuleEligibilityTypePermission:
+			approvers = append(approvers, apitype.ApprovalRuleEligibilityInputPermissionBuilder{
+				Permission: apitype.RbacPermission(approver.RbacPermission),
+			}.Build())
+		default:
+			return nil, fmt.Errorf("unsupported eligibility type %q", approver.EligibilityType)
+		}
+	}
+	return apitype.ChangeGateApprovalRuleInputBuilder{
+		NumApprovalsRequired:      int64(in.NumApprovalsRequired),
+		AllowSelfApproval:         in.AllowSelfApproval,
+		RequireReapprovalOnChange: in.RequireReapprovalOnChange,
+		EligibleApprovers:         approvers,
+	}.Build(), nil
+}
+
+func (in ChangeGateTargetInput) toAPI() apitype.ChangeGateTargetInput {
+	actionTypes := make([]apitype.ChangeGateTargetActionType, 0, len(in.ActionTypes))
+	for _, actionType := range in.ActionTypes {
+		actionTypes = append(actionTypes, apitype.ChangeGateTargetActionType(actionType))
+	}
+	return apitype.ChangeGateTargetInput{
+		EntityType:    apitype.ChangeGateTargetEntityType(in.EntityType),
+		QualifiedName: in.QualifiedName,
+		ActionTypes:   actionTypes,
+	}
+}
+
+// approvalRuleFromAPI converts the generated SDK's change gate into an
+// ApprovalRule.
+func approvalRuleFromAPI(gate *apitype.ChangeGate) *ApprovalRule {
+	rule := &ApprovalRule{
+		ID:      gate.ID,
+		Name:    gate.Name,
+		Enabled: gate.Enabled,
+	}
+	if approval, ok := gate.Rule.(apitype.ChangeGateApprovalRuleOutput); ok {
+		rule.Rule = ChangeGateRuleOutput{
+			NumApprovalsRequired:      int(approval.NumApprovalsRequired()),
+			AllowSelfApproval:         approval.AllowSelfApproval(),
+			RequireReapprovalOnChange: approval.RequireReapprovalOnChange(),
+			EligibleApproverOutputs:   []EligibleApproverOutput{},
+		}
+		for _, approver := range approval.EligibleApprovers() {
+			var out EligibleApproverOutput
+			switch approver := approver.(type) {
+			case apitype.ApprovalRuleEligibilityOutputTeam:
+				out.EligibilityType = ApprovalRuleEligibilityTypeTeam
+				out.TeamName = approver.Name()
+			case apitype.ApprovalRuleEligibilityOutputUser:
+				out.EligibilityType = ApprovalRuleEligibilityTypeUser
+				out.User = UserInfo{
+					Name:        approver.User().Name,
+					GithubLogin: approver.User().GitHubLogin,
+				}
+			case apitype.ApprovalRuleEligibilityOutputPermission:
+				out.EligibilityType = ApprovalRuleEligibilityTypePermission
+				out.RbacPermission = string(approver.Permission())
+			default:
+				continue
+			}
+			rule.Rule.EligibleApproverOutputs = append(rule.Rule.EligibleApproverOutputs, out)
+		}
+	}
+
+	target := &ChangeGateTargetOutput{
+		ActionTypes:   make([]string, 0, len(gate.Target.ActionTypes)),
+		QualifiedName: gate.Target.QualifiedName,
+		EntityType:    string(gate.Target.EntityType),
+	}
+	for _, actionType := range gate.Target.ActionTypes {
+		target.ActionTypes = append(target.ActionTypes, string(actionType))
+	}
+	if env, ok := gate.Target.EntityInfo.(apitype.TargetEntityEnvironment); ok {
+		target.EntityInfo = &ChangeGateTargetEntityInfo{
+			Environment: &EnvironmentEntity{Project: env.Project(), Name: env.Name()},
+		}
+	}
+	rule.Target = target
+	return rule
+}
+
 func (c *Client) CreateEnvironmentApprovalRule(
 	ctx context.Context,
 	orgName string,
 	req CreateApprovalRuleRequest,
 ) (*ApprovalRule, error) {
-	apiPath := path.Join("change-gates", orgName)
+	ruleInput, err := req.Rule.toAPI()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create approval rule: %w", err)
+	}
 
-	var rule ApprovalRule
-	_, err := c.do(ctx, http.MethodPost, apiPath, req, &rule)
+	gate, err := c.SDK.CreateGate(ctx, orgName, apitype.CreateChangeGateRequest{
+		Name:    req.Name,
+		Enabled: req.Enabled,
+		Rule:    ruleInput,
+		Target:  req.Target.toAPI(),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create approval rule: %w", err)
 	}
 
-	return &rule, nil
+	return approvalRuleFromAPI(gate), nil
 }
 
 func (c *Client) GetEnvironmentApprovalRule(ctx context.Context, orgName string, ruleID string) (*ApprovalRule, error) {
-	apiPath := path.Join("change-gates", orgName, ruleID)
-
-	var rule ApprovalRule
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &rule)
+	gate, err := c.SDK.ReadGate(ctx, orgName, ruleID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get approval rule: %w", err)
 	}
 
-	return &rule, nil
+	return approvalRuleFromAPI(gate), nil
 }
 
 func (c *Client) UpdateEnvironmentApprovalRule(
@@ -191,23 +294,28 @@ func (c *Client) UpdateEnvironmentApprovalRule(
 	ruleID string,
 	req UpdateApprovalRuleRequest,
 ) (*ApprovalRule, error) {
-	apiPath := path.Join("change-gates", orgName, ruleID)
+	ruleInput, err := req.Rule.toAPI()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update approval rule: %w", err)
+	}
 
-	var rule ApprovalRule
-	_, err := c.do(ctx, http.MethodPut, apiPath, req, &rule)
+	gate, err := c.SDK.UpdateGate(ctx, orgName, ruleID, apitype.UpdateChangeGateRequest{
+		Name:    req.Name,
+		Enabled: req.Enabled,
+		Target:  req.Target.toAPI(),
+		Rule:    ruleInput,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to update approval rule: %w", err)
 	}
 
-	return &rule, nil
+	return approvalRuleFromAPI(gate), nil
 }
 
 func (c *Client) DeleteEnvironmentApprovalRule(ctx context.Context, orgName string, ruleID string) error {
-	apiPath := path.Join("change-gates", orgName, ruleID)
-
-	result, err := c.do(ctx, http.MethodDelete, apiPath, nil, nil)
+	err := c.SDK.DeleteGate(ctx, orgName, ruleID)
 	if err != nil {
-		if result.StatusCode == 404 {
+		if GetErrorStatusCode(err) == http.StatusNotFound {
 			return nil
 		}
 		return fmt.Errorf("failed to delete approval rule: %w", err)
@@ -0,0 +1,55 @@
+package pulumiapi
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/cassette"
+)
+
+// cassetteOrg owns everything recorded under testdata/cassettes; see
+// cassette.New for how to re-record.
+const cassetteOrg = "service-provider-test-org"
+
+// newCassetteClient returns a Client whose traffic goes through the cassette
+// at testdata/cassettes/name. The token and URL only matter while recording,
+// where cassette.New substitutes the real ones.
+func newCassetteClient(t *testing.T, name string) (*Client, *cassette.Cassette) {
+	t.Helper()
+	tape := cassette.New(t, filepath.Join("testdata", "cassettes", name))
+	c, err := NewClient(&http.Client{Transport: tape}, "", "")
+	require.NoError(t, err)
+	return c, tape
+}
+
+func TestAgentPoolLifecycleCassette(t *testing.T) {
+	c, tape := newCassetteClient(t, "agent_pool_lifecycle.json")
+
+	pool, err := c.CreateAgentPool(ctx, cassetteOrg, "cassette-runners", "recorded")
+	require.NoError(t, err)
+	require.NotEmpty(t, pool.ID)
+	if !tape.Recording() {
+		assert.Equal(t, cassette.Redacted, pool.TokenValue)
+	}
+
+	got, err := c.GetAgentPool(ctx, pool.ID, cassetteOrg)
+	require.NoError(t, err)
+	assert.Equal(t, "cassette-runners", got.Name)
+	assert.Equal(t, "recorded", got.Description)
+
+	require.NoError(t, c.UpdateAgentPool(ctx, pool.ID, cassetteOrg, "cassette-runners", "re-recorded"))
+
+	got, err = c.GetAgentPool(ctx, pool.ID, cassetteOrg)
+	require.NoError(t, err)
+	assert.Equal(t, "re-recorded", got.Description)
+
+	require.NoError(t, c.DeleteAgentPool(ctx, pool.ID, cassetteOrg, false))
+
+	got, err = c.GetAgentPool(ctx, pool.ID, cassetteOrg)
+	require.NoError(t, err)
+	assert.Nil(t, got, "a deleted pool reads as not found")
+}
@@ -0,0 +1,83 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumiapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
+)
+
+// ChangeRequestClient follows and settles change requests, such as the ones
+// proposing an update to an ESC environment behind an approval gate.
+type ChangeRequestClient interface {
+	GetChangeRequest(ctx context.Context, orgName, changeRequestID string) (*ChangeRequest, error)
+	ApplyChangeRequest(ctx context.Context, orgName, changeRequestID string) error
+	CloseChangeRequest(ctx context.Context, orgName, changeRequestID, comment string) error
+}
+
+// ChangeRequest is the state of a change request. Approved reports whether
+// every gate that applies to it is satisfied, so it can be applied.
+type ChangeRequest struct {
+	ID                   string
+	Status               string
+	Approved             bool
+	LatestRevisionNumber int64
+}
+
+// Change request statuses. A draft becomes open once submitted, and stays
+// open until it is applied or closed.
+const (
+	ChangeRequestStatusDraft   = "draft"
+	ChangeRequestStatusOpen    = "open"
+	ChangeRequestStatusApplied = "applied"
+	ChangeRequestStatusClosed  = "closed"
+)
+
+// GetChangeRequest returns the change request, or nil if it does not exist.
+func (c *Client) GetChangeRequest(ctx context.Context, orgName, changeRequestID string) (*ChangeRequest, error) {
+	resp, err := c.SDK.Get(ctx, orgName, changeRequestID)
+	if err != nil {
+		if GetErrorStatusCode(err) == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get change request %s in %s: %w", changeRequestID, orgName, err)
+	}
+	return &ChangeRequest{
+		ID:                   resp.ID,
+		Status:               resp.Status,
+		Approved:             resp.GateEvaluation.Satisfied,
+		LatestRevisionNumber: resp.LatestRevisionNumber,
+	}, nil
+}
+
+// ApplyChangeRequest applies an approved change request.
+func (c *Client) ApplyChangeRequest(ctx context.Context, orgName, changeRequestID string) error {
+	if _, err := c.SDK.Apply(ctx, orgName, changeRequestID); err != nil {
+		return fmt.Errorf("failed to apply change request %s in %s: %w", changeRequestID, orgName, err)
+	}
+	return nil
+}
+
+// CloseChangeRequest closes a change request without applying it.
+func (c *Client) CloseChangeRequest(ctx context.Context, orgName, changeRequestID, comment string) error {
+	err := c.SDK.Close(ctx, orgName, changeRequestID, apitype.CloseChangeRequestRequest{Comment: optString(comment)})
+	if err != nil {
+		return fmt.Errorf("failed to close change request %s in %s: %w", changeRequestID, orgName, err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumiapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const changeRequestPath = "/api/change-requests/" + testOrgName + "/cr-1"
+
+func TestGetChangeRequest(t *testing.T) {
+	t.Run("Happy Path", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodGet,
+			ExpectedReqPath:   changeRequestPath,
+			ResponseCode:      http.StatusOK,
+			ResponseBody: map[string]any{
+				"id":                   "cr-1",
+				"status":               "open",
+				"latestRevisionNumber": 2,
+				"gateEvaluation":       map[string]any{"satisfied": true},
+			},
+		})
+		got, err := c.GetChangeRequest(ctx, testOrgName, "cr-1")
+		require.NoError(t, err)
+		assert.Equal(t, &ChangeRequest{ID: "cr-1", Status: "open", Approved: true, LatestRevisionNumber: 2}, got)
+	})
+
+	t.Run("404", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodGet,
+			ExpectedReqPath:   changeRequestPath,
+			ResponseCode:      http.StatusNotFound,
+			ResponseBody:      ErrorResponse{Message: "not found"},
+		})
+		got, err := c.GetChangeRequest(ctx, testOrgName, "cr-1")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestApplyChangeRequest(t *testing.T) {
+	c := startTestServer(t, testServerConfig{
+		ExpectedReqMethod: http.MethodPost,
+		ExpectedReqPath:   changeRequestPath + "/apply",
+		ResponseCode:      http.StatusOK,
+		ResponseBody:      map[string]any{"entityUrl": "/api/esc/environments/anOrg/p/dev"},
+	})
+	assert.NoError(t, c.ApplyChangeRequest(ctx, testOrgName, "cr-1"))
+}
+
+func TestCloseChangeRequest(t *testing.T) {
+	c := startTestServer(t, testServerConfig{
+		ExpectedReqMethod: http.MethodPost,
+		ExpectedReqPath:   changeRequestPath + "/close",
+		ExpectedReqBody:   map[string]any{"comment": "superseded"},
+		ResponseCode:      http.StatusNoContent,
+	})
+	assert.NoError(t, c.CloseChangeRequest(ctx, testOrgName, "cr-1", "superseded"))
+}
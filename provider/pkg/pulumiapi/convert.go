@@ -0,0 +1,61 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pulumiapi
+
+import (
+	"encoding/json"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype/jsonvalue"
+)
+
+// Helpers for translating between the hand-written pulumiapi types and the
+// generated SDK's request and response types.
+
+// optString returns &s, or nil when s is empty — the shape the generated SDK
+// takes optional query parameters (such as continuation tokens) in.
+func optString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// optValue returns p as a defined JSON value, or an undefined (omitted) one
+// when p is nil.
+func optValue[T any](p *T) jsonvalue.Value[T] {
+	if p == nil {
+		return nil
+	}
+	return jsonvalue.NotNull(*p)
+}
+
+// nonZeroValue returns v as a defined JSON value, or an undefined (omitted)
+// one when v is the zero value — the generated-type spelling of omitempty.
+func nonZeroValue[T comparable](v T) jsonvalue.Value[T] {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return jsonvalue.NotNull(v)
+}
+
+// remarshal decodes in (typically a loosely typed map from a generated
+// response) into out by round-tripping it through JSON.
+func remarshal(in, out any) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
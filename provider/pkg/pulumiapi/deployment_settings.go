@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"path"
 
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
@@ -79,14 +78,37 @@ type SecretValue = apitype.SecretValue
 
 type CacheOptions = apitype.CacheOptions
 
-func (c *Client) CreateDeploymentSettings(
+// deploymentSettingsRoute is the PUT target for CreateDeploymentSettings and
+// UpdateDeploymentSettings. The generated ReplaceDeploymentSettings takes a
+// DeploymentSettingsRequest, which wraps every field in an optional Value;
+// the endpoint accepts the DeploymentSettings shape the provider builds, so
+// it is sent as is through Do.
+const deploymentSettingsRoute = "/api/stacks/{orgName}/{projectName}/{stackName}/deployments/settings"
+
+func (c *Client) putDeploymentSettings(
 	ctx context.Context,
 	stack StackIdentifier,
 	ds DeploymentSettings,
 ) (*DeploymentSettings, error) {
-	apiPath := path.Join("stacks", stack.OrgName, stack.ProjectName, stack.StackName, "deployments", "settings")
+	pathParams := map[string]any{
+		"orgName":     stack.OrgName,
+		"projectName": stack.ProjectName,
+		"stackName":   stack.StackName,
+	}
 	var resultDS = &DeploymentSettings{}
-	_, err := c.do(ctx, http.MethodPut, apiPath, ds, resultDS)
+	err := c.SDK.Do(ctx, http.MethodPut, deploymentSettingsRoute, pathParams, nil, ds, resultDS)
+	if err != nil {
+		return nil, err
+	}
+	return resultDS, nil
+}
+
+func (c *Client) CreateDeploymentSettings(
+	ctx context.Context,
+	stack StackIdentifier,
+	ds DeploymentSettings,
+) (*DeploymentSettings, error) {
+	resultDS, err := c.putDeploymentSettings(ctx, stack, ds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create deployment settings for stack (%s): %w", stack.String(), err)
 	}
@@ -98,9 +120,7 @@ func (c *Client) UpdateDeploymentSettings(
 	stack StackIdentifier,
 	ds DeploymentSettings,
 ) (*DeploymentSettings, error) {
-	apiPath := path.Join("stacks", stack.OrgName, stack.ProjectName, stack.StackName, "deployments", "settings")
-	var resultDS = &DeploymentSettings{}
-	_, err := c.do(ctx, http.MethodPut, apiPath, ds, resultDS)
+	resultDS, err := c.putDeploymentSettings(ctx, stack, ds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update deployment settings for stack (%s): %w", stack.String(), err)
 	}
@@ -108,11 +128,7 @@ func (c *Client) UpdateDeploymentSettings(
 }
 
 func (c *Client) GetDeploymentSettings(ctx context.Context, stack StackIdentifier) (*DeploymentSettings, error) {
-	apiPath := path.Join(
-		"stacks", stack.OrgName, stack.ProjectName, stack.StackName, "deployments", "settings",
-	)
-	var ds DeploymentSettings
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &ds)
+	ds, err := c.SDK.GetDeploymentSettings(ctx, stack.OrgName, stack.ProjectName, stack.StackName)
 	if err != nil {
 		statusCode := GetErrorStatusCode(err)
 		if statusCode == http.StatusNotFound {
@@ -121,15 +137,12 @@ func (c *Client) GetDeploymentSettings(ctx context.Context, stack StackIdentifie
 		}
 		return nil, fmt.Errorf("failed to get deployment settings for stack (%s): %w", stack.String(), err)
 	}
-	return &ds, nil
+	return ds, nil
 }
 
 func (c *Client) DeleteDeploymentSettings(ctx context.Context, stack StackIdentifier) error {
-	apiPath := path.Join(
-		"stacks", stack.OrgName, stack.ProjectName, stack.StackName, "deployments", "settings",
-	)
-	_, err := c.do(ctx, http.MethodDelete, apiPath, nil, nil)
-	if err != nil {
+	_, err := c.SDK.DeleteDeploymentSettings(ctx, stack.OrgName, stack.ProjectName, stack.StackName)
+	if err := ignoreNoContent(err); err != nil {
 		return fmt.Errorf("failed to delete deployment settings for stack (%s): %w", stack.String(), err)
 	}
 	return nil
@@ -0,0 +1,232 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumiapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	sdkapitype "github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype/jsonvalue"
+)
+
+// DeploymentClient triggers Pulumi Deployments runs of a stack, follows them
+// to completion, and pauses deployments of a stack or a whole organization.
+type DeploymentClient interface {
+	CreateDeployment(ctx context.Context, stack StackIdentifier, req CreateStackDeploymentRequest) (*Deployment, error)
+	GetDeployment(ctx context.Context, stack StackIdentifier, deploymentID string) (*Deployment, error)
+	GetDeploymentUpdateURL(ctx context.Context, stack StackIdentifier, deployment Deployment) (string, error)
+	GetDeploymentLogTail(ctx context.Context, stack StackIdentifier, deploymentID string, lines int) ([]string, error)
+	PauseOrgDeployments(ctx context.Context, orgName string) error
+	ResumeOrgDeployments(ctx context.Context, orgName string) error
+	OrgDeploymentsPaused(ctx context.Context, orgName string) (bool, error)
+	PauseStackDeployments(ctx context.Context, stack StackIdentifier) error
+	ResumeStackDeployments(ctx context.Context, stack StackIdentifier) error
+	StackDeploymentsPaused(ctx context.Context, stack StackIdentifier) (bool, error)
+}
+
+// CreateStackDeploymentRequest starts an on-demand deployment. Operation
+// context fields override the matching stack deployment settings, which are
+// inherited unless InheritSettings is false.
+type CreateStackDeploymentRequest struct {
+	Operation        string
+	InheritSettings  *bool
+	OperationContext *OperationContext
+}
+
+// Deployment is a single deployment run. ConsoleURL is only known for runs
+// created through CreateDeployment.
+type Deployment struct {
+	ID         string
+	Version    int64
+	Status     string
+	ConsoleURL string
+}
+
+// Terminal deployment statuses. A run superseded by a newer one, when the
+// stack skips intermediate deployments, ends up skipped.
+const (
+	DeploymentStatusSucceeded = string(apitype.JobStatusSucceeded)
+	DeploymentStatusFailed    = string(apitype.JobStatusFailed)
+	DeploymentStatusSkipped   = string(apitype.JobStatusSkipped)
+)
+
+func (req CreateStackDeploymentRequest) toAPI() (apitype.CreateDeploymentRequest, error) {
+	deployment := apitype.CreateDeploymentRequest{
+		Op:              apitype.PulumiOperation(req.Operation),
+		InheritSettings: req.InheritSettings,
+	}
+	if req.OperationContext != nil {
+		// The settings and request shapes share a wire format; the request
+		// one wraps every field in an optional value.
+		var operation apitype.OperationContextRequest
+		if err := remarshal(req.OperationContext, &operation); err != nil {
+			return apitype.CreateDeploymentRequest{}, fmt.Errorf("encoding operation context: %w", err)
+		}
+		deployment.Operation = jsonvalue.NotNull(operation)
+	}
+	return deployment, nil
+}
+
+func (c *Client) CreateDeployment(
+	ctx context.Context,
+	stack StackIdentifier,
+	req CreateStackDeploymentRequest,
+) (*Deployment, error) {
+	body, err := req.toAPI()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deployment for stack (%s): %w", stack.String(), err)
+	}
+	resp, err := c.SDK.CreateAPIDeploymentHandlerV2(ctx, stack.OrgName, stack.ProjectName, stack.StackName, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deployment for stack (%s): %w", stack.String(), err)
+	}
+	return &Deployment{
+		ID:         resp.ID,
+		Version:    resp.Version,
+		Status:     string(apitype.JobStatusNotStarted),
+		ConsoleURL: resp.ConsoleURL,
+	}, nil
+}
+
+// GetDeployment returns the deployment run, or nil if it does not exist.
+func (c *Client) GetDeployment(ctx context.Context, stack StackIdentifier, deploymentID string) (*Deployment, error) {
+	resp, err := c.SDK.GetDeployment(ctx, stack.OrgName, stack.ProjectName, stack.StackName, deploymentID)
+	if err != nil {
+		if GetErrorStatusCode(err) == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get deployment %s for stack (%s): %w", deploymentID, stack.String(), err)
+	}
+	return &Deployment{ID: resp.ID, Version: resp.Version, Status: string(resp.Status)}, nil
+}
+
+// GetDeploymentUpdateURL returns the console URL of the last update or
+// preview the deployment ran, or "" when it ran none. The URL is derived
+// from the deployment's own ConsoleURL, which must be set.
+func (c *Client) GetDeploymentUpdateURL(
+	ctx context.Context,
+	stack StackIdentifier,
+	deployment Deployment,
+) (string, error) {
+	updates, err := c.SDK.GetDeploymentUpdates(ctx, stack.OrgName, stack.ProjectName, stack.StackName, deployment.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get updates of deployment %s for stack (%s): %w",
+			deployment.ID, stack.String(), err)
+	}
+	// A deployment's console URL is {stack URL}/deployments/{version}.
+	stackURL, _, ok := strings.Cut(deployment.ConsoleURL, "/deployments/")
+	if updates == nil || len(*updates) == 0 || !ok {
+		return "", nil
+	}
+	last := (*updates)[len(*updates)-1]
+	if last.Info.Kind == sdkapitype.PreviewUpdate {
+		return fmt.Sprintf("%s/previews/%s", stackURL, last.UpdateID), nil
+	}
+	return fmt.Sprintf("%s/updates/%d", stackURL, last.Version), nil
+}
+
+// GetDeploymentLogTail returns up to the last lines log lines of the
+// deployment, step headers included.
+func (c *Client) GetDeploymentLogTail(
+	ctx context.Context,
+	stack StackIdentifier,
+	deploymentID string,
+	lines int,
+) ([]string, error) {
+	var tail []string
+	var token *string
+	for {
+		resp, err := c.SDK.GetDeploymentLogs(ctx, stack.OrgName, stack.ProjectName, stack.StackName, deploymentID,
+			token, nil, nil, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get logs of deployment %s for stack (%s): %w",
+				deploymentID, stack.String(), err)
+		}
+		logs, ok := (*resp).(apitype.DeploymentLogs)
+		if !ok {
+			return nil, fmt.Errorf("failed to get logs of deployment %s for stack (%s): unexpected response %T",
+				deploymentID, stack.String(), *resp)
+		}
+		for _, l := range logs.Lines() {
+			line := l.Line
+			if l.Header != "" {
+				line = l.Header
+			}
+			tail = append(tail, strings.TrimRight(line, "\r\n"))
+		}
+		if len(tail) > lines {
+			tail = tail[len(tail)-lines:]
+		}
+		if logs.NextToken() == "" {
+			return tail, nil
+		}
+		token = optString(logs.NextToken())
+	}
+}
+
+// PauseOrgDeployments stops every stack in the organization from starting
+// new deployments until ResumeOrgDeployments is called.
+func (c *Client) PauseOrgDeployments(ctx context.Context, orgName string) error {
+	if err := c.SDK.PauseOrgDeployments(ctx, orgName); err != nil {
+		return fmt.Errorf("failed to pause deployments for organization %s: %w", orgName, err)
+	}
+	return nil
+}
+
+func (c *Client) ResumeOrgDeployments(ctx context.Context, orgName string) error {
+	if err := c.SDK.ResumeOrgDeployments(ctx, orgName); err != nil {
+		return fmt.Errorf("failed to resume deployments for organization %s: %w", orgName, err)
+	}
+	return nil
+}
+
+func (c *Client) OrgDeploymentsPaused(ctx context.Context, orgName string) (bool, error) {
+	metadata, err := c.SDK.OrgDeploymentsMetadata(ctx, orgName)
+	if err != nil {
+		return false, fmt.Errorf("failed to get deployments metadata for organization %s: %w", orgName, err)
+	}
+	return metadata.Paused, nil
+}
+
+// PauseStackDeployments stops the stack from starting new deployments until
+// ResumeStackDeployments is called.
+func (c *Client) PauseStackDeployments(ctx context.Context, stack StackIdentifier) error {
+	if err := c.SDK.PauseStackDeployments(ctx, stack.OrgName, stack.ProjectName, stack.StackName); err != nil {
+		return fmt.Errorf("failed to pause deployments for stack (%s): %w", stack.String(), err)
+	}
+	return nil
+}
+
+func (c *Client) ResumeStackDeployments(ctx context.Context, stack StackIdentifier) error {
+	if err := c.SDK.ResumeStackDeployments(ctx, stack.OrgName, stack.ProjectName, stack.StackName); err != nil {
+		return fmt.Errorf("failed to resume deployments for stack (%s): %w", stack.String(), err)
+	}
+	return nil
+}
+
+// StackDeploymentsPaused reports whether the stack itself is paused,
+// regardless of whether its organization is.
+func (c *Client) StackDeploymentsPaused(ctx context.Context, stack StackIdentifier) (bool, error) {
+	metadata, err := c.SDK.StackDeploymentsMetadata(ctx, stack.OrgName, stack.ProjectName, stack.StackName)
+	if err != nil {
+		return false, fmt.Errorf("failed to get deployments metadata for stack (%s): %w", stack.String(), err)
+	}
+	return metadata.StackPaused, nil
+}
@@ -0,0 +1,234 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumiapi
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deploymentsPath = "/api/stacks/organization/project/stack/deployments"
+
+func TestCreateDeployment(t *testing.T) {
+	s := StackIdentifier{OrgName: organizationKey, ProjectName: projectKey, StackName: stackKey}
+	inherit := true
+
+	c := startTestServer(t, testServerConfig{
+		ExpectedReqMethod: http.MethodPost,
+		ExpectedReqPath:   deploymentsPath,
+		ExpectedReqBody: map[string]any{
+			"operation":       "update",
+			"inheritSettings": true,
+			"operationContext": map[string]any{
+				"preRunCommands":       []string{"make deps"},
+				"environmentVariables": map[string]any{"PLAIN": "x", "TOKEN": map[string]any{"secret": "s3cret"}},
+				"options":              map[string]any{"skipInstallDependencies": true},
+			},
+		},
+		ResponseCode: http.StatusAccepted,
+		ResponseBody: map[string]any{
+			"id":         "dep-1",
+			"version":    4,
+			"consoleUrl": "https://app.pulumi.com/organization/project/stack/deployments/4",
+		},
+	})
+	got, err := c.CreateDeployment(ctx, s, CreateStackDeploymentRequest{
+		Operation:       "update",
+		InheritSettings: &inherit,
+		OperationContext: &OperationContext{
+			PreRunCommands: []string{"make deps"},
+			EnvironmentVariables: map[string]SecretValue{
+				"PLAIN": {Value: "x"},
+				"TOKEN": {Value: "s3cret", Secret: true},
+			},
+			Options: &OperationContextOptions{SkipInstallDependencies: true},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &Deployment{
+		ID:         "dep-1",
+		Version:    4,
+		Status:     "not-started",
+		ConsoleURL: "https://app.pulumi.com/organization/project/stack/deployments/4",
+	}, got)
+}
+
+func TestGetDeployment(t *testing.T) {
+	s := StackIdentifier{OrgName: organizationKey, ProjectName: projectKey, StackName: stackKey}
+
+	t.Run("Happy Path", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodGet,
+			ExpectedReqPath:   deploymentsPath + "/dep-1",
+			ResponseCode:      http.StatusOK,
+			ResponseBody:      map[string]any{"id": "dep-1", "version": 4, "status": "running"},
+		})
+		got, err := c.GetDeployment(ctx, s, "dep-1")
+		require.NoError(t, err)
+		assert.Equal(t, &Deployment{ID: "dep-1", Version: 4, Status: "running"}, got)
+	})
+
+	t.Run("404", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodGet,
+			ExpectedReqPath:   deploymentsPath + "/dep-1",
+			ResponseCode:      http.StatusNotFound,
+			ResponseBody:      ErrorResponse{Message: "not found"},
+		})
+		got, err := c.GetDeployment(ctx, s, "dep-1")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestGetDeploymentUpdateURL(t *testing.T) {
+	s := StackIdentifier{OrgName: organizationKey, ProjectName: projectKey, StackName: stackKey}
+	deployment := Deployment{
+		ID:         "dep-1",
+		ConsoleURL: "https://app.pulumi.com/organization/project/stack/deployments/4",
+	}
+
+	for name, tc := range map[string]struct {
+		updates []map[string]any
+		want    string
+	}{
+		"update": {
+			updates: []map[string]any{{"updateID": "u-1", "version": 12, "info": map[string]any{"kind": "update"}}},
+			want:    "https://app.pulumi.com/organization/project/stack/updates/12",
+		},
+		"preview": {
+			updates: []map[string]any{{"updateID": "u-1", "version": 0, "info": map[string]any{"kind": "preview"}}},
+			want:    "https://app.pulumi.com/organization/project/stack/previews/u-1",
+		},
+		"none": {updates: []map[string]any{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := startTestServer(t, testServerConfig{
+				ExpectedReqMethod: http.MethodGet,
+				ExpectedReqPath:   deploymentsPath + "/dep-1/updates",
+				ResponseCode:      http.StatusOK,
+				ResponseBody:      tc.updates,
+			})
+			got, err := c.GetDeploymentUpdateURL(ctx, s, deployment)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestGetDeploymentLogTail(t *testing.T) {
+	s := StackIdentifier{OrgName: organizationKey, ProjectName: projectKey, StackName: stackKey}
+	pages := []map[string]any{
+		{
+			"__type": "DeploymentLogs",
+			"lines": []map[string]any{
+				{"header": "Get source", "timestamp": "2026-01-01T00:00:00Z"},
+				{"line": "cloning\n", "timestamp": "2026-01-01T00:00:01Z"},
+			},
+			"nextToken": "1",
+		},
+		{
+			"__type": "DeploymentLogs",
+			"lines": []map[string]any{
+				{"header": "Pulumi up", "timestamp": "2026-01-01T00:00:02Z"},
+				{"line": "error: boom\n", "timestamp": "2026-01-01T00:00:03Z"},
+			},
+		},
+	}
+	c := startTestServerMulti(t, func(r *http.Request) (int, any) {
+		assert.Equal(t, deploymentsPath+"/dep-1/logs", r.URL.Path)
+		page := 0
+		if token := r.URL.Query().Get("continuationToken"); token != "" {
+			page, _ = strconv.Atoi(token)
+		}
+		return http.StatusOK, pages[page]
+	})
+
+	got, err := c.GetDeploymentLogTail(ctx, s, "dep-1", 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cloning", "Pulumi up", "error: boom"}, got)
+}
+
+func TestOrgDeploymentsPause(t *testing.T) {
+	const orgPath = "/api/orgs/organization/deployments"
+
+	t.Run("Pause", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPost,
+			ExpectedReqPath:   orgPath + "/pause",
+			ResponseCode:      http.StatusNoContent,
+		})
+		assert.NoError(t, c.PauseOrgDeployments(ctx, organizationKey))
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPost,
+			ExpectedReqPath:   orgPath + "/resume",
+			ResponseCode:      http.StatusNoContent,
+		})
+		assert.NoError(t, c.ResumeOrgDeployments(ctx, organizationKey))
+	})
+
+	t.Run("Paused", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodGet,
+			ExpectedReqPath:   orgPath + "/metadata",
+			ResponseCode:      http.StatusOK,
+			ResponseBody:      map[string]any{"paused": true, "concurrency": 1},
+		})
+		paused, err := c.OrgDeploymentsPaused(ctx, organizationKey)
+		require.NoError(t, err)
+		assert.True(t, paused)
+	})
+}
+
+func TestStackDeploymentsPause(t *testing.T) {
+	s := StackIdentifier{OrgName: organizationKey, ProjectName: projectKey, StackName: stackKey}
+
+	t.Run("Pause", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPost,
+			ExpectedReqPath:   deploymentsPath + "/pause",
+			ResponseCode:      http.StatusNoContent,
+		})
+		assert.NoError(t, c.PauseStackDeployments(ctx, s))
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPost,
+			ExpectedReqPath:   deploymentsPath + "/resume",
+			ResponseCode:      http.StatusNoContent,
+		})
+		assert.NoError(t, c.ResumeStackDeployments(ctx, s))
+	})
+
+	t.Run("Paused ignores the organization", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodGet,
+			ExpectedReqPath:   deploymentsPath + "/metadata",
+			ResponseCode:      http.StatusOK,
+			ResponseBody:      map[string]any{"paused": true, "stackPaused": false, "orgPaused": true},
+		})
+		paused, err := c.StackDeploymentsPaused(ctx, s)
+		require.NoError(t, err)
+		assert.False(t, paused)
+	})
+}
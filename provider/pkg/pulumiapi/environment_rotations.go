@@ -0,0 +1,159 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumiapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pulumi/esc/schema"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
+)
+
+// EnvironmentRotationClient rotates the secrets of an ESC environment on
+// demand, reads its rotation history, and describes the available rotators.
+type EnvironmentRotationClient interface {
+	RotateEnvironment(ctx context.Context, environment EnvironmentIdentifier, paths []string) (*SecretRotationEvent, error)
+	ListEnvironmentRotationHistory(ctx context.Context, environment EnvironmentIdentifier) ([]SecretRotationEvent, error)
+	ListRotators(ctx context.Context, orgName string) ([]string, error)
+	GetRotatorSchema(ctx context.Context, rotatorName string) (*schema.Schema, error)
+}
+
+// SecretRotationEvent is one rotation of an environment, scheduled or on
+// demand, and the outcome for each rotated secret.
+type SecretRotationEvent struct {
+	ID                   string
+	Status               string
+	PreRotationRevision  int
+	PostRotationRevision *int
+	Created              string
+	Completed            string
+	ScheduleID           string
+	ErrorMessage         string
+	Rotations            []SecretRotation
+}
+
+// SecretRotation is the outcome of rotating the secret at EnvironmentPath.
+type SecretRotation struct {
+	EnvironmentPath string
+	Status          string
+	ErrorMessage    string
+}
+
+func toSecretRotationEvent(event apitype.SecretRotationEvent) SecretRotationEvent {
+	converted := SecretRotationEvent{
+		ID:                  event.ID,
+		Status:              event.Status,
+		PreRotationRevision: int(event.PreRotationRevision),
+		Created:             event.CreatedAt.UTC().Format(time.RFC3339),
+		ScheduleID:          derefString(event.ScheduledActionID),
+		ErrorMessage:        derefString(event.ErrorMessage),
+		Rotations:           make([]SecretRotation, 0, len(event.Rotations)),
+	}
+	if event.PostRotationRevision != nil {
+		revision := int(*event.PostRotationRevision)
+		converted.PostRotationRevision = &revision
+	}
+	if event.CompletedAt != nil {
+		converted.Completed = event.CompletedAt.UTC().Format(time.RFC3339)
+	}
+	for _, rotation := range event.Rotations {
+		converted.Rotations = append(converted.Rotations, SecretRotation{
+			EnvironmentPath: rotation.EnvironmentPath,
+			Status:          rotation.Status,
+			ErrorMessage:    derefString(rotation.ErrorMessage),
+		})
+	}
+	return converted
+}
+
+// RotateEnvironment rotates the secrets of the environment at the given
+// paths, or all of them when paths is empty, and returns the rotation.
+func (c *Client) RotateEnvironment(
+	ctx context.Context,
+	environment EnvironmentIdentifier,
+	paths []string,
+) (*SecretRotationEvent, error) {
+	if paths == nil {
+		paths = []string{}
+	}
+	resp, err := c.SDK.RotateEnvironment(ctx, environment.OrgName, environment.ProjectName, environment.EnvName,
+		apitype.RotateEnvironmentRequest{Paths: paths})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate environment %s: %w", environment, err)
+	}
+	var diagnostics []string
+	for _, d := range resp.Diagnostics {
+		if d.Severity == "" || d.Severity == "error" {
+			diagnostics = append(diagnostics, d.Summary)
+		}
+	}
+	if len(diagnostics) > 0 {
+		return nil, fmt.Errorf("failed to rotate environment %s: %s", environment, strings.Join(diagnostics, "; "))
+	}
+	event := toSecretRotationEvent(resp.SecretRotationEvent)
+	return &event, nil
+}
+
+// ListEnvironmentRotationHistory returns the environment's rotations, as
+// ordered by the service.
+func (c *Client) ListEnvironmentRotationHistory(
+	ctx context.Context,
+	environment EnvironmentIdentifier,
+) ([]SecretRotationEvent, error) {
+	resp, err := c.SDK.ListEnvironmentSecretRotationHistory(
+		ctx, environment.OrgName, environment.ProjectName, environment.EnvName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rotations of environment %s: %w", environment, err)
+	}
+	events := make([]SecretRotationEvent, 0, len(resp.Events))
+	for _, event := range resp.Events {
+		events = append(events, toSecretRotationEvent(event))
+	}
+	return events, nil
+}
+
+// ListRotators returns the names of the rotators available to orgName.
+func (c *Client) ListRotators(ctx context.Context, orgName string) ([]string, error) {
+	if orgName == "" {
+		return nil, errors.New("organization name must not be empty")
+	}
+	resp, err := c.SDK.ListRotators(ctx, &orgName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rotators for %s: %w", orgName, err)
+	}
+	return resp.Rotators, nil
+}
+
+// GetRotatorSchema returns the schema of the rotator's inputs, or nil if the
+// rotator does not exist.
+func (c *Client) GetRotatorSchema(ctx context.Context, rotatorName string) (*schema.Schema, error) {
+	resp, err := c.SDK.GetRotatorSchema(ctx, rotatorName)
+	if err != nil {
+		if GetErrorStatusCode(err) == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get schema of rotator %s: %w", rotatorName, err)
+	}
+	if resp.Inputs == nil {
+		return schema.Always(), nil
+	}
+	return resp.Inputs, nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumiapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateEnvironment(t *testing.T) {
+	env := EnvironmentIdentifier{OrgName: testOrgName, ProjectName: "p", EnvName: "dev"}
+	event := map[string]any{
+		"id":                   "rot-1",
+		"status":               "completed",
+		"created":              "2026-03-01T10:00:00Z",
+		"completed":            "2026-03-01T10:00:05Z",
+		"preRotationRevision":  3,
+		"postRotationRevision": 4,
+		"rotations": []map[string]any{
+			{"id": "r-1", "environmentPath": "db.password", "status": "completed"},
+		},
+	}
+
+	t.Run("Happy Path", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPost,
+			ExpectedReqPath:   "/api/esc/environments/" + testOrgName + "/p/dev/rotate",
+			ExpectedReqBody:   map[string]any{"paths": []string{"db.password"}},
+			ResponseCode:      http.StatusOK,
+			ResponseBody:      map[string]any{"id": "rot-1", "secretRotationEvent": event},
+		})
+		got, err := c.RotateEnvironment(ctx, env, []string{"db.password"})
+		require.NoError(t, err)
+		post := 4
+		assert.Equal(t, &SecretRotationEvent{
+			ID:                   "rot-1",
+			Status:               "completed",
+			PreRotationRevision:  3,
+			PostRotationRevision: &post,
+			Created:              "2026-03-01T10:00:00Z",
+			Completed:            "2026-03-01T10:00:05Z",
+			Rotations:            []SecretRotation{{EnvironmentPath: "db.password", Status: "completed"}},
+		}, got)
+	})
+
+	t.Run("Diagnostics", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPost,
+			ExpectedReqPath:   "/api/esc/environments/" + testOrgName + "/p/dev/rotate",
+			ExpectedReqBody:   map[string]any{"paths": []string{}},
+			ResponseCode:      http.StatusOK,
+			ResponseBody: map[string]any{
+				"id":                  "rot-1",
+				"diagnostics":         []map[string]any{{"summary": "unknown path", "severity": "error"}},
+				"secretRotationEvent": event,
+			},
+		})
+		_, err := c.RotateEnvironment(ctx, env, nil)
+		assert.ErrorContains(t, err, "unknown path")
+	})
+}
+
+func TestListEnvironmentRotationHistory(t *testing.T) {
+	env := EnvironmentIdentifier{OrgName: testOrgName, ProjectName: "p", EnvName: "dev"}
+	c := startTestServer(t, testServerConfig{
+		ExpectedReqMethod: http.MethodGet,
+		ExpectedReqPath:   "/api/esc/environments/" + testOrgName + "/p/dev/rotate/history",
+		ResponseCode:      http.StatusOK,
+		ResponseBody: map[string]any{"events": []map[string]any{{
+			"id":                "rot-2",
+			"status":            "failed",
+			"created":           "2026-03-02T10:00:00Z",
+			"scheduledActionID": "sched-1",
+			"errorMessage":      "access denied",
+			"rotations":         []map[string]any{},
+		}}},
+	})
+	got, err := c.ListEnvironmentRotationHistory(ctx, env)
+	require.NoError(t, err)
+	assert.Equal(t, []SecretRotationEvent{{
+		ID:           "rot-2",
+		Status:       "failed",
+		Created:      "2026-03-02T10:00:00Z",
+		ScheduleID:   "sched-1",
+		ErrorMessage: "access denied",
+		Rotations:    []SecretRotation{},
+	}}, got)
+}
+
+func TestRotators(t *testing.T) {
+	t.Run("List", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodGet,
+			ExpectedReqPath:   "/api/esc/rotators",
+			ResponseCode:      http.StatusOK,
+			ResponseBody:      map[string]any{"rotators": []string{"aws-iam", "postgres"}},
+		})
+		got, err := c.ListRotators(ctx, testOrgName)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"aws-iam", "postgres"}, got)
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodGet,
+			ExpectedReqPath:   "/api/esc/rotators/aws-iam/schema",
+			ResponseCode:      http.StatusOK,
+			ResponseBody: map[string]any{
+				"name": "aws-iam",
+				"inputs": map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"region": map[string]any{"type": "string"}},
+					"required":   []string{"region"},
+				},
+			},
+		})
+		got, err := c.GetRotatorSchema(ctx, "aws-iam")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"region"}, got.Required)
+		assert.Contains(t, got.Properties, "region")
+	})
+
+	t.Run("Schema of an unknown rotator", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodGet,
+			ExpectedReqPath:   "/api/esc/rotators/nope/schema",
+			ResponseCode:      http.StatusNotFound,
+			ResponseBody:      ErrorResponse{Message: "not found"},
+		})
+		got, err := c.GetRotatorSchema(ctx, "nope")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
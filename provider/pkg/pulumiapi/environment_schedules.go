@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"path"
 	"time"
 )
 
@@ -58,21 +57,31 @@ type EnvironmentScheduleDefinition struct {
 	EnvironmentID   string `json:"environmentID"`
 }
 
+// environmentScheduleRoute is the schedule collection of an environment.
+//
+// Create and update post CreateEnvironmentRotationScheduleRequest through SDK.Do
+// rather than the generated methods: the generated request always serializes
+// secretRotationRequest.environmentPath (as "" when unset, which the service
+// rejects) and the generated update is a PATCH, where the service has always
+// been driven with POST.
+const environmentScheduleRoute = "/api/esc/environments/{orgName}/{projectName}/{envName}/schedules"
+
+func (e EnvironmentIdentifier) String() string {
+	return fmt.Sprintf("%s/%s/%s", e.OrgName, e.ProjectName, e.EnvName)
+}
+
+func (e EnvironmentIdentifier) pathParams() map[string]any {
+	return map[string]any{"orgName": e.OrgName, "projectName": e.ProjectName, "envName": e.EnvName}
+}
+
 func (c *Client) CreateEnvironmentRotationSchedule(
 	ctx context.Context,
 	environment EnvironmentIdentifier,
 	scheduleReq CreateEnvironmentRotationScheduleRequest,
 ) (*string, error) {
-	apiPath := path.Join(
-		"esc",
-		"environments",
-		environment.OrgName,
-		environment.ProjectName,
-		environment.EnvName,
-		"schedules",
-	)
 	var scheduleResponse EnvironmentScheduleResponse
-	_, err := c.do(ctx, http.MethodPost, apiPath, scheduleReq, &scheduleResponse)
+	err := c.SDK.Do(ctx, http.MethodPost, environmentScheduleRoute,
+		environment.pathParams(), nil, scheduleReq, &scheduleResponse)
 	if err != nil {
 		var cronString string
 		if scheduleReq.ScheduleCron != nil {
@@ -91,24 +100,23 @@ func (c *Client) GetEnvironmentSchedule(
 	environment EnvironmentIdentifier,
 	scheduleID string,
 ) (*EnvironmentScheduleResponse, error) {
-	apiPath := path.Join(
-		"esc",
-		"environments",
-		environment.OrgName,
-		environment.ProjectName,
-		environment.EnvName,
-		"schedules",
-		scheduleID,
-	)
-	var scheduleResponse EnvironmentScheduleResponse
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &scheduleResponse)
+	action, err := c.SDK.ReadEnvironmentSchedule(
+		ctx, environment.OrgName, environment.ProjectName, environment.EnvName, scheduleID)
 	if err != nil {
 		if GetErrorStatusCode(err) == http.StatusNotFound {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to get environment schedule with scheduleId %s : %w", scheduleID, err)
 	}
-	return &scheduleResponse, nil
+	scheduleResponse := &EnvironmentScheduleResponse{
+		ID:           action.ID,
+		ScheduleOnce: optString(action.ScheduleOnce),
+		ScheduleCron: optString(action.ScheduleCron),
+	}
+	if err := remarshal(action.Definition, &scheduleResponse.Definition); err != nil {
+		return nil, fmt.Errorf("failed to decode environment schedule %s: %w", scheduleID, err)
+	}
+	return scheduleResponse, nil
 }
 
 func (c *Client) UpdateEnvironmentRotationSchedule(
@@ -117,17 +125,11 @@ func (c *Client) UpdateEnvironmentRotationSchedule(
 	scheduleReq CreateEnvironmentRotationScheduleRequest,
 	scheduleID string,
 ) (*string, error) {
-	apiPath := path.Join(
-		"esc",
-		"environments",
-		environment.OrgName,
-		environment.ProjectName,
-		environment.EnvName,
-		"schedules",
-		scheduleID,
-	)
+	params := environment.pathParams()
+	params["scheduleID"] = scheduleID
 	var scheduleResponse EnvironmentScheduleResponse
-	_, err := c.do(ctx, http.MethodPost, apiPath, scheduleReq, &scheduleResponse)
+	err := c.SDK.Do(ctx, http.MethodPost, environmentScheduleRoute+"/{scheduleID}",
+		params, nil, scheduleReq, &scheduleResponse)
 	if err != nil {
 		var cronString string
 		if scheduleReq.ScheduleCron != nil {
@@ -146,16 +148,8 @@ func (c *Client) DeleteEnvironmentSchedule(
 	environment EnvironmentIdentifier,
 	scheduleID string,
 ) error {
-	apiPath := path.Join(
-		"esc",
-		"environments",
-		environment.OrgName,
-		environment.ProjectName,
-		environment.EnvName,
-		"schedules",
-		scheduleID,
-	)
-	_, err := c.do(ctx, http.MethodDelete, apiPath, nil, nil)
+	err := c.SDK.DeleteEnvironmentSchedule(
+		ctx, environment.OrgName, environment.ProjectName, environment.EnvName, scheduleID)
 	if err != nil {
 		return fmt.Errorf("failed to delete environment schedule with scheduleId %s : %w", scheduleID, err)
 	}
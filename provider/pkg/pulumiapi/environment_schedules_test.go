@@ -65,7 +65,7 @@ func TestCreateEnvironmentRotationSchedule(t *testing.T) {
 			t,
 			err,
 			"failed to create environment rotation schedule (scheduleCron=0 * 0 * 0, scheduleOnce=<nil>): "+
-				"401 API error: unauthorized",
+				"HTTP 401: unauthorized",
 		)
 	})
 }
@@ -98,7 +98,7 @@ func TestGetEnvironmentSchedule(t *testing.T) {
 		assert.EqualError(
 			t,
 			err,
-			"failed to get environment schedule with scheduleId test-schedule-id : 401 API error: unauthorized",
+			"failed to get environment schedule with scheduleId test-schedule-id : HTTP 401: unauthorized",
 		)
 	})
 
@@ -159,7 +159,7 @@ func TestUpdateEnvironmentRotationSchedule(t *testing.T) {
 			t,
 			err,
 			"failed to update environment schedule test-schedule-id (scheduleCron=0 * 0 * 0, "+
-				"scheduleOnce=<nil>): 401 API error: unauthorized",
+				"scheduleOnce=<nil>): HTTP 401: unauthorized",
 		)
 	})
 }
@@ -188,7 +188,7 @@ func TestDeleteEnvironmentSchedule(t *testing.T) {
 		assert.EqualError(
 			t,
 			err,
-			"failed to delete environment schedule with scheduleId test-schedule-id : 401 API error: unauthorized",
+			"failed to delete environment schedule with scheduleId test-schedule-id : HTTP 401: unauthorized",
 		)
 	})
 }
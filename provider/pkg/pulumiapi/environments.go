@@ -19,7 +19,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"path"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
 
 // EnvironmentMetadataClient is the slice of the Pulumi Cloud API needed to
@@ -31,6 +32,29 @@ type EnvironmentMetadataClient interface {
 	GetEnvironmentMetadata(ctx context.Context, orgName, projectName, envName string) (*EnvironmentMetadata, error)
 }
 
+// EnvironmentListClient lists the ESC environments in an organization.
+type EnvironmentListClient interface {
+	ListOrgEnvironments(ctx context.Context, orgName string) ([]OrgEnvironment, error)
+}
+
+// EnvironmentRestoreClient lists and restores soft-deleted ESC environments.
+type EnvironmentRestoreClient interface {
+	ListDeletedEnvironments(ctx context.Context, orgName string) ([]OrgEnvironment, error)
+	RestoreEnvironment(ctx context.Context, orgName, projectName, envName, deletedAt string) error
+}
+
+// OrgEnvironment is one entry of `GET /api/esc/environments/{org}`, or of
+// its deleted counterpart, which also sets DeletedAt.
+type OrgEnvironment struct {
+	ID           string `json:"id"`
+	Organization string `json:"organization"`
+	Project      string `json:"project"`
+	Name         string `json:"name"`
+	Created      string `json:"created"`
+	Modified     string `json:"modified"`
+	DeletedAt    string `json:"deletedAt,omitempty"`
+}
+
 // EnvironmentMetadata mirrors the read-only metadata block returned by
 // `GET /api/esc/environments/{org}/{project}/{env}/metadata`. We only
 // surface fields the provider needs today; the wire shape carries more
@@ -59,13 +83,90 @@ func (c *Client) GetEnvironmentMetadata(
 		return nil, errors.New("environment name must not be empty")
 	}
 
-	apiPath := path.Join("esc", "environments", orgName, projectName, envName, "metadata")
-	var meta EnvironmentMetadata
-	if _, err := c.do(ctx, http.MethodGet, apiPath, nil, &meta); err != nil {
+	meta, err := c.SDK.GetEnvironmentMetadata_esc_environments(ctx, orgName, projectName, envName)
+	if err != nil {
 		if GetErrorStatusCode(err) == http.StatusNotFound {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to get environment metadata for %s/%s/%s: %w", orgName, projectName, envName, err)
 	}
-	return &meta, nil
+	return &EnvironmentMetadata{ID: meta.ID}, nil
+}
+
+// ListOrgEnvironments returns every ESC environment in orgName the caller can
+// see, draining all pages.
+func (c *Client) ListOrgEnvironments(ctx context.Context, orgName string) ([]OrgEnvironment, error) {
+	if orgName == "" {
+		return nil, errors.New("organization name must not be empty")
+	}
+
+	envs, err := Paginate(ctx, func(ctx context.Context, token string) (Page[OrgEnvironment], error) {
+		// This endpoint hands back the next page's token as nextToken,
+		// unlike most list endpoints, which use continuationToken.
+		page, err := c.SDK.ListOrgEnvironments_esc(ctx, orgName, optString(token), nil, nil, nil)
+		if err != nil {
+			return Page[OrgEnvironment]{}, err
+		}
+		items := make([]OrgEnvironment, 0, len(page.Environments))
+		for _, env := range page.Environments {
+			items = append(items, OrgEnvironment{
+				ID:           env.ID,
+				Organization: env.Organization,
+				Project:      env.Project,
+				Name:         env.Name,
+				Created:      env.Created,
+				Modified:     env.Modified,
+			})
+		}
+		return Page[OrgEnvironment]{Items: items, Next: derefString(page.NextToken)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list environments for %s: %w", orgName, err)
+	}
+	return envs, nil
+}
+
+// ListDeletedEnvironments returns the soft-deleted ESC environments in
+// orgName that can still be restored, draining all pages.
+func (c *Client) ListDeletedEnvironments(ctx context.Context, orgName string) ([]OrgEnvironment, error) {
+	if orgName == "" {
+		return nil, errors.New("organization name must not be empty")
+	}
+
+	envs, err := Paginate(ctx, func(ctx context.Context, token string) (Page[OrgEnvironment], error) {
+		page, err := c.SDK.ListDeletedEnvironments(ctx, orgName, optString(token))
+		if err != nil {
+			return Page[OrgEnvironment]{}, err
+		}
+		items := make([]OrgEnvironment, 0, len(page.Environments))
+		for _, env := range page.Environments {
+			items = append(items, OrgEnvironment{
+				ID:           env.ID,
+				Organization: env.Organization,
+				Project:      env.Project,
+				Name:         env.Name,
+				Created:      env.Created,
+				Modified:     env.Modified,
+				DeletedAt:    env.DeletedAt,
+			})
+		}
+		return Page[OrgEnvironment]{Items: items, Next: derefString(page.NextToken)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted environments for %s: %w", orgName, err)
+	}
+	return envs, nil
+}
+
+// RestoreEnvironment restores the environment deleted at deletedAt, as
+// reported by ListDeletedEnvironments, with its revision history.
+func (c *Client) RestoreEnvironment(ctx context.Context, orgName, projectName, envName, deletedAt string) error {
+	err := c.SDK.RestoreEnvironment(ctx, orgName, apitype.RestoreEnvironmentRequest{
+		CreateEnvironmentRequest: apitype.CreateEnvironmentRequest{Project: projectName, Name: envName},
+		DeletionTimestamp:        deletedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore environment %s/%s/%s: %w", orgName, projectName, envName, err)
+	}
+	return nil
 }
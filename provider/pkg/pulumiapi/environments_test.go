@@ -0,0 +1,63 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumiapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const restorePath = "/api/esc/environments/" + testOrgName + "/restore"
+
+func TestListDeletedEnvironments(t *testing.T) {
+	next := "n2"
+	c := startTestServerMulti(t, func(r *http.Request) (int, any) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, restorePath, r.URL.Path)
+		if r.URL.Query().Get(continuationTokenParam) == "" {
+			return http.StatusOK, listOrgEnvironmentsResponse{
+				Environments: []OrgEnvironment{{Project: "p", Name: "dev", DeletedAt: "2026-01-01T00:00:00Z"}},
+				NextToken:    &next,
+			}
+		}
+		return http.StatusOK, listOrgEnvironmentsResponse{
+			Environments: []OrgEnvironment{{Project: "p", Name: "dev", DeletedAt: "2026-02-01T00:00:00Z"}},
+		}
+	})
+
+	envs, err := c.ListDeletedEnvironments(ctx, testOrgName)
+	require.NoError(t, err)
+	if assert.Len(t, envs, 2) {
+		assert.Equal(t, "2026-01-01T00:00:00Z", envs[0].DeletedAt)
+		assert.Equal(t, "2026-02-01T00:00:00Z", envs[1].DeletedAt)
+	}
+}
+
+func TestRestoreEnvironment(t *testing.T) {
+	c := startTestServer(t, testServerConfig{
+		ExpectedReqMethod: http.MethodPut,
+		ExpectedReqPath:   restorePath,
+		ExpectedReqBody: map[string]any{
+			"project":           "p",
+			"name":              "dev",
+			"deletionTimestamp": "2026-02-01T00:00:00Z",
+		},
+		ResponseCode: http.StatusNoContent,
+	})
+	assert.NoError(t, c.RestoreEnvironment(ctx, testOrgName, "p", "dev", "2026-02-01T00:00:00Z"))
+}
@@ -31,9 +31,9 @@ func (err *ErrorResponse) Error() string {
 }
 
 // GetErrorStatusCode returns the HTTP status code carried by err, or 0 if err
-// is not an API error. Recognises both the hand-rolled `*ErrorResponse` from
-// the legacy c.do() path and the generated SDK's `*apiclient.APIError` so
-// callers can switch on status uniformly during the SDK migration.
+// is not an API error. Recognises the generated SDK's `*apiclient.APIError`
+// as well as `*ErrorResponse`, which mock clients still return, so callers
+// can switch on status uniformly.
 func GetErrorStatusCode(err error) int {
 	var errResp *ErrorResponse
 	if errors.As(err, &errResp) {
@@ -45,3 +45,14 @@ func GetErrorStatusCode(err error) int {
 	}
 	return 0
 }
+
+// ignoreNoContent drops the 204 error generated methods return when an
+// endpoint documented with a response body answers with none. Use it only
+// where the caller discards that body anyway.
+func ignoreNoContent(err error) error {
+	var apiErr *apiclient.APIError
+	if errors.As(err, &apiErr) && apiErr.IsNoContent() {
+		return nil
+	}
+	return err
+}
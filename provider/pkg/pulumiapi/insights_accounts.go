@@ -19,7 +19,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"path"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype/jsonvalue"
 )
 
 type InsightsAccountClient interface {
@@ -110,9 +112,16 @@ func (c *Client) CreateInsightsAccount(
 		return errors.New("empty accountName")
 	}
 
-	apiPath := path.Join("preview", "insights", orgName, "accounts", accountName)
+	createReq := apitype.CreateInsightsAccountRequest{
+		Provider:     apitype.InsightsAccountProvider(req.Provider),
+		Environment:  req.Environment,
+		ScanSchedule: apitype.ScanSchedule(req.ScanSchedule),
+	}
+	if req.ProviderConfig != nil {
+		createReq.ProviderConfig = req.ProviderConfig
+	}
 
-	_, err := c.do(ctx, http.MethodPost, apiPath, req, nil)
+	err := c.SDK.CreateAccount(ctx, orgName, accountName, createReq)
 	if err != nil {
 		return fmt.Errorf("failed to create insights account: %w", err)
 	}
@@ -129,10 +138,7 @@ func (c *Client) GetInsightsAccount(ctx context.Context, orgName, accountName st
 		return nil, errors.New("empty accountName")
 	}
 
-	apiPath := path.Join("preview", "insights", orgName, "accounts", accountName)
-
-	var account InsightsAccount
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &account)
+	res, err := c.SDK.ReadAccount(ctx, orgName, accountName)
 	if err != nil {
 		statusCode := GetErrorStatusCode(err)
 		if statusCode == http.StatusNotFound {
@@ -142,6 +148,10 @@ func (c *Client) GetInsightsAccount(ctx context.Context, orgName, accountName st
 		return nil, fmt.Errorf("failed to get insights account: %w", err)
 	}
 
+	var account InsightsAccount
+	if err := remarshal(res, &account); err != nil {
+		return nil, fmt.Errorf("failed to parse insights account: %w", err)
+	}
 	return &account, nil
 }
 
@@ -154,14 +164,15 @@ func (c *Client) ListInsightsAccounts(ctx context.Context, orgName string) ([]In
 		return nil, errors.New("empty orgName")
 	}
 
-	apiPath := path.Join("preview", "insights", orgName, "accounts")
-
-	var response ListInsightsAccountsResponse
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &response)
+	res, err := c.SDK.ListAccounts(ctx, orgName, nil, nil, nil, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list insights accounts: %w", err)
 	}
 
+	var response ListInsightsAccountsResponse
+	if err := remarshal(res, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse insights accounts: %w", err)
+	}
 	return response.Accounts, nil
 }
 
@@ -178,9 +189,15 @@ func (c *Client) UpdateInsightsAccount(
 		return errors.New("empty accountName")
 	}
 
-	apiPath := path.Join("preview", "insights", orgName, "accounts", accountName)
+	updateReq := apitype.UpdateInsightsAccountRequest{
+		Environment:  req.Environment,
+		ScanSchedule: nonZeroValue(apitype.ScanSchedule(req.ScanSchedule)),
+	}
+	if req.ProviderConfig != nil {
+		updateReq.ProviderConfig = jsonvalue.NotNull[any](req.ProviderConfig)
+	}
 
-	_, err := c.do(ctx, http.MethodPatch, apiPath, req, nil)
+	err := c.SDK.UpdateAccount(ctx, orgName, accountName, updateReq)
 	if err != nil {
 		return fmt.Errorf("failed to update insights account: %w", err)
 	}
@@ -197,9 +214,7 @@ func (c *Client) DeleteInsightsAccount(ctx context.Context, orgName, accountName
 		return errors.New("empty accountName")
 	}
 
-	apiPath := path.Join("preview", "insights", orgName, "accounts", accountName)
-
-	_, err := c.do(ctx, http.MethodDelete, apiPath, nil, nil)
+	err := c.SDK.DeleteAccount(ctx, orgName, accountName)
 	if err != nil {
 		return fmt.Errorf("failed to delete insights account %q: %w", accountName, err)
 	}
@@ -215,6 +230,13 @@ type ScanOptions struct {
 	ReadTimeout     string `json:"readTimeout,omitempty"`
 }
 
+// insightsScanRoute is the current-scan route of an insights account.
+const insightsScanRoute = "/api/preview/insights/{orgName}/accounts/{accountName}/scan"
+
+func insightsAccountParams(orgName, accountName string) map[string]any {
+	return map[string]any{"orgName": orgName, "accountName": accountName}
+}
+
 // TriggerScan initiates an on-demand scan for the insights account
 // If a scan is already running, it returns the existing scan details instead of triggering a new one
 func (c *Client) TriggerScan(ctx context.Context, orgName, accountName string) (*TriggerScanResponse, error) {
@@ -235,8 +257,10 @@ func (c *Client) TriggerScan(ctx context.Context, orgName, accountName string) (
 		}, nil
 	}
 
-	// No scan running (or no scan exists yet, currentStatus == nil) - trigger a new scan
-	apiPath := path.Join("preview", "insights", orgName, "accounts", accountName, "scan")
+	// No scan running (or no scan exists yet, currentStatus == nil) - trigger a new scan.
+	// The scan routes go through SDK.Do rather than ScanAccount/ReadScanStatus:
+	// the generated WorkflowRun parses the timestamps into time.Time (rewriting
+	// unset ones as the zero time) and the status type drops resourceCount.
 
 	// Send empty ScanOptions (all fields are optional)
 	requestBody := ScanOptions{}
@@ -247,11 +271,11 @@ func (c *Client) TriggerScan(ctx context.Context, orgName, accountName string) (
 	// We need to handle both cases
 
 	var response TriggerScanResponse
-	httpResp, err := c.do(ctx, http.MethodPost, apiPath, requestBody, &response)
+	err = c.SDK.Do(ctx, http.MethodPost, insightsScanRoute, insightsAccountParams(orgName, accountName),
+		nil, requestBody, &response)
 
 	// Handle HTTP 204 No Content - scan triggered but no workflow run details returned yet
-	// The sendRequest will return an error when trying to unmarshal empty JSON for 204 responses
-	if httpResp != nil && httpResp.StatusCode == http.StatusNoContent {
+	if GetErrorStatusCode(err) == http.StatusNoContent {
 		// HTTP 204 - scan queued successfully but no details returned
 		return &TriggerScanResponse{
 			WorkflowRun: WorkflowRun{
@@ -277,10 +301,9 @@ func (c *Client) GetScanStatus(ctx context.Context, orgName, accountName string)
 		return nil, errors.New("empty accountName")
 	}
 
-	apiPath := path.Join("preview", "insights", orgName, "accounts", accountName, "scan")
-
 	var status ScanStatusResponse
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &status)
+	err := c.SDK.Do(ctx, http.MethodGet, insightsScanRoute, insightsAccountParams(orgName, accountName),
+		nil, nil, &status)
 	if err != nil {
 		statusCode := GetErrorStatusCode(err)
 		if statusCode == http.StatusNotFound {
@@ -303,10 +326,7 @@ func (c *Client) GetInsightsAccountTags(ctx context.Context, orgName, accountNam
 		return nil, errors.New("empty accountName")
 	}
 
-	apiPath := path.Join("preview", "insights", orgName, "accounts", accountName, "tags")
-
-	var response GetInsightsAccountTagsResponse
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &response)
+	response, err := c.SDK.GetInsightAccountTags(ctx, orgName, accountName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tags for insights account %q: %w", accountName, err)
 	}
@@ -336,13 +356,11 @@ func (c *Client) SetInsightsAccountTags(
 		return errors.New("empty accountName")
 	}
 
-	apiPath := path.Join("preview", "insights", orgName, "accounts", accountName, "tags")
-
-	req := SetInsightsAccountTagsRequest{
+	req := apitype.SetInsightAccountTagsRequest{
 		Tags: tags,
 	}
 
-	_, err := c.do(ctx, http.MethodPut, apiPath, req, nil)
+	err := c.SDK.SetInsightAccountTags(ctx, orgName, accountName, req)
 	if err != nil {
 		return fmt.Errorf("failed to set tags for insights account %q: %w", accountName, err)
 	}
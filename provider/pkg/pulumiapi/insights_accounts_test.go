@@ -69,7 +69,7 @@ func TestCreateInsightsAccount(t *testing.T) {
 		})
 
 		err := c.CreateInsightsAccount(t.Context(), orgName, accountName, reqBody)
-		assert.EqualError(t, err, `failed to create insights account: 400 API error: invalid environment reference`)
+		assert.EqualError(t, err, `failed to create insights account: HTTP 400: invalid environment reference`)
 	})
 }
 
@@ -133,7 +133,7 @@ func TestListInsightsAccounts(t *testing.T) {
 
 		accounts, err := c.ListInsightsAccounts(t.Context(), orgName)
 		assert.Nil(t, accounts)
-		assert.EqualError(t, err, `failed to list insights accounts: 500 API error: internal server error`)
+		assert.EqualError(t, err, `failed to list insights accounts: HTTP 500: internal server error`)
 	})
 }
 
@@ -193,7 +193,7 @@ func TestGetInsightsAccount(t *testing.T) {
 		})
 
 		_, err := c.GetInsightsAccount(t.Context(), orgName, accountName)
-		assert.EqualError(t, err, `failed to get insights account: 500 API error: internal server error`)
+		assert.EqualError(t, err, `failed to get insights account: HTTP 500: internal server error`)
 	})
 }
 
@@ -238,7 +238,7 @@ func TestUpdateInsightsAccount(t *testing.T) {
 		})
 
 		err := c.UpdateInsightsAccount(t.Context(), orgName, accountName, reqBody)
-		assert.EqualError(t, err, `failed to update insights account: 400 API error: environment not found`)
+		assert.EqualError(t, err, `failed to update insights account: HTTP 400: environment not found`)
 	})
 }
 
@@ -272,7 +272,7 @@ func TestDeleteInsightsAccount(t *testing.T) {
 		assert.EqualError(
 			t,
 			err,
-			fmt.Sprintf(`failed to delete insights account "%s": 404 API error: insights account not found`,
+			fmt.Sprintf(`failed to delete insights account "%s": HTTP 404: insights account not found`,
 				testInsightsAccountName),
 		)
 	})
@@ -390,7 +390,7 @@ func TestTriggerScan(t *testing.T) {
 		assert.EqualError(
 			t,
 			err,
-			fmt.Sprintf(`failed to trigger scan for insights account "%s": 400 API error: scan already in progress`,
+			fmt.Sprintf(`failed to trigger scan for insights account "%s": HTTP 400: scan already in progress`,
 				testInsightsAccountName),
 		)
 	})
@@ -455,7 +455,7 @@ func TestGetScanStatus(t *testing.T) {
 		assert.EqualError(
 			t,
 			err,
-			fmt.Sprintf(`failed to get scan status for insights account "%s": 500 API error: internal server error`,
+			fmt.Sprintf(`failed to get scan status for insights account "%s": HTTP 500: internal server error`,
 				testInsightsAccountName),
 		)
 	})
@@ -580,7 +580,7 @@ func TestGetInsightsAccountTags(t *testing.T) {
 		assert.EqualError(
 			t,
 			err,
-			fmt.Sprintf(`failed to get tags for insights account "%s": 500 API error: internal server error`,
+			fmt.Sprintf(`failed to get tags for insights account "%s": HTTP 500: internal server error`,
 				testInsightsAccountName),
 		)
 	})
@@ -669,7 +669,7 @@ func TestSetInsightsAccountTags(t *testing.T) {
 		assert.EqualError(
 			t,
 			err,
-			fmt.Sprintf(`failed to set tags for insights account "%s": 400 API error: invalid tag name`,
+			fmt.Sprintf(`failed to set tags for insights account "%s": HTTP 400: invalid tag name`,
 				testInsightsAccountName),
 		)
 	})
@@ -18,8 +18,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"net/url"
-	"path"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
 
 const (
@@ -63,15 +63,6 @@ type User struct {
 	Email       string `json:"email"`
 }
 
-type addMemberToOrgReq struct {
-	Role string `json:"role"`
-}
-
-type updateMemberRoleReq struct {
-	Role      string  `json:"role,omitempty"`
-	FGARoleID *string `json:"fgaRoleId,omitempty"`
-}
-
 func validBuiltinOrgRole(role string) bool {
 	switch role {
 	case adminRole, memberRole, "billing-manager":
@@ -91,12 +82,14 @@ func (c *Client) AddMemberToOrg(ctx context.Context, userName string, orgName st
 		return fmt.Errorf("role must be one of: admin, member, billing-manager")
 	}
 
-	apiPath := path.Join("orgs", orgName, "members", userName)
-
-	req := addMemberToOrgReq{
-		Role: role,
+	req := apitype.AddOrganizationMemberRequest{
+		Role: apitype.OrganizationRole(role),
 	}
-	_, err := c.do(ctx, http.MethodPost, apiPath, req, nil)
+	// The member record the generated AddOrganizationMember decodes is unused
+	// here, and the service may answer with an empty 200 that it cannot decode,
+	// so the call goes through SDK.Do with no result.
+	err := c.SDK.Do(ctx, http.MethodPost, "/api/orgs/{orgName}/members/{userLogin}",
+		map[string]any{"orgName": orgName, "userLogin": userName}, nil, req, nil)
 	if err != nil {
 		return fmt.Errorf("failed to add member to org: %w", err)
 	}
@@ -140,9 +133,8 @@ func (c *Client) UpdateOrgMemberRole(
 		effectiveFGA = &id
 	}
 
-	apiPath := path.Join("orgs", orgName, "members", userName)
-	req := updateMemberRoleReq{FGARoleID: effectiveFGA}
-	if _, err := c.do(ctx, http.MethodPatch, apiPath, req, nil); err != nil {
+	req := apitype.UpdateOrganizationMemberRequest{FGARoleID: effectiveFGA}
+	if err := c.SDK.UpdateOrganizationMember(ctx, orgName, userName, req); err != nil {
 		return fmt.Errorf("failed to update org member role: %w", err)
 	}
 	return nil
@@ -221,25 +213,17 @@ func memberKey(m Member) string {
 func (c *Client) listOrgMembersByType(
 	ctx context.Context, orgName, rosterType string,
 ) ([]Member, error) {
-	apiPath := path.Join("orgs", orgName, "members")
-	var all []Member
-	token := ""
-	for {
-		q := url.Values{"type": []string{rosterType}}
-		if token != "" {
-			q.Set("continuationToken", token)
-		}
+	// The generated ListOrganizationMembers has no type argument, so the
+	// rosters are fetched through getPage.
+	pathParams := map[string]any{"orgName": orgName}
+	query := map[string]any{"type": &rosterType}
+	return Paginate(ctx, func(ctx context.Context, token string) (Page[Member], error) {
 		var page Members
-		if _, err := c.doWithQuery(ctx, http.MethodGet, apiPath, q, nil, &page); err != nil {
-			return nil, err
-		}
-		all = append(all, page.Members...)
-		if page.ContinuationToken == nil || *page.ContinuationToken == "" {
-			break
+		if err := c.getPage(ctx, "/api/orgs/{orgName}/members", pathParams, query, token, &page); err != nil {
+			return Page[Member]{}, err
 		}
-		token = *page.ContinuationToken
-	}
-	return all, nil
+		return Page[Member]{Items: page.Members, Next: derefString(page.ContinuationToken)}, nil
+	})
 }
 
 // GetOrgMember looks up a single member by username using the list endpoint.
@@ -266,10 +250,7 @@ func (c *Client) DeleteMemberFromOrg(ctx context.Context, orgName string, userNa
 		return errors.New("userName must not be empty")
 	}
 
-	apiPath := path.Join("orgs", orgName, "members", userName)
-
-	_, err := c.do(ctx, http.MethodDelete, apiPath, nil, nil)
-
+	err := c.SDK.DeleteOrganizationMember(ctx, orgName, userName)
 	if err != nil {
 		return fmt.Errorf("failed to delete member from org: %w", err)
 	}
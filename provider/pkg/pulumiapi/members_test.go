@@ -55,7 +55,7 @@ func TestAddMemberToOrg(t *testing.T) {
 			},
 		})
 		err := c.AddMemberToOrg(ctx, userName, orgName, role)
-		assert.EqualError(t, err, "failed to add member to org: 401 API error: unauthorized")
+		assert.EqualError(t, err, "failed to add member to org: HTTP 401: unauthorized")
 	})
 }
 
@@ -89,7 +89,7 @@ func TestListOrgMembers(t *testing.T) {
 		})
 		got, err := c.ListOrgMembers(ctx, orgName)
 		assert.Nil(t, got, "members should be null since backend error was returned")
-		assert.EqualError(t, err, "failed to list organization members: 401 API error: unauthorized")
+		assert.EqualError(t, err, "failed to list organization members: HTTP 401: unauthorized")
 	})
 
 	// Frontend errors are non-fatal: a transient frontend issue should not
@@ -202,7 +202,7 @@ func TestDeleteMemberFromOrg(t *testing.T) {
 			},
 		})
 		err := c.DeleteMemberFromOrg(ctx, orgName, userName)
-		assert.EqualError(t, err, "failed to delete member from org: 401 API error: unauthorized")
+		assert.EqualError(t, err, "failed to delete member from org: HTTP 401: unauthorized")
 	})
 }
 
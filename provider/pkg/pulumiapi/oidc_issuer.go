@@ -4,7 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"path"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
 
 type OidcClient interface {
@@ -21,6 +22,7 @@ type OidcClient interface {
 	) (*OidcIssuerRegistrationResponse, error)
 	GetOidcIssuer(ctx context.Context, organization string, issuerID string) (*OidcIssuerRegistrationResponse, error)
 	DeleteOidcIssuer(ctx context.Context, organization string, issuerID string) error
+	ListOidcIssuers(ctx context.Context, organization string) ([]OidcIssuerRegistrationResponse, error)
 	GetAuthPolicies(ctx context.Context, organization string, issuerID string) (*AuthPolicy, error)
 	UpdateAuthPolicies(
 		ctx context.Context,
@@ -75,18 +77,50 @@ type AuthPolicyUpdateRequest struct {
 	Definition []AuthPolicyDefinition `json:"policies"`
 }
 
+func newOidcIssuerRegistrationResponse(
+	issuer *apitype.OidcIssuerRegistrationResponse,
+) *OidcIssuerRegistrationResponse {
+	return &OidcIssuerRegistrationResponse{
+		ID:            issuer.ID,
+		Name:          issuer.Name,
+		URL:           issuer.URL,
+		Issuer:        issuer.Issuer,
+		Thumbprints:   issuer.Thumbprints,
+		MaxExpiration: issuer.MaxExpiration,
+	}
+}
+
+// newAuthPolicy converts the generated policy into the provider's shape. The
+// two share their JSON encoding, so the definitions are carried across by
+// round-tripping them.
+func newAuthPolicy(policy *apitype.AuthPolicy) (*AuthPolicy, error) {
+	res := &AuthPolicy{
+		ID:       policy.ID,
+		Version:  int(policy.Version),
+		Created:  policy.Created,
+		Modified: policy.Modified,
+	}
+	if err := remarshal(policy.Definition, &res.Definition); err != nil {
+		return nil, fmt.Errorf("failed to parse auth policies: %w", err)
+	}
+	return res, nil
+}
+
 func (c *Client) RegisterOidcIssuer(
 	ctx context.Context,
 	organization string,
 	request OidcIssuerRegistrationRequest,
 ) (*OidcIssuerRegistrationResponse, error) {
-	apiPath := path.Join("orgs", organization, "oidc", "issuers")
-	var response = &OidcIssuerRegistrationResponse{}
-	_, err := c.do(ctx, http.MethodPost, apiPath, request, response)
+	response, err := c.SDK.RegisterOidcIssuer(ctx, organization, apitype.OidcIssuerRegistrationRequest{
+		Name:          request.Name,
+		URL:           request.URL,
+		Thumbprints:   request.Thumbprints,
+		MaxExpiration: request.MaxExpiration,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to register oidc issuer '%s': %w", request.Name, err)
 	}
-	return response, nil
+	return newOidcIssuerRegistrationResponse(response), nil
 }
 
 func (c *Client) UpdateOidcIssuer(
@@ -95,13 +129,15 @@ func (c *Client) UpdateOidcIssuer(
 	issuerID string,
 	request OidcIssuerUpdateRequest,
 ) (*OidcIssuerRegistrationResponse, error) {
-	apiPath := path.Join("orgs", organization, "oidc", "issuers", issuerID)
-	var response = &OidcIssuerRegistrationResponse{}
-	_, err := c.do(ctx, http.MethodPatch, apiPath, request, response)
+	response, err := c.SDK.UpdateOidcIssuer(ctx, organization, issuerID, apitype.OidcIssuerUpdateRequest{
+		Name:          optValue(request.Name),
+		Thumbprints:   optValue(request.Thumbprints),
+		MaxExpiration: optValue(request.MaxExpiration),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to update oidc issuer with id '%s': %w", issuerID, err)
 	}
-	return response, nil
+	return newOidcIssuerRegistrationResponse(response), nil
 }
 
 func (c *Client) GetOidcIssuer(
@@ -109,23 +145,20 @@ func (c *Client) GetOidcIssuer(
 	organization string,
 	issuerID string,
 ) (*OidcIssuerRegistrationResponse, error) {
-	apiPath := path.Join("orgs", organization, "oidc", "issuers", issuerID)
-	var response = &OidcIssuerRegistrationResponse{}
-	result, err := c.do(ctx, http.MethodGet, apiPath, nil, response)
+	response, err := c.SDK.GetOidcIssuer(ctx, organization, issuerID)
 	if err != nil {
-		if result.StatusCode == 404 {
+		if GetErrorStatusCode(err) == http.StatusNotFound {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to get oidc issuer with id '%s': %w", issuerID, err)
 	}
-	return response, nil
+	return newOidcIssuerRegistrationResponse(response), nil
 }
 
 func (c *Client) DeleteOidcIssuer(ctx context.Context, organization string, issuerID string) error {
-	apiPath := path.Join("orgs", organization, "oidc", "issuers", issuerID)
-	result, err := c.do(ctx, http.MethodDelete, apiPath, nil, nil)
+	err := c.SDK.DeleteOidcIssuer(ctx, organization, issuerID)
 	if err != nil {
-		if result.StatusCode == 404 {
+		if GetErrorStatusCode(err) == http.StatusNotFound {
 			return nil
 		}
 		return fmt.Errorf("failed to delete oidc issuer with id '%s': %w", issuerID, err)
@@ -133,14 +166,29 @@ func (c *Client) DeleteOidcIssuer(ctx context.Context, organization string, issu
 	return nil
 }
 
+func (c *Client) ListOidcIssuers(
+	ctx context.Context,
+	organization string,
+) ([]OidcIssuerRegistrationResponse, error) {
+	response, err := c.SDK.List_orgs_oidc_issuers(ctx, organization)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oidc issuers: %w", err)
+	}
+	issuers := make([]OidcIssuerRegistrationResponse, 0, len(response.OidcIssuers))
+	for _, issuer := range response.OidcIssuers {
+		if issuer != nil {
+			issuers = append(issuers, *newOidcIssuerRegistrationResponse(issuer))
+		}
+	}
+	return issuers, nil
+}
+
 func (c *Client) GetAuthPolicies(ctx context.Context, organization string, issuerID string) (*AuthPolicy, error) {
-	apiPath := path.Join("orgs", organization, "auth", "policies", "oidcissuers", issuerID)
-	var response = &AuthPolicy{}
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, response)
+	response, err := c.SDK.GetAuthPolicy(ctx, organization, issuerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get auth policies with issuer id '%s': %w", issuerID, err)
 	}
-	return response, nil
+	return newAuthPolicy(response)
 }
 
 func (c *Client) UpdateAuthPolicies(
@@ -149,11 +197,13 @@ func (c *Client) UpdateAuthPolicies(
 	policyID string,
 	request AuthPolicyUpdateRequest,
 ) (*AuthPolicy, error) {
-	apiPath := path.Join("orgs", organization, "auth", "policies", policyID)
-	var response = &AuthPolicy{}
-	_, err := c.do(ctx, http.MethodPatch, apiPath, request, response)
+	var req apitype.AuthPolicyUpdateRequest
+	if err := remarshal(request, &req); err != nil {
+		return nil, fmt.Errorf("failed to serialize auth policies: %w", err)
+	}
+	response, err := c.SDK.UpdateAuthPolicy(ctx, organization, policyID, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update auth policies with policy id '%s': %w", policyID, err)
 	}
-	return response, nil
+	return newAuthPolicy(response)
 }
@@ -17,31 +17,44 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
-	"path"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
 
 type OrgAccessTokenClient interface {
-	CreateOrgAccessToken(ctx context.Context, name, orgName, description string, admin bool) (*AccessToken, error)
+	CreateOrgAccessToken(
+		ctx context.Context, name, orgName, description string, admin bool, expires int64,
+	) (*AccessToken, error)
 	DeleteOrgAccessToken(ctx context.Context, tokenID, orgName string) error
 	GetOrgAccessToken(ctx context.Context, tokenID, orgName string) (*AccessToken, error)
+	ListOrgAccessTokens(ctx context.Context, orgName, filter string) ([]AccessTokenSummary, error)
 }
 
-type createOrgTokenResponse struct {
-	ID         string `json:"id"`
-	TokenValue string `json:"tokenValue"`
-}
-
-type createOrgTokenRequest struct {
-	Description string `json:"description"`
+// AccessTokenSummary is one entry of an organization, team or personal
+// token list. The token value is never returned after creation.
+type AccessTokenSummary struct {
+	ID          string `json:"id"`
 	Name        string `json:"name"`
+	Description string `json:"description"`
 	Admin       bool   `json:"admin"`
+	Created     string `json:"created"`
+	CreatedBy   string `json:"createdBy"`
+	Expires     int64  `json:"expires"`
+	LastUsed    int64  `json:"lastUsed"`
+}
+
+type listOrgTokensResponse struct {
+	Tokens            []AccessTokenSummary `json:"tokens"`
+	ContinuationToken *string              `json:"continuationToken,omitempty"`
 }
 
+// CreateOrgAccessToken creates an organization access token expiring at
+// expires, a Unix timestamp in seconds, or never when it is zero.
 func (c *Client) CreateOrgAccessToken(
 	ctx context.Context,
 	name, orgName, description string,
 	admin bool,
+	expires int64,
 ) (*AccessToken, error) {
 
 	if len(orgName) == 0 {
@@ -52,18 +65,16 @@ func (c *Client) CreateOrgAccessToken(
 		return nil, errors.New("empty name")
 	}
 
-	apiPath := path.Join("orgs", orgName, "tokens")
-
-	createReq := createOrgTokenRequest{
-		Name:        name,
-		Description: description,
-		Admin:       admin,
+	createReq := apitype.CreateOrgAccessTokenRequest{
+		BaseCreateAccessTokenRequest: apitype.BaseCreateAccessTokenRequest{
+			Description: description,
+			Expires:     expires,
+		},
+		Name:  name,
+		Admin: admin,
 	}
 
-	var createRes createOrgTokenResponse
-
-	_, err := c.do(ctx, http.MethodPost, apiPath, createReq, &createRes)
-
+	createRes, err := c.SDK.CreateOrgToken(ctx, orgName, nil, createReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create access token: %w", err)
 	}
@@ -71,7 +82,8 @@ func (c *Client) CreateOrgAccessToken(
 	return &AccessToken{
 		ID:          createRes.ID,
 		TokenValue:  createRes.TokenValue,
-		Description: createReq.Description,
+		Description: description,
+		Expires:     expires,
 	}, nil
 
 }
@@ -85,11 +97,7 @@ func (c *Client) DeleteOrgAccessToken(ctx context.Context, tokenID, orgName stri
 		return errors.New("orgname length must be greater than zero")
 	}
 
-	apiPath := path.Join("orgs", orgName, "tokens", tokenID)
-
-	fmt.Println(apiPath)
-
-	_, err := c.do(ctx, http.MethodDelete, apiPath, nil, nil)
+	err := c.SDK.DeleteOrgToken(ctx, orgName, tokenID)
 	if err != nil {
 		return fmt.Errorf("failed to delete access token %q: %w", tokenID, err)
 	}
@@ -97,28 +105,54 @@ func (c *Client) DeleteOrgAccessToken(ctx context.Context, tokenID, orgName stri
 	return nil
 }
 
+// GetOrgAccessToken returns the token with tokenID, including once it has
+// expired, or nil if there is none.
 func (c *Client) GetOrgAccessToken(ctx context.Context, tokenID, orgName string) (*AccessToken, error) {
-	apiPath := path.Join("orgs", orgName, "tokens")
-
-	var listRes listTokenResponse
-
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &listRes)
-
+	tokens, err := c.ListOrgAccessTokens(ctx, orgName, "all")
 	if err != nil {
-		return nil, fmt.Errorf("failed to list org access tokens: %w", err)
+		return nil, err
 	}
 
-	for i := 0; i < len(listRes.Tokens); i++ {
-		token := listRes.Tokens[i]
+	for _, token := range tokens {
 		if token.ID == tokenID {
 			return &AccessToken{
 				ID:          token.ID,
 				Name:        token.Name,
 				Description: token.Description,
 				Admin:       token.Admin,
+				Expires:     token.Expires,
+				LastUsed:    token.LastUsed,
 			}, nil
 		}
 	}
 
 	return nil, nil
 }
+
+// ListOrgAccessTokens returns every access token in orgName, draining all
+// pages. filter is one of "active", "expired" or "all"; empty leaves the
+// service default (active). The generated ListOrgTokens has no
+// continuationToken argument, so pages are fetched through getPage.
+func (c *Client) ListOrgAccessTokens(ctx context.Context, orgName, filter string) ([]AccessTokenSummary, error) {
+	if len(orgName) == 0 {
+		return nil, errors.New("empty orgName")
+	}
+
+	pathParams := map[string]any{"orgName": orgName}
+	query := map[string]any{}
+	if filter != "" {
+		query["filter"] = &filter
+	}
+
+	tokens, err := Paginate(ctx, func(ctx context.Context, token string) (Page[AccessTokenSummary], error) {
+		var page listOrgTokensResponse
+		if err := c.getPage(ctx, "/api/orgs/{orgName}/tokens", pathParams, query, token, &page); err != nil {
+			return Page[AccessTokenSummary]{}, err
+		}
+		return Page[AccessTokenSummary]{Items: page.Tokens, Next: derefString(page.ContinuationToken)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list org access tokens: %w", err)
+	}
+	return tokens, nil
+}
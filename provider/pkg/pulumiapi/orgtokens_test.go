@@ -3,6 +3,7 @@ package pulumiapi
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -41,7 +42,7 @@ func TestDeleteOrgAccessToken(t *testing.T) {
 		})
 		assert.EqualError(t,
 			c.DeleteOrgAccessToken(teamCtx, tokenID, orgName),
-			fmt.Sprintf(`failed to delete access token "%s": 404 API error: token not found`, testOrgTokenID),
+			fmt.Sprintf(`failed to delete access token "%s": HTTP 404: token not found`, testOrgTokenID),
 		)
 	})
 
@@ -67,7 +68,7 @@ func TestCreateOrgAccessToken(t *testing.T) {
 			ResponseCode:    201,
 			ResponseBody:    resp,
 		})
-		token, err := c.CreateOrgAccessToken(teamCtx, name, orgName, desc, false)
+		token, err := c.CreateOrgAccessToken(teamCtx, name, orgName, desc, false, 0)
 		assert.NoError(t, err)
 		assert.Equal(t, &AccessToken{
 			ID:          resp.ID,
@@ -92,7 +93,7 @@ func TestCreateOrgAccessToken(t *testing.T) {
 			ResponseCode:    201,
 			ResponseBody:    resp,
 		})
-		token, err := c.CreateOrgAccessToken(teamCtx, name, orgName, desc, true)
+		token, err := c.CreateOrgAccessToken(teamCtx, name, orgName, desc, true, 0)
 		assert.NoError(t, err)
 		assert.Equal(t, &AccessToken{
 			ID:          resp.ID,
@@ -115,11 +116,11 @@ func TestCreateOrgAccessToken(t *testing.T) {
 				Message:    unauthorizedError,
 			},
 		})
-		token, err := c.CreateOrgAccessToken(teamCtx, name, orgName, desc, false)
+		token, err := c.CreateOrgAccessToken(teamCtx, name, orgName, desc, false, 0)
 		assert.Nil(t, token, "token should be nil")
 		assert.EqualError(t,
 			err,
-			`failed to create access token: 401 API error: unauthorized`,
+			`failed to create access token: HTTP 401: unauthorized`,
 		)
 	})
 }
@@ -145,17 +146,19 @@ func TestGetOrgAccessToken(t *testing.T) {
 			},
 		}
 		c := startTestServer(t, testServerConfig{
-			ExpectedReqMethod: http.MethodGet,
-			ExpectedReqBody:   nil,
-			ExpectedReqPath:   fmt.Sprintf("/api/orgs/%s/tokens", org),
-			ResponseCode:      200,
-			ResponseBody:      resp,
+			ExpectedReqMethod:   http.MethodGet,
+			ExpectedReqBody:     nil,
+			ExpectedReqPath:     fmt.Sprintf("/api/orgs/%s/tokens", org),
+			ExpectedQueryParams: url.Values{"filter": []string{"all"}},
+			ResponseCode:        200,
+			ResponseBody:        resp,
 		})
 		token, err := c.GetOrgAccessToken(ctx, id, org)
 		assert.NoError(t, err)
 		assert.Equal(t, &AccessToken{
 			ID:          id,
 			Description: desc,
+			LastUsed:    int64(lastUsed),
 		}, token)
 	})
 
@@ -174,7 +177,7 @@ func TestGetOrgAccessToken(t *testing.T) {
 		assert.Nil(t, token, "token should be nil")
 		assert.EqualError(t,
 			err,
-			`failed to list org access tokens: 401 API error: unauthorized`,
+			`failed to list org access tokens: HTTP 401: unauthorized`,
 		)
 	})
 }
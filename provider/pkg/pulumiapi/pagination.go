@@ -0,0 +1,94 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pulumiapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// continuationTokenParam is the query parameter Pulumi Cloud list endpoints
+// accept to resume from a previous page.
+const continuationTokenParam = "continuationToken"
+
+// maxListPages bounds Paginate so a server that keeps handing out fresh
+// tokens can't spin a list call forever.
+const maxListPages = 10_000
+
+// Page is one page of a continuation-token list response. Next is the token
+// for the following page; empty means this was the last one.
+type Page[T any] struct {
+	Items []T
+	Next  string
+}
+
+// Paginate drains a continuation-token list endpoint. fetch is called with an
+// empty token for the first page and with each returned Next token after
+// that, until a page comes back without one. A token repeated from the
+// previous page is treated as a server bug rather than looped on.
+func Paginate[T any](ctx context.Context, fetch func(ctx context.Context, token string) (Page[T], error)) ([]T, error) {
+	var all []T
+	token := ""
+	for range maxListPages {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		page, err := fetch(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.Items...)
+		if page.Next == "" {
+			return all, nil
+		}
+		if page.Next == token {
+			return nil, fmt.Errorf("list pagination stalled: continuation token %q repeated", token)
+		}
+		token = page.Next
+	}
+	return nil, fmt.Errorf("list pagination exceeded %d pages", maxListPages)
+}
+
+// getPage issues one GET of a continuation-token list endpoint through the
+// SDK's Do, adding token (when set) to query, and decodes the response into
+// resBody. It is only for list routes whose generated method does not expose
+// every query parameter the provider needs; use the generated method with its
+// continuationToken argument everywhere else.
+func (c *Client) getPage(
+	ctx context.Context,
+	routePattern string,
+	pathParams map[string]any,
+	query map[string]any,
+	token string,
+	resBody any,
+) error {
+	q := map[string]any{}
+	for k, v := range query {
+		q[k] = v
+	}
+	if token != "" {
+		q[continuationTokenParam] = &token
+	}
+	return c.SDK.Do(ctx, http.MethodGet, routePattern, pathParams, q, nil, resBody)
+}
+
+// derefString returns *s, or "" when s is nil — the shape continuation
+// tokens arrive in.
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
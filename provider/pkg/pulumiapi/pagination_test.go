@@ -0,0 +1,204 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pulumiapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginate(t *testing.T) {
+	t.Run("Drains all pages", func(t *testing.T) {
+		pages := map[string]Page[int]{
+			"":   {Items: []int{1, 2}, Next: "p2"},
+			"p2": {Items: []int{3}, Next: "p3"},
+			"p3": {Items: []int{4}},
+		}
+		var seen []string
+		got, err := Paginate(ctx, func(_ context.Context, token string) (Page[int], error) {
+			seen = append(seen, token)
+			return pages[token], nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 4}, got)
+		assert.Equal(t, []string{"", "p2", "p3"}, seen)
+	})
+
+	t.Run("Repeated token", func(t *testing.T) {
+		calls := 0
+		_, err := Paginate(ctx, func(_ context.Context, _ string) (Page[int], error) {
+			calls++
+			return Page[int]{Items: []int{calls}, Next: "same"}, nil
+		})
+		assert.EqualError(t, err, `list pagination stalled: continuation token "same" repeated`)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("Fetch error", func(t *testing.T) {
+		boom := errors.New("boom")
+		_, err := Paginate(ctx, func(_ context.Context, token string) (Page[int], error) {
+			if token == "" {
+				return Page[int]{Items: []int{1}, Next: "p2"}, nil
+			}
+			return Page[int]{}, boom
+		})
+		assert.ErrorIs(t, err, boom)
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		cctx, cancel := context.WithCancel(ctx)
+		_, err := Paginate(cctx, func(_ context.Context, _ string) (Page[int], error) {
+			cancel()
+			return Page[int]{Items: []int{1}, Next: "p2"}, nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestListStacks(t *testing.T) {
+	next := "tok2"
+	calls := 0
+	c := startTestServerMulti(t, func(r *http.Request) (int, any) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/user/stacks", r.URL.Path)
+		q := r.URL.Query()
+		assert.Equal(t, testOrgName, q.Get("organization"))
+		assert.Equal(t, "proj", q.Get("project"))
+		assert.Equal(t, "env", q.Get("tagName"))
+		assert.Equal(t, "prod", q.Get("tagValue"))
+		calls++
+		if q.Get(continuationTokenParam) == "" {
+			return 200, listStacksResponse{
+				Stacks:            []StackSummary{{OrgName: testOrgName, ProjectName: "proj", StackName: "a"}},
+				ContinuationToken: &next,
+			}
+		}
+		assert.Equal(t, next, q.Get(continuationTokenParam))
+		return 200, listStacksResponse{
+			Stacks: []StackSummary{{OrgName: testOrgName, ProjectName: "proj", StackName: "b"}},
+		}
+	})
+
+	stacks, err := c.ListStacks(ctx, ListStacksOptions{
+		Organization: testOrgName,
+		Project:      "proj",
+		TagName:      "env",
+		TagValue:     "prod",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	if assert.Len(t, stacks, 2) {
+		assert.Equal(t, "a", stacks[0].StackName)
+		assert.Equal(t, "b", stacks[1].StackName)
+	}
+
+	t.Run("Tag value without name", func(t *testing.T) {
+		_, err := c.ListStacks(ctx, ListStacksOptions{TagValue: "prod"})
+		assert.EqualError(t, err, "tagValue requires tagName")
+	})
+}
+
+func TestListOrgEnvironments(t *testing.T) {
+	next := "n2"
+	c := startTestServerMulti(t, func(r *http.Request) (int, any) {
+		assert.Equal(t, "/api/esc/environments/"+testOrgName, r.URL.Path)
+		if r.URL.Query().Get(continuationTokenParam) == "" {
+			return 200, listOrgEnvironmentsResponse{
+				Environments: []OrgEnvironment{{Organization: testOrgName, Project: "p", Name: "dev"}},
+				NextToken:    &next,
+			}
+		}
+		return 200, listOrgEnvironmentsResponse{
+			Environments: []OrgEnvironment{{Organization: testOrgName, Project: "p", Name: "prod"}},
+		}
+	})
+
+	envs, err := c.ListOrgEnvironments(ctx, testOrgName)
+	assert.NoError(t, err)
+	if assert.Len(t, envs, 2) {
+		assert.Equal(t, "dev", envs[0].Name)
+		assert.Equal(t, "prod", envs[1].Name)
+	}
+}
+
+func TestListOrgAccessTokens(t *testing.T) {
+	t.Run("Forwards filter", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod:   http.MethodGet,
+			ExpectedReqPath:     orgTokPath,
+			ExpectedQueryParams: url.Values{"filter": []string{"all"}},
+			ResponseCode:        200,
+			ResponseBody: listOrgTokensResponse{
+				Tokens: []AccessTokenSummary{{ID: testOrgTokenID, Name: "ci", Expires: 42}},
+			},
+		})
+		tokens, err := c.ListOrgAccessTokens(ctx, testOrgTokenOrgName, "all")
+		assert.NoError(t, err)
+		assert.Equal(t, []AccessTokenSummary{{ID: testOrgTokenID, Name: "ci", Expires: 42}}, tokens)
+	})
+
+	t.Run("Get finds a token past the first page", func(t *testing.T) {
+		next := "t2"
+		c := startTestServerMulti(t, func(r *http.Request) (int, any) {
+			assert.Equal(t, orgTokPath, r.URL.Path)
+			if r.URL.Query().Get(continuationTokenParam) == "" {
+				return 200, listOrgTokensResponse{
+					Tokens:            []AccessTokenSummary{{ID: otherValue}},
+					ContinuationToken: &next,
+				}
+			}
+			return 200, listOrgTokensResponse{
+				Tokens: []AccessTokenSummary{{ID: testOrgTokenID, Description: testOrgTokenDescription}},
+			}
+		})
+		token, err := c.GetOrgAccessToken(ctx, testOrgTokenID, testOrgTokenOrgName)
+		assert.NoError(t, err)
+		assert.Equal(t, &AccessToken{ID: testOrgTokenID, Description: testOrgTokenDescription}, token)
+	})
+}
+
+func TestListAgentPools(t *testing.T) {
+	c := startTestServer(t, testServerConfig{
+		ExpectedReqMethod: http.MethodGet,
+		ExpectedReqPath:   "/api/orgs/" + testOrgName + "/agent-pools",
+		ResponseCode:      200,
+		ResponseBody: listAgentPoolsResponse{
+			AgentPools: []AgentPoolSummary{{ID: testAgentPoolUUID, Name: "pool", IsDefault: true}},
+		},
+	})
+	pools, err := c.ListAgentPools(ctx, testOrgName)
+	assert.NoError(t, err)
+	assert.Equal(t, []AgentPoolSummary{{ID: testAgentPoolUUID, Name: "pool", IsDefault: true}}, pools)
+}
+
+func TestListOidcIssuers(t *testing.T) {
+	c := startTestServer(t, testServerConfig{
+		ExpectedReqMethod: http.MethodGet,
+		ExpectedReqPath:   "/api/orgs/" + testOrgName + "/oidc/issuers",
+		ResponseCode:      200,
+		ResponseBody: listOidcIssuersResponse{
+			OidcIssuers: []OidcIssuerRegistrationResponse{{ID: "iss", Name: "github", URL: "https://token.actions.githubusercontent.com"}},
+		},
+	})
+	issuers, err := c.ListOidcIssuers(ctx, testOrgName)
+	assert.NoError(t, err)
+	if assert.Len(t, issuers, 1) {
+		assert.Equal(t, "github", issuers[0].Name)
+	}
+}
@@ -19,7 +19,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"path"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
 
 type PolicyGroupClient interface {
@@ -62,16 +63,6 @@ type PolicyPackMetadata struct {
 	Config      map[string]interface{} `json:"config,omitempty"`
 }
 
-type listPolicyGroupsResponse struct {
-	PolicyGroups []PolicyGroupSummary `json:"policyGroups"`
-}
-
-type createPolicyGroupRequest struct {
-	Name       string `json:"name"`
-	EntityType string `json:"entityType"`
-	Mode       string `json:"mode"`
-}
-
 type InsightsAccountReference struct {
 	Name string `json:"name"`
 }
@@ -91,14 +82,15 @@ func (c *Client) ListPolicyGroups(ctx context.Context, orgName string) ([]Policy
 		return nil, errors.New("empty orgName")
 	}
 
-	apiPath := path.Join("orgs", orgName, "policygroups")
-
-	var response listPolicyGroupsResponse
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &response)
+	response, err := c.SDK.ListPolicyGroups(ctx, orgName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list policy groups for %q: %w", orgName, err)
 	}
-	return response.PolicyGroups, nil
+	var policyGroups []PolicyGroupSummary
+	if err := remarshal(response.PolicyGroups, &policyGroups); err != nil {
+		return nil, fmt.Errorf("failed to parse policy groups for %q: %w", orgName, err)
+	}
+	return policyGroups, nil
 }
 
 func (c *Client) GetPolicyGroup(ctx context.Context, orgName string, policyGroupName string) (*PolicyGroup, error) {
@@ -110,10 +102,7 @@ func (c *Client) GetPolicyGroup(ctx context.Context, orgName string, policyGroup
 		return nil, errors.New("empty policyGroupName")
 	}
 
-	apiPath := path.Join("orgs", orgName, "policygroups", policyGroupName)
-
-	var policyGroup PolicyGroup
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &policyGroup)
+	response, err := c.SDK.GetPolicyGroup(ctx, orgName, policyGroupName)
 	if err != nil {
 		statusCode := GetErrorStatusCode(err)
 		if statusCode == http.StatusNotFound {
@@ -123,6 +112,10 @@ func (c *Client) GetPolicyGroup(ctx context.Context, orgName string, policyGroup
 		return nil, fmt.Errorf("failed to get policy group: %w", err)
 	}
 
+	var policyGroup PolicyGroup
+	if err := remarshal(response, &policyGroup); err != nil {
+		return nil, fmt.Errorf("failed to parse policy group: %w", err)
+	}
 	return &policyGroup, nil
 }
 
@@ -147,15 +140,13 @@ func (c *Client) CreatePolicyGroup(ctx context.Context, orgName, policyGroupName
 		return errors.New("mode must not be empty")
 	}
 
-	apiPath := path.Join("orgs", orgName, "policygroups")
-
-	req := createPolicyGroupRequest{
+	req := apitype.NewPolicyGroupRequest{
 		Name:       policyGroupName,
-		EntityType: entityType,
-		Mode:       mode,
+		EntityType: apitype.PolicyGroupEntityType(entityType),
+		Mode:       apitype.PolicyGroupMode(mode),
 	}
 
-	_, err := c.do(ctx, http.MethodPost, apiPath, req, nil)
+	err := c.SDK.NewPolicyGroup(ctx, orgName, req)
 	if err != nil {
 		return fmt.Errorf("failed to create policy group %q: %w", policyGroupName, err)
 	}
@@ -182,9 +173,11 @@ func (c *Client) BatchUpdatePolicyGroup(
 		return nil
 	}
 
-	apiPath := path.Join("orgs", orgName, "policygroups", policyGroupName, "batch")
-
-	_, err := c.do(ctx, http.MethodPatch, apiPath, reqs, nil)
+	// The generated UpdatePolicyGroupRequest embeds a full InsightsAccount and
+	// drops empty routingProject and versionTag fields, neither of which the
+	// service expects, so the batch is sent as built.
+	err := c.SDK.Do(ctx, http.MethodPatch, "/api/orgs/{orgName}/policygroups/{policyGroupName}/batch",
+		map[string]any{"orgName": orgName, "policyGroupName": policyGroupName}, nil, reqs, nil)
 	if err != nil {
 		return fmt.Errorf("failed to batch update policy group %q: %w", policyGroupName, err)
 	}
@@ -201,9 +194,7 @@ func (c *Client) DeletePolicyGroup(ctx context.Context, orgName, policyGroupName
 		return errors.New("policyGroupName must not be empty")
 	}
 
-	apiPath := path.Join("orgs", orgName, "policygroups", policyGroupName)
-
-	_, err := c.do(ctx, http.MethodDelete, apiPath, nil, nil)
+	err := c.SDK.DeletePolicyGroup(ctx, orgName, policyGroupName)
 	if err != nil {
 		return fmt.Errorf("failed to delete policy group %q: %w", policyGroupName, err)
 	}
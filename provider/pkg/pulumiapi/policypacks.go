@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"path"
 	"strconv"
 	"strings"
 	"time"
@@ -45,12 +44,6 @@ type CreatePolicyPackRequest struct {
 	Policies    []apitype.Policy `json:"policies"`
 }
 
-type CreatePolicyPackResponse struct {
-	Version         int               `json:"version"`
-	UploadURI       string            `json:"uploadURI"`
-	RequiredHeaders map[string]string `json:"requiredHeaders,omitempty"`
-}
-
 type PolicyPackWithVersions struct {
 	Name        string   `json:"name"`
 	DisplayName string   `json:"displayName"`
@@ -67,23 +60,20 @@ type PolicyPackDetail struct {
 	Policies    []apitype.Policy       `json:"policies,omitempty"`
 }
 
-type listPolicyPacksResponse struct {
-	PolicyPacks []PolicyPackWithVersions `json:"policyPacks"`
-}
-
 func (c *Client) ListPolicyPacks(ctx context.Context, orgName string) ([]PolicyPackWithVersions, error) {
 	if len(orgName) == 0 {
 		return nil, errors.New("empty orgName")
 	}
 
-	apiPath := path.Join("orgs", orgName, "policypacks")
-
-	var response listPolicyPacksResponse
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &response)
+	response, err := c.SDK.ListPolicyPacks_orgs(ctx, orgName, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list policy packs for %q: %w", orgName, err)
 	}
-	return response.PolicyPacks, nil
+	policyPacks := make([]PolicyPackWithVersions, 0, len(response.PolicyPacks))
+	for _, pp := range response.PolicyPacks {
+		policyPacks = append(policyPacks, PolicyPackWithVersions(pp))
+	}
+	return policyPacks, nil
 }
 
 func (c *Client) GetPolicyPack(
@@ -100,10 +90,12 @@ func (c *Client) GetPolicyPack(
 		return nil, errors.New("empty policyPackName")
 	}
 
-	apiPath := path.Join("orgs", orgName, "policypacks", policyPackName, "versions", strconv.Itoa(version))
-
+	// The generated GetPolicyPack response type has no config field, so the
+	// pack is decoded into PolicyPackDetail directly.
 	var policyPack PolicyPackDetail
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &policyPack)
+	err := c.SDK.Do(ctx, http.MethodGet, "/api/orgs/{orgName}/policypacks/{policyPackName}/versions/{version}",
+		map[string]any{"orgName": orgName, "policyPackName": policyPackName, "version": strconv.Itoa(version)},
+		nil, nil, &policyPack)
 	if err != nil {
 		statusCode := GetErrorStatusCode(err)
 		if statusCode == http.StatusNotFound {
@@ -128,10 +120,11 @@ func (c *Client) GetLatestPolicyPack(
 		return nil, errors.New("empty policyPackName")
 	}
 
-	apiPath := path.Join("orgs", orgName, "policypacks", policyPackName, "latest")
-
+	// The OpenAPI spec does not model the latest-version route.
 	var policyPack PolicyPackDetail
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &policyPack)
+	err := c.SDK.Do(ctx, http.MethodGet, "/api/orgs/{orgName}/policypacks/{policyPackName}/latest",
+		map[string]any{"orgName": orgName, "policyPackName": policyPackName},
+		nil, nil, &policyPack)
 	if err != nil {
 		statusCode := GetErrorStatusCode(err)
 		if statusCode == http.StatusNotFound {
@@ -159,9 +152,13 @@ func (c *Client) PublishPolicyPack(
 		return 0, errors.New("empty versionTag")
 	}
 
-	var resp CreatePolicyPackResponse
-	apiPath := path.Join("orgs", orgName, "policypacks")
-	if _, err = c.do(ctx, http.MethodPost, apiPath, req, &resp); err != nil {
+	resp, err := c.SDK.CreatePolicyPack(ctx, orgName, apitype.CreatePolicyPackRequest{
+		Name:        req.Name,
+		DisplayName: req.DisplayName,
+		VersionTag:  req.VersionTag,
+		Policies:    req.Policies,
+	})
+	if err != nil {
 		return 0, fmt.Errorf("publish policy pack metadata: %w", err)
 	}
 
@@ -186,10 +183,7 @@ func (c *Client) PublishPolicyPack(
 		return 0, err
 	}
 
-	completePath := path.Join(
-		"orgs", orgName, "policypacks", req.Name, "versions", req.VersionTag, "complete",
-	)
-	if _, err = c.do(ctx, http.MethodPost, completePath, nil, nil); err != nil {
+	if err = c.SDK.CompletePolicyPack(ctx, orgName, req.Name, req.VersionTag); err != nil {
 		return 0, fmt.Errorf("signal publish completion: %w", err)
 	}
 	return resp.Version, nil
@@ -262,8 +256,7 @@ func (c *Client) DeletePolicyPackVersion(ctx context.Context, orgName, policyPac
 	if versionTag == "" {
 		return errors.New("empty versionTag")
 	}
-	apiPath := path.Join("orgs", orgName, "policypacks", policyPackName, "versions", versionTag)
-	if _, err := c.do(ctx, http.MethodDelete, apiPath, nil, nil); err != nil {
+	if err := c.SDK.DeletePolicyPackVersion(ctx, orgName, policyPackName, versionTag); err != nil {
 		if GetErrorStatusCode(err) == http.StatusNotFound {
 			return nil
 		}
@@ -279,8 +272,7 @@ func (c *Client) DeletePolicyPack(ctx context.Context, orgName, policyPackName s
 	if policyPackName == "" {
 		return errors.New("empty policy pack name")
 	}
-	apiPath := path.Join("orgs", orgName, "policypacks", policyPackName)
-	if _, err := c.do(ctx, http.MethodDelete, apiPath, nil, nil); err != nil {
+	if err := c.SDK.DeletePolicyPack_orgs_policypacks(ctx, orgName, policyPackName); err != nil {
 		if GetErrorStatusCode(err) == http.StatusNotFound {
 			return nil
 		}
@@ -207,7 +207,7 @@ func TestPublishPolicyPack_HappyPath(t *testing.T) {
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
 			assert.Equal(t, alphaPolicyPack, got.Name)
 			assert.Equal(t, policyPackVersion, got.VersionTag)
-			return http.StatusOK, CreatePolicyPackResponse{
+			return http.StatusOK, createPolicyPackResponse{
 				Version:         7,
 				UploadURI:       uploadServer.URL + "/upload",
 				RequiredHeaders: map[string]string{"X-Required": valueKey},
@@ -242,7 +242,7 @@ func TestPublishPolicyPack_UploadFailureTriggersCleanup(t *testing.T) {
 	c := startTestServerMulti(t, func(r *http.Request) (int, any) {
 		switch {
 		case r.Method == http.MethodPost && r.URL.Path == policyPacksPath:
-			return http.StatusOK, CreatePolicyPackResponse{
+			return http.StatusOK, createPolicyPackResponse{
 				Version:   7,
 				UploadURI: uploadServer.URL + "/upload",
 			}
@@ -274,7 +274,7 @@ func TestPublishPolicyPack_CleanupUsesDetachedContext(t *testing.T) {
 	c := startTestServerMulti(t, func(r *http.Request) (int, any) {
 		switch {
 		case r.Method == http.MethodPost && r.URL.Path == policyPacksPath:
-			return http.StatusOK, CreatePolicyPackResponse{
+			return http.StatusOK, createPolicyPackResponse{
 				Version:   7,
 				UploadURI: uploadServer.URL + "/upload",
 			}
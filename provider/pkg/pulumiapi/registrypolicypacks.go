@@ -19,8 +19,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"net/url"
-	"path"
 )
 
 // tagQueryParam selects a specific policy pack version tag on registry reads.
@@ -51,6 +49,8 @@ type RegistryPolicyPackClient interface {
 // apitype.RegistryPolicyPack models it as semver.Version, which fails to
 // unmarshal outright if the service ever returns a non-semver tag. Provenance is
 // the field we care about here, and it should not be lost to a version parse.
+// For the same reason the registry reads below go through SDK.Do with this type
+// rather than through the generated methods.
 type RegistryPolicyPack struct {
 	ID                string   `json:"id,omitempty"`
 	Source            string   `json:"source"`
@@ -100,7 +100,8 @@ type getRegistryPolicyPackResponse struct {
 //
 // The route is NOT under /preview, even though its registry siblings
 // (/preview/registry/packages, /templates, /sources) are — policypacks is served
-// only at the unprefixed path. Getting this wrong is quiet rather than loud: the
+// only at the unprefixed path, and the generated ListPolicyPacks_preview_registry_post
+// targets the wrong one. Getting this wrong is quiet rather than loud: the
 // router answers an unknown path with a bare 404, which
 // ListPolicyPacksWithRegistryMetadata reads as "this backend has no registry" and
 // degrades to returning every pack unannotated, with no error to trace back.
@@ -112,11 +113,10 @@ func (c *Client) ListRegistryPolicyPacks(ctx context.Context, orgName string) ([
 		return nil, errors.New("empty orgName")
 	}
 
-	apiPath := path.Join("registry", "policypacks")
 	req := listRegistryPolicyPacksRequest{OrgLogin: orgName}
 
 	var response listRegistryPolicyPacksResponse
-	if _, err := c.do(ctx, http.MethodPost, apiPath, req, &response); err != nil {
+	if err := c.SDK.Do(ctx, http.MethodPost, "/api/registry/policypacks", nil, nil, req, &response); err != nil {
 		return nil, fmt.Errorf("failed to list registry policy packs for %q: %w", orgName, err)
 	}
 	return response.PolicyPacks, nil
@@ -147,15 +147,10 @@ func (c *Client) GetRegistryPolicyPack(
 		return nil, errors.New("empty policyPackName")
 	}
 
-	apiPath := path.Join("orgs", orgName, "registry", "policypacks", policyPackName)
-
 	var response getRegistryPolicyPackResponse
-	var err error
-	if versionTag != "" {
-		_, err = c.doWithQuery(ctx, http.MethodGet, apiPath, url.Values{tagQueryParam: {versionTag}}, nil, &response)
-	} else {
-		_, err = c.do(ctx, http.MethodGet, apiPath, nil, &response)
-	}
+	err := c.SDK.Do(ctx, http.MethodGet, "/api/orgs/{orgName}/registry/policypacks/{policyPackName}",
+		map[string]any{"orgName": orgName, "policyPackName": policyPackName},
+		map[string]any{tagQueryParam: optString(versionTag)}, nil, &response)
 	if err != nil {
 		if GetErrorStatusCode(err) == http.StatusNotFound {
 			return nil, nil
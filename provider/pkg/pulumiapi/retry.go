@@ -0,0 +1,234 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pulumiapi
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is how many times a transient failure is retried
+	// before the last response (or error) is handed back to the caller.
+	DefaultMaxRetries = 3
+	// DefaultRetryMinBackoff is the base delay of the exponential backoff.
+	DefaultRetryMinBackoff = 500 * time.Millisecond
+	// DefaultRetryMaxBackoff caps a single backoff delay, and is also the
+	// longest Retry-After the client is willing to wait out.
+	DefaultRetryMaxBackoff = 30 * time.Second
+	// DefaultRequestTimeout bounds a single HTTP attempt whose request
+	// carries no deadline of its own.
+	DefaultRequestTimeout = 60 * time.Second
+)
+
+// RetryPolicy controls how requests to Pulumi Cloud are retried when they
+// fail transiently. It is shared by the pulumiapi.Client executors and the
+// provider's rest.Transport, so both surfaces back off identically.
+//
+// Only idempotent methods (GET, HEAD, OPTIONS, PUT, DELETE) are retried by
+// default. A 429 is retried for every method, since a rate-limited request
+// was rejected before the service acted on it. Other methods are retried
+// only when the request's context was marked via [WithRetryableRequest].
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt. Zero
+	// disables retries.
+	MaxRetries int
+	// MinBackoff is the base delay; attempt n waits a random duration in
+	// [0, min(MaxBackoff, MinBackoff*2^n)).
+	MinBackoff time.Duration
+	// MaxBackoff caps each delay. A Retry-After longer than this is not
+	// waited out: the throttled response is returned as-is.
+	MaxBackoff time.Duration
+	// AttemptTimeout bounds each attempt, reading the response body
+	// included. Zero applies DefaultRequestTimeout only to requests whose
+	// context has no deadline; a request under an operation's customTimeouts
+	// is then bounded by that deadline alone, so slow calls aren't cut off
+	// early.
+	AttemptTimeout time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used when the provider config doesn't
+// override it.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: DefaultMaxRetries,
+		MinBackoff: DefaultRetryMinBackoff,
+		MaxBackoff: DefaultRetryMaxBackoff,
+	}
+}
+
+type retryableRequestKey struct{}
+
+// WithRetryableRequest marks requests issued under ctx as safe to retry even
+// when their method isn't idempotent. Use it for POSTs the service handles
+// idempotently.
+func WithRetryableRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryableRequestKey{}, true)
+}
+
+func isRetryableRequest(ctx context.Context) bool {
+	v, _ := ctx.Value(retryableRequestKey{}).(bool)
+	return v
+}
+
+// Do sends req through send, retrying transient failures (429, 502, 503, 504
+// and connection errors) with exponential backoff and full jitter. A
+// Retry-After header on the response overrides the computed delay. The last
+// response or error is returned once retries are exhausted, so callers keep
+// their existing status-code handling.
+//
+// Requests whose body can't be replayed (no GetBody) are sent exactly once.
+func (p RetryPolicy) Do(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	ctx := req.Context()
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		resp, err := p.sendAttempt(attemptReq, send)
+		if attempt >= p.MaxRetries || !replayable || ctx.Err() != nil {
+			return resp, err
+		}
+		wait, retry := p.shouldRetry(req, resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+		if resp != nil {
+			// Drain so the connection returns to the pool.
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			_ = resp.Body.Close()
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		next := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			next.Body = body
+		}
+		attemptReq = next
+	}
+}
+
+// sendAttempt sends one attempt under the policy's attempt timeout. The
+// timeout stays armed until the caller closes the response body.
+func (p RetryPolicy) sendAttempt(
+	req *http.Request, send func(*http.Request) (*http.Response, error),
+) (*http.Response, error) {
+	timeout := p.AttemptTimeout
+	if timeout <= 0 {
+		if _, ok := req.Context().Deadline(); ok {
+			return send(req)
+		}
+		timeout = DefaultRequestTimeout
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := send(req.WithContext(ctx))
+	if err != nil || resp == nil {
+		cancel()
+		return resp, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases an attempt's timeout once its body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// shouldRetry classifies one attempt's outcome and returns the delay before
+// the next attempt.
+func (p RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	idempotent := isIdempotent(req.Method) || isRetryableRequest(req.Context())
+	if err != nil {
+		return p.backoff(attempt), idempotent
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if !idempotent {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+			return 0, false
+		}
+		return wait, true
+	}
+	return p.backoff(attempt), true
+}
+
+// backoff returns a full-jitter delay for the given zero-based attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	ceiling := p.MaxBackoff
+	if p.MinBackoff > 0 && attempt < 32 {
+		if d := p.MinBackoff << attempt; d > 0 && (ceiling <= 0 || d < ceiling) {
+			ceiling = d
+		}
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling) //nolint:gosec // jitter, not a security boundary
+}
+
+// parseRetryAfter reads a Retry-After header in either of its RFC 9110 forms:
+// delay-seconds or an HTTP-date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	at, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	if d := at.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
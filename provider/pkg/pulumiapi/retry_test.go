@@ -0,0 +1,263 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pulumiapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fastRetryPolicy keeps backoff negligible so retry tests run in milliseconds.
+var fastRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	MinBackoff: time.Millisecond,
+	MaxBackoff: 5 * time.Millisecond,
+}
+
+// flakyServer answers the first `failures` requests with status, then 200.
+func flakyServer(t *testing.T, failures int32, status int, header http.Header) (*httptest.Server, *atomic.Int32) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method == http.MethodPost {
+			assert.Equal(t, `{"name":"a"}`, string(body), "body must be replayed on every attempt")
+		}
+		if attempts.Add(1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &attempts
+}
+
+func TestRetryPolicy_Do(t *testing.T) {
+	send := func(ctx context.Context, policy RetryPolicy, method, url string) (*http.Response, error) {
+		var body io.Reader
+		if method == http.MethodPost {
+			body = strings.NewReader(`{"name":"a"}`)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, url, body)
+		require.NoError(t, err)
+		resp, err := policy.Do(req, http.DefaultClient.Do)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		return resp, err
+	}
+
+	t.Run("idempotent request retries 502 until success", func(t *testing.T) {
+		server, attempts := flakyServer(t, 2, http.StatusBadGateway, nil)
+		resp, err := send(t.Context(), fastRetryPolicy, http.MethodGet, server.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.EqualValues(t, 3, attempts.Load())
+	})
+
+	t.Run("gives up after MaxRetries and returns the last response", func(t *testing.T) {
+		server, attempts := flakyServer(t, 10, http.StatusServiceUnavailable, nil)
+		resp, err := send(t.Context(), fastRetryPolicy, http.MethodDelete, server.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.EqualValues(t, 4, attempts.Load(), "one attempt plus three retries")
+	})
+
+	t.Run("POST is not retried on 503", func(t *testing.T) {
+		server, attempts := flakyServer(t, 1, http.StatusServiceUnavailable, nil)
+		resp, err := send(t.Context(), fastRetryPolicy, http.MethodPost, server.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.EqualValues(t, 1, attempts.Load())
+	})
+
+	t.Run("POST is retried on 429", func(t *testing.T) {
+		server, attempts := flakyServer(t, 1, http.StatusTooManyRequests, nil)
+		resp, err := send(t.Context(), fastRetryPolicy, http.MethodPost, server.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.EqualValues(t, 2, attempts.Load())
+	})
+
+	t.Run("opted-in POST is retried on 503", func(t *testing.T) {
+		server, attempts := flakyServer(t, 1, http.StatusServiceUnavailable, nil)
+		resp, err := send(WithRetryableRequest(t.Context()), fastRetryPolicy, http.MethodPost, server.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.EqualValues(t, 2, attempts.Load())
+	})
+
+	t.Run("500 is not transient", func(t *testing.T) {
+		server, attempts := flakyServer(t, 1, http.StatusInternalServerError, nil)
+		resp, err := send(t.Context(), fastRetryPolicy, http.MethodGet, server.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.EqualValues(t, 1, attempts.Load())
+	})
+
+	t.Run("Retry-After longer than MaxBackoff is not waited out", func(t *testing.T) {
+		server, attempts := flakyServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"120"}})
+		resp, err := send(t.Context(), fastRetryPolicy, http.MethodGet, server.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.EqualValues(t, 1, attempts.Load())
+	})
+
+	t.Run("Retry-After within MaxBackoff is honored", func(t *testing.T) {
+		server, attempts := flakyServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}})
+		policy := fastRetryPolicy
+		policy.MaxBackoff = 2 * time.Second
+		start := time.Now()
+		resp, err := send(t.Context(), policy, http.MethodGet, server.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.EqualValues(t, 2, attempts.Load())
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	})
+
+	t.Run("zero policy sends once", func(t *testing.T) {
+		server, attempts := flakyServer(t, 1, http.StatusBadGateway, nil)
+		resp, err := send(t.Context(), RetryPolicy{}, http.MethodGet, server.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		assert.EqualValues(t, 1, attempts.Load())
+	})
+
+	t.Run("canceled context stops the backoff wait", func(t *testing.T) {
+		server, attempts := flakyServer(t, 10, http.StatusServiceUnavailable, nil)
+		policy := RetryPolicy{MaxRetries: 3, MinBackoff: time.Hour, MaxBackoff: time.Hour}
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+		_, err := send(ctx, policy, http.MethodGet, server.URL)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), "got %v", err)
+		assert.EqualValues(t, 1, attempts.Load())
+	})
+}
+
+func TestRetryPolicy_AttemptTimeout(t *testing.T) {
+	var attempts atomic.Int32
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+		}
+		_, _ = w.Write([]byte("done"))
+	}))
+	t.Cleanup(slow.Close)
+	get := func(ctx context.Context, policy RetryPolicy) (string, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, slow.URL, nil)
+		require.NoError(t, err)
+		resp, err := policy.Do(req, http.DefaultClient.Do)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return string(body), err
+	}
+
+	t.Run("a hung attempt times out and is retried", func(t *testing.T) {
+		attempts.Store(0)
+		policy := fastRetryPolicy
+		policy.MaxRetries = 1
+		policy.AttemptTimeout = 20 * time.Millisecond
+		_, err := get(t.Context(), policy)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.EqualValues(t, 2, attempts.Load())
+	})
+
+	t.Run("the body stays readable until closed", func(t *testing.T) {
+		body, err := get(t.Context(), RetryPolicy{AttemptTimeout: time.Second})
+		require.NoError(t, err)
+		assert.Equal(t, "done", body)
+	})
+
+	t.Run("an operation deadline replaces the default", func(t *testing.T) {
+		deadlineOf := func(ctx context.Context, policy RetryPolicy) time.Time {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, slow.URL, nil)
+			require.NoError(t, err)
+			var got time.Time
+			_, _ = policy.sendAttempt(req, func(r *http.Request) (*http.Response, error) {
+				got, _ = r.Context().Deadline()
+				return nil, errors.New("not sent")
+			})
+			return got
+		}
+		assert.WithinDuration(t, time.Now().Add(DefaultRequestTimeout), deadlineOf(t.Context(), RetryPolicy{}),
+			time.Second)
+
+		ctx, cancel := context.WithTimeout(t.Context(), time.Hour)
+		defer cancel()
+		operation, _ := ctx.Deadline()
+		assert.Equal(t, operation, deadlineOf(ctx, RetryPolicy{}))
+		assert.WithinDuration(t, time.Now().Add(time.Minute),
+			deadlineOf(ctx, RetryPolicy{AttemptTimeout: time.Minute}), time.Second)
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+		ok     bool
+	}{
+		{"", 0, false},
+		{"7", 7 * time.Second, true},
+		{"-1", 0, false},
+		{now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.header, now)
+		assert.Equal(t, tt.ok, ok, "header %q", tt.header)
+		assert.Equal(t, tt.want, got, "header %q", tt.header)
+	}
+}
+
+// Both the Client methods and raw requests sent through the SDK executor go
+// through the configured RetryPolicy.
+func TestClient_RetriesTransientFailures(t *testing.T) {
+	server, attempts := flakyServer(t, 1, http.StatusBadGateway, nil)
+	c, err := NewClient(&http.Client{}, "tok", server.URL, WithRetryPolicy(fastRetryPolicy))
+	require.NoError(t, err)
+
+	err = c.DeleteTeam(t.Context(), "acme", "a-team")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, attempts.Load())
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL+"/api/user", nil)
+	require.NoError(t, err)
+	attempts.Store(0)
+	resp, err := c.SDK.Executor(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.EqualValues(t, 2, attempts.Load())
+}
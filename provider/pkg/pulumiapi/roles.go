@@ -18,7 +18,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"path"
 
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
@@ -64,19 +63,6 @@ type RoleScope struct {
 	Description string `json:"-"`
 }
 
-// rbacScope matches the RbacScope JSON shape: {name: "...", metadata: {description: "..."}}.
-// RbacScope.name is an enum on the wire but serialises as a plain string; we keep
-// it as a string here because the enum drifts and we don't want customers to
-// hit a hard wall when the service adds a new scope.
-type rbacScope struct {
-	Name     string            `json:"name"`
-	Metadata rbacScopeMetadata `json:"metadata"`
-}
-
-type rbacScopeMetadata struct {
-	Description string `json:"description"`
-}
-
 // RoleScopeGroup is a bucket of related scopes (e.g. "Stacks",
 // "Stack deployments"). The bucketing matches what the Pulumi Cloud console
 // shows when building a custom role.
@@ -85,11 +71,6 @@ type RoleScopeGroup struct {
 	Scopes []RoleScope `json:"-"`
 }
 
-type rbacScopeGroup struct {
-	Name   string      `json:"name"`
-	Scopes []rbacScope `json:"scopes"`
-}
-
 // CreateRole creates a new permission descriptor on the organization. The
 // caller chooses whether the entry is a role, policy, or other kind via
 // `req.UxPurpose`; resource-layer policy on which kinds are valid is enforced
@@ -171,21 +152,23 @@ func (c *Client) ListAvailableRoleScopes(
 		return nil, errors.New("organization name must not be empty")
 	}
 
-	apiPath := path.Join("orgs", orgName, "roles", "scopes")
-	raw := map[string][]rbacScopeGroup{}
-	if _, err := c.do(ctx, http.MethodGet, apiPath, nil, &raw); err != nil {
+	raw, err := c.SDK.ListAvailableScopes(ctx, orgName)
+	if err != nil {
 		return nil, fmt.Errorf("failed to list available role scopes: %w", err)
 	}
 
-	out := make(map[string][]RoleScopeGroup, len(raw))
-	for bucket, groups := range raw {
+	// RbacScope.name is an enum on the wire, but the generated type is a plain
+	// string underneath, so scopes the service adds after the spec was
+	// generated still decode.
+	out := make(map[string][]RoleScopeGroup, len(*raw))
+	for bucket, groups := range *raw {
 		converted := make([]RoleScopeGroup, 0, len(groups))
 		for _, g := range groups {
 			scopes := make([]RoleScope, 0, len(g.Scopes))
 			for _, s := range g.Scopes {
-				scopes = append(scopes, RoleScope{Name: s.Name, Description: s.Metadata.Description})
+				scopes = append(scopes, RoleScope{Name: string(s.Name), Description: s.Metadata.Description})
 			}
-			converted = append(converted, RoleScopeGroup{Name: g.Name, Scopes: scopes})
+			converted = append(converted, RoleScopeGroup{Name: string(g.Name), Scopes: scopes})
 		}
 		out[bucket] = converted
 	}
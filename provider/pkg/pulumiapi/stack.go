@@ -2,16 +2,68 @@ package pulumiapi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
-	"net/url"
-	"path"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+
+	cloudapitype "github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
 
 type StackClient interface {
 	CreateStack(ctx context.Context, stack StackIdentifier) error
 	StackExists(ctx context.Context, stack StackIdentifier) (bool, error)
 	DeleteStack(ctx context.Context, stack StackIdentifier, forceDestroy bool) error
+	ListStacks(ctx context.Context, opts ListStacksOptions) ([]StackSummary, error)
+	RenameStack(ctx context.Context, stack StackIdentifier, newProject, newName string) error
+	TransferStack(ctx context.Context, stack StackIdentifier, toOrg string) error
+	GetStackConfig(ctx context.Context, stack StackIdentifier) (*StackConfig, error)
+	UpdateStackConfig(ctx context.Context, stack StackIdentifier, config StackConfig) error
+	DeleteStackConfig(ctx context.Context, stack StackIdentifier) error
+	EncryptStackValue(ctx context.Context, stack StackIdentifier, plaintext []byte) ([]byte, error)
+	GetStackMetadata(ctx context.Context, stack StackIdentifier) (*StackMetadata, error)
+	UpdateStackNotificationSettings(ctx context.Context, stack StackIdentifier, settings StackNotificationSettings) error
+	ReassignStackOwnership(ctx context.Context, stack StackIdentifier, githubLogin string) error
+}
+
+// StackConfig is a stack's service-managed configuration: the ESC
+// environment the CLI reads the stack's config from instead of the local
+// Pulumi.<stack>.yaml.
+type StackConfig struct {
+	Environment string `json:"environment"`
+}
+
+// StackNotificationSettings controls which update results notify the stack's
+// subscribers.
+type StackNotificationSettings struct {
+	NotifyUpdateFailure bool `json:"notifyUpdateFailure"`
+	NotifyUpdateSuccess bool `json:"notifyUpdateSuccess"`
+}
+
+// StackMetadata is the ownership and notification metadata of a stack.
+type StackMetadata struct {
+	// Owner is the GitHub login of the user who owns the stack.
+	Owner                string                    `json:"owner"`
+	NotificationSettings StackNotificationSettings `json:"notificationSettings"`
+}
+
+// ListStacksOptions are the server-side filters ListStacks forwards. Empty
+// fields are not sent.
+type ListStacksOptions struct {
+	Organization string
+	Project      string
+	TagName      string
+	TagValue     string
+}
+
+// StackSummary is one entry of the stack list.
+type StackSummary struct {
+	OrgName       string `json:"orgName"`
+	ProjectName   string `json:"projectName"`
+	StackName     string `json:"stackName"`
+	LastUpdate    *int64 `json:"lastUpdate,omitempty"`
+	ResourceCount *int   `json:"resourceCount,omitempty"`
 }
 
 type CreateStackRequest struct {
@@ -19,11 +71,10 @@ type CreateStackRequest struct {
 }
 
 func (c *Client) CreateStack(ctx context.Context, stack StackIdentifier) error {
-	apiPath := path.Join("stacks", stack.OrgName, stack.ProjectName)
-	_, err := c.do(ctx, http.MethodPost, apiPath, CreateStackRequest{
+	_, err := c.SDK.CreateStack(ctx, stack.OrgName, stack.ProjectName, apitype.CreateStackRequest{
 		StackName: stack.StackName,
-	}, nil)
-	if err != nil {
+	})
+	if err := ignoreNoContent(err); err != nil {
 		return fmt.Errorf("failed to create stack '%s': %w", stack, err)
 	}
 	return nil
@@ -33,9 +84,7 @@ func (c *Client) StackExists(ctx context.Context, stackName StackIdentifier) (bo
 	if stackName.OrgName == "" || stackName.ProjectName == "" || stackName.StackName == "" {
 		return false, fmt.Errorf("invalid stack identifier: %v", stackName)
 	}
-	apiPath := path.Join("stacks", stackName.OrgName, stackName.ProjectName, stackName.StackName)
-	var s stack
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &s)
+	_, err := c.SDK.GetStack(ctx, stackName.OrgName, stackName.ProjectName, stackName.StackName)
 	if err != nil {
 		statusCode := GetErrorStatusCode(err)
 		if statusCode == http.StatusNotFound {
@@ -48,19 +97,160 @@ func (c *Client) StackExists(ctx context.Context, stackName StackIdentifier) (bo
 }
 
 func (c *Client) DeleteStack(ctx context.Context, stackName StackIdentifier, forceDestroy bool) error {
-	apiPath := path.Join(
-		"stacks", stackName.OrgName, stackName.ProjectName, stackName.StackName,
-	)
-
-	var err error
+	// The generated DeleteStack spells the flag `force`; this provider has
+	// always sent `forceDestroy`, so the request is built here to keep it.
+	var force *bool
 	if forceDestroy {
-		_, err = c.doWithQuery(ctx, http.MethodDelete, apiPath, url.Values{"forceDestroy": []string{trueValue}}, nil, nil)
-	} else {
-		_, err = c.do(ctx, http.MethodDelete, apiPath, nil, nil)
+		force = &forceDestroy
 	}
+	err := c.SDK.Do(ctx, http.MethodDelete, "/api/stacks/{orgName}/{projectName}/{stackName}",
+		map[string]any{
+			"orgName":     stackName.OrgName,
+			"projectName": stackName.ProjectName,
+			"stackName":   stackName.StackName,
+		}, map[string]any{"forceDestroy": force}, nil, nil)
 	if err != nil {
 		return fmt.Errorf("failed to delete stack: %w", err)
 	}
 
 	return nil
 }
+
+// RenameStack renames stack in place, moving it to newProject within the same
+// organization when that differs from its current project. The stack keeps
+// its update history and resources.
+func (c *Client) RenameStack(ctx context.Context, stack StackIdentifier, newProject, newName string) error {
+	_, err := c.SDK.RenameStack(ctx, stack.OrgName, stack.ProjectName, stack.StackName, apitype.StackRenameRequest{
+		NewName:    newName,
+		NewProject: newProject,
+	})
+	if err := ignoreNoContent(err); err != nil {
+		return fmt.Errorf("failed to rename stack '%s': %w", stack, err)
+	}
+	return nil
+}
+
+// TransferStack moves stack, with its history, to the organization toOrg.
+// The caller must be an admin of both organizations.
+func (c *Client) TransferStack(ctx context.Context, stack StackIdentifier, toOrg string) error {
+	err := c.SDK.TransferStack(ctx, stack.OrgName, stack.ProjectName, stack.StackName,
+		cloudapitype.TransferStackRequest{ToOrg: toOrg})
+	if err := ignoreNoContent(err); err != nil {
+		return fmt.Errorf("failed to transfer stack '%s' to organization '%s': %w", stack, toOrg, err)
+	}
+	return nil
+}
+
+// GetStackConfig returns the stack's service-managed configuration, or nil
+// when the stack has none.
+func (c *Client) GetStackConfig(ctx context.Context, stack StackIdentifier) (*StackConfig, error) {
+	config, err := c.SDK.GetStackConfig(ctx, stack.OrgName, stack.ProjectName, stack.StackName)
+	if err != nil {
+		if GetErrorStatusCode(err) == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get config for stack '%s': %w", stack, err)
+	}
+	return &StackConfig{Environment: config.Environment}, nil
+}
+
+func (c *Client) UpdateStackConfig(ctx context.Context, stack StackIdentifier, config StackConfig) error {
+	_, err := c.SDK.UpdateStackConfig(ctx, stack.OrgName, stack.ProjectName, stack.StackName, apitype.StackConfig{
+		Environment: config.Environment,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update config for stack '%s': %w", stack, err)
+	}
+	return nil
+}
+
+// DeleteStackConfig removes the stack's service-managed configuration, so the
+// CLI falls back to the local config file. A stack without one is not an error.
+func (c *Client) DeleteStackConfig(ctx context.Context, stack StackIdentifier) error {
+	err := ignoreNoContent(c.SDK.DeleteStackConfig(ctx, stack.OrgName, stack.ProjectName, stack.StackName))
+	if err != nil && GetErrorStatusCode(err) != http.StatusNotFound {
+		return fmt.Errorf("failed to delete config for stack '%s': %w", stack, err)
+	}
+	return nil
+}
+
+// EncryptStackValue encrypts plaintext with the stack's encryption key,
+// returning ciphertext the CLI accepts as a `secure:` config value.
+func (c *Client) EncryptStackValue(ctx context.Context, stack StackIdentifier, plaintext []byte) ([]byte, error) {
+	resp, err := c.SDK.EncryptValue(ctx, stack.OrgName, stack.ProjectName, stack.StackName, apitype.EncryptValueRequest{
+		Plaintext: plaintext,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt value for stack '%s': %w", stack, err)
+	}
+	return resp.Ciphertext, nil
+}
+
+func (c *Client) GetStackMetadata(ctx context.Context, stack StackIdentifier) (*StackMetadata, error) {
+	metadata, err := c.SDK.GetStackMetadata(ctx, stack.OrgName, stack.ProjectName, stack.StackName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata for stack '%s': %w", stack, err)
+	}
+	return &StackMetadata{
+		Owner: metadata.OwnedBy.GitHubLogin,
+		NotificationSettings: StackNotificationSettings{
+			NotifyUpdateFailure: metadata.NotificationSettings.NotifyUpdateFailure,
+			NotifyUpdateSuccess: metadata.NotificationSettings.NotifyUpdateSuccess,
+		},
+	}, nil
+}
+
+func (c *Client) UpdateStackNotificationSettings(
+	ctx context.Context, stack StackIdentifier, settings StackNotificationSettings,
+) error {
+	_, err := c.SDK.UpdateStackNotificationSettings(ctx, stack.OrgName, stack.ProjectName, stack.StackName,
+		cloudapitype.UpdateStackNotificationSettingsRequest{
+			NotifyUpdateFailure: &settings.NotifyUpdateFailure,
+			NotifyUpdateSuccess: &settings.NotifyUpdateSuccess,
+		})
+	if err != nil {
+		return fmt.Errorf("failed to update notification settings for stack '%s': %w", stack, err)
+	}
+	return nil
+}
+
+// ReassignStackOwnership makes the user with githubLogin the stack's owner.
+func (c *Client) ReassignStackOwnership(ctx context.Context, stack StackIdentifier, githubLogin string) error {
+	_, err := c.SDK.ReassignStackOwnership(ctx, stack.OrgName, stack.ProjectName, stack.StackName,
+		cloudapitype.UserInfo{GitHubLogin: githubLogin})
+	if err != nil {
+		return fmt.Errorf("failed to reassign ownership of stack '%s' to '%s': %w", stack, githubLogin, err)
+	}
+	return nil
+}
+
+// ListStacks returns every stack visible to the caller that matches opts,
+// draining all pages.
+func (c *Client) ListStacks(ctx context.Context, opts ListStacksOptions) ([]StackSummary, error) {
+	if opts.TagValue != "" && opts.TagName == "" {
+		return nil, errors.New("tagValue requires tagName")
+	}
+	stacks, err := Paginate(ctx, func(ctx context.Context, token string) (Page[StackSummary], error) {
+		page, err := c.SDK.ListUserStacks(ctx, optString(token), nil,
+			optString(opts.Organization), optString(opts.Project), nil,
+			optString(opts.TagName), optString(opts.TagValue))
+		if err != nil {
+			return Page[StackSummary]{}, err
+		}
+		items := make([]StackSummary, 0, len(page.Stacks))
+		for _, s := range page.Stacks {
+			items = append(items, StackSummary{
+				OrgName:       s.OrgName,
+				ProjectName:   s.ProjectName,
+				StackName:     s.StackName,
+				LastUpdate:    s.LastUpdate,
+				ResourceCount: s.ResourceCount,
+			})
+		}
+		return Page[StackSummary]{Items: items, Next: derefString(page.ContinuationToken)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stacks: %w", err)
+	}
+	return stacks, nil
+}
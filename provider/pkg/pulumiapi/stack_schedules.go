@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"path"
 	"time"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype/jsonvalue"
 )
 
 type StackScheduleClient interface {
@@ -36,6 +38,8 @@ type StackScheduleClient interface {
 		scheduleID string,
 	) (*string, error)
 	DeleteStackSchedule(ctx context.Context, stack StackIdentifier, scheduleID string) error
+	PauseStackSchedule(ctx context.Context, stack StackIdentifier, scheduleID string) error
+	ResumeStackSchedule(ctx context.Context, stack StackIdentifier, scheduleID string) error
 }
 
 type CreateDeploymentRequest struct {
@@ -77,6 +81,51 @@ type StackScheduleResponse struct {
 	ScheduleOnce *string                 `json:"scheduleOnce,omitempty"`
 	ScheduleCron *string                 `json:"scheduleCron,omitempty"`
 	Definition   StackScheduleDefinition `json:"definition,omitempty"`
+	Paused       bool                    `json:"paused,omitempty"`
+}
+
+func (req CreateDeploymentScheduleRequest) toAPI() apitype.CreateScheduledDeploymentRequest {
+	deployment := apitype.CreateDeploymentRequest{Op: apitype.PulumiOperation(req.Request.PulumiOperation)}
+	deployment.Operation = jsonvalue.NotNull(apitype.OperationContextRequest{
+		Options: jsonvalue.NotNull(apitype.OperationContextOptionsRequest{
+			RemediateIfDriftDetected: nonZeroValue(req.Request.OperationContext.Options.AutoRemediate),
+			DeleteAfterDestroy:       nonZeroValue(req.Request.OperationContext.Options.DeleteAfterDestroy),
+		}),
+	})
+	return apitype.CreateScheduledDeploymentRequest{
+		ScheduleCron: optValue(req.ScheduleCron),
+		ScheduleOnce: optValue(req.ScheduleOnce),
+		Request:      jsonvalue.NotNull(deployment),
+	}
+}
+
+func (req CreateDriftScheduleRequest) toAPI() apitype.CreateScheduledDriftDeploymentRequest {
+	return apitype.CreateScheduledDriftDeploymentRequest{
+		ScheduleCron:  nonZeroValue(req.ScheduleCron),
+		AutoRemediate: nonZeroValue(req.AutoRemediate),
+	}
+}
+
+func (req CreateTTLScheduleRequest) toAPI() apitype.CreateScheduledTTLDeploymentRequest {
+	return apitype.CreateScheduledTTLDeploymentRequest{
+		Timestamp:          jsonvalue.NotNull(req.Timestamp),
+		DeleteAfterDestroy: nonZeroValue(req.DeleteAfterDestroy),
+	}
+}
+
+// stackScheduleFromAPI converts a generated ScheduledAction into a
+// StackScheduleResponse, decoding the kind-specific definition.
+func stackScheduleFromAPI(action *apitype.ScheduledAction) (*StackScheduleResponse, error) {
+	res := &StackScheduleResponse{
+		ID:           action.ID,
+		ScheduleOnce: optString(action.ScheduleOnce),
+		ScheduleCron: optString(action.ScheduleCron),
+		Paused:       action.Paused,
+	}
+	if err := remarshal(action.Definition, &res.Definition); err != nil {
+		return nil, fmt.Errorf("failed to decode schedule definition: %w", err)
+	}
+	return res, nil
 }
 
 func (c *Client) CreateDeploymentSchedule(
@@ -84,9 +133,8 @@ func (c *Client) CreateDeploymentSchedule(
 	stack StackIdentifier,
 	scheduleReq CreateDeploymentScheduleRequest,
 ) (*string, error) {
-	apiPath := path.Join("stacks", stack.OrgName, stack.ProjectName, stack.StackName, "deployments", "schedules")
-	var scheduleResponse StackScheduleResponse
-	_, err := c.do(ctx, http.MethodPost, apiPath, scheduleReq, &scheduleResponse)
+	scheduleResponse, err := c.SDK.CreateScheduledDeployment(
+		ctx, stack.OrgName, stack.ProjectName, stack.StackName, scheduleReq.toAPI())
 	if err != nil {
 		var cronString string
 		if scheduleReq.ScheduleCron != nil {
@@ -110,17 +158,8 @@ func (c *Client) CreateDriftSchedule(
 	stack StackIdentifier,
 	scheduleReq CreateDriftScheduleRequest,
 ) (*string, error) {
-	apiPath := path.Join(
-		"stacks",
-		stack.OrgName,
-		stack.ProjectName,
-		stack.StackName,
-		"deployments",
-		"drift",
-		"schedules",
-	)
-	var scheduleResponse StackScheduleResponse
-	_, err := c.do(ctx, http.MethodPost, apiPath, scheduleReq, &scheduleResponse)
+	scheduleResponse, err := c.SDK.CreateScheduledDriftDeployment(
+		ctx, stack.OrgName, stack.ProjectName, stack.StackName, scheduleReq.toAPI())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create drift schedule (scheduleCron=%s, autoRemediate=%t): %w",
 			scheduleReq.ScheduleCron, scheduleReq.AutoRemediate, err)
@@ -133,9 +172,8 @@ func (c *Client) CreateTTLSchedule(
 	stack StackIdentifier,
 	scheduleReq CreateTTLScheduleRequest,
 ) (*string, error) {
-	apiPath := path.Join("stacks", stack.OrgName, stack.ProjectName, stack.StackName, "deployments", "ttl", "schedules")
-	var scheduleResponse StackScheduleResponse
-	_, err := c.do(ctx, http.MethodPost, apiPath, scheduleReq, &scheduleResponse)
+	scheduleResponse, err := c.SDK.CreateScheduledTTLDeployment(
+		ctx, stack.OrgName, stack.ProjectName, stack.StackName, scheduleReq.toAPI())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create ttl schedule (timestamp=%s, deleteAfterDestroy=%t): %w",
 			scheduleReq.Timestamp, scheduleReq.DeleteAfterDestroy, err)
@@ -148,24 +186,14 @@ func (c *Client) GetStackSchedule(
 	stack StackIdentifier,
 	scheduleID string,
 ) (*StackScheduleResponse, error) {
-	apiPath := path.Join(
-		"stacks",
-		stack.OrgName,
-		stack.ProjectName,
-		stack.StackName,
-		"deployments",
-		"schedules",
-		scheduleID,
-	)
-	var scheduleResponse StackScheduleResponse
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &scheduleResponse)
+	action, err := c.SDK.ReadScheduledDeployment(ctx, stack.OrgName, stack.ProjectName, stack.StackName, scheduleID)
 	if err != nil {
 		if GetErrorStatusCode(err) == http.StatusNotFound {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to get stack schedule with scheduleId %s : %w", scheduleID, err)
 	}
-	return &scheduleResponse, nil
+	return stackScheduleFromAPI(action)
 }
 
 func (c *Client) UpdateDeploymentSchedule(
@@ -174,17 +202,8 @@ func (c *Client) UpdateDeploymentSchedule(
 	scheduleReq CreateDeploymentScheduleRequest,
 	scheduleID string,
 ) (*string, error) {
-	apiPath := path.Join(
-		"stacks",
-		stack.OrgName,
-		stack.ProjectName,
-		stack.StackName,
-		"deployments",
-		"schedules",
-		scheduleID,
-	)
-	var scheduleResponse StackScheduleResponse
-	_, err := c.do(ctx, http.MethodPost, apiPath, scheduleReq, &scheduleResponse)
+	scheduleResponse, err := c.SDK.UpdateScheduledDeployment(
+		ctx, stack.OrgName, stack.ProjectName, stack.StackName, scheduleID, scheduleReq.toAPI())
 	if err != nil {
 		var cronString string
 		if scheduleReq.ScheduleCron != nil {
@@ -210,18 +229,8 @@ func (c *Client) UpdateDriftSchedule(
 	scheduleReq CreateDriftScheduleRequest,
 	scheduleID string,
 ) (*string, error) {
-	apiPath := path.Join(
-		"stacks",
-		stack.OrgName,
-		stack.ProjectName,
-		stack.StackName,
-		"deployments",
-		"drift",
-		"schedules",
-		scheduleID,
-	)
-	var scheduleResponse StackScheduleResponse
-	_, err := c.do(ctx, http.MethodPost, apiPath, scheduleReq, &scheduleResponse)
+	scheduleResponse, err := c.SDK.UpdateScheduledDriftDeployment(
+		ctx, stack.OrgName, stack.ProjectName, stack.StackName, scheduleID, scheduleReq.toAPI())
 	if err != nil {
 		return nil, fmt.Errorf("failed to update drift schedule %s (scheduleCron=%s, autoRemediate=%t): %w",
 			scheduleID, scheduleReq.ScheduleCron, scheduleReq.AutoRemediate, err)
@@ -235,18 +244,8 @@ func (c *Client) UpdateTTLSchedule(
 	scheduleReq CreateTTLScheduleRequest,
 	scheduleID string,
 ) (*string, error) {
-	apiPath := path.Join(
-		"stacks",
-		stack.OrgName,
-		stack.ProjectName,
-		stack.StackName,
-		"deployments",
-		"ttl",
-		"schedules",
-		scheduleID,
-	)
-	var scheduleResponse StackScheduleResponse
-	_, err := c.do(ctx, http.MethodPost, apiPath, scheduleReq, &scheduleResponse)
+	scheduleResponse, err := c.SDK.UpdateScheduledTTLDeployment(
+		ctx, stack.OrgName, stack.ProjectName, stack.StackName, scheduleID, scheduleReq.toAPI())
 	if err != nil {
 		return nil, fmt.Errorf("failed to update ttl schedule %s (timestamp=%s, deleteAfterDestroy=%t): %w",
 			scheduleID, scheduleReq.Timestamp, scheduleReq.DeleteAfterDestroy, err)
@@ -255,18 +254,25 @@ func (c *Client) UpdateTTLSchedule(
 }
 
 func (c *Client) DeleteStackSchedule(ctx context.Context, stack StackIdentifier, scheduleID string) error {
-	apiPath := path.Join(
-		"stacks",
-		stack.OrgName,
-		stack.ProjectName,
-		stack.StackName,
-		"deployments",
-		"schedules",
-		scheduleID,
-	)
-	_, err := c.do(ctx, http.MethodDelete, apiPath, nil, nil)
+	err := c.SDK.DeleteScheduledDeployment(ctx, stack.OrgName, stack.ProjectName, stack.StackName, scheduleID)
 	if err != nil {
 		return fmt.Errorf("failed to delete stack schedule with scheduleId %s : %w", scheduleID, err)
 	}
 	return nil
 }
+
+func (c *Client) PauseStackSchedule(ctx context.Context, stack StackIdentifier, scheduleID string) error {
+	err := c.SDK.PauseScheduledDeployment(ctx, stack.OrgName, stack.ProjectName, stack.StackName, scheduleID)
+	if err != nil {
+		return fmt.Errorf("failed to pause stack schedule with scheduleId %s : %w", scheduleID, err)
+	}
+	return nil
+}
+
+func (c *Client) ResumeStackSchedule(ctx context.Context, stack StackIdentifier, scheduleID string) error {
+	err := c.SDK.ResumeScheduledDeployment(ctx, stack.OrgName, stack.ProjectName, stack.StackName, scheduleID)
+	if err != nil {
+		return fmt.Errorf("failed to resume stack schedule with scheduleId %s : %w", scheduleID, err)
+	}
+	return nil
+}
@@ -77,7 +77,7 @@ func TestCreateDeploymentSchedule(t *testing.T) {
 			t,
 			err,
 			"failed to create deployment schedule (scheduleCron=0 * 0 * 0, scheduleOnce=<nil>, "+
-				"pulumiOperation=update): 401 API error: unauthorized",
+				"pulumiOperation=update): HTTP 401: unauthorized",
 		)
 	})
 }
@@ -110,7 +110,7 @@ func TestGetDeploymentSchedule(t *testing.T) {
 		assert.EqualError(
 			t,
 			err,
-			"failed to get stack schedule with scheduleId test-schedule-id : 401 API error: unauthorized",
+			"failed to get stack schedule with scheduleId test-schedule-id : HTTP 401: unauthorized",
 		)
 	})
 
@@ -171,7 +171,7 @@ func TestUpdateDeploymentSchedule(t *testing.T) {
 			t,
 			err,
 			"failed to update deployment schedule test-schedule-id (scheduleCron=0 * 0 * 0, "+
-				"scheduleOnce=<nil>, pulumiOperation=update): 401 API error: unauthorized",
+				"scheduleOnce=<nil>, pulumiOperation=update): HTTP 401: unauthorized",
 		)
 	})
 }
@@ -200,7 +200,7 @@ func TestDeleteSchedule(t *testing.T) {
 		assert.EqualError(
 			t,
 			err,
-			"failed to delete stack schedule with scheduleId test-schedule-id : 401 API error: unauthorized",
+			"failed to delete stack schedule with scheduleId test-schedule-id : HTTP 401: unauthorized",
 		)
 	})
 }
@@ -235,7 +235,7 @@ func TestCreateDriftSchedule(t *testing.T) {
 		assert.EqualError(
 			t,
 			err,
-			"failed to create drift schedule (scheduleCron=0 * 0 * 0, autoRemediate=true): 401 API error: unauthorized",
+			"failed to create drift schedule (scheduleCron=0 * 0 * 0, autoRemediate=true): HTTP 401: unauthorized",
 		)
 	})
 }
@@ -271,7 +271,7 @@ func TestUpdateDriftSchedule(t *testing.T) {
 			t,
 			err,
 			"failed to update drift schedule test-schedule-id (scheduleCron=0 * 0 * 0, "+
-				"autoRemediate=true): 401 API error: unauthorized",
+				"autoRemediate=true): HTTP 401: unauthorized",
 		)
 	})
 }
@@ -307,7 +307,7 @@ func TestCreateTTLSchedule(t *testing.T) {
 			t,
 			err,
 			"failed to create ttl schedule (timestamp="+timestamp.String()+", "+
-				"deleteAfterDestroy=true): 401 API error: unauthorized",
+				"deleteAfterDestroy=true): HTTP 401: unauthorized",
 		)
 	})
 }
@@ -343,7 +343,46 @@ func TestUpdateTTLSchedule(t *testing.T) {
 			t,
 			err,
 			"failed to update ttl schedule test-schedule-id (timestamp="+timestamp.String()+", "+
-				"deleteAfterDestroy=true): 401 API error: unauthorized",
+				"deleteAfterDestroy=true): HTTP 401: unauthorized",
+		)
+	})
+}
+
+func TestPauseAndResumeStackSchedule(t *testing.T) {
+	schedulePath := "/api/stacks/org/project/stack/deployments/schedules/" + testScheduleID
+
+	t.Run("Pause", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPost,
+			ExpectedReqPath:   schedulePath + "/pause",
+			ResponseCode:      204,
+		})
+		assert.NoError(t, c.PauseStackSchedule(ctx, testStack, testScheduleID))
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPost,
+			ExpectedReqPath:   schedulePath + "/resume",
+			ResponseCode:      204,
+		})
+		assert.NoError(t, c.ResumeStackSchedule(ctx, testStack, testScheduleID))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPost,
+			ExpectedReqPath:   schedulePath + "/pause",
+			ResponseCode:      401,
+			ResponseBody: ErrorResponse{
+				Message: unauthorizedError,
+			},
+		})
+		err := c.PauseStackSchedule(ctx, testStack, testScheduleID)
+		assert.EqualError(
+			t,
+			err,
+			"failed to pause stack schedule with scheduleId test-schedule-id : HTTP 401: unauthorized",
 		)
 	})
 }
@@ -0,0 +1,99 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumiapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+)
+
+// StackStateClient reads a stack's checkpoint: its resources and outputs as
+// of an update.
+type StackStateClient interface {
+	ExportStack(ctx context.Context, stack StackIdentifier, version *int) (*apitype.DeploymentV3, error)
+	DecryptStackValues(ctx context.Context, stack StackIdentifier, ciphertexts []string) (map[string][]byte, error)
+}
+
+// ExportStack returns the stack's checkpoint as of its latest update, or as
+// of update version when that is non-nil. Secret values in it are still
+// encrypted; see DecryptStackValues.
+func (c *Client) ExportStack(
+	ctx context.Context, stack StackIdentifier, version *int,
+) (*apitype.DeploymentV3, error) {
+	var (
+		untyped *apitype.UntypedDeployment
+		err     error
+	)
+	if version != nil {
+		untyped, err = c.SDK.ExportStackAtVersion(ctx, stack.OrgName, stack.ProjectName, stack.StackName,
+			int64(*version))
+	} else {
+		untyped, err = c.SDK.ExportStack(ctx, stack.OrgName, stack.ProjectName, stack.StackName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to export stack '%s': %w", stack, err)
+	}
+
+	// Versions 3 and 4 share a layout; 4 only adds feature flags.
+	if untyped.Version > apitype.DeploymentSchemaVersionLatest {
+		return nil, fmt.Errorf("failed to export stack '%s': unsupported deployment version %d",
+			stack, untyped.Version)
+	}
+	var deployment apitype.DeploymentV3
+	if len(untyped.Deployment) > 0 {
+		if err := json.Unmarshal(untyped.Deployment, &deployment); err != nil {
+			return nil, fmt.Errorf("failed to export stack '%s': decoding deployment: %w", stack, err)
+		}
+	}
+	return &deployment, nil
+}
+
+// DecryptStackValues decrypts secrets encrypted with the stack's key, as
+// found in an exported checkpoint: base64-encoded ciphertexts. The result
+// maps each ciphertext to its plaintext.
+func (c *Client) DecryptStackValues(
+	ctx context.Context, stack StackIdentifier, ciphertexts []string,
+) (map[string][]byte, error) {
+	if len(ciphertexts) == 0 {
+		return map[string][]byte{}, nil
+	}
+	raw := make([][]byte, len(ciphertexts))
+	for i, ciphertext := range ciphertexts {
+		b, err := base64.StdEncoding.DecodeString(ciphertext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt values for stack '%s': invalid ciphertext: %w", stack, err)
+		}
+		raw[i] = b
+	}
+	resp, err := c.SDK.BatchDecryptValue(ctx, stack.OrgName, stack.ProjectName, stack.StackName,
+		apitype.BatchDecryptRequest{Ciphertexts: raw})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt values for stack '%s': %w", stack, err)
+	}
+	// The response is keyed by the base64 encoding of each ciphertext.
+	plaintexts := make(map[string][]byte, len(ciphertexts))
+	for i, ciphertext := range ciphertexts {
+		plaintext, ok := resp.Plaintexts[base64.StdEncoding.EncodeToString(raw[i])]
+		if !ok {
+			return nil, fmt.Errorf("failed to decrypt values for stack '%s': no plaintext returned", stack)
+		}
+		plaintexts[ciphertext] = plaintext
+	}
+	return plaintexts, nil
+}
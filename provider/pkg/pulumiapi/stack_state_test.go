@@ -0,0 +1,109 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pulumiapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+)
+
+func TestExportStack(t *testing.T) {
+	s := StackIdentifier{
+		OrgName:     organizationKey,
+		ProjectName: projectKey,
+		StackName:   stackKey,
+	}
+	deployment := apitype.UntypedDeployment{
+		Version: 3,
+		Deployment: json.RawMessage(`{"resources":[` +
+			`{"urn":"urn:pulumi:stack::project::pulumi:pulumi:Stack::project-stack",` +
+			`"type":"pulumi:pulumi:Stack","outputs":{"url":"https://example.com"}}]}`),
+	}
+
+	t.Run("Latest", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodGet,
+			ExpectedReqPath:   "/api/stacks/organization/project/stack/export",
+			ResponseCode:      http.StatusOK,
+			ResponseBody:      deployment,
+		})
+		got, err := c.ExportStack(ctx, s, nil)
+		require.NoError(t, err)
+		require.Len(t, got.Resources, 1)
+		assert.Equal(t, "pulumi:pulumi:Stack", string(got.Resources[0].Type))
+		assert.Equal(t, map[string]any{"url": "https://example.com"}, got.Resources[0].Outputs)
+	})
+
+	t.Run("At version", func(t *testing.T) {
+		version := 7
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodGet,
+			ExpectedReqPath:   "/api/stacks/organization/project/stack/export/7",
+			ResponseCode:      http.StatusOK,
+			ResponseBody:      deployment,
+		})
+		_, err := c.ExportStack(ctx, s, &version)
+		require.NoError(t, err)
+	})
+
+	t.Run("Unsupported version", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodGet,
+			ExpectedReqPath:   "/api/stacks/organization/project/stack/export",
+			ResponseCode:      http.StatusOK,
+			ResponseBody:      apitype.UntypedDeployment{Version: 99, Deployment: json.RawMessage(`{}`)},
+		})
+		_, err := c.ExportStack(ctx, s, nil)
+		assert.EqualError(t, err,
+			"failed to export stack 'organization/project/stack': unsupported deployment version 99")
+	})
+}
+
+func TestDecryptStackValues(t *testing.T) {
+	s := StackIdentifier{
+		OrgName:     organizationKey,
+		ProjectName: projectKey,
+		StackName:   stackKey,
+	}
+	ciphertext := base64.StdEncoding.EncodeToString([]byte("ciphertext"))
+
+	t.Run("Happy Path", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPost,
+			ExpectedReqPath:   "/api/stacks/organization/project/stack/batch-decrypt",
+			ExpectedReqBody:   apitype.BatchDecryptRequest{Ciphertexts: [][]byte{[]byte("ciphertext")}},
+			ResponseCode:      http.StatusOK,
+			ResponseBody: apitype.BatchDecryptResponse{
+				Plaintexts: map[string][]byte{ciphertext: []byte(`"hunter2"`)},
+			},
+		})
+		plaintexts, err := c.DecryptStackValues(ctx, s, []string{ciphertext})
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{ciphertext: []byte(`"hunter2"`)}, plaintexts)
+	})
+
+	t.Run("Nothing to decrypt", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{})
+		plaintexts, err := c.DecryptStackValues(ctx, s, nil)
+		require.NoError(t, err)
+		assert.Empty(t, plaintexts)
+	})
+}
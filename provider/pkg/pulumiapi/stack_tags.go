@@ -3,9 +3,9 @@ package pulumiapi
 import (
 	"context"
 	"fmt"
-	"net/http"
-	"path"
 	"strings"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
 
 type StackIdentifier struct {
@@ -42,14 +42,11 @@ type StackTag struct {
 	Value string `json:"value"`
 }
 
-// in order to retrieve stack tags, we have to get the entire stack. we only need to unmarshal the tags property
-type stack struct {
-	Tags map[string]string `json:"tags"`
-}
-
 func (c *Client) CreateStackTag(ctx context.Context, stack StackIdentifier, tag StackTag) error {
-	apiPath := path.Join("stacks", stack.OrgName, stack.ProjectName, stack.StackName, "tags")
-	_, err := c.do(ctx, http.MethodPost, apiPath, tag, nil)
+	err := c.SDK.AddStackTag(ctx, stack.OrgName, stack.ProjectName, stack.StackName, apitype.StackTag{
+		Name:  apitype.AppStackTagName(tag.Name),
+		Value: tag.Value,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to create tag (%s=%s): %w", tag.Name, tag.Value, err)
 	}
@@ -72,10 +69,7 @@ func (c *Client) GetStackTag(ctx context.Context, stackName StackIdentifier, tag
 }
 
 func (c *Client) DeleteStackTag(ctx context.Context, stackName StackIdentifier, tagName string) error {
-	apiPath := path.Join(
-		"stacks", stackName.OrgName, stackName.ProjectName, stackName.StackName, "tags", tagName,
-	)
-	_, err := c.do(ctx, http.MethodDelete, apiPath, nil, nil)
+	err := c.SDK.DeleteStackTag(ctx, stackName.OrgName, stackName.ProjectName, stackName.StackName, tagName)
 	if err != nil {
 		return fmt.Errorf("failed to make request: %w", err)
 	}
@@ -84,14 +78,14 @@ func (c *Client) DeleteStackTag(ctx context.Context, stackName StackIdentifier,
 
 // GetStackTags retrieves all tags for a stack
 func (c *Client) GetStackTags(ctx context.Context, stackName StackIdentifier) (map[string]string, error) {
-	apiPath := path.Join("stacks", stackName.OrgName, stackName.ProjectName, stackName.StackName)
-	var s stack
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &s)
+	// In order to retrieve stack tags, we have to get the entire stack.
+	s, err := c.SDK.GetStack(ctx, stackName.OrgName, stackName.ProjectName, stackName.StackName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get stack tags: %w", err)
 	}
-	if s.Tags == nil {
-		return make(map[string]string), nil
+	tags := make(map[string]string, len(s.Tags))
+	for k, v := range s.Tags {
+		tags[string(k)] = v
 	}
-	return s.Tags, nil
+	return tags, nil
 }
@@ -55,7 +55,7 @@ func TestCreateStackTags(t *testing.T) {
 			},
 		})
 		err := c.CreateStackTag(ctx, stackName, tag)
-		assert.EqualError(t, err, "failed to create tag (tagName=tagValue): 401 API error: unauthorized")
+		assert.EqualError(t, err, "failed to create tag (tagName=tagValue): HTTP 401: unauthorized")
 	})
 }
 
@@ -87,7 +87,7 @@ func TestDeleteStackTags(t *testing.T) {
 		assert.EqualError(
 			t,
 			c.DeleteStackTag(ctx, stackName, tagName),
-			"failed to make request: 401 API error: unauthorized",
+			"failed to make request: HTTP 401: unauthorized",
 		)
 	})
 }
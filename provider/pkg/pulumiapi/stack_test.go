@@ -7,6 +7,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+
+	cloudapitype "github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
 
 func TestCreateStack(t *testing.T) {
@@ -38,7 +42,7 @@ func TestCreateStack(t *testing.T) {
 			},
 		})
 		err := c.CreateStack(ctx, s)
-		assert.EqualError(t, err, "failed to create stack 'organization/project/stack': 401 API error: unauthorized")
+		assert.EqualError(t, err, "failed to create stack 'organization/project/stack': HTTP 401: unauthorized")
 	})
 }
 
@@ -76,6 +80,189 @@ func TestDeleteStack(t *testing.T) {
 				Message: unauthorizedError,
 			},
 		})
-		assert.EqualError(t, c.DeleteStack(ctx, s, false), "failed to delete stack: 401 API error: unauthorized")
+		assert.EqualError(t, c.DeleteStack(ctx, s, false), "failed to delete stack: HTTP 401: unauthorized")
+	})
+}
+
+func TestRenameStack(t *testing.T) {
+	s := StackIdentifier{
+		OrgName:     organizationKey,
+		ProjectName: projectKey,
+		StackName:   stackKey,
+	}
+	t.Run("Happy Path", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPost,
+			ExpectedReqPath:   "/api/stacks/organization/project/stack/rename",
+			ExpectedReqBody: apitype.StackRenameRequest{
+				NewName:    "renamed",
+				NewProject: "other-project",
+			},
+			ResponseCode: http.StatusNoContent,
+		})
+		assert.NoError(t, c.RenameStack(ctx, s, "other-project", "renamed"))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPost,
+			ExpectedReqPath:   "/api/stacks/organization/project/stack/rename",
+			ResponseCode:      http.StatusConflict,
+			ResponseBody: ErrorResponse{
+				Message: "stack already exists",
+			},
+		})
+		err := c.RenameStack(ctx, s, projectKey, "renamed")
+		assert.EqualError(t, err, "failed to rename stack 'organization/project/stack': HTTP 409: stack already exists")
+	})
+}
+
+func TestTransferStack(t *testing.T) {
+	s := StackIdentifier{
+		OrgName:     organizationKey,
+		ProjectName: projectKey,
+		StackName:   stackKey,
+	}
+	t.Run("Happy Path", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPost,
+			ExpectedReqPath:   "/api/stacks/organization/project/stack/transfer",
+			ExpectedReqBody:   cloudapitype.TransferStackRequest{ToOrg: "other-org"},
+			ResponseCode:      http.StatusNoContent,
+		})
+		assert.NoError(t, c.TransferStack(ctx, s, "other-org"))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPost,
+			ExpectedReqPath:   "/api/stacks/organization/project/stack/transfer",
+			ResponseCode:      http.StatusForbidden,
+			ResponseBody: ErrorResponse{
+				Message: "forbidden",
+			},
+		})
+		err := c.TransferStack(ctx, s, "other-org")
+		assert.EqualError(t, err,
+			"failed to transfer stack 'organization/project/stack' to organization 'other-org': HTTP 403: forbidden")
+	})
+}
+
+func TestStackConfig(t *testing.T) {
+	s := StackIdentifier{
+		OrgName:     organizationKey,
+		ProjectName: projectKey,
+		StackName:   stackKey,
+	}
+	const configPath = "/api/stacks/organization/project/stack/config"
+
+	t.Run("Get", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodGet,
+			ExpectedReqPath:   configPath,
+			ResponseCode:      http.StatusOK,
+			ResponseBody:      apitype.StackConfig{Environment: "project/dev"},
+		})
+		config, err := c.GetStackConfig(ctx, s)
+		assert.NoError(t, err)
+		assert.Equal(t, &StackConfig{Environment: "project/dev"}, config)
+	})
+
+	t.Run("Get without config", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodGet,
+			ExpectedReqPath:   configPath,
+			ResponseCode:      http.StatusNotFound,
+			ResponseBody:      ErrorResponse{Message: "not found"},
+		})
+		config, err := c.GetStackConfig(ctx, s)
+		assert.NoError(t, err)
+		assert.Nil(t, config)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPut,
+			ExpectedReqPath:   configPath,
+			ExpectedReqBody:   apitype.StackConfig{Environment: "project/dev"},
+			ResponseCode:      http.StatusOK,
+			ResponseBody:      apitype.StackConfig{Environment: "project/dev"},
+		})
+		assert.NoError(t, c.UpdateStackConfig(ctx, s, StackConfig{Environment: "project/dev"}))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodDelete,
+			ExpectedReqPath:   configPath,
+			ResponseCode:      http.StatusNoContent,
+		})
+		assert.NoError(t, c.DeleteStackConfig(ctx, s))
+	})
+}
+
+func TestEncryptStackValue(t *testing.T) {
+	s := StackIdentifier{
+		OrgName:     organizationKey,
+		ProjectName: projectKey,
+		StackName:   stackKey,
+	}
+	c := startTestServer(t, testServerConfig{
+		ExpectedReqMethod: http.MethodPost,
+		ExpectedReqPath:   "/api/stacks/organization/project/stack/encrypt",
+		ExpectedReqBody:   apitype.EncryptValueRequest{Plaintext: []byte("hunter2")},
+		ResponseCode:      http.StatusOK,
+		ResponseBody:      apitype.EncryptValueResponse{Ciphertext: []byte("ciphertext")},
+	})
+	ciphertext, err := c.EncryptStackValue(ctx, s, []byte("hunter2"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("ciphertext"), ciphertext)
+}
+
+func TestStackMetadata(t *testing.T) {
+	s := StackIdentifier{
+		OrgName:     organizationKey,
+		ProjectName: projectKey,
+		StackName:   stackKey,
+	}
+
+	t.Run("Get", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodGet,
+			ExpectedReqPath:   "/api/stacks/organization/project/stack/metadata",
+			ResponseCode:      http.StatusOK,
+			ResponseBody: cloudapitype.StackMetadata{
+				OwnedBy:              cloudapitype.UserInfo{GitHubLogin: "octocat"},
+				NotificationSettings: cloudapitype.StackNotificationSettings{NotifyUpdateFailure: true},
+			},
+		})
+		metadata, err := c.GetStackMetadata(ctx, s)
+		assert.NoError(t, err)
+		assert.Equal(t, &StackMetadata{
+			Owner:                "octocat",
+			NotificationSettings: StackNotificationSettings{NotifyUpdateFailure: true},
+		}, metadata)
+	})
+
+	t.Run("Update notification settings", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPatch,
+			ExpectedReqPath:   "/api/stacks/organization/project/stack/notifications/settings",
+			ExpectedReqBody:   StackNotificationSettings{NotifyUpdateFailure: true},
+			ResponseCode:      http.StatusOK,
+			ResponseBody:      cloudapitype.StackMetadata{},
+		})
+		assert.NoError(t, c.UpdateStackNotificationSettings(ctx, s, StackNotificationSettings{NotifyUpdateFailure: true}))
+	})
+
+	t.Run("Reassign ownership", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPost,
+			ExpectedReqPath:   "/api/stacks/organization/project/stack/ownership",
+			ExpectedReqBody:   cloudapitype.UserInfo{GitHubLogin: "octocat"},
+			ResponseCode:      http.StatusOK,
+			ResponseBody:      cloudapitype.UserInfo{GitHubLogin: "previous-owner"},
+		})
+		assert.NoError(t, c.ReassignStackOwnership(ctx, s, "octocat"))
 	})
 }
@@ -18,7 +18,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"path"
 )
 
 type TeamRoleClient interface {
@@ -33,10 +32,6 @@ type TeamRoleRef struct {
 	Name string `json:"name"`
 }
 
-type listTeamRolesResponse struct {
-	Roles []TeamRoleRef `json:"roles"`
-}
-
 // AssignRoleToTeam assigns a custom role to a team. The organization must
 // already have the custom-roles feature enabled; this client is a thin
 // wrapper around a single endpoint and performs no side effects.
@@ -51,8 +46,7 @@ func (c *Client) AssignRoleToTeam(ctx context.Context, orgName, teamName, roleID
 		return errors.New("role id should not be empty")
 	}
 
-	apiPath := path.Join("orgs", orgName, "teams", teamName, "roles", roleID)
-	if _, err := c.do(ctx, http.MethodPost, apiPath, nil, nil); err != nil {
+	if err := c.SDK.UpdateTeamRoles(ctx, orgName, teamName, roleID); err != nil {
 		return fmt.Errorf("failed to assign role to team: %w", err)
 	}
 	return nil
@@ -69,8 +63,7 @@ func (c *Client) RemoveRoleFromTeam(ctx context.Context, orgName, teamName, role
 		return errors.New("role id should not be empty")
 	}
 
-	apiPath := path.Join("orgs", orgName, "teams", teamName, "roles", roleID)
-	if _, err := c.do(ctx, http.MethodDelete, apiPath, nil, nil); err != nil {
+	if err := c.SDK.DeleteTeamRole(ctx, orgName, teamName, roleID); err != nil {
 		if GetErrorStatusCode(err) == http.StatusNotFound {
 			return nil
 		}
@@ -87,15 +80,18 @@ func (c *Client) ListTeamRoles(ctx context.Context, orgName, teamName string) ([
 		return nil, errors.New("team name should not be empty")
 	}
 
-	apiPath := path.Join("orgs", orgName, "teams", teamName, "roles")
-	var resp listTeamRolesResponse
-	if _, err := c.do(ctx, http.MethodGet, apiPath, nil, &resp); err != nil {
+	resp, err := c.SDK.ListTeamRoles(ctx, orgName, teamName)
+	if err != nil {
 		if GetErrorStatusCode(err) == http.StatusNotFound {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to list team roles: %w", err)
 	}
-	return resp.Roles, nil
+	roles := make([]TeamRoleRef, 0, len(resp.Roles))
+	for _, r := range resp.Roles {
+		roles = append(roles, TeamRoleRef{ID: r.ID, Name: r.Name})
+	}
+	return roles, nil
 }
 
 func (c *Client) GetTeamRole(ctx context.Context, orgName, teamName, roleID string) (*TeamRoleRef, error) {
@@ -18,7 +18,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"path"
+	"time"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
 
 const (
@@ -39,6 +41,8 @@ type TeamClient interface {
 	DeleteTeam(ctx context.Context, orgName, teamName string) error
 	AddMemberToTeam(ctx context.Context, orgName, teamName, userName string) error
 	DeleteMemberFromTeam(ctx context.Context, orgName, teamName, userName string) error
+	UpdateTeamMembers(ctx context.Context, orgName, teamName string, add, remove []string) error
+	ListGitHubOrganizationTeams(ctx context.Context, githubOrgName string) ([]GitHubTeam, error)
 	AddStackPermission(ctx context.Context, stack StackIdentifier, teamName string, permission int) error
 	RemoveStackPermission(ctx context.Context, stack StackIdentifier, teamName string) error
 	GetTeamStackPermission(ctx context.Context, stack StackIdentifier, teamName string) (*int, error)
@@ -68,6 +72,15 @@ type TeamMember struct {
 	Role        string
 }
 
+// GitHubTeam is a team of the GitHub organization backing a Pulumi
+// organization, which a "github" team can mirror.
+type GitHubTeam struct {
+	ID          int64
+	Name        string
+	Slug        string
+	Description string
+}
+
 type TeamStackPermission struct {
 	ProjectName string `json:"projectName"`
 	StackName   string `json:"stackName"`
@@ -81,44 +94,6 @@ type TeamEnvironmentSettings struct {
 	MaxOpenDuration *Duration `json:"maxOpenDuration,omitempty"`
 }
 
-type createTeamRequest struct {
-	Organization string `json:"organization"`
-	TeamType     string `json:"teamType"`
-	Name         string `json:"name"`
-	DisplayName  string `json:"displayName"`
-	Description  string `json:"description"`
-	GitHubTeamID int64  `json:"githubTeamID,omitempty"`
-}
-
-type updateTeamRequest struct {
-	NewDisplayName string `json:"newDisplayName"`
-	NewDescription string `json:"newDescription"`
-}
-
-type updateTeamMembershipRequest struct {
-	MemberAction string `json:"memberAction"`
-	Member       string `json:"member"`
-}
-
-type AddStackPermission struct {
-	ProjectName string `json:"projectName"`
-	StackName   string `json:"stackName"`
-	Permission  int    `json:"permission"`
-}
-
-type addStackPermissionRequest struct {
-	AddStackPermission AddStackPermission `json:"addStackPermission"`
-}
-
-type RemoveStackPermission struct {
-	ProjectName string `json:"projectName"`
-	StackName   string `json:"stackName"`
-}
-
-type removeStackPermissionRequest struct {
-	RemoveStackPermission RemoveStackPermission `json:"removeStack"`
-}
-
 type CreateTeamEnvironmentSettingsRequest struct {
 	TeamEnvironmentSettingsRequest
 	Permission      string    `json:"permission,omitempty"`
@@ -132,24 +107,14 @@ type TeamEnvironmentSettingsRequest struct {
 	Project      string `json:"project,omitempty"`
 }
 
-type AddEnvironmentPermission struct {
-	EnvName         string    `json:"envName"`
-	ProjectName     string    `json:"projectName"`
-	Permission      string    `json:"permission"`
-	MaxOpenDuration *Duration `json:"maxOpenDuration,omitempty"`
-}
-
-type addEnvironmentSettingsRequest struct {
-	AddEnvironmentPermission AddEnvironmentPermission `json:"addEnvironmentPermission"`
-}
-
-type RemoveEnvironmentPermission struct {
-	EnvName     string `json:"envName"`
-	ProjectName string `json:"projectName"`
-}
-
-type removeEnvironmentPermissionRequest struct {
-	RemoveEnvironment RemoveEnvironmentPermission `json:"removeEnvironment"`
+// newTeam converts a generated team into the provider's shape. The two share
+// their JSON encoding, so the team is carried across by round-tripping it.
+func newTeam(team *apitype.Team) (*Team, error) {
+	var res Team
+	if err := remarshal(team, &res); err != nil {
+		return nil, fmt.Errorf("failed to parse team: %w", err)
+	}
+	return &res, nil
 }
 
 func (c *Client) ListTeams(ctx context.Context, orgName string) ([]Team, error) {
@@ -157,13 +122,14 @@ func (c *Client) ListTeams(ctx context.Context, orgName string) ([]Team, error)
 		return nil, errors.New("empty orgName")
 	}
 
-	apiURL := path.Join("orgs", orgName, "teams")
-
-	var teamArray Teams
-	_, err := c.do(ctx, http.MethodGet, apiURL, nil, &teamArray)
+	res, err := c.SDK.ListTeams(ctx, orgName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list teams for %q: %w", orgName, err)
 	}
+	var teamArray Teams
+	if err := remarshal(res, &teamArray); err != nil {
+		return nil, fmt.Errorf("failed to parse teams for %q: %w", orgName, err)
+	}
 	return teamArray.Teams, nil
 }
 
@@ -176,10 +142,7 @@ func (c *Client) GetTeam(ctx context.Context, orgName string, teamName string) (
 		return nil, errors.New("empty teamName")
 	}
 
-	apiPath := path.Join("orgs", orgName, "teams", teamName)
-
-	var team Team
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &team)
+	team, err := c.SDK.GetTeam(ctx, orgName, teamName)
 	if err != nil {
 		statusCode := GetErrorStatusCode(err)
 		if statusCode == http.StatusNotFound {
@@ -189,7 +152,7 @@ func (c *Client) GetTeam(ctx context.Context, orgName string, teamName string) (
 		return nil, fmt.Errorf("failed to get team: %w", err)
 	}
 
-	return &team, nil
+	return newTeam(team)
 }
 
 func (c *Client) CreateTeam(
@@ -214,24 +177,22 @@ func (c *Client) CreateTeam(
 		return nil, errors.New("github teams require a githubTeamId")
 	}
 
-	apiPath := path.Join("orgs", orgName, "teams", teamType)
-
-	createReq := createTeamRequest{
-		Organization: orgName,
-		TeamType:     teamType,
-		Name:         teamName,
-		DisplayName:  displayName,
-		Description:  description,
-		GitHubTeamID: teamID,
+	var team *apitype.Team
+	var err error
+	if teamType == githubTeamType {
+		team, err = c.SDK.CreateGitHubTeam(ctx, orgName, apitype.CreateGitHubTeamRequest{TeamID: teamID})
+	} else {
+		team, err = c.SDK.CreatePulumiTeam(ctx, orgName, apitype.CreatePulumiTeamRequest{
+			Name:        teamName,
+			DisplayName: displayName,
+			Description: description,
+		})
 	}
-
-	var team Team
-	_, err := c.do(ctx, http.MethodPost, apiPath, createReq, &team)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create team: %w", err)
 	}
 
-	return &team, nil
+	return newTeam(team)
 }
 
 func (c *Client) UpdateTeam(ctx context.Context, orgName, teamName, displayName, description string) error {
@@ -243,14 +204,12 @@ func (c *Client) UpdateTeam(ctx context.Context, orgName, teamName, displayName,
 		return errors.New("teamname must not be empty")
 	}
 
-	apiPath := path.Join("orgs", orgName, "teams", teamName)
-
-	updateReq := updateTeamRequest{
-		NewDisplayName: displayName,
-		NewDescription: description,
+	updateReq := apitype.UpdateTeamRequest{
+		NewDisplayName: &displayName,
+		NewDescription: &description,
 	}
 
-	_, err := c.do(ctx, "PATCH", apiPath, updateReq, nil)
+	err := c.SDK.UpdateTeam(ctx, orgName, teamName, updateReq)
 	if err != nil {
 		return fmt.Errorf("failed to update team: %w", err)
 	}
@@ -266,9 +225,7 @@ func (c *Client) DeleteTeam(ctx context.Context, orgName, teamName string) error
 		return errors.New("teamname must not be empty")
 	}
 
-	apiPath := path.Join("orgs", orgName, "teams", teamName)
-
-	_, err := c.do(ctx, http.MethodDelete, apiPath, nil, nil)
+	err := c.SDK.DeleteTeam(ctx, orgName, teamName)
 	if err != nil {
 		return fmt.Errorf("failed to delete team: %w", err)
 	}
@@ -293,14 +250,13 @@ func (c *Client) updateTeamMembership(ctx context.Context, orgName, teamName, us
 		return errors.New("value must be `add` or `remove`")
 	}
 
-	apiPath := path.Join("orgs", orgName, "teams", teamName)
-
-	updateMembershipReq := updateTeamMembershipRequest{
-		MemberAction: addOrRemove,
-		Member:       userName,
+	memberAction := apitype.MemberAction(addOrRemove)
+	updateMembershipReq := apitype.UpdateTeamRequest{
+		MemberAction: &memberAction,
+		Member:       &userName,
 	}
 
-	_, err := c.do(ctx, http.MethodPatch, apiPath, updateMembershipReq, nil)
+	err := c.SDK.UpdateTeam(ctx, orgName, teamName, updateMembershipReq)
 	if err != nil {
 		return fmt.Errorf("failed to update team membership: %w", err)
 	}
@@ -328,6 +284,41 @@ func (c *Client) DeleteMemberFromTeam(ctx context.Context, orgName, teamName, us
 	return nil
 }
 
+// UpdateTeamMembers removes then adds the given members. The API changes one
+// member per request, so callers should only pass actual differences. Every
+// change is attempted; the failures are returned together.
+func (c *Client) UpdateTeamMembers(ctx context.Context, orgName, teamName string, add, remove []string) error {
+	var errs []error
+	for _, userName := range remove {
+		if err := c.DeleteMemberFromTeam(ctx, orgName, teamName, userName); err != nil {
+			errs = append(errs, fmt.Errorf("removing %s: %w", userName, err))
+		}
+	}
+	for _, userName := range add {
+		if err := c.AddMemberToTeam(ctx, orgName, teamName, userName); err != nil {
+			errs = append(errs, fmt.Errorf("adding %s: %w", userName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ListGitHubOrganizationTeams lists the teams of a GitHub organization, as
+// visible to the user the client authenticates as.
+func (c *Client) ListGitHubOrganizationTeams(ctx context.Context, githubOrgName string) ([]GitHubTeam, error) {
+	if len(githubOrgName) == 0 {
+		return nil, errors.New("githubOrgName must not be empty")
+	}
+	res, err := c.SDK.ListGitHubOrganizationTeams(ctx, githubOrgName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams of GitHub organization %q: %w", githubOrgName, err)
+	}
+	teams := make([]GitHubTeam, 0, len(res.Teams))
+	for _, t := range res.Teams {
+		teams = append(teams, GitHubTeam{ID: t.ID, Name: t.Name, Slug: t.Slug, Description: t.Description})
+	}
+	return teams, nil
+}
+
 func (c *Client) AddStackPermission(ctx context.Context, stack StackIdentifier, teamName string, permission int) error {
 	if len(stack.OrgName) == 0 {
 		return errors.New("orgname must not be empty")
@@ -337,17 +328,15 @@ func (c *Client) AddStackPermission(ctx context.Context, stack StackIdentifier,
 		return errors.New("teamname must not be empty")
 	}
 
-	apiPath := path.Join("orgs", stack.OrgName, "teams", teamName)
-
-	addStackPermissionRequest := addStackPermissionRequest{
-		AddStackPermission: AddStackPermission{
+	addStackPermissionRequest := apitype.UpdateTeamRequest{
+		AddStackPermission: &apitype.TeamStackPermission{
 			ProjectName: stack.ProjectName,
 			StackName:   stack.StackName,
-			Permission:  permission,
+			Permission:  apitype.StackPermission(permission),
 		},
 	}
 
-	_, err := c.do(ctx, http.MethodPatch, apiPath, addStackPermissionRequest, nil)
+	err := c.SDK.UpdateTeam(ctx, stack.OrgName, teamName, addStackPermissionRequest)
 	if err != nil {
 		return fmt.Errorf("failed to add stack permission for team: %w", err)
 	}
@@ -363,13 +352,11 @@ func (c *Client) RemoveStackPermission(ctx context.Context, stack StackIdentifie
 		return errors.New("teamname must not be empty")
 	}
 
-	apiPath := path.Join("orgs", stack.OrgName, "teams", teamName)
-
-	removeStackPermissionRequest := removeStackPermissionRequest{
-		RemoveStackPermission: RemoveStackPermission{ProjectName: stack.ProjectName, StackName: stack.StackName},
+	removeStackPermissionRequest := apitype.UpdateTeamRequest{
+		RemoveStack: &apitype.RemoveStackIdentifier{ProjectName: stack.ProjectName, StackName: stack.StackName},
 	}
 
-	_, err := c.do(ctx, http.MethodPatch, apiPath, removeStackPermissionRequest, nil)
+	err := c.SDK.UpdateTeam(ctx, stack.OrgName, teamName, removeStackPermissionRequest)
 	if err != nil {
 		return fmt.Errorf("failed to remove stack permission for team: %w", err)
 	}
@@ -385,17 +372,15 @@ func (c *Client) GetTeamStackPermission(ctx context.Context, stack StackIdentifi
 		return nil, errors.New("teamname must not be empty")
 	}
 
-	apiPath := path.Join("orgs", stack.OrgName, "teams", teamName)
-
-	var team Team
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &team)
+	team, err := c.SDK.GetTeam(ctx, stack.OrgName, teamName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get team: %w", err)
 	}
 
 	for _, stackPermission := range team.Stacks {
 		if stackPermission.ProjectName == stack.ProjectName && stackPermission.StackName == stack.StackName {
-			return &stackPermission.Permission, nil
+			permission := int(stackPermission.Permission)
+			return &permission, nil
 		}
 	}
 
@@ -413,18 +398,21 @@ func (c *Client) AddEnvironmentSettings(ctx context.Context, req CreateTeamEnvir
 		return errors.New("environment name must not be empty")
 	}
 
-	apiPath := path.Join("orgs", req.Organization, "teams", req.Team)
-
-	addEnvironmentSettingsRequest := addEnvironmentSettingsRequest{
-		AddEnvironmentPermission: AddEnvironmentPermission{
+	var maxOpenDuration *string
+	if req.MaxOpenDuration != nil {
+		d := time.Duration(*req.MaxOpenDuration).String()
+		maxOpenDuration = &d
+	}
+	addEnvironmentSettingsRequest := apitype.UpdateTeamRequest{
+		AddEnvironmentPermission: &apitype.TeamEnvironmentSettings{
 			ProjectName:     req.Project,
 			EnvName:         req.Environment,
-			Permission:      req.Permission,
-			MaxOpenDuration: req.MaxOpenDuration,
+			Permission:      apitype.EnvironmentPermission(req.Permission),
+			MaxOpenDuration: maxOpenDuration,
 		},
 	}
 
-	_, err := c.do(ctx, http.MethodPatch, apiPath, addEnvironmentSettingsRequest, nil)
+	err := c.SDK.UpdateTeam(ctx, req.Organization, req.Team, addEnvironmentSettingsRequest)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to add team settings for environment %s to team %s due to error: %w",
@@ -447,16 +435,14 @@ func (c *Client) RemoveEnvironmentSettings(ctx context.Context, req TeamEnvironm
 		return errors.New("environment name must not be empty")
 	}
 
-	apiPath := path.Join("orgs", req.Organization, "teams", req.Team)
-
-	removeEnvironmentSettingsRequest := removeEnvironmentPermissionRequest{
-		RemoveEnvironment: RemoveEnvironmentPermission{
+	removeEnvironmentSettingsRequest := apitype.UpdateTeamRequest{
+		RemoveEnvironment: &apitype.RemoveEnvironmentIdentifier{
 			ProjectName: req.Project,
 			EnvName:     req.Environment,
 		},
 	}
 
-	_, err := c.do(ctx, http.MethodPatch, apiPath, removeEnvironmentSettingsRequest, nil)
+	err := c.SDK.UpdateTeam(ctx, req.Organization, req.Team, removeEnvironmentSettingsRequest)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to remove permissions for environment %s from team %s due to error: %w",
@@ -482,13 +468,14 @@ func (c *Client) GetTeamEnvironmentSettings(
 		return nil, nil, errors.New("environment name must not be empty")
 	}
 
-	apiPath := path.Join("orgs", req.Organization, "teams", req.Team)
-
-	var team Team
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &team)
+	res, err := c.SDK.GetTeam(ctx, req.Organization, req.Team)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get team environment permission: %w", err)
 	}
+	team, err := newTeam(res)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	for _, settings := range team.Environments {
 		if settings.EnvName == req.Environment && settings.ProjectName == req.Project {
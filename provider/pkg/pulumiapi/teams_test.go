@@ -1,12 +1,15 @@
 package pulumiapi
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/pgavlin/fx/v2"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
 
 const (
@@ -56,7 +59,7 @@ func TestListTeams(t *testing.T) {
 		})
 		teamsList, err := c.ListTeams(ctx, orgName)
 		assert.Nil(t, teamsList, "if list teams has error, no teams object should be returned")
-		assert.EqualError(t, err, `failed to list teams for "an-organization": 401 API error: unauthorized`)
+		assert.EqualError(t, err, `failed to list teams for "an-organization": HTTP 401: unauthorized`)
 	})
 }
 
@@ -96,7 +99,7 @@ func TestGetTeam(t *testing.T) {
 		})
 		team, err := c.GetTeam(ctx, orgName, teamName)
 		assert.Nil(t, team, "team should be nil since error was returned")
-		assert.EqualError(t, err, "failed to get team: 401 API error: unauthorized")
+		assert.EqualError(t, err, "failed to get team: HTTP 401: unauthorized")
 	})
 
 	t.Run("404", func(t *testing.T) {
@@ -130,12 +133,10 @@ func TestCreateTeam(t *testing.T) {
 		c := startTestServer(t, testServerConfig{
 			ExpectedReqMethod: http.MethodPost,
 			ExpectedReqPath:   "/api/orgs/an-organization/teams/pulumi",
-			ExpectedReqBody: createTeamRequest{
-				Organization: orgName,
-				TeamType:     pulumiTeamType,
-				Name:         teamName,
-				DisplayName:  displayName,
-				Description:  description,
+			ExpectedReqBody: apitype.CreatePulumiTeamRequest{
+				Name:        teamName,
+				DisplayName: displayName,
+				Description: description,
 			},
 			ResponseBody: expected,
 			ResponseCode: 201,
@@ -154,10 +155,8 @@ func TestCreateTeam(t *testing.T) {
 		c := startTestServer(t, testServerConfig{
 			ExpectedReqMethod: http.MethodPost,
 			ExpectedReqPath:   "/api/orgs/an-organization/teams/github",
-			ExpectedReqBody: createTeamRequest{
-				TeamType:     githubTeamType,
-				Organization: orgName,
-				GitHubTeamID: 1,
+			ExpectedReqBody: apitype.CreateGitHubTeamRequest{
+				TeamID: 1,
 			},
 			ResponseBody: expected,
 			ResponseCode: 201,
@@ -170,12 +169,10 @@ func TestCreateTeam(t *testing.T) {
 		c := startTestServer(t, testServerConfig{
 			ExpectedReqMethod: http.MethodPost,
 			ExpectedReqPath:   "/api/orgs/an-organization/teams/pulumi",
-			ExpectedReqBody: createTeamRequest{
-				Organization: orgName,
-				TeamType:     pulumiTeamType,
-				Name:         teamName,
-				DisplayName:  displayName,
-				Description:  description,
+			ExpectedReqBody: apitype.CreatePulumiTeamRequest{
+				Name:        teamName,
+				DisplayName: displayName,
+				Description: description,
 			},
 			ResponseCode: 401,
 			ResponseBody: ErrorResponse{
@@ -184,7 +181,7 @@ func TestCreateTeam(t *testing.T) {
 		})
 		team, err := c.CreateTeam(ctx, orgName, teamName, pulumiTeamType, displayName, description, 0)
 		assert.Nil(t, team, "team should be nil since error was returned")
-		assert.EqualError(t, err, "failed to create team: 401 API error: unauthorized")
+		assert.EqualError(t, err, "failed to create team: HTTP 401: unauthorized")
 	})
 	t.Run("Error (github team missing ID)", func(t *testing.T) {
 		c := startTestServer(t, testServerConfig{})
@@ -240,11 +237,48 @@ func TestAddMemberToTeam(t *testing.T) {
 		})
 		assert.EqualError(t,
 			c.AddMemberToTeam(ctx, orgName, teamName, userName),
-			"failed to update team membership: 401 API error: unauthorized",
+			"failed to update team membership: HTTP 401: unauthorized",
 		)
 	})
 }
 
+func TestUpdateTeamMembers(t *testing.T) {
+	var got []updateTeamMembershipRequest
+	c := startTestServerMulti(t, func(r *http.Request) (int, any) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, teamPath, r.URL.Path)
+		var req updateTeamMembershipRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		got = append(got, req)
+		if req.Member == "forbidden" {
+			return http.StatusForbidden, ErrorResponse{Message: "forbidden"}
+		}
+		return http.StatusNoContent, nil
+	})
+
+	err := c.UpdateTeamMembers(ctx, testTeamOrgName, testTeamName, []string{"new", "forbidden"}, []string{"old"})
+	assert.EqualError(t, err, "adding forbidden: failed to update team membership: HTTP 403: forbidden")
+	assert.Equal(t, []updateTeamMembershipRequest{
+		{MemberAction: "remove", Member: "old"},
+		{MemberAction: addMembershipAction, Member: "new"},
+		{MemberAction: addMembershipAction, Member: "forbidden"},
+	}, got)
+}
+
+func TestListGitHubOrganizationTeams(t *testing.T) {
+	c := startTestServer(t, testServerConfig{
+		ExpectedReqMethod: http.MethodGet,
+		ExpectedReqPath:   "/api/user/github/acme/teams",
+		ResponseCode:      200,
+		ResponseBody: apitype.ListGitHubOrganizationTeamsResponse{Teams: []apitype.GitHubTeam{
+			{ID: 42, Name: "Platform", Slug: "platform", KnownToPulumi: true},
+		}},
+	})
+	teams, err := c.ListGitHubOrganizationTeams(ctx, "acme")
+	assert.NoError(t, err)
+	assert.Equal(t, []GitHubTeam{{ID: 42, Name: "Platform", Slug: "platform"}}, teams)
+}
+
 func TestAddStackPermission(t *testing.T) {
 	teamName := testTeamName
 	stack := StackIdentifier{
@@ -258,7 +292,7 @@ func TestAddStackPermission(t *testing.T) {
 			ExpectedReqMethod: http.MethodPatch,
 			ExpectedReqPath:   teamPath,
 			ExpectedReqBody: addStackPermissionRequest{
-				AddStackPermission: AddStackPermission{
+				AddStackPermission: addStackPermission{
 					ProjectName: stack.ProjectName,
 					StackName:   stack.StackName,
 					Permission:  permission,
@@ -282,7 +316,7 @@ func TestRemoveStackPermission(t *testing.T) {
 			ExpectedReqMethod: http.MethodPatch,
 			ExpectedReqPath:   teamPath,
 			ExpectedReqBody: removeStackPermissionRequest{
-				RemoveStackPermission: RemoveStackPermission{
+				RemoveStackPermission: removeStackPermission{
 					ProjectName: stack.ProjectName,
 					StackName:   stack.StackName,
 				},
@@ -304,7 +338,7 @@ func TestAddEnvironmentPermission(t *testing.T) {
 			ExpectedReqMethod: http.MethodPatch,
 			ExpectedReqPath:   teamPath,
 			ExpectedReqBody: addEnvironmentSettingsRequest{
-				AddEnvironmentPermission: AddEnvironmentPermission{
+				AddEnvironmentPermission: addEnvironmentPermission{
 					ProjectName:     project,
 					EnvName:         environment,
 					Permission:      permission,
@@ -393,7 +427,7 @@ func TestRemoveEnvironmentPermission(t *testing.T) {
 			ExpectedReqMethod: http.MethodPatch,
 			ExpectedReqPath:   teamPath,
 			ExpectedReqBody: removeEnvironmentPermissionRequest{
-				RemoveEnvironment: RemoveEnvironmentPermission{
+				RemoveEnvironment: removeEnvironmentPermission{
 					ProjectName: project,
 					EnvName:     environment,
 				},
@@ -17,29 +17,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
-	"path"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
 
 type TeamAccessTokenClient interface {
-	CreateTeamAccessToken(ctx context.Context, name, orgName, teamName, description string) (*AccessToken, error)
+	CreateTeamAccessToken(
+		ctx context.Context, name, orgName, teamName, description string, expires int64,
+	) (*AccessToken, error)
 	DeleteTeamAccessToken(ctx context.Context, tokenID, orgName, teamName string) error
 	GetTeamAccessToken(ctx context.Context, tokenID, orgName, teamName string) (*AccessToken, error)
 }
 
-type createTeamTokenResponse struct {
-	ID         string `json:"id"`
-	TokenValue string `json:"tokenValue"`
-}
-
-type createTeamTokenRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-}
-
+// CreateTeamAccessToken creates a team access token expiring at expires, a
+// Unix timestamp in seconds, or never when it is zero.
 func (c *Client) CreateTeamAccessToken(
 	ctx context.Context,
 	name, orgName, teamName, description string,
+	expires int64,
 ) (*AccessToken, error) {
 
 	if len(orgName) == 0 {
@@ -50,17 +45,15 @@ func (c *Client) CreateTeamAccessToken(
 		return nil, errors.New("empty teamName")
 	}
 
-	apiPath := path.Join("orgs", orgName, "teams", teamName, "tokens")
-
-	createReq := createTeamTokenRequest{
-		Name:        name,
-		Description: description,
+	createReq := apitype.CreateTeamAccessTokenRequest{
+		BaseCreateAccessTokenRequest: apitype.BaseCreateAccessTokenRequest{
+			Description: description,
+			Expires:     expires,
+		},
+		Name: name,
 	}
 
-	var createRes createTeamTokenResponse
-
-	_, err := c.do(ctx, http.MethodPost, apiPath, createReq, &createRes)
-
+	createRes, err := c.SDK.CreateTeamToken(ctx, orgName, teamName, nil, createReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create access token: %w", err)
 	}
@@ -68,7 +61,8 @@ func (c *Client) CreateTeamAccessToken(
 	return &AccessToken{
 		ID:          createRes.ID,
 		TokenValue:  createRes.TokenValue,
-		Description: createReq.Description,
+		Description: description,
+		Expires:     expires,
 	}, nil
 
 }
@@ -86,9 +80,7 @@ func (c *Client) DeleteTeamAccessToken(ctx context.Context, tokenID, orgName, te
 		return errors.New("orgName length must be greater than zero")
 	}
 
-	apiPath := path.Join("orgs", orgName, "teams", teamName, "tokens", tokenID)
-
-	_, err := c.do(ctx, http.MethodDelete, apiPath, nil, nil)
+	err := c.SDK.DeleteTeamToken(ctx, orgName, teamName, tokenID, nil)
 	if err != nil {
 		return fmt.Errorf("failed to delete access token %q: %w", tokenID, err)
 	}
@@ -97,12 +89,7 @@ func (c *Client) DeleteTeamAccessToken(ctx context.Context, tokenID, orgName, te
 }
 
 func (c *Client) GetTeamAccessToken(ctx context.Context, tokenID, orgName, teamName string) (*AccessToken, error) {
-	apiPath := path.Join("orgs", orgName, "teams", teamName, "tokens")
-
-	var listRes listTokenResponse
-
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &listRes)
-
+	listRes, err := c.SDK.ListTeamTokens(ctx, orgName, teamName, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list team access tokens: %w", err)
 	}
@@ -112,7 +99,10 @@ func (c *Client) GetTeamAccessToken(ctx context.Context, tokenID, orgName, teamN
 		if token.ID == tokenID {
 			return &AccessToken{
 				ID:          token.ID,
+				Name:        token.Name,
 				Description: token.Description,
+				Expires:     token.Expires,
+				LastUsed:    token.LastUsed,
 			}, nil
 		}
 	}
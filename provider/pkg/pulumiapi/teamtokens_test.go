@@ -41,7 +41,7 @@ func TestDeleteTeamAccessToken(t *testing.T) {
 		})
 		assert.EqualError(t,
 			c.DeleteTeamAccessToken(teamCtx, tokenID, orgName, teamName),
-			`failed to delete access token "abcdegh": 404 API error: token not found`,
+			`failed to delete access token "abcdegh": HTTP 404: token not found`,
 		)
 	})
 
@@ -67,7 +67,7 @@ func TestCreateTeamAccessToken(t *testing.T) {
 			ResponseCode:    201,
 			ResponseBody:    resp,
 		})
-		token, err := c.CreateTeamAccessToken(teamCtx, tokenName, orgName, teamName, desc)
+		token, err := c.CreateTeamAccessToken(teamCtx, tokenName, orgName, teamName, desc, 0)
 		assert.NoError(t, err)
 		assert.Equal(t, &AccessToken{
 			ID:          resp.ID,
@@ -90,11 +90,11 @@ func TestCreateTeamAccessToken(t *testing.T) {
 				Message:    unauthorizedError,
 			},
 		})
-		token, err := c.CreateTeamAccessToken(teamCtx, tokenName, orgName, teamName, desc)
+		token, err := c.CreateTeamAccessToken(teamCtx, tokenName, orgName, teamName, desc, 0)
 		assert.Nil(t, token, "token should be nil")
 		assert.EqualError(t,
 			err,
-			`failed to create access token: 401 API error: unauthorized`,
+			`failed to create access token: HTTP 401: unauthorized`,
 		)
 	})
 }
@@ -132,6 +132,7 @@ func TestGetTeamAccessToken(t *testing.T) {
 		assert.Equal(t, &AccessToken{
 			ID:          id,
 			Description: desc,
+			LastUsed:    int64(lastUsed),
 		}, token)
 	})
 
@@ -150,7 +151,7 @@ func TestGetTeamAccessToken(t *testing.T) {
 		assert.Nil(t, token, "token should be nil")
 		assert.EqualError(t,
 			err,
-			`failed to list team access tokens: 401 API error: unauthorized`,
+			`failed to list team access tokens: HTTP 401: unauthorized`,
 		)
 	})
 }
@@ -4,7 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"path"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
 
 type TemplateSourceClient interface {
@@ -38,8 +39,29 @@ type TemplateSourceResponse struct {
 	Destination *CreateTemplateSourceRequestDestination `json:"destination"`
 }
 
-type ListResponse struct {
-	Sources []TemplateSourceResponse `json:"sources"`
+func (r CreateTemplateSourceRequest) toUpsert() apitype.UpsertOrgTemplateSourceRequest {
+	req := apitype.UpsertOrgTemplateSourceRequest{
+		Name:      r.Name,
+		SourceURL: r.SourceURL,
+	}
+	if r.Destination != nil && r.Destination.URL != nil {
+		req.Destination = &apitype.TemplateDestination{URL: *r.Destination.URL}
+	}
+	return req
+}
+
+func newTemplateSourceResponse(source *apitype.TemplateSource) *TemplateSourceResponse {
+	res := &TemplateSourceResponse{
+		ID:        source.ID,
+		IsValid:   source.IsValid,
+		Name:      source.Name,
+		SourceURL: source.SourceURL,
+	}
+	if source.Destination != nil {
+		url := source.Destination.URL
+		res.Destination = &CreateTemplateSourceRequestDestination{URL: &url}
+	}
+	return res
 }
 
 func (c *Client) CreateTemplateSource(
@@ -47,9 +69,7 @@ func (c *Client) CreateTemplateSource(
 	organizationName string,
 	request CreateTemplateSourceRequest,
 ) (*TemplateSourceResponse, error) {
-	apiPath := path.Join("orgs", organizationName, "templates/sources")
-	var response TemplateSourceResponse
-	_, err := c.do(ctx, http.MethodPost, apiPath, request, &response)
+	response, err := c.SDK.CreateOrgTemplateCollection(ctx, organizationName, request.toUpsert())
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to create template source in org %s: %+v due to error: %w",
@@ -58,7 +78,7 @@ func (c *Client) CreateTemplateSource(
 			err,
 		)
 	}
-	return &response, nil
+	return newTemplateSourceResponse(response), nil
 }
 
 func (c *Client) UpdateTemplateSource(
@@ -67,9 +87,7 @@ func (c *Client) UpdateTemplateSource(
 	templateID string,
 	request CreateTemplateSourceRequest,
 ) (*TemplateSourceResponse, error) {
-	apiPath := path.Join("orgs", organizationName, "templates/sources", templateID)
-	var response TemplateSourceResponse
-	_, err := c.do(ctx, http.MethodPatch, apiPath, request, &response)
+	response, err := c.SDK.UpdateOrgTemplateCollection(ctx, organizationName, templateID, request.toUpsert())
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to update template source in org %s with id %s: %+v due to error: %w",
@@ -79,7 +97,7 @@ func (c *Client) UpdateTemplateSource(
 			err,
 		)
 	}
-	return &response, nil
+	return newTemplateSourceResponse(response), nil
 }
 
 func (c *Client) GetTemplateSource(
@@ -91,9 +109,7 @@ func (c *Client) GetTemplateSource(
 	// Thus, using a List and then finding by ID
 	// TODO issue to improve this - https://github.com/pulumi/pulumi-service/issues/21637
 
-	apiPath := path.Join("orgs", organizationName, "templates/sources")
-	var templateSources ListResponse
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &templateSources)
+	templateSources, err := c.SDK.GetOrgTemplateCollections(ctx, organizationName)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to get template source in org %s with id %s due to error: %w",
@@ -104,8 +120,8 @@ func (c *Client) GetTemplateSource(
 	}
 
 	for _, source := range templateSources.Sources {
-		if source.ID == templateID {
-			return &source, nil
+		if source != nil && source.ID == templateID {
+			return newTemplateSourceResponse(source), nil
 		}
 	}
 
@@ -113,9 +129,8 @@ func (c *Client) GetTemplateSource(
 }
 
 func (c *Client) DeleteTemplateSource(ctx context.Context, organizationName string, templateID string) error {
-	apiPath := path.Join("orgs", organizationName, "templates/sources", templateID)
-	response, err := c.do(ctx, http.MethodDelete, apiPath, nil, nil)
-	if response.StatusCode == 404 {
+	err := c.SDK.DeleteOrgTemplateCollection(ctx, organizationName, templateID)
+	if GetErrorStatusCode(err) == http.StatusNotFound {
 		return nil
 	}
 	if err != nil {
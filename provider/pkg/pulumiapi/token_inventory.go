@@ -0,0 +1,131 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumiapi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
+)
+
+// Kinds of access tokens in an inventory.
+const (
+	TokenKindOrganization = "organization"
+	TokenKindTeam         = "team"
+	TokenKindPersonal     = "personal"
+)
+
+// TokenKinds are every kind of access token, in inventory order.
+var TokenKinds = []string{TokenKindOrganization, TokenKindTeam, TokenKindPersonal}
+
+// TokenInventoryClient lists the access tokens of an organization across
+// kinds, and revokes them.
+type TokenInventoryClient interface {
+	ListTokenInventory(ctx context.Context, orgName string, kinds []string) ([]InventoryToken, error)
+	RevokeInventoryToken(ctx context.Context, orgName string, token InventoryToken) error
+}
+
+// InventoryToken is an access token of any kind. TeamName is only set for
+// team tokens. Personal tokens are those of the user the provider
+// authenticates as.
+type InventoryToken struct {
+	AccessTokenSummary
+	Kind     string
+	TeamName string
+}
+
+func toAccessTokenSummary(token apitype.AccessToken) AccessTokenSummary {
+	return AccessTokenSummary{
+		ID:          token.ID,
+		Name:        token.Name,
+		Description: token.Description,
+		Admin:       token.Admin,
+		Created:     token.Created,
+		CreatedBy:   derefString(token.CreatedBy),
+		Expires:     token.Expires,
+		LastUsed:    token.LastUsed,
+	}
+}
+
+// ListTokenInventory returns the organization's tokens of the given kinds,
+// or of every kind when kinds is empty. Team tokens of every team are
+// included. Expired tokens are included too.
+func (c *Client) ListTokenInventory(ctx context.Context, orgName string, kinds []string) ([]InventoryToken, error) {
+	if len(kinds) == 0 {
+		kinds = TokenKinds
+	}
+	var inventory []InventoryToken
+	add := func(kind, teamName string, tokens []AccessTokenSummary) {
+		for _, token := range tokens {
+			inventory = append(inventory, InventoryToken{AccessTokenSummary: token, Kind: kind, TeamName: teamName})
+		}
+	}
+	all := "all"
+
+	if slices.Contains(kinds, TokenKindOrganization) {
+		tokens, err := c.ListOrgAccessTokens(ctx, orgName, all)
+		if err != nil {
+			return nil, err
+		}
+		add(TokenKindOrganization, "", tokens)
+	}
+
+	if slices.Contains(kinds, TokenKindTeam) {
+		teams, err := c.ListTeams(ctx, orgName)
+		if err != nil {
+			return nil, err
+		}
+		for _, team := range teams {
+			resp, err := c.SDK.ListTeamTokens(ctx, orgName, team.Name, &all)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list access tokens of team %s: %w", team.Name, err)
+			}
+			tokens := make([]AccessTokenSummary, 0, len(resp.Tokens))
+			for _, token := range resp.Tokens {
+				tokens = append(tokens, toAccessTokenSummary(token))
+			}
+			add(TokenKindTeam, team.Name, tokens)
+		}
+	}
+
+	if slices.Contains(kinds, TokenKindPersonal) {
+		resp, err := c.SDK.ListPersonalTokens(ctx, &all)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list personal access tokens: %w", err)
+		}
+		tokens := make([]AccessTokenSummary, 0, len(resp.Tokens))
+		for _, token := range resp.Tokens {
+			tokens = append(tokens, toAccessTokenSummary(token))
+		}
+		add(TokenKindPersonal, "", tokens)
+	}
+	return inventory, nil
+}
+
+// RevokeInventoryToken deletes a token listed by ListTokenInventory.
+func (c *Client) RevokeInventoryToken(ctx context.Context, orgName string, token InventoryToken) error {
+	switch token.Kind {
+	case TokenKindOrganization:
+		return c.DeleteOrgAccessToken(ctx, token.ID, orgName)
+	case TokenKindTeam:
+		return c.DeleteTeamAccessToken(ctx, token.ID, orgName, token.TeamName)
+	case TokenKindPersonal:
+		return c.DeleteAccessToken(ctx, token.ID)
+	default:
+		return fmt.Errorf("unknown kind %q of access token %s", token.Kind, token.ID)
+	}
+}
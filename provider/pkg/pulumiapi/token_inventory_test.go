@@ -0,0 +1,92 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumiapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTokenInventory(t *testing.T) {
+	c := startTestServerMulti(t, func(r *http.Request) (int, any) {
+		switch r.URL.Path {
+		case "/api/orgs/anOrg/tokens":
+			assert.Equal(t, "all", r.URL.Query().Get("filter"))
+			return http.StatusOK, map[string]any{"tokens": []map[string]any{
+				{"id": "org-1", "name": "ci", "createdBy": "alice", "expires": 42},
+			}}
+		case "/api/orgs/anOrg/teams":
+			return http.StatusOK, map[string]any{"teams": []map[string]any{{"name": "platform"}}}
+		case "/api/orgs/anOrg/teams/platform/tokens":
+			return http.StatusOK, map[string]any{"tokens": []map[string]any{
+				{"id": "team-1", "name": "deploy", "lastUsed": 7},
+			}}
+		case "/api/user/tokens":
+			return http.StatusOK, map[string]any{"tokens": []map[string]any{
+				{"id": "user-1", "description": "laptop", "createdBy": "bob"},
+			}}
+		}
+		t.Errorf("unexpected request %s", r.URL.Path)
+		return http.StatusNotFound, nil
+	})
+
+	t.Run("every kind", func(t *testing.T) {
+		tokens, err := c.ListTokenInventory(ctx, testOrgName, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []InventoryToken{
+			{
+				AccessTokenSummary: AccessTokenSummary{ID: "org-1", Name: "ci", CreatedBy: "alice", Expires: 42},
+				Kind:               TokenKindOrganization,
+			},
+			{
+				AccessTokenSummary: AccessTokenSummary{ID: "team-1", Name: "deploy", LastUsed: 7},
+				Kind:               TokenKindTeam,
+				TeamName:           "platform",
+			},
+			{
+				AccessTokenSummary: AccessTokenSummary{ID: "user-1", Description: "laptop", CreatedBy: "bob"},
+				Kind:               TokenKindPersonal,
+			},
+		}, tokens)
+	})
+
+	t.Run("selected kinds", func(t *testing.T) {
+		tokens, err := c.ListTokenInventory(ctx, testOrgName, []string{TokenKindPersonal})
+		require.NoError(t, err)
+		require.Len(t, tokens, 1)
+		assert.Equal(t, "user-1", tokens[0].ID)
+	})
+}
+
+func TestRevokeInventoryToken(t *testing.T) {
+	for kind, path := range map[string]string{
+		TokenKindOrganization: "/api/orgs/anOrg/tokens/tok-1",
+		TokenKindTeam:         "/api/orgs/anOrg/teams/platform/tokens/tok-1",
+		TokenKindPersonal:     "/api/user/tokens/tok-1",
+	} {
+		t.Run(kind, func(t *testing.T) {
+			c := startTestServer(t, testServerConfig{
+				ExpectedReqMethod: http.MethodDelete,
+				ExpectedReqPath:   path,
+				ResponseCode:      http.StatusNoContent,
+			})
+			token := InventoryToken{AccessTokenSummary: AccessTokenSummary{ID: "tok-1"}, Kind: kind, TeamName: "platform"}
+			assert.NoError(t, c.RevokeInventoryToken(ctx, testOrgName, token))
+		})
+	}
+}
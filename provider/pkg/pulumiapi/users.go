@@ -17,7 +17,6 @@ package pulumiapi
 import (
 	"context"
 	"fmt"
-	"net/http"
 )
 
 type UserClient interface {
@@ -36,9 +35,15 @@ type CurrentUser struct {
 }
 
 func (c *Client) GetCurrentUser(ctx context.Context) (*CurrentUser, error) {
-	var user CurrentUser
-	if _, err := c.do(ctx, http.MethodGet, "user", nil, &user); err != nil {
+	user, err := c.SDK.GetCurrentUser(ctx)
+	if err != nil {
 		return nil, fmt.Errorf("failed to get current user: %w", err)
 	}
-	return &user, nil
+	return &CurrentUser{
+		ID:          user.ID,
+		GithubLogin: user.GitHubLogin,
+		Name:        user.Name,
+		Email:       user.Email,
+		AvatarURL:   user.AvatarURL,
+	}, nil
 }
@@ -27,10 +27,6 @@ func contains(s []string, e string) bool {
 	return false
 }
 
-func ok(code int) bool {
-	return code >= 200 && code < 300
-}
-
 // A Duration is a wrapper for time.Duration that marshals into JSON as a human-readable string.
 type Duration time.Duration
 
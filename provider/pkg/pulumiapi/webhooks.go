@@ -18,7 +18,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"path"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apiclient"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
 
 type WebhookClient interface {
@@ -41,6 +43,24 @@ type WebhookClient interface {
 		projectName, stackName, environmentName *string,
 		name string,
 	) error
+	PingWebhook(
+		ctx context.Context,
+		orgName string,
+		projectName, stackName, environmentName *string,
+		name string,
+	) (*WebhookDelivery, error)
+	ListWebhookDeliveries(
+		ctx context.Context,
+		orgName string,
+		projectName, stackName, environmentName *string,
+		name string,
+	) ([]WebhookDelivery, error)
+	RedeliverWebhookEvent(
+		ctx context.Context,
+		orgName string,
+		projectName, stackName, environmentName *string,
+		name, eventID string,
+	) (*WebhookDelivery, error)
 }
 
 type Webhook struct {
@@ -56,6 +76,33 @@ type Webhook struct {
 	SecretCiphertext string
 }
 
+// WebhookDelivery is one attempt to deliver an event to a webhook's payload
+// URL. ID identifies the event, so it is what RedeliverWebhookEvent takes.
+type WebhookDelivery struct {
+	ID           string
+	Kind         string
+	Timestamp    int64
+	Duration     int64
+	ResponseCode int64
+	ResponseBody string
+}
+
+// Succeeded reports whether the payload URL answered with a 2xx status.
+func (d WebhookDelivery) Succeeded() bool {
+	return d.ResponseCode >= 200 && d.ResponseCode < 300
+}
+
+func newWebhookDelivery(res apitype.WebhookDelivery) WebhookDelivery {
+	return WebhookDelivery{
+		ID:           res.ID,
+		Kind:         res.Kind,
+		Timestamp:    res.Timestamp,
+		Duration:     res.Duration,
+		ResponseCode: res.ResponseCode,
+		ResponseBody: res.ResponseBody,
+	}
+}
+
 type WebhookRequest struct {
 	OrganizationName string   `json:"organizationName"`
 	ProjectName      *string  `json:"projectName,omitempty"`
@@ -75,6 +122,166 @@ type UpdateWebhookRequest struct {
 	Name string `json:"name"`
 }
 
+func (r WebhookRequest) toWebhook(name string) apitype.Webhook {
+	webhook := apitype.Webhook{
+		OrganizationName: r.OrganizationName,
+		ProjectName:      r.ProjectName,
+		StackName:        r.StackName,
+		EnvName:          r.EnvironmentName,
+		Name:             name,
+		DisplayName:      r.DisplayName,
+		PayloadURL:       r.PayloadURL,
+		Active:           r.Active,
+		Format:           r.Format,
+		Filters:          r.Filters,
+		Groups:           r.Groups,
+	}
+	if r.Secret != nil {
+		webhook.Secret = *r.Secret
+	}
+	return webhook
+}
+
+func newWebhook(res *apitype.WebhookResponse) *Webhook {
+	webhook := &Webhook{
+		Active:           res.Active,
+		DisplayName:      res.DisplayName,
+		PayloadURL:       res.PayloadURL,
+		Name:             res.Name,
+		Filters:          res.Filters,
+		Groups:           res.Groups,
+		HasSecret:        res.HasSecret,
+		SecretCiphertext: res.SecretCiphertext,
+	}
+	if res.Secret != "" {
+		secret := res.Secret
+		webhook.Secret = &secret
+	}
+	if res.Format != nil {
+		webhook.Format = *res.Format
+	}
+	return webhook
+}
+
+// webhookScope selects the family of generated webhook methods for the
+// stack, environment or organization a webhook is attached to.
+type webhookScope struct {
+	sdk             *apiclient.CloudClient
+	orgName         string
+	projectName     *string
+	stackName       *string
+	environmentName *string
+}
+
+func (c *Client) webhookScope(orgName string, projectName, stackName, environmentName *string) webhookScope {
+	return webhookScope{
+		sdk:             c.SDK,
+		orgName:         orgName,
+		projectName:     projectName,
+		stackName:       stackName,
+		environmentName: environmentName,
+	}
+}
+
+func (s webhookScope) isStack() bool {
+	return s.projectName != nil && s.stackName != nil
+}
+
+func (s webhookScope) isEnvironment() bool {
+	return s.projectName != nil && s.environmentName != nil
+}
+
+func (s webhookScope) create(ctx context.Context, req apitype.Webhook) (*apitype.WebhookResponse, error) {
+	switch {
+	case s.isStack():
+		return s.sdk.CreateStackWebhook(ctx, s.orgName, *s.projectName, *s.stackName, req)
+	case s.isEnvironment():
+		return s.sdk.CreateWebhook_esc_environments(ctx, s.orgName, *s.projectName, *s.environmentName, req)
+	default:
+		return s.sdk.CreateOrganizationWebhook(ctx, s.orgName, req)
+	}
+}
+
+func (s webhookScope) list(ctx context.Context) (*[]apitype.WebhookResponse, error) {
+	switch {
+	case s.isStack():
+		return s.sdk.ListStackWebhooks(ctx, s.orgName, *s.projectName, *s.stackName)
+	case s.isEnvironment():
+		return s.sdk.ListWebhooks_esc_environments(ctx, s.orgName, *s.projectName, *s.environmentName)
+	default:
+		return s.sdk.ListOrganizationWebhooks(ctx, s.orgName)
+	}
+}
+
+func (s webhookScope) get(ctx context.Context, name string) (*apitype.WebhookResponse, error) {
+	switch {
+	case s.isStack():
+		return s.sdk.GetStackWebhook(ctx, s.orgName, *s.projectName, *s.stackName, name)
+	case s.isEnvironment():
+		return s.sdk.GetWebhook_esc_environments(ctx, s.orgName, *s.projectName, *s.environmentName, name)
+	default:
+		return s.sdk.GetOrganizationWebhook(ctx, s.orgName, name)
+	}
+}
+
+func (s webhookScope) update(
+	ctx context.Context, name string, req apitype.Webhook,
+) (*apitype.WebhookResponse, error) {
+	switch {
+	case s.isStack():
+		return s.sdk.UpdateStackWebhook(ctx, s.orgName, *s.projectName, *s.stackName, name, req)
+	case s.isEnvironment():
+		return s.sdk.UpdateWebhook_esc_environments(ctx, s.orgName, *s.projectName, *s.environmentName, name, req)
+	default:
+		return s.sdk.UpdateOrganizationWebhook(ctx, s.orgName, name, req)
+	}
+}
+
+func (s webhookScope) delete(ctx context.Context, name string) error {
+	switch {
+	case s.isStack():
+		return s.sdk.DeleteStackWebhook(ctx, s.orgName, *s.projectName, *s.stackName, name)
+	case s.isEnvironment():
+		return s.sdk.DeleteWebhook_esc_environments(ctx, s.orgName, *s.projectName, *s.environmentName, name)
+	default:
+		return s.sdk.DeleteOrganizationWebhook(ctx, s.orgName, name)
+	}
+}
+
+func (s webhookScope) ping(ctx context.Context, name string) (*apitype.WebhookDelivery, error) {
+	switch {
+	case s.isStack():
+		return s.sdk.PingStackWebhook(ctx, s.orgName, *s.projectName, *s.stackName, name)
+	case s.isEnvironment():
+		return s.sdk.PingWebhook_esc_environments(ctx, s.orgName, *s.projectName, *s.environmentName, name)
+	default:
+		return s.sdk.PingOrganizationWebhook(ctx, s.orgName, name)
+	}
+}
+
+func (s webhookScope) deliveries(ctx context.Context, name string) (*[]apitype.WebhookDelivery, error) {
+	switch {
+	case s.isStack():
+		return s.sdk.GetStackWebhookDeliveries(ctx, s.orgName, *s.projectName, *s.stackName, name)
+	case s.isEnvironment():
+		return s.sdk.GetWebhookDeliveries_esc_environments(ctx, s.orgName, *s.projectName, *s.environmentName, name)
+	default:
+		return s.sdk.GetOrganizationWebhookDeliveries(ctx, s.orgName, name)
+	}
+}
+
+func (s webhookScope) redeliver(ctx context.Context, name, event string) (*apitype.WebhookDelivery, error) {
+	switch {
+	case s.isStack():
+		return s.sdk.RedeliverStackWebhookEvent(ctx, s.orgName, *s.projectName, *s.stackName, name, event)
+	case s.isEnvironment():
+		return s.sdk.RedeliverWebhookEvent_esc_environments(
+			ctx, s.orgName, *s.projectName, *s.environmentName, name, event)
+	default:
+		return s.sdk.RedeliverOrganizationWebhookEvent(ctx, s.orgName, name, event)
+	}
+}
+
 func (c *Client) CreateWebhook(ctx context.Context, req WebhookRequest) (*Webhook, error) {
 	if len(req.OrganizationName) == 0 {
 		return nil, errors.New("orgname must not be empty")
@@ -88,14 +295,12 @@ func (c *Client) CreateWebhook(ctx context.Context, req WebhookRequest) (*Webhoo
 		return nil, errors.New("payloadurl must not be empty")
 	}
 
-	apiPath := constructAPIPath(req.OrganizationName, req.ProjectName, req.StackName, req.EnvironmentName)
-
-	var webhook Webhook
-	_, err := c.do(ctx, http.MethodPost, apiPath, req, &webhook)
+	scope := c.webhookScope(req.OrganizationName, req.ProjectName, req.StackName, req.EnvironmentName)
+	webhook, err := scope.create(ctx, req.toWebhook(""))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create webhook: %w", err)
 	}
-	return &webhook, nil
+	return newWebhook(webhook), nil
 }
 
 func (c *Client) ListWebhooks(
@@ -107,14 +312,15 @@ func (c *Client) ListWebhooks(
 		return nil, errors.New("orgName must not be empty")
 	}
 
-	apiPath := constructAPIPath(orgName, projectName, stackName, environmentName)
-
-	var webhooks []Webhook
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &webhooks)
+	res, err := c.webhookScope(orgName, projectName, stackName, environmentName).list(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list webhooks: %w", err)
 	}
 
+	webhooks := make([]Webhook, 0, len(*res))
+	for i := range *res {
+		webhooks = append(webhooks, *newWebhook(&(*res)[i]))
+	}
 	return webhooks, nil
 }
 
@@ -128,10 +334,7 @@ func (c *Client) GetWebhook(ctx context.Context,
 		return nil, errors.New("webhookname must not be empty")
 	}
 
-	apiPath := constructAPIPath(orgName, projectName, stackName, environmentName) + "/" + webhookName
-
-	var webhook Webhook
-	_, err := c.do(ctx, http.MethodGet, apiPath, nil, &webhook)
+	webhook, err := c.webhookScope(orgName, projectName, stackName, environmentName).get(ctx, webhookName)
 	if err != nil {
 		statusCode := GetErrorStatusCode(err)
 		if statusCode == http.StatusNotFound {
@@ -140,7 +343,7 @@ func (c *Client) GetWebhook(ctx context.Context,
 		}
 		return nil, fmt.Errorf("failed to get webhook: %w", err)
 	}
-	return &webhook, nil
+	return newWebhook(webhook), nil
 }
 
 func (c *Client) UpdateWebhook(ctx context.Context, req UpdateWebhookRequest) (*Webhook, error) {
@@ -157,19 +360,12 @@ func (c *Client) UpdateWebhook(ctx context.Context, req UpdateWebhookRequest) (*
 		return nil, errors.New("payloadurl must not be empty")
 	}
 
-	apiPath := constructAPIPath(
-		req.OrganizationName,
-		req.ProjectName,
-		req.StackName,
-		req.EnvironmentName,
-	) + "/" + req.Name
-
-	var webhook Webhook
-	_, err := c.do(ctx, http.MethodPatch, apiPath, req, &webhook)
+	scope := c.webhookScope(req.OrganizationName, req.ProjectName, req.StackName, req.EnvironmentName)
+	webhook, err := scope.update(ctx, req.Name, req.toWebhook(req.Name))
 	if err != nil {
 		return nil, fmt.Errorf("failed to update webhook: %w", err)
 	}
-	return &webhook, nil
+	return newWebhook(webhook), nil
 }
 
 func (c *Client) DeleteWebhook(
@@ -185,20 +381,73 @@ func (c *Client) DeleteWebhook(
 		return errors.New("orgname must not be empty")
 	}
 
-	apiPath := constructAPIPath(orgName, projectName, stackName, environmentName) + "/" + name
-
-	_, err := c.do(ctx, http.MethodDelete, apiPath, nil, nil)
+	err := c.webhookScope(orgName, projectName, stackName, environmentName).delete(ctx, name)
 	if err != nil {
 		return fmt.Errorf("failed to delete webhook: %w", err)
 	}
 	return nil
 }
 
-func constructAPIPath(orgName string, projectName, stackName, environmentName *string) string {
-	if projectName != nil && stackName != nil {
-		return path.Join("stacks", orgName, *projectName, *stackName, "hooks")
-	} else if projectName != nil && environmentName != nil {
-		return path.Join("esc", "environments", orgName, *projectName, *environmentName, "hooks")
+// PingWebhook sends a test event to the webhook and returns the resulting
+// delivery, whether or not the payload URL accepted it.
+func (c *Client) PingWebhook(
+	ctx context.Context,
+	orgName string,
+	projectName, stackName, environmentName *string,
+	name string,
+) (*WebhookDelivery, error) {
+	if len(name) == 0 {
+		return nil, errors.New("name must not be empty")
+	}
+	res, err := c.webhookScope(orgName, projectName, stackName, environmentName).ping(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ping webhook: %w", err)
+	}
+	delivery := newWebhookDelivery(*res)
+	return &delivery, nil
+}
+
+// ListWebhookDeliveries returns the webhook's recent deliveries, as far back
+// as Pulumi Cloud keeps them.
+func (c *Client) ListWebhookDeliveries(
+	ctx context.Context,
+	orgName string,
+	projectName, stackName, environmentName *string,
+	name string,
+) ([]WebhookDelivery, error) {
+	if len(name) == 0 {
+		return nil, errors.New("name must not be empty")
+	}
+	res, err := c.webhookScope(orgName, projectName, stackName, environmentName).deliveries(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deliveries of webhook %s: %w", name, err)
+	}
+	if res == nil {
+		return nil, nil
+	}
+	deliveries := make([]WebhookDelivery, 0, len(*res))
+	for _, d := range *res {
+		deliveries = append(deliveries, newWebhookDelivery(d))
+	}
+	return deliveries, nil
+}
+
+// RedeliverWebhookEvent sends the event with the given ID to the webhook
+// again and returns the new delivery.
+func (c *Client) RedeliverWebhookEvent(
+	ctx context.Context,
+	orgName string,
+	projectName, stackName, environmentName *string,
+	name, eventID string,
+) (*WebhookDelivery, error) {
+	if len(name) == 0 {
+		return nil, errors.New("name must not be empty")
+	}
+	scope := c.webhookScope(orgName, projectName, stackName, environmentName)
+	res, err := scope.redeliver(ctx, name, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeliver event %s to webhook %s: %w", eventID, name, err)
 	}
-	return path.Join("orgs", orgName, "hooks")
+	delivery := newWebhookDelivery(*res)
+	return &delivery, nil
 }
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
 )
 
 const (
@@ -37,11 +39,20 @@ func TestCreateWebhook(t *testing.T) {
 		Secret:           &secret,
 		Active:           true,
 	}
+	// The generated Webhook always serializes name, which the service assigns
+	// on create, so the create body carries it empty.
+	createBody := apitype.Webhook{
+		OrganizationName: orgName,
+		DisplayName:      displayName,
+		PayloadURL:       payloadURL,
+		Secret:           secret,
+		Active:           true,
+	}
 	t.Run("Happy Path", func(t *testing.T) {
 		c := startTestServer(t, testServerConfig{
 			ExpectedReqMethod: http.MethodPost,
 			ExpectedReqPath:   hooksPath,
-			ExpectedReqBody:   createReq,
+			ExpectedReqBody:   createBody,
 			ResponseCode:      201,
 			ResponseBody:      webhook,
 		})
@@ -54,7 +65,7 @@ func TestCreateWebhook(t *testing.T) {
 		c := startTestServer(t, testServerConfig{
 			ExpectedReqMethod: http.MethodPost,
 			ExpectedReqPath:   hooksPath,
-			ExpectedReqBody:   createReq,
+			ExpectedReqBody:   createBody,
 			ResponseCode:      401,
 			ResponseBody: ErrorResponse{
 				Message: unauthorizedError,
@@ -62,7 +73,7 @@ func TestCreateWebhook(t *testing.T) {
 		})
 		actualWebhook, err := c.CreateWebhook(ctx, createReq)
 		assert.Nil(t, actualWebhook, "webhook should be nil since error was returned")
-		assert.EqualError(t, err, "failed to create webhook: 401 API error: unauthorized")
+		assert.EqualError(t, err, "failed to create webhook: HTTP 401: unauthorized")
 	})
 }
 
@@ -103,7 +114,7 @@ func TestListWebhooks(t *testing.T) {
 		})
 		actualWebhooks, err := c.ListWebhooks(ctx, orgName, nil, nil, nil)
 		assert.Nil(t, actualWebhooks, "webhooks should be nil since error was returned")
-		assert.EqualError(t, err, "failed to list webhooks: 401 API error: unauthorized")
+		assert.EqualError(t, err, "failed to list webhooks: HTTP 401: unauthorized")
 	})
 }
 
@@ -143,7 +154,7 @@ func TestGetWebhook(t *testing.T) {
 		})
 		actualWebhook, err := c.GetWebhook(ctx, orgName, nil, nil, nil, webhookName)
 		assert.Nil(t, actualWebhook, "webhooks should be nil since error was returned")
-		assert.EqualError(t, err, "failed to get webhook: 401 API error: unauthorized")
+		assert.EqualError(t, err, "failed to get webhook: HTTP 401: unauthorized")
 	})
 
 	t.Run("404", func(t *testing.T) {
@@ -209,7 +220,7 @@ func TestUpdateWebhook(t *testing.T) {
 			},
 		})
 		_, err := c.UpdateWebhook(ctx, updateReq)
-		assert.EqualError(t, err, "failed to update webhook: 401 API error: unauthorized")
+		assert.EqualError(t, err, "failed to update webhook: HTTP 401: unauthorized")
 	})
 }
 
@@ -236,6 +247,53 @@ func TestDeleteWebhook(t *testing.T) {
 			},
 		})
 		err := c.DeleteWebhook(ctx, orgName, nil, nil, nil, webhookName)
-		assert.EqualError(t, err, "failed to delete webhook: 401 API error: unauthorized")
+		assert.EqualError(t, err, "failed to delete webhook: HTTP 401: unauthorized")
+	})
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	t.Run("Ping", func(t *testing.T) {
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPost,
+			ExpectedReqPath:   webhookPath + "/ping",
+			ResponseCode:      200,
+			ResponseBody:      apitype.WebhookDelivery{ID: "ev-1", Kind: "ping", ResponseCode: 404},
+		})
+		delivery, err := c.PingWebhook(ctx, testWebhookOrgName, nil, nil, nil, testWebhookName)
+		assert.NoError(t, err)
+		assert.Equal(t, &WebhookDelivery{ID: "ev-1", Kind: "ping", ResponseCode: 404}, delivery)
+		assert.False(t, delivery.Succeeded())
+	})
+
+	t.Run("List for an environment", func(t *testing.T) {
+		project, env := "proj", "dev"
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodGet,
+			ExpectedReqPath:   "/api/esc/environments/an-organization/proj/dev/hooks/a-webhook/deliveries",
+			ResponseCode:      200,
+			ResponseBody: []apitype.WebhookDelivery{
+				{ID: "ev-1", ResponseCode: 200, Timestamp: 10},
+				{ID: "ev-2", ResponseCode: 500, Timestamp: 20},
+			},
+		})
+		deliveries, err := c.ListWebhookDeliveries(ctx, testWebhookOrgName, &project, nil, &env, testWebhookName)
+		assert.NoError(t, err)
+		assert.Equal(t, []WebhookDelivery{
+			{ID: "ev-1", ResponseCode: 200, Timestamp: 10},
+			{ID: "ev-2", ResponseCode: 500, Timestamp: 20},
+		}, deliveries)
+	})
+
+	t.Run("Redeliver to a stack webhook", func(t *testing.T) {
+		project, stack := "proj", "dev"
+		c := startTestServer(t, testServerConfig{
+			ExpectedReqMethod: http.MethodPost,
+			ExpectedReqPath:   "/api/stacks/an-organization/proj/dev/hooks/a-webhook/deliveries/ev-2/redeliver",
+			ResponseCode:      200,
+			ResponseBody:      apitype.WebhookDelivery{ID: "ev-2", ResponseCode: 204},
+		})
+		delivery, err := c.RedeliverWebhookEvent(ctx, testWebhookOrgName, &project, &stack, nil, testWebhookName, "ev-2")
+		assert.NoError(t, err)
+		assert.True(t, delivery.Succeeded())
 	})
 }
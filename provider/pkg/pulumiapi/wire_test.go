@@ -0,0 +1,168 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pulumiapi
+
+// The types below are the request and response bodies the hand-rolled HTTP
+// client sent and decoded before the client moved onto the generated
+// apiclient.CloudClient. Tests assert the wire traffic against them, so any
+// drift in what the generated methods put on the wire shows up as a failure.
+
+type createTokenResponse struct {
+	ID         string `json:"id"`
+	TokenValue string `json:"tokenValue"`
+}
+
+// The generated BaseCreateAccessTokenRequest always serializes expires. Zero is
+// the service's "never expires", which is what omitting it meant before, so the
+// token request fixtures carry the field rather than treat it as drift.
+type createTokenRequest struct {
+	Description string `json:"description"`
+	Expires     int64  `json:"expires"`
+}
+
+type listTokenResponse struct {
+	Tokens []accessTokenResponse `json:"tokens"`
+}
+
+type accessTokenResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	LastUsed    int    `json:"lastUsed"`
+	Expires     int64  `json:"expires"`
+	Admin       bool   `json:"admin"`
+}
+
+type createUpdateAgentPoolRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type addMemberToOrgReq struct {
+	Role string `json:"role"`
+}
+
+type updateMemberRoleReq struct {
+	Role      string  `json:"role,omitempty"`
+	FGARoleID *string `json:"fgaRoleId,omitempty"`
+}
+
+type createOrgTokenRequest struct {
+	Description string `json:"description"`
+	Name        string `json:"name"`
+	Admin       bool   `json:"admin"`
+	Expires     int64  `json:"expires"`
+}
+
+type createTeamTokenRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Expires     int64  `json:"expires"`
+}
+
+type listStacksResponse struct {
+	Stacks            []StackSummary `json:"stacks"`
+	ContinuationToken *string        `json:"continuationToken,omitempty"`
+}
+
+type listOrgEnvironmentsResponse struct {
+	Environments []OrgEnvironment `json:"environments"`
+	NextToken    *string          `json:"nextToken,omitempty"`
+}
+
+type listAgentPoolsResponse struct {
+	AgentPools []AgentPoolSummary `json:"agentPools"`
+}
+
+type listOidcIssuersResponse struct {
+	OidcIssuers []OidcIssuerRegistrationResponse `json:"oidcIssuers"`
+}
+
+type createPolicyGroupRequest struct {
+	Name       string `json:"name"`
+	EntityType string `json:"entityType"`
+	Mode       string `json:"mode"`
+}
+
+type listPolicyPacksResponse struct {
+	PolicyPacks []PolicyPackWithVersions `json:"policyPacks"`
+}
+
+type createPolicyPackResponse struct {
+	Version         int               `json:"version"`
+	UploadURI       string            `json:"uploadURI"`
+	RequiredHeaders map[string]string `json:"requiredHeaders,omitempty"`
+}
+
+type rbacScopeGroup struct {
+	Name   string      `json:"name"`
+	Scopes []rbacScope `json:"scopes"`
+}
+
+type rbacScope struct {
+	Name     string            `json:"name"`
+	Metadata rbacScopeMetadata `json:"metadata"`
+}
+
+type rbacScopeMetadata struct {
+	Description string `json:"description"`
+}
+
+type listTeamRolesResponse struct {
+	Roles []TeamRoleRef `json:"roles"`
+}
+
+type updateTeamMembershipRequest struct {
+	MemberAction string `json:"memberAction"`
+	Member       string `json:"member"`
+}
+
+type addStackPermissionRequest struct {
+	AddStackPermission addStackPermission `json:"addStackPermission"`
+}
+
+type addStackPermission struct {
+	ProjectName string `json:"projectName"`
+	StackName   string `json:"stackName"`
+	Permission  int    `json:"permission"`
+}
+
+type removeStackPermissionRequest struct {
+	RemoveStackPermission removeStackPermission `json:"removeStack"`
+}
+
+type removeStackPermission struct {
+	ProjectName string `json:"projectName"`
+	StackName   string `json:"stackName"`
+}
+
+type addEnvironmentSettingsRequest struct {
+	AddEnvironmentPermission addEnvironmentPermission `json:"addEnvironmentPermission"`
+}
+
+type addEnvironmentPermission struct {
+	EnvName         string    `json:"envName"`
+	ProjectName     string    `json:"projectName"`
+	Permission      string    `json:"permission"`
+	MaxOpenDuration *Duration `json:"maxOpenDuration,omitempty"`
+}
+
+type removeEnvironmentPermissionRequest struct {
+	RemoveEnvironment removeEnvironmentPermission `json:"removeEnvironment"`
+}
+
+type removeEnvironmentPermission struct {
+	EnvName     string `json:"envName"`
+	ProjectName string `json:"projectName"`
+}
@@ -18,10 +18,12 @@ import (
 	"context"
 	"fmt"
 
+	p "github.com/pulumi/pulumi-go-provider"
 	"github.com/pulumi/pulumi-go-provider/infer"
 	"github.com/pulumi/pulumi/sdk/v3/go/property"
 
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
 )
 
 const (
@@ -30,21 +32,30 @@ const (
 	gcValue       = "value"
 )
 
-type AccessToken struct{}
+type AccessToken struct {
+	// now overrides the clock rotations come due by.
+	now tokenClock
+}
 
 var (
+	_ infer.CustomCheck[AccessTokenInput]                    = &AccessToken{}
 	_ infer.CustomCreate[AccessTokenInput, AccessTokenState] = &AccessToken{}
+	_ infer.CustomDiff[AccessTokenInput, AccessTokenState]   = &AccessToken{}
+	_ infer.CustomUpdate[AccessTokenInput, AccessTokenState] = &AccessToken{}
 	_ infer.CustomDelete[AccessTokenState]                   = &AccessToken{}
 	_ infer.CustomRead[AccessTokenInput, AccessTokenState]   = &AccessToken{}
 	_ infer.CustomStateMigrations[AccessTokenState]          = &AccessToken{}
 )
 
-func (*AccessToken) Annotate(a infer.Annotator) {
-	a.Describe(&AccessToken{}, "Access tokens allow a user to authenticate against the Pulumi Cloud.")
+func (t *AccessToken) Annotate(a infer.Annotator) {
+	a.Describe(t, "Access tokens allow a user to authenticate against the Pulumi Cloud.\n\n"+
+		"With `ttl` and `rotation`, a successor token is issued ahead of expiry and `value` changes to it, "+
+		"while the previous token stays valid for `keepPrevious` so its consumers can move over.")
 }
 
 type AccessTokenInput struct {
 	Description string `pulumi:"description" provider:"replaceOnChanges"`
+	TokenExpiry
 }
 
 func (i *AccessTokenInput) Annotate(a infer.Annotator) {
@@ -54,13 +65,25 @@ func (i *AccessTokenInput) Annotate(a infer.Annotator) {
 type AccessTokenState struct {
 	AccessTokenInput
 	Value string `pulumi:"value" provider:"secret"`
+	TokenRotationState
 }
 
 func (s *AccessTokenState) Annotate(a infer.Annotator) {
 	a.Describe(&s.Value, "The token's value.")
 }
 
-func (*AccessToken) Create(
+func (*AccessToken) Check(
+	ctx context.Context, req infer.CheckRequest,
+) (infer.CheckResponse[AccessTokenInput], error) {
+	i, failures, err := infer.DefaultCheck[AccessTokenInput](ctx, req.NewInputs)
+	if err != nil {
+		return infer.CheckResponse[AccessTokenInput]{}, err
+	}
+	failures = append(failures, i.check()...)
+	return infer.CheckResponse[AccessTokenInput]{Inputs: i, Failures: failures}, nil
+}
+
+func (t *AccessToken) Create(
 	ctx context.Context,
 	req infer.CreateRequest[AccessTokenInput],
 ) (infer.CreateResponse[AccessTokenState], error) {
@@ -69,49 +92,106 @@ func (*AccessToken) Create(
 			Output: AccessTokenState{AccessTokenInput: req.Inputs},
 		}, nil
 	}
-	token, err := config.GetClient(ctx).CreateAccessToken(ctx, req.Inputs.Description)
+	expires := req.Inputs.expiresAt(t.now.now())
+	token, err := config.GetClient(ctx).CreateAccessToken(ctx, req.Inputs.Description, expires)
 	if err != nil {
 		return infer.CreateResponse[AccessTokenState]{}, fmt.Errorf(
 			"error creating access token %q: %w", req.Inputs.Description, err,
 		)
 	}
+	inputs := req.Inputs
+	inputs.TokenExpiry = req.Inputs.issued(token.Expires)
 	return infer.CreateResponse[AccessTokenState]{
 		ID: token.ID,
 		Output: AccessTokenState{
-			AccessTokenInput: AccessTokenInput{Description: req.Inputs.Description},
-			Value:            token.TokenValue,
+			AccessTokenInput:   inputs,
+			Value:              token.TokenValue,
+			TokenRotationState: TokenRotationState{TokenID: token.ID},
 		},
 	}, nil
 }
 
-func (*AccessToken) Delete(
+func (t *AccessToken) Diff(
+	_ context.Context,
+	req infer.DiffRequest[AccessTokenInput, AccessTokenState],
+) (infer.DiffResponse, error) {
+	diff := map[string]p.PropertyDiff{}
+	if req.State.Description != req.Inputs.Description {
+		diff[gcDescription] = p.PropertyDiff{Kind: p.UpdateReplace, InputDiff: true}
+	}
+	diffTokenExpiry(diff, req.State.TokenExpiry, req.State.TokenRotationState, req.Inputs.TokenExpiry, t.now.now())
+	return infer.DiffResponse{HasChanges: len(diff) > 0, DetailedDiff: diff}, nil
+}
+
+// Update rotates the token when it is due; other changes replace it.
+func (t *AccessToken) Update(
+	ctx context.Context,
+	req infer.UpdateRequest[AccessTokenInput, AccessTokenState],
+) (infer.UpdateResponse[AccessTokenState], error) {
+	if req.DryRun {
+		output := req.State
+		output.Rotation = req.Inputs.Rotation
+		return infer.UpdateResponse[AccessTokenState]{Output: output}, nil
+	}
+	result, err := t.rotation(ctx, req.Inputs.Description).apply(
+		req.State.TokenExpiry, req.State.current(req.ID), req.Inputs.TokenExpiry, t.now.now())
+	output := AccessTokenState{
+		AccessTokenInput:   req.Inputs,
+		Value:              req.State.Value,
+		TokenRotationState: result.state,
+	}
+	output.TokenExpiry = result.expiry
+	if result.value != nil {
+		output.Value = *result.value
+	}
+	return infer.UpdateResponse[AccessTokenState]{Output: output}, tokenUpdateError(err)
+}
+
+func (*AccessToken) rotation(ctx context.Context, description string) tokenRotation {
+	client := config.GetClient(ctx)
+	return tokenRotation{
+		issue: func(expires int64) (*pulumiapi.AccessToken, error) {
+			return client.CreateAccessToken(ctx, description, expires)
+		},
+		delete: func(tokenID string) error {
+			return client.DeleteAccessToken(ctx, tokenID)
+		},
+	}
+}
+
+func (t *AccessToken) Delete(
 	ctx context.Context,
 	req infer.DeleteRequest[AccessTokenState],
 ) (infer.DeleteResponse, error) {
-	return infer.DeleteResponse{}, config.GetClient(ctx).DeleteAccessToken(ctx, req.ID)
+	return infer.DeleteResponse{}, t.rotation(ctx, req.State.Description).deleteTokens(req.State.current(req.ID))
 }
 
-func (*AccessToken) Read(
+func (t *AccessToken) Read(
 	ctx context.Context,
 	req infer.ReadRequest[AccessTokenInput, AccessTokenState],
 ) (infer.ReadResponse[AccessTokenInput, AccessTokenState], error) {
-	token, err := config.GetClient(ctx).GetAccessToken(ctx, req.ID)
+	rotation := req.State.current(req.ID)
+	token, err := config.GetClient(ctx).GetAccessToken(ctx, rotation.TokenID)
 	if err != nil {
 		return infer.ReadResponse[AccessTokenInput, AccessTokenState]{}, err
 	}
 	if token == nil {
 		return infer.ReadResponse[AccessTokenInput, AccessTokenState]{}, nil
 	}
-	inputs := AccessTokenInput{Description: token.Description}
+	expiryInputs, expiryState := readTokenExpiry(req.Inputs.TokenExpiry, *token)
+	inputs := AccessTokenInput{Description: token.Description, TokenExpiry: expiryInputs}
+	state := AccessTokenState{
+		AccessTokenInput: inputs,
+		// The list-tokens API does not return token values; carry the existing
+		// secret from state so refresh does not erase it.
+		Value:              req.State.Value,
+		TokenRotationState: rotation.read(*token, t.now.now()),
+	}
+	state.TokenExpiry = expiryState
 	return infer.ReadResponse[AccessTokenInput, AccessTokenState]{
 		ID:     req.ID,
 		Inputs: inputs,
-		State: AccessTokenState{
-			AccessTokenInput: inputs,
-			// The list-tokens API does not return token values; carry the existing
-			// secret from state so refresh does not erase it.
-			Value: req.State.Value,
-		},
+		State:  state,
 	}, nil
 }
 
@@ -0,0 +1,260 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/poll"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+type Deployment struct {
+	// runPoll overrides how waitForDeployment polls; zero uses
+	// deploymentPollInterval, and the operation's customTimeouts or else
+	// deploymentTimeout.
+	runPoll poll.Options
+}
+
+const (
+	deploymentPollInterval = 5 * time.Second
+	deploymentTimeout      = time.Hour
+	// deploymentLogTailLines bounds the log excerpt of a failed deployment.
+	deploymentLogTailLines = 20
+)
+
+var (
+	_ infer.CustomCreate[DeploymentInput, DeploymentState] = &Deployment{}
+	_ infer.CustomUpdate[DeploymentInput, DeploymentState] = &Deployment{}
+	_ infer.CustomRead[DeploymentInput, DeploymentState]   = &Deployment{}
+)
+
+func (d *Deployment) Annotate(a infer.Annotator) {
+	a.Describe(d, "Runs a Pulumi Deployments operation on a stack and waits for it to finish. "+
+		"The deployment uses the stack's deployment settings, overridden by `operationContext`.\n\n"+
+		"The operation runs again whenever an input changes, such as one of the `triggers`. "+
+		"Deleting the resource only forgets the deployment: it does not undo the operation.\n\n"+
+		"A deployment that fails, or is still running when the wait times out, is recorded with its status; "+
+		"the next `pulumi up` runs it again.")
+	a.SetToken("index", "Deployment")
+}
+
+type DeploymentInput struct {
+	Organization     string                              `pulumi:"organization"              provider:"replaceOnChanges"`
+	Project          string                              `pulumi:"project"                   provider:"replaceOnChanges"`
+	Stack            string                              `pulumi:"stack"                     provider:"replaceOnChanges"`
+	Operation        PulumiOperation                     `pulumi:"operation"`
+	InheritSettings  *bool                               `pulumi:"inheritSettings,optional"`
+	OperationContext *DeploymentSettingsOperationContext `pulumi:"operationContext,optional"`
+	Triggers         []any                               `pulumi:"triggers,optional"`
+}
+
+func (i *DeploymentInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.Organization, "Organization name.")
+	a.Describe(&i.Project, "Project name.")
+	a.Describe(&i.Stack, "Stack name.")
+	a.Describe(&i.Operation, "Which command to run.")
+	a.Describe(&i.InheritSettings, "Whether the deployment uses the stack's deployment settings. "+
+		"Defaults to `true`.")
+	a.Describe(&i.OperationContext, "Overrides the stack's operation context settings for this deployment.")
+	a.Describe(&i.Triggers, "Arbitrary values that, when changed, run the deployment again.")
+}
+
+type DeploymentState struct {
+	DeploymentInput
+	DeploymentID string  `pulumi:"deploymentId"`
+	Version      int     `pulumi:"version"`
+	Status       string  `pulumi:"status"`
+	ConsoleURL   string  `pulumi:"consoleUrl"`
+	UpdateURL    *string `pulumi:"updateUrl,optional"`
+}
+
+func (s *DeploymentState) Annotate(a infer.Annotator) {
+	a.Describe(&s.DeploymentID, "The ID of the latest deployment run, assigned by Pulumi Cloud.")
+	a.Describe(&s.Version, "The version of the latest deployment run within the stack.")
+	a.Describe(&s.Status, "The status of the latest deployment run as last observed, e.g. `succeeded` or `failed`.")
+	a.Describe(&s.ConsoleURL, "The Pulumi Cloud console URL of the latest deployment run.")
+	a.Describe(&s.UpdateURL, "The Pulumi Cloud console URL of the update or preview the latest deployment run "+
+		"performed, if any.")
+}
+
+func (i *DeploymentInput) stackIdentifier() pulumiapi.StackIdentifier {
+	return pulumiapi.StackIdentifier{OrgName: i.Organization, ProjectName: i.Project, StackName: i.Stack}
+}
+
+func (i *DeploymentInput) toPulumiServiceRequest(ctx context.Context) pulumiapi.CreateStackDeploymentRequest {
+	inherit := true
+	if i.InheritSettings != nil {
+		inherit = *i.InheritSettings
+	}
+	req := pulumiapi.CreateStackDeploymentRequest{Operation: string(i.Operation), InheritSettings: &inherit}
+	if i.OperationContext != nil {
+		req.OperationContext = i.OperationContext.toPulumiServiceOperationContext(secretEnvironmentVariables(ctx))
+	}
+	return req
+}
+
+func (d *Deployment) Create(
+	ctx context.Context,
+	req infer.CreateRequest[DeploymentInput],
+) (infer.CreateResponse[DeploymentState], error) {
+	if req.DryRun {
+		return infer.CreateResponse[DeploymentState]{
+			Output: DeploymentState{DeploymentInput: req.Inputs},
+		}, nil
+	}
+	state, err := d.run(ctx, req.Inputs)
+	if state == nil {
+		return infer.CreateResponse[DeploymentState]{}, err
+	}
+	return infer.CreateResponse[DeploymentState]{ID: d.id(*state), Output: *state}, err
+}
+
+func (d *Deployment) Update(
+	ctx context.Context,
+	req infer.UpdateRequest[DeploymentInput, DeploymentState],
+) (infer.UpdateResponse[DeploymentState], error) {
+	if req.DryRun {
+		return infer.UpdateResponse[DeploymentState]{
+			Output: DeploymentState{DeploymentInput: req.Inputs},
+		}, nil
+	}
+	state, err := d.run(ctx, req.Inputs)
+	if state == nil {
+		return infer.UpdateResponse[DeploymentState]{}, err
+	}
+	return infer.UpdateResponse[DeploymentState]{Output: *state}, err
+}
+
+func (*Deployment) id(state DeploymentState) string {
+	return path.Join(state.Organization, state.Project, state.Stack, state.DeploymentID)
+}
+
+// run triggers a deployment and waits for it to finish. Once the deployment
+// exists, its state is returned even when it fails, together with an
+// infer.ResourceInitFailedError, so the next update runs it again.
+func (d *Deployment) run(ctx context.Context, input DeploymentInput) (*DeploymentState, error) {
+	client := config.GetClient(ctx)
+	stack := input.stackIdentifier()
+	deployment, err := client.CreateDeployment(ctx, stack, input.toPulumiServiceRequest(ctx))
+	if err != nil {
+		return nil, err
+	}
+	state := &DeploymentState{
+		DeploymentInput: input,
+		DeploymentID:    deployment.ID,
+		Version:         int(deployment.Version),
+		Status:          deployment.Status,
+		ConsoleURL:      deployment.ConsoleURL,
+	}
+
+	status, err := d.waitForDeployment(ctx, client, stack, deployment.ID)
+	if status != "" {
+		state.Status = status
+	}
+	if err != nil {
+		var failed *poll.FailedError
+		if errors.As(err, &failed) {
+			err = deploymentFailure(ctx, client, stack, *state)
+		}
+		return state, infer.ResourceInitFailedError{Reasons: []string{err.Error()}}
+	}
+
+	if updateURL, err := client.GetDeploymentUpdateURL(ctx, stack, *deployment); err != nil {
+		return state, infer.ResourceInitFailedError{Reasons: []string{err.Error()}}
+	} else if updateURL != "" {
+		state.UpdateURL = &updateURL
+	}
+	return state, nil
+}
+
+func (d *Deployment) waitForDeployment(
+	ctx context.Context, client config.Client, stack pulumiapi.StackIdentifier, deploymentID string,
+) (string, error) {
+	opts := d.runPoll
+	if opts.Interval == 0 {
+		opts.Interval = deploymentPollInterval
+	}
+	if _, bounded := ctx.Deadline(); opts.Timeout == 0 && !bounded {
+		opts.Timeout = deploymentTimeout
+	}
+	states := poll.States{
+		Success: []string{pulumiapi.DeploymentStatusSucceeded},
+		Failure: []string{pulumiapi.DeploymentStatusFailed, pulumiapi.DeploymentStatusSkipped},
+	}
+	status, err := poll.Status(ctx, opts, states, func(ctx context.Context) (string, error) {
+		deployment, err := client.GetDeployment(ctx, stack, deploymentID)
+		if err != nil || deployment == nil {
+			return "", err
+		}
+		return deployment.Status, nil
+	})
+	if err != nil {
+		return status, fmt.Errorf("waiting for deployment %s of stack '%s': %w", deploymentID, stack, err)
+	}
+	return status, nil
+}
+
+// deploymentFailure describes a deployment that finished unsuccessfully,
+// with the tail of its logs.
+func deploymentFailure(
+	ctx context.Context, client config.Client, stack pulumiapi.StackIdentifier, state DeploymentState,
+) error {
+	msg := fmt.Sprintf("deployment %d of stack '%s' finished with status %q (%s)",
+		state.Version, stack, state.Status, state.ConsoleURL)
+	lines, err := client.GetDeploymentLogTail(ctx, stack, state.DeploymentID, deploymentLogTailLines)
+	switch {
+	case err != nil:
+		return fmt.Errorf("%s; its logs could not be read: %w", msg, err)
+	case len(lines) == 0:
+		return errors.New(msg)
+	default:
+		return fmt.Errorf("%s. Last log lines:\n%s", msg, strings.Join(lines, "\n"))
+	}
+}
+
+func (*Deployment) Read(
+	ctx context.Context,
+	req infer.ReadRequest[DeploymentInput, DeploymentState],
+) (infer.ReadResponse[DeploymentInput, DeploymentState], error) {
+	if req.State.DeploymentID == "" {
+		// The operation and its context can't be recovered from a deployment.
+		return infer.ReadResponse[DeploymentInput, DeploymentState]{},
+			fmt.Errorf("importing a Deployment is not supported; declare it to run a new deployment")
+	}
+	stack := req.State.stackIdentifier()
+	deployment, err := config.GetClient(ctx).GetDeployment(ctx, stack, req.State.DeploymentID)
+	if err != nil {
+		return infer.ReadResponse[DeploymentInput, DeploymentState]{}, err
+	}
+	if deployment == nil {
+		return infer.ReadResponse[DeploymentInput, DeploymentState]{}, nil
+	}
+	state := req.State
+	state.Status = deployment.Status
+	return infer.ReadResponse[DeploymentInput, DeploymentState]{
+		ID:     req.ID,
+		Inputs: req.Inputs,
+		State:  state,
+	}, nil
+}
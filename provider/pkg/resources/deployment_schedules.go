@@ -73,6 +73,7 @@ type DeploymentScheduleInput struct {
 	ScheduleCron    *string         `pulumi:"scheduleCron,optional"`
 	Timestamp       *string         `pulumi:"timestamp,optional"    provider:"replaceOnChanges"`
 	PulumiOperation PulumiOperation `pulumi:"pulumiOperation"`
+	Paused          *bool           `pulumi:"paused,optional"`
 }
 
 func (i *DeploymentScheduleInput) Annotate(a infer.Annotator) {
@@ -89,6 +90,8 @@ func (i *DeploymentScheduleInput) Annotate(a infer.Annotator) {
 			"Eg: 2020-01-01T00:00:00Z. If you are supplying this, do not supply scheduleCron.",
 	)
 	a.Describe(&i.PulumiOperation, "Which command to run.")
+	a.Describe(&i.Paused, "Whether the schedule is paused. A paused schedule does not run until it is resumed.")
+	a.SetDefault(&i.Paused, false)
 }
 
 type DeploymentScheduleState struct {
@@ -143,13 +146,16 @@ func (*DeploymentSchedule) Create(
 	if err != nil {
 		return infer.CreateResponse[DeploymentScheduleState]{}, fmt.Errorf("error creating deployment schedule: %w", err)
 	}
-	return infer.CreateResponse[DeploymentScheduleState]{
-		ID: deploymentScheduleID(stack, *scheduleID),
-		Output: DeploymentScheduleState{
-			DeploymentScheduleInput: req.Inputs,
-			ScheduleID:              *scheduleID,
-		},
-	}, nil
+	id := deploymentScheduleID(stack, *scheduleID)
+	state := DeploymentScheduleState{
+		DeploymentScheduleInput: req.Inputs,
+		ScheduleID:              *scheduleID,
+	}
+	if err := setStackSchedulePaused(ctx, stack, *scheduleID, nil, req.Inputs.Paused); err != nil {
+		state.Paused = nil
+		return infer.CreateResponse[DeploymentScheduleState]{ID: id, Output: state}, err
+	}
+	return infer.CreateResponse[DeploymentScheduleState]{ID: id, Output: state}, nil
 }
 
 func (*DeploymentSchedule) Update(
@@ -174,12 +180,15 @@ func (*DeploymentSchedule) Update(
 	if err != nil {
 		return infer.UpdateResponse[DeploymentScheduleState]{}, fmt.Errorf("error updating deployment schedule: %w", err)
 	}
-	return infer.UpdateResponse[DeploymentScheduleState]{
-		Output: DeploymentScheduleState{
-			DeploymentScheduleInput: req.Inputs,
-			ScheduleID:              *scheduleID,
-		},
-	}, nil
+	state := DeploymentScheduleState{
+		DeploymentScheduleInput: req.Inputs,
+		ScheduleID:              *scheduleID,
+	}
+	if err := setStackSchedulePaused(ctx, stack, *scheduleID, req.State.Paused, req.Inputs.Paused); err != nil {
+		state.Paused = req.State.Paused
+		return infer.UpdateResponse[DeploymentScheduleState]{Output: state}, err
+	}
+	return infer.UpdateResponse[DeploymentScheduleState]{Output: state}, nil
 }
 
 func (*DeploymentSchedule) Delete(
@@ -219,6 +228,7 @@ func (*DeploymentSchedule) Read(
 		Stack:           stack.StackName,
 		ScheduleCron:    resp.ScheduleCron,
 		PulumiOperation: PulumiOperation(resp.Definition.Request.PulumiOperation),
+		Paused:          &resp.Paused,
 	}
 	if resp.ScheduleOnce != nil {
 		parsed, err := time.Parse(time.DateTime, *resp.ScheduleOnce)
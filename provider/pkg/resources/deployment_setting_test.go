@@ -5,35 +5,43 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
-	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
-	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+	presource "github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
 
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
-	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/util"
 )
 
 type getDeploymentSettingsFunc func() (*pulumiapi.DeploymentSettings, error)
 
 type DeploymentSettingsClientMock struct {
+	config.Client
 	getDeploymentSettingsFunc getDeploymentSettingsFunc
+	// sent records the last settings passed to Create or Update.
+	sent *pulumiapi.DeploymentSettings
 }
 
 func (c *DeploymentSettingsClientMock) CreateDeploymentSettings(
 	_ context.Context,
 	_ pulumiapi.StackIdentifier,
-	_ pulumiapi.DeploymentSettings,
+	ds pulumiapi.DeploymentSettings,
 ) (*pulumiapi.DeploymentSettings, error) {
-	return nil, nil
+	c.sent = &ds
+	return &ds, nil
 }
 
 func (c *DeploymentSettingsClientMock) UpdateDeploymentSettings(
 	_ context.Context,
 	_ pulumiapi.StackIdentifier,
-	_ pulumiapi.DeploymentSettings,
+	ds pulumiapi.DeploymentSettings,
 ) (*pulumiapi.DeploymentSettings, error) {
-	return nil, nil
+	c.sent = &ds
+	return &ds, nil
 }
 
 func (c *DeploymentSettingsClientMock) GetDeploymentSettings(
@@ -54,7 +62,7 @@ func buildDeploymentSettingsClientMock(
 	getDeploymentSettingsFunc getDeploymentSettingsFunc,
 ) *DeploymentSettingsClientMock {
 	return &DeploymentSettingsClientMock{
-		getDeploymentSettingsFunc,
+		getDeploymentSettingsFunc: getDeploymentSettingsFunc,
 	}
 }
 
@@ -63,21 +71,12 @@ func TestDeploymentSettings(t *testing.T) {
 		mockedClient := buildDeploymentSettingsClientMock(
 			func() (*pulumiapi.DeploymentSettings, error) { return nil, nil },
 		)
+		ctx := config.WithMockClient(t.Context(), mockedClient)
 
-		provider := PulumiServiceDeploymentSettingsResource{
-			Client: mockedClient,
-		}
-
-		req := pulumirpc.ReadRequest{
-			Id:  "abc/def/123",
-			Urn: "urn:123",
-		}
-
-		resp, err := provider.Read(&req)
+		resp, err := (&DeploymentSettings{}).Read(ctx, infer.ReadRequest[dsIn, dsOut]{ID: "abc/def/123"})
 
 		assert.NoError(t, err)
-		assert.Equal(t, resp.Id, "")
-		assert.Nil(t, resp.Properties)
+		assert.Equal(t, resp.ID, "")
 	})
 
 	t.Run("Read when the resource is found", func(t *testing.T) {
@@ -91,178 +90,263 @@ func TestDeploymentSettings(t *testing.T) {
 				}, nil
 			},
 		)
+		ctx := config.WithMockClient(t.Context(), mockedClient)
 
-		provider := PulumiServiceDeploymentSettingsResource{
-			Client: mockedClient,
-		}
+		resp, err := (&DeploymentSettings{}).Read(ctx, infer.ReadRequest[dsIn, dsOut]{ID: "abc/def/123"})
 
-		req := pulumirpc.ReadRequest{
-			Id:  "abc/def/123",
-			Urn: "urn:123",
-		}
+		assert.NoError(t, err)
+		assert.Equal(t, resp.ID, "abc/def/123")
+		assert.Equal(t, "abc", resp.State.Organization)
+		assert.Equal(t, "def", resp.State.Project)
+		assert.Equal(t, "123", resp.State.Stack)
+	})
 
-		resp, err := provider.Read(&req)
+	t.Run("Preview does not call the service", func(t *testing.T) {
+		mockedClient := buildDeploymentSettingsClientMock(nil)
+		ctx := config.WithMockClient(t.Context(), mockedClient)
+		inputs := dsIn{Organization: "an-org", Project: "a-project", Stack: "a-stack"}
 
-		assert.NoError(t, err)
-		assert.Equal(t, resp.Id, "abc/def/123")
+		resp, err := (&DeploymentSettings{}).Create(ctx, infer.CreateRequest[dsIn]{Inputs: inputs, DryRun: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, "an-org/a-project/a-stack", resp.ID)
+		assert.Equal(t, inputs, resp.Output.DeploymentSettingsInput)
+		assert.Nil(t, mockedClient.sent)
 	})
 }
 
-// checkGitAuth runs Check over deployment settings carrying gitAuth and hands
-// back the checked gitAuth property map.
-func checkGitAuth(t *testing.T, gitAuth resource.PropertyMap) resource.PropertyMap {
+// checkDeploymentSettings runs Check through infer, so the result carries the
+// secret markers the engine would see.
+func checkDeploymentSettings(t *testing.T, inputs property.Map) property.Map {
 	t.Helper()
 
-	propertyMap := resource.PropertyMap{
-		gcOrganization: resource.NewStringProperty("an-org"),
-		gcProject:      resource.NewStringProperty("a-project"),
-		gcStack:        resource.NewStringProperty("a-stack"),
-		gcSourceContext: resource.NewObjectProperty(resource.PropertyMap{
-			gcGit: resource.NewObjectProperty(resource.PropertyMap{
-				"repoUrl": resource.NewStringProperty("https://github.com/pulumi/deploy-demos.git"),
-				gcGitAuth: resource.NewObjectProperty(gitAuth),
-			}),
-		}),
-	}
-
-	news, err := plugin.MarshalProperties(propertyMap, util.StandardMarshal)
-	assert.NoError(t, err)
+	prov, err := infer.NewProviderBuilder().
+		WithResources(infer.Resource(&DeploymentSettings{})).
+		WithModuleMap(map[tokens.ModuleName]tokens.ModuleName{"resources": "index"}).
+		Build()
+	require.NoError(t, err)
 
-	resp, err := (&PulumiServiceDeploymentSettingsResource{}).Check(&pulumirpc.CheckRequest{News: news})
-	assert.NoError(t, err)
-	assert.Empty(t, resp.Failures)
+	news := inputs.AsMap()
+	news[gcOrganization] = property.New("an-org")
+	news[gcProject] = property.New("a-project")
+	news[gcStack] = property.New("a-stack")
 
-	checked, err := plugin.UnmarshalProperties(resp.GetInputs(), util.KeepSecretsUnmarshal)
-	assert.NoError(t, err)
-
-	sourceContext := checked[gcSourceContext]
-	assert.True(t, sourceContext.IsObject(), "sourceContext should be present")
-	git := sourceContext.ObjectValue()[gcGit]
-	assert.True(t, git.IsObject(), "git should be present")
-	checkedGitAuth := git.ObjectValue()[gcGitAuth]
-	assert.True(t, checkedGitAuth.IsObject(), "gitAuth should be present")
+	resp, err := prov.Check(t.Context(), p.CheckRequest{
+		Urn:    presource.NewURN("stack", "project", "", "pulumiservice:index:DeploymentSettings", "settings"),
+		Inputs: property.NewMap(news),
+	})
+	require.NoError(t, err)
+	require.Empty(t, resp.Failures)
+	return resp.Inputs
+}
 
-	return checkedGitAuth.ObjectValue()
+// at digs the value at path out of m.
+func at(t *testing.T, m property.Map, path ...string) property.Value {
+	t.Helper()
+	v := property.New(m)
+	for _, key := range path {
+		require.Truef(t, v.IsMap(), "expected an object before %q", key)
+		v = v.AsMap().Get(key)
+	}
+	return v
 }
 
 // assertSecret asserts that prop is a secret wrapping the given plaintext.
-func assertSecret(t *testing.T, prop resource.PropertyValue, want string) {
+func assertSecret(t *testing.T, prop property.Value, want string) {
 	t.Helper()
-	assert.True(t, prop.IsSecret(), "expected a secret, got %v", prop)
-	assert.Equal(t, want, prop.SecretValue().Element.StringValue())
+	assert.True(t, prop.Secret(), "expected a secret, got %v", prop)
+	assert.Equal(t, want, prop.AsString())
+}
+
+func gitAuthInputs(gitAuth map[string]property.Value) property.Map {
+	return property.NewMap(map[string]property.Value{
+		gcSourceContext: property.New(map[string]property.Value{
+			gcGit: property.New(map[string]property.Value{
+				"repoUrl": property.New("https://github.com/pulumi/deploy-demos.git"),
+				gcGitAuth: property.New(gitAuth),
+			}),
+		}),
+	})
 }
 
-// The schema's `"secret": true` on these fields is codegen-only, and for a
-// property nested inside a type only the .NET SDK acts on it, so Check has to
-// promote them. Without this, a plaintext literal lands in the state file
-// verbatim for every other language.
+// A property nested inside a type is only marked secret by the .NET SDK, so
+// Check has to promote them. Without this, a plaintext literal lands in the
+// state file verbatim for every other language.
 func TestDeploymentSettingsCheckGitAuthSecrets(t *testing.T) {
+	gitAuthPath := []string{gcSourceContext, gcGit, gcGitAuth}
+
 	t.Run("promotes plaintext sshAuth credentials", func(t *testing.T) {
-		gitAuth := checkGitAuth(t, resource.PropertyMap{
-			gcSSHAuth: resource.NewObjectProperty(resource.PropertyMap{
-				gcSSHPrivateKey: resource.NewStringProperty("a-private-key"),
-				gcPassword:      resource.NewStringProperty("a-key-password"),
+		checked := checkDeploymentSettings(t, gitAuthInputs(map[string]property.Value{
+			gcSSHAuth: property.New(map[string]property.Value{
+				gcSSHPrivateKey: property.New("a-private-key"),
+				gcPassword:      property.New("a-key-password"),
 			}),
-		})
+		}))
 
-		sshAuth := gitAuth[gcSSHAuth].ObjectValue()
-		assertSecret(t, sshAuth[gcSSHPrivateKey], "a-private-key")
-		assertSecret(t, sshAuth[gcPassword], "a-key-password")
+		sshAuth := at(t, checked, append(gitAuthPath, gcSSHAuth)...).AsMap()
+		assertSecret(t, sshAuth.Get(gcSSHPrivateKey), "a-private-key")
+		assertSecret(t, sshAuth.Get(gcPassword), "a-key-password")
 	})
 
 	t.Run("promotes plaintext basicAuth credentials", func(t *testing.T) {
-		gitAuth := checkGitAuth(t, resource.PropertyMap{
-			gcBasicAuth: resource.NewObjectProperty(resource.PropertyMap{
-				gcUsername: resource.NewStringProperty("alice"),
-				gcPassword: resource.NewStringProperty("hunter2"),
+		checked := checkDeploymentSettings(t, gitAuthInputs(map[string]property.Value{
+			gcBasicAuth: property.New(map[string]property.Value{
+				gcUsername: property.New("alice"),
+				gcPassword: property.New("hunter2"),
 			}),
-		})
+		}))
 
-		basicAuth := gitAuth[gcBasicAuth].ObjectValue()
-		assertSecret(t, basicAuth[gcUsername], "alice")
-		assertSecret(t, basicAuth[gcPassword], "hunter2")
+		basicAuth := at(t, checked, append(gitAuthPath, gcBasicAuth)...).AsMap()
+		assertSecret(t, basicAuth.Get(gcUsername), "alice")
+		assertSecret(t, basicAuth.Get(gcPassword), "hunter2")
 	})
 
 	t.Run("leaves already secret credentials untouched", func(t *testing.T) {
-		gitAuth := checkGitAuth(t, resource.PropertyMap{
-			gcSSHAuth: resource.NewObjectProperty(resource.PropertyMap{
-				gcSSHPrivateKey: resource.MakeSecret(resource.NewStringProperty("a-private-key")),
+		checked := checkDeploymentSettings(t, gitAuthInputs(map[string]property.Value{
+			gcSSHAuth: property.New(map[string]property.Value{
+				gcSSHPrivateKey: property.New("a-private-key").WithSecret(true),
 			}),
-			gcBasicAuth: resource.NewObjectProperty(resource.PropertyMap{
-				gcUsername: resource.MakeSecret(resource.NewStringProperty("alice")),
-				gcPassword: resource.MakeSecret(resource.NewStringProperty("hunter2")),
+			gcBasicAuth: property.New(map[string]property.Value{
+				gcUsername: property.New("alice").WithSecret(true),
+				gcPassword: property.New("hunter2").WithSecret(true),
 			}),
-		})
+		}))
 
-		// Not double-wrapped: the element is still the string, not another secret.
-		assertSecret(t, gitAuth[gcSSHAuth].ObjectValue()[gcSSHPrivateKey], "a-private-key")
-		assertSecret(t, gitAuth[gcBasicAuth].ObjectValue()[gcUsername], "alice")
-		assertSecret(t, gitAuth[gcBasicAuth].ObjectValue()[gcPassword], "hunter2")
+		assertSecret(t, at(t, checked, append(gitAuthPath, gcSSHAuth, gcSSHPrivateKey)...), "a-private-key")
+		assertSecret(t, at(t, checked, append(gitAuthPath, gcBasicAuth, gcUsername)...), "alice")
+		assertSecret(t, at(t, checked, append(gitAuthPath, gcBasicAuth, gcPassword)...), "hunter2")
 	})
 
 	t.Run("optional sshAuth password stays absent", func(t *testing.T) {
-		gitAuth := checkGitAuth(t, resource.PropertyMap{
-			gcSSHAuth: resource.NewObjectProperty(resource.PropertyMap{
-				gcSSHPrivateKey: resource.NewStringProperty("a-private-key"),
+		checked := checkDeploymentSettings(t, gitAuthInputs(map[string]property.Value{
+			gcSSHAuth: property.New(map[string]property.Value{
+				gcSSHPrivateKey: property.New("a-private-key"),
 			}),
-		})
+		}))
 
-		sshAuth := gitAuth[gcSSHAuth].ObjectValue()
-		assertSecret(t, sshAuth[gcSSHPrivateKey], "a-private-key")
-		assert.NotContains(t, sshAuth, resource.PropertyKey(gcPassword))
+		sshAuth := at(t, checked, append(gitAuthPath, gcSSHAuth)...).AsMap()
+		assertSecret(t, sshAuth.Get(gcSSHPrivateKey), "a-private-key")
+		_, ok := sshAuth.GetOk(gcPassword)
+		assert.False(t, ok)
 	})
 }
 
 // The executor image registry password is nested inside a type too, so it needs
 // the same promotion in Check as the git auth credentials.
 func TestDeploymentSettingsCheckExecutorImageCredentialsSecret(t *testing.T) {
-	credentials := func(password resource.PropertyValue) resource.PropertyMap {
+	credentials := func(password property.Value) property.Map {
 		t.Helper()
-
-		propertyMap := resource.PropertyMap{
-			gcOrganization: resource.NewStringProperty("an-org"),
-			gcProject:      resource.NewStringProperty("a-project"),
-			gcStack:        resource.NewStringProperty("a-stack"),
-			gcExecutorContext: resource.NewObjectProperty(resource.PropertyMap{
-				"executorImage": resource.NewStringProperty("myreg.example.com/custom-pulumi:latest"),
-				gcCredentials: resource.NewObjectProperty(resource.PropertyMap{
-					gcUsername: resource.NewStringProperty(testRegistryUsername),
+		checked := checkDeploymentSettings(t, property.NewMap(map[string]property.Value{
+			gcExecutorContext: property.New(map[string]property.Value{
+				"executorImage": property.New("myreg.example.com/custom-pulumi:latest"),
+				gcCredentials: property.New(map[string]property.Value{
+					gcUsername: property.New(testRegistryUsername),
 					gcPassword: password,
 				}),
 			}),
-		}
+		}))
+		return at(t, checked, gcExecutorContext, gcCredentials).AsMap()
+	}
 
-		news, err := plugin.MarshalProperties(propertyMap, util.StandardMarshal)
-		assert.NoError(t, err)
+	t.Run("promotes a plaintext password", func(t *testing.T) {
+		checked := credentials(property.New(testRegistryPassword))
 
-		resp, err := (&PulumiServiceDeploymentSettingsResource{}).Check(&pulumirpc.CheckRequest{News: news})
-		assert.NoError(t, err)
-		assert.Empty(t, resp.Failures)
+		assertSecret(t, checked.Get(gcPassword), testRegistryPassword)
+		// The username is not a credential the schema marks secret.
+		assert.Equal(t, property.New(testRegistryUsername), checked.Get(gcUsername))
+	})
 
-		checked, err := plugin.UnmarshalProperties(resp.GetInputs(), util.KeepSecretsUnmarshal)
-		assert.NoError(t, err)
+	t.Run("leaves an already secret password untouched", func(t *testing.T) {
+		checked := credentials(property.New(testRegistryPassword).WithSecret(true))
 
-		executorContext := checked[gcExecutorContext]
-		assert.True(t, executorContext.IsObject(), "executorContext should be present")
-		checkedCredentials := executorContext.ObjectValue()[gcCredentials]
-		assert.True(t, checkedCredentials.IsObject(), "credentials should be present")
+		assertSecret(t, checked.Get(gcPassword), testRegistryPassword)
+	})
+}
 
-		return checkedCredentials.ObjectValue()
-	}
+// Settings with no sourceContext at all must pass through Check unchanged.
+func TestDeploymentSettingsCheckWithoutSourceContext(t *testing.T) {
+	checked := checkDeploymentSettings(t, property.Map{})
 
-	t.Run("promotes a plaintext password", func(t *testing.T) {
-		checked := credentials(resource.NewStringProperty(testRegistryPassword))
+	assert.Equal(t, property.NewMap(map[string]property.Value{
+		gcOrganization: property.New("an-org"),
+		gcProject:      property.New("a-project"),
+		gcStack:        property.New("a-stack"),
+	}), checked)
+}
 
-		assertSecret(t, checked[gcPassword], testRegistryPassword)
-		// The username is not a credential the schema marks secret.
-		assert.Equal(t, resource.NewStringProperty(testRegistryUsername), checked[gcUsername])
+func TestDeploymentSettingsCheckNormalizesDuration(t *testing.T) {
+	checked := checkDeploymentSettings(t, property.NewMap(map[string]property.Value{
+		gcOperationContext: property.New(map[string]property.Value{
+			"oidc": property.New(map[string]property.Value{
+				gcAWS: property.New(map[string]property.Value{
+					"roleARN":     property.New("arn:aws:iam::123:role/a-role"),
+					"sessionName": property.New("a-session"),
+					"duration":    property.New("90m"),
+				}),
+			}),
+		}),
+	}))
+
+	assert.Equal(t, "1h30m0s", at(t, checked, gcOperationContext, "oidc", gcAWS, "duration").AsString())
+}
+
+// Only the environment variables the program marked secret are sent to Pulumi
+// Cloud as secrets.
+func TestDeploymentSettingsCreateSecretEnvironmentVariables(t *testing.T) {
+	mockedClient := buildDeploymentSettingsClientMock(nil)
+	news := property.NewMap(map[string]property.Value{
+		gcOperationContext: property.New(map[string]property.Value{
+			gcEnvironmentVars: property.New(map[string]property.Value{
+				"PLAIN":  property.New("a"),
+				"SECRET": property.New("b").WithSecret(true),
+			}),
+		}),
 	})
+	ctx := WithRawInputs(config.WithMockClient(t.Context(), mockedClient), property.Map{}, news)
 
-	t.Run("leaves an already secret password untouched", func(t *testing.T) {
-		checked := credentials(resource.MakeSecret(resource.NewStringProperty(testRegistryPassword)))
+	_, err := (&DeploymentSettings{}).Create(ctx, infer.CreateRequest[dsIn]{Inputs: dsIn{
+		Organization: "an-org", Project: "a-project", Stack: "a-stack",
+		OperationContext: &DeploymentSettingsOperationContext{
+			EnvironmentVariables: map[string]string{"PLAIN": "a", "SECRET": "b"},
+		},
+	}})
+	require.NoError(t, err)
 
-		// Not double-wrapped: the element is still the string, not another secret.
-		assertSecret(t, checked[gcPassword], testRegistryPassword)
+	require.NotNil(t, mockedClient.sent)
+	variables := mockedClient.sent.Operation.EnvironmentVariables
+	assert.Equal(t, pulumiapi.SecretValue{Value: "a"}, variables["PLAIN"])
+	assert.Equal(t, pulumiapi.SecretValue{Secret: true, Value: "b"}, variables["SECRET"])
+}
+
+func TestDeploymentSettingsDiff(t *testing.T) {
+	olds := property.NewMap(map[string]property.Value{
+		gcOrganization: property.New("an-org"),
+		gcProject:      property.New("a-project"),
+		gcStack:        property.New("a-stack"),
+		"agentPoolId":  property.New("pool-1"),
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		ctx := WithRawInputs(t.Context(), olds, olds)
+		resp, err := (&DeploymentSettings{}).Diff(ctx, infer.DiffRequest[dsIn, dsOut]{})
+		require.NoError(t, err)
+		assert.False(t, resp.HasChanges)
+	})
+
+	t.Run("update in place", func(t *testing.T) {
+		ctx := WithRawInputs(t.Context(), olds, olds.Set("agentPoolId", property.New("pool-2")))
+		resp, err := (&DeploymentSettings{}).Diff(ctx, infer.DiffRequest[dsIn, dsOut]{})
+		require.NoError(t, err)
+		assert.True(t, resp.HasChanges)
+		assert.Equal(t, p.Update, resp.DetailedDiff["agentPoolId"].Kind)
+	})
+
+	t.Run("stack change replaces", func(t *testing.T) {
+		ctx := WithRawInputs(t.Context(), olds, olds.Set(gcStack, property.New("b-stack")))
+		resp, err := (&DeploymentSettings{}).Diff(ctx, infer.DiffRequest[dsIn, dsOut]{})
+		require.NoError(t, err)
+		assert.Equal(t, p.UpdateReplace, resp.DetailedDiff[gcStack].Kind)
+		assert.True(t, resp.DeleteBeforeReplace)
 	})
 }
 
@@ -299,17 +383,12 @@ func redactedBasicAuth() pulumiapi.DeploymentSettings {
 	return basicAuthSettings(redacted, redacted)
 }
 
-// basicAuthPropertyMap digs the git basic auth back out of an encoded property map.
-func basicAuthPropertyMap(t *testing.T, encoded resource.PropertyMap) resource.PropertyMap {
-	t.Helper()
+var testStack = pulumiapi.StackIdentifier{OrgName: "an-org", ProjectName: "a-project", StackName: "a-stack"}
 
-	value := encoded[gcSourceContext]
-	for _, key := range []resource.PropertyKey{gcGit, gcGitAuth, gcBasicAuth} {
-		assert.Truef(t, value.IsObject(), "expected an object before %q", key)
-		value = value.ObjectValue()[key]
-	}
-	assert.True(t, value.IsObject(), "basicAuth should be present")
-	return value.ObjectValue()
+// renderInputs renders settings as the inputs a program would supply.
+func renderInputs(settings pulumiapi.DeploymentSettings) *DeploymentSettingsInput {
+	inputs := newDeploymentSettingsState(testStack, &settings).DeploymentSettingsInput
+	return &inputs
 }
 
 // The username is a twin-value secret like the password: Pulumi Cloud encrypts
@@ -317,14 +396,12 @@ func basicAuthPropertyMap(t *testing.T, encoded resource.PropertyMap) resource.P
 // create/refresh/import paths.
 func TestDeploymentSettingsBasicAuthRoundtrip(t *testing.T) {
 	settings := plaintextBasicAuth()
-	initial := PulumiServiceDeploymentSettingsInput{DeploymentSettings: settings}
+	initial := renderInputs(settings)
 
-	// Create mode: plaintext inputs are available, no prior cipher state yet.
-	encoded := initial.ToPropertyMap(&settings, nil, true)
-	decoded := (&PulumiServiceDeploymentSettingsResource{}).ToPulumiServiceDeploymentSettingsInput(encoded)
+	decoded := initial.toPulumiServiceDeploymentSettings(func(string) bool { return false })
 
-	assert.EqualValues(t, initial, decoded)
-	assertSecret(t, basicAuthPropertyMap(t, encoded)[gcUsername], "alice")
+	assert.EqualValues(t, settings, decoded)
+	assert.Equal(t, "alice", initial.SourceContext.Git.GitAuth.BasicAuth.Username)
 }
 
 // On refresh the API hands back the credentials redacted plus their ciphertext,
@@ -332,17 +409,17 @@ func TestDeploymentSettingsBasicAuthRoundtrip(t *testing.T) {
 // refresh reports no change.
 func TestDeploymentSettingsBasicAuthRefresh(t *testing.T) {
 	fromAPI := redactedBasicAuth()
-	priorState := redactedBasicAuth()
-	plaintextInputs := plaintextBasicAuth()
+	priorState := renderInputs(redactedBasicAuth())
+	plaintextInputs := renderInputs(plaintextBasicAuth())
 
-	input := PulumiServiceDeploymentSettingsInput{DeploymentSettings: fromAPI}
+	state := newDeploymentSettingsState(testStack, &fromAPI)
+	twins := twinSecrets{plaintext: plaintextInputs, prior: priorState}
 
-	inputs := basicAuthPropertyMap(t, input.ToPropertyMap(&plaintextInputs, &priorState, true))
-	assertSecret(t, inputs[gcUsername], "alice")
-	assertSecret(t, inputs[gcPassword], "hunter2")
+	basicAuth := twins.inputs(state, &fromAPI).SourceContext.Git.GitAuth.BasicAuth
+	assert.Equal(t, "alice", basicAuth.Username)
+	assert.Equal(t, "hunter2", basicAuth.Password)
 
-	outputs := basicAuthPropertyMap(t, input.ToPropertyMap(&plaintextInputs, &priorState, false))
-	assert.Equal(t, testRedactedSecret, util.GetSecretOrStringValue(outputs[gcUsername]),
+	assert.Equal(t, testRedactedSecret, state.SourceContext.Git.GitAuth.BasicAuth.Username,
 		"outputs hold what the service returned, not the plaintext")
 }
 
@@ -350,45 +427,35 @@ func TestDeploymentSettingsBasicAuthRefresh(t *testing.T) {
 // placeholder that prompts the user to fill them in.
 func TestDeploymentSettingsBasicAuthImport(t *testing.T) {
 	settings := redactedBasicAuth()
-	input := PulumiServiceDeploymentSettingsInput{DeploymentSettings: settings}
+	state := newDeploymentSettingsState(testStack, &settings)
 
-	inputs := basicAuthPropertyMap(t, input.ToPropertyMap(nil, nil, true))
-	assertSecret(t, inputs[gcUsername], "<REPLACE WITH ACTUAL SECRET VALUE>")
-	assertSecret(t, inputs[gcPassword], "<REPLACE WITH ACTUAL SECRET VALUE>")
+	basicAuth := twinSecrets{}.inputs(state, &settings).SourceContext.Git.GitAuth.BasicAuth
+	assert.Equal(t, importSecretPlaceholder, basicAuth.Username)
+	assert.Equal(t, importSecretPlaceholder, basicAuth.Password)
 }
 
-// Settings with no sourceContext at all must pass through Check unchanged.
-func TestDeploymentSettingsCheckWithoutSourceContext(t *testing.T) {
-	propertyMap := resource.PropertyMap{
-		gcOrganization: resource.NewStringProperty("an-org"),
-		gcProject:      resource.NewStringProperty("a-project"),
-		gcStack:        resource.NewStringProperty("a-stack"),
+func TestDeploymentSettingsRoundtrip(t *testing.T) {
+	settings := pulumiapi.DeploymentSettings{
+		CacheOptions: &pulumiapi.CacheOptions{
+			Enable: true,
+		},
 	}
 
-	news, err := plugin.MarshalProperties(propertyMap, util.StandardMarshal)
-	assert.NoError(t, err)
+	decoded := renderInputs(settings).toPulumiServiceDeploymentSettings(func(string) bool { return false })
 
-	resp, err := (&PulumiServiceDeploymentSettingsResource{}).Check(&pulumirpc.CheckRequest{News: news})
-	assert.NoError(t, err)
-	assert.Empty(t, resp.Failures)
-
-	checked, err := plugin.UnmarshalProperties(resp.GetInputs(), util.KeepSecretsUnmarshal)
-	assert.NoError(t, err)
-	assert.Equal(t, propertyMap, checked)
+	assert.EqualValues(t, settings, decoded)
 }
 
-func TestDeploymentSettingsRoundtrip(t *testing.T) {
-	initial := PulumiServiceDeploymentSettingsInput{
-		DeploymentSettings: pulumiapi.DeploymentSettings{
-			CacheOptions: &pulumiapi.CacheOptions{
-				Enable: true,
-			},
-		}}
+func TestDeploymentSettingsShellRoundtrip(t *testing.T) {
+	settings := pulumiapi.DeploymentSettings{
+		Operation: &pulumiapi.OperationContext{
+			Options: &pulumiapi.OperationContextOptions{Shell: "/bin/zsh"},
+		},
+	}
 
-	encoded := initial.ToPropertyMap(nil, nil, true)
-	decoded := (&PulumiServiceDeploymentSettingsResource{}).ToPulumiServiceDeploymentSettingsInput(encoded)
+	decoded := renderInputs(settings).toPulumiServiceDeploymentSettings(func(string) bool { return false })
 
-	assert.EqualValues(t, initial, decoded)
+	assert.EqualValues(t, settings, decoded)
 }
 
 const (
@@ -429,30 +496,16 @@ func redactedPassword() pulumiapi.SecretValue {
 	}
 }
 
-// credentialsPropertyMap digs the executor image credentials back out of an
-// encoded property map.
-func credentialsPropertyMap(t *testing.T, encoded resource.PropertyMap) resource.PropertyMap {
-	t.Helper()
-	executorContext := encoded["executorContext"]
-	assert.True(t, executorContext.IsObject(), "executorContext should be present")
-	credentials := executorContext.ObjectValue()["credentials"]
-	assert.True(t, credentials.IsObject(), "credentials should be present")
-	return credentials.ObjectValue()
-}
-
 func TestDeploymentSettingsExecutorImageCredentialsRoundtrip(t *testing.T) {
 	settings := executorImageCredentialsSettings(plaintextPassword())
-	initial := PulumiServiceDeploymentSettingsInput{DeploymentSettings: settings}
 
-	// Create mode: plaintext inputs are available, no prior cipher state yet.
-	encoded := initial.ToPropertyMap(&settings, nil, true)
-	decoded := (&PulumiServiceDeploymentSettingsResource{}).ToPulumiServiceDeploymentSettingsInput(encoded)
+	decoded := renderInputs(settings).toPulumiServiceDeploymentSettings(func(string) bool { return false })
 
-	assert.EqualValues(t, initial, decoded)
+	assert.EqualValues(t, settings, decoded)
 	assert.Equal(t, testRegistryPassword, decoded.Executor.ExecutorImage.Credentials.Password.Value)
 }
 
-// An executor image without credentials must encode exactly as it did before
+// An executor image without credentials must render exactly as it did before
 // credentials existed, so upgrading the provider produces no diff.
 func TestDeploymentSettingsExecutorImageWithoutCredentialsRoundtrip(t *testing.T) {
 	settings := pulumiapi.DeploymentSettings{
@@ -460,14 +513,12 @@ func TestDeploymentSettingsExecutorImageWithoutCredentialsRoundtrip(t *testing.T
 			ExecutorImage: &pulumiapi.DockerImage{Reference: "pulumi/pulumi-nodejs:latest"},
 		},
 	}
-	initial := PulumiServiceDeploymentSettingsInput{DeploymentSettings: settings}
+	inputs := renderInputs(settings)
 
-	encoded := initial.ToPropertyMap(&settings, nil, true)
-	decoded := (&PulumiServiceDeploymentSettingsResource{}).ToPulumiServiceDeploymentSettingsInput(encoded)
+	decoded := inputs.toPulumiServiceDeploymentSettings(func(string) bool { return false })
 
-	assert.EqualValues(t, initial, decoded)
-	assert.NotContains(t, encoded["executorContext"].ObjectValue(), resource.PropertyKey("credentials"))
-	assert.Nil(t, decoded.Executor.ExecutorImage.Credentials)
+	assert.EqualValues(t, settings, decoded)
+	assert.Nil(t, inputs.ExecutorContext.Credentials)
 }
 
 // On refresh the API hands back the password redacted to "[secret]" plus its
@@ -475,17 +526,15 @@ func TestDeploymentSettingsExecutorImageWithoutCredentialsRoundtrip(t *testing.T
 // forward so refresh reports no change.
 func TestDeploymentSettingsExecutorImageCredentialsRefresh(t *testing.T) {
 	fromAPI := executorImageCredentialsSettings(redactedPassword())
-	priorState := executorImageCredentialsSettings(redactedPassword())
-	plaintextInputs := executorImageCredentialsSettings(plaintextPassword())
+	priorState := renderInputs(executorImageCredentialsSettings(redactedPassword()))
+	plaintextInputs := renderInputs(executorImageCredentialsSettings(plaintextPassword()))
 
-	input := PulumiServiceDeploymentSettingsInput{DeploymentSettings: fromAPI}
+	state := newDeploymentSettingsState(testStack, &fromAPI)
+	twins := twinSecrets{plaintext: plaintextInputs, prior: priorState}
 
-	inputs := credentialsPropertyMap(t, input.ToPropertyMap(&plaintextInputs, &priorState, true))
-	assert.Equal(t, testRegistryPassword, util.GetSecretOrStringValue(inputs["password"]),
+	assert.Equal(t, testRegistryPassword, twins.inputs(state, &fromAPI).ExecutorContext.Credentials.Password,
 		"refresh must preserve the plaintext password from prior inputs")
-
-	outputs := credentialsPropertyMap(t, input.ToPropertyMap(&plaintextInputs, &priorState, false))
-	assert.Equal(t, testRedactedSecret, util.GetSecretOrStringValue(outputs["password"]),
+	assert.Equal(t, testRedactedSecret, state.ExecutorContext.Credentials.Password,
 		"outputs hold what the service returned, not the plaintext")
 }
 
@@ -493,46 +542,43 @@ func TestDeploymentSettingsExecutorImageCredentialsRefresh(t *testing.T) {
 // so the input is blanked and the engine surfaces a diff.
 func TestDeploymentSettingsExecutorImageCredentialsRefreshWithoutPriorState(t *testing.T) {
 	fromAPI := executorImageCredentialsSettings(redactedPassword())
-	priorState := pulumiapi.DeploymentSettings{Executor: &pulumiapi.ExecutorContext{}}
-	plaintextInputs := executorImageCredentialsSettings(plaintextPassword())
+	priorState := &DeploymentSettingsInput{ExecutorContext: &DeploymentSettingsExecutorContext{}}
+	plaintextInputs := renderInputs(executorImageCredentialsSettings(plaintextPassword()))
 
-	input := PulumiServiceDeploymentSettingsInput{DeploymentSettings: fromAPI}
+	state := newDeploymentSettingsState(testStack, &fromAPI)
+	twins := twinSecrets{plaintext: plaintextInputs, prior: priorState}
 
-	inputs := credentialsPropertyMap(t, input.ToPropertyMap(&plaintextInputs, &priorState, true))
-	assert.Equal(t, "", util.GetSecretOrStringValue(inputs["password"]))
+	assert.Equal(t, "", twins.inputs(state, &fromAPI).ExecutorContext.Credentials.Password)
 }
 
 // Import has no inputs at all, so the password is replaced with the placeholder
 // that prompts the user to fill it in.
 func TestDeploymentSettingsExecutorImageCredentialsImport(t *testing.T) {
 	settings := executorImageCredentialsSettings(redactedPassword())
-	input := PulumiServiceDeploymentSettingsInput{DeploymentSettings: settings}
+	state := newDeploymentSettingsState(testStack, &settings)
 
-	inputs := credentialsPropertyMap(t, input.ToPropertyMap(nil, nil, true))
-	assert.Equal(t, "<REPLACE WITH ACTUAL SECRET VALUE>", util.GetSecretOrStringValue(inputs["password"]))
-	assert.Equal(t, "registry-user", util.GetSecretOrStringValue(inputs["username"]))
+	credentials := twinSecrets{}.inputs(state, &settings).ExecutorContext.Credentials
+	assert.Equal(t, importSecretPlaceholder, credentials.Password)
+	assert.Equal(t, testRegistryUsername, credentials.Username)
 }
 
 func TestDeploymentSettingsVcsRoundtrip(t *testing.T) {
 	deployPR := int64(1)
-	initial := PulumiServiceDeploymentSettingsInput{
-		DeploymentSettings: pulumiapi.DeploymentSettings{
-			Vcs: pulumiapi.DeploymentSettingsVCSAzureDevOpsBuilder{
-				DeploymentSettingsVCSBuilder: pulumiapi.DeploymentSettingsVCSBuilder{
-					Repository:          "my-org/my-repo",
-					InstallationID:      "129444790",
-					DeployCommits:       true,
-					PreviewPullRequests: true,
-					PullRequestTemplate: false,
-					Paths:               []string{"infra/**"},
-					DeployPullRequest:   &deployPR,
-				},
-			}.Build(),
-		},
+	settings := pulumiapi.DeploymentSettings{
+		Vcs: pulumiapi.DeploymentSettingsVCSAzureDevOpsBuilder{
+			DeploymentSettingsVCSBuilder: pulumiapi.DeploymentSettingsVCSBuilder{
+				Repository:          "my-org/my-repo",
+				InstallationID:      "129444790",
+				DeployCommits:       true,
+				PreviewPullRequests: true,
+				PullRequestTemplate: false,
+				Paths:               []string{"infra/**"},
+				DeployPullRequest:   &deployPR,
+			},
+		}.Build(),
 	}
 
-	encoded := initial.ToPropertyMap(nil, nil, true)
-	decoded := (&PulumiServiceDeploymentSettingsResource{}).ToPulumiServiceDeploymentSettingsInput(encoded)
+	decoded := renderInputs(settings).toPulumiServiceDeploymentSettings(func(string) bool { return false })
 
-	assert.EqualValues(t, initial, decoded)
+	assert.EqualValues(t, settings, decoded)
 }
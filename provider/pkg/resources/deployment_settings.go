@@ -6,12 +6,13 @@ import (
 	"path"
 	"time"
 
-	pbempty "google.golang.org/protobuf/types/known/emptypb"
-
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
-	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
 
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/util"
 )
@@ -24,1062 +25,920 @@ const (
 
 // Property names along the paths to the nested credentials.
 const (
-	gcSourceContext   = "sourceContext"
-	gcGit             = "git"
-	gcGitAuth         = "gitAuth"
-	gcSSHAuth         = "sshAuth"
-	gcSSHPrivateKey   = "sshPrivateKey"
-	gcBasicAuth       = "basicAuth"
-	gcUsername        = "username"
-	gcPassword        = "password"
-	gcExecutorContext = "executorContext"
-	gcCredentials     = "credentials"
+	gcSourceContext    = "sourceContext"
+	gcGit              = "git"
+	gcGitAuth          = "gitAuth"
+	gcSSHAuth          = "sshAuth"
+	gcSSHPrivateKey    = "sshPrivateKey"
+	gcBasicAuth        = "basicAuth"
+	gcUsername         = "username"
+	gcPassword         = "password"
+	gcExecutorContext  = "executorContext"
+	gcCredentials      = "credentials"
+	gcOperationContext = "operationContext"
+	gcEnvironmentVars  = "environmentVariables"
 )
 
-type PulumiServiceDeploymentSettingsInput struct {
-	pulumiapi.DeploymentSettings
-	Stack pulumiapi.StackIdentifier
-}
-
-// plaintextInputSettings are the latest inputs of the resource, containing
-// plaintext values wrapped in Secrets currentStateCipherSettings are the
-// latest outputs/properties of the resource, containing ciphertext strings of
-// secret values isInput is a flag that selects whether to generating an input
-// PropertyMap that contains plaintext (true) or an output PropertyMap that
-// contains ciphertext (false)
-func (ds *PulumiServiceDeploymentSettingsInput) ToPropertyMap(
-	plaintextInputSettings *pulumiapi.DeploymentSettings,
-	currentStateCipherSettings *pulumiapi.DeploymentSettings,
-	isInput bool,
-) resource.PropertyMap {
-	// Below flags are used throughout this method and direct the serialization
-	// of twin value secrets Twin value secrets are values whose plaintext
-	// cannot be retrieved from the API, thus forcing the development of this
-	// fairly complex system When plaintextInputSettings is passed in, but
-	// currentStateCipherSettings is not, that means the resource is being
-	// created or updated
-	createMode := plaintextInputSettings != nil && currentStateCipherSettings == nil
-	// When both plaintextInputSettings and currentStateCipherSettings are
-	// passed in, that means an existing resource is being refreshed, and it's
-	// necessary to merge values In case we are merging, but some of the
-	// properties don't previously exist, we will merge with empty value,
-	// setting plaintext to be empty string
-	mergeMode := plaintextInputSettings != nil && currentStateCipherSettings != nil
-	// If neither one is passed in, we are importing an existing resource into
-	// the state
-
-	pm := resource.PropertyMap{}
-	pm[gcOrganization] = resource.NewPropertyValue(ds.Stack.OrgName)
-	pm[gcProject] = resource.NewPropertyValue(ds.Stack.ProjectName)
-	pm[gcStack] = resource.NewPropertyValue(ds.Stack.StackName)
-
-	if ds.AgentPoolID != nil {
-		pm["agentPoolId"] = resource.NewPropertyValue(*ds.AgentPoolID)
-	}
-
-	if ds.SourceContext != nil {
-		scMap := resource.PropertyMap{}
-		if ds.SourceContext.Git != nil {
-			gitPropertyMap := resource.PropertyMap{}
-			if ds.SourceContext.Git.RepoURL != "" {
-				gitPropertyMap["repoUrl"] = resource.NewPropertyValue(ds.SourceContext.Git.RepoURL)
-			}
-			if ds.SourceContext.Git.Commit != "" {
-				gitPropertyMap["commit"] = resource.NewPropertyValue(ds.SourceContext.Git.Commit)
-			}
-			if ds.SourceContext.Git.Branch != "" {
-				gitPropertyMap["branch"] = resource.NewPropertyValue(ds.SourceContext.Git.Branch)
-			}
-			if ds.SourceContext.Git.RepoDir != "" {
-				gitPropertyMap["repoDir"] = resource.NewPropertyValue(ds.SourceContext.Git.RepoDir)
-			}
-			if ds.SourceContext.Git.GitAuth != nil {
-				gitAuthPropertyMap := resource.PropertyMap{}
-				if ds.SourceContext.Git.GitAuth.SSHAuth != nil {
-					sshAuthPropertyMap := resource.PropertyMap{}
-					if ds.SourceContext.Git.GitAuth.SSHAuth.SSHPrivateKey.Value != "" {
-						if mergeMode {
-							var plaintextValue *pulumiapi.SecretValue
-							var currentCipherValue *pulumiapi.SecretValue
-							if currentStateCipherSettings.SourceContext != nil &&
-								currentStateCipherSettings.SourceContext.Git != nil &&
-								currentStateCipherSettings.SourceContext.Git.GitAuth != nil &&
-								currentStateCipherSettings.SourceContext.Git.GitAuth.SSHAuth != nil {
-								plaintextValue = &plaintextInputSettings.SourceContext.Git.GitAuth.SSHAuth.SSHPrivateKey
-								currentCipherValue = &currentStateCipherSettings.SourceContext.Git.GitAuth.SSHAuth.SSHPrivateKey
-							}
-							util.MergeSecretValue(
-								sshAuthPropertyMap,
-								"sshPrivateKey",
-								ds.SourceContext.Git.GitAuth.SSHAuth.SSHPrivateKey,
-								plaintextValue,
-								currentCipherValue,
-								isInput,
-							)
-						} else if createMode {
-							util.CreateSecretValue(sshAuthPropertyMap, "sshPrivateKey", ds.SourceContext.Git.GitAuth.SSHAuth.SSHPrivateKey,
-								plaintextInputSettings.SourceContext.Git.GitAuth.SSHAuth.SSHPrivateKey, isInput)
-						} else {
-							util.ImportSecretValue(
-								sshAuthPropertyMap, "sshPrivateKey", ds.SourceContext.Git.GitAuth.SSHAuth.SSHPrivateKey, isInput,
-							)
-						}
-					}
-					if ds.SourceContext.Git.GitAuth.SSHAuth.Password != nil &&
-						ds.SourceContext.Git.GitAuth.SSHAuth.Password.Value != "" {
-						if mergeMode {
-							var plaintextValue *pulumiapi.SecretValue
-							var currentCipherValue *pulumiapi.SecretValue
-							if currentStateCipherSettings.SourceContext != nil &&
-								currentStateCipherSettings.SourceContext.Git != nil &&
-								currentStateCipherSettings.SourceContext.Git.GitAuth != nil &&
-								currentStateCipherSettings.SourceContext.Git.GitAuth.SSHAuth != nil &&
-								currentStateCipherSettings.SourceContext.Git.GitAuth.SSHAuth.Password != nil {
-								plaintextValue = plaintextInputSettings.SourceContext.Git.GitAuth.SSHAuth.Password
-								currentCipherValue = currentStateCipherSettings.SourceContext.Git.GitAuth.SSHAuth.Password
-							}
-							util.MergeSecretValue(
-								sshAuthPropertyMap,
-								"password",
-								*ds.SourceContext.Git.GitAuth.SSHAuth.Password,
-								plaintextValue,
-								currentCipherValue,
-								isInput,
-							)
-						} else if createMode {
-							util.CreateSecretValue(sshAuthPropertyMap, "password", *ds.SourceContext.Git.GitAuth.SSHAuth.Password,
-								*plaintextInputSettings.SourceContext.Git.GitAuth.SSHAuth.Password, isInput)
-						} else {
-							util.ImportSecretValue(sshAuthPropertyMap, "password", *ds.SourceContext.Git.GitAuth.SSHAuth.Password, isInput)
-						}
-					}
-					gitAuthPropertyMap["sshAuth"] = resource.PropertyValue{V: sshAuthPropertyMap}
-				}
-				if ds.SourceContext.Git.GitAuth.BasicAuth != nil {
-					basicAuthPropertyMap := resource.PropertyMap{}
-					if ds.SourceContext.Git.GitAuth.BasicAuth.UserName.Value != "" {
-						if mergeMode {
-							var plaintextValue *pulumiapi.SecretValue
-							var currentCipherValue *pulumiapi.SecretValue
-							if currentStateCipherSettings.SourceContext != nil &&
-								currentStateCipherSettings.SourceContext.Git != nil &&
-								currentStateCipherSettings.SourceContext.Git.GitAuth != nil &&
-								currentStateCipherSettings.SourceContext.Git.GitAuth.BasicAuth != nil {
-								plaintextValue = &plaintextInputSettings.SourceContext.Git.GitAuth.BasicAuth.UserName
-								currentCipherValue = &currentStateCipherSettings.SourceContext.Git.GitAuth.BasicAuth.UserName
-							}
-							util.MergeSecretValue(
-								basicAuthPropertyMap,
-								gcUsername,
-								ds.SourceContext.Git.GitAuth.BasicAuth.UserName,
-								plaintextValue,
-								currentCipherValue,
-								isInput,
-							)
-						} else if createMode {
-							util.CreateSecretValue(basicAuthPropertyMap, gcUsername, ds.SourceContext.Git.GitAuth.BasicAuth.UserName,
-								plaintextInputSettings.SourceContext.Git.GitAuth.BasicAuth.UserName, isInput)
-						} else {
-							util.ImportSecretValue(
-								basicAuthPropertyMap, gcUsername, ds.SourceContext.Git.GitAuth.BasicAuth.UserName, isInput,
-							)
-						}
-					}
-					if ds.SourceContext.Git.GitAuth.BasicAuth.Password.Value != "" {
-						if mergeMode {
-							var plaintextValue *pulumiapi.SecretValue
-							var currentCipherValue *pulumiapi.SecretValue
-							if currentStateCipherSettings.SourceContext != nil &&
-								currentStateCipherSettings.SourceContext.Git != nil &&
-								currentStateCipherSettings.SourceContext.Git.GitAuth != nil &&
-								currentStateCipherSettings.SourceContext.Git.GitAuth.BasicAuth != nil {
-								plaintextValue = &plaintextInputSettings.SourceContext.Git.GitAuth.BasicAuth.Password
-								currentCipherValue = &currentStateCipherSettings.SourceContext.Git.GitAuth.BasicAuth.Password
-							}
-							util.MergeSecretValue(
-								basicAuthPropertyMap,
-								"password",
-								ds.SourceContext.Git.GitAuth.BasicAuth.Password,
-								plaintextValue,
-								currentCipherValue,
-								isInput,
-							)
-						} else if createMode {
-							util.CreateSecretValue(basicAuthPropertyMap, "password", ds.SourceContext.Git.GitAuth.BasicAuth.Password,
-								plaintextInputSettings.SourceContext.Git.GitAuth.BasicAuth.Password, isInput)
-						} else {
-							util.ImportSecretValue(
-								basicAuthPropertyMap, "password", ds.SourceContext.Git.GitAuth.BasicAuth.Password, isInput,
-							)
-						}
-					}
-					gitAuthPropertyMap["basicAuth"] = resource.PropertyValue{V: basicAuthPropertyMap}
-				}
-				gitPropertyMap["gitAuth"] = resource.PropertyValue{V: gitAuthPropertyMap}
-			}
-			scMap["git"] = resource.PropertyValue{V: gitPropertyMap}
-		}
-		pm["sourceContext"] = resource.PropertyValue{V: scMap}
-	}
+// importSecretPlaceholder stands in for a secret whose plaintext Pulumi Cloud
+// never returns, when a resource is imported without any prior inputs.
+const importSecretPlaceholder = "<REPLACE WITH ACTUAL SECRET VALUE>"
 
-	if ds.Operation != nil {
-		ocMap := resource.PropertyMap{}
-		if ds.Operation.PreRunCommands != nil {
-			ocMap["preRunCommands"] = resource.NewPropertyValue(ds.Operation.PreRunCommands)
-		}
-		if ds.Operation.EnvironmentVariables != nil {
-			evMap := resource.PropertyMap{}
-			for k, v := range ds.Operation.EnvironmentVariables {
-				if v.Secret {
-					if mergeMode {
-						var plaintextValue pulumiapi.SecretValue
-						var currentCipherValue pulumiapi.SecretValue
-						if currentStateCipherSettings.Operation != nil {
-							plaintextValue = plaintextInputSettings.Operation.EnvironmentVariables[k]
-							currentCipherValue = currentStateCipherSettings.Operation.EnvironmentVariables[k]
-						}
-						util.MergeSecretValue(evMap, k, v, &plaintextValue, &currentCipherValue, isInput)
-					} else if createMode {
-						util.CreateSecretValue(evMap, k, v,
-							plaintextInputSettings.Operation.EnvironmentVariables[k], isInput)
-					} else {
-						util.ImportSecretValue(evMap, k, v, isInput)
-					}
-				} else {
-					evMap[resource.PropertyKey(k)] = resource.NewPropertyValue(v.Value)
-				}
-			}
-			ocMap["environmentVariables"] = resource.PropertyValue{V: evMap}
-		}
-		if ds.Operation.Options != nil {
-			optionsMap := resource.PropertyMap{}
-			if ds.Operation.Options.Shell != "" {
-				optionsMap["shell"] = resource.NewPropertyValue(ds.Operation.Options.Shell)
-			}
-			if ds.Operation.Options.SkipInstallDependencies {
-				optionsMap["skipInstallDependencies"] = resource.NewPropertyValue(true)
-			}
-			if ds.Operation.Options.SkipIntermediateDeployments {
-				optionsMap["skipIntermediateDeployments"] = resource.NewPropertyValue(true)
-			}
-			if ds.Operation.Options.DeleteAfterDestroy {
-				optionsMap["deleteAfterDestroy"] = resource.NewPropertyValue(true)
-			}
-			ocMap["options"] = resource.PropertyValue{V: optionsMap}
-		}
-		if ds.Operation.OIDC != nil {
-			if ds.Operation.OIDC.AWS != nil || ds.Operation.OIDC.GCP != nil ||
-				ds.Operation.OIDC.Azure != nil {
-				oidcMap := resource.PropertyMap{}
-				if ds.Operation.OIDC.AWS != nil {
-					awsMap := resource.PropertyMap{}
-					if ds.Operation.OIDC.AWS.RoleARN != "" {
-						awsMap["roleARN"] = resource.NewPropertyValue(ds.Operation.OIDC.AWS.RoleARN)
-					}
-					if ds.Operation.OIDC.AWS.SessionName != "" {
-						awsMap["sessionName"] = resource.NewPropertyValue(ds.Operation.OIDC.AWS.SessionName)
-					}
-					if ds.Operation.OIDC.AWS.PolicyARNs != nil {
-						awsMap["policyARNs"] = resource.NewPropertyValue(ds.Operation.OIDC.AWS.PolicyARNs)
-					}
-					if ds.Operation.OIDC.AWS.Duration != "" {
-						awsMap["duration"] = resource.NewPropertyValue(ds.Operation.OIDC.AWS.Duration)
-					}
-					oidcMap[gcAWS] = resource.PropertyValue{V: awsMap}
-				}
-				if ds.Operation.OIDC.GCP != nil {
-					gcpMap := resource.PropertyMap{}
-					if ds.Operation.OIDC.GCP.ProviderID != "" {
-						gcpMap["providerId"] = resource.NewPropertyValue(ds.Operation.OIDC.GCP.ProviderID)
-					}
-					if ds.Operation.OIDC.GCP.ServiceAccount != "" {
-						gcpMap["serviceAccount"] = resource.NewPropertyValue(
-							ds.Operation.OIDC.GCP.ServiceAccount,
-						)
-					}
-					if ds.Operation.OIDC.GCP.Region != "" {
-						gcpMap["region"] = resource.NewPropertyValue(ds.Operation.OIDC.GCP.Region)
-					}
-					if ds.Operation.OIDC.GCP.WorkloadPoolID != "" {
-						gcpMap["workloadPoolId"] = resource.NewPropertyValue(
-							ds.Operation.OIDC.GCP.WorkloadPoolID,
-						)
-					}
-					if ds.Operation.OIDC.GCP.ProjectID != "" {
-						gcpMap["projectId"] = resource.NewPropertyValue(ds.Operation.OIDC.GCP.ProjectID)
-					}
-					if ds.Operation.OIDC.GCP.TokenLifetime != "" {
-						gcpMap["tokenLifetime"] = resource.NewPropertyValue(ds.Operation.OIDC.GCP.TokenLifetime)
-					}
-					oidcMap["gcp"] = resource.PropertyValue{V: gcpMap}
-				}
-				if ds.Operation.OIDC.Azure != nil {
-					azureMap := resource.PropertyMap{}
-					if ds.Operation.OIDC.Azure.TenantID != "" {
-						azureMap["tenantId"] = resource.NewPropertyValue(ds.Operation.OIDC.Azure.TenantID)
-					}
-					if ds.Operation.OIDC.Azure.ClientID != "" {
-						azureMap["clientId"] = resource.NewPropertyValue(ds.Operation.OIDC.Azure.ClientID)
-					}
-					if ds.Operation.OIDC.Azure.SubscriptionID != "" {
-						azureMap["subscriptionId"] = resource.NewPropertyValue(
-							ds.Operation.OIDC.Azure.SubscriptionID,
-						)
-					}
-					oidcMap["azure"] = resource.PropertyValue{V: azureMap}
-				}
-				ocMap["oidc"] = resource.PropertyValue{V: oidcMap}
-			}
-		}
-		pm["operationContext"] = resource.PropertyValue{V: ocMap}
-	}
+type DeploymentSettings struct{}
 
-	if ds.GitHub != nil {
-		githubMap := resource.PropertyMap{}
-		githubMap["previewPullRequests"] = resource.NewPropertyValue(ds.GitHub.PreviewPullRequests)
-		githubMap["deployCommits"] = resource.NewPropertyValue(ds.GitHub.DeployCommits)
-		githubMap["pullRequestTemplate"] = resource.NewPropertyValue(ds.GitHub.PullRequestTemplate)
-		if ds.GitHub.Repository != "" {
-			githubMap["repository"] = resource.NewPropertyValue(ds.GitHub.Repository)
-		}
-		if len(ds.GitHub.Paths) > 0 {
-			githubMap["paths"] = resource.NewPropertyValue(ds.GitHub.Paths)
-		}
-		pm["github"] = resource.PropertyValue{V: githubMap}
-	}
-
-	if ds.Vcs != nil {
-		vcsMap := resource.PropertyMap{}
-		provider, _ := ds.Vcs.GetDiscriminatorValue()
-		vcsMap["provider"] = resource.NewPropertyValue(provider)
-		vcsMap["deployCommits"] = resource.NewPropertyValue(ds.Vcs.DeployCommits())
-		vcsMap["previewPullRequests"] = resource.NewPropertyValue(ds.Vcs.PreviewPullRequests())
-		vcsMap["pullRequestTemplate"] = resource.NewPropertyValue(ds.Vcs.PullRequestTemplate())
-		if ds.Vcs.Repository() != "" {
-			vcsMap["repository"] = resource.NewPropertyValue(ds.Vcs.Repository())
-		}
-		if ds.Vcs.InstallationID() != "" {
-			vcsMap["installationId"] = resource.NewPropertyValue(ds.Vcs.InstallationID())
-		}
-		if len(ds.Vcs.Paths()) > 0 {
-			vcsMap["paths"] = resource.NewPropertyValue(ds.Vcs.Paths())
-		}
-		if ds.Vcs.DeployPullRequest() != nil {
-			vcsMap["deployPullRequest"] = resource.NewPropertyValue(float64(*ds.Vcs.DeployPullRequest()))
-		}
-		pm["vcs"] = resource.PropertyValue{V: vcsMap}
-	}
-
-	if ds.Executor != nil && ds.Executor.ExecutorImage != nil &&
-		ds.Executor.ExecutorImage.Reference != "" {
-		ecMap := resource.PropertyMap{}
-		ecMap["executorImage"] = resource.NewPropertyValue(ds.Executor.ExecutorImage.Reference)
-		if ds.Executor.ExecutorImage.Credentials != nil {
-			credentialsPropertyMap := resource.PropertyMap{}
-			if ds.Executor.ExecutorImage.Credentials.Username != "" {
-				credentialsPropertyMap["username"] = resource.NewPropertyValue(
-					ds.Executor.ExecutorImage.Credentials.Username,
-				)
-			}
-			if ds.Executor.ExecutorImage.Credentials.Password.Value != "" {
-				if mergeMode {
-					var plaintextValue *pulumiapi.SecretValue
-					var currentCipherValue *pulumiapi.SecretValue
-					if executorImageCredentials(plaintextInputSettings) != nil &&
-						executorImageCredentials(currentStateCipherSettings) != nil {
-						plaintextValue = &executorImageCredentials(plaintextInputSettings).Password
-						currentCipherValue = &executorImageCredentials(currentStateCipherSettings).Password
-					}
-					util.MergeSecretValue(
-						credentialsPropertyMap,
-						"password",
-						ds.Executor.ExecutorImage.Credentials.Password,
-						plaintextValue,
-						currentCipherValue,
-						isInput,
-					)
-				} else if createMode && executorImageCredentials(plaintextInputSettings) != nil {
-					util.CreateSecretValue(credentialsPropertyMap, "password", ds.Executor.ExecutorImage.Credentials.Password,
-						executorImageCredentials(plaintextInputSettings).Password, isInput)
-				} else {
-					util.ImportSecretValue(
-						credentialsPropertyMap, "password", ds.Executor.ExecutorImage.Credentials.Password, isInput,
-					)
-				}
-			}
-			ecMap["credentials"] = resource.PropertyValue{V: credentialsPropertyMap}
-		}
-		pm["executorContext"] = resource.PropertyValue{V: ecMap}
-	}
+type (
+	dsIn  = DeploymentSettingsInput
+	dsOut = DeploymentSettingsState
+)
 
-	if ds.CacheOptions != nil {
-		coMap := resource.PropertyMap{}
-		coMap["enable"] = resource.NewPropertyValue(ds.CacheOptions.Enable)
-		pm["cacheOptions"] = resource.PropertyValue{V: coMap}
-	}
+var (
+	_ infer.CustomCheck[dsIn]         = &DeploymentSettings{}
+	_ infer.CustomDiff[dsIn, dsOut]   = &DeploymentSettings{}
+	_ infer.CustomCreate[dsIn, dsOut] = &DeploymentSettings{}
+	_ infer.CustomUpdate[dsIn, dsOut] = &DeploymentSettings{}
+	_ infer.CustomRead[dsIn, dsOut]   = &DeploymentSettings{}
+	_ infer.CustomDelete[dsOut]       = &DeploymentSettings{}
+)
 
-	return pm
+func (*DeploymentSettings) Annotate(a infer.Annotator) {
+	a.Describe(&DeploymentSettings{}, "Deployment settings configure Pulumi Deployments for a stack.\n\n"+
+		"### Import\n\n"+
+		"Deployment settings can be imported using the `id`, which for deployment settings is "+
+		"`{org}/{project}/{stack}` e.g.,\n\n"+
+		"```sh\n $ pulumi import pulumiservice:index:DeploymentSettings my_settings my-org/my-project/my-stack\n```\n\n")
+	a.SetToken("index", "DeploymentSettings")
 }
 
-type PulumiServiceDeploymentSettingsResource struct {
-	Client pulumiapi.DeploymentSettingsClient
+type DeploymentSettingsInput struct {
+	Organization     string                              `pulumi:"organization"              provider:"replaceOnChanges"`
+	Project          string                              `pulumi:"project"                   provider:"replaceOnChanges"`
+	Stack            string                              `pulumi:"stack"                     provider:"replaceOnChanges"`
+	ExecutorContext  *DeploymentSettingsExecutorContext  `pulumi:"executorContext,optional"`
+	SourceContext    *DeploymentSettingsSourceContext    `pulumi:"sourceContext,optional"`
+	GitHub           *DeploymentSettingsGithub           `pulumi:"github,optional"`
+	Vcs              *DeploymentSettingsVcs              `pulumi:"vcs,optional"`
+	OperationContext *DeploymentSettingsOperationContext `pulumi:"operationContext,optional"`
+	AgentPoolID      *string                             `pulumi:"agentPoolId,optional"`
+	CacheOptions     *DeploymentSettingsCacheOptions     `pulumi:"cacheOptions,optional"`
 }
 
-func (ds *PulumiServiceDeploymentSettingsResource) ToPulumiServiceDeploymentSettingsInput(
-	inputMap resource.PropertyMap,
-) PulumiServiceDeploymentSettingsInput {
-	input := PulumiServiceDeploymentSettingsInput{}
-
-	input.Stack.OrgName = util.GetSecretOrStringValue(inputMap[gcOrganization])
-	input.Stack.ProjectName = util.GetSecretOrStringValue(inputMap[gcProject])
-	input.Stack.StackName = util.GetSecretOrStringValue(inputMap[gcStack])
-
-	if inputMap["agentPoolId"].HasValue() {
-		value := util.GetSecretOrStringValue(inputMap["agentPoolId"])
-		input.AgentPoolID = &value
-	}
-
-	input.Executor = toExecutorContext(inputMap)
-	input.GitHub = toGitHubConfig(inputMap)
-	input.SourceContext = toSourceContext(inputMap)
-	input.Operation = toOperationContext(inputMap)
-	input.CacheOptions = toCacheOptions(inputMap)
-	input.Vcs = toVCSConfig(inputMap)
-
-	return input
+func (i *DeploymentSettingsInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.Organization, "Organization name.")
+	a.Describe(&i.Project, "Project name.")
+	a.Describe(&i.Stack, "Stack name.")
+	a.Describe(&i.ExecutorContext, "Settings related to the deployment executor.")
+	a.Describe(&i.SourceContext, "Settings related to the source of the deployment.")
+	a.Describe(&i.GitHub, "GitHub settings for the deployment.")
+	a.Deprecate(&i.GitHub, "Use the 'vcs' property instead, which supports both GitHub and Azure DevOps.")
+	a.Describe(&i.Vcs, "VCS settings for the deployment. Supports Azure DevOps and GitHub via the "+
+		"'provider' discriminator field.")
+	a.Describe(&i.OperationContext, "Settings related to the Pulumi operation environment during the deployment.")
+	a.Describe(&i.AgentPoolID, "The agent pool identifier to use for the deployment.")
+	a.Describe(&i.CacheOptions, "Dependency cache settings for the deployment")
 }
 
-// executorImageCredentials walks the executor image credentials out of a settings
-// struct, tolerating a nil at any level of the chain.
-func executorImageCredentials(settings *pulumiapi.DeploymentSettings) *pulumiapi.DockerImageCredentials {
-	if settings == nil || settings.Executor == nil || settings.Executor.ExecutorImage == nil {
-		return nil
-	}
-	return settings.Executor.ExecutorImage.Credentials
+// DeploymentSettingsState mirrors the inputs. Twin-value secrets, whose
+// plaintext Pulumi Cloud never hands back, hold what the service returned in
+// its place.
+type DeploymentSettingsState struct {
+	DeploymentSettingsInput
 }
 
-func toExecutorContext(inputMap resource.PropertyMap) *pulumiapi.ExecutorContext {
-	if !inputMap["executorContext"].HasValue() {
-		return nil
-	}
-
-	ecInput := util.GetSecretOrObjectValue(inputMap["executorContext"])
-	var ec pulumiapi.ExecutorContext
+type DeploymentSettingsExecutorContext struct {
+	ExecutorImage string                                      `pulumi:"executorImage"`
+	Credentials   *DeploymentSettingsExecutorImageCredentials `pulumi:"credentials,optional"`
+}
 
-	if ecInput["executorImage"].HasValue() {
-		ec.ExecutorImage = &pulumiapi.DockerImage{
-			Reference: util.GetSecretOrStringValue(ecInput["executorImage"]),
-		}
+func (c *DeploymentSettingsExecutorContext) Annotate(a infer.Annotator) {
+	a.Describe(c, "The executor context defines information about the executor where the deployment is executed. "+
+		"If unspecified, the default 'pulumi/pulumi' image is used.")
+	a.Describe(&c.ExecutorImage, "Allows overriding the default executor image with a custom image. "+
+		"E.g. 'pulumi/pulumi-nodejs:latest'")
+	a.Describe(&c.Credentials, "Credentials for pulling `executorImage` from a private container registry. "+
+		"Only needed when the image is not publicly accessible.")
+}
 
-		if ecInput["credentials"].HasValue() {
-			credentialsInput := util.GetSecretOrObjectValue(ecInput["credentials"])
-			var credentials pulumiapi.DockerImageCredentials
+type DeploymentSettingsExecutorImageCredentials struct {
+	Username string `pulumi:"username"`
+	Password string `pulumi:"password" provider:"secret"`
+}
 
-			if credentialsInput["username"].HasValue() {
-				credentials.Username = util.GetSecretOrStringValue(credentialsInput["username"])
-			}
-			if credentialsInput["password"].HasValue() {
-				// Secret must be set, otherwise Pulumi Cloud stores the password
-				// in plaintext: it only encrypts SecretValues flagged as secret.
-				credentials.Password = pulumiapi.SecretValue{
-					Secret: true,
-					Value:  util.GetSecretOrStringValue(credentialsInput["password"]),
-				}
-			}
+func (c *DeploymentSettingsExecutorImageCredentials) Annotate(a infer.Annotator) {
+	a.Describe(c, "Credentials for pulling the executor image from a private container registry.")
+	a.Describe(&c.Username, "Username for authenticating with the container registry.")
+	a.Describe(&c.Password, "Password or access token for authenticating with the container registry.")
+}
 
-			ec.ExecutorImage.Credentials = &credentials
-		}
-	}
+type DeploymentSettingsSourceContext struct {
+	Git *DeploymentSettingsGitSource `pulumi:"git,optional"`
+}
 
-	return &ec
+func (c *DeploymentSettingsSourceContext) Annotate(a infer.Annotator) {
+	a.Describe(c, "Settings related to the source of the deployment.")
+	a.Describe(&c.Git, "Git source settings for a deployment.")
 }
 
-func toGitHubConfig(inputMap resource.PropertyMap) *pulumiapi.DeploymentSettingsGitHub {
-	if !inputMap["github"].HasValue() {
-		return nil
-	}
+type DeploymentSettingsGitSource struct {
+	RepoURL *string                             `pulumi:"repoUrl,optional"`
+	GitAuth *DeploymentSettingsGitSourceGitAuth `pulumi:"gitAuth,optional"`
+	Branch  *string                             `pulumi:"branch,optional"`
+	Commit  *string                             `pulumi:"commit,optional"`
+	RepoDir *string                             `pulumi:"repoDir,optional"`
+}
 
-	githubInput := util.GetSecretOrObjectValue(inputMap["github"])
-	var github pulumiapi.DeploymentSettingsGitHub
+func (g *DeploymentSettingsGitSource) Annotate(a infer.Annotator) {
+	a.Describe(g, "Git source settings for a deployment.")
+	a.Describe(&g.RepoURL, "The repository URL to use for git settings. Should not be specified if there are "+
+		"`gitHub` settings for this deployment.")
+	a.Describe(&g.GitAuth, "Git authentication configuration for this deployment. Should not be specified if "+
+		"there are `gitHub` settings for this deployment.")
+	a.Describe(&g.Branch, "The branch to deploy. One of either `branch` or `commit` must be specified.")
+	a.Describe(&g.Commit, "The commit to deploy. One of either `branch` or `commit` must be specified.")
+	a.Describe(&g.RepoDir, "The directory within the repository where the Pulumi.yaml is located.")
+}
 
-	if githubInput["repository"].HasValue() {
-		github.Repository = util.GetSecretOrStringValue(githubInput["repository"])
-	}
+type DeploymentSettingsGitSourceGitAuth struct {
+	SSHAuth   *DeploymentSettingsGitAuthSSHAuth   `pulumi:"sshAuth,optional"`
+	BasicAuth *DeploymentSettingsGitAuthBasicAuth `pulumi:"basicAuth,optional"`
+}
 
-	if githubInput["deployCommits"].HasValue() {
-		github.DeployCommits = util.GetSecretOrBoolValue(githubInput["deployCommits"])
-	}
-	if githubInput["previewPullRequests"].HasValue() {
-		github.PreviewPullRequests = util.GetSecretOrBoolValue(githubInput["previewPullRequests"])
-	}
-	if githubInput["pullRequestTemplate"].HasValue() {
-		github.PullRequestTemplate = util.GetSecretOrBoolValue(githubInput["pullRequestTemplate"])
-	}
-	if githubInput["paths"].HasValue() {
-		pathsInput := util.GetSecretOrArrayValue(githubInput["paths"])
-		paths := make([]string, len(pathsInput))
+func (g *DeploymentSettingsGitSourceGitAuth) Annotate(a infer.Annotator) {
+	a.Describe(g, "Git source settings for a deployment.")
+	a.Describe(&g.SSHAuth, "SSH auth for git authentication. Only one of `personalAccessToken`, `sshAuth`, or "+
+		"`basicAuth` must be defined.")
+	a.Describe(&g.BasicAuth, "Basic auth for git authentication. Only one of `personalAccessToken`, `sshAuth`, or "+
+		"`basicAuth` must be defined.")
+}
 
-		for i, v := range pathsInput {
-			paths[i] = util.GetSecretOrStringValue(v)
-		}
+type DeploymentSettingsGitAuthSSHAuth struct {
+	SSHPrivateKey string  `pulumi:"sshPrivateKey"     provider:"secret"`
+	Password      *string `pulumi:"password,optional" provider:"secret"`
+}
 
-		github.Paths = paths
-	}
+func (s *DeploymentSettingsGitAuthSSHAuth) Annotate(a infer.Annotator) {
+	a.Describe(s, "Git source settings for a deployment.")
+	a.Describe(&s.SSHPrivateKey, "SSH private key.")
+	a.Describe(&s.Password, "Optional password for SSH authentication.")
+}
 
-	return &github
+type DeploymentSettingsGitAuthBasicAuth struct {
+	Username string `pulumi:"username" provider:"secret"`
+	Password string `pulumi:"password" provider:"secret"`
 }
 
-func toVCSConfig(inputMap resource.PropertyMap) pulumiapi.DeploymentSettingsVCS {
-	if !inputMap["vcs"].HasValue() {
-		return nil
-	}
+func (b *DeploymentSettingsGitAuthBasicAuth) Annotate(a infer.Annotator) {
+	a.Describe(b, "Git source settings for a deployment.")
+	a.Describe(&b.Username, "User name for git basic authentication.")
+	a.Describe(&b.Password, "Password for git basic authentication.")
+}
 
-	vcsInput := util.GetSecretOrObjectValue(inputMap["vcs"])
-	var vcs pulumiapi.DeploymentSettingsVCS
+type DeploymentSettingsGithub struct {
+	Repository          *string  `pulumi:"repository,optional"`
+	DeployCommits       *bool    `pulumi:"deployCommits,optional"`
+	PreviewPullRequests *bool    `pulumi:"previewPullRequests,optional"`
+	PullRequestTemplate *bool    `pulumi:"pullRequestTemplate,optional"`
+	Paths               []string `pulumi:"paths,optional"`
+}
 
-	if !vcsInput["provider"].HasValue() {
-		return nil
-	}
-	switch util.GetSecretOrStringValue(vcsInput["provider"]) {
-	case "azure_devops":
-		vcs = pulumiapi.DeploymentSettingsVCSAzureDevOpsBuilder{}.Build()
+func (g *DeploymentSettingsGithub) Annotate(a infer.Annotator) {
+	a.Describe(g, "GitHub settings for the deployment.")
+	a.Describe(&g.Repository, "The GitHub repository in the format org/repo.")
+	a.Describe(&g.DeployCommits, "Trigger a deployment running `pulumi up` on commit.")
+	a.SetDefault(&g.DeployCommits, true)
+	a.Describe(&g.PreviewPullRequests, "Trigger a deployment running `pulumi preview` when a PR is opened.")
+	a.SetDefault(&g.PreviewPullRequests, true)
+	a.Describe(&g.PullRequestTemplate, "Use this stack as a template for pull request review stacks.")
+	a.SetDefault(&g.PullRequestTemplate, false)
+	a.Describe(&g.Paths, "The paths within the repo that deployments should be filtered to.")
+}
 
-	case "bitbucket":
-		vcs = pulumiapi.DeploymentSettingsVCSBitbucketBuilder{}.Build()
+type DeploymentSettingsVcs struct {
+	Provider            string   `pulumi:"provider"`
+	Repository          *string  `pulumi:"repository,optional"`
+	InstallationID      *string  `pulumi:"installationId,optional"`
+	DeployCommits       *bool    `pulumi:"deployCommits,optional"`
+	PreviewPullRequests *bool    `pulumi:"previewPullRequests,optional"`
+	PullRequestTemplate *bool    `pulumi:"pullRequestTemplate,optional"`
+	Paths               []string `pulumi:"paths,optional"`
+	DeployPullRequest   *int     `pulumi:"deployPullRequest,optional"`
+}
 
-	case "custom":
-		vcs = pulumiapi.DeploymentSettingsVCSCustomBuilder{}.Build()
+func (v *DeploymentSettingsVcs) Annotate(a infer.Annotator) {
+	a.Describe(v, "VCS settings for the deployment, supporting multiple VCS providers.")
+	a.Describe(&v.Provider, "The VCS provider type: `azure_devops`, `github` or `gitlab`.")
+	a.Describe(&v.Repository, "The repository identifier (e.g., 'ProjectName/RepoName' for Azure DevOps, "+
+		"'org/repo' for GitHub).")
+	a.Describe(&v.InstallationID, "The VCS integration installation ID. Use to disambiguate when an organization "+
+		"has multiple integrations of the same provider type (e.g., two GitHub Apps). If omitted, the API resolves "+
+		"the integration automatically from `provider` and `repository`.")
+	a.Describe(&v.DeployCommits, "Trigger a deployment running `pulumi up` on commit.")
+	a.SetDefault(&v.DeployCommits, true)
+	a.Describe(&v.PreviewPullRequests, "Trigger a deployment running `pulumi preview` when a PR is opened.")
+	a.SetDefault(&v.PreviewPullRequests, true)
+	a.Describe(&v.PullRequestTemplate, "Use this stack as a template for pull request review stacks.")
+	a.SetDefault(&v.PullRequestTemplate, false)
+	a.Describe(&v.Paths, "The paths within the repo that deployments should be filtered to.")
+	a.Describe(&v.DeployPullRequest, "Deploy a specific pull request number.")
+}
 
-	case "github":
-		vcs = pulumiapi.DeploymentSettingsVCSGitHubBuilder{}.Build()
+type DeploymentSettingsOperationContext struct {
+	PreRunCommands       []string                 `pulumi:"preRunCommands,optional"`
+	EnvironmentVariables map[string]string        `pulumi:"environmentVariables,optional"`
+	Options              *OperationContextOptions `pulumi:"options,optional"`
+	OIDC                 *OperationContextOIDC    `pulumi:"oidc,optional"`
+}
 
-	case "gitlab":
-		vcs = pulumiapi.DeploymentSettingsVCSGitLabBuilder{}.Build()
+func (c *DeploymentSettingsOperationContext) Annotate(a infer.Annotator) {
+	a.Describe(c, "Settings related to the Pulumi operation environment during the deployment.")
+	a.Describe(&c.PreRunCommands, "Shell commands to run before the Pulumi operation executes.")
+	a.Describe(&c.EnvironmentVariables, "Environment variables to set for the deployment.")
+	a.Describe(&c.Options, "Options to override default behavior during the deployment.")
+	a.Describe(&c.OIDC, "OIDC configuration to use during the deployment.")
+}
 
-	default:
-		return nil
-	}
+type OperationContextOptions struct {
+	SkipInstallDependencies     *bool   `pulumi:"skipInstallDependencies,optional"`
+	SkipIntermediateDeployments *bool   `pulumi:"skipIntermediateDeployments,optional"`
+	Shell                       *string `pulumi:"shell,optional"`
+	DeleteAfterDestroy          *bool   `pulumi:"deleteAfterDestroy,optional"`
+}
 
-	if vcsInput["repository"].HasValue() {
-		_ = vcs.SetRepository(util.GetSecretOrStringValue(vcsInput["repository"]))
-	}
-	if vcsInput["installationId"].HasValue() {
-		_ = vcs.SetInstallationID(util.GetSecretOrStringValue(vcsInput["installationId"]))
-	}
-	if vcsInput["deployCommits"].HasValue() {
-		_ = vcs.SetDeployCommits(util.GetSecretOrBoolValue(vcsInput["deployCommits"]))
-	}
-	if vcsInput["previewPullRequests"].HasValue() {
-		_ = vcs.SetPreviewPullRequests(util.GetSecretOrBoolValue(vcsInput["previewPullRequests"]))
-	}
-	if vcsInput["pullRequestTemplate"].HasValue() {
-		_ = vcs.SetPullRequestTemplate(util.GetSecretOrBoolValue(vcsInput["pullRequestTemplate"]))
-	}
-	if vcsInput["paths"].HasValue() {
-		pathsInput := util.GetSecretOrArrayValue(vcsInput["paths"])
-		paths := make([]string, len(pathsInput))
-		for i, v := range pathsInput {
-			paths[i] = util.GetSecretOrStringValue(v)
-		}
-		_ = vcs.SetPaths(paths)
-	}
-	if vcsInput["deployPullRequest"].HasValue() {
-		val := int64(util.GetSecretOrNumberValue(vcsInput["deployPullRequest"]))
-		_ = vcs.SetDeployPullRequest(&val)
-	}
+func (o *OperationContextOptions) Annotate(a infer.Annotator) {
+	a.Describe(&o.SkipInstallDependencies, "Skip the default dependency installation step - use this to customize "+
+		"the dependency installation (e.g. if using yarn or poetry)")
+	a.Describe(&o.SkipIntermediateDeployments, "Skip intermediate deployments (Consolidate multiple deployments of "+
+		"the same type into one deployment)")
+	a.Describe(&o.Shell, "The shell to use to run commands during the deployment. Defaults to 'bash'.")
+	a.Describe(&o.DeleteAfterDestroy, "Whether the stack should be deleted after it is destroyed.")
+}
 
-	return vcs
+type OperationContextOIDC struct {
+	AWS   *AWSOIDCConfiguration   `pulumi:"aws,optional"`
+	GCP   *GCPOIDCConfiguration   `pulumi:"gcp,optional"`
+	Azure *AzureOIDCConfiguration `pulumi:"azure,optional"`
 }
 
-func toSourceContext(inputMap resource.PropertyMap) *pulumiapi.SourceContext {
-	if !inputMap["sourceContext"].HasValue() {
-		return nil
-	}
+func (o *OperationContextOIDC) Annotate(a infer.Annotator) {
+	a.Describe(&o.AWS, "AWS-specific OIDC configuration.")
+	a.Describe(&o.GCP, "GCP-specific OIDC configuration.")
+	a.Describe(&o.Azure, "Azure-specific OIDC configuration.")
+}
 
-	scInput := util.GetSecretOrObjectValue(inputMap["sourceContext"])
-	var sc pulumiapi.SourceContext
+type AWSOIDCConfiguration struct {
+	Duration    *string  `pulumi:"duration,optional"`
+	PolicyARNs  []string `pulumi:"policyARNs,optional"`
+	RoleARN     string   `pulumi:"roleARN"`
+	SessionName string   `pulumi:"sessionName"`
+}
 
-	if scInput["git"].HasValue() {
-		gitInput := util.GetSecretOrObjectValue(scInput["git"])
-		var g pulumiapi.SourceContextGit
+func (c *AWSOIDCConfiguration) Annotate(a infer.Annotator) {
+	a.Describe(&c.Duration, "Duration of the assume-role session in “XhYmZs” format")
+	a.Describe(&c.PolicyARNs, "Optional set of IAM policy ARNs that further restrict the assume-role session")
+	a.Describe(&c.RoleARN, "The ARN of the role to assume using the OIDC token.")
+	a.Describe(&c.SessionName, "The name of the assume-role session.")
+}
 
-		if gitInput["repoUrl"].HasValue() {
-			g.RepoURL = util.GetSecretOrStringValue(gitInput["repoUrl"])
-		}
-		if gitInput["branch"].HasValue() {
-			g.Branch = util.GetSecretOrStringValue(gitInput["branch"])
-		}
-		if gitInput["commit"].HasValue() {
-			g.Commit = util.GetSecretOrStringValue(gitInput["commit"])
-		}
-		if gitInput["repoDir"].HasValue() {
-			g.RepoDir = util.GetSecretOrStringValue(gitInput["repoDir"])
-		}
+type GCPOIDCConfiguration struct {
+	ProjectID      string  `pulumi:"projectId"`
+	Region         *string `pulumi:"region,optional"`
+	WorkloadPoolID string  `pulumi:"workloadPoolId"`
+	ProviderID     string  `pulumi:"providerId"`
+	ServiceAccount string  `pulumi:"serviceAccount"`
+	TokenLifetime  *string `pulumi:"tokenLifetime,optional"`
+}
 
-		if gitInput["gitAuth"].HasValue() {
-			authInput := util.GetSecretOrObjectValue(gitInput["gitAuth"])
-			var a pulumiapi.GitAuthConfig
+func (c *GCPOIDCConfiguration) Annotate(a infer.Annotator) {
+	a.Describe(&c.ProjectID, "The numerical ID of the GCP project.")
+	a.Describe(&c.Region, "The region of the GCP project.")
+	a.Describe(&c.WorkloadPoolID, "The ID of the workload pool to use.")
+	a.Describe(&c.ProviderID, "The ID of the identity provider associated with the workload pool.")
+	a.Describe(&c.ServiceAccount, "The email address of the service account to use.")
+	a.Describe(&c.TokenLifetime, "The lifetime of the temporary credentials in “XhYmZs” format.")
+}
 
-			if authInput["sshAuth"].HasValue() {
-				sshInput := util.GetSecretOrObjectValue(authInput["sshAuth"])
-				var s pulumiapi.SSHAuth
+type AzureOIDCConfiguration struct {
+	ClientID       string `pulumi:"clientId"`
+	TenantID       string `pulumi:"tenantId"`
+	SubscriptionID string `pulumi:"subscriptionId"`
+}
 
-				if sshInput["sshPrivateKey"].HasValue() || sshInput["sshPrivateKeyCipher"].HasValue() {
-					s.SSHPrivateKey = pulumiapi.SecretValue{
-						Secret: true,
-						Value:  util.GetSecretOrStringValue(sshInput["sshPrivateKey"]),
-					}
-				}
-				if sshInput["password"].HasValue() || sshInput["passwordCipher"].HasValue() {
-					s.Password = &pulumiapi.SecretValue{
-						Secret: true,
-						Value:  util.GetSecretOrStringValue(sshInput["password"]),
-					}
-				}
+func (c *AzureOIDCConfiguration) Annotate(a infer.Annotator) {
+	a.Describe(&c.ClientID, "The client ID of the federated workload identity.")
+	a.Describe(&c.TenantID, "The tenant ID of the federated workload identity.")
+	a.Describe(&c.SubscriptionID, "The subscription ID of the federated workload identity.")
+}
 
-				a.SSHAuth = &s
-			}
+type DeploymentSettingsCacheOptions struct {
+	Enable *bool `pulumi:"enable,optional"`
+}
 
-			if authInput["basicAuth"].HasValue() {
-				basicInput := util.GetSecretOrObjectValue(authInput["basicAuth"])
-				var b pulumiapi.BasicAuth
+func (c *DeploymentSettingsCacheOptions) Annotate(a infer.Annotator) {
+	a.Describe(c, "Dependency cache settings for the deployment")
+	a.Describe(&c.Enable, "Enable dependency caching")
+	a.SetDefault(&c.Enable, false)
+}
 
-				if basicInput["username"].HasValue() || basicInput["usernameCipher"].HasValue() {
-					// Secret must be set, otherwise Pulumi Cloud stores the value in
-					// plaintext: it only encrypts SecretValues flagged as secret.
-					b.UserName = pulumiapi.SecretValue{
-						Value:  util.GetSecretOrStringValue(basicInput["username"]),
-						Secret: true,
-					}
-				}
-				if basicInput["password"].HasValue() || basicInput["passwordCipher"].HasValue() {
-					b.Password = pulumiapi.SecretValue{
-						Value:  util.GetSecretOrStringValue(basicInput["password"]),
-						Secret: true,
-					}
-				}
+func (i *DeploymentSettingsInput) id() string {
+	return path.Join(i.Organization, i.Project, i.Stack)
+}
 
-				a.BasicAuth = &b
-			}
+func (i *DeploymentSettingsInput) stackIdentifier() pulumiapi.StackIdentifier {
+	return pulumiapi.StackIdentifier{OrgName: i.Organization, ProjectName: i.Project, StackName: i.Stack}
+}
 
-			g.GitAuth = &a
+func (*DeploymentSettings) Check(
+	ctx context.Context, req infer.CheckRequest,
+) (infer.CheckResponse[dsIn], error) {
+	var failures []p.CheckFailure
+	// Normalizing duration input
+	inputs := alterPath(req.NewInputs, func(duration property.Value) property.Value {
+		if !duration.IsString() {
+			return duration
+		}
+		normalized, err := normalizeDurationString(duration.AsString())
+		if err != nil {
+			failures = append(failures, p.CheckFailure{
+				Reason:   fmt.Sprintf("Failed to normalize duration string due to error: %s", err.Error()),
+				Property: "operationContext.oidc.aws.duration",
+			})
+			return duration
 		}
+		return property.WithGoValue(duration, *normalized)
+	}, gcOperationContext, "oidc", gcAWS, "duration")
 
-		sc.Git = &g
+	i, checkFailures, err := infer.DefaultCheck[dsIn](ctx, inputs)
+	if err != nil {
+		return infer.CheckResponse[dsIn]{}, err
 	}
-
-	return &sc
+	return infer.CheckResponse[dsIn]{Inputs: i, Failures: append(checkFailures, failures...)}, nil
 }
 
-func toOperationContext(inputMap resource.PropertyMap) *pulumiapi.OperationContext {
-	if !inputMap["operationContext"].HasValue() {
-		return nil
+// alterPath applies f to the value at path within m. Every enclosing value
+// keeps its secret and dependency markers; an absent path leaves m as is.
+func alterPath(m property.Map, f func(property.Value) property.Value, path ...string) property.Map {
+	v, ok := m.GetOk(path[0])
+	if !ok {
+		return m
 	}
-
-	ocInput := util.GetSecretOrObjectValue(inputMap["operationContext"])
-	cascadeSecret := inputMap["operationContext"].IsSecret()
-	var oc pulumiapi.OperationContext
-
-	if ocInput["environmentVariables"].HasValue() {
-		ev := map[string]pulumiapi.SecretValue{}
-		evInput := util.GetSecretOrObjectValue(ocInput["environmentVariables"])
-		cascadeSecret = cascadeSecret || ocInput["environmentVariables"].IsSecret()
-
-		for k, v := range evInput {
-			value := util.GetSecretOrStringValue(v)
-			ev[string(k)] = pulumiapi.SecretValue{Secret: v.IsSecret() || cascadeSecret, Value: value}
-		}
-
-		oc.EnvironmentVariables = ev
+	if len(path) == 1 {
+		return m.Set(path[0], f(v))
 	}
-
-	if ocInput["preRunCommands"].HasValue() {
-		pcInput := util.GetSecretOrArrayValue(ocInput["preRunCommands"])
-		pc := make([]string, len(pcInput))
-
-		for i, v := range pcInput {
-			pc[i] = util.GetSecretOrStringValue(v)
-		}
-
-		oc.PreRunCommands = pc
+	if !v.IsMap() {
+		return m
 	}
+	return m.Set(path[0], property.WithGoValue(v, alterPath(v.AsMap(), f, path[1:]...)))
+}
 
-	if ocInput["options"].HasValue() {
-		oInput := util.GetSecretOrObjectValue(ocInput["options"])
-		var o pulumiapi.OperationContextOptions
-
-		if oInput["skipInstallDependencies"].HasValue() {
-			o.SkipInstallDependencies = util.GetSecretOrBoolValue(oInput["skipInstallDependencies"])
-		}
-
-		if oInput["skipIntermediateDeployments"].HasValue() {
-			o.SkipIntermediateDeployments = util.GetSecretOrBoolValue(oInput["skipIntermediateDeployments"])
-		}
-
-		if oInput["Shell"].HasValue() {
-			o.Shell = util.GetSecretOrStringValue(oInput["Shell"])
-		}
-
-		if oInput["deleteAfterDestroy"].HasValue() {
-			o.DeleteAfterDestroy = util.GetSecretOrBoolValue(oInput["deleteAfterDestroy"])
-		}
-
-		oc.Options = &o
+// Diff compares the old and new inputs: the state holds what Pulumi Cloud
+// returned for twin-value secrets, so it can't stand in for the old inputs.
+func (*DeploymentSettings) Diff(
+	ctx context.Context, req infer.DiffRequest[dsIn, dsOut],
+) (infer.DiffResponse, error) {
+	olds, news, ok := rawInputs(ctx)
+	if !ok {
+		return infer.DiffResponse{}, fmt.Errorf("diffing deployment settings %q: inputs are unavailable", req.ID)
 	}
 
-	if ocInput["oidc"].HasValue() {
-		oidcInput := util.GetSecretOrObjectValue(ocInput["oidc"])
-		var oidc pulumiapi.OperationContextOIDCConfiguration
-
-		if oidcInput[gcAWS].HasValue() {
-			awsInput := util.GetSecretOrObjectValue(oidcInput[gcAWS])
-			var aws pulumiapi.OperationContextAWSOIDCConfiguration
-
-			if awsInput["roleARN"].HasValue() {
-				aws.RoleARN = util.GetSecretOrStringValue(awsInput["roleARN"])
-			}
-			if awsInput["duration"].HasValue() {
-				aws.Duration = util.GetSecretOrStringValue(awsInput["duration"])
-			}
-			if awsInput["sessionName"].HasValue() {
-				aws.SessionName = util.GetSecretOrStringValue(awsInput["sessionName"])
-			}
-			if awsInput["policyARNs"].HasValue() {
-				policyARNsInput := util.GetSecretOrArrayValue(awsInput["policyARNs"])
-				policyARNs := make([]string, len(policyARNsInput))
-
-				for i, v := range policyARNsInput {
-					policyARNs[i] = util.GetSecretOrStringValue(v)
-				}
-
-				aws.PolicyARNs = policyARNs
-			}
-
-			oidc.AWS = &aws
-		}
-
-		if oidcInput["gcp"].HasValue() {
-			gcpInput := util.GetSecretOrObjectValue(oidcInput["gcp"])
-			var gcp pulumiapi.OperationContextGCPOIDCConfiguration
-
-			if gcpInput["projectId"].HasValue() {
-				gcp.ProjectID = util.GetSecretOrStringValue(gcpInput["projectId"])
-			}
-			if gcpInput["region"].HasValue() {
-				gcp.Region = util.GetSecretOrStringValue(gcpInput["region"])
-			}
-			if gcpInput["workloadPoolId"].HasValue() {
-				gcp.WorkloadPoolID = util.GetSecretOrStringValue(gcpInput["workloadPoolId"])
-			}
-			if gcpInput["providerId"].HasValue() {
-				gcp.ProviderID = util.GetSecretOrStringValue(gcpInput["providerId"])
-			}
-			if gcpInput["serviceAccount"].HasValue() {
-				gcp.ServiceAccount = util.GetSecretOrStringValue(gcpInput["serviceAccount"])
-			}
-			if gcpInput["tokenLifetime"].HasValue() {
-				gcp.TokenLifetime = util.GetSecretOrStringValue(gcpInput["tokenLifetime"])
-			}
+	objDiff := resource.ToResourcePropertyValue(property.New(olds)).ObjectValue().Diff(
+		resource.ToResourcePropertyValue(property.New(news)).ObjectValue(),
+	)
+	if objDiff == nil {
+		return infer.DiffResponse{}, nil
+	}
 
-			oidc.GCP = &gcp
+	replaceProperties := map[string]bool{
+		gcOrganization: true,
+		gcProject:      true,
+		gcStack:        true,
+	}
+	diff := map[string]p.PropertyDiff{}
+	for k, v := range plugin.NewDetailedDiffFromObjectDiff(objDiff, false) {
+		if replaceProperties[k] {
+			v.Kind = v.Kind.AsReplace()
 		}
+		diff[k] = p.PropertyDiff{Kind: diffKind(v.Kind), InputDiff: v.InputDiff}
+	}
 
-		if oidcInput["azure"].HasValue() {
-			azureInput := util.GetSecretOrObjectValue(oidcInput["azure"])
-			var azure pulumiapi.OperationContextAzureOIDCConfiguration
+	return infer.DiffResponse{
+		HasChanges:          true,
+		DetailedDiff:        diff,
+		DeleteBeforeReplace: true,
+	}, nil
+}
 
-			if azureInput["tenantId"].HasValue() {
-				azure.TenantID = util.GetSecretOrStringValue(azureInput["tenantId"])
-			}
-			if azureInput["clientId"].HasValue() {
-				azure.ClientID = util.GetSecretOrStringValue(azureInput["clientId"])
-			}
-			if azureInput["subscriptionId"].HasValue() {
-				azure.SubscriptionID = util.GetSecretOrStringValue(azureInput["subscriptionId"])
-			}
+func diffKind(kind plugin.DiffKind) p.DiffKind {
+	switch kind {
+	case plugin.DiffAdd:
+		return p.Add
+	case plugin.DiffAddReplace:
+		return p.AddReplace
+	case plugin.DiffDelete:
+		return p.Delete
+	case plugin.DiffDeleteReplace:
+		return p.DeleteReplace
+	case plugin.DiffUpdateReplace:
+		return p.UpdateReplace
+	default:
+		return p.Update
+	}
+}
 
-			oidc.Azure = &azure
-		}
+func (*DeploymentSettings) Create(
+	ctx context.Context, req infer.CreateRequest[dsIn],
+) (infer.CreateResponse[dsOut], error) {
+	if req.DryRun {
+		return infer.CreateResponse[dsOut]{
+			ID:     req.Inputs.id(),
+			Output: DeploymentSettingsState{DeploymentSettingsInput: req.Inputs},
+		}, nil
+	}
 
-		oc.OIDC = &oidc
+	stack := req.Inputs.stackIdentifier()
+	settings := req.Inputs.toPulumiServiceDeploymentSettings(secretEnvironmentVariables(ctx))
+	response, err := config.GetClient(ctx).CreateDeploymentSettings(ctx, stack, settings)
+	if err != nil {
+		return infer.CreateResponse[dsOut]{}, err
 	}
 
-	return &oc
+	return infer.CreateResponse[dsOut]{
+		ID:     req.Inputs.id(),
+		Output: newDeploymentSettingsState(stack, response),
+	}, nil
 }
 
-func toCacheOptions(inputMap resource.PropertyMap) *pulumiapi.CacheOptions {
-	if !inputMap["cacheOptions"].HasValue() {
-		return nil
+func (*DeploymentSettings) Update(
+	ctx context.Context, req infer.UpdateRequest[dsIn, dsOut],
+) (infer.UpdateResponse[dsOut], error) {
+	if req.DryRun {
+		return infer.UpdateResponse[dsOut]{
+			Output: DeploymentSettingsState{DeploymentSettingsInput: req.Inputs},
+		}, nil
 	}
 
-	coInput := util.GetSecretOrObjectValue(inputMap["cacheOptions"])
-	var co pulumiapi.CacheOptions
-
-	if coInput["enable"].HasValue() {
-		co.Enable = util.GetSecretOrBoolValue(coInput["enable"])
+	stack := req.Inputs.stackIdentifier()
+	settings := req.Inputs.toPulumiServiceDeploymentSettings(secretEnvironmentVariables(ctx))
+	response, err := config.GetClient(ctx).UpdateDeploymentSettings(ctx, stack, settings)
+	if err != nil {
+		return infer.UpdateResponse[dsOut]{}, err
 	}
 
-	return &co
+	return infer.UpdateResponse[dsOut]{Output: newDeploymentSettingsState(stack, response)}, nil
 }
 
-func (ds *PulumiServiceDeploymentSettingsResource) Diff(req *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error) {
-	olds, err := plugin.UnmarshalProperties(req.GetOldInputs(), util.StandardUnmarshal)
+func (*DeploymentSettings) Read(
+	ctx context.Context, req infer.ReadRequest[dsIn, dsOut],
+) (infer.ReadResponse[dsIn, dsOut], error) {
+	stack, err := pulumiapi.NewStackIdentifier(req.ID)
 	if err != nil {
-		return nil, err
+		return infer.ReadResponse[dsIn, dsOut]{}, err
 	}
-
-	news, err := plugin.UnmarshalProperties(req.GetNews(), util.StandardUnmarshal)
+	settings, err := config.GetClient(ctx).GetDeploymentSettings(ctx, stack)
 	if err != nil {
-		return nil, err
+		return infer.ReadResponse[dsIn, dsOut]{}, err
 	}
-
-	diffs := olds.Diff(news)
-	if diffs == nil {
-		return &pulumirpc.DiffResponse{
-			Changes: pulumirpc.DiffResponse_DIFF_NONE,
-		}, nil
+	if settings == nil {
+		// Empty response causes the resource to be deleted from the state.
+		return infer.ReadResponse[dsIn, dsOut]{}, nil
 	}
 
-	dd := plugin.NewDetailedDiffFromObjectDiff(diffs, false)
-
-	detailedDiffs := map[string]*pulumirpc.PropertyDiff{}
-	replaces := []string(nil)
-	replaceProperties := map[string]bool{
-		gcOrganization: true,
-		gcProject:      true,
-		gcStack:        true,
-	}
-	for k, v := range dd {
-		if _, ok := replaceProperties[k]; ok {
-			v.Kind = v.Kind.AsReplace()
-			replaces = append(replaces, k)
-		}
-		detailedDiffs[k] = &pulumirpc.PropertyDiff{
-			Kind:      pulumirpc.PropertyDiff_Kind(v.Kind), //nolint:gosec // safe conversion from plugin.DiffKind
-			InputDiff: v.InputDiff,
-		}
+	state := newDeploymentSettingsState(stack, settings)
+	// Prior state means a refresh, whose inputs carry the plaintext forward;
+	// without it the resource is being imported.
+	twins := twinSecrets{}
+	if req.State.Stack != "" {
+		twins = twinSecrets{plaintext: &req.Inputs, prior: &req.State.DeploymentSettingsInput}
 	}
 
-	return &pulumirpc.DiffResponse{
-		Changes:             pulumirpc.DiffResponse_DIFF_SOME,
-		Replaces:            replaces,
-		DetailedDiff:        detailedDiffs,
-		HasDetailedDiff:     true,
-		DeleteBeforeReplace: true,
+	return infer.ReadResponse[dsIn, dsOut]{
+		ID:     req.ID,
+		Inputs: twins.inputs(state, settings),
+		State:  state,
 	}, nil
 }
 
-func (ds *PulumiServiceDeploymentSettingsResource) Check(
-	req *pulumirpc.CheckRequest,
-) (*pulumirpc.CheckResponse, error) {
-	news, err := plugin.UnmarshalProperties(req.GetNews(), util.KeepSecretsUnmarshal)
+func (*DeploymentSettings) Delete(ctx context.Context, req infer.DeleteRequest[dsOut]) (infer.DeleteResponse, error) {
+	stack, err := pulumiapi.NewStackIdentifier(req.ID)
 	if err != nil {
-		return nil, err
+		return infer.DeleteResponse{}, err
 	}
+	return infer.DeleteResponse{}, config.GetClient(ctx).DeleteDeploymentSettings(ctx, stack)
+}
 
-	var failures []*pulumirpc.CheckFailure
-	for _, p := range []resource.PropertyKey{gcOrganization, gcProject, gcStack} {
-		if !news[(p)].HasValue() {
-			failures = append(failures, &pulumirpc.CheckFailure{
-				Reason:   fmt.Sprintf("missing required property '%s'", p),
-				Property: string(p),
-			})
-		}
+// secretEnvironmentVariables reports which environment variables the program
+// marked secret, either directly or through an enclosing secret value.
+func secretEnvironmentVariables(ctx context.Context) func(name string) bool {
+	_, news, _ := rawInputs(ctx)
+	operationContext := news.Get(gcOperationContext)
+	if !operationContext.IsMap() {
+		return func(string) bool { return false }
+	}
+	variables := operationContext.AsMap().Get(gcEnvironmentVars)
+	cascade := operationContext.Secret() || variables.Secret()
+	return func(name string) bool {
+		return cascade || (variables.IsMap() && variables.AsMap().Get(name).Secret())
 	}
+}
 
-	// Normalizing duration input
-	if news["operationContext"].HasValue() {
-		operationContext := util.GetSecretOrObjectValue(news["operationContext"])
-		if operationContext["oidc"].HasValue() {
-			oidc := util.GetSecretOrObjectValue(operationContext["oidc"])
-			if oidc[gcAWS].HasValue() {
-				aws := util.GetSecretOrObjectValue(oidc[gcAWS])
-				if aws["duration"].HasValue() {
-					durationString := util.GetSecretOrStringValue(aws["duration"])
-					normalized, err := normalizeDurationString(durationString)
-					if err != nil {
-						failures = append(failures, &pulumirpc.CheckFailure{
-							Reason:   fmt.Sprintf("Failed to normalize duration string due to error: %s", err.Error()),
-							Property: string("operationContext.oidc.aws.duration"),
-						})
-					} else {
-						if aws["duration"].IsSecret() {
-							aws["duration"] = resource.MakeSecret(resource.NewStringProperty(*normalized))
-						} else {
-							aws["duration"] = resource.NewStringProperty(*normalized)
-						}
-					}
-				}
+// toPulumiServiceDeploymentSettings builds the Pulumi Cloud request body.
+// Credentials are always sent with Secret set, otherwise Pulumi Cloud stores
+// them in plaintext: it only encrypts SecretValues flagged as secret.
+func (i *DeploymentSettingsInput) toPulumiServiceDeploymentSettings(
+	secretVariable func(name string) bool,
+) pulumiapi.DeploymentSettings {
+	settings := pulumiapi.DeploymentSettings{AgentPoolID: i.AgentPoolID}
+
+	if ec := i.ExecutorContext; ec != nil {
+		image := &pulumiapi.DockerImage{Reference: ec.ExecutorImage}
+		if c := ec.Credentials; c != nil {
+			image.Credentials = &pulumiapi.DockerImageCredentials{
+				Username: c.Username,
+				Password: pulumiapi.SecretValue{Secret: true, Value: c.Password},
 			}
 		}
+		settings.Executor = &pulumiapi.ExecutorContext{ExecutorImage: image}
 	}
 
-	// Force the nested credentials to be secret. Their `"secret": true` in the
-	// schema is codegen-only, and for a property nested inside a type only the
-	// .NET SDK acts on it — so in every other language a program passing a
-	// plaintext literal would have that literal written to state verbatim. See
-	// util.MakeNestedSecret.
-	for _, path := range [][]resource.PropertyKey{
-		{gcSourceContext, gcGit, gcGitAuth, gcSSHAuth, gcSSHPrivateKey},
-		{gcSourceContext, gcGit, gcGitAuth, gcSSHAuth, gcPassword},
-		{gcSourceContext, gcGit, gcGitAuth, gcBasicAuth, gcUsername},
-		{gcSourceContext, gcGit, gcGitAuth, gcBasicAuth, gcPassword},
-		{gcExecutorContext, gcCredentials, gcPassword},
-	} {
-		util.MakeNestedSecret(news, path...)
+	if gh := i.GitHub; gh != nil {
+		settings.GitHub = &pulumiapi.DeploymentSettingsGitHub{
+			Repository:          util.OrZero(gh.Repository),
+			DeployCommits:       util.OrZero(gh.DeployCommits),
+			PreviewPullRequests: util.OrZero(gh.PreviewPullRequests),
+			PullRequestTemplate: util.OrZero(gh.PullRequestTemplate),
+			Paths:               gh.Paths,
+		}
 	}
 
-	checkedNews, err := plugin.MarshalProperties(news, util.StandardMarshal)
-	if err != nil {
-		return nil, err
+	if i.Vcs != nil {
+		settings.Vcs = i.Vcs.toPulumiServiceVCS()
 	}
 
-	return &pulumirpc.CheckResponse{Inputs: checkedNews, Failures: failures}, nil
-}
-
-func (ds *PulumiServiceDeploymentSettingsResource) Read(req *pulumirpc.ReadRequest) (*pulumirpc.ReadResponse, error) {
-	ctx := context.Background()
-
-	stack, err := pulumiapi.NewStackIdentifier(req.GetId())
-	if err != nil {
-		return nil, err
-	}
-	settings, err := ds.Client.GetDeploymentSettings(ctx, stack)
-	if err != nil {
-		return nil, err
+	if sc := i.SourceContext; sc != nil {
+		settings.SourceContext = &pulumiapi.SourceContext{}
+		if g := sc.Git; g != nil {
+			git := &pulumiapi.SourceContextGit{
+				RepoURL: util.OrZero(g.RepoURL),
+				Branch:  util.OrZero(g.Branch),
+				Commit:  util.OrZero(g.Commit),
+				RepoDir: util.OrZero(g.RepoDir),
+			}
+			if auth := g.GitAuth; auth != nil {
+				git.GitAuth = &pulumiapi.GitAuthConfig{}
+				if ssh := auth.SSHAuth; ssh != nil {
+					git.GitAuth.SSHAuth = &pulumiapi.SSHAuth{
+						SSHPrivateKey: pulumiapi.SecretValue{Secret: true, Value: ssh.SSHPrivateKey},
+					}
+					if ssh.Password != nil {
+						git.GitAuth.SSHAuth.Password = &pulumiapi.SecretValue{Secret: true, Value: *ssh.Password}
+					}
+				}
+				if basic := auth.BasicAuth; basic != nil {
+					git.GitAuth.BasicAuth = &pulumiapi.BasicAuth{
+						UserName: pulumiapi.SecretValue{Secret: true, Value: basic.Username},
+						Password: pulumiapi.SecretValue{Secret: true, Value: basic.Password},
+					}
+				}
+			}
+			settings.SourceContext.Git = git
+		}
 	}
 
-	if settings == nil {
-		// Empty response causes the resource to be deleted from the state.
-		return &pulumirpc.ReadResponse{Id: "", Properties: nil}, nil
+	if i.OperationContext != nil {
+		settings.Operation = i.OperationContext.toPulumiServiceOperationContext(secretVariable)
 	}
 
-	dsInput := PulumiServiceDeploymentSettingsInput{
-		Stack:              stack,
-		DeploymentSettings: *settings,
+	if co := i.CacheOptions; co != nil {
+		settings.CacheOptions = &pulumiapi.CacheOptions{Enable: util.OrZero(co.Enable)}
 	}
 
-	var plaintextSettings *pulumiapi.DeploymentSettings
-	var ciphertextSettings *pulumiapi.DeploymentSettings
-	propertyMap, err := plugin.UnmarshalProperties(req.GetProperties(), util.KeepSecretsUnmarshal)
-	if err != nil {
-		return nil, err
-	}
-	inputMap, err := plugin.UnmarshalProperties(req.GetInputs(), util.KeepSecretsUnmarshal)
-	if err != nil {
-		return nil, err
-	}
-	if propertyMap[gcStack].HasValue() {
-		tempPlain := ds.ToPulumiServiceDeploymentSettingsInput(inputMap)
-		plaintextSettings = &tempPlain.DeploymentSettings
-		tempCipher := ds.ToPulumiServiceDeploymentSettingsInput(propertyMap)
-		ciphertextSettings = &tempCipher.DeploymentSettings
-	}
+	return settings
+}
 
-	properties, err := plugin.MarshalProperties(
-		dsInput.ToPropertyMap(plaintextSettings, ciphertextSettings, false), util.StandardMarshal,
-	)
-	if err != nil {
-		return nil, err
+// toPulumiServiceOperationContext builds the operation context of deployment
+// settings or of a single deployment run.
+func (oc *DeploymentSettingsOperationContext) toPulumiServiceOperationContext(
+	secretVariable func(name string) bool,
+) *pulumiapi.OperationContext {
+	operation := &pulumiapi.OperationContext{PreRunCommands: oc.PreRunCommands}
+	if oc.EnvironmentVariables != nil {
+		operation.EnvironmentVariables = map[string]pulumiapi.SecretValue{}
+		for k, v := range oc.EnvironmentVariables {
+			operation.EnvironmentVariables[k] = pulumiapi.SecretValue{Secret: secretVariable(k), Value: v}
+		}
+	}
+	if o := oc.Options; o != nil {
+		operation.Options = &pulumiapi.OperationContextOptions{
+			SkipInstallDependencies:     util.OrZero(o.SkipInstallDependencies),
+			SkipIntermediateDeployments: util.OrZero(o.SkipIntermediateDeployments),
+			Shell:                       util.OrZero(o.Shell),
+			DeleteAfterDestroy:          util.OrZero(o.DeleteAfterDestroy),
+		}
+	}
+	if oidc := oc.OIDC; oidc != nil {
+		operation.OIDC = &pulumiapi.OperationContextOIDCConfiguration{}
+		if aws := oidc.AWS; aws != nil {
+			operation.OIDC.AWS = &pulumiapi.OperationContextAWSOIDCConfiguration{
+				Duration:    util.OrZero(aws.Duration),
+				PolicyARNs:  aws.PolicyARNs,
+				RoleARN:     aws.RoleARN,
+				SessionName: aws.SessionName,
+			}
+		}
+		if gcp := oidc.GCP; gcp != nil {
+			operation.OIDC.GCP = &pulumiapi.OperationContextGCPOIDCConfiguration{
+				ProjectID:      gcp.ProjectID,
+				Region:         util.OrZero(gcp.Region),
+				WorkloadPoolID: gcp.WorkloadPoolID,
+				ProviderID:     gcp.ProviderID,
+				ServiceAccount: gcp.ServiceAccount,
+				TokenLifetime:  util.OrZero(gcp.TokenLifetime),
+			}
+		}
+		if azure := oidc.Azure; azure != nil {
+			operation.OIDC.Azure = &pulumiapi.OperationContextAzureOIDCConfiguration{
+				ClientID:       azure.ClientID,
+				TenantID:       azure.TenantID,
+				SubscriptionID: azure.SubscriptionID,
+			}
+		}
 	}
+	return operation
+}
 
-	inputs, err := plugin.MarshalProperties(
-		dsInput.ToPropertyMap(plaintextSettings, ciphertextSettings, true), util.StandardMarshal,
-	)
-	if err != nil {
-		return nil, err
+// toPulumiServiceVCS builds the VCS settings for the provider named by the
+// discriminator, or nil for a provider Pulumi Cloud doesn't know.
+func (v *DeploymentSettingsVcs) toPulumiServiceVCS() pulumiapi.DeploymentSettingsVCS {
+	var vcs pulumiapi.DeploymentSettingsVCS
+	switch v.Provider {
+	case "azure_devops":
+		vcs = pulumiapi.DeploymentSettingsVCSAzureDevOpsBuilder{}.Build()
+	case "bitbucket":
+		vcs = pulumiapi.DeploymentSettingsVCSBitbucketBuilder{}.Build()
+	case "custom":
+		vcs = pulumiapi.DeploymentSettingsVCSCustomBuilder{}.Build()
+	case "github":
+		vcs = pulumiapi.DeploymentSettingsVCSGitHubBuilder{}.Build()
+	case "gitlab":
+		vcs = pulumiapi.DeploymentSettingsVCSGitLabBuilder{}.Build()
+	default:
+		return nil
 	}
 
-	return &pulumirpc.ReadResponse{
-		Id:         req.Id,
-		Properties: properties,
-		Inputs:     inputs,
-	}, nil
+	_ = vcs.SetRepository(util.OrZero(v.Repository))
+	_ = vcs.SetInstallationID(util.OrZero(v.InstallationID))
+	_ = vcs.SetDeployCommits(util.OrZero(v.DeployCommits))
+	_ = vcs.SetPreviewPullRequests(util.OrZero(v.PreviewPullRequests))
+	_ = vcs.SetPullRequestTemplate(util.OrZero(v.PullRequestTemplate))
+	if v.Paths != nil {
+		_ = vcs.SetPaths(v.Paths)
+	}
+	if v.DeployPullRequest != nil {
+		deployPullRequest := int64(*v.DeployPullRequest)
+		_ = vcs.SetDeployPullRequest(&deployPullRequest)
+	}
+	return vcs
 }
 
-func (ds *PulumiServiceDeploymentSettingsResource) Delete(req *pulumirpc.DeleteRequest) (*pbempty.Empty, error) {
-	ctx := context.Background()
-	stack, err := pulumiapi.NewStackIdentifier(req.GetId())
-	if err != nil {
-		return nil, err
+// newDeploymentSettingsState renders settings returned by Pulumi Cloud. Values
+// the service leaves empty are omitted, and twin-value secrets hold whatever
+// the service returned in place of their plaintext.
+func newDeploymentSettingsState(
+	stack pulumiapi.StackIdentifier, settings *pulumiapi.DeploymentSettings,
+) DeploymentSettingsState {
+	state := DeploymentSettingsState{DeploymentSettingsInput{
+		Organization: stack.OrgName,
+		Project:      stack.ProjectName,
+		Stack:        stack.StackName,
+		AgentPoolID:  settings.AgentPoolID,
+	}}
+
+	if sc := settings.SourceContext; sc != nil {
+		state.SourceContext = &DeploymentSettingsSourceContext{}
+		if g := sc.Git; g != nil {
+			git := &DeploymentSettingsGitSource{
+				RepoURL: util.OrNil(g.RepoURL),
+				Commit:  util.OrNil(g.Commit),
+				Branch:  util.OrNil(g.Branch),
+				RepoDir: util.OrNil(g.RepoDir),
+			}
+			if auth := g.GitAuth; auth != nil {
+				git.GitAuth = &DeploymentSettingsGitSourceGitAuth{}
+				if ssh := auth.SSHAuth; ssh != nil {
+					git.GitAuth.SSHAuth = &DeploymentSettingsGitAuthSSHAuth{SSHPrivateKey: ssh.SSHPrivateKey.Value}
+					if ssh.Password != nil {
+						git.GitAuth.SSHAuth.Password = util.OrNil(ssh.Password.Value)
+					}
+				}
+				if basic := auth.BasicAuth; basic != nil {
+					git.GitAuth.BasicAuth = &DeploymentSettingsGitAuthBasicAuth{
+						Username: basic.UserName.Value,
+						Password: basic.Password.Value,
+					}
+				}
+			}
+			state.SourceContext.Git = git
+		}
 	}
 
-	err = ds.Client.DeleteDeploymentSettings(ctx, stack)
-	if err != nil {
-		return nil, err
+	if op := settings.Operation; op != nil {
+		oc := &DeploymentSettingsOperationContext{PreRunCommands: op.PreRunCommands}
+		if op.EnvironmentVariables != nil {
+			oc.EnvironmentVariables = map[string]string{}
+			for k, v := range op.EnvironmentVariables {
+				oc.EnvironmentVariables[k] = v.Value
+			}
+		}
+		if o := op.Options; o != nil {
+			oc.Options = &OperationContextOptions{
+				Shell:                       util.OrNil(o.Shell),
+				SkipInstallDependencies:     util.OrNil(o.SkipInstallDependencies),
+				SkipIntermediateDeployments: util.OrNil(o.SkipIntermediateDeployments),
+				DeleteAfterDestroy:          util.OrNil(o.DeleteAfterDestroy),
+			}
+		}
+		if oidc := op.OIDC; oidc != nil && (oidc.AWS != nil || oidc.GCP != nil || oidc.Azure != nil) {
+			oc.OIDC = &OperationContextOIDC{}
+			if aws := oidc.AWS; aws != nil {
+				oc.OIDC.AWS = &AWSOIDCConfiguration{
+					RoleARN:     aws.RoleARN,
+					SessionName: aws.SessionName,
+					PolicyARNs:  aws.PolicyARNs,
+					Duration:    util.OrNil(aws.Duration),
+				}
+			}
+			if gcp := oidc.GCP; gcp != nil {
+				oc.OIDC.GCP = &GCPOIDCConfiguration{
+					ProviderID:     gcp.ProviderID,
+					ServiceAccount: gcp.ServiceAccount,
+					Region:         util.OrNil(gcp.Region),
+					WorkloadPoolID: gcp.WorkloadPoolID,
+					ProjectID:      gcp.ProjectID,
+					TokenLifetime:  util.OrNil(gcp.TokenLifetime),
+				}
+			}
+			if azure := oidc.Azure; azure != nil {
+				oc.OIDC.Azure = &AzureOIDCConfiguration{
+					TenantID:       azure.TenantID,
+					ClientID:       azure.ClientID,
+					SubscriptionID: azure.SubscriptionID,
+				}
+			}
+		}
+		state.OperationContext = oc
 	}
-	return &pbempty.Empty{}, nil
-}
 
-func (ds *PulumiServiceDeploymentSettingsResource) Create(
-	req *pulumirpc.CreateRequest,
-) (*pulumirpc.CreateResponse, error) {
-	ctx := context.Background()
-	inputsMap, err := plugin.UnmarshalProperties(req.GetProperties(), util.KeepSecretsUnmarshal)
-	if err != nil {
-		return nil, err
+	if gh := settings.GitHub; gh != nil {
+		state.GitHub = &DeploymentSettingsGithub{
+			Repository:          util.OrNil(gh.Repository),
+			DeployCommits:       &gh.DeployCommits,
+			PreviewPullRequests: &gh.PreviewPullRequests,
+			PullRequestTemplate: &gh.PullRequestTemplate,
+		}
+		if len(gh.Paths) > 0 {
+			state.GitHub.Paths = gh.Paths
+		}
 	}
 
-	input := ds.ToPulumiServiceDeploymentSettingsInput(inputsMap)
-	settings := input.DeploymentSettings
-	response, err := ds.Client.CreateDeploymentSettings(ctx, input.Stack, settings)
-	if err != nil {
-		return nil, err
+	if vcs := settings.Vcs; vcs != nil {
+		provider, _ := vcs.GetDiscriminatorValue()
+		state.Vcs = &DeploymentSettingsVcs{
+			Provider:            provider,
+			Repository:          util.OrNil(vcs.Repository()),
+			InstallationID:      util.OrNil(vcs.InstallationID()),
+			DeployCommits:       util.OrNil(vcs.DeployCommits()),
+			PreviewPullRequests: util.OrNil(vcs.PreviewPullRequests()),
+			PullRequestTemplate: util.OrNil(vcs.PullRequestTemplate()),
+		}
+		// The bools are always present, false included.
+		deployCommits, previewPullRequests, pullRequestTemplate :=
+			vcs.DeployCommits(), vcs.PreviewPullRequests(), vcs.PullRequestTemplate()
+		state.Vcs.DeployCommits = &deployCommits
+		state.Vcs.PreviewPullRequests = &previewPullRequests
+		state.Vcs.PullRequestTemplate = &pullRequestTemplate
+		if len(vcs.Paths()) > 0 {
+			state.Vcs.Paths = vcs.Paths()
+		}
+		if pr := vcs.DeployPullRequest(); pr != nil {
+			deployPullRequest := int(*pr)
+			state.Vcs.DeployPullRequest = &deployPullRequest
+		}
 	}
 
-	responseInput := PulumiServiceDeploymentSettingsInput{
-		DeploymentSettings: *response,
-		Stack:              input.Stack,
+	if ec := settings.Executor; ec != nil && ec.ExecutorImage != nil && ec.ExecutorImage.Reference != "" {
+		state.ExecutorContext = &DeploymentSettingsExecutorContext{ExecutorImage: ec.ExecutorImage.Reference}
+		if c := ec.ExecutorImage.Credentials; c != nil {
+			state.ExecutorContext.Credentials = &DeploymentSettingsExecutorImageCredentials{
+				Username: c.Username,
+				Password: c.Password.Value,
+			}
+		}
 	}
 
-	outputProperties, err := plugin.MarshalProperties(
-		responseInput.ToPropertyMap(&input.DeploymentSettings, nil, false), util.StandardMarshal,
-	)
-	if err != nil {
-		return nil, err
+	if co := settings.CacheOptions; co != nil {
+		state.CacheOptions = &DeploymentSettingsCacheOptions{Enable: &co.Enable}
 	}
 
-	return &pulumirpc.CreateResponse{
-		Id:         path.Join(input.Stack.OrgName, input.Stack.ProjectName, input.Stack.StackName),
-		Properties: outputProperties,
-	}, nil
+	return state
 }
 
-func (ds *PulumiServiceDeploymentSettingsResource) Update(
-	req *pulumirpc.UpdateRequest,
-) (*pulumirpc.UpdateResponse, error) {
-	ctx := context.Background()
-	inputsMap, err := plugin.UnmarshalProperties(req.GetNews(), util.KeepSecretsUnmarshal)
-	if err != nil {
-		return nil, err
+// twinSecrets rebuilds the inputs on Read. Twin-value secrets are values whose
+// plaintext Pulumi Cloud never returns; a refresh carries the plaintext input
+// forward as long as the service still holds what the prior state recorded,
+// and an import, which has no inputs at all, prompts the user to fill it in.
+type twinSecrets struct {
+	// plaintext are the latest inputs and prior the latest state. Both are
+	// nil on import.
+	plaintext *DeploymentSettingsInput
+	prior     *DeploymentSettingsInput
+}
+
+// value returns the input for a twin-value secret the service now holds as
+// current. at locates the secret within a set of inputs, or returns nil.
+func (t twinSecrets) value(current string, at func(*DeploymentSettingsInput) *string) string {
+	if t.plaintext == nil {
+		return importSecretPlaceholder
 	}
+	if old := at(t.prior); old == nil || *old != current {
+		return ""
+	}
+	return util.OrZero(at(t.plaintext))
+}
 
-	input := ds.ToPulumiServiceDeploymentSettingsInput(inputsMap)
-	settings := input.DeploymentSettings
-	response, err := ds.Client.UpdateDeploymentSettings(ctx, input.Stack, settings)
-	if err != nil {
-		return nil, err
+func (t twinSecrets) inputs(state DeploymentSettingsState, settings *pulumiapi.DeploymentSettings) dsIn {
+	// Start from a fresh render, so filling in the inputs leaves state untouched.
+	inputs := newDeploymentSettingsState(state.stackIdentifier(), settings).DeploymentSettingsInput
+
+	if auth := gitAuth(&inputs); auth != nil {
+		if ssh := auth.SSHAuth; ssh != nil {
+			if ssh.SSHPrivateKey != "" {
+				ssh.SSHPrivateKey = t.value(ssh.SSHPrivateKey, func(i *dsIn) *string {
+					if auth := gitAuth(i); auth != nil && auth.SSHAuth != nil {
+						return &auth.SSHAuth.SSHPrivateKey
+					}
+					return nil
+				})
+			}
+			if ssh.Password != nil {
+				ssh.Password = util.OrNil(t.value(*ssh.Password, func(i *dsIn) *string {
+					if auth := gitAuth(i); auth != nil && auth.SSHAuth != nil {
+						return auth.SSHAuth.Password
+					}
+					return nil
+				}))
+			}
+		}
+		if basic := auth.BasicAuth; basic != nil {
+			if basic.Username != "" {
+				basic.Username = t.value(basic.Username, func(i *dsIn) *string {
+					if auth := gitAuth(i); auth != nil && auth.BasicAuth != nil {
+						return &auth.BasicAuth.Username
+					}
+					return nil
+				})
+			}
+			if basic.Password != "" {
+				basic.Password = t.value(basic.Password, func(i *dsIn) *string {
+					if auth := gitAuth(i); auth != nil && auth.BasicAuth != nil {
+						return &auth.BasicAuth.Password
+					}
+					return nil
+				})
+			}
+		}
 	}
 
-	responseInput := PulumiServiceDeploymentSettingsInput{
-		DeploymentSettings: *response,
-		Stack:              input.Stack,
+	if ec := inputs.ExecutorContext; ec != nil && ec.Credentials != nil && ec.Credentials.Password != "" {
+		ec.Credentials.Password = t.value(ec.Credentials.Password, func(i *dsIn) *string {
+			if i.ExecutorContext != nil && i.ExecutorContext.Credentials != nil {
+				return &i.ExecutorContext.Credentials.Password
+			}
+			return nil
+		})
 	}
 
-	outputProperties, err := plugin.MarshalProperties(
-		responseInput.ToPropertyMap(&input.DeploymentSettings, nil, false), util.StandardMarshal,
-	)
-	if err != nil {
-		return nil, err
+	if oc := inputs.OperationContext; oc != nil && settings.Operation != nil {
+		for k, v := range settings.Operation.EnvironmentVariables {
+			if !v.Secret || v.Value == "" {
+				continue
+			}
+			oc.EnvironmentVariables[k] = t.value(v.Value, func(i *dsIn) *string {
+				if i.OperationContext == nil {
+					return nil
+				}
+				if value, ok := i.OperationContext.EnvironmentVariables[k]; ok {
+					return &value
+				}
+				return nil
+			})
+		}
 	}
 
-	return &pulumirpc.UpdateResponse{
-		Properties: outputProperties,
-	}, nil
+	return inputs
 }
 
-func (ds *PulumiServiceDeploymentSettingsResource) Name() string {
-	return "pulumiservice:index:DeploymentSettings"
+func gitAuth(i *DeploymentSettingsInput) *DeploymentSettingsGitSourceGitAuth {
+	if i.SourceContext == nil || i.SourceContext.Git == nil {
+		return nil
+	}
+	return i.SourceContext.Git.GitAuth
 }
 
 func normalizeDurationString(input string) (*string, error) {
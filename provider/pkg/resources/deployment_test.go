@@ -0,0 +1,195 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/poll"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+// deploymentClientMock runs every deployment through the given statuses,
+// one per GetDeployment call.
+type deploymentClientMock struct {
+	config.Client
+	statuses []string
+	created  []pulumiapi.CreateStackDeploymentRequest
+	logs     []string
+}
+
+func (c *deploymentClientMock) CreateDeployment(
+	_ context.Context, _ pulumiapi.StackIdentifier, req pulumiapi.CreateStackDeploymentRequest,
+) (*pulumiapi.Deployment, error) {
+	c.created = append(c.created, req)
+	return &pulumiapi.Deployment{
+		ID:         "dep-1",
+		Version:    4,
+		Status:     "not-started",
+		ConsoleURL: "https://app.pulumi.com/my-org/my-project/dev/deployments/4",
+	}, nil
+}
+
+func (c *deploymentClientMock) GetDeployment(
+	_ context.Context, _ pulumiapi.StackIdentifier, id string,
+) (*pulumiapi.Deployment, error) {
+	if len(c.statuses) == 0 {
+		return nil, nil
+	}
+	status := c.statuses[0]
+	if len(c.statuses) > 1 {
+		c.statuses = c.statuses[1:]
+	}
+	return &pulumiapi.Deployment{ID: id, Version: 4, Status: status}, nil
+}
+
+func (c *deploymentClientMock) GetDeploymentUpdateURL(
+	context.Context, pulumiapi.StackIdentifier, pulumiapi.Deployment,
+) (string, error) {
+	return "https://app.pulumi.com/my-org/my-project/dev/updates/12", nil
+}
+
+func (c *deploymentClientMock) GetDeploymentLogTail(
+	context.Context, pulumiapi.StackIdentifier, string, int,
+) ([]string, error) {
+	return c.logs, nil
+}
+
+func deploymentInput() DeploymentInput {
+	return DeploymentInput{
+		Organization: gcMyOrg,
+		Project:      gcMyProject,
+		Stack:        "dev",
+		Operation:    PulumiOperationUpdate,
+		OperationContext: &DeploymentSettingsOperationContext{
+			PreRunCommands:       []string{"make deps"},
+			EnvironmentVariables: map[string]string{"STAGE": "dev"},
+		},
+	}
+}
+
+func TestDeploymentCreate(t *testing.T) {
+	d := &Deployment{runPoll: poll.Options{Interval: time.Millisecond}}
+
+	t.Run("waits for success", func(t *testing.T) {
+		client := &deploymentClientMock{statuses: []string{"accepted", "running", "succeeded"}}
+		ctx := config.WithMockClient(context.Background(), client)
+
+		resp, err := d.Create(ctx, infer.CreateRequest[DeploymentInput]{Inputs: deploymentInput()})
+		require.NoError(t, err)
+		assert.Equal(t, "my-org/my-project/dev/dep-1", resp.ID)
+		assert.Equal(t, "succeeded", resp.Output.Status)
+		assert.Equal(t, 4, resp.Output.Version)
+		require.NotNil(t, resp.Output.UpdateURL)
+		assert.Equal(t, "https://app.pulumi.com/my-org/my-project/dev/updates/12", *resp.Output.UpdateURL)
+
+		require.Len(t, client.created, 1)
+		req := client.created[0]
+		assert.Equal(t, "update", req.Operation)
+		require.NotNil(t, req.InheritSettings)
+		assert.True(t, *req.InheritSettings, "settings are inherited by default")
+		assert.Equal(t, []string{"make deps"}, req.OperationContext.PreRunCommands)
+		assert.Equal(t, pulumiapi.SecretValue{Value: "dev"}, req.OperationContext.EnvironmentVariables["STAGE"])
+	})
+
+	t.Run("records a failure with the log tail", func(t *testing.T) {
+		client := &deploymentClientMock{
+			statuses: []string{"running", "failed"},
+			logs:     []string{"Pulumi up", "error: boom"},
+		}
+		ctx := config.WithMockClient(context.Background(), client)
+
+		resp, err := d.Create(ctx, infer.CreateRequest[DeploymentInput]{Inputs: deploymentInput()})
+		var initErr infer.ResourceInitFailedError
+		require.ErrorAs(t, err, &initErr)
+		require.Len(t, initErr.Reasons, 1)
+		assert.Contains(t, initErr.Reasons[0], `finished with status "failed"`)
+		assert.Contains(t, initErr.Reasons[0], "Pulumi up\nerror: boom")
+		assert.Equal(t, "my-org/my-project/dev/dep-1", resp.ID)
+		assert.Equal(t, "failed", resp.Output.Status)
+		assert.Nil(t, resp.Output.UpdateURL)
+	})
+
+	t.Run("records a timeout", func(t *testing.T) {
+		client := &deploymentClientMock{statuses: []string{"running"}}
+		ctx := config.WithMockClient(context.Background(), client)
+
+		d := &Deployment{runPoll: poll.Options{Interval: time.Millisecond, Timeout: 10 * time.Millisecond}}
+		resp, err := d.Create(ctx, infer.CreateRequest[DeploymentInput]{Inputs: deploymentInput()})
+		var initErr infer.ResourceInitFailedError
+		require.ErrorAs(t, err, &initErr)
+		assert.Contains(t, initErr.Reasons[0], "timed out")
+		assert.Equal(t, "running", resp.Output.Status)
+	})
+}
+
+func TestDeploymentUpdateRunsAgain(t *testing.T) {
+	client := &deploymentClientMock{statuses: []string{"succeeded"}}
+	ctx := config.WithMockClient(context.Background(), client)
+	d := &Deployment{runPoll: poll.Options{Interval: time.Millisecond}}
+
+	news := deploymentInput()
+	news.Triggers = []any{"v2"}
+	inherit := false
+	news.InheritSettings = &inherit
+	resp, err := d.Update(ctx, infer.UpdateRequest[DeploymentInput, DeploymentState]{
+		ID:     "my-org/my-project/dev/dep-0",
+		State:  DeploymentState{DeploymentInput: deploymentInput(), DeploymentID: "dep-0"},
+		Inputs: news,
+	})
+	require.NoError(t, err)
+	require.Len(t, client.created, 1)
+	assert.False(t, *client.created[0].InheritSettings)
+	assert.Equal(t, "dep-1", resp.Output.DeploymentID)
+	assert.Equal(t, news, resp.Output.DeploymentInput)
+}
+
+func TestDeploymentRead(t *testing.T) {
+	state := DeploymentState{DeploymentInput: deploymentInput(), DeploymentID: "dep-1", Status: "running"}
+
+	t.Run("refreshes the status", func(t *testing.T) {
+		ctx := config.WithMockClient(context.Background(), &deploymentClientMock{statuses: []string{"succeeded"}})
+		resp, err := (&Deployment{}).Read(ctx, infer.ReadRequest[DeploymentInput, DeploymentState]{
+			ID: "my-org/my-project/dev/dep-1", Inputs: state.DeploymentInput, State: state,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "succeeded", resp.State.Status)
+	})
+
+	t.Run("a deleted deployment is gone", func(t *testing.T) {
+		ctx := config.WithMockClient(context.Background(), &deploymentClientMock{})
+		resp, err := (&Deployment{}).Read(ctx, infer.ReadRequest[DeploymentInput, DeploymentState]{
+			ID: "my-org/my-project/dev/dep-1", Inputs: state.DeploymentInput, State: state,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, resp.ID)
+	})
+
+	t.Run("import is rejected", func(t *testing.T) {
+		ctx := config.WithMockClient(context.Background(), &deploymentClientMock{})
+		_, err := (&Deployment{}).Read(ctx, infer.ReadRequest[DeploymentInput, DeploymentState]{
+			ID: "my-org/my-project/dev/dep-1",
+		})
+		assert.ErrorContains(t, err, "not supported")
+	})
+}
@@ -0,0 +1,169 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+type DeploymentsPause struct{}
+
+var (
+	_ infer.CustomCheck[DeploymentsPauseInput]                         = &DeploymentsPause{}
+	_ infer.CustomCreate[DeploymentsPauseInput, DeploymentsPauseState] = &DeploymentsPause{}
+	_ infer.CustomDelete[DeploymentsPauseState]                        = &DeploymentsPause{}
+	_ infer.CustomRead[DeploymentsPauseInput, DeploymentsPauseState]   = &DeploymentsPause{}
+)
+
+func (*DeploymentsPause) Annotate(a infer.Annotator) {
+	a.Describe(&DeploymentsPause{}, "Pauses Pulumi Deployments of a whole organization, or of a single stack "+
+		"when `project` and `stack` are set. While the resource exists, no new deployment starts in its scope; "+
+		"destroying it resumes deployments.\n\n"+
+		"Each scope has a single pause switch, so declare at most one `DeploymentsPause` per scope: destroying "+
+		"any of them resumes deployments. A pause lifted outside of Pulumi shows up as a deleted resource on "+
+		"refresh.\n\n"+
+		"### Import\n\n"+
+		"A pause can be imported using the `id`, which is `{org}` for an organization and "+
+		"`{org}/{project}/{stack}` for a stack, e.g.,\n\n"+
+		"```sh\n $ pulumi import pulumiservice:index:DeploymentsPause freeze my-org/my-project/my-stack\n```\n\n")
+	a.SetToken("index", "DeploymentsPause")
+}
+
+type DeploymentsPauseInput struct {
+	Organization string  `pulumi:"organization"     provider:"replaceOnChanges"`
+	Project      *string `pulumi:"project,optional" provider:"replaceOnChanges"`
+	Stack        *string `pulumi:"stack,optional"   provider:"replaceOnChanges"`
+}
+
+func (i *DeploymentsPauseInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.Organization, "Organization name.")
+	a.Describe(&i.Project, "Project name. Set together with `stack` to pause a single stack.")
+	a.Describe(&i.Stack, "Stack name. Set together with `project` to pause a single stack.")
+}
+
+type DeploymentsPauseState struct {
+	DeploymentsPauseInput
+}
+
+// stack returns the paused stack, or nil when the whole organization is.
+func (i *DeploymentsPauseInput) stack() *pulumiapi.StackIdentifier {
+	if i.Project == nil || i.Stack == nil {
+		return nil
+	}
+	return &pulumiapi.StackIdentifier{OrgName: i.Organization, ProjectName: *i.Project, StackName: *i.Stack}
+}
+
+func (i *DeploymentsPauseInput) id() string {
+	if stack := i.stack(); stack != nil {
+		return stack.String()
+	}
+	return i.Organization
+}
+
+func (*DeploymentsPause) Check(
+	ctx context.Context, req infer.CheckRequest,
+) (infer.CheckResponse[DeploymentsPauseInput], error) {
+	i, failures, err := infer.DefaultCheck[DeploymentsPauseInput](ctx, req.NewInputs)
+	if err != nil {
+		return infer.CheckResponse[DeploymentsPauseInput]{}, err
+	}
+	if (i.Project == nil) != (i.Stack == nil) {
+		failures = append(failures, p.CheckFailure{
+			Property: gcStack,
+			Reason:   "project and stack must be set together",
+		})
+	}
+	return infer.CheckResponse[DeploymentsPauseInput]{Inputs: i, Failures: failures}, nil
+}
+
+func (*DeploymentsPause) Create(
+	ctx context.Context,
+	req infer.CreateRequest[DeploymentsPauseInput],
+) (infer.CreateResponse[DeploymentsPauseState], error) {
+	if req.DryRun {
+		return infer.CreateResponse[DeploymentsPauseState]{
+			Output: DeploymentsPauseState{DeploymentsPauseInput: req.Inputs},
+		}, nil
+	}
+	client := config.GetClient(ctx)
+	var err error
+	if stack := req.Inputs.stack(); stack != nil {
+		err = client.PauseStackDeployments(ctx, *stack)
+	} else {
+		err = client.PauseOrgDeployments(ctx, req.Inputs.Organization)
+	}
+	if err != nil {
+		return infer.CreateResponse[DeploymentsPauseState]{}, err
+	}
+	return infer.CreateResponse[DeploymentsPauseState]{
+		ID:     req.Inputs.id(),
+		Output: DeploymentsPauseState{DeploymentsPauseInput: req.Inputs},
+	}, nil
+}
+
+func (*DeploymentsPause) Delete(
+	ctx context.Context,
+	req infer.DeleteRequest[DeploymentsPauseState],
+) (infer.DeleteResponse, error) {
+	client := config.GetClient(ctx)
+	if stack := req.State.stack(); stack != nil {
+		return infer.DeleteResponse{}, client.ResumeStackDeployments(ctx, *stack)
+	}
+	return infer.DeleteResponse{}, client.ResumeOrgDeployments(ctx, req.State.Organization)
+}
+
+func (*DeploymentsPause) Read(
+	ctx context.Context,
+	req infer.ReadRequest[DeploymentsPauseInput, DeploymentsPauseState],
+) (infer.ReadResponse[DeploymentsPauseInput, DeploymentsPauseState], error) {
+	var inputs DeploymentsPauseInput
+	switch parts := strings.Split(req.ID, "/"); len(parts) {
+	case 1:
+		inputs = DeploymentsPauseInput{Organization: parts[0]}
+	case 3:
+		inputs = DeploymentsPauseInput{Organization: parts[0], Project: &parts[1], Stack: &parts[2]}
+	default:
+		return infer.ReadResponse[DeploymentsPauseInput, DeploymentsPauseState]{},
+			fmt.Errorf("%q is invalid, expected organization or organization/project/stack", req.ID)
+	}
+
+	client := config.GetClient(ctx)
+	var paused bool
+	var err error
+	if stack := inputs.stack(); stack != nil {
+		paused, err = client.StackDeploymentsPaused(ctx, *stack)
+	} else {
+		paused, err = client.OrgDeploymentsPaused(ctx, inputs.Organization)
+	}
+	if err != nil {
+		return infer.ReadResponse[DeploymentsPauseInput, DeploymentsPauseState]{}, err
+	}
+	if !paused {
+		return infer.ReadResponse[DeploymentsPauseInput, DeploymentsPauseState]{}, nil
+	}
+	return infer.ReadResponse[DeploymentsPauseInput, DeploymentsPauseState]{
+		ID:     req.ID,
+		Inputs: inputs,
+		State:  DeploymentsPauseState{DeploymentsPauseInput: inputs},
+	}, nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+// deploymentsPauseClientMock tracks the pause switches of organizations and
+// stacks, keyed by their resource ID, and of stack schedules.
+type deploymentsPauseClientMock struct {
+	config.Client
+	paused      map[string]bool
+	scheduleErr error
+}
+
+func (c *deploymentsPauseClientMock) PauseOrgDeployments(_ context.Context, org string) error {
+	c.paused[org] = true
+	return nil
+}
+
+func (c *deploymentsPauseClientMock) ResumeOrgDeployments(_ context.Context, org string) error {
+	c.paused[org] = false
+	return nil
+}
+
+func (c *deploymentsPauseClientMock) OrgDeploymentsPaused(_ context.Context, org string) (bool, error) {
+	return c.paused[org], nil
+}
+
+func (c *deploymentsPauseClientMock) PauseStackDeployments(_ context.Context, stack pulumiapi.StackIdentifier) error {
+	c.paused[stack.String()] = true
+	return nil
+}
+
+func (c *deploymentsPauseClientMock) ResumeStackDeployments(_ context.Context, stack pulumiapi.StackIdentifier) error {
+	c.paused[stack.String()] = false
+	return nil
+}
+
+func (c *deploymentsPauseClientMock) StackDeploymentsPaused(
+	_ context.Context, stack pulumiapi.StackIdentifier,
+) (bool, error) {
+	return c.paused[stack.String()], nil
+}
+
+func (c *deploymentsPauseClientMock) UpdateTTLSchedule(
+	context.Context, pulumiapi.StackIdentifier, pulumiapi.CreateTTLScheduleRequest, string,
+) (*string, error) {
+	id := "schedule-1"
+	return &id, nil
+}
+
+func (c *deploymentsPauseClientMock) PauseStackSchedule(
+	_ context.Context, _ pulumiapi.StackIdentifier, scheduleID string,
+) error {
+	if c.scheduleErr != nil {
+		return c.scheduleErr
+	}
+	c.paused[scheduleID] = true
+	return nil
+}
+
+func (c *deploymentsPauseClientMock) ResumeStackSchedule(
+	_ context.Context, _ pulumiapi.StackIdentifier, scheduleID string,
+) error {
+	if c.scheduleErr != nil {
+		return c.scheduleErr
+	}
+	c.paused[scheduleID] = false
+	return nil
+}
+
+func TestDeploymentsPauseCheck(t *testing.T) {
+	resp, err := (&DeploymentsPause{}).Check(context.Background(), infer.CheckRequest{
+		NewInputs: property.NewMap(map[string]property.Value{
+			"organization": property.New(gcMyOrg),
+			"project":      property.New(gcMyProject),
+		}),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Failures, 1)
+	assert.Equal(t, gcStack, resp.Failures[0].Property)
+}
+
+func TestDeploymentsPauseLifecycle(t *testing.T) {
+	project, stack := gcMyProject, "dev"
+	for name, inputs := range map[string]DeploymentsPauseInput{
+		"organization": {Organization: gcMyOrg},
+		"stack":        {Organization: gcMyOrg, Project: &project, Stack: &stack},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client := &deploymentsPauseClientMock{paused: map[string]bool{}}
+			ctx := config.WithMockClient(context.Background(), client)
+			r := &DeploymentsPause{}
+
+			created, err := r.Create(ctx, infer.CreateRequest[DeploymentsPauseInput]{Inputs: inputs})
+			require.NoError(t, err)
+			assert.True(t, client.paused[created.ID])
+
+			read, err := r.Read(ctx, infer.ReadRequest[DeploymentsPauseInput, DeploymentsPauseState]{ID: created.ID})
+			require.NoError(t, err)
+			assert.Equal(t, created.ID, read.ID)
+			assert.Equal(t, inputs, read.Inputs)
+
+			_, err = r.Delete(ctx, infer.DeleteRequest[DeploymentsPauseState]{ID: created.ID, State: created.Output})
+			require.NoError(t, err)
+			assert.False(t, client.paused[created.ID])
+
+			read, err = r.Read(ctx, infer.ReadRequest[DeploymentsPauseInput, DeploymentsPauseState]{ID: created.ID})
+			require.NoError(t, err)
+			assert.Empty(t, read.ID, "a lifted pause is gone")
+		})
+	}
+}
+
+func TestStackSchedulePaused(t *testing.T) {
+	yes, no := true, false
+	inputs := TTLScheduleInput{
+		Organization: gcMyOrg,
+		Project:      gcMyProject,
+		Stack:        "dev",
+		Timestamp:    "2030-01-01T00:00:00Z",
+	}
+	update := func(ctx context.Context, was, want *bool) (infer.UpdateResponse[TTLScheduleState], error) {
+		olds, news := inputs, inputs
+		olds.Paused, news.Paused = was, want
+		return (&TTLSchedule{}).Update(ctx, infer.UpdateRequest[TTLScheduleInput, TTLScheduleState]{
+			State:  TTLScheduleState{TTLScheduleInput: olds, ScheduleID: "schedule-1"},
+			Inputs: news,
+		})
+	}
+
+	t.Run("pauses and resumes", func(t *testing.T) {
+		client := &deploymentsPauseClientMock{paused: map[string]bool{}}
+		ctx := config.WithMockClient(context.Background(), client)
+
+		_, err := update(ctx, &no, &yes)
+		require.NoError(t, err)
+		assert.True(t, client.paused["schedule-1"])
+
+		_, err = update(ctx, &yes, &no)
+		require.NoError(t, err)
+		assert.False(t, client.paused["schedule-1"])
+	})
+
+	t.Run("unchanged is left alone", func(t *testing.T) {
+		client := &deploymentsPauseClientMock{paused: map[string]bool{}, scheduleErr: errors.New("unexpected")}
+		ctx := config.WithMockClient(context.Background(), client)
+
+		_, err := update(ctx, nil, &no)
+		require.NoError(t, err)
+	})
+
+	t.Run("a failure keeps the old state", func(t *testing.T) {
+		client := &deploymentsPauseClientMock{paused: map[string]bool{}, scheduleErr: errors.New("boom")}
+		ctx := config.WithMockClient(context.Background(), client)
+
+		resp, err := update(ctx, &no, &yes)
+		var initErr infer.ResourceInitFailedError
+		require.ErrorAs(t, err, &initErr)
+		assert.Equal(t, &no, resp.Output.Paused)
+	})
+}
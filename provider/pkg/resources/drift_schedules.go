@@ -44,6 +44,7 @@ type DriftScheduleInput struct {
 	Stack         string `pulumi:"stack"        provider:"replaceOnChanges"`
 	ScheduleCron  string `pulumi:"scheduleCron"`
 	AutoRemediate *bool  `pulumi:"autoRemediate,optional"`
+	Paused        *bool  `pulumi:"paused,optional"`
 }
 
 func (i *DriftScheduleInput) Annotate(a infer.Annotator) {
@@ -53,6 +54,8 @@ func (i *DriftScheduleInput) Annotate(a infer.Annotator) {
 	a.Describe(&i.ScheduleCron, "Cron expression for when to run drift detection.")
 	a.Describe(&i.AutoRemediate, "Whether any drift detected should be remediated after a drift run.")
 	a.SetDefault(&i.AutoRemediate, false)
+	a.Describe(&i.Paused, "Whether the schedule is paused. A paused schedule does not run until it is resumed.")
+	a.SetDefault(&i.Paused, false)
 }
 
 type DriftScheduleState struct {
@@ -81,13 +84,16 @@ func (*DriftSchedule) Create(
 	if err != nil {
 		return infer.CreateResponse[DriftScheduleState]{}, fmt.Errorf("error creating drift schedule: %w", err)
 	}
-	return infer.CreateResponse[DriftScheduleState]{
-		ID: stackScheduleID(stack, "drift", *scheduleID),
-		Output: DriftScheduleState{
-			DriftScheduleInput: req.Inputs,
-			ScheduleID:         *scheduleID,
-		},
-	}, nil
+	id := stackScheduleID(stack, "drift", *scheduleID)
+	state := DriftScheduleState{
+		DriftScheduleInput: req.Inputs,
+		ScheduleID:         *scheduleID,
+	}
+	if err := setStackSchedulePaused(ctx, stack, *scheduleID, nil, req.Inputs.Paused); err != nil {
+		state.Paused = nil
+		return infer.CreateResponse[DriftScheduleState]{ID: id, Output: state}, err
+	}
+	return infer.CreateResponse[DriftScheduleState]{ID: id, Output: state}, nil
 }
 
 func (*DriftSchedule) Update(
@@ -110,12 +116,15 @@ func (*DriftSchedule) Update(
 	if err != nil {
 		return infer.UpdateResponse[DriftScheduleState]{}, fmt.Errorf("error updating drift schedule: %w", err)
 	}
-	return infer.UpdateResponse[DriftScheduleState]{
-		Output: DriftScheduleState{
-			DriftScheduleInput: req.Inputs,
-			ScheduleID:         *scheduleID,
-		},
-	}, nil
+	state := DriftScheduleState{
+		DriftScheduleInput: req.Inputs,
+		ScheduleID:         *scheduleID,
+	}
+	if err := setStackSchedulePaused(ctx, stack, *scheduleID, req.State.Paused, req.Inputs.Paused); err != nil {
+		state.Paused = req.State.Paused
+		return infer.UpdateResponse[DriftScheduleState]{Output: state}, err
+	}
+	return infer.UpdateResponse[DriftScheduleState]{Output: state}, nil
 }
 
 func (*DriftSchedule) Delete(
@@ -160,6 +169,7 @@ func (*DriftSchedule) Read(
 		Stack:         stack.StackName,
 		ScheduleCron:  *resp.ScheduleCron,
 		AutoRemediate: &autoRemediate,
+		Paused:        &resp.Paused,
 	}
 	return infer.ReadResponse[DriftScheduleInput, DriftScheduleState]{
 		ID:     req.ID,
@@ -4,97 +4,117 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"path"
+	"strconv"
 	"strings"
 
-	pbempty "google.golang.org/protobuf/types/known/emptypb"
-	"google.golang.org/protobuf/types/known/structpb"
-
 	esc_client "github.com/pulumi/esc/cmd/esc/cli/client"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/asset"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
-	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
 
-	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/poll"
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/util"
 )
 
 const (
-	gcProject = "project"
-	gcYaml    = "yaml"
+	gcProject            = "project"
+	gcYaml               = "yaml"
+	gcRollbackToRevision = "rollbackToRevision"
 )
 
 const defaultProject = "default"
 
-type PulumiServiceEnvironmentResource struct {
-	Client         esc_client.Client
-	MetadataClient pulumiapi.EnvironmentMetadataClient
-}
-
-type PulumiServiceEnvironmentInput struct {
-	OrgName     string
-	ProjectName string
-	EnvName     string
-	Yaml        string
+type Environment struct {
+	// changeRequestPoll overrides how applyChangeRequest polls; zero uses
+	// changeRequestPollInterval, and the operation's customTimeouts or else
+	// changeRequestTimeout.
+	changeRequestPoll poll.Options
 }
 
-type PulumiServiceEnvironmentOutput struct {
-	input         PulumiServiceEnvironmentInput
-	revision      int
-	environmentID string
-}
+type (
+	envIn  = EnvironmentInput
+	envOut = EnvironmentState
+)
 
-func (i *PulumiServiceEnvironmentInput) ToPropertyMap() (resource.PropertyMap, error) {
-	propertyMap := resource.PropertyMap{}
-	propertyMap[gcOrganization] = resource.NewPropertyValue(i.OrgName)
-	propertyMap[gcProject] = resource.NewPropertyValue(i.ProjectName)
-	propertyMap[gcName] = resource.NewPropertyValue(i.EnvName)
-	propertyMap[gcYaml] = resource.MakeSecret(resource.NewStringProperty(i.Yaml))
+var (
+	_ infer.CustomCheck[envIn]          = &Environment{}
+	_ infer.CustomDiff[envIn, envOut]   = &Environment{}
+	_ infer.CustomCreate[envIn, envOut] = &Environment{}
+	_ infer.CustomUpdate[envIn, envOut] = &Environment{}
+	_ infer.CustomRead[envIn, envOut]   = &Environment{}
+	_ infer.CustomDelete[envOut]        = &Environment{}
+)
 
-	return propertyMap, nil
+func (e *Environment) Annotate(a infer.Annotator) {
+	a.Describe(e, "An ESC Environment.")
+	a.SetToken("index", "Environment")
 }
 
-func (i *PulumiServiceEnvironmentOutput) ToPropertyMap() (resource.PropertyMap, error) {
-	propertyMap, err := i.input.ToPropertyMap()
-	if err != nil {
-		return nil, err
-	}
-
-	propertyMap["revision"] = resource.NewPropertyValue(i.revision)
-	if i.environmentID != "" {
-		propertyMap["environmentId"] = resource.NewPropertyValue(i.environmentID)
-	}
-
-	return propertyMap, nil
+// EnvironmentInput holds the environment definition as trimmed text. The
+// schema advertises `yaml` as an Asset; Check reads the asset down to its text
+// so state keeps the shape it has always had. A structured definition is
+// serialized by Check into `yaml`, which the rest of the lifecycle uses, and
+// so is the content of a revision to roll back to.
+type EnvironmentInput struct {
+	Organization             string                 `pulumi:"organization"`
+	Project                  string                 `pulumi:"project,optional"`
+	Name                     string                 `pulumi:"name"`
+	Yaml                     string                 `pulumi:"yaml,optional"       provider:"secret"`
+	Definition               *EnvironmentDefinition `pulumi:"definition,optional"`
+	RollbackToRevision       *int                   `pulumi:"rollbackToRevision,optional"`
+	AdoptDeleted             *bool                  `pulumi:"adoptDeleted,optional"`
+	ProposeViaChangeRequest  *bool                  `pulumi:"proposeViaChangeRequest,optional"`
+	ChangeRequestDescription *string                `pulumi:"changeRequestDescription,optional"`
+	ApplyChangeRequest       *bool                  `pulumi:"applyChangeRequest,optional"`
 }
 
-func ToPulumiServiceEnvironmentInput(properties *structpb.Struct) (*PulumiServiceEnvironmentInput, error) {
-	inputMap, err := plugin.UnmarshalProperties(properties, plugin.MarshalOptions{KeepUnknowns: true, SkipNulls: true})
-	if err != nil {
-		return nil, err
-	}
-
-	input := PulumiServiceEnvironmentInput{}
-	input.OrgName = inputMap[gcOrganization].StringValue()
-	input.EnvName = inputMap[gcName].StringValue()
-
-	inputYaml := inputMap[gcYaml]
-	if inputYaml.IsAsset() {
-		input.Yaml = inputYaml.AssetValue().Text
-	} else {
-		input.Yaml = inputYaml.StringValue()
-	}
+func (i *EnvironmentInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.Organization, "Organization name.")
+	a.Describe(&i.Project, "Project name.")
+	a.SetDefault(&i.Project, defaultProject)
+	a.Describe(&i.Name, "Environment name.")
+	a.Describe(&i.Yaml, "Environment's yaml file. Set either this, `definition` or `rollbackToRevision`.")
+	a.Describe(&i.Definition, "Environment's definition as structured values, instead of `yaml`. It is stored as "+
+		"canonical YAML, which is also recorded in `yaml`, and changes to it are shown value by value.")
+	a.Describe(&i.RollbackToRevision, "Publishes the content of this earlier revision of the environment as its "+
+		"latest revision, instead of `yaml` or `definition`. The content is published again whenever the "+
+		"environment changes elsewhere.")
+	a.Describe(&i.AdoptDeleted, "When creating the environment, restore a deleted environment of the same name, "+
+		"with its revision history, instead of creating a new one. The declared content is then published as "+
+		"its latest revision. Only considered on create.")
+	a.Describe(&i.ProposeViaChangeRequest, "Publish changes to the environment's content through a change "+
+		"request instead of updating it directly, as required when an approval gate protects the environment. "+
+		"While the change request is pending, `yaml` holds the proposed content.")
+	a.Describe(&i.ChangeRequestDescription, "Description of the change requests opened by "+
+		"`proposeViaChangeRequest`.")
+	a.Describe(&i.ApplyChangeRequest, "Wait for a change request opened by `proposeViaChangeRequest` to be "+
+		"approved, and apply it. The wait is bounded by the operation's custom timeout, one hour by default.")
+}
 
-	// Set project to "default" if not in input
-	input.ProjectName = defaultProject
+type EnvironmentState struct {
+	EnvironmentInput
+	Revision            int     `pulumi:"revision"`
+	EnvironmentID       string  `pulumi:"environmentId,optional"`
+	ChangeRequestID     *string `pulumi:"changeRequestId,optional"`
+	ChangeRequestStatus *string `pulumi:"changeRequestStatus,optional"`
+}
 
-	inputProject := inputMap[gcProject]
-	if inputProject.HasValue() && inputProject.IsString() {
-		input.ProjectName = inputProject.StringValue()
-	}
+func (s *EnvironmentState) Annotate(a infer.Annotator) {
+	a.Describe(&s.Revision, "Revision number of the latest version.")
+	a.Describe(&s.EnvironmentID, "The environment's UUID. Use this as the `identity` value when pinning a "+
+		"custom RBAC role to this environment via a `PermissionLiteralExpressionEnvironment` in "+
+		"`OrganizationRole.permissions`, or pass it directly to the `buildEnvironmentScopedPermissions` helper.")
+	a.Describe(&s.ChangeRequestID, "The ID of the latest change request opened by `proposeViaChangeRequest`.")
+	a.Describe(&s.ChangeRequestStatus, "The status of the latest change request as last observed, e.g. `open`, "+
+		"`applied` or `closed`.")
+}
 
-	return &input, nil
+func (s *EnvironmentState) id() string {
+	return path.Join(s.Organization, s.Project, s.Name)
 }
 
 func getBytesFromAsset(asset *asset.Asset) ([]byte, error) {
@@ -111,290 +131,447 @@ func getBytesFromAsset(asset *asset.Asset) ([]byte, error) {
 	return io.ReadAll(reader)
 }
 
-func (st *PulumiServiceEnvironmentResource) Diff(req *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error) {
-	olds, err := plugin.UnmarshalProperties(
-		req.GetOldInputs(),
-		plugin.MarshalOptions{KeepUnknowns: true, SkipNulls: true},
-	)
-	if err != nil {
-		return nil, err
+func (*Environment) Check(
+	ctx context.Context, req infer.CheckRequest,
+) (infer.CheckResponse[EnvironmentInput], error) {
+	// Read an asset down to its text and store it trimmed and secret. An
+	// unknown definition (e.g. built from an Output) is passed through as is.
+	if yaml, ok := req.NewInputs.GetOk(gcYaml); ok && !yaml.IsComputed() {
+		var text string
+		switch {
+		case yaml.IsAsset():
+			bytes, err := getBytesFromAsset(yaml.AsAsset())
+			if err != nil {
+				return infer.CheckResponse[EnvironmentInput]{}, err
+			}
+			text = string(bytes)
+		case yaml.IsString():
+			text = yaml.AsString()
+		}
+		req.NewInputs = req.NewInputs.Set(gcYaml, property.New(strings.TrimSpace(text)).WithSecret(true))
+	}
+
+	// Store Pulumi secrets of a definition as ESC secrets. The YAML of a
+	// definition that isn't fully known yet is unknown too.
+	yaml, hasYaml := req.NewInputs.GetOk(gcYaml)
+	hasYaml = hasYaml && !yaml.IsNull()
+	definition, hasDefinition := req.NewInputs.GetOk(gcDefinition)
+	hasDefinition = hasDefinition && !definition.IsNull()
+	if hasDefinition {
+		req.NewInputs = req.NewInputs.Set(gcDefinition, wrapDefinitionSecrets(definition))
+		if !hasYaml && definition.HasComputed() {
+			req.NewInputs = req.NewInputs.Set(gcYaml, property.New(property.Computed).WithSecret(true))
+		}
 	}
 
-	// Backfill project for state from pre-0.25.0 which didn't have this field.
-	if !olds[gcProject].HasValue() {
-		olds[gcProject] = resource.NewPropertyValue(defaultProject)
+	// The content of a revision to roll back to is read into the yaml, once
+	// the revision and the environment are known.
+	rollback, hasRollback := req.NewInputs.GetOk(gcRollbackToRevision)
+	hasRollback = hasRollback && !rollback.IsNull()
+	readRollback := hasRollback && !hasYaml && !hasDefinition
+	if readRollback && !environmentKnown(req.NewInputs, rollback) {
+		req.NewInputs = req.NewInputs.Set(gcYaml, property.New(property.Computed).WithSecret(true))
+		readRollback = false
 	}
 
-	news, err := plugin.UnmarshalProperties(req.GetNews(), plugin.MarshalOptions{KeepUnknowns: true, SkipNulls: true})
+	i, failures, err := infer.DefaultCheck[EnvironmentInput](ctx, req.NewInputs)
 	if err != nil {
-		return nil, err
+		return infer.CheckResponse[EnvironmentInput]{}, err
+	}
+	switch {
+	case hasYaml && hasDefinition:
+		failures = append(failures, p.CheckFailure{
+			Property: gcDefinition,
+			Reason:   "only one of `yaml`, `definition` and `rollbackToRevision` may be set",
+		})
+	case hasRollback && (hasYaml || hasDefinition):
+		failures = append(failures, p.CheckFailure{
+			Property: gcRollbackToRevision,
+			Reason:   "only one of `yaml`, `definition` and `rollbackToRevision` may be set",
+		})
+	case !hasYaml && !hasDefinition && !hasRollback:
+		failures = append(failures, p.CheckFailure{
+			Property: gcYaml,
+			Reason:   "one of `yaml`, `definition` and `rollbackToRevision` must be set",
+		})
+	case hasDefinition && !definition.HasComputed():
+		definitionFailures, err := checkEnvironmentDefinition(ctx, &i, req.NewInputs.Get(gcOrganization))
+		if err != nil {
+			return infer.CheckResponse[EnvironmentInput]{}, err
+		}
+		failures = append(failures, definitionFailures...)
+	case readRollback:
+		yaml, err := readEnvironmentRevision(ctx, i, *i.RollbackToRevision)
+		if err != nil {
+			failures = append(failures, p.CheckFailure{Property: gcRollbackToRevision, Reason: err.Error()})
+		}
+		i.Yaml = yaml
+	}
+	if organization := req.NewInputs.Get(gcOrganization); len(failures) == 0 && organization.IsString() {
+		key := gcYaml
+		switch {
+		case hasDefinition:
+			key = gcDefinition
+		case readRollback:
+			key = gcRollbackToRevision
+		}
+		rotatorFailures, err := checkEnvironmentRotators(ctx, i, key)
+		if err != nil {
+			return infer.CheckResponse[EnvironmentInput]{}, err
+		}
+		failures = append(failures, rotatorFailures...)
+	}
+	for key, value := range map[string]string{
+		gcOrganization: i.Organization,
+		gcProject:      i.Project,
+		gcName:         i.Name,
+	} {
+		if strings.Contains(value, "/") {
+			failures = append(failures, p.CheckFailure{
+				Property: key,
+				Reason:   fmt.Sprintf("'%s' property contains `/` illegal character", key),
+			})
+		}
 	}
+	return infer.CheckResponse[EnvironmentInput]{Inputs: i, Failures: failures}, nil
+}
 
-	diffs := olds.Diff(news)
-	if diffs == nil {
-		return &pulumirpc.DiffResponse{
-			Changes: pulumirpc.DiffResponse_DIFF_NONE,
-		}, nil
+// checkEnvironmentDefinition serializes the definition into the input's
+// yaml and validates it with ESC, once the organization is known.
+func checkEnvironmentDefinition(
+	ctx context.Context, i *EnvironmentInput, organization property.Value,
+) ([]p.CheckFailure, error) {
+	yaml, err := i.Definition.toYAML()
+	if err != nil {
+		return []p.CheckFailure{{Property: gcDefinition, Reason: err.Error()}}, nil
 	}
-
-	dd := plugin.NewDetailedDiffFromObjectDiff(diffs, false)
-
-	detailedDiffs := map[string]*pulumirpc.PropertyDiff{}
-	replaces := []string(nil)
-	replaceProperties := map[string]bool{
-		gcOrganization: true,
-		gcProject:      true,
-		gcName:         true,
+	i.Yaml = yaml
+	if !organization.IsString() || i.Organization == "" {
+		return nil, nil
 	}
-	for k, v := range dd {
-		if _, ok := replaceProperties[k]; ok {
-			v.Kind = v.Kind.AsReplace()
-			replaces = append(replaces, k)
+	_, diagnostics, err := config.GetEscClient(ctx).CheckYAMLEnvironment(
+		ctx,
+		i.Organization,
+		[]byte(yaml),
+		esc_client.CheckYAMLOption{},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check environment definition: %w", err)
+	}
+	var failures []p.CheckFailure
+	for _, d := range diagnostics {
+		if !d.IsError() {
+			continue
 		}
-		detailedDiffs[k] = &pulumirpc.PropertyDiff{
-			Kind:      pulumirpc.PropertyDiff_Kind(v.Kind), //nolint:gosec // safe conversion from plugin.DiffKind
-			InputDiff: v.InputDiff,
+		reason := d.Summary
+		if d.Detail != "" {
+			reason += ": " + d.Detail
 		}
+		failures = append(failures, p.CheckFailure{Property: gcDefinition, Reason: reason})
 	}
-
-	changes := pulumirpc.DiffResponse_DIFF_NONE
-	if len(detailedDiffs) > 0 {
-		changes = pulumirpc.DiffResponse_DIFF_SOME
-	}
-	return &pulumirpc.DiffResponse{
-		Changes:             changes,
-		Replaces:            replaces,
-		DetailedDiff:        detailedDiffs,
-		HasDetailedDiff:     true,
-		DeleteBeforeReplace: len(replaces) > 0,
-	}, nil
+	return failures, nil
 }
 
-func (st *PulumiServiceEnvironmentResource) Delete(req *pulumirpc.DeleteRequest) (*pbempty.Empty, error) {
-	input, err := ToPulumiServiceEnvironmentInput(req.GetProperties())
-	if err != nil {
-		return nil, err
+// environmentKnown reports whether the revision and the environment it
+// belongs to are known, so the revision can be read.
+func environmentKnown(inputs property.Map, revision property.Value) bool {
+	if !revision.IsNumber() {
+		return false
+	}
+	for _, key := range []string{gcOrganization, gcProject, gcName} {
+		v, ok := inputs.GetOk(key)
+		// A missing project is defaulted.
+		if ok && !v.IsString() || !ok && key != gcProject {
+			return false
+		}
 	}
+	return true
+}
 
-	err = st.Client.DeleteEnvironment(context.Background(), input.OrgName, input.ProjectName, input.EnvName)
+// readEnvironmentRevision returns the trimmed yaml of an earlier revision of
+// the environment, secrets still encrypted.
+func readEnvironmentRevision(ctx context.Context, i EnvironmentInput, revision int) (string, error) {
+	yaml, _, _, err := config.GetEscClient(ctx).GetEnvironment(
+		ctx,
+		i.Organization,
+		i.Project,
+		i.Name,
+		strconv.Itoa(revision),
+		false,
+	)
 	if err != nil {
-		return nil, err
+		return "", fmt.Errorf("failed to read revision %d of environment %s/%s/%s: %w",
+			revision, i.Organization, i.Project, i.Name, err)
 	}
-	return &pbempty.Empty{}, nil
+	return strings.TrimSpace(string(yaml)), nil
 }
 
-func (st *PulumiServiceEnvironmentResource) Create(req *pulumirpc.CreateRequest) (*pulumirpc.CreateResponse, error) {
-	input, err := ToPulumiServiceEnvironmentInput(req.GetProperties())
-	if err != nil {
-		return nil, err
+func (*Environment) Diff(_ context.Context, req infer.DiffRequest[envIn, envOut]) (infer.DiffResponse, error) {
+	// Backfill project for state from pre-0.25.0 which didn't have this field.
+	project := req.State.Project
+	if project == "" {
+		project = defaultProject
 	}
 
-	// First check if yaml is valid
-	_, diagnostics, err := st.Client.CheckYAMLEnvironment(
-		context.Background(),
-		input.OrgName,
-		[]byte(input.Yaml),
-		esc_client.CheckYAMLOption{},
-	)
-	if diagnostics != nil {
-		return nil, fmt.Errorf("failed to check environment, yaml code failed following checks: %+v", diagnostics)
+	diff := map[string]p.PropertyDiff{}
+	replace := func(key string) { diff[key] = p.PropertyDiff{Kind: p.UpdateReplace, InputDiff: true} }
+	if req.State.Organization != req.Inputs.Organization {
+		replace(gcOrganization)
 	}
-	if err != nil {
-		return nil, fmt.Errorf("failed to check environment due to error: %+v", err)
+	if project != req.Inputs.Project {
+		replace(gcProject)
 	}
-
-	// Then create environment, and update it with yaml provided. ESC API architecture doesn't let you do it in one call
-	err = st.Client.CreateEnvironmentWithProject(context.Background(), input.OrgName, input.ProjectName, input.EnvName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create new environment due to error: %+v", err)
+	if req.State.Name != req.Inputs.Name {
+		replace(gcName)
 	}
-	diagnostics, revision, err := st.Client.UpdateEnvironmentWithRevision(
-		context.Background(),
-		input.OrgName,
-		input.ProjectName,
-		input.EnvName,
-		[]byte(input.Yaml),
-		"",
-	)
-	if diagnostics != nil {
-		return nil, fmt.Errorf(
-			"failed to update brand new environment with pre-checked yaml, due to failing the following checks: %+v \n"+
-				"This should never happen, if you're seeing this message there's likely a bug in ESC APIs",
-			diagnostics,
-		)
+	replaces := len(diff) > 0
+	if req.Inputs.Definition != nil {
+		maps.Copy(diff, diffEnvironmentDefinition(req.State.EnvironmentInput, req.Inputs))
+	} else {
+		if req.State.Yaml != req.Inputs.Yaml {
+			diff[gcYaml] = p.PropertyDiff{Kind: p.Update, InputDiff: true}
+		}
+		if req.State.Definition != nil {
+			diff[gcDefinition] = p.PropertyDiff{Kind: p.Delete, InputDiff: true}
+		}
 	}
-	if err != nil {
-		return nil, fmt.Errorf("failed to push yaml into environment due to error: %+v", err)
+	// These only change how the environment is created or its content
+	// published, so updating them only records them.
+	update := func(key string) { diff[key] = p.PropertyDiff{Kind: p.Update, InputDiff: true} }
+	if util.OrZero(req.State.AdoptDeleted) != util.OrZero(req.Inputs.AdoptDeleted) {
+		update("adoptDeleted")
 	}
-
-	envID, err := st.fetchEnvironmentID(context.Background(), input.OrgName, input.ProjectName, input.EnvName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to resolve new environment's id: %w", err)
+	if util.OrZero(req.State.ProposeViaChangeRequest) != util.OrZero(req.Inputs.ProposeViaChangeRequest) {
+		update("proposeViaChangeRequest")
 	}
-
-	output := PulumiServiceEnvironmentOutput{
-		input:         *input,
-		revision:      revision,
-		environmentID: envID,
+	if util.OrZero(req.State.ChangeRequestDescription) != util.OrZero(req.Inputs.ChangeRequestDescription) {
+		update("changeRequestDescription")
 	}
-
-	propertyMap, err := output.ToPropertyMap()
-	if err != nil {
-		return nil, err
+	if util.OrZero(req.State.ApplyChangeRequest) != util.OrZero(req.Inputs.ApplyChangeRequest) {
+		update("applyChangeRequest")
 	}
-	outputProperties, err := plugin.MarshalProperties(
-		propertyMap,
-		plugin.MarshalOptions{
-			KeepSecrets: true,
-		},
-	)
-	if err != nil {
-		return nil, err
+	switch olds, news := req.State.RollbackToRevision, req.Inputs.RollbackToRevision; {
+	case olds == nil && news != nil:
+		diff[gcRollbackToRevision] = p.PropertyDiff{Kind: p.Add, InputDiff: true}
+	case olds != nil && news == nil:
+		diff[gcRollbackToRevision] = p.PropertyDiff{Kind: p.Delete, InputDiff: true}
+	case olds != nil && *olds != *news:
+		diff[gcRollbackToRevision] = p.PropertyDiff{Kind: p.Update, InputDiff: true}
 	}
 
-	return &pulumirpc.CreateResponse{
-		Id:         path.Join(input.OrgName, input.ProjectName, input.EnvName),
-		Properties: outputProperties,
+	return infer.DiffResponse{
+		HasChanges:          len(diff) > 0,
+		DetailedDiff:        diff,
+		DeleteBeforeReplace: replaces,
 	}, nil
 }
 
-// fetchEnvironmentID resolves an environment's UUID via the metadata endpoint.
-// Returns "" with no error when the metadata client is not configured, so
-// tests that omit it behave as before — the resource simply skips emitting
-// `environmentId` rather than crashing.
-func (st *PulumiServiceEnvironmentResource) fetchEnvironmentID(
-	ctx context.Context,
-	orgName, projectName, envName string,
-) (string, error) {
-	if st.MetadataClient == nil {
-		return "", nil
-	}
-	meta, err := st.MetadataClient.GetEnvironmentMetadata(ctx, orgName, projectName, envName)
-	if err != nil {
-		return "", err
+// diffEnvironmentDefinition diffs a new definition value by value against
+// the old definition, or the old yaml parsed as one.
+func diffEnvironmentDefinition(olds, news EnvironmentInput) map[string]p.PropertyDiff {
+	oldDefinition := olds.Definition
+	if oldDefinition == nil {
+		parsed, err := parseEnvironmentDefinition(olds.Yaml)
+		if err != nil {
+			return map[string]p.PropertyDiff{gcDefinition: {Kind: p.Update, InputDiff: true}}
+		}
+		oldDefinition = parsed
 	}
-	if meta == nil {
-		return "", nil
+	diff := diffEnvironmentDefinitions(oldDefinition, news.Definition)
+	if len(diff) == 0 && (olds.Definition == nil || olds.Yaml != news.Yaml) {
+		// The same values, written differently: record the definition.
+		diff[gcDefinition] = p.PropertyDiff{Kind: p.Update, InputDiff: true}
 	}
-	return meta.ID, nil
+	return diff
 }
 
-func (st *PulumiServiceEnvironmentResource) Check(req *pulumirpc.CheckRequest) (*pulumirpc.CheckResponse, error) {
-	inputMap, err := plugin.UnmarshalProperties(
-		req.GetNews(),
-		plugin.MarshalOptions{KeepUnknowns: true, SkipNulls: true, KeepSecrets: true},
+func (e *Environment) Create(
+	ctx context.Context, req infer.CreateRequest[envIn],
+) (infer.CreateResponse[envOut], error) {
+	input := req.Inputs
+	output := EnvironmentState{EnvironmentInput: input}
+	if req.DryRun {
+		return infer.CreateResponse[envOut]{ID: output.id(), Output: output}, nil
+	}
+	client := config.GetEscClient(ctx)
+
+	// First check if yaml is valid
+	_, diagnostics, err := client.CheckYAMLEnvironment(
+		ctx,
+		input.Organization,
+		[]byte(input.Yaml),
+		esc_client.CheckYAMLOption{},
 	)
+	if diagnostics != nil {
+		return infer.CreateResponse[envOut]{}, fmt.Errorf(
+			"failed to check environment, yaml code failed following checks: %+v", diagnostics)
+	}
 	if err != nil {
-		return nil, err
+		return infer.CreateResponse[envOut]{}, fmt.Errorf("failed to check environment due to error: %+v", err)
 	}
 
-	var failures []*pulumirpc.CheckFailure
-	for _, p := range []resource.PropertyKey{gcOrganization, gcProject, gcName, gcYaml} {
-		input := inputMap[(p)]
-
-		if !input.HasValue() {
-			failures = append(failures, &pulumirpc.CheckFailure{
-				Reason:   fmt.Sprintf("missing required property '%s'", p),
-				Property: string(p),
-			})
-		} else if p != gcYaml && !input.IsComputed() && strings.Contains(util.GetSecretOrStringValue(input), "/") {
-			failures = append(failures, &pulumirpc.CheckFailure{
-				Reason:   fmt.Sprintf("'%s' property contains `/` illegal character", p),
-				Property: string(p),
-			})
+	// Then create environment, and update it with yaml provided. ESC API architecture doesn't let you do it in one call
+	restored := false
+	if input.AdoptDeleted != nil && *input.AdoptDeleted {
+		restored, err = restoreDeletedEnvironment(ctx, input)
+		if err != nil {
+			return infer.CreateResponse[envOut]{}, err
 		}
 	}
-
-	var stringYaml string
-	inputYaml := inputMap[gcYaml]
-	if !inputYaml.IsComputed() {
-		if inputYaml.IsSecret() {
-			inputYaml = inputYaml.SecretValue().Element
+	if !restored {
+		err = client.CreateEnvironmentWithProject(ctx, input.Organization, input.Project, input.Name)
+		if err != nil {
+			return infer.CreateResponse[envOut]{}, fmt.Errorf("failed to create new environment due to error: %+v", err)
 		}
+	}
 
-		// After unwrapping secret, check again if the inner value is computed
-		if !inputYaml.IsComputed() {
-			if inputYaml.IsAsset() {
-				yamlBytes, err := getBytesFromAsset(inputYaml.AssetValue())
-				if err != nil {
-					return nil, err
-				}
-				stringYaml = string(yamlBytes)
-			} else {
-				stringYaml = inputYaml.StringValue()
+	// The environment exists from here on: any later failure (including a
+	// cancellation) is reported as a partial create so the engine records it
+	// rather than leaking it.
+	unapplied := output
+	unapplied.Yaml = ""
+	if input.proposes() {
+		output, err = e.propose(ctx, input, unapplied)
+		if err != nil {
+			return infer.CreateResponse[envOut]{ID: output.id(), Output: output}, err
+		}
+	} else {
+		diagnostics, revision, err := client.UpdateEnvironmentWithRevision(
+			ctx,
+			input.Organization,
+			input.Project,
+			input.Name,
+			[]byte(input.Yaml),
+			"",
+		)
+		if diagnostics != nil {
+			return infer.CreateResponse[envOut]{ID: output.id(), Output: unapplied}, infer.ResourceInitFailedError{
+				Reasons: []string{fmt.Sprintf(
+					"failed to update brand new environment with pre-checked yaml, due to failing the following "+
+						"checks: %+v \nThis should never happen, if you're seeing this message there's likely a bug "+
+						"in ESC APIs",
+					diagnostics,
+				)},
+			}
+		}
+		if err != nil {
+			return infer.CreateResponse[envOut]{ID: output.id(), Output: unapplied}, infer.ResourceInitFailedError{
+				Reasons: []string{fmt.Sprintf("failed to push yaml into environment due to error: %+v", err)},
 			}
 		}
+		output.Revision = revision
 	}
 
-	trimmedYaml := strings.TrimSpace(stringYaml)
-	inputMap[gcYaml] = resource.MakeSecret(resource.NewStringProperty(trimmedYaml))
-
-	inputs, err := plugin.MarshalProperties(
-		inputMap,
-		plugin.MarshalOptions{KeepUnknowns: true, SkipNulls: true, KeepSecrets: true},
-	)
+	output.EnvironmentID, err = fetchEnvironmentID(ctx, input.Organization, input.Project, input.Name)
 	if err != nil {
-		return nil, err
+		return infer.CreateResponse[envOut]{ID: output.id(), Output: output}, infer.ResourceInitFailedError{
+			Reasons: []string{fmt.Sprintf("failed to resolve new environment's id: %v", err)},
+		}
 	}
 
-	return &pulumirpc.CheckResponse{Inputs: inputs, Failures: failures}, nil
+	return infer.CreateResponse[envOut]{ID: output.id(), Output: output}, nil
 }
 
-func (st *PulumiServiceEnvironmentResource) Update(req *pulumirpc.UpdateRequest) (*pulumirpc.UpdateResponse, error) {
-	input, err := ToPulumiServiceEnvironmentInput(req.GetNews())
+// restoreDeletedEnvironment restores the most recently deleted environment
+// matching the input, and reports whether there was one.
+func restoreDeletedEnvironment(ctx context.Context, input EnvironmentInput) (bool, error) {
+	client := config.GetClient(ctx)
+	deleted, err := client.ListDeletedEnvironments(ctx, input.Organization)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
+	var deletedAt string
+	for _, env := range deleted {
+		if env.Project == input.Project && env.Name == input.Name && env.DeletedAt > deletedAt {
+			deletedAt = env.DeletedAt
+		}
+	}
+	if deletedAt == "" {
+		return false, nil
+	}
+	err = client.RestoreEnvironment(ctx, input.Organization, input.Project, input.Name, deletedAt)
+	return err == nil, err
+}
 
-	diagnostics, revision, _ := st.Client.UpdateEnvironmentWithRevision(
-		context.Background(),
-		input.OrgName,
-		input.ProjectName,
-		input.EnvName,
+// fetchEnvironmentID resolves an environment's UUID via the metadata endpoint.
+// Returns "" with no error when the service has no metadata for it, so the
+// resource simply skips emitting `environmentId`.
+func fetchEnvironmentID(ctx context.Context, orgName, projectName, envName string) (string, error) {
+	meta, err := config.GetClient(ctx).GetEnvironmentMetadata(ctx, orgName, projectName, envName)
+	if err != nil {
+		return "", err
+	}
+	if meta == nil {
+		return "", nil
+	}
+	return meta.ID, nil
+}
+
+func (e *Environment) Update(
+	ctx context.Context, req infer.UpdateRequest[envIn, envOut],
+) (infer.UpdateResponse[envOut], error) {
+	input := req.Inputs
+	if req.DryRun {
+		return infer.UpdateResponse[envOut]{Output: EnvironmentState{
+			EnvironmentInput:    input,
+			Revision:            req.State.Revision,
+			EnvironmentID:       req.State.EnvironmentID,
+			ChangeRequestID:     req.State.ChangeRequestID,
+			ChangeRequestStatus: req.State.ChangeRequestStatus,
+		}}, nil
+	}
+	if input.Yaml == req.State.Yaml && req.State.pendingChangeRequest() == "" {
+		// Only settings changed: there is no content to publish.
+		return infer.UpdateResponse[envOut]{Output: EnvironmentState{
+			EnvironmentInput:    input,
+			Revision:            req.State.Revision,
+			EnvironmentID:       req.State.EnvironmentID,
+			ChangeRequestID:     req.State.ChangeRequestID,
+			ChangeRequestStatus: req.State.ChangeRequestStatus,
+		}}, nil
+	}
+	if input.proposes() {
+		output, err := e.propose(ctx, input, req.State)
+		return infer.UpdateResponse[envOut]{Output: output}, err
+	}
+
+	diagnostics, revision, err := config.GetEscClient(ctx).UpdateEnvironmentWithRevision(
+		ctx,
+		input.Organization,
+		input.Project,
+		input.Name,
 		[]byte(input.Yaml),
 		"",
 	)
 	if diagnostics != nil {
-		return nil, fmt.Errorf("failed to update environment, yaml code failed following checks: %+v", diagnostics)
+		return infer.UpdateResponse[envOut]{}, fmt.Errorf(
+			"failed to update environment, yaml code failed following checks: %+v", diagnostics)
 	}
-
-	envID, err := st.fetchEnvironmentID(context.Background(), input.OrgName, input.ProjectName, input.EnvName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolve environment's id: %w", err)
-	}
-
-	output := PulumiServiceEnvironmentOutput{
-		input:         *input,
-		revision:      revision,
-		environmentID: envID,
+		return infer.UpdateResponse[envOut]{}, fmt.Errorf("failed to update environment: %w", err)
 	}
 
-	propertyMap, err := output.ToPropertyMap()
+	envID, err := fetchEnvironmentID(ctx, input.Organization, input.Project, input.Name)
 	if err != nil {
-		return nil, err
-	}
-	outputProperties, err := plugin.MarshalProperties(
-		propertyMap,
-		plugin.MarshalOptions{
-			KeepSecrets: true,
-		},
-	)
-	if err != nil {
-		return nil, err
+		return infer.UpdateResponse[envOut]{}, fmt.Errorf("failed to resolve environment's id: %w", err)
 	}
 
-	return &pulumirpc.UpdateResponse{
-		Properties: outputProperties,
-	}, nil
+	return infer.UpdateResponse[envOut]{Output: EnvironmentState{
+		EnvironmentInput: input,
+		Revision:         revision,
+		EnvironmentID:    envID,
+	}}, nil
 }
 
-func (st *PulumiServiceEnvironmentResource) Read(req *pulumirpc.ReadRequest) (*pulumirpc.ReadResponse, error) {
+func (*Environment) Read(
+	ctx context.Context, req infer.ReadRequest[envIn, envOut],
+) (infer.ReadResponse[envIn, envOut], error) {
 	// Split Id into either:
 	//   <org>/<project>/<env> or
 	//   <org>/<env> (legacy pattern)
 	var orgName, projectName, envName string
 
-	splitID := strings.Split(req.Id, "/")
+	splitID := strings.Split(req.ID, "/")
 	switch len(splitID) {
 	case 3:
 		orgName = splitID[0]
@@ -406,11 +583,11 @@ func (st *PulumiServiceEnvironmentResource) Read(req *pulumirpc.ReadRequest) (*p
 		projectName = defaultProject
 		envName = splitID[1]
 	default:
-		return nil, fmt.Errorf("invalid environment id: %s", req.Id)
+		return infer.ReadResponse[envIn, envOut]{}, fmt.Errorf("invalid environment id: %s", req.ID)
 	}
 
-	retrievedYaml, _, revision, err := st.Client.GetEnvironment(
-		context.Background(),
+	retrievedYaml, _, revision, err := config.GetEscClient(ctx).GetEnvironment(
+		ctx,
 		orgName,
 		projectName,
 		envName,
@@ -418,69 +595,96 @@ func (st *PulumiServiceEnvironmentResource) Read(req *pulumirpc.ReadRequest) (*p
 		false,
 	)
 	if err != nil {
-		return &pulumirpc.ReadResponse{Id: "", Properties: nil}, nil
+		// A canceled read says nothing about whether the environment still
+		// exists; reporting it gone would drop it from state.
+		if ctx.Err() != nil {
+			return infer.ReadResponse[envIn, envOut]{}, fmt.Errorf("failed to read environment %q: %w", req.ID, err)
+		}
+		return infer.ReadResponse[envIn, envOut]{}, nil
 	}
 
-	stringYaml := string(retrievedYaml)
-	trimmedYaml := strings.TrimSpace(stringYaml)
-
-	input := PulumiServiceEnvironmentInput{
-		OrgName:     orgName,
-		ProjectName: projectName,
-		EnvName:     envName,
-		Yaml:        trimmedYaml,
+	input := EnvironmentInput{
+		Organization:             orgName,
+		Project:                  projectName,
+		Name:                     envName,
+		Yaml:                     strings.TrimSpace(string(retrievedYaml)),
+		RollbackToRevision:       req.State.RollbackToRevision,
+		AdoptDeleted:             req.State.AdoptDeleted,
+		ProposeViaChangeRequest:  req.State.ProposeViaChangeRequest,
+		ChangeRequestDescription: req.State.ChangeRequestDescription,
+		ApplyChangeRequest:       req.State.ApplyChangeRequest,
+	}
+	changeRequestStatus, err := readChangeRequestStatus(ctx, req.State)
+	if err != nil {
+		return infer.ReadResponse[envIn, envOut]{}, err
+	}
+	pending := req.State
+	pending.ChangeRequestStatus = changeRequestStatus
+	if pending.pendingChangeRequest() != "" {
+		// The proposed yaml stays in state until the change request settles.
+		input.Definition, input.Yaml = req.State.Definition, req.State.Yaml
+	}
+	if req.State.Definition != nil && pending.pendingChangeRequest() == "" {
+		if revision == req.State.Revision {
+			// Nothing changed since the definition, whose secrets are
+			// still plaintext, was written.
+			input.Definition, input.Yaml = req.State.Definition, req.State.Yaml
+		} else {
+			input.Definition = readEnvironmentDefinition(input.Yaml)
+		}
 	}
 
 	// Best-effort: legacy state (pre-environmentId) refreshed against an
 	// older provider build can still be missing this field. Don't fail
 	// refresh just because the metadata fetch errored — if it returns
-	// empty, ToPropertyMap simply omits `environmentId`.
-	envID, err := st.fetchEnvironmentID(context.Background(), orgName, projectName, envName)
+	// empty, `environmentId` is simply omitted.
+	envID, err := fetchEnvironmentID(ctx, orgName, projectName, envName)
 	if err != nil {
 		envID = ""
 	}
 
-	result := PulumiServiceEnvironmentOutput{
-		input:         input,
-		revision:      revision,
-		environmentID: envID,
-	}
+	return infer.ReadResponse[envIn, envOut]{
+		ID:     req.ID,
+		Inputs: input,
+		State: EnvironmentState{
+			EnvironmentInput:    input,
+			Revision:            revision,
+			EnvironmentID:       envID,
+			ChangeRequestID:     req.State.ChangeRequestID,
+			ChangeRequestStatus: changeRequestStatus,
+		},
+	}, nil
+}
 
-	inputMap, err := input.ToPropertyMap()
-	if err != nil {
-		return nil, err
+// readChangeRequestStatus returns the current status of the state's change
+// request, if it has one that still exists.
+func readChangeRequestStatus(ctx context.Context, state EnvironmentState) (*string, error) {
+	if state.ChangeRequestID == nil {
+		return nil, nil
 	}
-	inputs, err := plugin.MarshalProperties(
-		inputMap,
-		plugin.MarshalOptions{
-			KeepSecrets: true,
-		},
-	)
-	if err != nil {
+	cr, err := config.GetClient(ctx).GetChangeRequest(ctx, state.Organization, *state.ChangeRequestID)
+	if err != nil || cr == nil {
 		return nil, err
 	}
+	return &cr.Status, nil
+}
 
-	propertyMap, err := result.ToPropertyMap()
-	if err != nil {
-		return nil, err
-	}
-	properties, err := plugin.MarshalProperties(
-		propertyMap,
-		plugin.MarshalOptions{
-			KeepSecrets: true,
-		},
-	)
+// readEnvironmentDefinition parses the yaml of an environment managed
+// through `definition` that changed elsewhere. Yaml a definition can't express
+// reads as an empty definition, which the next update overwrites.
+func readEnvironmentDefinition(yaml string) *EnvironmentDefinition {
+	definition, err := parseEnvironmentDefinition(yaml)
 	if err != nil {
-		return nil, err
+		return &EnvironmentDefinition{}
 	}
-
-	return &pulumirpc.ReadResponse{
-		Id:         req.Id,
-		Properties: properties,
-		Inputs:     inputs,
-	}, nil
+	return definition
 }
 
-func (st *PulumiServiceEnvironmentResource) Name() string {
-	return "pulumiservice:index:Environment"
+func (*Environment) Delete(ctx context.Context, req infer.DeleteRequest[envOut]) (infer.DeleteResponse, error) {
+	project := req.State.Project
+	if project == "" {
+		project = defaultProject
+	}
+	err := config.GetEscClient(ctx).DeleteEnvironment(ctx, req.State.Organization, project, req.State.Name)
+	return infer.DeleteResponse{}, err
 }
@@ -0,0 +1,183 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/poll"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+const (
+	changeRequestPollInterval = 10 * time.Second
+	changeRequestTimeout      = time.Hour
+	// changeRequestApproved is the status reported while waiting for a
+	// change request that is open and satisfies every gate.
+	changeRequestApproved = "approved"
+)
+
+func (i *EnvironmentInput) proposes() bool {
+	return i.ProposeViaChangeRequest != nil && *i.ProposeViaChangeRequest
+}
+
+func (i *EnvironmentInput) appliesChangeRequest() bool {
+	return i.ApplyChangeRequest != nil && *i.ApplyChangeRequest
+}
+
+// pendingChangeRequest returns the state's change request that is still
+// waiting to be applied, if any.
+func (s *EnvironmentState) pendingChangeRequest() string {
+	if s.ChangeRequestID == nil || s.ChangeRequestStatus == nil {
+		return ""
+	}
+	switch *s.ChangeRequestStatus {
+	case pulumiapi.ChangeRequestStatusDraft, pulumiapi.ChangeRequestStatusOpen:
+		return *s.ChangeRequestID
+	}
+	return ""
+}
+
+// propose publishes the input's yaml through a change request instead of
+// updating the environment directly, and applies it once approved when
+// applyChangeRequest is set. A pending proposal of the same yaml is reused;
+// one of different yaml is closed as superseded.
+//
+// The returned state records the proposed yaml, even while the change
+// request is still pending, so the next update doesn't propose it again.
+// Failures are reported as an infer.ResourceInitFailedError, so the next
+// update tries again; a change request that was closed instead of applied
+// leaves the previous yaml in state.
+func (e *Environment) propose(
+	ctx context.Context, input EnvironmentInput, previous EnvironmentState,
+) (EnvironmentState, error) {
+	client := config.GetClient(ctx)
+	state := EnvironmentState{
+		EnvironmentInput: input,
+		Revision:         previous.Revision,
+		EnvironmentID:    previous.EnvironmentID,
+	}
+
+	id := previous.pendingChangeRequest()
+	if id != "" && previous.Yaml != input.Yaml {
+		err := client.CloseChangeRequest(ctx, input.Organization, id, "Superseded by a newer proposal.")
+		if err != nil {
+			return previous, infer.ResourceInitFailedError{Reasons: []string{err.Error()}}
+		}
+		id = ""
+	}
+	if id == "" {
+		var err error
+		id, err = openEnvironmentChangeRequest(ctx, input)
+		if err != nil {
+			return previous, infer.ResourceInitFailedError{Reasons: []string{err.Error()}}
+		}
+	}
+	status := pulumiapi.ChangeRequestStatusOpen
+	state.ChangeRequestID, state.ChangeRequestStatus = &id, &status
+	if !input.appliesChangeRequest() {
+		return state, nil
+	}
+
+	status, err := e.applyChangeRequest(ctx, client, input.Organization, id)
+	if err != nil {
+		if status == pulumiapi.ChangeRequestStatusClosed {
+			// Rejected: the next update proposes the yaml again.
+			state.Yaml, state.Definition = previous.Yaml, previous.Definition
+		} else {
+			status = pulumiapi.ChangeRequestStatusOpen
+		}
+		return state, infer.ResourceInitFailedError{Reasons: []string{err.Error()}}
+	}
+
+	_, _, revision, err := config.GetEscClient(ctx).GetEnvironment(
+		ctx, input.Organization, input.Project, input.Name, "", false,
+	)
+	if err != nil {
+		return state, infer.ResourceInitFailedError{
+			Reasons: []string{fmt.Sprintf("failed to read the applied environment: %v", err)},
+		}
+	}
+	state.Revision = revision
+	return state, nil
+}
+
+// openEnvironmentChangeRequest drafts the input's yaml and submits the
+// draft as a change request, whose ID it returns.
+func openEnvironmentChangeRequest(ctx context.Context, input EnvironmentInput) (string, error) {
+	escClient := config.GetEscClient(ctx)
+	id, diagnostics, err := escClient.CreateEnvironmentDraft(
+		ctx,
+		input.Organization,
+		input.Project,
+		input.Name,
+		[]byte(input.Yaml),
+		"",
+	)
+	if diagnostics != nil {
+		return "", fmt.Errorf("failed to draft environment, yaml code failed following checks: %+v", diagnostics)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to draft environment: %w", err)
+	}
+	if err := escClient.SubmitChangeRequest(ctx, input.Organization, id, input.ChangeRequestDescription); err != nil {
+		return "", fmt.Errorf("failed to submit change request %s: %w", id, err)
+	}
+	return id, nil
+}
+
+// applyChangeRequest waits for the change request to be approved and
+// applies it, returning its last status. A change request applied in the
+// meantime is left as is.
+func (e *Environment) applyChangeRequest(
+	ctx context.Context, client config.Client, orgName, id string,
+) (string, error) {
+	opts := e.changeRequestPoll
+	if opts.Interval == 0 {
+		opts.Interval = changeRequestPollInterval
+	}
+	if _, bounded := ctx.Deadline(); opts.Timeout == 0 && !bounded {
+		opts.Timeout = changeRequestTimeout
+	}
+	states := poll.States{
+		Success: []string{changeRequestApproved, pulumiapi.ChangeRequestStatusApplied},
+		Failure: []string{pulumiapi.ChangeRequestStatusClosed},
+	}
+	status, err := poll.Status(ctx, opts, states, func(ctx context.Context) (string, error) {
+		cr, err := client.GetChangeRequest(ctx, orgName, id)
+		if err != nil || cr == nil {
+			return "", err
+		}
+		if cr.Status == pulumiapi.ChangeRequestStatusOpen && cr.Approved {
+			return changeRequestApproved, nil
+		}
+		return cr.Status, nil
+	})
+	if err != nil {
+		return status, fmt.Errorf("waiting for change request %s to be approved: %w", id, err)
+	}
+	if status == pulumiapi.ChangeRequestStatusApplied {
+		return status, nil
+	}
+	if err := client.ApplyChangeRequest(ctx, orgName, id); err != nil {
+		return status, err
+	}
+	return pulumiapi.ChangeRequestStatusApplied, nil
+}
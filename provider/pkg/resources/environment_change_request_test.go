@@ -0,0 +1,210 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/poll"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+// changeRequestClientMock moves every change request through the given
+// states, one per GetChangeRequest call.
+type changeRequestClientMock struct {
+	environmentMetadataClientMock
+	states  []pulumiapi.ChangeRequest
+	applied []string
+	closed  []string
+}
+
+func (c *changeRequestClientMock) GetChangeRequest(
+	_ context.Context, _, id string,
+) (*pulumiapi.ChangeRequest, error) {
+	if len(c.states) == 0 {
+		return nil, nil
+	}
+	cr := c.states[0]
+	if len(c.states) > 1 {
+		c.states = c.states[1:]
+	}
+	cr.ID = id
+	return &cr, nil
+}
+
+func (c *changeRequestClientMock) ApplyChangeRequest(_ context.Context, _, id string) error {
+	c.applied = append(c.applied, id)
+	return nil
+}
+
+func (c *changeRequestClientMock) CloseChangeRequest(_ context.Context, _, id, _ string) error {
+	c.closed = append(c.closed, id)
+	return nil
+}
+
+func proposingEnvironment(yaml string, apply bool) EnvironmentInput {
+	propose := true
+	return EnvironmentInput{
+		Organization: gcOrg, Project: gcProject, Name: gcEnv, Yaml: yaml,
+		ProposeViaChangeRequest: &propose, ApplyChangeRequest: &apply,
+	}
+}
+
+func TestEnvironmentProposeViaChangeRequest(t *testing.T) {
+	e := &Environment{changeRequestPoll: poll.Options{Interval: time.Millisecond}}
+	previous := EnvironmentState{
+		EnvironmentInput: EnvironmentInput{Organization: gcOrg, Project: gcProject, Name: gcEnv, Yaml: "values: {}"},
+		Revision:         3,
+	}
+	setup := func(states ...pulumiapi.ChangeRequest) (context.Context, *EscClientMock, *changeRequestClientMock) {
+		escClient := buildEscClientMock(
+			func(context.Context, string, string, string, bool) ([]byte, string, int, error) {
+				return []byte("values:\n  foo: bar"), "", 4, nil
+			},
+			nil,
+		)
+		client := &changeRequestClientMock{states: states}
+		return config.WithMockEscClient(config.WithMockClient(t.Context(), client), escClient), escClient, client
+	}
+
+	t.Run("opens a change request", func(t *testing.T) {
+		ctx, escClient, client := setup()
+		resp, err := e.Update(ctx, infer.UpdateRequest[envIn, envOut]{
+			State: previous, Inputs: proposingEnvironment("values:\n  foo: bar", false),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"values:\n  foo: bar"}, escClient.drafts)
+		assert.Equal(t, []string{"cr-1"}, escClient.submitted)
+		assert.Empty(t, client.applied)
+		assert.Equal(t, "cr-1", *resp.Output.ChangeRequestID)
+		assert.Equal(t, "open", *resp.Output.ChangeRequestStatus)
+		assert.Equal(t, "values:\n  foo: bar", resp.Output.Yaml, "the proposed yaml is recorded")
+		assert.Equal(t, 3, resp.Output.Revision)
+	})
+
+	t.Run("applies once approved", func(t *testing.T) {
+		ctx, _, client := setup(
+			pulumiapi.ChangeRequest{Status: "open"},
+			pulumiapi.ChangeRequest{Status: "open", Approved: true},
+		)
+		resp, err := e.Update(ctx, infer.UpdateRequest[envIn, envOut]{
+			State: previous, Inputs: proposingEnvironment("values:\n  foo: bar", true),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cr-1"}, client.applied)
+		assert.Equal(t, "applied", *resp.Output.ChangeRequestStatus)
+		assert.Equal(t, 4, resp.Output.Revision)
+	})
+
+	t.Run("a closed change request keeps the previous yaml", func(t *testing.T) {
+		ctx, _, client := setup(pulumiapi.ChangeRequest{Status: "closed"})
+		resp, err := e.Update(ctx, infer.UpdateRequest[envIn, envOut]{
+			State: previous, Inputs: proposingEnvironment("values:\n  foo: bar", true),
+		})
+		var initErr infer.ResourceInitFailedError
+		require.ErrorAs(t, err, &initErr)
+		assert.Empty(t, client.applied)
+		assert.Equal(t, "closed", *resp.Output.ChangeRequestStatus)
+		assert.Equal(t, "values: {}", resp.Output.Yaml)
+	})
+
+	t.Run("reuses a pending proposal of the same yaml", func(t *testing.T) {
+		ctx, escClient, client := setup(pulumiapi.ChangeRequest{Status: "open", Approved: true})
+		id, status := "cr-7", "open"
+		pending := previous
+		pending.Yaml = "values:\n  foo: bar"
+		pending.ChangeRequestID, pending.ChangeRequestStatus = &id, &status
+
+		resp, err := e.Update(ctx, infer.UpdateRequest[envIn, envOut]{
+			State: pending, Inputs: proposingEnvironment("values:\n  foo: bar", true),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, escClient.drafts)
+		assert.Equal(t, []string{"cr-7"}, client.applied)
+		assert.Equal(t, "cr-7", *resp.Output.ChangeRequestID)
+	})
+
+	t.Run("supersedes a pending proposal of other yaml", func(t *testing.T) {
+		ctx, escClient, client := setup()
+		id, status := "cr-7", "open"
+		pending := previous
+		pending.Yaml = "values:\n  foo: baz"
+		pending.ChangeRequestID, pending.ChangeRequestStatus = &id, &status
+
+		resp, err := e.Update(ctx, infer.UpdateRequest[envIn, envOut]{
+			State: pending, Inputs: proposingEnvironment("values:\n  foo: bar", false),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cr-7"}, client.closed)
+		assert.Len(t, escClient.drafts, 1)
+		assert.Equal(t, "cr-1", *resp.Output.ChangeRequestID)
+	})
+
+	t.Run("switching to proposals only records it", func(t *testing.T) {
+		ctx, escClient, _ := setup()
+		news := proposingEnvironment("values: {}", false)
+		diff, err := e.Diff(ctx, infer.DiffRequest[envIn, envOut]{State: previous, Inputs: news})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]p.PropertyDiff{
+			"proposeViaChangeRequest": {Kind: p.Update, InputDiff: true},
+		}, diff.DetailedDiff)
+
+		resp, err := e.Update(ctx, infer.UpdateRequest[envIn, envOut]{State: previous, Inputs: news})
+		require.NoError(t, err)
+		assert.Empty(t, escClient.drafts)
+		assert.Nil(t, resp.Output.ChangeRequestID)
+		assert.True(t, resp.Output.proposes())
+		assert.Equal(t, 3, resp.Output.Revision)
+	})
+
+	t.Run("Read keeps a pending proposal", func(t *testing.T) {
+		ctx, _, _ := setup(pulumiapi.ChangeRequest{Status: "open"})
+		id, status := "cr-1", "open"
+		pending := previous
+		pending.EnvironmentInput = proposingEnvironment("values:\n  foo: qux", false)
+		pending.ChangeRequestID, pending.ChangeRequestStatus = &id, &status
+
+		resp, err := e.Read(ctx, infer.ReadRequest[envIn, envOut]{
+			ID: pending.id(), Inputs: pending.EnvironmentInput, State: pending,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "values:\n  foo: qux", resp.State.Yaml)
+		assert.Equal(t, "open", *resp.State.ChangeRequestStatus)
+	})
+
+	t.Run("Read shows a settled proposal", func(t *testing.T) {
+		ctx, _, _ := setup(pulumiapi.ChangeRequest{Status: "closed"})
+		id, status := "cr-1", "open"
+		pending := previous
+		pending.EnvironmentInput = proposingEnvironment("values:\n  foo: qux", false)
+		pending.ChangeRequestID, pending.ChangeRequestStatus = &id, &status
+
+		resp, err := e.Read(ctx, infer.ReadRequest[envIn, envOut]{
+			ID: pending.id(), Inputs: pending.EnvironmentInput, State: pending,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "values:\n  foo: bar", resp.State.Yaml)
+		assert.Equal(t, "closed", *resp.State.ChangeRequestStatus)
+	})
+}
@@ -0,0 +1,261 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	gcDefinition           = "definition"
+	gcImports              = "imports"
+	gcValues               = "values"
+	gcEnvironmentVariables = "environmentVariables"
+	gcFiles                = "files"
+
+	escSecretBuiltin = "fn::secret"
+)
+
+// EnvironmentDefinition is the structured form of an environment's YAML. The
+// environmentVariables and files projections live under values in YAML.
+type EnvironmentDefinition struct {
+	Imports              []string       `pulumi:"imports,optional"`
+	Values               map[string]any `pulumi:"values,optional"`
+	EnvironmentVariables map[string]any `pulumi:"environmentVariables,optional"`
+	Files                map[string]any `pulumi:"files,optional"`
+}
+
+func (d *EnvironmentDefinition) Annotate(a infer.Annotator) {
+	a.Describe(&d.Imports, "Environments to import, e.g. `my-project/base`. Later imports take precedence.")
+	a.Describe(&d.Values, "The environment's values. Values may be nested maps and lists, interpolations such "+
+		"as `${aws.region}`, or `fn::` builtins such as `{\"fn::open::aws-login\": {...}}`. Pulumi secrets are "+
+		"stored as `fn::secret`.")
+	a.Describe(&d.EnvironmentVariables, "Environment variables to export, by name. Stored as "+
+		"`values.environmentVariables`.")
+	a.Describe(&d.Files, "Files to export, by the name of the environment variable that holds each file's path. "+
+		"Stored as `values.files`.")
+}
+
+// environmentDocument is the YAML layout of an environment definition.
+type environmentDocument struct {
+	Imports []string       `yaml:"imports,omitempty"`
+	Values  map[string]any `yaml:"values,omitempty"`
+}
+
+// toYAML serializes the definition to canonical YAML: keys are sorted, so
+// equal definitions always produce the same text.
+func (d *EnvironmentDefinition) toYAML() (string, error) {
+	values := maps.Clone(d.Values)
+	for key, projection := range map[string]map[string]any{
+		gcEnvironmentVariables: d.EnvironmentVariables,
+		gcFiles:                d.Files,
+	} {
+		if projection == nil {
+			continue
+		}
+		if _, ok := values[key]; ok {
+			return "", fmt.Errorf("`%s` is set both as a field and under `values`", key)
+		}
+		if values == nil {
+			values = map[string]any{}
+		}
+		values[key] = projection
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(environmentDocument{Imports: d.Imports, Values: values}); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	text := strings.TrimSpace(buf.String())
+	if text == "{}" {
+		return "", nil
+	}
+	return text, nil
+}
+
+// parseEnvironmentDefinition parses environment YAML into a definition. It
+// fails on YAML that uses keys a definition can't hold.
+func parseEnvironmentDefinition(text string) (*EnvironmentDefinition, error) {
+	var raw map[string]any
+	if err := yaml.Unmarshal([]byte(text), &raw); err != nil {
+		return nil, err
+	}
+	for key := range raw {
+		if key != gcImports && key != gcValues {
+			return nil, fmt.Errorf("unsupported top-level key %q", key)
+		}
+	}
+	var doc environmentDocument
+	if err := yaml.Unmarshal([]byte(text), &doc); err != nil {
+		return nil, err
+	}
+	// YAML and Pulumi disagree on numbers; JSON gives both the same shape.
+	if err := remarshalJSON(doc.Values, &doc.Values); err != nil {
+		return nil, err
+	}
+	def := &EnvironmentDefinition{Imports: doc.Imports, Values: doc.Values}
+	for key, field := range map[string]*map[string]any{
+		gcEnvironmentVariables: &def.EnvironmentVariables,
+		gcFiles:                &def.Files,
+	} {
+		projection, ok := def.Values[key].(map[string]any)
+		if !ok {
+			continue
+		}
+		*field = projection
+		delete(def.Values, key)
+	}
+	if len(def.Values) == 0 {
+		def.Values = nil
+	}
+	return def, nil
+}
+
+func remarshalJSON(src, dst any) error {
+	b, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dst)
+}
+
+// wrapDefinitionSecrets rewrites the Pulumi secrets in a raw definition as
+// `fn::secret` builtins, so ESC encrypts them too. The wrapped values stay
+// Pulumi secrets.
+func wrapDefinitionSecrets(def property.Value) property.Value {
+	if !def.IsMap() {
+		return def
+	}
+	m := def.AsMap()
+	for _, key := range []string{gcValues, gcEnvironmentVariables, gcFiles} {
+		if v, ok := m.GetOk(key); ok {
+			m = m.Set(key, wrapSecrets(v, false))
+		}
+	}
+	return property.New(m).WithSecret(def.Secret())
+}
+
+func wrapSecrets(v property.Value, secret bool) property.Value {
+	secret = secret || v.Secret()
+	switch {
+	case v.IsComputed():
+		return v
+	case v.IsMap():
+		m := v.AsMap()
+		if _, ok := m.GetOk(escSecretBuiltin); ok && m.Len() == 1 {
+			return v
+		}
+		elems := make(map[string]property.Value, m.Len())
+		for k, e := range m.All {
+			elems[k] = wrapSecrets(e, secret)
+		}
+		return property.New(elems)
+	case v.IsArray():
+		elems := make([]property.Value, 0, v.AsArray().Len())
+		for _, e := range v.AsArray().All {
+			elems = append(elems, wrapSecrets(e, secret))
+		}
+		return property.New(elems)
+	case secret:
+		return property.New(map[string]property.Value{escSecretBuiltin: v.WithSecret(true)})
+	default:
+		return v
+	}
+}
+
+// diffEnvironmentDefinitions reports the changes between two definitions,
+// keyed by their property path under `definition`.
+func diffEnvironmentDefinitions(olds, news *EnvironmentDefinition) map[string]p.PropertyDiff {
+	diff := map[string]p.PropertyDiff{}
+	diffDefinitionValues(diff, resource.PropertyPath{gcDefinition}, olds.toMap(), news.toMap())
+	return diff
+}
+
+func (d *EnvironmentDefinition) toMap() map[string]any {
+	m := map[string]any{}
+	if d == nil {
+		return m
+	}
+	var imports any
+	if d.Imports != nil {
+		imports = d.Imports
+	}
+	for k, v := range map[string]any{
+		gcImports:              imports,
+		gcValues:               d.Values,
+		gcEnvironmentVariables: d.EnvironmentVariables,
+		gcFiles:                d.Files,
+	} {
+		var normalized any
+		if err := remarshalJSON(v, &normalized); err == nil && normalized != nil {
+			m[k] = normalized
+		}
+	}
+	return m
+}
+
+func diffDefinitionValues(diff map[string]p.PropertyDiff, path resource.PropertyPath, olds, news any) {
+	oldMap, oldIsMap := olds.(map[string]any)
+	newMap, newIsMap := news.(map[string]any)
+	// A builtin, such as fn::secret, changes as a whole.
+	if oldIsMap && newIsMap && !isBuiltin(oldMap) && !isBuiltin(newMap) {
+		keys := slices.Collect(maps.Keys(oldMap))
+		for k := range newMap {
+			if _, ok := oldMap[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		for _, k := range keys {
+			diffDefinitionValues(diff, append(slices.Clip(path), k), oldMap[k], newMap[k])
+		}
+		return
+	}
+	switch {
+	case reflect.DeepEqual(olds, news):
+	case olds == nil:
+		diff[path.String()] = p.PropertyDiff{Kind: p.Add, InputDiff: true}
+	case news == nil:
+		diff[path.String()] = p.PropertyDiff{Kind: p.Delete, InputDiff: true}
+	default:
+		diff[path.String()] = p.PropertyDiff{Kind: p.Update, InputDiff: true}
+	}
+}
+
+func isBuiltin(m map[string]any) bool {
+	if len(m) != 1 {
+		return false
+	}
+	for k := range m {
+		return strings.HasPrefix(k, "fn::")
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/util"
+)
+
+type EnvironmentRevisionRetraction struct{}
+
+var (
+	_ infer.CustomCreate[
+		EnvironmentRevisionRetractionInput, EnvironmentRevisionRetractionState,
+	] = &EnvironmentRevisionRetraction{}
+	_ infer.CustomDelete[EnvironmentRevisionRetractionState] = &EnvironmentRevisionRetraction{}
+	_ infer.CustomRead[
+		EnvironmentRevisionRetractionInput, EnvironmentRevisionRetractionState,
+	] = &EnvironmentRevisionRetraction{}
+)
+
+func (*EnvironmentRevisionRetraction) Annotate(a infer.Annotator) {
+	a.Describe(&EnvironmentRevisionRetraction{}, "Retracts a revision of an environment. Opening a retracted "+
+		"revision, directly or through a tag, opens its replacement instead.\n\n"+
+		"A retraction can't be undone: destroying this resource only removes it from the stack.\n\n"+
+		"### Import\n\n"+
+		"A retraction can be imported using the `id`, which is `{org}/{project}/{environment}/{revision}`, e.g.,\n\n"+
+		"```sh\n $ pulumi import pulumiservice:index:EnvironmentRevisionRetraction bad-rev my-org/my-project/"+
+		"my-env/7\n```\n\n")
+	a.SetToken("index", "EnvironmentRevisionRetraction")
+}
+
+type EnvironmentRevisionRetractionInput struct {
+	Organization string  `pulumi:"organization"         provider:"replaceOnChanges"`
+	Project      string  `pulumi:"project,optional"     provider:"replaceOnChanges"`
+	Environment  string  `pulumi:"environment"          provider:"replaceOnChanges"`
+	Revision     int     `pulumi:"revision"             provider:"replaceOnChanges"`
+	Replacement  *int    `pulumi:"replacement,optional" provider:"replaceOnChanges"`
+	Reason       *string `pulumi:"reason,optional"      provider:"replaceOnChanges"`
+}
+
+func (i *EnvironmentRevisionRetractionInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.Organization, "Organization name.")
+	a.Describe(&i.Project, "Project name.")
+	a.SetDefault(&i.Project, defaultProject)
+	a.Describe(&i.Environment, "Environment name.")
+	a.Describe(&i.Revision, "The revision to retract. The latest revision can't be retracted.")
+	a.Describe(&i.Replacement, "The revision that replaces the retracted one. Defaults to the closest earlier "+
+		"revision that isn't retracted.")
+	a.Describe(&i.Reason, "Why the revision is retracted.")
+}
+
+type EnvironmentRevisionRetractionState struct {
+	EnvironmentRevisionRetractionInput
+}
+
+func (i *EnvironmentRevisionRetractionInput) id() string {
+	return path.Join(i.Organization, i.Project, i.Environment, strconv.Itoa(i.Revision))
+}
+
+func (*EnvironmentRevisionRetraction) Create(
+	ctx context.Context,
+	req infer.CreateRequest[EnvironmentRevisionRetractionInput],
+) (infer.CreateResponse[EnvironmentRevisionRetractionState], error) {
+	if req.DryRun {
+		return infer.CreateResponse[EnvironmentRevisionRetractionState]{
+			Output: EnvironmentRevisionRetractionState{EnvironmentRevisionRetractionInput: req.Inputs},
+		}, nil
+	}
+	err := config.GetEscClient(ctx).RetractEnvironmentRevision(
+		ctx,
+		req.Inputs.Organization,
+		req.Inputs.Project,
+		req.Inputs.Environment,
+		strconv.Itoa(req.Inputs.Revision),
+		req.Inputs.Replacement,
+		util.OrZero(req.Inputs.Reason),
+	)
+	if err != nil {
+		return infer.CreateResponse[EnvironmentRevisionRetractionState]{}, fmt.Errorf(
+			"error retracting revision %d of environment %s/%s/%s: %w", req.Inputs.Revision,
+			req.Inputs.Organization, req.Inputs.Project, req.Inputs.Environment, err,
+		)
+	}
+	return infer.CreateResponse[EnvironmentRevisionRetractionState]{
+		ID:     req.Inputs.id(),
+		Output: EnvironmentRevisionRetractionState{EnvironmentRevisionRetractionInput: req.Inputs},
+	}, nil
+}
+
+func (*EnvironmentRevisionRetraction) Delete(
+	context.Context,
+	infer.DeleteRequest[EnvironmentRevisionRetractionState],
+) (infer.DeleteResponse, error) {
+	// ESC can't un-retract a revision.
+	return infer.DeleteResponse{}, nil
+}
+
+func (*EnvironmentRevisionRetraction) Read(
+	ctx context.Context,
+	req infer.ReadRequest[EnvironmentRevisionRetractionInput, EnvironmentRevisionRetractionState],
+) (infer.ReadResponse[EnvironmentRevisionRetractionInput, EnvironmentRevisionRetractionState], error) {
+	s := strings.Split(req.ID, "/")
+	var revision int
+	var err error
+	if len(s) == 4 {
+		revision, err = strconv.Atoi(s[3])
+	}
+	if len(s) != 4 || err != nil {
+		return infer.ReadResponse[EnvironmentRevisionRetractionInput, EnvironmentRevisionRetractionState]{},
+			fmt.Errorf("%q is invalid, must be in the format: organization/project/environment/revision", req.ID)
+	}
+
+	rev, err := config.GetEscClient(ctx).GetEnvironmentRevision(ctx, s[0], s[1], s[2], revision)
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		return infer.ReadResponse[EnvironmentRevisionRetractionInput, EnvironmentRevisionRetractionState]{},
+			fmt.Errorf("failed to read EnvironmentRevisionRetraction (%q): %w", req.ID, err)
+	}
+	if rev == nil || rev.Number != revision || rev.Retracted == nil {
+		return infer.ReadResponse[EnvironmentRevisionRetractionInput, EnvironmentRevisionRetractionState]{}, nil
+	}
+
+	inputs := EnvironmentRevisionRetractionInput{
+		Organization: s[0],
+		Project:      s[1],
+		Environment:  s[2],
+		Revision:     revision,
+		Replacement:  &rev.Retracted.Replacement,
+	}
+	if rev.Retracted.Reason != "" {
+		inputs.Reason = &rev.Retracted.Reason
+	}
+	// Keep a replacement chosen by the service out of declared inputs.
+	if req.Inputs.Environment != "" && req.Inputs.Replacement == nil {
+		inputs.Replacement = nil
+	}
+	return infer.ReadResponse[EnvironmentRevisionRetractionInput, EnvironmentRevisionRetractionState]{
+		ID:     req.ID,
+		Inputs: inputs,
+		State:  EnvironmentRevisionRetractionState{EnvironmentRevisionRetractionInput: inputs},
+	}, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/esc/cmd/esc/cli/client"
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+)
+
+type (
+	retractionIn  = EnvironmentRevisionRetractionInput
+	retractionOut = EnvironmentRevisionRetractionState
+)
+
+func TestEnvironmentRevisionRetractionCreate(t *testing.T) {
+	escClient := buildEscClientMock(nil, nil)
+	ctx := config.WithMockEscClient(t.Context(), escClient)
+	replacement, reason := 5, "leaked credentials"
+
+	resp, err := (&EnvironmentRevisionRetraction{}).Create(ctx, infer.CreateRequest[retractionIn]{
+		Inputs: retractionIn{
+			Organization: gcOrg, Project: gcProject, Environment: gcEnv,
+			Revision: 7, Replacement: &replacement, Reason: &reason,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, gcOrg+"/"+gcProject+"/"+gcEnv+"/7", resp.ID)
+	assert.Equal(t, []retraction{{version: "7", replacement: &replacement, reason: reason}}, escClient.retractions)
+}
+
+func TestEnvironmentRevisionRetractionRead(t *testing.T) {
+	id := gcOrg + "/" + gcProject + "/" + gcEnv + "/7"
+	read := func(t *testing.T, revision *client.EnvironmentRevision, inputs retractionIn) (
+		infer.ReadResponse[retractionIn, retractionOut], error,
+	) {
+		escClient := buildEscClientMock(nil, nil)
+		escClient.environmentRevision = revision
+		return (&EnvironmentRevisionRetraction{}).Read(
+			config.WithMockEscClient(t.Context(), escClient),
+			infer.ReadRequest[retractionIn, retractionOut]{ID: id, Inputs: inputs},
+		)
+	}
+	retracted := &client.EnvironmentRevision{
+		Number:    7,
+		Retracted: &client.EnvironmentRevisionRetracted{Replacement: 6, Reason: "leaked credentials"},
+	}
+
+	t.Run("import reads the retraction", func(t *testing.T) {
+		resp, err := read(t, retracted, retractionIn{})
+		require.NoError(t, err)
+		assert.Equal(t, id, resp.ID)
+		assert.Equal(t, 7, resp.Inputs.Revision)
+		assert.Equal(t, 6, *resp.Inputs.Replacement)
+		assert.Equal(t, "leaked credentials", *resp.Inputs.Reason)
+	})
+
+	t.Run("a defaulted replacement stays unset", func(t *testing.T) {
+		resp, err := read(t, retracted, retractionIn{Organization: gcOrg, Project: gcProject, Environment: gcEnv})
+		require.NoError(t, err)
+		assert.Nil(t, resp.Inputs.Replacement)
+	})
+
+	t.Run("a revision that isn't retracted is gone", func(t *testing.T) {
+		resp, err := read(t, &client.EnvironmentRevision{Number: 7}, retractionIn{})
+		require.NoError(t, err)
+		assert.Empty(t, resp.ID)
+	})
+
+	t.Run("a missing revision is gone", func(t *testing.T) {
+		resp, err := read(t, &client.EnvironmentRevision{Number: 6, Retracted: retracted.Retracted}, retractionIn{})
+		require.NoError(t, err)
+		assert.Empty(t, resp.ID)
+	})
+
+	t.Run("an invalid id is rejected", func(t *testing.T) {
+		_, err := (&EnvironmentRevisionRetraction{}).Read(t.Context(), infer.ReadRequest[retractionIn, retractionOut]{
+			ID: gcOrg + "/" + gcEnv,
+		})
+		assert.ErrorContains(t, err, "is invalid")
+	})
+}
@@ -0,0 +1,352 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/pulumi/esc/schema"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+	"gopkg.in/yaml.v3"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+type EnvironmentRotation struct{}
+
+// rotationStatusFailed is the status of a rotation that failed to rotate
+// at least one secret.
+const rotationStatusFailed = "failed"
+
+var (
+	_ infer.CustomCreate[EnvironmentRotationInput, EnvironmentRotationState] = &EnvironmentRotation{}
+	_ infer.CustomUpdate[EnvironmentRotationInput, EnvironmentRotationState] = &EnvironmentRotation{}
+	_ infer.CustomRead[EnvironmentRotationInput, EnvironmentRotationState]   = &EnvironmentRotation{}
+)
+
+func (r *EnvironmentRotation) Annotate(a infer.Annotator) {
+	a.Describe(r, "Rotates the secrets of an ESC environment on demand, e.g. after a credential leak.\n\n"+
+		"The rotation runs again whenever an input changes, such as one of the `triggers`. "+
+		"Deleting the resource only forgets the rotation: rotated secrets stay rotated.\n\n"+
+		"A rotation that fails is recorded with its status; the next `pulumi up` runs it again.")
+	a.SetToken("index", "EnvironmentRotation")
+}
+
+type EnvironmentRotationInput struct {
+	Organization string   `pulumi:"organization"     provider:"replaceOnChanges"`
+	Project      string   `pulumi:"project,optional" provider:"replaceOnChanges"`
+	Environment  string   `pulumi:"environment"      provider:"replaceOnChanges"`
+	Paths        []string `pulumi:"paths,optional"`
+	Triggers     []any    `pulumi:"triggers,optional"`
+}
+
+func (i *EnvironmentRotationInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.Organization, "Organization name.")
+	a.Describe(&i.Project, "Project name.")
+	a.SetDefault(&i.Project, defaultProject)
+	a.Describe(&i.Environment, "Environment name.")
+	a.Describe(&i.Paths, "Paths of the rotated secrets within the environment, e.g. `db.password`. "+
+		"Defaults to every rotated secret of the environment.")
+	a.Describe(&i.Triggers, "Arbitrary values that, when changed, rotate the secrets again.")
+}
+
+type EnvironmentRotationState struct {
+	EnvironmentRotationInput
+	EnvironmentRotationEvent
+}
+
+// EnvironmentRotationEvent is the outcome of one rotation of an
+// environment's secrets.
+type EnvironmentRotationEvent struct {
+	RotationID           string                      `pulumi:"rotationId"`
+	Status               string                      `pulumi:"status"`
+	Created              string                      `pulumi:"created"`
+	Completed            *string                     `pulumi:"completed,optional"`
+	PreRotationRevision  int                         `pulumi:"preRotationRevision"`
+	PostRotationRevision *int                        `pulumi:"postRotationRevision,optional"`
+	ScheduleID           *string                     `pulumi:"scheduleId,optional"`
+	ErrorMessage         *string                     `pulumi:"errorMessage,optional"`
+	Secrets              []EnvironmentSecretRotation `pulumi:"secrets"`
+}
+
+func (e *EnvironmentRotationEvent) Annotate(a infer.Annotator) {
+	a.Describe(&e.RotationID, "The ID of the rotation, assigned by Pulumi Cloud.")
+	a.Describe(&e.Status, "The status of the rotation as last observed, e.g. `completed` or `failed`.")
+	a.Describe(&e.Created, "When the rotation started, in RFC 3339 format.")
+	a.Describe(&e.Completed, "When the rotation finished, in RFC 3339 format.")
+	a.Describe(&e.PreRotationRevision, "The environment's revision before the rotation.")
+	a.Describe(&e.PostRotationRevision, "The environment's revision holding the rotated secrets.")
+	a.Describe(&e.ScheduleID, "The ID of the rotation schedule that ran the rotation, if any.")
+	a.Describe(&e.ErrorMessage, "Why the rotation failed.")
+	a.Describe(&e.Secrets, "The outcome for each rotated secret.")
+}
+
+type EnvironmentSecretRotation struct {
+	Path         string  `pulumi:"path"`
+	Status       string  `pulumi:"status"`
+	ErrorMessage *string `pulumi:"errorMessage,optional"`
+}
+
+func (s *EnvironmentSecretRotation) Annotate(a infer.Annotator) {
+	a.Describe(&s.Path, "The secret's path within the environment.")
+	a.Describe(&s.Status, "The status of the secret's rotation.")
+	a.Describe(&s.ErrorMessage, "Why the secret's rotation failed.")
+}
+
+// EnvironmentRotationEventFromAPI converts a rotation read from Pulumi Cloud.
+func EnvironmentRotationEventFromAPI(event pulumiapi.SecretRotationEvent) EnvironmentRotationEvent {
+	converted := EnvironmentRotationEvent{
+		RotationID:           event.ID,
+		Status:               event.Status,
+		Created:              event.Created,
+		PreRotationRevision:  event.PreRotationRevision,
+		PostRotationRevision: event.PostRotationRevision,
+		Completed:            stringPtrIfNonEmpty(event.Completed),
+		ScheduleID:           stringPtrIfNonEmpty(event.ScheduleID),
+		ErrorMessage:         stringPtrIfNonEmpty(event.ErrorMessage),
+		Secrets:              make([]EnvironmentSecretRotation, 0, len(event.Rotations)),
+	}
+	for _, rotation := range event.Rotations {
+		converted.Secrets = append(converted.Secrets, EnvironmentSecretRotation{
+			Path:         rotation.EnvironmentPath,
+			Status:       rotation.Status,
+			ErrorMessage: stringPtrIfNonEmpty(rotation.ErrorMessage),
+		})
+	}
+	return converted
+}
+
+func (i *EnvironmentRotationInput) environmentIdentifier() pulumiapi.EnvironmentIdentifier {
+	return pulumiapi.EnvironmentIdentifier{OrgName: i.Organization, ProjectName: i.Project, EnvName: i.Environment}
+}
+
+func (r *EnvironmentRotation) Create(
+	ctx context.Context,
+	req infer.CreateRequest[EnvironmentRotationInput],
+) (infer.CreateResponse[EnvironmentRotationState], error) {
+	if req.DryRun {
+		return infer.CreateResponse[EnvironmentRotationState]{
+			Output: EnvironmentRotationState{EnvironmentRotationInput: req.Inputs},
+		}, nil
+	}
+	state, err := r.rotate(ctx, req.Inputs)
+	if state == nil {
+		return infer.CreateResponse[EnvironmentRotationState]{}, err
+	}
+	return infer.CreateResponse[EnvironmentRotationState]{
+		ID:     path.Join(req.Inputs.environmentIdentifier().String(), state.RotationID),
+		Output: *state,
+	}, err
+}
+
+func (r *EnvironmentRotation) Update(
+	ctx context.Context,
+	req infer.UpdateRequest[EnvironmentRotationInput, EnvironmentRotationState],
+) (infer.UpdateResponse[EnvironmentRotationState], error) {
+	if req.DryRun {
+		return infer.UpdateResponse[EnvironmentRotationState]{
+			Output: EnvironmentRotationState{EnvironmentRotationInput: req.Inputs},
+		}, nil
+	}
+	state, err := r.rotate(ctx, req.Inputs)
+	if state == nil {
+		return infer.UpdateResponse[EnvironmentRotationState]{}, err
+	}
+	return infer.UpdateResponse[EnvironmentRotationState]{Output: *state}, err
+}
+
+// rotate rotates the environment's secrets. A rotation that ran but failed
+// is returned together with an infer.ResourceInitFailedError, so the next
+// update rotates again.
+func (*EnvironmentRotation) rotate(ctx context.Context, input EnvironmentRotationInput) (
+	*EnvironmentRotationState, error,
+) {
+	env := input.environmentIdentifier()
+	event, err := config.GetClient(ctx).RotateEnvironment(ctx, env, input.Paths)
+	if err != nil {
+		return nil, err
+	}
+	state := &EnvironmentRotationState{
+		EnvironmentRotationInput: input,
+		EnvironmentRotationEvent: EnvironmentRotationEventFromAPI(*event),
+	}
+	if failure := rotationFailure(*event); failure != "" {
+		return state, infer.ResourceInitFailedError{Reasons: []string{
+			fmt.Sprintf("rotation %s of environment %s failed: %s", event.ID, env, failure),
+		}}
+	}
+	return state, nil
+}
+
+// rotationFailure describes why the rotation failed, or returns "" if it
+// didn't.
+func rotationFailure(event pulumiapi.SecretRotationEvent) string {
+	var reasons []string
+	if event.ErrorMessage != "" {
+		reasons = append(reasons, event.ErrorMessage)
+	}
+	for _, rotation := range event.Rotations {
+		if rotation.ErrorMessage != "" || strings.EqualFold(rotation.Status, rotationStatusFailed) {
+			msg := rotation.ErrorMessage
+			if msg == "" {
+				msg = "status " + rotation.Status
+			}
+			reasons = append(reasons, fmt.Sprintf("%s: %s", rotation.EnvironmentPath, msg))
+		}
+	}
+	if len(reasons) == 0 && strings.EqualFold(event.Status, rotationStatusFailed) {
+		reasons = append(reasons, "status "+event.Status)
+	}
+	return strings.Join(reasons, "; ")
+}
+
+func (*EnvironmentRotation) Read(
+	ctx context.Context,
+	req infer.ReadRequest[EnvironmentRotationInput, EnvironmentRotationState],
+) (infer.ReadResponse[EnvironmentRotationInput, EnvironmentRotationState], error) {
+	if req.State.RotationID == "" {
+		// The paths and triggers can't be recovered from a rotation.
+		return infer.ReadResponse[EnvironmentRotationInput, EnvironmentRotationState]{},
+			fmt.Errorf("importing an EnvironmentRotation is not supported; declare it to rotate again")
+	}
+	events, err := config.GetClient(ctx).ListEnvironmentRotationHistory(ctx, req.State.environmentIdentifier())
+	if err != nil {
+		return infer.ReadResponse[EnvironmentRotationInput, EnvironmentRotationState]{}, err
+	}
+	for _, event := range events {
+		if event.ID != req.State.RotationID {
+			continue
+		}
+		state := req.State
+		state.EnvironmentRotationEvent = EnvironmentRotationEventFromAPI(event)
+		return infer.ReadResponse[EnvironmentRotationInput, EnvironmentRotationState]{
+			ID:     req.ID,
+			Inputs: req.Inputs,
+			State:  state,
+		}, nil
+	}
+	// Rotations age out of the history; keep the last recorded outcome.
+	return infer.ReadResponse[EnvironmentRotationInput, EnvironmentRotationState]{
+		ID:     req.ID,
+		Inputs: req.Inputs,
+		State:  req.State,
+	}, nil
+}
+
+// rotatorBlock is a `fn::rotate` call within an environment definition.
+type rotatorBlock struct {
+	path    string
+	rotator string
+	inputs  any
+}
+
+// checkEnvironmentRotators validates the inputs of every rotator the
+// environment's yaml calls against the rotator's schema. Failures are
+// reported on key. Environments without rotators don't call Pulumi Cloud.
+func checkEnvironmentRotators(ctx context.Context, i EnvironmentInput, key string) ([]p.CheckFailure, error) {
+	if i.Yaml == "" || i.Organization == "" {
+		return nil, nil
+	}
+	var definition any
+	if err := yaml.Unmarshal([]byte(i.Yaml), &definition); err != nil {
+		// Malformed yaml is reported by ESC itself.
+		return nil, nil
+	}
+	blocks := findRotatorBlocks("", definition)
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+
+	client := config.GetClient(ctx)
+	rotators, err := client.ListRotators(ctx, i.Organization)
+	if err != nil {
+		return nil, err
+	}
+	schemas := map[string]*schema.Schema{}
+	var failures []p.CheckFailure
+	fail := func(format string, args ...any) {
+		failures = append(failures, p.CheckFailure{Property: key, Reason: fmt.Sprintf(format, args...)})
+	}
+	for _, block := range blocks {
+		if !slices.Contains(rotators, block.rotator) {
+			fail("rotator %q at `%s` is not available to organization %s", block.rotator, block.path, i.Organization)
+			continue
+		}
+		inputSchema, ok := schemas[block.rotator]
+		if !ok {
+			if inputSchema, err = client.GetRotatorSchema(ctx, block.rotator); err != nil {
+				return nil, err
+			}
+			schemas[block.rotator] = inputSchema
+		}
+		if inputSchema == nil {
+			fail("rotator %q at `%s` does not exist", block.rotator, block.path)
+			continue
+		}
+		inputs, _ := block.inputs.(map[string]any)
+		for _, name := range inputSchema.Required {
+			if _, ok := inputs[name]; !ok {
+				fail("rotator %q at `%s` is missing the required input %q", block.rotator, block.path, name)
+			}
+		}
+		if inputSchema.AdditionalProperties == nil || !inputSchema.AdditionalProperties.Never {
+			continue
+		}
+		for _, name := range slices.Sorted(maps.Keys(inputs)) {
+			if _, ok := inputSchema.Properties[name]; !ok {
+				fail("rotator %q at `%s` has no input %q", block.rotator, block.path, name)
+			}
+		}
+	}
+	return failures, nil
+}
+
+// findRotatorBlocks returns the rotator calls within value, in path order.
+// Both the `fn::rotate::<rotator>` form and the older `fn::rotate` form
+// with a `provider` are recognized.
+func findRotatorBlocks(path string, value any) []rotatorBlock {
+	var blocks []rotatorBlock
+	switch value := value.(type) {
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(value)) {
+			args, _ := value[key].(map[string]any)
+			if rotator, ok := strings.CutPrefix(key, "fn::rotate::"); ok {
+				blocks = append(blocks, rotatorBlock{path: path, rotator: rotator, inputs: args["inputs"]})
+				continue
+			}
+			if provider, ok := args["provider"].(string); key == "fn::rotate" && ok {
+				blocks = append(blocks, rotatorBlock{path: path, rotator: provider, inputs: args["inputs"]})
+				continue
+			}
+			child := key
+			if path != "" {
+				child = path + "." + key
+			}
+			blocks = append(blocks, findRotatorBlocks(child, value[key])...)
+		}
+	case []any:
+		for index, item := range value {
+			blocks = append(blocks, findRotatorBlocks(fmt.Sprintf("%s[%d]", path, index), item)...)
+		}
+	}
+	return blocks
+}
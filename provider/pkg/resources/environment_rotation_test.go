@@ -0,0 +1,206 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/esc/schema"
+	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+// rotationClientMock rotates environments into event, and knows the
+// rotators in schemas.
+type rotationClientMock struct {
+	config.Client
+	event   pulumiapi.SecretRotationEvent
+	history []pulumiapi.SecretRotationEvent
+	rotated [][]string
+	schemas map[string]*schema.Schema
+}
+
+func (c *rotationClientMock) RotateEnvironment(
+	_ context.Context, _ pulumiapi.EnvironmentIdentifier, paths []string,
+) (*pulumiapi.SecretRotationEvent, error) {
+	c.rotated = append(c.rotated, paths)
+	return &c.event, nil
+}
+
+func (c *rotationClientMock) ListEnvironmentRotationHistory(
+	context.Context, pulumiapi.EnvironmentIdentifier,
+) ([]pulumiapi.SecretRotationEvent, error) {
+	return c.history, nil
+}
+
+func (c *rotationClientMock) ListRotators(context.Context, string) ([]string, error) {
+	var rotators []string
+	for name := range c.schemas {
+		rotators = append(rotators, name)
+	}
+	return rotators, nil
+}
+
+func (c *rotationClientMock) GetRotatorSchema(_ context.Context, name string) (*schema.Schema, error) {
+	return c.schemas[name], nil
+}
+
+func rotationInput() EnvironmentRotationInput {
+	return EnvironmentRotationInput{
+		Organization: gcMyOrg,
+		Project:      gcMyProject,
+		Environment:  "prod",
+		Paths:        []string{"db.password"},
+	}
+}
+
+func TestEnvironmentRotationCreate(t *testing.T) {
+	revision := 8
+
+	t.Run("records the rotation", func(t *testing.T) {
+		client := &rotationClientMock{event: pulumiapi.SecretRotationEvent{
+			ID:                   "rot-1",
+			Status:               "completed",
+			PreRotationRevision:  7,
+			PostRotationRevision: &revision,
+			Rotations:            []pulumiapi.SecretRotation{{EnvironmentPath: "db.password", Status: "succeeded"}},
+		}}
+		ctx := config.WithMockClient(context.Background(), client)
+
+		resp, err := (&EnvironmentRotation{}).Create(ctx,
+			infer.CreateRequest[EnvironmentRotationInput]{Inputs: rotationInput()})
+		require.NoError(t, err)
+		assert.Equal(t, "my-org/my-project/prod/rot-1", resp.ID)
+		assert.Equal(t, [][]string{{"db.password"}}, client.rotated)
+		assert.Equal(t, "completed", resp.Output.Status)
+		assert.Equal(t, &revision, resp.Output.PostRotationRevision)
+		assert.Equal(t, []EnvironmentSecretRotation{{Path: "db.password", Status: "succeeded"}}, resp.Output.Secrets)
+	})
+
+	t.Run("records a failed secret", func(t *testing.T) {
+		client := &rotationClientMock{event: pulumiapi.SecretRotationEvent{
+			ID:     "rot-1",
+			Status: "failed",
+			Rotations: []pulumiapi.SecretRotation{
+				{EnvironmentPath: "db.password", Status: "failed", ErrorMessage: "access denied"},
+			},
+		}}
+		ctx := config.WithMockClient(context.Background(), client)
+
+		resp, err := (&EnvironmentRotation{}).Create(ctx,
+			infer.CreateRequest[EnvironmentRotationInput]{Inputs: rotationInput()})
+		var initErr infer.ResourceInitFailedError
+		require.ErrorAs(t, err, &initErr)
+		assert.Contains(t, initErr.Reasons[0], "db.password: access denied")
+		assert.Equal(t, "my-org/my-project/prod/rot-1", resp.ID)
+		assert.Equal(t, "failed", resp.Output.Status)
+	})
+
+	t.Run("preview does not rotate", func(t *testing.T) {
+		client := &rotationClientMock{}
+		ctx := config.WithMockClient(context.Background(), client)
+
+		_, err := (&EnvironmentRotation{}).Create(ctx,
+			infer.CreateRequest[EnvironmentRotationInput]{Inputs: rotationInput(), DryRun: true})
+		require.NoError(t, err)
+		assert.Empty(t, client.rotated)
+	})
+}
+
+func TestEnvironmentRotationRead(t *testing.T) {
+	state := EnvironmentRotationState{
+		EnvironmentRotationInput: rotationInput(),
+		EnvironmentRotationEvent: EnvironmentRotationEvent{RotationID: "rot-1", Status: "in-progress"},
+	}
+	read := func(client *rotationClientMock, state EnvironmentRotationState) (
+		infer.ReadResponse[EnvironmentRotationInput, EnvironmentRotationState], error,
+	) {
+		ctx := config.WithMockClient(context.Background(), client)
+		return (&EnvironmentRotation{}).Read(ctx, infer.ReadRequest[EnvironmentRotationInput, EnvironmentRotationState]{
+			ID: "my-org/my-project/prod/rot-1", Inputs: state.EnvironmentRotationInput, State: state,
+		})
+	}
+
+	t.Run("refreshes the status", func(t *testing.T) {
+		resp, err := read(&rotationClientMock{history: []pulumiapi.SecretRotationEvent{
+			{ID: "rot-2", Status: "completed"},
+			{ID: "rot-1", Status: "completed"},
+		}}, state)
+		require.NoError(t, err)
+		assert.Equal(t, "completed", resp.State.Status)
+		assert.Equal(t, rotationInput(), resp.Inputs)
+	})
+
+	t.Run("keeps a rotation gone from the history", func(t *testing.T) {
+		resp, err := read(&rotationClientMock{}, state)
+		require.NoError(t, err)
+		assert.Equal(t, "my-org/my-project/prod/rot-1", resp.ID)
+		assert.Equal(t, "in-progress", resp.State.Status)
+	})
+
+	t.Run("import is rejected", func(t *testing.T) {
+		_, err := read(&rotationClientMock{}, EnvironmentRotationState{})
+		assert.ErrorContains(t, err, "not supported")
+	})
+}
+
+func TestEnvironmentCheckRotators(t *testing.T) {
+	client := &rotationClientMock{schemas: map[string]*schema.Schema{
+		"aws-iam": {
+			Properties: map[string]*schema.Schema{
+				"login":   schema.Always(),
+				"userArn": schema.Always(),
+			},
+			AdditionalProperties: schema.Never(),
+			Required:             []string{"userArn"},
+		},
+	}}
+	ctx := config.WithMockClient(context.Background(), client)
+	ctx = config.WithMockEscClient(ctx, buildEscClientMock(nil, nil))
+	check := func(yaml string) []string {
+		resp, err := (&Environment{}).Check(ctx, infer.CheckRequest{NewInputs: environmentInputs(property.New(yaml))})
+		require.NoError(t, err)
+		var reasons []string
+		for _, failure := range resp.Failures {
+			assert.Equal(t, gcYaml, failure.Property)
+			reasons = append(reasons, failure.Reason)
+		}
+		return reasons
+	}
+
+	t.Run("accepts valid inputs", func(t *testing.T) {
+		assert.Empty(t, check("values:\n  iam:\n    fn::rotate::aws-iam:\n      inputs:\n        userArn: arn\n"))
+	})
+
+	t.Run("rejects missing and unknown inputs", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"rotator \"aws-iam\" at `values.iam` is missing the required input \"userArn\"",
+			"rotator \"aws-iam\" at `values.iam` has no input \"user\"",
+		}, check("values:\n  iam:\n    fn::rotate::aws-iam:\n      inputs:\n        user: arn\n"))
+	})
+
+	t.Run("rejects an unknown rotator", func(t *testing.T) {
+		assert.Equal(t, []string{
+			"rotator \"postgres\" at `values.db[0]` is not available to organization " + gcOrg,
+		}, check("values:\n  db:\n    - fn::rotate:\n        provider: postgres\n        inputs: {}\n"))
+	})
+}
@@ -7,14 +7,18 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/pulumi/esc"
 	"github.com/pulumi/esc/cmd/esc/cli/client"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/asset"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
-	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
 )
 
 const (
@@ -33,6 +37,20 @@ type EscClientMock struct {
 	client.Client
 	getEnvironmentFunc            getEnvironmentFunc
 	getEnvironmentRevisionTagFunc getEnvironmentRevisionTagFunc
+	updateEnvironmentErr          error
+	checkDiagnostics              []client.EnvironmentDiagnostic
+	environmentRevision           *client.EnvironmentRevision
+	created                       []string
+	retractions                   []retraction
+	drafts                        []string
+	submitted                     []string
+}
+
+// retraction records a RetractEnvironmentRevision call.
+type retraction struct {
+	version     string
+	replacement *int
+	reason      string
 }
 
 func (c *EscClientMock) GetEnvironment(
@@ -49,7 +67,7 @@ func (c *EscClientMock) GetEnvironmentRevision(
 	_, _, _ string,
 	_ int,
 ) (*client.EnvironmentRevision, error) {
-	return nil, nil
+	return c.environmentRevision, nil
 }
 
 func (c *EscClientMock) GetEnvironmentRevisionTag(
@@ -72,14 +90,15 @@ func (c *EscClientMock) CheckYAMLEnvironment(
 	[]byte,
 	...client.CheckYAMLOption,
 ) (*esc.Environment, []client.EnvironmentDiagnostic, error) {
-	return nil, nil, nil
+	return nil, c.checkDiagnostics, nil
 }
 
 func (c *EscClientMock) CreateEnvironment(context.Context, string, string) error {
 	return nil
 }
 
-func (c *EscClientMock) CreateEnvironmentWithProject(context.Context, string, string, string) error {
+func (c *EscClientMock) CreateEnvironmentWithProject(_ context.Context, _, _, envName string) error {
+	c.created = append(c.created, envName)
 	return nil
 }
 
@@ -202,7 +221,8 @@ func (c *EscClientMock) RotateEnvironment(
 	return nil, nil, nil
 }
 
-func (c *EscClientMock) SubmitChangeRequest(context.Context, string, string, *string) error {
+func (c *EscClientMock) SubmitChangeRequest(_ context.Context, _, changeRequestID string, _ *string) error {
+	c.submitted = append(c.submitted, changeRequestID)
 	return nil
 }
 
@@ -240,14 +260,13 @@ func (c *EscClientMock) UpdateEnvironmentDraft(
 }
 
 func (c *EscClientMock) CreateEnvironmentDraft(
-	context.Context,
-	string,
-	string,
-	string,
-	[]byte,
-	string,
+	_ context.Context,
+	_, _, _ string,
+	yaml []byte,
+	_ string,
 ) (string, []client.EnvironmentDiagnostic, error) {
-	return "", nil, nil
+	c.drafts = append(c.drafts, string(yaml))
+	return fmt.Sprintf("cr-%d", len(c.drafts)), nil, nil
 }
 
 func (c *EscClientMock) GetDefaultOrg(context.Context) (string, error) {
@@ -320,6 +339,9 @@ func (c *EscClientMock) UpdateEnvironmentWithRevision(
 	_ []byte,
 	_ string,
 ) ([]client.EnvironmentDiagnostic, int, error) {
+	if c.updateEnvironmentErr != nil {
+		return nil, 0, c.updateEnvironmentErr
+	}
 	return nil, 0, nil
 }
 
@@ -341,10 +363,11 @@ func (c *EscClientMock) DeleteEnvironmentRevisionTag(
 func (c *EscClientMock) RetractEnvironmentRevision(
 	_ context.Context,
 	_, _, _ string,
-	_ string,
-	_ *int,
-	_ string,
+	version string,
+	replacement *int,
+	reason string,
 ) error {
+	c.retractions = append(c.retractions, retraction{version: version, replacement: replacement, reason: reason})
 	return nil
 }
 
@@ -396,156 +419,132 @@ func buildEscClientMock(
 	}
 }
 
-func TestEnvironmentCheck(t *testing.T) {
-	mockedClient := buildEscClientMock(
-		func(_ context.Context, _ string, _ string, _ string, _ bool) (yaml []byte, etag string, revision int, err error) {
-			return nil, "", 0, fmt.Errorf("not found")
-		},
-		func(_ context.Context, _, _, _ string) (*client.EnvironmentRevisionTag, error) {
-			return nil, nil
-		},
-	)
-	provider := PulumiServiceEnvironmentResource{
-		Client: mockedClient,
-	}
+// environmentMetadataClientMock serves the metadata lookup behind
+// `environmentId`; environments without metadata resolve to no id.
+type environmentMetadataClientMock struct {
+	config.Client
+}
+
+func (c *environmentMetadataClientMock) GetEnvironmentMetadata(
+	_ context.Context, _, _, _ string,
+) (*pulumiapi.EnvironmentMetadata, error) {
+	return nil, nil
+}
+
+// environmentContext wires the ESC client mock and a metadata client into ctx.
+func environmentContext(ctx context.Context, escClient *EscClientMock) context.Context {
+	ctx = config.WithMockClient(ctx, &environmentMetadataClientMock{})
+	return config.WithMockEscClient(ctx, escClient)
+}
+
+func environmentInputs(yaml property.Value) property.Map {
+	return property.NewMap(map[string]property.Value{
+		gcOrganization: property.New(gcOrg),
+		gcProject:      property.New(gcProject),
+		gcName:         property.New(gcEnv),
+		gcYaml:         yaml,
+	})
+}
 
+func TestEnvironmentCheck(t *testing.T) {
 	envDef := `values:
 foo: bar
 `
-	propertyMap := resource.PropertyMap{}
-	propertyMap[gcOrganization] = resource.NewPropertyValue(gcOrg)
-	propertyMap[gcProject] = resource.NewPropertyValue(gcProject)
-	propertyMap[gcName] = resource.NewPropertyValue(gcEnv)
-	propertyMap[gcYaml] = resource.NewAssetProperty(&asset.Asset{Text: envDef})
 
 	t.Run("Check", func(t *testing.T) {
-		properties, _ := plugin.MarshalProperties(
-			propertyMap,
-			plugin.MarshalOptions{
-				KeepUnknowns: true,
-				SkipNulls:    true,
-			},
-		)
-		req := pulumirpc.CheckRequest{
-			News: properties,
-		}
+		resp, err := (&Environment{}).Check(t.Context(), infer.CheckRequest{
+			NewInputs: environmentInputs(property.New(&asset.Asset{Text: envDef})),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Failures)
+		assert.Equal(t, "values:\nfoo: bar", resp.Inputs.Yaml, "the asset is read down to its trimmed text")
+	})
 
-		_, err := provider.Check(&req)
-		assert.NoError(t, err)
+	t.Run("Check defaults the project", func(t *testing.T) {
+		inputs := environmentInputs(property.New(envDef)).Delete(gcProject)
+
+		resp, err := (&Environment{}).Check(t.Context(), infer.CheckRequest{NewInputs: inputs})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Failures)
+		assert.Equal(t, defaultProject, resp.Inputs.Project)
 	})
 
-	t.Run("Check when yaml contains computed resource", func(t *testing.T) {
-		propertyMap[gcYaml] = resource.NewComputedProperty(resource.Computed{Element: resource.NewStringProperty("")})
+	t.Run("Check rejects a slash in the name", func(t *testing.T) {
+		inputs := environmentInputs(property.New(envDef)).Set(gcName, property.New("a/b"))
 
-		properties, _ := plugin.MarshalProperties(
-			propertyMap,
-			plugin.MarshalOptions{
-				KeepUnknowns: true,
-				SkipNulls:    true,
-			},
-		)
-		req := pulumirpc.CheckRequest{
-			News: properties,
-		}
+		resp, err := (&Environment{}).Check(t.Context(), infer.CheckRequest{NewInputs: inputs})
+		require.NoError(t, err)
+		require.Len(t, resp.Failures, 1)
+		assert.Equal(t, gcName, resp.Failures[0].Property)
+	})
 
-		_, err := provider.Check(&req)
+	t.Run("Check when yaml contains computed resource", func(t *testing.T) {
+		_, err := (&Environment{}).Check(t.Context(), infer.CheckRequest{
+			NewInputs: environmentInputs(property.New(property.Computed)),
+		})
 		assert.NoError(t, err)
 	})
 
 	t.Run("Check when yaml is secret wrapping computed resource", func(t *testing.T) {
 		// This tests the bug fix for issue #606:
 		// Secret wraps a computed value (from Output.ApplyT), causing panic in Check()
-		computedValue := resource.NewComputedProperty(resource.Computed{Element: resource.NewStringProperty("")})
-		propertyMap[gcYaml] = resource.MakeSecret(computedValue)
-
-		properties, _ := plugin.MarshalProperties(
-			propertyMap,
-			plugin.MarshalOptions{
-				KeepUnknowns: true,
-				SkipNulls:    true,
-				KeepSecrets:  true,
-			},
-		)
-		req := pulumirpc.CheckRequest{
-			News: properties,
-		}
-
-		_, err := provider.Check(&req)
+		_, err := (&Environment{}).Check(t.Context(), infer.CheckRequest{
+			NewInputs: environmentInputs(property.New(property.Computed).WithSecret(true)),
+		})
 		assert.NoError(t, err)
 	})
 }
 
 func TestEnvironmentDiff(t *testing.T) {
-	mockedClient := buildEscClientMock(
-		func(_ context.Context, _ string, _ string, _ string, _ bool) (yaml []byte, etag string, revision int, err error) {
-			return nil, "", 0, nil
-		},
-		func(_ context.Context, _, _, _ string) (*client.EnvironmentRevisionTag, error) {
-			return nil, nil
-		},
-	)
-	provider := PulumiServiceEnvironmentResource{
-		Client: mockedClient,
-	}
+	const yaml = "values:\n  foo: bar"
 
 	t.Run("No spurious replace when upgrading from pre-0.25.0 state without project", func(t *testing.T) {
-		// Old inputs from pre-0.25.0 lack the "project" field.
-		oldInputs := resource.PropertyMap{
-			gcOrganization: resource.NewPropertyValue(gcOrg),
-			gcName:         resource.NewPropertyValue(gcEnv),
-			gcYaml:         resource.MakeSecret(resource.NewStringProperty("values:\n  foo: bar")),
-		}
+		// State from pre-0.25.0 lacks the "project" field.
+		state := EnvironmentState{EnvironmentInput: EnvironmentInput{
+			Organization: gcOrg,
+			Name:         gcEnv,
+			Yaml:         yaml,
+		}}
 		// New inputs from 0.25.0+ include project defaulting to "default".
-		newInputs := resource.PropertyMap{
-			gcOrganization: resource.NewPropertyValue(gcOrg),
-			gcProject:      resource.NewPropertyValue("default"),
-			gcName:         resource.NewPropertyValue(gcEnv),
-			gcYaml:         resource.MakeSecret(resource.NewStringProperty("values:\n  foo: bar")),
-		}
+		inputs := EnvironmentInput{Organization: gcOrg, Project: defaultProject, Name: gcEnv, Yaml: yaml}
 
-		oldProps, err := plugin.MarshalProperties(oldInputs, plugin.MarshalOptions{KeepSecrets: true})
-		assert.NoError(t, err)
-		newProps, err := plugin.MarshalProperties(newInputs, plugin.MarshalOptions{KeepSecrets: true})
-		assert.NoError(t, err)
-
-		resp, err := provider.Diff(&pulumirpc.DiffRequest{
-			OldInputs: oldProps,
-			News:      newProps,
-		})
-		assert.NoError(t, err)
-		assert.Equal(t, pulumirpc.DiffResponse_DIFF_NONE, resp.Changes)
+		resp, err := (&Environment{}).Diff(t.Context(), infer.DiffRequest[envIn, envOut]{State: state, Inputs: inputs})
+		require.NoError(t, err)
+		assert.False(t, resp.HasChanges)
 		assert.Empty(t, resp.DetailedDiff)
-		assert.Empty(t, resp.Replaces)
 	})
 
 	t.Run("Replace when project actually changes", func(t *testing.T) {
-		oldInputs := resource.PropertyMap{
-			gcOrganization: resource.NewPropertyValue(gcOrg),
-			gcProject:      resource.NewPropertyValue("proj-a"),
-			gcName:         resource.NewPropertyValue(gcEnv),
-			gcYaml:         resource.MakeSecret(resource.NewStringProperty("values:\n  foo: bar")),
-		}
-		newInputs := resource.PropertyMap{
-			gcOrganization: resource.NewPropertyValue(gcOrg),
-			gcProject:      resource.NewPropertyValue("proj-b"),
-			gcName:         resource.NewPropertyValue(gcEnv),
-			gcYaml:         resource.MakeSecret(resource.NewStringProperty("values:\n  foo: bar")),
-		}
-
-		oldProps, err := plugin.MarshalProperties(oldInputs, plugin.MarshalOptions{KeepSecrets: true})
-		assert.NoError(t, err)
-		newProps, err := plugin.MarshalProperties(newInputs, plugin.MarshalOptions{KeepSecrets: true})
-		assert.NoError(t, err)
+		state := EnvironmentState{EnvironmentInput: EnvironmentInput{
+			Organization: gcOrg,
+			Project:      "proj-a",
+			Name:         gcEnv,
+			Yaml:         yaml,
+		}}
+		inputs := EnvironmentInput{Organization: gcOrg, Project: "proj-b", Name: gcEnv, Yaml: yaml}
+
+		resp, err := (&Environment{}).Diff(t.Context(), infer.DiffRequest[envIn, envOut]{State: state, Inputs: inputs})
+		require.NoError(t, err)
+		assert.True(t, resp.HasChanges)
+		assert.Equal(t, p.UpdateReplace, resp.DetailedDiff[gcProject].Kind)
+		assert.True(t, resp.DeleteBeforeReplace)
+	})
 
-		resp, err := provider.Diff(&pulumirpc.DiffRequest{
-			OldInputs: oldProps,
-			News:      newProps,
-		})
-		assert.NoError(t, err)
-		assert.Equal(t, pulumirpc.DiffResponse_DIFF_SOME, resp.Changes)
-		assert.Contains(t, resp.DetailedDiff, gcProject)
-		// The kind should be a replace variant.
-		assert.True(t, resp.DetailedDiff[gcProject].Kind >= pulumirpc.PropertyDiff_ADD_REPLACE)
+	t.Run("Update in place when only the yaml changes", func(t *testing.T) {
+		state := EnvironmentState{EnvironmentInput: EnvironmentInput{
+			Organization: gcOrg,
+			Project:      gcProject,
+			Name:         gcEnv,
+			Yaml:         yaml,
+		}}
+		inputs := state.EnvironmentInput
+		inputs.Yaml = "values:\n  foo: baz"
+
+		resp, err := (&Environment{}).Diff(t.Context(), infer.DiffRequest[envIn, envOut]{State: state, Inputs: inputs})
+		require.NoError(t, err)
+		assert.True(t, resp.HasChanges)
+		assert.Equal(t, p.Update, resp.DetailedDiff[gcYaml].Kind)
+		assert.False(t, resp.DeleteBeforeReplace)
 	})
 }
 
@@ -560,77 +559,418 @@ func TestEnvironment(t *testing.T) {
 			},
 		)
 
-		provider := PulumiServiceEnvironmentResource{
-			Client: mockedClient,
-		}
-
-		input := PulumiServiceEnvironmentInput{
-			OrgName:     gcOrg,
-			ProjectName: gcProject,
-			EnvName:     gcEnv,
-			Yaml: `values:
-	foo: bar
-`,
-		}
-
-		propertyMap, _ := input.ToPropertyMap()
-		outputProperties, _ := plugin.MarshalProperties(
-			propertyMap,
-			plugin.MarshalOptions{
-				KeepUnknowns: true,
-				SkipNulls:    true,
-			},
+		resp, err := (&Environment{}).Read(
+			environmentContext(t.Context(), mockedClient),
+			infer.ReadRequest[envIn, envOut]{ID: "org/env"},
 		)
-		req := pulumirpc.ReadRequest{
-			Id:         "org/env",
-			Properties: outputProperties,
-		}
-
-		resp, err := provider.Read(&req)
 
 		assert.NoError(t, err)
-		assert.Equal(t, resp.Id, "")
-		assert.Nil(t, resp.Properties)
+		assert.Equal(t, "", resp.ID)
 	})
 
 	t.Run("Read when the resource is found", func(t *testing.T) {
 		mockedClient := buildEscClientMock(
 			func(_ context.Context, _ string, _ string, _ string, _ bool) (yaml []byte, etag string, revision int, err error) {
-				return nil, "", 0, nil
+				return []byte("values:\n  foo: bar\n"), "", 3, nil
 			},
 			func(_ context.Context, _, _, _ string) (*client.EnvironmentRevisionTag, error) {
 				return nil, nil
 			},
 		)
 
-		provider := PulumiServiceEnvironmentResource{
-			Client: mockedClient,
-		}
+		resp, err := (&Environment{}).Read(
+			environmentContext(t.Context(), mockedClient),
+			infer.ReadRequest[envIn, envOut]{ID: "org/env"},
+		)
 
-		input := PulumiServiceEnvironmentInput{
-			OrgName: gcOrg,
-			EnvName: gcProject,
-			Yaml: `values:
-	foo: bar
-`,
-		}
+		assert.NoError(t, err)
+		assert.Equal(t, "org/env", resp.ID)
+		// The legacy two-part id resolves to the default project.
+		assert.Equal(t, defaultProject, resp.Inputs.Project)
+		assert.Equal(t, "values:\n  foo: bar", resp.State.Yaml)
+		assert.Equal(t, 3, resp.State.Revision)
+	})
 
-		propertyMap, _ := input.ToPropertyMap()
-		outputProperties, _ := plugin.MarshalProperties(
-			propertyMap,
-			plugin.MarshalOptions{
-				KeepUnknowns: true,
-				SkipNulls:    true,
+	t.Run("Read does not report a canceled lookup as deleted", func(t *testing.T) {
+		mockedClient := buildEscClientMock(
+			func(ctx context.Context, _ string, _ string, _ string, _ bool) (yaml []byte, etag string, revision int, err error) {
+				return nil, "", 0, ctx.Err()
 			},
+			nil,
 		)
-		req := pulumirpc.ReadRequest{
-			Id:         "org/env",
-			Properties: outputProperties,
+		ctx, cancel := context.WithCancel(environmentContext(t.Context(), mockedClient))
+		cancel()
+
+		_, err := (&Environment{}).Read(ctx, infer.ReadRequest[envIn, envOut]{ID: "org/project/env"})
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Create records the environment when pushing yaml fails", func(t *testing.T) {
+		mockedClient := buildEscClientMock(nil, nil)
+		mockedClient.updateEnvironmentErr = context.Canceled
+
+		resp, err := (&Environment{}).Create(
+			environmentContext(t.Context(), mockedClient),
+			infer.CreateRequest[envIn]{Inputs: EnvironmentInput{
+				Organization: gcOrg,
+				Project:      gcProject,
+				Name:         gcEnv,
+				Yaml:         "values:\n  foo: bar",
+			}},
+		)
+
+		var initErr infer.ResourceInitFailedError
+		require.ErrorAs(t, err, &initErr)
+		assert.Equal(t, gcOrg+"/"+gcProject+"/"+gcEnv, resp.ID)
+		assert.Equal(t, "", resp.Output.Yaml, "state must not claim the yaml was applied")
+	})
+
+	t.Run("Create during preview returns the planned state", func(t *testing.T) {
+		resp, err := (&Environment{}).Create(
+			environmentContext(t.Context(), buildEscClientMock(nil, nil)),
+			infer.CreateRequest[envIn]{
+				Inputs: EnvironmentInput{Organization: gcOrg, Project: gcProject, Name: gcEnv, Yaml: "values: {}"},
+				DryRun: true,
+			},
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, "values: {}", resp.Output.Yaml)
+	})
+}
+
+func environmentDefinition() *EnvironmentDefinition {
+	return &EnvironmentDefinition{
+		Imports: []string{"base"},
+		Values: map[string]any{
+			"region": "us-west-2",
+			"db":     map[string]any{"port": 5432.0, "host": "${region}.db"},
+			"creds":  map[string]any{"fn::open::aws-login": map[string]any{"oidc": map[string]any{"duration": "1h"}}},
+		},
+		EnvironmentVariables: map[string]any{"REGION": "${region}"},
+	}
+}
+
+const environmentDefinitionYaml = `imports:
+  - base
+values:
+  creds:
+    fn::open::aws-login:
+      oidc:
+        duration: 1h
+  db:
+    host: ${region}.db
+    port: 5432
+  environmentVariables:
+    REGION: ${region}
+  region: us-west-2`
+
+func TestEnvironmentDefinitionYaml(t *testing.T) {
+	yaml, err := environmentDefinition().toYAML()
+	require.NoError(t, err)
+	assert.Equal(t, environmentDefinitionYaml, yaml)
+
+	parsed, err := parseEnvironmentDefinition(yaml)
+	require.NoError(t, err)
+	assert.Equal(t, environmentDefinition(), parsed)
+
+	_, err = parseEnvironmentDefinition("values: {}\nsettings: {}")
+	assert.ErrorContains(t, err, `unsupported top-level key "settings"`)
+
+	conflicting := environmentDefinition()
+	conflicting.Values[gcEnvironmentVariables] = map[string]any{}
+	_, err = conflicting.toYAML()
+	assert.ErrorContains(t, err, "`environmentVariables` is set both")
+}
+
+func TestEnvironmentCheckDefinition(t *testing.T) {
+	definitionInputs := func(definition property.Value) property.Map {
+		return environmentInputs(property.Value{}).Delete(gcYaml).Set(gcDefinition, definition)
+	}
+	values := func(values map[string]property.Value) property.Value {
+		return property.New(map[string]property.Value{gcValues: property.New(values)})
+	}
+
+	t.Run("serializes the definition and wraps secrets", func(t *testing.T) {
+		ctx := environmentContext(t.Context(), buildEscClientMock(nil, nil))
+		resp, err := (&Environment{}).Check(ctx, infer.CheckRequest{NewInputs: definitionInputs(values(
+			map[string]property.Value{
+				"user":     property.New("admin"),
+				"password": property.New("hunter2").WithSecret(true),
+			},
+		))})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Failures)
+		assert.Equal(t, map[string]any{escSecretBuiltin: "hunter2"}, resp.Inputs.Definition.Values["password"])
+		assert.Equal(t, "values:\n  password:\n    fn::secret: hunter2\n  user: admin", resp.Inputs.Yaml)
+	})
+
+	t.Run("reports ESC diagnostics", func(t *testing.T) {
+		escClient := buildEscClientMock(nil, nil)
+		escClient.checkDiagnostics = []client.EnvironmentDiagnostic{{Summary: "unknown property \"nope\""}}
+		ctx := environmentContext(t.Context(), escClient)
+
+		resp, err := (&Environment{}).Check(ctx, infer.CheckRequest{NewInputs: definitionInputs(values(
+			map[string]property.Value{"ref": property.New("${nope}")},
+		))})
+		require.NoError(t, err)
+		require.Len(t, resp.Failures, 1)
+		assert.Equal(t, gcDefinition, resp.Failures[0].Property)
+		assert.Equal(t, `unknown property "nope"`, resp.Failures[0].Reason)
+	})
+
+	t.Run("an unknown definition is not checked", func(t *testing.T) {
+		resp, err := (&Environment{}).Check(t.Context(), infer.CheckRequest{NewInputs: definitionInputs(values(
+			map[string]property.Value{"id": property.New(property.Computed)},
+		))})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Failures)
+	})
+
+	t.Run("rejects both yaml and definition", func(t *testing.T) {
+		inputs := environmentInputs(property.New("values: {}")).Set(gcDefinition, values(nil))
+		resp, err := (&Environment{}).Check(t.Context(), infer.CheckRequest{NewInputs: inputs})
+		require.NoError(t, err)
+		require.Len(t, resp.Failures, 1)
+		assert.Equal(t, gcDefinition, resp.Failures[0].Property)
+	})
+
+	t.Run("requires yaml or definition", func(t *testing.T) {
+		inputs := environmentInputs(property.Value{}).Delete(gcYaml)
+		resp, err := (&Environment{}).Check(t.Context(), infer.CheckRequest{NewInputs: inputs})
+		require.NoError(t, err)
+		require.Len(t, resp.Failures, 1)
+		assert.Equal(t, gcYaml, resp.Failures[0].Property)
+	})
+}
+
+func TestEnvironmentDiffDefinition(t *testing.T) {
+	input := func(definition *EnvironmentDefinition) EnvironmentInput {
+		yaml, err := definition.toYAML()
+		require.NoError(t, err)
+		return EnvironmentInput{Organization: gcOrg, Project: gcProject, Name: gcEnv, Yaml: yaml, Definition: definition}
+	}
+	diff := func(state, inputs EnvironmentInput) infer.DiffResponse {
+		resp, err := (&Environment{}).Diff(t.Context(), infer.DiffRequest[envIn, envOut]{
+			State: EnvironmentState{EnvironmentInput: state}, Inputs: inputs,
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("reports changed values by path", func(t *testing.T) {
+		news := environmentDefinition()
+		news.Values["db"].(map[string]any)["port"] = 6543.0
+		delete(news.Values, "region")
+		news.Files = map[string]any{"KUBECONFIG": "apiVersion: v1"}
+		news.Values["creds"] = map[string]any{"fn::open::aws-login": map[string]any{}}
+
+		resp := diff(input(environmentDefinition()), input(news))
+		assert.True(t, resp.HasChanges)
+		assert.Equal(t, map[string]p.PropertyDiff{
+			"definition.values.db.port": {Kind: p.Update, InputDiff: true},
+			"definition.values.region":  {Kind: p.Delete, InputDiff: true},
+			"definition.values.creds":   {Kind: p.Update, InputDiff: true},
+			"definition.files":          {Kind: p.Add, InputDiff: true},
+		}, resp.DetailedDiff)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		resp := diff(input(environmentDefinition()), input(environmentDefinition()))
+		assert.False(t, resp.HasChanges)
+	})
+
+	t.Run("diffs against the yaml when switching from it", func(t *testing.T) {
+		state := EnvironmentInput{
+			Organization: gcOrg, Project: gcProject, Name: gcEnv,
+			Yaml: "values:\n  region: us-east-1",
 		}
+		resp := diff(state, input(&EnvironmentDefinition{Values: map[string]any{"region": "us-west-2"}}))
+		assert.Equal(t, map[string]p.PropertyDiff{
+			"definition.values.region": {Kind: p.Update, InputDiff: true},
+		}, resp.DetailedDiff)
+
+		resp = diff(state, input(&EnvironmentDefinition{Values: map[string]any{"region": "us-east-1"}}))
+		assert.Equal(t, map[string]p.PropertyDiff{
+			gcDefinition: {Kind: p.Update, InputDiff: true},
+		}, resp.DetailedDiff, "the same values still record the definition")
+	})
+}
 
-		resp, err := provider.Read(&req)
+func TestEnvironmentReadDefinition(t *testing.T) {
+	state := EnvironmentState{
+		EnvironmentInput: EnvironmentInput{
+			Organization: gcOrg, Project: gcProject, Name: gcEnv,
+			Yaml:       environmentDefinitionYaml,
+			Definition: environmentDefinition(),
+		},
+		Revision: 3,
+	}
+	read := func(yaml string, revision int) infer.ReadResponse[envIn, envOut] {
+		escClient := buildEscClientMock(
+			func(context.Context, string, string, string, bool) ([]byte, string, int, error) {
+				return []byte(yaml), "", revision, nil
+			},
+			nil,
+		)
+		resp, err := (&Environment{}).Read(environmentContext(t.Context(), escClient), infer.ReadRequest[envIn, envOut]{
+			ID: state.id(), Inputs: state.EnvironmentInput, State: state,
+		})
+		require.NoError(t, err)
+		return resp
+	}
 
-		assert.NoError(t, err)
-		assert.Equal(t, resp.Id, "org/env")
+	t.Run("keeps the definition while the revision is unchanged", func(t *testing.T) {
+		resp := read("values:\n  password:\n    fn::secret:\n      ciphertext: abc", 3)
+		assert.Equal(t, state.EnvironmentInput, resp.Inputs)
+	})
+
+	t.Run("parses a definition changed elsewhere", func(t *testing.T) {
+		resp := read("values:\n  region: eu-west-1", 4)
+		assert.Equal(t, &EnvironmentDefinition{Values: map[string]any{"region": "eu-west-1"}}, resp.Inputs.Definition)
+		assert.Equal(t, 4, resp.State.Revision)
+	})
+}
+
+func TestEnvironmentRollback(t *testing.T) {
+	revisions := map[string]string{"": "values:\n  foo: baz\n", "2": "values:\n  foo: bar\n"}
+	escClient := buildEscClientMock(
+		func(_ context.Context, _, _, version string, _ bool) ([]byte, string, int, error) {
+			if yaml, ok := revisions[version]; ok {
+				return []byte(yaml), "", 3, nil
+			}
+			return nil, "", 0, fmt.Errorf("[404] Not found")
+		},
+		nil,
+	)
+	ctx := environmentContext(t.Context(), escClient)
+	inputs := func(revision property.Value) property.Map {
+		return environmentInputs(property.Value{}).Delete(gcYaml).Set(gcRollbackToRevision, revision)
+	}
+
+	t.Run("Check reads the revision into the yaml", func(t *testing.T) {
+		resp, err := (&Environment{}).Check(ctx, infer.CheckRequest{NewInputs: inputs(property.New(2.0))})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Failures)
+		assert.Equal(t, "values:\n  foo: bar", resp.Inputs.Yaml)
+		assert.Equal(t, 2, *resp.Inputs.RollbackToRevision)
+	})
+
+	t.Run("Check reports a missing revision", func(t *testing.T) {
+		resp, err := (&Environment{}).Check(ctx, infer.CheckRequest{NewInputs: inputs(property.New(9.0))})
+		require.NoError(t, err)
+		require.Len(t, resp.Failures, 1)
+		assert.Equal(t, gcRollbackToRevision, resp.Failures[0].Property)
+	})
+
+	t.Run("Check leaves the yaml unknown until the environment is known", func(t *testing.T) {
+		news := inputs(property.New(2.0)).Set(gcName, property.New(property.Computed))
+		resp, err := (&Environment{}).Check(ctx, infer.CheckRequest{NewInputs: news})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Failures)
+	})
+
+	t.Run("Check rejects a rollback together with yaml", func(t *testing.T) {
+		news := environmentInputs(property.New("values: {}")).Set(gcRollbackToRevision, property.New(2.0))
+		resp, err := (&Environment{}).Check(ctx, infer.CheckRequest{NewInputs: news})
+		require.NoError(t, err)
+		require.Len(t, resp.Failures, 1)
+		assert.Equal(t, gcRollbackToRevision, resp.Failures[0].Property)
+	})
+
+	t.Run("Diff reports the rollback", func(t *testing.T) {
+		state := EnvironmentState{EnvironmentInput: EnvironmentInput{
+			Organization: gcOrg, Project: gcProject, Name: gcEnv, Yaml: "values:\n  foo: baz",
+		}}
+		news := state.EnvironmentInput
+		news.Yaml = "values:\n  foo: bar"
+		revision := 2
+		news.RollbackToRevision = &revision
+
+		resp, err := (&Environment{}).Diff(t.Context(), infer.DiffRequest[envIn, envOut]{State: state, Inputs: news})
+		require.NoError(t, err)
+		assert.Equal(t, p.Add, resp.DetailedDiff[gcRollbackToRevision].Kind)
+		assert.Equal(t, p.Update, resp.DetailedDiff[gcYaml].Kind)
+	})
+
+	t.Run("Read keeps the rollback", func(t *testing.T) {
+		revision := 2
+		state := EnvironmentState{EnvironmentInput: EnvironmentInput{
+			Organization: gcOrg, Project: gcProject, Name: gcEnv, Yaml: "values:\n  foo: bar",
+			RollbackToRevision: &revision,
+		}}
+		resp, err := (&Environment{}).Read(ctx, infer.ReadRequest[envIn, envOut]{
+			ID: state.id(), Inputs: state.EnvironmentInput, State: state,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "values:\n  foo: baz", resp.Inputs.Yaml, "a later change shows as drift")
+		assert.Equal(t, &revision, resp.Inputs.RollbackToRevision)
+	})
+}
+
+// environmentRestoreClientMock serves deleted environments to restore.
+type environmentRestoreClientMock struct {
+	environmentMetadataClientMock
+	deleted  []pulumiapi.OrgEnvironment
+	restored []string
+}
+
+func (c *environmentRestoreClientMock) ListDeletedEnvironments(
+	context.Context, string,
+) ([]pulumiapi.OrgEnvironment, error) {
+	return c.deleted, nil
+}
+
+func (c *environmentRestoreClientMock) RestoreEnvironment(_ context.Context, _, _, _, deletedAt string) error {
+	c.restored = append(c.restored, deletedAt)
+	return nil
+}
+
+func TestEnvironmentAdoptDeleted(t *testing.T) {
+	adopt := true
+	input := EnvironmentInput{
+		Organization: gcOrg, Project: gcProject, Name: gcEnv, Yaml: "values: {}", AdoptDeleted: &adopt,
+	}
+	create := func(deleted ...pulumiapi.OrgEnvironment) (*EscClientMock, *environmentRestoreClientMock) {
+		escClient := buildEscClientMock(nil, nil)
+		client := &environmentRestoreClientMock{deleted: deleted}
+		ctx := config.WithMockEscClient(config.WithMockClient(t.Context(), client), escClient)
+		_, err := (&Environment{}).Create(ctx, infer.CreateRequest[envIn]{Inputs: input})
+		require.NoError(t, err)
+		return escClient, client
+	}
+
+	t.Run("restores the latest deletion", func(t *testing.T) {
+		escClient, client := create(
+			pulumiapi.OrgEnvironment{Project: gcProject, Name: gcEnv, DeletedAt: "2026-01-01T00:00:00Z"},
+			pulumiapi.OrgEnvironment{Project: gcProject, Name: gcEnv, DeletedAt: "2026-02-01T00:00:00Z"},
+			pulumiapi.OrgEnvironment{Project: gcProject, Name: "other", DeletedAt: "2026-03-01T00:00:00Z"},
+		)
+		assert.Equal(t, []string{"2026-02-01T00:00:00Z"}, client.restored)
+		assert.Empty(t, escClient.created)
+	})
+
+	t.Run("creates the environment when none was deleted", func(t *testing.T) {
+		escClient, client := create(
+			pulumiapi.OrgEnvironment{Project: "other", Name: gcEnv, DeletedAt: "2026-01-01T00:00:00Z"},
+		)
+		assert.Empty(t, client.restored)
+		assert.Equal(t, []string{gcEnv}, escClient.created)
+	})
+
+	t.Run("changing it after create is only recorded", func(t *testing.T) {
+		state := EnvironmentState{EnvironmentInput: input}
+		state.AdoptDeleted = nil
+		resp, err := (&Environment{}).Diff(t.Context(), infer.DiffRequest[envIn, envOut]{State: state, Inputs: input})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]p.PropertyDiff{
+			"adoptDeleted": {Kind: p.Update, InputDiff: true},
+		}, resp.DetailedDiff)
+
+		ctx := config.WithMockEscClient(t.Context(), buildEscClientMock(nil, nil))
+		update, err := (&Environment{}).Update(ctx, infer.UpdateRequest[envIn, envOut]{State: state, Inputs: input})
+		require.NoError(t, err)
+		assert.Equal(t, &adopt, update.Output.AdoptDeleted)
 	})
 }
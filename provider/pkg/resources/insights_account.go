@@ -18,14 +18,26 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pulumi/pulumi-go-provider/infer"
 
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/poll"
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
 )
 
-type InsightsAccount struct{}
+type InsightsAccount struct {
+	// scanPoll overrides how waitForFirstScan polls; zero uses
+	// insightsScanInterval, and the create's customTimeouts or else
+	// insightsScanTimeout.
+	scanPoll poll.Options
+}
+
+const (
+	insightsScanInterval = 10 * time.Second
+	insightsScanTimeout  = 30 * time.Minute
+)
 
 var (
 	_ infer.CustomCreate[InsightsAccountInput, InsightsAccountState] = &InsightsAccount{}
@@ -114,6 +126,16 @@ func (c *InsightsAccountCore) Annotate(a infer.Annotator) {
 // InsightsAccountInput represents the input properties for creating an insights account
 type InsightsAccountInput struct {
 	InsightsAccountCore
+	WaitForFirstScan bool `pulumi:"waitForFirstScan,optional"`
+}
+
+func (i *InsightsAccountInput) Annotate(a infer.Annotator) {
+	a.Describe(
+		&i.WaitForFirstScan,
+		"Whether to trigger a scan when the account is created and wait for it to finish before "+
+			"reporting the account as created, so that resources depending on it can rely on "+
+			"discovered resources being present. A failed scan fails the create. Defaults to false.",
+	)
 }
 
 // InsightsAccountState represents the output properties of an insights account
@@ -148,7 +170,7 @@ func InsightsAccountStateFromAPI(orgName string, account pulumiapi.InsightsAccou
 	}
 }
 
-func (*InsightsAccount) Create(
+func (ia *InsightsAccount) Create(
 	ctx context.Context,
 	req infer.CreateRequest[InsightsAccountInput],
 ) (infer.CreateResponse[InsightsAccountState], error) {
@@ -217,16 +239,56 @@ func (*InsightsAccount) Create(
 			}
 	}
 
+	output := InsightsAccountState{
+		InsightsAccountCore:  req.Inputs.InsightsAccountCore,
+		InsightsAccountID:    account.ID,
+		ScheduledScanEnabled: account.ScheduledScanEnabled,
+	}
+	if req.Inputs.WaitForFirstScan {
+		if err := ia.waitForFirstScan(ctx, client, req.Inputs.OrganizationName, req.Inputs.AccountName); err != nil {
+			return infer.CreateResponse[InsightsAccountState]{
+				ID:     accountID,
+				Output: output,
+			}, infer.ResourceInitFailedError{Reasons: []string{err.Error()}}
+		}
+	}
+
 	return infer.CreateResponse[InsightsAccountState]{
-		ID: accountID,
-		Output: InsightsAccountState{
-			InsightsAccountCore:  req.Inputs.InsightsAccountCore,
-			InsightsAccountID:    account.ID,
-			ScheduledScanEnabled: account.ScheduledScanEnabled,
-		},
+		ID:     accountID,
+		Output: output,
 	}, nil
 }
 
+// waitForFirstScan triggers a scan (or joins the one already running) and
+// polls its status until it finishes. No status yet means the scan is still
+// being queued.
+func (ia *InsightsAccount) waitForFirstScan(
+	ctx context.Context, client config.Client, orgName, accountName string,
+) error {
+	if _, err := client.TriggerScan(ctx, orgName, accountName); err != nil {
+		return fmt.Errorf("failed to trigger scan: %w", err)
+	}
+	opts := ia.scanPoll
+	if opts.Interval == 0 {
+		opts.Interval = insightsScanInterval
+	}
+	if _, bounded := ctx.Deadline(); opts.Timeout == 0 && !bounded {
+		opts.Timeout = insightsScanTimeout
+	}
+	states := poll.States{Success: []string{"succeeded"}, Failure: []string{"failed"}}
+	_, err := poll.Status(ctx, opts, states, func(ctx context.Context) (string, error) {
+		status, err := client.GetScanStatus(ctx, orgName, accountName)
+		if err != nil || status == nil {
+			return "", err
+		}
+		return status.Status, nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for first scan of insights account '%s': %w", accountName, err)
+	}
+	return nil
+}
+
 func (*InsightsAccount) Delete(
 	ctx context.Context,
 	req infer.DeleteRequest[InsightsAccountState],
@@ -290,6 +352,7 @@ func (*InsightsAccount) Read(
 		ID: req.ID,
 		Inputs: InsightsAccountInput{
 			InsightsAccountCore: core,
+			WaitForFirstScan:    req.Inputs.WaitForFirstScan,
 		},
 		State: InsightsAccountState{
 			InsightsAccountCore:  core,
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -13,6 +14,7 @@ import (
 	"github.com/pulumi/pulumi-go-provider/infer"
 
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/poll"
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
 )
 
@@ -44,6 +46,12 @@ type InsightsAccountClientMock struct {
 	deleteInsightsAccountFunc  func(ctx context.Context, orgName string, accountName string) error
 	getInsightsAccountTagsFunc func(ctx context.Context, orgName string, accountName string) (map[string]string, error)
 	setInsightsAccountTagsFunc func(ctx context.Context, orgName string, accountName string, tags map[string]string) error
+	triggerScanFunc            func(
+		ctx context.Context, orgName string, accountName string,
+	) (*pulumiapi.TriggerScanResponse, error)
+	getScanStatusFunc func(
+		ctx context.Context, orgName string, accountName string,
+	) (*pulumiapi.ScanStatusResponse, error)
 }
 
 func (c *InsightsAccountClientMock) GetInsightsAccount(
@@ -93,10 +101,13 @@ func (c *InsightsAccountClientMock) DeleteInsightsAccount(
 }
 
 func (c *InsightsAccountClientMock) TriggerScan(
-	_ context.Context,
-	_ string,
-	_ string,
+	ctx context.Context,
+	orgName string,
+	accountName string,
 ) (*pulumiapi.TriggerScanResponse, error) {
+	if c.triggerScanFunc != nil {
+		return c.triggerScanFunc(ctx, orgName, accountName)
+	}
 	return &pulumiapi.TriggerScanResponse{
 		WorkflowRun: pulumiapi.WorkflowRun{
 			ID:     "test-scan-id",
@@ -106,10 +117,13 @@ func (c *InsightsAccountClientMock) TriggerScan(
 }
 
 func (c *InsightsAccountClientMock) GetScanStatus(
-	_ context.Context,
-	_ string,
-	_ string,
+	ctx context.Context,
+	orgName string,
+	accountName string,
 ) (*pulumiapi.ScanStatusResponse, error) {
+	if c.getScanStatusFunc != nil {
+		return c.getScanStatusFunc(ctx, orgName, accountName)
+	}
 	return &pulumiapi.ScanStatusResponse{
 		WorkflowRun: pulumiapi.WorkflowRun{
 			ID:     "test-scan-id",
@@ -1229,4 +1243,81 @@ func TestInsightsAccount(t *testing.T) {
 		assert.Equal(t, gcAccountID123, resp.Output.InsightsAccountID)
 	})
 
+	t.Run("Create waits for the first scan", func(t *testing.T) {
+		t.Parallel()
+		triggered := false
+		statuses := []*pulumiapi.ScanStatusResponse{
+			nil,
+			{WorkflowRun: pulumiapi.WorkflowRun{Status: "running"}},
+			{WorkflowRun: pulumiapi.WorkflowRun{Status: "succeeded"}},
+		}
+		polls := 0
+		mockedClient := &InsightsAccountClientMock{
+			getInsightsAccountFunc: func(_ context.Context, _ string, accountName string) (*pulumiapi.InsightsAccount, error) {
+				return &pulumiapi.InsightsAccount{ID: gcAccountID123, Name: accountName, Provider: gcAWS}, nil
+			},
+			triggerScanFunc: func(_ context.Context, _ string, _ string) (*pulumiapi.TriggerScanResponse, error) {
+				triggered = true
+				return &pulumiapi.TriggerScanResponse{}, nil
+			},
+			getScanStatusFunc: func(_ context.Context, _ string, _ string) (*pulumiapi.ScanStatusResponse, error) {
+				status := statuses[polls]
+				polls++
+				return status, nil
+			},
+		}
+		ctx := config.WithMockClient(context.Background(), mockedClient)
+
+		ia := &InsightsAccount{scanPoll: poll.Options{Interval: time.Millisecond}}
+		resp, err := ia.Create(ctx, infer.CreateRequest[InsightsAccountInput]{
+			Inputs: InsightsAccountInput{
+				InsightsAccountCore: InsightsAccountCore{
+					OrganizationName: gcTestOrg,
+					AccountName:      gcTestAccount,
+					Provider:         CloudProviderAWS,
+					Environment:      gcTestEnv,
+					ScanSchedule:     ScanScheduleNone,
+				},
+				WaitForFirstScan: true,
+			},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, triggered)
+		assert.Equal(t, 3, polls)
+		assert.Equal(t, gcAccountID123, resp.Output.InsightsAccountID)
+	})
+
+	t.Run("Create fails when the first scan fails", func(t *testing.T) {
+		t.Parallel()
+		mockedClient := &InsightsAccountClientMock{
+			getInsightsAccountFunc: func(_ context.Context, _ string, accountName string) (*pulumiapi.InsightsAccount, error) {
+				return &pulumiapi.InsightsAccount{ID: gcAccountID123, Name: accountName, Provider: gcAWS}, nil
+			},
+			getScanStatusFunc: func(_ context.Context, _ string, _ string) (*pulumiapi.ScanStatusResponse, error) {
+				return &pulumiapi.ScanStatusResponse{WorkflowRun: pulumiapi.WorkflowRun{Status: "failed"}}, nil
+			},
+		}
+		ctx := config.WithMockClient(context.Background(), mockedClient)
+
+		ia := &InsightsAccount{}
+		resp, err := ia.Create(ctx, infer.CreateRequest[InsightsAccountInput]{
+			Inputs: InsightsAccountInput{
+				InsightsAccountCore: InsightsAccountCore{
+					OrganizationName: gcTestOrg,
+					AccountName:      gcTestAccount,
+					Provider:         CloudProviderAWS,
+					Environment:      gcTestEnv,
+					ScanSchedule:     ScanScheduleNone,
+				},
+				WaitForFirstScan: true,
+			},
+		})
+
+		var initErr infer.ResourceInitFailedError
+		require.ErrorAs(t, err, &initErr)
+		assert.Contains(t, initErr.Reasons[0], `finished with status "failed"`)
+		assert.Equal(t, gcTestOrgAccount, resp.ID)
+		assert.Equal(t, gcAccountID123, resp.Output.InsightsAccountID)
+	})
 }
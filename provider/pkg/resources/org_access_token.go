@@ -19,26 +19,38 @@ import (
 	"fmt"
 	"strings"
 
+	p "github.com/pulumi/pulumi-go-provider"
 	"github.com/pulumi/pulumi-go-provider/infer"
 	"github.com/pulumi/pulumi/sdk/v3/go/property"
 
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/util"
 )
 
-type OrgAccessToken struct{}
+type OrgAccessToken struct {
+	// now overrides the clock rotations come due by.
+	now tokenClock
+}
 
 var (
+	_ infer.CustomCheck[OrgAccessTokenInput]                       = &OrgAccessToken{}
 	_ infer.CustomCreate[OrgAccessTokenInput, OrgAccessTokenState] = &OrgAccessToken{}
+	_ infer.CustomDiff[OrgAccessTokenInput, OrgAccessTokenState]   = &OrgAccessToken{}
+	_ infer.CustomUpdate[OrgAccessTokenInput, OrgAccessTokenState] = &OrgAccessToken{}
 	_ infer.CustomDelete[OrgAccessTokenState]                      = &OrgAccessToken{}
 	_ infer.CustomRead[OrgAccessTokenInput, OrgAccessTokenState]   = &OrgAccessToken{}
 	_ infer.CustomStateMigrations[OrgAccessTokenState]             = &OrgAccessToken{}
 )
 
-func (*OrgAccessToken) Annotate(a infer.Annotator) {
+func (t *OrgAccessToken) Annotate(a infer.Annotator) {
 	a.Describe(
-		&OrgAccessToken{},
+		t,
 		"The Pulumi Cloud allows users to create access tokens scoped to orgs. "+
-			"Org access tokens is a resource to create them and assign them to an org",
+			"Org access tokens is a resource to create them and assign them to an org.\n\n"+
+			"With `ttl` and `rotation`, a successor token is issued ahead of expiry and `value` changes to it, "+
+			"while the previous token stays valid for `keepPrevious` so its consumers can move over. "+
+			"Token names are unique, so a successor is named after `name` with the rotation time appended.",
 	)
 }
 
@@ -47,6 +59,7 @@ type OrgAccessTokenInput struct {
 	OrganizationName string  `pulumi:"organizationName"     provider:"replaceOnChanges"`
 	Description      *string `pulumi:"description,optional" provider:"replaceOnChanges"`
 	Admin            *bool   `pulumi:"admin,optional"       provider:"replaceOnChanges"`
+	TokenExpiry
 }
 
 func (i *OrgAccessTokenInput) Annotate(a infer.Annotator) {
@@ -60,13 +73,25 @@ func (i *OrgAccessTokenInput) Annotate(a infer.Annotator) {
 type OrgAccessTokenState struct {
 	OrgAccessTokenInput
 	Value string `pulumi:"value" provider:"secret"`
+	TokenRotationState
 }
 
 func (s *OrgAccessTokenState) Annotate(a infer.Annotator) {
 	a.Describe(&s.Value, "The token's value.")
 }
 
-func (*OrgAccessToken) Create(
+func (*OrgAccessToken) Check(
+	ctx context.Context, req infer.CheckRequest,
+) (infer.CheckResponse[OrgAccessTokenInput], error) {
+	i, failures, err := infer.DefaultCheck[OrgAccessTokenInput](ctx, req.NewInputs)
+	if err != nil {
+		return infer.CheckResponse[OrgAccessTokenInput]{}, err
+	}
+	failures = append(failures, i.check()...)
+	return infer.CheckResponse[OrgAccessTokenInput]{Inputs: i, Failures: failures}, nil
+}
+
+func (t *OrgAccessToken) Create(
 	ctx context.Context,
 	req infer.CreateRequest[OrgAccessTokenInput],
 ) (infer.CreateResponse[OrgAccessTokenState], error) {
@@ -75,43 +100,99 @@ func (*OrgAccessToken) Create(
 			Output: OrgAccessTokenState{OrgAccessTokenInput: req.Inputs},
 		}, nil
 	}
-	desc := ""
-	if req.Inputs.Description != nil {
-		desc = *req.Inputs.Description
-	}
-	admin := false
-	if req.Inputs.Admin != nil {
-		admin = *req.Inputs.Admin
-	}
-	token, err := config.GetClient(ctx).CreateOrgAccessToken(
-		ctx, req.Inputs.Name, req.Inputs.OrganizationName, desc, admin,
-	)
+	token, err := t.rotation(ctx, req.Inputs, req.Inputs.Name).issue(req.Inputs.expiresAt(t.now.now()))
 	if err != nil {
 		return infer.CreateResponse[OrgAccessTokenState]{}, fmt.Errorf(
 			"error creating org access token %q: %w", req.Inputs.Name, err,
 		)
 	}
+	inputs := req.Inputs
+	inputs.TokenExpiry = req.Inputs.issued(token.Expires)
 	return infer.CreateResponse[OrgAccessTokenState]{
 		ID: orgAccessTokenID(req.Inputs.OrganizationName, req.Inputs.Name, token.ID),
 		Output: OrgAccessTokenState{
-			OrgAccessTokenInput: req.Inputs,
+			OrgAccessTokenInput: inputs,
 			Value:               token.TokenValue,
+			TokenRotationState:  TokenRotationState{TokenID: token.ID},
 		},
 	}, nil
 }
 
-func (*OrgAccessToken) Delete(
+func (t *OrgAccessToken) Diff(
+	_ context.Context,
+	req infer.DiffRequest[OrgAccessTokenInput, OrgAccessTokenState],
+) (infer.DiffResponse, error) {
+	diff := map[string]p.PropertyDiff{}
+	for key, changed := range map[string]bool{
+		gcName:             req.State.Name != req.Inputs.Name,
+		gcOrganizationName: req.State.OrganizationName != req.Inputs.OrganizationName,
+		gcDescription:      util.OrZero(req.State.Description) != util.OrZero(req.Inputs.Description),
+		gcAdmin:            util.OrZero(req.State.Admin) != util.OrZero(req.Inputs.Admin),
+	} {
+		if changed {
+			diff[key] = p.PropertyDiff{Kind: p.UpdateReplace, InputDiff: true}
+		}
+	}
+	diffTokenExpiry(diff, req.State.TokenExpiry, req.State.TokenRotationState, req.Inputs.TokenExpiry, t.now.now())
+	return infer.DiffResponse{HasChanges: len(diff) > 0, DetailedDiff: diff}, nil
+}
+
+// Update rotates the token when it is due; other changes replace it.
+func (t *OrgAccessToken) Update(
+	ctx context.Context,
+	req infer.UpdateRequest[OrgAccessTokenInput, OrgAccessTokenState],
+) (infer.UpdateResponse[OrgAccessTokenState], error) {
+	if req.DryRun {
+		output := req.State
+		output.Rotation = req.Inputs.Rotation
+		return infer.UpdateResponse[OrgAccessTokenState]{Output: output}, nil
+	}
+	_, _, tokenID, err := splitOrgAccessTokenID(req.ID)
+	if err != nil {
+		return infer.UpdateResponse[OrgAccessTokenState]{}, err
+	}
+	now := t.now.now()
+	result, err := t.rotation(ctx, req.Inputs, successorName(req.Inputs.Name, now)).apply(
+		req.State.TokenExpiry, req.State.current(tokenID), req.Inputs.TokenExpiry, now)
+	output := OrgAccessTokenState{
+		OrgAccessTokenInput: req.Inputs,
+		Value:               req.State.Value,
+		TokenRotationState:  result.state,
+	}
+	output.TokenExpiry = result.expiry
+	if result.value != nil {
+		output.Value = *result.value
+	}
+	return infer.UpdateResponse[OrgAccessTokenState]{Output: output}, tokenUpdateError(err)
+}
+
+// rotation issues tokens named name.
+func (*OrgAccessToken) rotation(ctx context.Context, inputs OrgAccessTokenInput, name string) tokenRotation {
+	client := config.GetClient(ctx)
+	return tokenRotation{
+		issue: func(expires int64) (*pulumiapi.AccessToken, error) {
+			return client.CreateOrgAccessToken(ctx, name, inputs.OrganizationName,
+				util.OrZero(inputs.Description), util.OrZero(inputs.Admin), expires)
+		},
+		delete: func(tokenID string) error {
+			return client.DeleteOrgAccessToken(ctx, tokenID, inputs.OrganizationName)
+		},
+	}
+}
+
+func (t *OrgAccessToken) Delete(
 	ctx context.Context,
 	req infer.DeleteRequest[OrgAccessTokenState],
 ) (infer.DeleteResponse, error) {
-	orgName, _, tokenID, err := splitOrgAccessTokenID(req.ID)
+	orgName, name, tokenID, err := splitOrgAccessTokenID(req.ID)
 	if err != nil {
 		return infer.DeleteResponse{}, err
 	}
-	return infer.DeleteResponse{}, config.GetClient(ctx).DeleteOrgAccessToken(ctx, tokenID, orgName)
+	inputs := OrgAccessTokenInput{OrganizationName: orgName}
+	return infer.DeleteResponse{}, t.rotation(ctx, inputs, name).deleteTokens(req.State.current(tokenID))
 }
 
-func (*OrgAccessToken) Read(
+func (t *OrgAccessToken) Read(
 	ctx context.Context,
 	req infer.ReadRequest[OrgAccessTokenInput, OrgAccessTokenState],
 ) (infer.ReadResponse[OrgAccessTokenInput, OrgAccessTokenState], error) {
@@ -120,7 +201,8 @@ func (*OrgAccessToken) Read(
 		return infer.ReadResponse[OrgAccessTokenInput, OrgAccessTokenState]{}, err
 	}
 
-	token, err := config.GetClient(ctx).GetOrgAccessToken(ctx, tokenID, orgName)
+	rotation := req.State.current(tokenID)
+	token, err := config.GetClient(ctx).GetOrgAccessToken(ctx, rotation.TokenID, orgName)
 	if err != nil {
 		return infer.ReadResponse[OrgAccessTokenInput, OrgAccessTokenState]{}, err
 	}
@@ -128,22 +210,32 @@ func (*OrgAccessToken) Read(
 		return infer.ReadResponse[OrgAccessTokenInput, OrgAccessTokenState]{}, nil
 	}
 
+	name := token.Name
+	if rotation.TokenID != tokenID {
+		// A successor's name carries its rotation time.
+		name = req.State.Name
+	}
 	admin := token.Admin
+	expiryInputs, expiryState := readTokenExpiry(req.Inputs.TokenExpiry, *token)
 	inputs := OrgAccessTokenInput{
-		Name:             token.Name,
+		Name:             name,
 		OrganizationName: orgName,
 		Description:      stringPtrIfNonEmpty(token.Description),
 		Admin:            &admin,
+		TokenExpiry:      expiryInputs,
 	}
+	state := OrgAccessTokenState{
+		OrgAccessTokenInput: inputs,
+		// Token values aren't retrievable from the API after creation; carry
+		// the existing secret from state so refresh does not erase it.
+		Value:              req.State.Value,
+		TokenRotationState: rotation.read(*token, t.now.now()),
+	}
+	state.TokenExpiry = expiryState
 	return infer.ReadResponse[OrgAccessTokenInput, OrgAccessTokenState]{
 		ID:     req.ID,
 		Inputs: inputs,
-		State: OrgAccessTokenState{
-			OrgAccessTokenInput: inputs,
-			// Token values aren't retrievable from the API after creation; carry
-			// the existing secret from state so refresh does not erase it.
-			Value: req.State.Value,
-		},
+		State:  state,
 	}, nil
 }
 
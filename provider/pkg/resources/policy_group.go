@@ -22,15 +22,12 @@ import (
 	"strings"
 	"time"
 
-	"google.golang.org/grpc/codes"
-	pbempty "google.golang.org/protobuf/types/known/emptypb"
-	"google.golang.org/protobuf/types/known/structpb"
-
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil/rpcerror"
-	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
 
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/util"
 )
@@ -49,375 +46,479 @@ const (
 	gcPreventative     = "preventative"
 )
 
-type PulumiServicePolicyGroupResource struct {
-	Client pulumiapi.PolicyGroupClient
-}
+type PolicyGroup struct{}
 
-type PulumiServicePolicyGroupInput struct {
-	Name             string
-	OrganizationName string
-	EntityType       string
-	Mode             string
-	Stacks           []pulumiapi.StackReference
-	Accounts         []string
-	PolicyPacks      []pulumiapi.PolicyPackMetadata
-}
-
-func (i *PulumiServicePolicyGroupInput) ToPropertyMap() resource.PropertyMap {
-	// Convert the entire struct to a map first, then use helper
-	inputMap := map[string]interface{}{
-		gcName:             i.Name,
-		gcOrganizationName: i.OrganizationName,
-		gcEntityType:       i.EntityType,
-		gcMode:             i.Mode,
-		gcStacks:           convertStacksToInterfaceArray(i.Stacks),
-		gcAccounts:         i.Accounts,
-		gcPolicyPacks:      convertPolicyPacksToInterfaceArray(i.PolicyPacks),
-	}
+var (
+	_ infer.CustomCheck[PolicyGroupInput]                    = &PolicyGroup{}
+	_ infer.CustomDiff[PolicyGroupInput, PolicyGroupState]   = &PolicyGroup{}
+	_ infer.CustomCreate[PolicyGroupInput, PolicyGroupState] = &PolicyGroup{}
+	_ infer.CustomUpdate[PolicyGroupInput, PolicyGroupState] = &PolicyGroup{}
+	_ infer.CustomRead[PolicyGroupInput, PolicyGroupState]   = &PolicyGroup{}
+	_ infer.CustomDelete[PolicyGroupState]                   = &PolicyGroup{}
+)
 
-	return resource.NewPropertyMapFromMap(inputMap)
+func (*PolicyGroup) Annotate(a infer.Annotator) {
+	a.Describe(&PolicyGroup{}, "A Policy Group allows you to apply policy packs to a set of stacks in your organization.")
+	a.SetToken("index", "PolicyGroup")
 }
 
-// convertStacksToInterfaceArray converts []pulumiapi.StackReference to []interface{}
-func convertStacksToInterfaceArray(stacks []pulumiapi.StackReference) []interface{} {
-	result := make([]interface{}, len(stacks))
-	for i, stack := range stacks {
-		result[i] = map[string]interface{}{
-			gcName:           stack.Name,
-			"routingProject": stack.RoutingProject,
-		}
-	}
-	return result
+type PolicyGroupInput struct {
+	Name             string                                `pulumi:"name"                 provider:"replaceOnChanges"`
+	OrganizationName string                                `pulumi:"organizationName"     provider:"replaceOnChanges"`
+	EntityType       string                                `pulumi:"entityType,optional"  provider:"replaceOnChanges"`
+	Mode             string                                `pulumi:"mode,optional"        provider:"replaceOnChanges"`
+	Stacks           []PolicyGroupStackReference           `pulumi:"stacks,optional"`
+	Accounts         []string                              `pulumi:"accounts,optional"`
+	PolicyPacks      []PolicyGroupPolicyPackReferenceInput `pulumi:"policyPacks,optional"`
 }
 
-// convertPolicyPacksToInterfaceArray converts []pulumiapi.PolicyPackMetadata to []interface{}
-func convertPolicyPacksToInterfaceArray(policyPacks []pulumiapi.PolicyPackMetadata) []interface{} {
-	result := make([]interface{}, len(policyPacks))
-	for i, pp := range policyPacks {
-		ppMap := map[string]interface{}{
-			gcName:        pp.Name,
-			gcDisplayName: pp.DisplayName,
-			"version":     float64(pp.Version),
-			gcVersionTag:  pp.VersionTag,
-		}
-		if pp.Config != nil {
-			ppMap["config"] = pp.Config
-		}
-		result[i] = ppMap
-	}
-	return result
+func (i *PolicyGroupInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.Name, "The name of the policy group.")
+	a.Describe(&i.OrganizationName, "The name of the Pulumi organization the policy group belongs to.")
+	a.Describe(&i.EntityType, "The entity type for the policy group. Valid values are 'stacks' or 'accounts'. "+
+		"Defaults to 'stacks'.")
+	a.SetDefault(&i.EntityType, gcStacks)
+	a.Describe(&i.Mode, "The mode for the policy group. Valid values are 'audit' (reports violations) or "+
+		"'preventative' (blocks operations). Defaults to 'audit'.")
+	a.SetDefault(&i.Mode, gcAudit)
+	a.Describe(&i.Stacks, "List of stack references that belong to this policy group.")
+	a.Describe(&i.Accounts, "List of accounts that belong to this policy group.")
+	a.Describe(&i.PolicyPacks, "List of policy packs applied to this policy group.")
 }
 
-// convertInterfaceArrayToStacks converts []interface{} to []pulumiapi.StackReference using helpers
-func convertInterfaceArrayToStacks(arr []interface{}) []pulumiapi.StackReference {
-	result := make([]pulumiapi.StackReference, 0, len(arr))
-	for _, item := range arr {
-		if stackMap, ok := item.(map[string]interface{}); ok {
-			stack := pulumiapi.StackReference{}
-			if name, ok := stackMap[gcName].(string); ok {
-				stack.Name = name
-			}
-			if routingProject, ok := stackMap["routingProject"].(string); ok {
-				stack.RoutingProject = routingProject
-			}
-			result = append(result, stack)
-		}
-	}
-	return result
+type PolicyGroupState struct {
+	Name             string                           `pulumi:"name"`
+	OrganizationName string                           `pulumi:"organizationName"`
+	EntityType       string                           `pulumi:"entityType"`
+	Mode             string                           `pulumi:"mode"`
+	Stacks           []PolicyGroupStackReference      `pulumi:"stacks,optional"`
+	Accounts         []string                         `pulumi:"accounts,optional"`
+	PolicyPacks      []PolicyGroupPolicyPackReference `pulumi:"policyPacks,optional"`
 }
 
-// convertInterfaceArrayToPolicyPacks converts []interface{} to []pulumiapi.PolicyPackMetadata using helpers
-func convertInterfaceArrayToPolicyPacks(arr []interface{}) []pulumiapi.PolicyPackMetadata {
-	result := make([]pulumiapi.PolicyPackMetadata, 0, len(arr))
-	for _, item := range arr {
-		if ppMap, ok := item.(map[string]interface{}); ok {
-			pp := pulumiapi.PolicyPackMetadata{}
-			if name, ok := ppMap[gcName].(string); ok {
-				pp.Name = name
-			}
-			if displayName, ok := ppMap[gcDisplayName].(string); ok {
-				pp.DisplayName = displayName
-			}
-			if version, ok := ppMap["version"].(float64); ok {
-				pp.Version = int(version)
-			}
-			if versionTag, ok := ppMap[gcVersionTag].(string); ok {
-				pp.VersionTag = versionTag
-			}
-			if config, ok := ppMap["config"].(map[string]interface{}); ok {
-				pp.Config = config
-			}
-			result = append(result, pp)
-		}
-	}
-	return result
+func (s *PolicyGroupState) Annotate(a infer.Annotator) {
+	a.Describe(&s.Name, "The name of the policy group.")
+	a.Describe(&s.OrganizationName, "The name of the Pulumi organization the policy group belongs to.")
+	a.Describe(&s.EntityType, "The entity type for the policy group. Valid values are 'stacks' or 'accounts'. "+
+		"Defaults to 'stacks'.")
+	a.Describe(&s.Mode, "The mode for the policy group. Valid values are 'audit' (reports violations) or "+
+		"'preventative' (blocks operations). Defaults to 'audit'.")
+	a.Describe(&s.Stacks, "List of stack references that belong to this policy group.")
+	a.Describe(&s.Accounts, "List of accounts that belong to this policy group.")
+	a.Describe(&s.PolicyPacks, "List of policy packs applied to this policy group.")
 }
 
-// convertInterfaceArrayToStrings converts []interface{} to []string
-func convertInterfaceArrayToStrings(arr []interface{}) []string {
-	result := make([]string, 0, len(arr))
-	for _, item := range arr {
-		if s, ok := item.(string); ok {
-			result = append(result, s)
-		}
-	}
-	return result
+type PolicyGroupStackReference struct {
+	Name           string `pulumi:"name"`
+	RoutingProject string `pulumi:"routingProject"`
 }
 
-func (i *PulumiServicePolicyGroupInput) ToRPC() (*structpb.Struct, error) {
-	return plugin.MarshalProperties(i.ToPropertyMap(), plugin.MarshalOptions{
-		KeepOutputValues: true,
-	})
+func (r *PolicyGroupStackReference) Annotate(a infer.Annotator) {
+	a.Describe(r, "A reference to a stack within a policy group.")
+	a.Describe(&r.Name, "The name of the stack.")
+	a.Describe(&r.RoutingProject, "The routing project name (also known as project name).")
 }
 
-func ToPulumiServicePolicyGroupInput(inputMap resource.PropertyMap) PulumiServicePolicyGroupInput {
-	// Convert PropertyMap to regular map using helper, then extract fields
-	interfaceMap := inputMap.Mappable()
-
-	input := PulumiServicePolicyGroupInput{}
+type PolicyGroupPolicyPackReferenceInput struct {
+	Name        string         `pulumi:"name"`
+	DisplayName *string        `pulumi:"displayName,optional"`
+	VersionTag  *string        `pulumi:"versionTag,optional"`
+	Config      map[string]any `pulumi:"config,optional"`
+}
 
-	if name, ok := interfaceMap[gcName].(string); ok {
-		input.Name = name
-	}
+func (r *PolicyGroupPolicyPackReferenceInput) Annotate(a infer.Annotator) {
+	a.Describe(r, "A reference to a policy pack within a policy group (input).")
+	a.Describe(&r.Name, "The name of the policy pack.")
+	a.Describe(&r.DisplayName, "The display name of the policy pack.")
+	a.Describe(&r.VersionTag, "The version tag of the policy pack.")
+	a.Describe(&r.Config, policyPackConfigDescription)
+}
 
-	if organizationName, ok := interfaceMap[gcOrganizationName].(string); ok {
-		input.OrganizationName = organizationName
-	}
+type PolicyGroupPolicyPackReference struct {
+	Name        string         `pulumi:"name"`
+	DisplayName string         `pulumi:"displayName,optional"`
+	Version     float64        `pulumi:"version,optional"`
+	VersionTag  string         `pulumi:"versionTag,optional"`
+	Config      map[string]any `pulumi:"config,optional"`
+}
 
-	if entityType, ok := interfaceMap[gcEntityType].(string); ok {
-		input.EntityType = entityType
-	}
+func (r *PolicyGroupPolicyPackReference) Annotate(a infer.Annotator) {
+	a.Describe(r, "A reference to a policy pack within a policy group.")
+	a.Describe(&r.Name, "The name of the policy pack.")
+	a.Describe(&r.DisplayName, "The display name of the policy pack.")
+	a.Describe(&r.Version, "The server-derived numeric version of the policy pack. This is output-only; use "+
+		"`versionTag` to pin a specific version.")
+	a.Describe(&r.VersionTag, "The version tag of the policy pack.")
+	a.Describe(&r.Config, policyPackConfigDescription)
+}
 
-	if mode, ok := interfaceMap[gcMode].(string); ok {
-		input.Mode = mode
-	}
+const policyPackConfigDescription = "Optional configuration for the policy pack. The special key `all` sets the " +
+	"default enforcement level for every policy in the pack; per-policy entries override it."
 
-	// Parse stacks using helper
-	if stacksInterface, ok := interfaceMap[gcStacks].([]interface{}); ok {
-		input.Stacks = convertInterfaceArrayToStacks(stacksInterface)
-	}
+// policyGroupSpec is a policy group in Pulumi Cloud's terms. Inputs, state and
+// API responses all convert to it, so they can be compared with one another.
+type policyGroupSpec struct {
+	Name             string
+	OrganizationName string
+	EntityType       string
+	Mode             string
+	Stacks           []pulumiapi.StackReference
+	Accounts         []string
+	PolicyPacks      []pulumiapi.PolicyPackMetadata
+}
 
-	// Parse policy packs using helper
-	if policyPacksInterface, ok := interfaceMap[gcPolicyPacks].([]interface{}); ok {
-		input.PolicyPacks = convertInterfaceArrayToPolicyPacks(policyPacksInterface)
+func (i *PolicyGroupInput) spec() policyGroupSpec {
+	spec := policyGroupSpec{
+		Name:             i.Name,
+		OrganizationName: i.OrganizationName,
+		EntityType:       i.EntityType,
+		Mode:             i.Mode,
+		Stacks:           toStackReferences(i.Stacks),
+		Accounts:         i.Accounts,
+	}
+	for _, pp := range i.PolicyPacks {
+		spec.PolicyPacks = append(spec.PolicyPacks, pulumiapi.PolicyPackMetadata{
+			Name:        pp.Name,
+			DisplayName: util.OrZero(pp.DisplayName),
+			VersionTag:  util.OrZero(pp.VersionTag),
+			Config:      pp.Config,
+		})
 	}
+	return spec
+}
 
-	// Parse accounts using helper
-	if accountsInterface, ok := interfaceMap[gcAccounts].([]interface{}); ok {
-		input.Accounts = convertInterfaceArrayToStrings(accountsInterface)
+func (s *PolicyGroupState) spec() policyGroupSpec {
+	spec := policyGroupSpec{
+		Name:             s.Name,
+		OrganizationName: s.OrganizationName,
+		EntityType:       s.EntityType,
+		Mode:             s.Mode,
+		Stacks:           toStackReferences(s.Stacks),
+		Accounts:         s.Accounts,
+	}
+	for _, pp := range s.PolicyPacks {
+		spec.PolicyPacks = append(spec.PolicyPacks, pulumiapi.PolicyPackMetadata{
+			Name:        pp.Name,
+			DisplayName: pp.DisplayName,
+			Version:     int(pp.Version),
+			VersionTag:  pp.VersionTag,
+			Config:      pp.Config,
+		})
 	}
-
-	return input
+	return spec
 }
 
-func (p *PulumiServicePolicyGroupResource) Name() string {
-	return "pulumiservice:index:PolicyGroup"
+// state is the state Pulumi Cloud is expected to report once the inputs are
+// applied. It stands in for the real state in previews and partial failures.
+func (i *PolicyGroupInput) state() PolicyGroupState {
+	state := PolicyGroupState{
+		Name:             i.Name,
+		OrganizationName: i.OrganizationName,
+		EntityType:       i.EntityType,
+		Mode:             i.Mode,
+		Stacks:           i.Stacks,
+		Accounts:         i.Accounts,
+	}
+	for _, pp := range i.PolicyPacks {
+		state.PolicyPacks = append(state.PolicyPacks, PolicyGroupPolicyPackReference{
+			Name:        pp.Name,
+			DisplayName: util.OrZero(pp.DisplayName),
+			VersionTag:  util.OrZero(pp.VersionTag),
+			Config:      pp.Config,
+		})
+	}
+	return state
 }
 
-func (p *PulumiServicePolicyGroupResource) Check(req *pulumirpc.CheckRequest) (*pulumirpc.CheckResponse, error) {
-	news := req.GetNews()
-	newsMap, err := plugin.UnmarshalProperties(news, plugin.MarshalOptions{KeepUnknowns: true, SkipNulls: true})
-	if err != nil {
-		return nil, err
+func toStackReferences(stacks []PolicyGroupStackReference) []pulumiapi.StackReference {
+	var result []pulumiapi.StackReference
+	for _, s := range stacks {
+		result = append(result, pulumiapi.StackReference{Name: s.Name, RoutingProject: s.RoutingProject})
 	}
+	return result
+}
 
-	var failures []*pulumirpc.CheckFailure
-
-	if !newsMap[gcName].HasValue() {
-		failures = append(failures, &pulumirpc.CheckFailure{
-			Reason:   "missing required property 'name'",
-			Property: gcName,
+// newPolicyGroupState renders a policy group returned by Pulumi Cloud.
+func newPolicyGroupState(orgName string, pg *pulumiapi.PolicyGroup) PolicyGroupState {
+	state := PolicyGroupState{
+		Name:             pg.Name,
+		OrganizationName: orgName,
+		EntityType:       pg.EntityType,
+		Mode:             pg.Mode,
+		Accounts:         pg.Accounts,
+	}
+	for _, s := range pg.Stacks {
+		state.Stacks = append(state.Stacks, PolicyGroupStackReference{Name: s.Name, RoutingProject: s.RoutingProject})
+	}
+	for _, pp := range pg.AppliedPolicyPacks {
+		state.PolicyPacks = append(state.PolicyPacks, PolicyGroupPolicyPackReference{
+			Name:        pp.Name,
+			DisplayName: pp.DisplayName,
+			Version:     float64(pp.Version),
+			VersionTag:  pp.VersionTag,
+			Config:      pp.Config,
 		})
 	}
+	return state
+}
 
-	if !newsMap[gcOrganizationName].HasValue() {
-		failures = append(failures, &pulumirpc.CheckFailure{
-			Reason:   "missing required property 'organizationName'",
-			Property: gcOrganizationName,
+// policyGroupInputFromState renders state as inputs. The numeric `version` of
+// each policy pack is output-only, so it's left behind.
+func policyGroupInputFromState(state PolicyGroupState) PolicyGroupInput {
+	input := PolicyGroupInput{
+		Name:             state.Name,
+		OrganizationName: state.OrganizationName,
+		EntityType:       state.EntityType,
+		Mode:             state.Mode,
+		Stacks:           state.Stacks,
+		Accounts:         state.Accounts,
+	}
+	for _, pp := range state.PolicyPacks {
+		input.PolicyPacks = append(input.PolicyPacks, PolicyGroupPolicyPackReferenceInput{
+			Name:        pp.Name,
+			DisplayName: util.OrNil(pp.DisplayName),
+			VersionTag:  util.OrNil(pp.VersionTag),
+			Config:      pp.Config,
 		})
 	}
+	return input
+}
 
-	// Apply defaults if not provided
-	if !newsMap[gcEntityType].HasValue() {
-		newsMap[gcEntityType] = resource.NewPropertyValue(gcStacks)
-	}
-	if !newsMap[gcMode].HasValue() {
-		newsMap[gcMode] = resource.NewPropertyValue(gcAudit)
+// policyGroupSpecFromMap reads a policy group from untyped inputs. Missing or
+// mistyped fields are left at their zero value.
+func policyGroupSpecFromMap(inputMap resource.PropertyMap) policyGroupSpec {
+	interfaceMap := inputMap.Mappable()
+
+	spec := policyGroupSpec{}
+	spec.Name, _ = interfaceMap[gcName].(string)
+	spec.OrganizationName, _ = interfaceMap[gcOrganizationName].(string)
+	spec.EntityType, _ = interfaceMap[gcEntityType].(string)
+	spec.Mode, _ = interfaceMap[gcMode].(string)
+
+	if stacks, ok := interfaceMap[gcStacks].([]interface{}); ok {
+		for _, item := range stacks {
+			if stackMap, ok := item.(map[string]interface{}); ok {
+				stack := pulumiapi.StackReference{}
+				stack.Name, _ = stackMap[gcName].(string)
+				stack.RoutingProject, _ = stackMap["routingProject"].(string)
+				spec.Stacks = append(spec.Stacks, stack)
+			}
+		}
 	}
 
-	// Validate enum values
-	if newsMap[gcEntityType].HasValue() {
-		entityType := newsMap[gcEntityType].StringValue()
-		if entityType != gcStacks && entityType != gcAccounts {
-			failures = append(failures, &pulumirpc.CheckFailure{
-				Reason:   "entityType must be either 'stacks' or 'accounts'",
-				Property: gcEntityType,
-			})
+	if policyPacks, ok := interfaceMap[gcPolicyPacks].([]interface{}); ok {
+		for _, item := range policyPacks {
+			if ppMap, ok := item.(map[string]interface{}); ok {
+				pp := pulumiapi.PolicyPackMetadata{}
+				pp.Name, _ = ppMap[gcName].(string)
+				pp.DisplayName, _ = ppMap[gcDisplayName].(string)
+				if version, ok := ppMap["version"].(float64); ok {
+					pp.Version = int(version)
+				}
+				pp.VersionTag, _ = ppMap[gcVersionTag].(string)
+				pp.Config, _ = ppMap["config"].(map[string]interface{})
+				spec.PolicyPacks = append(spec.PolicyPacks, pp)
+			}
 		}
 	}
 
-	if newsMap[gcMode].HasValue() {
-		mode := newsMap[gcMode].StringValue()
-		if mode != gcAudit && mode != gcPreventative {
-			failures = append(failures, &pulumirpc.CheckFailure{
-				Reason:   "mode must be either 'audit' or 'preventative'",
-				Property: gcMode,
-			})
+	if accounts, ok := interfaceMap[gcAccounts].([]interface{}); ok {
+		for _, item := range accounts {
+			if s, ok := item.(string); ok {
+				spec.Accounts = append(spec.Accounts, s)
+			}
 		}
 	}
 
+	return spec
+}
+
+func (*PolicyGroup) Check(
+	ctx context.Context, req infer.CheckRequest,
+) (infer.CheckResponse[PolicyGroupInput], error) {
 	// The numeric `version` on policy packs is server-derived from `versionTag`
 	// and is output-only. Strip it from inputs so legacy programs that still set
 	// it upgrade cleanly without spurious diffs.
-	stripPolicyPackVersion(newsMap)
+	news := stripPolicyPackVersion(req.NewInputs)
 
-	inputs, err := plugin.MarshalProperties(newsMap, plugin.MarshalOptions{KeepUnknowns: true, SkipNulls: true})
+	in, failures, err := infer.DefaultCheck[PolicyGroupInput](ctx, news)
 	if err != nil {
-		return nil, err
+		return infer.CheckResponse[PolicyGroupInput]{}, err
 	}
 
-	return &pulumirpc.CheckResponse{Inputs: inputs, Failures: failures}, nil
-}
-
-func (p *PulumiServicePolicyGroupResource) Delete(req *pulumirpc.DeleteRequest) (*pbempty.Empty, error) {
-	ctx := context.Background()
-	orgName, policyGroupName, err := splitSingleSlashString(req.Id)
-	if err != nil {
-		return &pbempty.Empty{}, err
+	// Validate enum values
+	// DefaultCheck replaces an explicit "" with the default, so it's rejected
+	// before the decoded value is looked at.
+	if isEmptyInput(news, gcEntityType) ||
+		!isUnknownInput(news, gcEntityType) && in.EntityType != gcStacks && in.EntityType != gcAccounts {
+		failures = append(failures, p.CheckFailure{
+			Reason:   "entityType must be either 'stacks' or 'accounts'",
+			Property: gcEntityType,
+		})
 	}
-
-	err = p.Client.DeletePolicyGroup(ctx, orgName, policyGroupName)
-	if err != nil {
-		return &pbempty.Empty{}, fmt.Errorf("failed to delete policy group %q: %w", req.Id, err)
+	if isEmptyInput(news, gcMode) ||
+		!isUnknownInput(news, gcMode) && in.Mode != gcAudit && in.Mode != gcPreventative {
+		failures = append(failures, p.CheckFailure{
+			Reason:   "mode must be either 'audit' or 'preventative'",
+			Property: gcMode,
+		})
 	}
 
-	return &pbempty.Empty{}, nil
+	return infer.CheckResponse[PolicyGroupInput]{Inputs: in, Failures: failures}, nil
 }
 
-func (p *PulumiServicePolicyGroupResource) Diff(req *pulumirpc.DiffRequest) (*pulumirpc.DiffResponse, error) {
-	olds, err := plugin.UnmarshalProperties(
-		req.GetOldInputs(),
-		plugin.MarshalOptions{KeepUnknowns: false, SkipNulls: true},
-	)
-	if err != nil {
-		return nil, err
-	}
+// isEmptyInput reports whether key is explicitly set to the empty string.
+func isEmptyInput(m property.Map, key string) bool {
+	v, ok := m.GetOk(key)
+	return ok && v.IsString() && v.AsString() == ""
+}
 
-	news, err := plugin.UnmarshalProperties(req.GetNews(), plugin.MarshalOptions{KeepUnknowns: true, SkipNulls: false})
-	if err != nil {
-		return nil, err
+// stripPolicyPackVersion removes the server-derived `version` field from each
+// policy pack in the given inputs. See Check for rationale.
+func stripPolicyPackVersion(news property.Map) property.Map {
+	packs, ok := news.GetOk(gcPolicyPacks)
+	if !ok || !packs.IsArray() {
+		return news
 	}
+	items := packs.AsArray().AsSlice()
+	for i, item := range items {
+		if item.IsMap() {
+			items[i] = property.WithGoValue(item, item.AsMap().Delete("version"))
+		}
+	}
+	return news.Set(gcPolicyPacks, property.WithGoValue(packs, property.NewArray(items)))
+}
 
-	oldPolicyGroup := ToPulumiServicePolicyGroupInput(olds)
-	newPolicyGroup := ToPulumiServicePolicyGroupInput(news)
-
-	changes := pulumirpc.DiffResponse_DIFF_NONE
+// Diff compares the old and new inputs. The state reflects Pulumi Cloud, which
+// adds the child accounts of every account it's given, so diffing against it
+// would report those as removals on every update.
+func (*PolicyGroup) Diff(
+	ctx context.Context, req infer.DiffRequest[PolicyGroupInput, PolicyGroupState],
+) (infer.DiffResponse, error) {
+	oldPolicyGroup := req.State.spec()
+	if olds, _, ok := rawInputs(ctx); ok {
+		oldPolicyGroup = policyGroupSpecFromMap(resource.ToResourcePropertyMap(olds))
+	}
+	newPolicyGroup := req.Inputs.spec()
 
 	// Compare using order-independent comparison for arrays
 	// This prevents spurious diffs when array elements are in different order
-	if !policyGroupInputsEqual(oldPolicyGroup, newPolicyGroup) {
-		changes = pulumirpc.DiffResponse_DIFF_SOME
+	if policyGroupInputsEqual(oldPolicyGroup, newPolicyGroup) {
+		return infer.DiffResponse{}, nil
 	}
 
-	// Name change requires replacement
-	replaces := []string{}
-	if oldPolicyGroup.Name != newPolicyGroup.Name {
-		replaces = append(replaces, gcName)
+	diff := map[string]p.PropertyDiff{}
+	update := func(property string, replace bool) {
+		kind := p.Update
+		if replace {
+			kind = p.UpdateReplace
+		}
+		diff[property] = p.PropertyDiff{Kind: kind, InputDiff: true}
 	}
 
-	// EntityType change requires replacement
+	// Name, organization and entity type identify the group; the API doesn't
+	// support updating the mode yet, so all four force a replacement.
+	if oldPolicyGroup.Name != newPolicyGroup.Name {
+		update(gcName, true)
+	}
+	if oldPolicyGroup.OrganizationName != newPolicyGroup.OrganizationName {
+		update(gcOrganizationName, true)
+	}
 	if oldPolicyGroup.EntityType != newPolicyGroup.EntityType {
-		replaces = append(replaces, gcEntityType)
+		update(gcEntityType, true)
 	}
-
-	// Mode change requires replacement (API doesn't support update yet)
 	if oldPolicyGroup.Mode != newPolicyGroup.Mode {
-		replaces = append(replaces, gcMode)
+		update(gcMode, true)
+	}
+	if !stackReferencesEqual(oldPolicyGroup.Stacks, newPolicyGroup.Stacks) {
+		update(gcStacks, false)
+	}
+	if !util.ElementsEqual(oldPolicyGroup.Accounts, newPolicyGroup.Accounts) {
+		update(gcAccounts, false)
+	}
+	if !policyPacksEqual(oldPolicyGroup.PolicyPacks, newPolicyGroup.PolicyPacks) {
+		update(gcPolicyPacks, false)
 	}
 
-	return &pulumirpc.DiffResponse{
-		Changes:             changes,
-		Replaces:            replaces,
-		Stables:             []string{},
+	return infer.DiffResponse{
+		HasChanges:          true,
+		DetailedDiff:        diff,
 		DeleteBeforeReplace: true,
 	}, nil
 }
 
-func (p *PulumiServicePolicyGroupResource) Read(req *pulumirpc.ReadRequest) (*pulumirpc.ReadResponse, error) {
-	ctx := context.Background()
-
-	orgName, policyGroupName, err := splitSingleSlashString(req.Id)
-	if err != nil {
-		return nil, err
+func (*PolicyGroup) Create(
+	ctx context.Context, req infer.CreateRequest[PolicyGroupInput],
+) (infer.CreateResponse[PolicyGroupState], error) {
+	policyGroupID := fmt.Sprintf("%s/%s", req.Inputs.OrganizationName, req.Inputs.Name)
+	desired := req.Inputs.state()
+	if req.DryRun {
+		return infer.CreateResponse[PolicyGroupState]{ID: policyGroupID, Output: desired}, nil
 	}
 
-	policyGroup, err := p.Client.GetPolicyGroup(ctx, orgName, policyGroupName)
+	client := config.GetClient(ctx)
+	spec := req.Inputs.spec()
+
+	// Create the policy group
+	err := client.CreatePolicyGroup(ctx, spec.OrganizationName, spec.Name, spec.EntityType, spec.Mode)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read policy group (%q): %w", req.Id, err)
-	}
-	if policyGroup == nil {
-		return &pulumirpc.ReadResponse{}, nil
+		return infer.CreateResponse[PolicyGroupState]{}, fmt.Errorf(
+			"error creating policy group '%s': %w", spec.Name, err)
 	}
 
-	// Get the previous state and inputs if available
-	previousStateAccounts, previousInputAccounts := parsePreviousAccounts(req.Properties, req.Inputs)
+	// From here on the group exists: every error is returned with its ID as a
+	// partial failure, otherwise the group leaks.
 
-	// Determine which accounts to use for inputs
-	// If the API accounts match the previous state, preserve the original inputs
-	// This prevents auto-added child accounts from polluting the inputs
-	inputAccounts := policyGroup.Accounts
-	if util.ElementsEqual(previousStateAccounts, policyGroup.Accounts) {
-		inputAccounts = previousInputAccounts
+	// Collect all add operations into a batch request
+	var batchReqs []pulumiapi.UpdatePolicyGroupRequest
+	for _, stack := range spec.Stacks {
+		batchReqs = append(batchReqs, pulumiapi.UpdatePolicyGroupRequest{AddStack: &stack})
 	}
-
-	// Properties (state) always reflect the full API response
-	propsMap := map[string]interface{}{
-		gcName:             policyGroup.Name,
-		gcOrganizationName: orgName,
-		gcEntityType:       policyGroup.EntityType,
-		gcMode:             policyGroup.Mode,
-		gcStacks:           convertStacksToInterfaceArray(policyGroup.Stacks),
-		gcAccounts:         policyGroup.Accounts,
-		gcPolicyPacks:      convertPolicyPacksToInterfaceArray(policyGroup.AppliedPolicyPacks),
+	for _, pp := range spec.PolicyPacks {
+		batchReqs = append(batchReqs, pulumiapi.UpdatePolicyGroupRequest{AddPolicyPack: &pp})
+	}
+	for _, account := range spec.Accounts {
+		batchReqs = append(batchReqs, pulumiapi.UpdatePolicyGroupRequest{
+			AddInsightsAccount: &pulumiapi.InsightsAccountReference{Name: account},
+		})
 	}
 
-	props, err := plugin.MarshalProperties(resource.NewPropertyMapFromMap(propsMap), plugin.MarshalOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal properties: %w", err)
+	// Create is adds-only, so the cloud's reorder/upsert-by-name bug doesn't
+	// apply here — batch the whole thing in a single request.
+	if len(batchReqs) > 0 {
+		err = client.BatchUpdatePolicyGroup(ctx, spec.OrganizationName, spec.Name, batchReqs)
+		if err != nil {
+			return infer.CreateResponse[PolicyGroupState]{ID: policyGroupID, Output: desired},
+				infer.ResourceInitFailedError{Reasons: []string{
+					fmt.Sprintf("failed to add items to policy group: %s", err.Error()),
+				}}
+		}
 	}
 
-	propsMap[gcAccounts] = inputAccounts
-	inputs, err := plugin.MarshalProperties(resource.NewPropertyMapFromMap(propsMap), plugin.MarshalOptions{})
+	// Read back the policy group to get the full state
+	policyGroup, err := client.GetPolicyGroup(ctx, spec.OrganizationName, spec.Name)
+	if err == nil && policyGroup == nil {
+		err = fmt.Errorf("policy group %q not found after create", policyGroupID)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal inputs: %w", err)
+		return infer.CreateResponse[PolicyGroupState]{ID: policyGroupID, Output: desired},
+			infer.ResourceInitFailedError{Reasons: []string{err.Error()}}
 	}
 
-	return &pulumirpc.ReadResponse{
-		Id:         req.Id,
-		Properties: props,
-		Inputs:     inputs,
+	return infer.CreateResponse[PolicyGroupState]{
+		ID:     policyGroupID,
+		Output: newPolicyGroupState(spec.OrganizationName, policyGroup),
 	}, nil
 }
 
-func (p *PulumiServicePolicyGroupResource) Update(req *pulumirpc.UpdateRequest) (*pulumirpc.UpdateResponse, error) {
-	ctx := context.Background()
-	inputsOld, err := plugin.UnmarshalProperties(
-		req.GetOlds(),
-		plugin.MarshalOptions{KeepUnknowns: true, SkipNulls: true},
-	)
-	if err != nil {
-		return nil, err
+func (*PolicyGroup) Update(
+	ctx context.Context, req infer.UpdateRequest[PolicyGroupInput, PolicyGroupState],
+) (infer.UpdateResponse[PolicyGroupState], error) {
+	policyGroupOld := req.State.spec()
+	policyGroupNew := req.Inputs.spec()
+	if req.DryRun {
+		return infer.UpdateResponse[PolicyGroupState]{Output: req.Inputs.state()}, nil
 	}
-	inputsNew, err := plugin.UnmarshalProperties(
-		req.GetNews(),
-		plugin.MarshalOptions{KeepUnknowns: true, SkipNulls: true},
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	policyGroupOld := ToPulumiServicePolicyGroupInput(inputsOld)
-	policyGroupNew := ToPulumiServicePolicyGroupInput(inputsNew)
 
 	// Collect all update operations into a batch request
 	var batchReqs []pulumiapi.UpdatePolicyGroupRequest
@@ -427,20 +528,14 @@ func (p *PulumiServicePolicyGroupResource) Update(req *pulumirpc.UpdateRequest)
 		// Remove stacks that are no longer in the new list
 		for _, oldStack := range policyGroupOld.Stacks {
 			if !containsStackReference(policyGroupNew.Stacks, oldStack) {
-				stack := oldStack
-				batchReqs = append(batchReqs, pulumiapi.UpdatePolicyGroupRequest{
-					RemoveStack: &stack,
-				})
+				batchReqs = append(batchReqs, pulumiapi.UpdatePolicyGroupRequest{RemoveStack: &oldStack})
 			}
 		}
 
 		// Add stacks that are new in the new list
 		for _, newStack := range policyGroupNew.Stacks {
 			if !containsStackReference(policyGroupOld.Stacks, newStack) {
-				stack := newStack
-				batchReqs = append(batchReqs, pulumiapi.UpdatePolicyGroupRequest{
-					AddStack: &stack,
-				})
+				batchReqs = append(batchReqs, pulumiapi.UpdatePolicyGroupRequest{AddStack: &newStack})
 			}
 		}
 	}
@@ -450,20 +545,14 @@ func (p *PulumiServicePolicyGroupResource) Update(req *pulumirpc.UpdateRequest)
 		// Remove policy packs that are no longer in the new list
 		for _, oldPP := range policyGroupOld.PolicyPacks {
 			if !containsPolicyPack(policyGroupNew.PolicyPacks, oldPP) {
-				pp := oldPP
-				batchReqs = append(batchReqs, pulumiapi.UpdatePolicyGroupRequest{
-					RemovePolicyPack: &pp,
-				})
+				batchReqs = append(batchReqs, pulumiapi.UpdatePolicyGroupRequest{RemovePolicyPack: &oldPP})
 			}
 		}
 
 		// Add policy packs that are new in the new list
 		for _, newPP := range policyGroupNew.PolicyPacks {
 			if !containsPolicyPack(policyGroupOld.PolicyPacks, newPP) {
-				pp := newPP
-				batchReqs = append(batchReqs, pulumiapi.UpdatePolicyGroupRequest{
-					AddPolicyPack: &pp,
-				})
+				batchReqs = append(batchReqs, pulumiapi.UpdatePolicyGroupRequest{AddPolicyPack: &newPP})
 			}
 		}
 	}
@@ -495,9 +584,9 @@ func (p *PulumiServicePolicyGroupResource) Update(req *pulumirpc.UpdateRequest)
 		}
 	}
 
-	policyGroupID := fmt.Sprintf("%s/%s", policyGroupNew.OrganizationName, policyGroupNew.Name)
+	client := config.GetClient(ctx)
 	for i := range batchReqs {
-		err = p.Client.BatchUpdatePolicyGroup(
+		err := client.BatchUpdatePolicyGroup(
 			ctx, policyGroupNew.OrganizationName, policyGroupNew.Name, batchReqs[i:i+1],
 		)
 		if err != nil {
@@ -505,154 +594,82 @@ func (p *PulumiServicePolicyGroupResource) Update(req *pulumirpc.UpdateRequest)
 			// checkpoint. Detach ctx so a cancellation that caused the failure
 			// doesn't also kill the read (which would force the fallback path).
 			readCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
-			actual := policyGroupNew
-			if pg, getErr := p.Client.GetPolicyGroup(
+			actual := req.State
+			if pg, getErr := client.GetPolicyGroup(
 				readCtx, policyGroupNew.OrganizationName, policyGroupNew.Name,
 			); getErr == nil && pg != nil {
-				actual = PulumiServicePolicyGroupInput{
-					Name:             pg.Name,
-					OrganizationName: policyGroupNew.OrganizationName,
-					Stacks:           pg.Stacks,
-					Accounts:         pg.Accounts,
-					PolicyPacks:      pg.AppliedPolicyPacks,
-					EntityType:       pg.EntityType,
-					Mode:             pg.Mode,
-				}
+				actual = newPolicyGroupState(policyGroupNew.OrganizationName, pg)
 			}
 			cancel()
-			return nil, partialErrorPolicyGroup(
-				policyGroupID,
-				fmt.Errorf("failed to update policy group: %w", err),
-				actual,
-				policyGroupNew,
-			)
+			return infer.UpdateResponse[PolicyGroupState]{Output: actual},
+				infer.ResourceInitFailedError{Reasons: []string{
+					fmt.Sprintf("failed to update policy group: %s", err.Error()),
+				}}
 		}
 	}
 
 	// Read back the policy group to get the full state
 	// This is important because adding accounts may auto-add child accounts
-	policyGroup, err := p.Client.GetPolicyGroup(ctx, policyGroupNew.OrganizationName, policyGroupNew.Name)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read policy group after update: %w", err)
+	policyGroup, err := client.GetPolicyGroup(ctx, policyGroupNew.OrganizationName, policyGroupNew.Name)
+	if err == nil && policyGroup == nil {
+		err = fmt.Errorf("policy group not found")
 	}
-
-	outputs := PulumiServicePolicyGroupInput{
-		Name:             policyGroup.Name,
-		OrganizationName: policyGroupNew.OrganizationName,
-		Stacks:           policyGroup.Stacks,
-		Accounts:         policyGroup.Accounts,
-		PolicyPacks:      policyGroup.AppliedPolicyPacks,
-		EntityType:       policyGroup.EntityType,
-		Mode:             policyGroup.Mode,
-	}
-
-	outputProperties, err := outputs.ToRPC()
 	if err != nil {
-		return nil, err
+		return infer.UpdateResponse[PolicyGroupState]{}, fmt.Errorf(
+			"failed to read policy group after update: %w", err)
 	}
 
-	return &pulumirpc.UpdateResponse{
-		Properties: outputProperties,
+	return infer.UpdateResponse[PolicyGroupState]{
+		Output: newPolicyGroupState(policyGroupNew.OrganizationName, policyGroup),
 	}, nil
 }
 
-func (p *PulumiServicePolicyGroupResource) Create(req *pulumirpc.CreateRequest) (*pulumirpc.CreateResponse, error) {
-	ctx := context.Background()
-	inputs, err := plugin.UnmarshalProperties(
-		req.GetProperties(),
-		plugin.MarshalOptions{KeepUnknowns: true, SkipNulls: true},
-	)
+func (*PolicyGroup) Read(
+	ctx context.Context, req infer.ReadRequest[PolicyGroupInput, PolicyGroupState],
+) (infer.ReadResponse[PolicyGroupInput, PolicyGroupState], error) {
+	orgName, policyGroupName, err := splitSingleSlashString(req.ID)
 	if err != nil {
-		return nil, err
+		return infer.ReadResponse[PolicyGroupInput, PolicyGroupState]{}, err
 	}
 
-	inputsPolicyGroup := ToPulumiServicePolicyGroupInput(inputs)
-
-	// Create the policy group
-	err = p.Client.CreatePolicyGroup(
-		ctx,
-		inputsPolicyGroup.OrganizationName,
-		inputsPolicyGroup.Name,
-		inputsPolicyGroup.EntityType,
-		inputsPolicyGroup.Mode,
-	)
+	policyGroup, err := config.GetClient(ctx).GetPolicyGroup(ctx, orgName, policyGroupName)
 	if err != nil {
-		return nil, fmt.Errorf("error creating policy group '%s': %w", inputsPolicyGroup.Name, err)
+		return infer.ReadResponse[PolicyGroupInput, PolicyGroupState]{}, fmt.Errorf(
+			"failed to read policy group (%q): %w", req.ID, err)
 	}
-
-	policyGroupID := fmt.Sprintf("%s/%s", inputsPolicyGroup.OrganizationName, inputsPolicyGroup.Name)
-
-	// Collect all add operations into a batch request
-	var batchReqs []pulumiapi.UpdatePolicyGroupRequest
-
-	// Add stacks to the policy group
-	for _, stack := range inputsPolicyGroup.Stacks {
-		s := stack
-		batchReqs = append(batchReqs, pulumiapi.UpdatePolicyGroupRequest{
-			AddStack: &s,
-		})
+	if policyGroup == nil {
+		return infer.ReadResponse[PolicyGroupInput, PolicyGroupState]{}, nil
 	}
 
-	// Add policy packs to the policy group
-	for _, pp := range inputsPolicyGroup.PolicyPacks {
-		p := pp
-		batchReqs = append(batchReqs, pulumiapi.UpdatePolicyGroupRequest{
-			AddPolicyPack: &p,
-		})
-	}
+	// State always reflects the full API response
+	state := newPolicyGroupState(orgName, policyGroup)
+	inputs := policyGroupInputFromState(state)
 
-	// Add accounts to the policy group
-	for _, account := range inputsPolicyGroup.Accounts {
-		a := account
-		batchReqs = append(batchReqs, pulumiapi.UpdatePolicyGroupRequest{
-			AddInsightsAccount: &pulumiapi.InsightsAccountReference{Name: a},
-		})
+	// If the API accounts match the previous state, preserve the original inputs
+	// This prevents auto-added child accounts from polluting the inputs
+	if util.ElementsEqual(req.State.Accounts, policyGroup.Accounts) {
+		inputs.Accounts = req.Inputs.Accounts
 	}
 
-	// Create is adds-only, so the cloud's reorder/upsert-by-name bug doesn't
-	// apply here — batch the whole thing in a single request.
-	if len(batchReqs) > 0 {
-		err = p.Client.BatchUpdatePolicyGroup(
-			ctx,
-			inputsPolicyGroup.OrganizationName,
-			inputsPolicyGroup.Name,
-			batchReqs,
-		)
-		if err != nil {
-			return nil, partialErrorPolicyGroup(
-				policyGroupID,
-				fmt.Errorf("failed to add items to policy group: %w", err),
-				inputsPolicyGroup,
-				inputsPolicyGroup,
-			)
-		}
-	}
+	return infer.ReadResponse[PolicyGroupInput, PolicyGroupState]{
+		ID:     req.ID,
+		Inputs: inputs,
+		State:  state,
+	}, nil
+}
 
-	// Read back the policy group to get the full state
-	policyGroup, err := p.Client.GetPolicyGroup(ctx, inputsPolicyGroup.OrganizationName, inputsPolicyGroup.Name)
+func (*PolicyGroup) Delete(ctx context.Context, req infer.DeleteRequest[PolicyGroupState]) (infer.DeleteResponse, error) {
+	orgName, policyGroupName, err := splitSingleSlashString(req.ID)
 	if err != nil {
-		return nil, partialErrorPolicyGroup(policyGroupID, err, inputsPolicyGroup, inputsPolicyGroup)
-	}
-
-	outputs := PulumiServicePolicyGroupInput{
-		Name:             policyGroup.Name,
-		OrganizationName: inputsPolicyGroup.OrganizationName,
-		Stacks:           policyGroup.Stacks,
-		Accounts:         policyGroup.Accounts,
-		PolicyPacks:      policyGroup.AppliedPolicyPacks,
-		EntityType:       policyGroup.EntityType,
-		Mode:             policyGroup.Mode,
+		return infer.DeleteResponse{}, err
 	}
 
-	outputProperties, err := outputs.ToRPC()
+	err = config.GetClient(ctx).DeletePolicyGroup(ctx, orgName, policyGroupName)
 	if err != nil {
-		return nil, partialErrorPolicyGroup(policyGroupID, err, outputs, inputsPolicyGroup)
+		return infer.DeleteResponse{}, fmt.Errorf("failed to delete policy group %q: %w", req.ID, err)
 	}
 
-	return &pulumirpc.CreateResponse{
-		Id:         policyGroupID,
-		Properties: outputProperties,
-	}, nil
+	return infer.DeleteResponse{}, nil
 }
 
 // Helper functions
@@ -731,53 +748,6 @@ func containsPolicyPack(packs []pulumiapi.PolicyPackMetadata, target pulumiapi.P
 	return false
 }
 
-// stripPolicyPackVersion removes the server-derived `version` field from each
-// policy pack in the given inputs map. See Check for rationale.
-func stripPolicyPackVersion(news resource.PropertyMap) {
-	packs, ok := news[gcPolicyPacks]
-	if !ok || !packs.IsArray() {
-		return
-	}
-	items := packs.ArrayValue()
-	for i, item := range items {
-		if !item.IsObject() {
-			continue
-		}
-		obj := item.ObjectValue()
-		if _, has := obj["version"]; has {
-			delete(obj, "version")
-			items[i] = resource.NewObjectProperty(obj)
-		}
-	}
-	news[gcPolicyPacks] = resource.NewArrayProperty(items)
-}
-
-// parsePreviousAccounts extracts the accounts from previous state and inputs.
-// This is used during Read to determine if we should preserve original inputs.
-func parsePreviousAccounts(properties, inputs *structpb.Struct) (stateAccounts, inputAccounts []string) {
-	if properties != nil {
-		oldProps, err := plugin.UnmarshalProperties(
-			properties,
-			plugin.MarshalOptions{KeepUnknowns: false, SkipNulls: true},
-		)
-		if err == nil {
-			oldState := ToPulumiServicePolicyGroupInput(oldProps)
-			stateAccounts = oldState.Accounts
-		}
-	}
-	if inputs != nil {
-		oldInputs, err := plugin.UnmarshalProperties(
-			inputs,
-			plugin.MarshalOptions{KeepUnknowns: false, SkipNulls: true},
-		)
-		if err == nil {
-			oldInput := ToPulumiServicePolicyGroupInput(oldInputs)
-			inputAccounts = oldInput.Accounts
-		}
-	}
-	return stateAccounts, inputAccounts
-}
-
 // hasParentAccount checks if the given account has a parent account in the list.
 // Account names use "/" as a separator, so "parent/child" has parent "parent".
 func hasParentAccount(account string, accounts []string) bool {
@@ -790,10 +760,10 @@ func hasParentAccount(account string, accounts []string) bool {
 	return false
 }
 
-// policyGroupInputsEqual compares two PulumiServicePolicyGroupInput structs using order-independent
+// policyGroupInputsEqual compares two policy groups using order-independent
 // comparison for array fields (stacks, accounts, policyPacks). This prevents spurious diffs when
 // array elements are in different order but contain the same values.
-func policyGroupInputsEqual(a, b PulumiServicePolicyGroupInput) bool {
+func policyGroupInputsEqual(a, b policyGroupSpec) bool {
 	// Compare scalar fields
 	if a.Name != b.Name ||
 		a.OrganizationName != b.OrganizationName ||
@@ -815,30 +785,3 @@ func policyGroupInputsEqual(a, b PulumiServicePolicyGroupInput) bool {
 
 	return true
 }
-
-// partialErrorPolicyGroup creates an error for resources that did not complete an operation in progress.
-// The last known state of the object is included in the error so that it can be checkpointed.
-func partialErrorPolicyGroup(
-	id string,
-	err error,
-	state PulumiServicePolicyGroupInput,
-	inputs PulumiServicePolicyGroupInput,
-) error {
-	stateRPC, stateSerErr := state.ToRPC()
-	inputRPC, inputSerErr := inputs.ToRPC()
-
-	// combine errors if we can't serialize state or inputs for some reason
-	if stateSerErr != nil {
-		err = fmt.Errorf("err serializing state: %v, (src error: %v)", stateSerErr, err)
-	}
-	if inputSerErr != nil {
-		err = fmt.Errorf("err serializing inputs: %v (src error: %v)", inputSerErr, err)
-	}
-	detail := pulumirpc.ErrorResourceInitFailed{
-		Id:         id,
-		Properties: stateRPC,
-		Reasons:    []string{err.Error()},
-		Inputs:     inputRPC,
-	}
-	return rpcerror.WithDetails(rpcerror.New(codes.Unknown, err.Error()), &detail)
-}
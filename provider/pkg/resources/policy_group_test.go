@@ -22,11 +22,14 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"google.golang.org/protobuf/types/known/structpb"
 
-	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
-	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+	presource "github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
 
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
 )
 
@@ -42,6 +45,7 @@ const (
 
 // Mock client for PolicyGroup tests
 type PolicyGroupClientMock struct {
+	config.Client
 	getPolicyGroupFunc         func() (*pulumiapi.PolicyGroup, error)
 	createPolicyGroupFunc      func(ctx context.Context, orgName, policyGroupName, entityType, mode string) error
 	batchUpdatePolicyGroupFunc func(
@@ -49,13 +53,6 @@ type PolicyGroupClientMock struct {
 	) error
 }
 
-func (c *PolicyGroupClientMock) ListPolicyGroups(
-	_ context.Context,
-	_ string,
-) ([]pulumiapi.PolicyGroupSummary, error) {
-	return nil, nil
-}
-
 func (c *PolicyGroupClientMock) GetPolicyGroup(
 	_ context.Context,
 	_ string,
@@ -77,14 +74,6 @@ func (c *PolicyGroupClientMock) CreatePolicyGroup(
 	return nil
 }
 
-func (c *PolicyGroupClientMock) UpdatePolicyGroup(
-	_ context.Context,
-	_, _ string,
-	_ pulumiapi.UpdatePolicyGroupRequest,
-) error {
-	return nil
-}
-
 func (c *PolicyGroupClientMock) BatchUpdatePolicyGroup(
 	ctx context.Context,
 	orgName, policyGroupName string,
@@ -152,50 +141,67 @@ func (b *policyGroupInputBuilder) withAccounts(accounts ...string) *policyGroupI
 	return b
 }
 
-func (b *policyGroupInputBuilder) build() resource.PropertyMap {
-	pm := resource.PropertyMap{
-		gcName:             resource.NewStringProperty(b.name),
-		gcOrganizationName: resource.NewStringProperty(b.org),
-		gcEntityType:       resource.NewStringProperty(b.entityType),
-		gcMode:             resource.NewStringProperty(b.mode),
-	}
-
-	// Build stacks array
-	stackValues := make([]resource.PropertyValue, len(b.stacks))
+// build renders the inputs as the engine sends them.
+func (b *policyGroupInputBuilder) build() property.Map {
+	stackValues := make([]property.Value, len(b.stacks))
 	for i, s := range b.stacks {
-		stackValues[i] = resource.NewObjectProperty(resource.PropertyMap{
-			gcName:           resource.NewStringProperty(s.name),
-			"routingProject": resource.NewStringProperty(s.project),
-		})
+		stackValues[i] = property.New(property.NewMap(map[string]property.Value{
+			gcName:           property.New(s.name),
+			"routingProject": property.New(s.project),
+		}))
 	}
-	pm[gcStacks] = resource.NewArrayProperty(stackValues)
 
-	// Build policy packs array. `version` is intentionally omitted from inputs:
-	// it is server-derived and output-only.
-	ppValues := make([]resource.PropertyValue, len(b.policyPacks))
+	// `version` is intentionally omitted from inputs: it is server-derived
+	// and output-only.
+	ppValues := make([]property.Value, len(b.policyPacks))
 	for i, pp := range b.policyPacks {
-		ppValues[i] = resource.NewObjectProperty(resource.PropertyMap{
-			gcName:       resource.NewStringProperty(pp.name),
-			gcVersionTag: resource.NewStringProperty(pp.versionTag),
-		})
+		ppValues[i] = property.New(property.NewMap(map[string]property.Value{
+			gcName:       property.New(pp.name),
+			gcVersionTag: property.New(pp.versionTag),
+		}))
 	}
-	pm[gcPolicyPacks] = resource.NewArrayProperty(ppValues)
 
-	// Build accounts array
-	accountValues := make([]resource.PropertyValue, len(b.accounts))
+	accountValues := make([]property.Value, len(b.accounts))
 	for i, a := range b.accounts {
-		accountValues[i] = resource.NewStringProperty(a)
+		accountValues[i] = property.New(a)
 	}
-	pm[gcAccounts] = resource.NewArrayProperty(accountValues)
 
-	return pm
+	return property.NewMap(map[string]property.Value{
+		gcName:             property.New(b.name),
+		gcOrganizationName: property.New(b.org),
+		gcEntityType:       property.New(b.entityType),
+		gcMode:             property.New(b.mode),
+		gcStacks:           property.New(property.NewArray(stackValues)),
+		gcPolicyPacks:      property.New(property.NewArray(ppValues)),
+		gcAccounts:         property.New(property.NewArray(accountValues)),
+	})
 }
 
-func (b *policyGroupInputBuilder) buildStruct(t *testing.T) *structpb.Struct {
-	t.Helper()
-	s, err := structpb.NewStruct(b.build().Mappable())
-	require.NoError(t, err)
-	return s
+// input renders the inputs as the provider decodes them.
+func (b *policyGroupInputBuilder) input() PolicyGroupInput {
+	input := PolicyGroupInput{
+		Name:             b.name,
+		OrganizationName: b.org,
+		EntityType:       b.entityType,
+		Mode:             b.mode,
+		Accounts:         b.accounts,
+	}
+	for _, s := range b.stacks {
+		input.Stacks = append(input.Stacks, PolicyGroupStackReference{Name: s.name, RoutingProject: s.project})
+	}
+	for _, pp := range b.policyPacks {
+		input.PolicyPacks = append(input.PolicyPacks, PolicyGroupPolicyPackReferenceInput{
+			Name:       pp.name,
+			VersionTag: &pp.versionTag,
+		})
+	}
+	return input
+}
+
+// state is the state recorded after the inputs were applied as-is.
+func (b *policyGroupInputBuilder) state() PolicyGroupState {
+	input := b.input()
+	return input.state()
 }
 
 // mockPolicyGroupBuilder provides a fluent API for building mock PolicyGroup responses
@@ -239,91 +245,97 @@ func (b *mockPolicyGroupBuilder) build() *pulumiapi.PolicyGroup {
 	return b.pg
 }
 
-const testPolicyGroupURN = "urn:pulumi:dev::test::pulumiservice:index:PolicyGroup::testPolicyGroup"
 const testPolicyGroupID = "test-org/test-policy-group"
 
-// TestPolicyGroup_Check_Defaults tests that Check applies default values when entityType and mode are not provided
-func TestPolicyGroup_Check_Defaults(t *testing.T) {
-	provider := PulumiServicePolicyGroupResource{
-		Client: &PolicyGroupClientMock{},
-	}
+// checkPolicyGroup runs Check through an infer provider, so defaults are
+// applied the way the engine sees them.
+func checkPolicyGroup(t *testing.T, inputs property.Map) p.CheckResponse {
+	t.Helper()
 
-	// Create input without entityType and mode
-	inputs := resource.PropertyMap{
-		gcName:             resource.NewStringProperty(gcTestPolicyGroup),
-		gcOrganizationName: resource.NewStringProperty(gcTestOrg),
-		gcStacks:           resource.NewArrayProperty([]resource.PropertyValue{}),
-		gcPolicyPacks:      resource.NewArrayProperty([]resource.PropertyValue{}),
-	}
+	prov, err := infer.NewProviderBuilder().
+		WithResources(infer.Resource(&PolicyGroup{})).
+		WithModuleMap(map[tokens.ModuleName]tokens.ModuleName{"resources": "index"}).
+		Build()
+	require.NoError(t, err)
 
-	inputsStruct, err := structpb.NewStruct(inputs.Mappable())
+	resp, err := prov.Check(t.Context(), p.CheckRequest{
+		Urn:    presource.NewURN("dev", "test", "", "pulumiservice:index:PolicyGroup", "testPolicyGroup"),
+		Inputs: inputs,
+	})
 	require.NoError(t, err)
+	return resp
+}
+
+// requireCheckFailure asserts that Check reported a failure for property.
+func requireCheckFailure(t *testing.T, resp p.CheckResponse, property, reason string) {
+	t.Helper()
 
-	req := &pulumirpc.CheckRequest{
-		Urn:  testPolicyGroupURN,
-		News: inputsStruct,
+	require.NotEmpty(t, resp.Failures, "Check should fail with validation error")
+	for _, failure := range resp.Failures {
+		if failure.Property == property {
+			assert.Contains(t, failure.Reason, reason)
+			return
+		}
 	}
+	assert.Failf(t, "missing check failure", "Should have failure for %s property", property)
+}
 
-	resp, err := provider.Check(req)
+func diffPolicyGroup(t *testing.T, olds, news property.Map, inputs PolicyGroupInput) infer.DiffResponse {
+	t.Helper()
+
+	ctx := WithRawInputs(t.Context(), olds, news)
+	resp, err := (&PolicyGroup{}).Diff(ctx, infer.DiffRequest[PolicyGroupInput, PolicyGroupState]{
+		ID:     testPolicyGroupID,
+		Inputs: inputs,
+	})
 	require.NoError(t, err)
-	require.NotNil(t, resp)
-	assert.Empty(t, resp.Failures, "Check should succeed with no failures")
+	return resp
+}
 
-	// Verify defaults are applied
-	outputMap := resource.PropertyMap{}
-	for k, v := range resp.Inputs.GetFields() {
-		outputMap[resource.PropertyKey(k)] = resource.NewPropertyValue(v.AsInterface())
-	}
+// TestPolicyGroup_Check_Defaults tests that Check applies default values when entityType and mode are not provided
+func TestPolicyGroup_Check_Defaults(t *testing.T) {
+	// Create input without entityType and mode
+	inputs := property.NewMap(map[string]property.Value{
+		gcName:             property.New(gcTestPolicyGroup),
+		gcOrganizationName: property.New(gcTestOrg),
+		gcStacks:           property.New(property.NewArray(nil)),
+		gcPolicyPacks:      property.New(property.NewArray(nil)),
+	})
 
-	assert.True(t, outputMap[gcEntityType].HasValue(), "entityType should have a value")
-	assert.Equal(t, gcStacks, outputMap[gcEntityType].StringValue(), "entityType should default to 'stacks'")
+	resp := checkPolicyGroup(t, inputs)
+	assert.Empty(t, resp.Failures, "Check should succeed with no failures")
 
-	assert.True(t, outputMap[gcMode].HasValue(), "mode should have a value")
-	assert.Equal(t, gcAudit, outputMap[gcMode].StringValue(), "mode should default to 'audit'")
+	// Verify defaults are applied
+	assert.Equal(t, property.New(gcStacks), resp.Inputs.Get(gcEntityType), "entityType should default to 'stacks'")
+	assert.Equal(t, property.New(gcAudit), resp.Inputs.Get(gcMode), "mode should default to 'audit'")
 }
 
 // TestPolicyGroup_Check_StripsPolicyPackVersion verifies that the server-derived
 // `version` field is stripped from policy pack inputs during Check, so legacy
 // programs (or stacks with old state) that still supply it upgrade cleanly.
 func TestPolicyGroup_Check_StripsPolicyPackVersion(t *testing.T) {
-	provider := PulumiServicePolicyGroupResource{
-		Client: &PolicyGroupClientMock{},
-	}
-
-	inputs := resource.PropertyMap{
-		gcName:             resource.NewStringProperty(gcTestPolicyGroup),
-		gcOrganizationName: resource.NewStringProperty(gcTestOrg),
-		gcPolicyPacks: resource.NewArrayProperty([]resource.PropertyValue{
-			resource.NewObjectProperty(resource.PropertyMap{
-				gcName:       resource.NewStringProperty("pp1"),
-				"version":    resource.NewNumberProperty(5),
-				gcVersionTag: resource.NewStringProperty(gcVersion100),
+	inputs := property.NewMap(map[string]property.Value{
+		gcName:             property.New(gcTestPolicyGroup),
+		gcOrganizationName: property.New(gcTestOrg),
+		gcPolicyPacks: property.New([]property.Value{
+			property.New(map[string]property.Value{
+				gcName:       property.New("pp1"),
+				"version":    property.New(5.0),
+				gcVersionTag: property.New(gcVersion100),
 			}),
 		}),
-	}
-
-	inputsStruct, err := structpb.NewStruct(inputs.Mappable())
-	require.NoError(t, err)
-
-	resp, err := provider.Check(&pulumirpc.CheckRequest{
-		Urn:  testPolicyGroupURN,
-		News: inputsStruct,
 	})
-	require.NoError(t, err)
-	require.Empty(t, resp.Failures)
 
-	outputMap := resource.PropertyMap{}
-	for k, v := range resp.Inputs.GetFields() {
-		outputMap[resource.PropertyKey(k)] = resource.NewPropertyValue(v.AsInterface())
-	}
+	resp := checkPolicyGroup(t, inputs)
+	require.Empty(t, resp.Failures)
 
-	packs := outputMap[gcPolicyPacks].ArrayValue()
-	require.Len(t, packs, 1)
-	pp := packs[0].ObjectValue()
-	_, hasVersion := pp["version"]
+	packs := resp.Inputs.Get(gcPolicyPacks).AsArray()
+	require.Equal(t, 1, packs.Len())
+	pp := packs.Get(0).AsMap()
+	_, hasVersion := pp.GetOk("version")
 	assert.False(t, hasVersion, "version should be stripped from policy pack inputs")
-	assert.Equal(t, "pp1", pp[gcName].StringValue())
-	assert.Equal(t, gcVersion100, pp[gcVersionTag].StringValue())
+	assert.Equal(t, "pp1", pp.Get(gcName).AsString())
+	assert.Equal(t, gcVersion100, pp.Get(gcVersionTag).AsString())
 }
 
 // TestPolicyGroup_Check_ExplicitValues tests that Check preserves explicit entityType and mode values
@@ -357,45 +369,16 @@ func TestPolicyGroup_Check_ExplicitValues(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			provider := PulumiServicePolicyGroupResource{
-				Client: &PolicyGroupClientMock{},
-			}
+			b := newPolicyGroupInput().withEntityType(tc.entityType)
+			b.mode = tc.mode
 
-			inputs := resource.PropertyMap{
-				gcName:             resource.NewStringProperty(gcTestPolicyGroup),
-				gcOrganizationName: resource.NewStringProperty(gcTestOrg),
-				gcEntityType:       resource.NewStringProperty(tc.entityType),
-				gcMode:             resource.NewStringProperty(tc.mode),
-				gcStacks:           resource.NewArrayProperty([]resource.PropertyValue{}),
-				gcPolicyPacks:      resource.NewArrayProperty([]resource.PropertyValue{}),
-			}
-
-			inputsStruct, err := structpb.NewStruct(inputs.Mappable())
-			require.NoError(t, err)
-
-			req := &pulumirpc.CheckRequest{
-				Urn:  testPolicyGroupURN,
-				News: inputsStruct,
-			}
-
-			resp, err := provider.Check(req)
-			require.NoError(t, err)
-			require.NotNil(t, resp)
+			resp := checkPolicyGroup(t, b.build())
 			assert.Empty(t, resp.Failures, "Check should succeed with no failures")
 
 			// Verify explicit values are preserved
-			outputMap := resource.PropertyMap{}
-			for k, v := range resp.Inputs.GetFields() {
-				outputMap[resource.PropertyKey(k)] = resource.NewPropertyValue(v.AsInterface())
-			}
-
-			assert.Equal(
-				t,
-				tc.entityType,
-				outputMap[gcEntityType].StringValue(),
-				"entityType should preserve explicit value",
-			)
-			assert.Equal(t, tc.mode, outputMap[gcMode].StringValue(), "mode should preserve explicit value")
+			assert.Equal(t, tc.entityType, resp.Inputs.Get(gcEntityType).AsString(),
+				"entityType should preserve explicit value")
+			assert.Equal(t, tc.mode, resp.Inputs.Get(gcMode).AsString(), "mode should preserve explicit value")
 		})
 	}
 }
@@ -414,42 +397,8 @@ func TestPolicyGroup_Check_InvalidEntityType(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			provider := PulumiServicePolicyGroupResource{
-				Client: &PolicyGroupClientMock{},
-			}
-
-			inputs := resource.PropertyMap{
-				gcName:             resource.NewStringProperty(gcTestPolicyGroup),
-				gcOrganizationName: resource.NewStringProperty(gcTestOrg),
-				gcEntityType:       resource.NewStringProperty(tc.entityType),
-				gcMode:             resource.NewStringProperty(gcAudit),
-				gcStacks:           resource.NewArrayProperty([]resource.PropertyValue{}),
-				gcPolicyPacks:      resource.NewArrayProperty([]resource.PropertyValue{}),
-			}
-
-			inputsStruct, err := structpb.NewStruct(inputs.Mappable())
-			require.NoError(t, err)
-
-			req := &pulumirpc.CheckRequest{
-				Urn:  testPolicyGroupURN,
-				News: inputsStruct,
-			}
-
-			resp, err := provider.Check(req)
-			require.NoError(t, err)
-			require.NotNil(t, resp)
-			require.NotEmpty(t, resp.Failures, "Check should fail with validation error")
-
-			// Verify failure message mentions entityType
-			found := false
-			for _, failure := range resp.Failures {
-				if failure.Property == gcEntityType {
-					found = true
-					assert.Contains(t, failure.Reason, "entityType must be either 'stacks' or 'accounts'")
-					break
-				}
-			}
-			assert.True(t, found, "Should have failure for entityType property")
+			resp := checkPolicyGroup(t, newPolicyGroupInput().withEntityType(tc.entityType).build())
+			requireCheckFailure(t, resp, gcEntityType, "entityType must be either 'stacks' or 'accounts'")
 		})
 	}
 }
@@ -469,274 +418,138 @@ func TestPolicyGroup_Check_InvalidMode(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			provider := PulumiServicePolicyGroupResource{
-				Client: &PolicyGroupClientMock{},
-			}
-
-			inputs := resource.PropertyMap{
-				gcName:             resource.NewStringProperty(gcTestPolicyGroup),
-				gcOrganizationName: resource.NewStringProperty(gcTestOrg),
-				gcEntityType:       resource.NewStringProperty(gcStacks),
-				gcMode:             resource.NewStringProperty(tc.mode),
-				gcStacks:           resource.NewArrayProperty([]resource.PropertyValue{}),
-				gcPolicyPacks:      resource.NewArrayProperty([]resource.PropertyValue{}),
-			}
+			b := newPolicyGroupInput()
+			b.mode = tc.mode
 
-			inputsStruct, err := structpb.NewStruct(inputs.Mappable())
-			require.NoError(t, err)
-
-			req := &pulumirpc.CheckRequest{
-				Urn:  testPolicyGroupURN,
-				News: inputsStruct,
-			}
-
-			resp, err := provider.Check(req)
-			require.NoError(t, err)
-			require.NotNil(t, resp)
-			require.NotEmpty(t, resp.Failures, "Check should fail with validation error")
-
-			// Verify failure message mentions mode
-			found := false
-			for _, failure := range resp.Failures {
-				if failure.Property == gcMode {
-					found = true
-					assert.Contains(t, failure.Reason, "mode must be either 'audit' or 'preventative'")
-					break
-				}
-			}
-			assert.True(t, found, "Should have failure for mode property")
+			resp := checkPolicyGroup(t, b.build())
+			requireCheckFailure(t, resp, gcMode, "mode must be either 'audit' or 'preventative'")
 		})
 	}
 }
 
 // TestPolicyGroup_Diff_EntityTypeChange tests that changing entityType triggers replacement
 func TestPolicyGroup_Diff_EntityTypeChange(t *testing.T) {
-	provider := PulumiServicePolicyGroupResource{
-		Client: &PolicyGroupClientMock{},
-	}
-
-	// Old state: entityType = "stacks"
-	oldInputs := resource.PropertyMap{
-		gcName:             resource.NewStringProperty(gcTestPolicyGroup),
-		gcOrganizationName: resource.NewStringProperty(gcTestOrg),
-		gcEntityType:       resource.NewStringProperty(gcStacks),
-		gcMode:             resource.NewStringProperty(gcAudit),
-		gcStacks:           resource.NewArrayProperty([]resource.PropertyValue{}),
-		gcPolicyPacks:      resource.NewArrayProperty([]resource.PropertyValue{}),
-	}
-
-	oldState, err := structpb.NewStruct(oldInputs.Mappable())
-	require.NoError(t, err)
-
-	// New state: entityType = "accounts"
-	newInputs := resource.PropertyMap{
-		gcName:             resource.NewStringProperty(gcTestPolicyGroup),
-		gcOrganizationName: resource.NewStringProperty(gcTestOrg),
-		gcEntityType:       resource.NewStringProperty(gcAccounts),
-		gcMode:             resource.NewStringProperty(gcAudit),
-		gcStacks:           resource.NewArrayProperty([]resource.PropertyValue{}),
-		gcPolicyPacks:      resource.NewArrayProperty([]resource.PropertyValue{}),
-	}
-
-	newState, err := structpb.NewStruct(newInputs.Mappable())
-	require.NoError(t, err)
-
-	req := &pulumirpc.DiffRequest{
-		Id:   testPolicyGroupID,
-		Urn:  testPolicyGroupURN,
-		Olds: oldState,
-		News: newState,
-	}
+	olds := newPolicyGroupInput()
+	news := newPolicyGroupInput().withEntityType(gcAccounts)
 
-	resp, err := provider.Diff(req)
-	require.NoError(t, err)
-	require.NotNil(t, resp)
+	resp := diffPolicyGroup(t, olds.build(), news.build(), news.input())
 
 	// Verify that entityType change triggers replacement
-	assert.Contains(t, resp.Replaces, gcEntityType, "Changing entityType should trigger replacement")
+	require.Contains(t, resp.DetailedDiff, gcEntityType)
+	assert.Equal(t, p.UpdateReplace, resp.DetailedDiff[gcEntityType].Kind,
+		"Changing entityType should trigger replacement")
 }
 
 // TestPolicyGroup_Diff_ModeChange tests that changing mode triggers replacement
 func TestPolicyGroup_Diff_ModeChange(t *testing.T) {
-	provider := PulumiServicePolicyGroupResource{
-		Client: &PolicyGroupClientMock{},
-	}
-
-	// Old state: mode = "audit"
-	oldInputs := resource.PropertyMap{
-		gcName:             resource.NewStringProperty(gcTestPolicyGroup),
-		gcOrganizationName: resource.NewStringProperty(gcTestOrg),
-		gcEntityType:       resource.NewStringProperty(gcStacks),
-		gcMode:             resource.NewStringProperty(gcAudit),
-		gcStacks:           resource.NewArrayProperty([]resource.PropertyValue{}),
-		gcPolicyPacks:      resource.NewArrayProperty([]resource.PropertyValue{}),
-	}
-
-	oldState, err := structpb.NewStruct(oldInputs.Mappable())
-	require.NoError(t, err)
-
-	// New state: mode = "preventative"
-	newInputs := resource.PropertyMap{
-		gcName:             resource.NewStringProperty(gcTestPolicyGroup),
-		gcOrganizationName: resource.NewStringProperty(gcTestOrg),
-		gcEntityType:       resource.NewStringProperty(gcStacks),
-		gcMode:             resource.NewStringProperty(gcPreventative),
-		gcStacks:           resource.NewArrayProperty([]resource.PropertyValue{}),
-		gcPolicyPacks:      resource.NewArrayProperty([]resource.PropertyValue{}),
-	}
-
-	newState, err := structpb.NewStruct(newInputs.Mappable())
-	require.NoError(t, err)
-
-	req := &pulumirpc.DiffRequest{
-		Id:   testPolicyGroupID,
-		Urn:  testPolicyGroupURN,
-		Olds: oldState,
-		News: newState,
-	}
+	olds := newPolicyGroupInput()
+	news := newPolicyGroupInput()
+	news.mode = gcPreventative
 
-	resp, err := provider.Diff(req)
-	require.NoError(t, err)
-	require.NotNil(t, resp)
+	resp := diffPolicyGroup(t, olds.build(), news.build(), news.input())
 
 	// Verify that mode change triggers replacement
-	assert.Contains(t, resp.Replaces, gcMode, "Changing mode should trigger replacement")
+	require.Contains(t, resp.DetailedDiff, gcMode)
+	assert.Equal(t, p.UpdateReplace, resp.DetailedDiff[gcMode].Kind, "Changing mode should trigger replacement")
 }
 
 // TestPolicyGroup_Diff_ArrayOrderIndependent tests that arrays with same elements in different order don't cause diffs
 func TestPolicyGroup_Diff_ArrayOrderIndependent(t *testing.T) {
-	provider := PulumiServicePolicyGroupResource{
-		Client: &PolicyGroupClientMock{},
-	}
-
-	// Old state (from refresh): accounts in one order
-	oldInputs := resource.PropertyMap{
-		gcAccounts: resource.NewArrayProperty([]resource.PropertyValue{
-			resource.NewStringProperty("account-b"),
-			resource.NewStringProperty("account-a"),
-		}),
-	}
-
-	oldState, err := structpb.NewStruct(oldInputs.Mappable())
-	require.NoError(t, err)
+	// Old inputs (from refresh): accounts in one order
+	olds := property.NewMap(map[string]property.Value{
+		gcAccounts: property.New([]property.Value{property.New("account-b"), property.New("account-a")}),
+	})
 
 	// New inputs (from program): accounts in different order
-	newInputs := resource.PropertyMap{
-		gcAccounts: resource.NewArrayProperty([]resource.PropertyValue{
-			resource.NewStringProperty("account-a"),
-			resource.NewStringProperty("account-b"),
-		}),
-	}
-
-	newState, err := structpb.NewStruct(newInputs.Mappable())
-	require.NoError(t, err)
-
-	req := &pulumirpc.DiffRequest{
-		Id:        testPolicyGroupID,
-		Urn:       testPolicyGroupURN,
-		OldInputs: oldState,
-		News:      newState,
-	}
+	news := property.NewMap(map[string]property.Value{
+		gcAccounts: property.New([]property.Value{property.New("account-a"), property.New("account-b")}),
+	})
 
-	resp, err := provider.Diff(req)
-	require.NoError(t, err)
-	require.NotNil(t, resp)
+	resp := diffPolicyGroup(t, olds, news, PolicyGroupInput{Accounts: []string{"account-a", "account-b"}})
 
 	// Verify that there is NO diff (order of array elements should not matter)
-	assert.Equal(t, pulumirpc.DiffResponse_DIFF_NONE, resp.Changes,
+	assert.False(t, resp.HasChanges,
 		"Expected no diff when array elements are in different order but contain same values")
-	assert.Empty(t, resp.Replaces, "Expected no replacements")
+	assert.Empty(t, resp.DetailedDiff, "Expected no replacements")
 }
 
 // TestPolicyGroup_Diff_NullVsEmptyArray tests that null arrays in inputs don't
 // cause diffs against empty arrays in state
 func TestPolicyGroup_Diff_NullVsEmptyArray(t *testing.T) {
-	provider := PulumiServicePolicyGroupResource{
-		Client: &PolicyGroupClientMock{},
-	}
-
-	// Old state (from refresh): empty arrays
-	oldInputs := resource.PropertyMap{
-		gcStacks:      resource.NewArrayProperty([]resource.PropertyValue{}),
-		gcAccounts:    resource.NewArrayProperty([]resource.PropertyValue{}),
-		gcPolicyPacks: resource.NewArrayProperty([]resource.PropertyValue{}),
-	}
-
-	oldState, err := structpb.NewStruct(oldInputs.Mappable())
-	require.NoError(t, err)
+	// Old inputs (from refresh): empty arrays
+	olds := property.NewMap(map[string]property.Value{
+		gcStacks:      property.New(property.NewArray(nil)),
+		gcAccounts:    property.New(property.NewArray(nil)),
+		gcPolicyPacks: property.New(property.NewArray(nil)),
+	})
 
 	// New inputs (from program): null values (property not specified)
-	newInputs := resource.PropertyMap{
-		gcStacks:      resource.NewNullProperty(),
-		gcAccounts:    resource.NewNullProperty(),
-		gcPolicyPacks: resource.NewNullProperty(),
-	}
-
-	newState, err := structpb.NewStruct(newInputs.Mappable())
-	require.NoError(t, err)
-
-	req := &pulumirpc.DiffRequest{
-		Id:        testPolicyGroupID,
-		Urn:       testPolicyGroupURN,
-		OldInputs: oldState,
-		News:      newState,
-	}
+	news := property.NewMap(map[string]property.Value{
+		gcStacks:      property.New(property.Null),
+		gcAccounts:    property.New(property.Null),
+		gcPolicyPacks: property.New(property.Null),
+	})
 
-	resp, err := provider.Diff(req)
-	require.NoError(t, err)
-	require.NotNil(t, resp)
+	resp := diffPolicyGroup(t, olds, news, PolicyGroupInput{})
 
 	// Verify that there is NO diff (null should be treated same as empty array)
-	assert.Equal(t, pulumirpc.DiffResponse_DIFF_NONE, resp.Changes,
-		"Expected no diff when comparing null arrays with empty arrays")
-	assert.Empty(t, resp.Replaces, "Expected no replacements")
+	assert.False(t, resp.HasChanges, "Expected no diff when comparing null arrays with empty arrays")
+	assert.Empty(t, resp.DetailedDiff, "Expected no replacements")
 }
 
-// TestPolicyGroup_ToPropertyMap tests that ToPropertyMap includes entityType and mode
-func TestPolicyGroup_ToPropertyMap(t *testing.T) {
-	input := PulumiServicePolicyGroupInput{
-		Name:             gcTestPolicyGroup,
-		OrganizationName: gcTestOrg,
-		EntityType:       gcAccounts,
-		Mode:             gcPreventative,
-		Stacks:           []pulumiapi.StackReference{},
-		PolicyPacks:      []pulumiapi.PolicyPackMetadata{},
-	}
-
-	pm := input.ToPropertyMap()
-
-	// Verify all fields are present
-	assert.True(t, pm[gcName].HasValue())
-	assert.Equal(t, gcTestPolicyGroup, pm[gcName].StringValue())
-
-	assert.True(t, pm[gcOrganizationName].HasValue())
-	assert.Equal(t, gcTestOrg, pm[gcOrganizationName].StringValue())
+// TestPolicyGroup_Diff_WithoutRawInputs tests that Diff falls back to the
+// recorded state when the old inputs aren't available
+func TestPolicyGroup_Diff_WithoutRawInputs(t *testing.T) {
+	news := newPolicyGroupInput().withStacks(stackRef{name: gcStack1, project: gcProject1})
 
-	assert.True(t, pm[gcEntityType].HasValue())
-	assert.Equal(t, gcAccounts, pm[gcEntityType].StringValue())
-
-	assert.True(t, pm[gcMode].HasValue())
-	assert.Equal(t, gcPreventative, pm[gcMode].StringValue())
+	resp, err := (&PolicyGroup{}).Diff(t.Context(), infer.DiffRequest[PolicyGroupInput, PolicyGroupState]{
+		ID:     testPolicyGroupID,
+		Inputs: news.input(),
+		State:  newPolicyGroupInput().state(),
+	})
+	require.NoError(t, err)
 
-	assert.True(t, pm[gcStacks].HasValue())
-	assert.True(t, pm[gcStacks].IsArray())
+	assert.True(t, resp.HasChanges)
+	assert.Equal(t, map[string]p.PropertyDiff{
+		gcStacks: {Kind: p.Update, InputDiff: true},
+	}, resp.DetailedDiff)
+}
 
-	assert.True(t, pm[gcPolicyPacks].HasValue())
-	assert.True(t, pm[gcPolicyPacks].IsArray())
+// TestPolicyGroup_InputFromState tests that inputs rendered from state keep
+// entityType and mode, and leave the output-only policy pack version behind
+func TestPolicyGroup_InputFromState(t *testing.T) {
+	state := newPolicyGroupState(gcTestOrg, newMockPolicyGroup().
+		withAccounts("account-1").
+		withPolicyPacks(policyPackRef{name: gcPolicyPack1, version: 3, versionTag: gcVersion100}).
+		build())
+	state.Mode = gcPreventative
+
+	input := policyGroupInputFromState(state)
+
+	assert.Equal(t, gcTestPolicyGroup, input.Name)
+	assert.Equal(t, gcTestOrg, input.OrganizationName)
+	assert.Equal(t, gcAccounts, input.EntityType)
+	assert.Equal(t, gcPreventative, input.Mode)
+	assert.Equal(t, []string{"account-1"}, input.Accounts)
+	require.Len(t, input.PolicyPacks, 1)
+	assert.Equal(t, PolicyGroupPolicyPackReferenceInput{
+		Name:       gcPolicyPack1,
+		VersionTag: &[]string{gcVersion100}[0],
+	}, input.PolicyPacks[0])
 }
 
-// TestPolicyGroup_ToPulumiServicePolicyGroupInput tests parsing of entityType and mode from PropertyMap
-func TestPolicyGroup_ToPulumiServicePolicyGroupInput(t *testing.T) {
-	inputMap := resource.PropertyMap{
-		gcName:             resource.NewStringProperty(gcTestPolicyGroup),
-		gcOrganizationName: resource.NewStringProperty(gcTestOrg),
-		gcEntityType:       resource.NewStringProperty(gcAccounts),
-		gcMode:             resource.NewStringProperty(gcPreventative),
-		gcStacks:           resource.NewArrayProperty([]resource.PropertyValue{}),
-		gcPolicyPacks:      resource.NewArrayProperty([]resource.PropertyValue{}),
+// TestPolicyGroup_PolicyGroupSpecFromMap tests parsing of entityType and mode from PropertyMap
+func TestPolicyGroup_PolicyGroupSpecFromMap(t *testing.T) {
+	inputMap := presource.PropertyMap{
+		gcName:             presource.NewStringProperty(gcTestPolicyGroup),
+		gcOrganizationName: presource.NewStringProperty(gcTestOrg),
+		gcEntityType:       presource.NewStringProperty(gcAccounts),
+		gcMode:             presource.NewStringProperty(gcPreventative),
+		gcStacks:           presource.NewArrayProperty([]presource.PropertyValue{}),
+		gcPolicyPacks:      presource.NewArrayProperty([]presource.PropertyValue{}),
 	}
 
-	result := ToPulumiServicePolicyGroupInput(inputMap)
+	result := policyGroupSpecFromMap(inputMap)
 
 	assert.Equal(t, gcTestPolicyGroup, result.Name)
 	assert.Equal(t, gcTestOrg, result.OrganizationName)
@@ -746,16 +559,16 @@ func TestPolicyGroup_ToPulumiServicePolicyGroupInput(t *testing.T) {
 	assert.Empty(t, result.PolicyPacks)
 }
 
-// TestPolicyGroup_ToPulumiServicePolicyGroupInput_MissingFields tests that missing entityType/mode are handled
-func TestPolicyGroup_ToPulumiServicePolicyGroupInput_MissingFields(t *testing.T) {
-	inputMap := resource.PropertyMap{
-		gcName:             resource.NewStringProperty(gcTestPolicyGroup),
-		gcOrganizationName: resource.NewStringProperty(gcTestOrg),
-		gcStacks:           resource.NewArrayProperty([]resource.PropertyValue{}),
-		gcPolicyPacks:      resource.NewArrayProperty([]resource.PropertyValue{}),
+// TestPolicyGroup_PolicyGroupSpecFromMap_MissingFields tests that missing entityType/mode are handled
+func TestPolicyGroup_PolicyGroupSpecFromMap_MissingFields(t *testing.T) {
+	inputMap := presource.PropertyMap{
+		gcName:             presource.NewStringProperty(gcTestPolicyGroup),
+		gcOrganizationName: presource.NewStringProperty(gcTestOrg),
+		gcStacks:           presource.NewArrayProperty([]presource.PropertyValue{}),
+		gcPolicyPacks:      presource.NewArrayProperty([]presource.PropertyValue{}),
 	}
 
-	result := ToPulumiServicePolicyGroupInput(inputMap)
+	result := policyGroupSpecFromMap(inputMap)
 
 	assert.Equal(t, gcTestPolicyGroup, result.Name)
 	assert.Equal(t, gcTestOrg, result.OrganizationName)
@@ -764,24 +577,24 @@ func TestPolicyGroup_ToPulumiServicePolicyGroupInput_MissingFields(t *testing.T)
 	assert.Equal(t, "", result.Mode)
 }
 
-// TestPolicyGroup_ToPulumiServicePolicyGroupInput_OptionalPolicyPackFields
+// TestPolicyGroup_PolicyGroupSpecFromMap_OptionalPolicyPackFields
 // tests that optional policy pack fields are handled
-func TestPolicyGroup_ToPulumiServicePolicyGroupInput_OptionalPolicyPackFields(t *testing.T) {
+func TestPolicyGroup_PolicyGroupSpecFromMap_OptionalPolicyPackFields(t *testing.T) {
 	// Test with only required name field
-	inputMap := resource.PropertyMap{
-		gcName:             resource.NewStringProperty(gcTestPolicyGroup),
-		gcOrganizationName: resource.NewStringProperty(gcTestOrg),
-		gcEntityType:       resource.NewStringProperty(gcStacks),
-		gcMode:             resource.NewStringProperty(gcAudit),
-		gcStacks:           resource.NewArrayProperty([]resource.PropertyValue{}),
-		gcPolicyPacks: resource.NewArrayProperty([]resource.PropertyValue{
-			resource.NewObjectProperty(resource.PropertyMap{
-				gcName: resource.NewStringProperty("test-policy-pack"),
+	inputMap := presource.PropertyMap{
+		gcName:             presource.NewStringProperty(gcTestPolicyGroup),
+		gcOrganizationName: presource.NewStringProperty(gcTestOrg),
+		gcEntityType:       presource.NewStringProperty(gcStacks),
+		gcMode:             presource.NewStringProperty(gcAudit),
+		gcStacks:           presource.NewArrayProperty([]presource.PropertyValue{}),
+		gcPolicyPacks: presource.NewArrayProperty([]presource.PropertyValue{
+			presource.NewObjectProperty(presource.PropertyMap{
+				gcName: presource.NewStringProperty("test-policy-pack"),
 			}),
 		}),
 	}
 
-	result := ToPulumiServicePolicyGroupInput(inputMap)
+	result := policyGroupSpecFromMap(inputMap)
 
 	assert.Equal(t, gcTestPolicyGroup, result.Name)
 	assert.Equal(t, gcTestOrg, result.OrganizationName)
@@ -799,28 +612,28 @@ func TestPolicyGroup_ToPulumiServicePolicyGroupInput_OptionalPolicyPackFields(t
 	assert.Nil(t, pp.Config)
 }
 
-// TestPolicyGroup_ToPulumiServicePolicyGroupInput_PartialPolicyPackFields tests mixed optional fields
-func TestPolicyGroup_ToPulumiServicePolicyGroupInput_PartialPolicyPackFields(t *testing.T) {
+// TestPolicyGroup_PolicyGroupSpecFromMap_PartialPolicyPackFields tests mixed optional fields
+func TestPolicyGroup_PolicyGroupSpecFromMap_PartialPolicyPackFields(t *testing.T) {
 	// Test with some optional fields provided
-	inputMap := resource.PropertyMap{
-		gcName:             resource.NewStringProperty(gcTestPolicyGroup),
-		gcOrganizationName: resource.NewStringProperty(gcTestOrg),
-		gcEntityType:       resource.NewStringProperty(gcStacks),
-		gcMode:             resource.NewStringProperty(gcAudit),
-		gcStacks:           resource.NewArrayProperty([]resource.PropertyValue{}),
-		gcPolicyPacks: resource.NewArrayProperty([]resource.PropertyValue{
-			resource.NewObjectProperty(resource.PropertyMap{
-				gcName:        resource.NewStringProperty("test-policy-pack"),
-				gcDisplayName: resource.NewStringProperty("Test Policy Pack"),
+	inputMap := presource.PropertyMap{
+		gcName:             presource.NewStringProperty(gcTestPolicyGroup),
+		gcOrganizationName: presource.NewStringProperty(gcTestOrg),
+		gcEntityType:       presource.NewStringProperty(gcStacks),
+		gcMode:             presource.NewStringProperty(gcAudit),
+		gcStacks:           presource.NewArrayProperty([]presource.PropertyValue{}),
+		gcPolicyPacks: presource.NewArrayProperty([]presource.PropertyValue{
+			presource.NewObjectProperty(presource.PropertyMap{
+				gcName:        presource.NewStringProperty("test-policy-pack"),
+				gcDisplayName: presource.NewStringProperty("Test Policy Pack"),
 				// Note: version and versionTag are intentionally omitted
-				"config": resource.NewObjectProperty(resource.PropertyMap{
-					"enabled": resource.NewBoolProperty(true),
+				"config": presource.NewObjectProperty(presource.PropertyMap{
+					"enabled": presource.NewBoolProperty(true),
 				}),
 			}),
 		}),
 	}
 
-	result := ToPulumiServicePolicyGroupInput(inputMap)
+	result := policyGroupSpecFromMap(inputMap)
 
 	assert.Len(t, result.PolicyPacks, 1)
 
@@ -895,198 +708,76 @@ func TestPolicyGroup_Read(t *testing.T) {
 	accountB := "account-b"
 	accountC := "account-c"
 
+	read := func(
+		t *testing.T, client *PolicyGroupClientMock, req infer.ReadRequest[PolicyGroupInput, PolicyGroupState],
+	) infer.ReadResponse[PolicyGroupInput, PolicyGroupState] {
+		t.Helper()
+		req.ID = testPolicyGroupID
+		resp, err := (&PolicyGroup{}).Read(config.WithMockClient(t.Context(), client), req)
+		require.NoError(t, err)
+		return resp
+	}
+
 	t.Run("preserves original inputs when API state matches previous state", func(t *testing.T) {
 		// Scenario: User specified ["parent-account"], API returned ["parent-account", "parent-account/child"]
 		// After refresh, API still returns the same. We should preserve the original input ["parent-account"]
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					return newMockPolicyGroup().withAccounts(parentAccount, childAccount).build(), nil
-				},
+		resp := read(t, &PolicyGroupClientMock{
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				return newMockPolicyGroup().withAccounts(parentAccount, childAccount).build(), nil
 			},
-		}
-
-		req := &pulumirpc.ReadRequest{
-			Id:  testPolicyGroupID,
-			Urn: testPolicyGroupURN,
-			Properties: newPolicyGroupInput().withEntityType(gcAccounts).
+		}, infer.ReadRequest[PolicyGroupInput, PolicyGroupState]{
+			State: newPolicyGroupInput().withEntityType(gcAccounts).
 				withAccounts(parentAccount, childAccount).
-				buildStruct(t),
-			Inputs: newPolicyGroupInput().withEntityType(gcAccounts).withAccounts(parentAccount).buildStruct(t),
-		}
-
-		resp, err := provider.Read(req)
-		require.NoError(t, err)
-		require.NotNil(t, resp)
+				state(),
+			Inputs: newPolicyGroupInput().withEntityType(gcAccounts).withAccounts(parentAccount).input(),
+		})
 
-		// Properties should reflect full API response (including child)
-		propsMap := resource.PropertyMap{}
-		for k, v := range resp.Properties.GetFields() {
-			propsMap[resource.PropertyKey(k)] = resource.NewPropertyValue(v.AsInterface())
-		}
-		assert.Len(t, propsMap[gcAccounts].ArrayValue(), 2, "Properties should have both parent and child accounts")
+		// State should reflect full API response (including child)
+		assert.Len(t, resp.State.Accounts, 2, "State should have both parent and child accounts")
 
 		// Inputs should preserve original user input (just the parent)
-		inputsMap := resource.PropertyMap{}
-		for k, v := range resp.Inputs.GetFields() {
-			inputsMap[resource.PropertyKey(k)] = resource.NewPropertyValue(v.AsInterface())
-		}
-		assert.Len(t, inputsMap[gcAccounts].ArrayValue(), 1, "Inputs should preserve original user input")
-		assert.Equal(t, parentAccount, inputsMap[gcAccounts].ArrayValue()[0].StringValue())
+		assert.Equal(t, []string{parentAccount}, resp.Inputs.Accounts, "Inputs should preserve original user input")
 	})
 
 	t.Run("updates inputs when API state has changed externally", func(t *testing.T) {
 		// Scenario: API state changed (someone added a new account externally)
 		// We should update inputs to reflect the new state
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					return newMockPolicyGroup().withAccounts(accountA, accountB, accountC).build(), nil
-				},
+		resp := read(t, &PolicyGroupClientMock{
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				return newMockPolicyGroup().withAccounts(accountA, accountB, accountC).build(), nil
 			},
-		}
-
-		req := &pulumirpc.ReadRequest{
-			Id:  testPolicyGroupID,
-			Urn: testPolicyGroupURN,
-			Properties: newPolicyGroupInput().withEntityType(gcAccounts).
-				withAccounts(accountA, accountB).
-				buildStruct(t),
-			Inputs: newPolicyGroupInput().withEntityType(gcAccounts).
-				withAccounts(accountA, accountB).
-				buildStruct(t),
-		}
-
-		resp, err := provider.Read(req)
-		require.NoError(t, err)
-		require.NotNil(t, resp)
+		}, infer.ReadRequest[PolicyGroupInput, PolicyGroupState]{
+			State:  newPolicyGroupInput().withEntityType(gcAccounts).withAccounts(accountA, accountB).state(),
+			Inputs: newPolicyGroupInput().withEntityType(gcAccounts).withAccounts(accountA, accountB).input(),
+		})
 
-		// Both properties and inputs should reflect the new API state
-		inputsMap := resource.PropertyMap{}
-		for k, v := range resp.Inputs.GetFields() {
-			inputsMap[resource.PropertyKey(k)] = resource.NewPropertyValue(v.AsInterface())
-		}
-		assert.Len(t, inputsMap[gcAccounts].ArrayValue(), 3, "Inputs should be updated to match new API state")
+		// Both state and inputs should reflect the new API state
+		assert.Len(t, resp.Inputs.Accounts, 3, "Inputs should be updated to match new API state")
 	})
 
 	t.Run("handles read with no previous state or inputs", func(t *testing.T) {
 		// Scenario: Import or first read - no previous state available
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					return newMockPolicyGroup().withAccounts(accountA).build(), nil
-				},
+		resp := read(t, &PolicyGroupClientMock{
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				return newMockPolicyGroup().withAccounts(accountA).build(), nil
 			},
-		}
-
-		req := &pulumirpc.ReadRequest{
-			Id:         testPolicyGroupID,
-			Urn:        testPolicyGroupURN,
-			Properties: nil,
-			Inputs:     nil,
-		}
-
-		resp, err := provider.Read(req)
-		require.NoError(t, err)
-		require.NotNil(t, resp)
+		}, infer.ReadRequest[PolicyGroupInput, PolicyGroupState]{})
 
 		// Both should reflect API state
-		propsMap := resource.PropertyMap{}
-		for k, v := range resp.Properties.GetFields() {
-			propsMap[resource.PropertyKey(k)] = resource.NewPropertyValue(v.AsInterface())
-		}
-		assert.Len(t, propsMap[gcAccounts].ArrayValue(), 1)
-
-		inputsMap := resource.PropertyMap{}
-		for k, v := range resp.Inputs.GetFields() {
-			inputsMap[resource.PropertyKey(k)] = resource.NewPropertyValue(v.AsInterface())
-		}
-		assert.Len(t, inputsMap[gcAccounts].ArrayValue(), 1)
+		assert.Equal(t, testPolicyGroupID, resp.ID)
+		assert.Len(t, resp.State.Accounts, 1)
+		assert.Len(t, resp.Inputs.Accounts, 1)
+		assert.Equal(t, gcTestOrg, resp.Inputs.OrganizationName)
 	})
 
 	t.Run("returns empty response when policy group not found", func(t *testing.T) {
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					return nil, nil // Not found
-				},
+		resp := read(t, &PolicyGroupClientMock{
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				return nil, nil // Not found
 			},
-		}
-
-		req := &pulumirpc.ReadRequest{
-			Id:  testPolicyGroupID,
-			Urn: testPolicyGroupURN,
-		}
-
-		resp, err := provider.Read(req)
-		require.NoError(t, err)
-		require.NotNil(t, resp)
-		assert.Empty(t, resp.Id, "Should return empty response for not found")
-		assert.Nil(t, resp.Properties)
-	})
-}
-
-// TestParsePreviousAccounts tests the parsePreviousAccounts helper function
-func TestParsePreviousAccounts(t *testing.T) {
-	t.Run("parses both properties and inputs", func(t *testing.T) {
-		props := resource.PropertyMap{
-			gcAccounts: resource.NewArrayProperty([]resource.PropertyValue{
-				resource.NewStringProperty("state-account-1"),
-				resource.NewStringProperty("state-account-2"),
-			}),
-		}
-		propsStruct, err := structpb.NewStruct(props.Mappable())
-		require.NoError(t, err)
-
-		inputs := resource.PropertyMap{
-			gcAccounts: resource.NewArrayProperty([]resource.PropertyValue{
-				resource.NewStringProperty("input-account-1"),
-			}),
-		}
-		inputsStruct, err := structpb.NewStruct(inputs.Mappable())
-		require.NoError(t, err)
-
-		stateAccounts, inputAccounts := parsePreviousAccounts(propsStruct, inputsStruct)
+		}, infer.ReadRequest[PolicyGroupInput, PolicyGroupState]{})
 
-		assert.Equal(t, []string{"state-account-1", "state-account-2"}, stateAccounts)
-		assert.Equal(t, []string{"input-account-1"}, inputAccounts)
-	})
-
-	t.Run("handles nil properties", func(t *testing.T) {
-		inputs := resource.PropertyMap{
-			gcAccounts: resource.NewArrayProperty([]resource.PropertyValue{
-				resource.NewStringProperty("input-account"),
-			}),
-		}
-		inputsStruct, err := structpb.NewStruct(inputs.Mappable())
-		require.NoError(t, err)
-
-		stateAccounts, inputAccounts := parsePreviousAccounts(nil, inputsStruct)
-
-		assert.Nil(t, stateAccounts)
-		assert.Equal(t, []string{"input-account"}, inputAccounts)
-	})
-
-	t.Run("handles nil inputs", func(t *testing.T) {
-		props := resource.PropertyMap{
-			gcAccounts: resource.NewArrayProperty([]resource.PropertyValue{
-				resource.NewStringProperty("state-account"),
-			}),
-		}
-		propsStruct, err := structpb.NewStruct(props.Mappable())
-		require.NoError(t, err)
-
-		stateAccounts, inputAccounts := parsePreviousAccounts(propsStruct, nil)
-
-		assert.Equal(t, []string{"state-account"}, stateAccounts)
-		assert.Nil(t, inputAccounts)
-	})
-
-	t.Run("handles both nil", func(t *testing.T) {
-		stateAccounts, inputAccounts := parsePreviousAccounts(nil, nil)
-
-		assert.Nil(t, stateAccounts)
-		assert.Nil(t, inputAccounts)
+		assert.Empty(t, resp.ID, "Should return empty response for not found")
 	})
 }
 
@@ -1098,68 +789,65 @@ func TestPolicyGroup_Create(t *testing.T) {
 	account1 := "account-1"
 	account2 := "account-2"
 
+	create := func(
+		t *testing.T, client *PolicyGroupClientMock, inputs *policyGroupInputBuilder,
+	) (infer.CreateResponse[PolicyGroupState], error) {
+		t.Helper()
+		return (&PolicyGroup{}).Create(config.WithMockClient(t.Context(), client),
+			infer.CreateRequest[PolicyGroupInput]{Inputs: inputs.input()})
+	}
+
 	t.Run("creates empty policy group", func(t *testing.T) {
 		var createCalled bool
 		var batchUpdateCalled bool
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				createPolicyGroupFunc: func(_ context.Context, orgName, policyGroupName, entityType, mode string) error {
-					createCalled = true
-					assert.Equal(t, gcTestOrg, orgName)
-					assert.Equal(t, gcTestPolicyGroup, policyGroupName)
-					assert.Equal(t, gcStacks, entityType)
-					assert.Equal(t, gcAudit, mode)
-					return nil
-				},
-				batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, _ []pulumiapi.UpdatePolicyGroupRequest) error {
-					batchUpdateCalled = true
-					return nil
-				},
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					return newMockPolicyGroup().build(), nil
-				},
+		resp, err := create(t, &PolicyGroupClientMock{
+			createPolicyGroupFunc: func(_ context.Context, orgName, policyGroupName, entityType, mode string) error {
+				createCalled = true
+				assert.Equal(t, gcTestOrg, orgName)
+				assert.Equal(t, gcTestPolicyGroup, policyGroupName)
+				assert.Equal(t, gcStacks, entityType)
+				assert.Equal(t, gcAudit, mode)
+				return nil
 			},
-		}
-
-		req := &pulumirpc.CreateRequest{
-			Urn:        testPolicyGroupURN,
-			Properties: newPolicyGroupInput().buildStruct(t),
-		}
-
-		resp, err := provider.Create(req)
+			batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, _ []pulumiapi.UpdatePolicyGroupRequest) error {
+				batchUpdateCalled = true
+				return nil
+			},
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				return newMockPolicyGroup().build(), nil
+			},
+		}, newPolicyGroupInput())
 		require.NoError(t, err)
-		require.NotNil(t, resp)
 
 		assert.True(t, createCalled, "CreatePolicyGroup should be called")
 		assert.False(t, batchUpdateCalled, "BatchUpdatePolicyGroup should not be called for empty policy group")
-		assert.Equal(t, testPolicyGroupID, resp.Id)
+		assert.Equal(t, testPolicyGroupID, resp.ID)
+	})
+
+	t.Run("previews without calling the API", func(t *testing.T) {
+		resp, err := (&PolicyGroup{}).Create(config.WithMockClient(t.Context(), &PolicyGroupClientMock{}),
+			infer.CreateRequest[PolicyGroupInput]{
+				Inputs: newPolicyGroupInput().withStacks(stack1).input(),
+				DryRun: true,
+			})
+		require.NoError(t, err)
+
+		assert.Equal(t, testPolicyGroupID, resp.ID)
+		assert.Equal(t, newPolicyGroupInput().withStacks(stack1).state(), resp.Output)
 	})
 
 	t.Run("creates policy group with stacks", func(t *testing.T) {
 		var capturedReqs []pulumiapi.UpdatePolicyGroupRequest
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				createPolicyGroupFunc: func(_ context.Context, _, _, _, _ string) error {
-					return nil
-				},
-				batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, reqs []pulumiapi.UpdatePolicyGroupRequest) error {
-					capturedReqs = append(capturedReqs, reqs...)
-					return nil
-				},
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					return newMockPolicyGroup().withStacks(stack1, stack2).build(), nil
-				},
+		_, err := create(t, &PolicyGroupClientMock{
+			batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, reqs []pulumiapi.UpdatePolicyGroupRequest) error {
+				capturedReqs = append(capturedReqs, reqs...)
+				return nil
 			},
-		}
-
-		req := &pulumirpc.CreateRequest{
-			Urn:        testPolicyGroupURN,
-			Properties: newPolicyGroupInput().withStacks(stack1, stack2).buildStruct(t),
-		}
-
-		resp, err := provider.Create(req)
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				return newMockPolicyGroup().withStacks(stack1, stack2).build(), nil
+			},
+		}, newPolicyGroupInput().withStacks(stack1, stack2))
 		require.NoError(t, err)
-		require.NotNil(t, resp)
 
 		require.Len(t, capturedReqs, 2)
 		require.NotNil(t, capturedReqs[0].AddStack)
@@ -1170,29 +858,16 @@ func TestPolicyGroup_Create(t *testing.T) {
 
 	t.Run("creates policy group with policy packs", func(t *testing.T) {
 		var capturedReqs []pulumiapi.UpdatePolicyGroupRequest
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				createPolicyGroupFunc: func(_ context.Context, _, _, _, _ string) error {
-					return nil
-				},
-				batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, reqs []pulumiapi.UpdatePolicyGroupRequest) error {
-					capturedReqs = append(capturedReqs, reqs...)
-					return nil
-				},
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					return newMockPolicyGroup().withPolicyPacks(pp1).build(), nil
-				},
+		_, err := create(t, &PolicyGroupClientMock{
+			batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, reqs []pulumiapi.UpdatePolicyGroupRequest) error {
+				capturedReqs = append(capturedReqs, reqs...)
+				return nil
 			},
-		}
-
-		req := &pulumirpc.CreateRequest{
-			Urn:        testPolicyGroupURN,
-			Properties: newPolicyGroupInput().withPolicyPacks(pp1).buildStruct(t),
-		}
-
-		resp, err := provider.Create(req)
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				return newMockPolicyGroup().withPolicyPacks(pp1).build(), nil
+			},
+		}, newPolicyGroupInput().withPolicyPacks(pp1))
 		require.NoError(t, err)
-		require.NotNil(t, resp)
 
 		require.Len(t, capturedReqs, 1)
 		require.NotNil(t, capturedReqs[0].AddPolicyPack)
@@ -1201,31 +876,16 @@ func TestPolicyGroup_Create(t *testing.T) {
 
 	t.Run("creates policy group with accounts", func(t *testing.T) {
 		var capturedReqs []pulumiapi.UpdatePolicyGroupRequest
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				createPolicyGroupFunc: func(_ context.Context, _, _, _, _ string) error {
-					return nil
-				},
-				batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, reqs []pulumiapi.UpdatePolicyGroupRequest) error {
-					capturedReqs = append(capturedReqs, reqs...)
-					return nil
-				},
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					return newMockPolicyGroup().withAccounts(account1, account2).build(), nil
-				},
+		_, err := create(t, &PolicyGroupClientMock{
+			batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, reqs []pulumiapi.UpdatePolicyGroupRequest) error {
+				capturedReqs = append(capturedReqs, reqs...)
+				return nil
 			},
-		}
-
-		req := &pulumirpc.CreateRequest{
-			Urn: testPolicyGroupURN,
-			Properties: newPolicyGroupInput().withEntityType(gcAccounts).
-				withAccounts(account1, account2).
-				buildStruct(t),
-		}
-
-		resp, err := provider.Create(req)
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				return newMockPolicyGroup().withAccounts(account1, account2).build(), nil
+			},
+		}, newPolicyGroupInput().withEntityType(gcAccounts).withAccounts(account1, account2))
 		require.NoError(t, err)
-		require.NotNil(t, resp)
 
 		require.Len(t, capturedReqs, 2)
 		require.NotNil(t, capturedReqs[0].AddInsightsAccount)
@@ -1235,103 +895,53 @@ func TestPolicyGroup_Create(t *testing.T) {
 	})
 
 	t.Run("returns error from CreatePolicyGroup", func(t *testing.T) {
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				createPolicyGroupFunc: func(_ context.Context, _, _, _, _ string) error {
-					return fmt.Errorf("create error")
-				},
+		resp, err := create(t, &PolicyGroupClientMock{
+			createPolicyGroupFunc: func(_ context.Context, _, _, _, _ string) error {
+				return fmt.Errorf("create error")
 			},
-		}
-
-		req := &pulumirpc.CreateRequest{
-			Urn:        testPolicyGroupURN,
-			Properties: newPolicyGroupInput().buildStruct(t),
-		}
-
-		_, err := provider.Create(req)
+		}, newPolicyGroupInput())
 		assert.ErrorContains(t, err, "error creating policy group")
 		assert.ErrorContains(t, err, "create error")
+		assert.Empty(t, resp.ID, "Nothing was created, so no ID should be returned")
 	})
 
-	t.Run("returns error from BatchUpdatePolicyGroup", func(t *testing.T) {
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				createPolicyGroupFunc: func(_ context.Context, _, _, _, _ string) error {
-					return nil
-				},
-				batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, _ []pulumiapi.UpdatePolicyGroupRequest) error {
-					return fmt.Errorf("batch update error")
-				},
+	t.Run("returns partial failure from BatchUpdatePolicyGroup", func(t *testing.T) {
+		resp, err := create(t, &PolicyGroupClientMock{
+			batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, _ []pulumiapi.UpdatePolicyGroupRequest) error {
+				return fmt.Errorf("batch update error")
 			},
-		}
-
-		req := &pulumirpc.CreateRequest{
-			Urn:        testPolicyGroupURN,
-			Properties: newPolicyGroupInput().withStacks(stack1).buildStruct(t),
-		}
+		}, newPolicyGroupInput().withStacks(stack1))
 
-		_, err := provider.Create(req)
-		assert.ErrorContains(t, err, "failed to add items to policy group")
-		assert.ErrorContains(t, err, "batch update error")
+		var initErr infer.ResourceInitFailedError
+		require.ErrorAs(t, err, &initErr)
+		assert.Equal(t, []string{"failed to add items to policy group: batch update error"}, initErr.Reasons)
+		assert.Equal(t, testPolicyGroupID, resp.ID, "The group exists, so its ID must be checkpointed")
 	})
 
-	t.Run("returns error from GetPolicyGroup after create", func(t *testing.T) {
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				createPolicyGroupFunc: func(_ context.Context, _, _, _, _ string) error {
-					return nil
-				},
-				batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, _ []pulumiapi.UpdatePolicyGroupRequest) error {
-					return nil
-				},
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					return nil, fmt.Errorf("read error")
-				},
+	t.Run("returns partial failure from GetPolicyGroup after create", func(t *testing.T) {
+		resp, err := create(t, &PolicyGroupClientMock{
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				return nil, fmt.Errorf("read error")
 			},
-		}
+		}, newPolicyGroupInput().withStacks(stack1))
 
-		req := &pulumirpc.CreateRequest{
-			Urn:        testPolicyGroupURN,
-			Properties: newPolicyGroupInput().withStacks(stack1).buildStruct(t),
-		}
-
-		_, err := provider.Create(req)
-		assert.ErrorContains(t, err, "read error")
+		var initErr infer.ResourceInitFailedError
+		require.ErrorAs(t, err, &initErr)
+		assert.Equal(t, []string{"read error"}, initErr.Reasons)
+		assert.Equal(t, testPolicyGroupID, resp.ID)
+		assert.Equal(t, newPolicyGroupInput().withStacks(stack1).state(), resp.Output)
 	})
 
 	t.Run("returns state from API response", func(t *testing.T) {
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				createPolicyGroupFunc: func(_ context.Context, _, _, _, _ string) error {
-					return nil
-				},
-				batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, _ []pulumiapi.UpdatePolicyGroupRequest) error {
-					return nil
-				},
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					// API returns more than what was requested (e.g., auto-added child accounts)
-					return newMockPolicyGroup().withAccounts(account1, account1+"/child").build(), nil
-				},
+		resp, err := create(t, &PolicyGroupClientMock{
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				// API returns more than what was requested (e.g., auto-added child accounts)
+				return newMockPolicyGroup().withAccounts(account1, account1+"/child").build(), nil
 			},
-		}
-
-		req := &pulumirpc.CreateRequest{
-			Urn:        testPolicyGroupURN,
-			Properties: newPolicyGroupInput().withEntityType(gcAccounts).withAccounts(account1).buildStruct(t),
-		}
-
-		resp, err := provider.Create(req)
+		}, newPolicyGroupInput().withEntityType(gcAccounts).withAccounts(account1))
 		require.NoError(t, err)
-		require.NotNil(t, resp)
-		require.NotNil(t, resp.Properties)
-
-		propsMap := resource.PropertyMap{}
-		for k, v := range resp.Properties.GetFields() {
-			propsMap[resource.PropertyKey(k)] = resource.NewPropertyValue(v.AsInterface())
-		}
 
-		accounts := propsMap[gcAccounts].ArrayValue()
-		require.Len(t, accounts, 2, "Should return full state from API including auto-added accounts")
+		require.Len(t, resp.Output.Accounts, 2, "Should return full state from API including auto-added accounts")
 	})
 }
 
@@ -1346,30 +956,30 @@ func TestPolicyGroup_Update(t *testing.T) {
 	parentAccount := "parent-account"
 	childAccount := "parent-account/child"
 
+	update := func(
+		t *testing.T, client *PolicyGroupClientMock, olds, news *policyGroupInputBuilder,
+	) (infer.UpdateResponse[PolicyGroupState], error) {
+		t.Helper()
+		return (&PolicyGroup{}).Update(config.WithMockClient(t.Context(), client),
+			infer.UpdateRequest[PolicyGroupInput, PolicyGroupState]{
+				ID:     testPolicyGroupID,
+				Inputs: news.input(),
+				State:  olds.state(),
+			})
+	}
+
 	t.Run("adds new stacks", func(t *testing.T) {
 		var capturedReqs []pulumiapi.UpdatePolicyGroupRequest
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, reqs []pulumiapi.UpdatePolicyGroupRequest) error {
-					capturedReqs = append(capturedReqs, reqs...)
-					return nil
-				},
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					return newMockPolicyGroup().withStacks(stack1, stack2).build(), nil
-				},
+		_, err := update(t, &PolicyGroupClientMock{
+			batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, reqs []pulumiapi.UpdatePolicyGroupRequest) error {
+				capturedReqs = append(capturedReqs, reqs...)
+				return nil
 			},
-		}
-
-		req := &pulumirpc.UpdateRequest{
-			Id:   testPolicyGroupID,
-			Urn:  testPolicyGroupURN,
-			Olds: newPolicyGroupInput().withStacks(stack1).buildStruct(t),
-			News: newPolicyGroupInput().withStacks(stack1, stack2).buildStruct(t),
-		}
-
-		resp, err := provider.Update(req)
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				return newMockPolicyGroup().withStacks(stack1, stack2).build(), nil
+			},
+		}, newPolicyGroupInput().withStacks(stack1), newPolicyGroupInput().withStacks(stack1, stack2))
 		require.NoError(t, err)
-		require.NotNil(t, resp)
 
 		require.Len(t, capturedReqs, 1)
 		require.NotNil(t, capturedReqs[0].AddStack)
@@ -1379,28 +989,16 @@ func TestPolicyGroup_Update(t *testing.T) {
 
 	t.Run("removes stacks", func(t *testing.T) {
 		var capturedReqs []pulumiapi.UpdatePolicyGroupRequest
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, reqs []pulumiapi.UpdatePolicyGroupRequest) error {
-					capturedReqs = append(capturedReqs, reqs...)
-					return nil
-				},
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					return newMockPolicyGroup().withStacks(stack1).build(), nil
-				},
+		_, err := update(t, &PolicyGroupClientMock{
+			batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, reqs []pulumiapi.UpdatePolicyGroupRequest) error {
+				capturedReqs = append(capturedReqs, reqs...)
+				return nil
 			},
-		}
-
-		req := &pulumirpc.UpdateRequest{
-			Id:   testPolicyGroupID,
-			Urn:  testPolicyGroupURN,
-			Olds: newPolicyGroupInput().withStacks(stack1, stack2).buildStruct(t),
-			News: newPolicyGroupInput().withStacks(stack1).buildStruct(t),
-		}
-
-		resp, err := provider.Update(req)
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				return newMockPolicyGroup().withStacks(stack1).build(), nil
+			},
+		}, newPolicyGroupInput().withStacks(stack1, stack2), newPolicyGroupInput().withStacks(stack1))
 		require.NoError(t, err)
-		require.NotNil(t, resp)
 
 		require.Len(t, capturedReqs, 1)
 		require.NotNil(t, capturedReqs[0].RemoveStack)
@@ -1410,28 +1008,16 @@ func TestPolicyGroup_Update(t *testing.T) {
 
 	t.Run("adds and removes policy packs", func(t *testing.T) {
 		var capturedReqs []pulumiapi.UpdatePolicyGroupRequest
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, reqs []pulumiapi.UpdatePolicyGroupRequest) error {
-					capturedReqs = append(capturedReqs, reqs...)
-					return nil
-				},
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					return newMockPolicyGroup().withPolicyPacks(pp2).build(), nil
-				},
+		_, err := update(t, &PolicyGroupClientMock{
+			batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, reqs []pulumiapi.UpdatePolicyGroupRequest) error {
+				capturedReqs = append(capturedReqs, reqs...)
+				return nil
 			},
-		}
-
-		req := &pulumirpc.UpdateRequest{
-			Id:   testPolicyGroupID,
-			Urn:  testPolicyGroupURN,
-			Olds: newPolicyGroupInput().withPolicyPacks(pp1).buildStruct(t),
-			News: newPolicyGroupInput().withPolicyPacks(pp2).buildStruct(t),
-		}
-
-		resp, err := provider.Update(req)
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				return newMockPolicyGroup().withPolicyPacks(pp2).build(), nil
+			},
+		}, newPolicyGroupInput().withPolicyPacks(pp1), newPolicyGroupInput().withPolicyPacks(pp2))
 		require.NoError(t, err)
-		require.NotNil(t, resp)
 
 		require.Len(t, capturedReqs, 2)
 
@@ -1453,28 +1039,18 @@ func TestPolicyGroup_Update(t *testing.T) {
 
 	t.Run("adds accounts", func(t *testing.T) {
 		var capturedReqs []pulumiapi.UpdatePolicyGroupRequest
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, reqs []pulumiapi.UpdatePolicyGroupRequest) error {
-					capturedReqs = append(capturedReqs, reqs...)
-					return nil
-				},
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					return newMockPolicyGroup().withAccounts(account1, account2).build(), nil
-				},
+		_, err := update(t, &PolicyGroupClientMock{
+			batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, reqs []pulumiapi.UpdatePolicyGroupRequest) error {
+				capturedReqs = append(capturedReqs, reqs...)
+				return nil
 			},
-		}
-
-		req := &pulumirpc.UpdateRequest{
-			Id:   testPolicyGroupID,
-			Urn:  testPolicyGroupURN,
-			Olds: newPolicyGroupInput().withEntityType(gcAccounts).withAccounts(account1).buildStruct(t),
-			News: newPolicyGroupInput().withEntityType(gcAccounts).withAccounts(account1, account2).buildStruct(t),
-		}
-
-		resp, err := provider.Update(req)
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				return newMockPolicyGroup().withAccounts(account1, account2).build(), nil
+			},
+		},
+			newPolicyGroupInput().withEntityType(gcAccounts).withAccounts(account1),
+			newPolicyGroupInput().withEntityType(gcAccounts).withAccounts(account1, account2))
 		require.NoError(t, err)
-		require.NotNil(t, resp)
 
 		require.Len(t, capturedReqs, 1)
 		require.NotNil(t, capturedReqs[0].AddInsightsAccount)
@@ -1483,30 +1059,18 @@ func TestPolicyGroup_Update(t *testing.T) {
 
 	t.Run("removes accounts but skips child accounts with parent still present", func(t *testing.T) {
 		var capturedReqs []pulumiapi.UpdatePolicyGroupRequest
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, reqs []pulumiapi.UpdatePolicyGroupRequest) error {
-					capturedReqs = append(capturedReqs, reqs...)
-					return nil
-				},
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					return newMockPolicyGroup().withAccounts(parentAccount, childAccount).build(), nil
-				},
+		_, err := update(t, &PolicyGroupClientMock{
+			batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, reqs []pulumiapi.UpdatePolicyGroupRequest) error {
+				capturedReqs = append(capturedReqs, reqs...)
+				return nil
 			},
-		}
-
-		req := &pulumirpc.UpdateRequest{
-			Id:  testPolicyGroupID,
-			Urn: testPolicyGroupURN,
-			Olds: newPolicyGroupInput().withEntityType(gcAccounts).
-				withAccounts(parentAccount, childAccount).
-				buildStruct(t),
-			News: newPolicyGroupInput().withEntityType(gcAccounts).withAccounts(parentAccount).buildStruct(t),
-		}
-
-		resp, err := provider.Update(req)
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				return newMockPolicyGroup().withAccounts(parentAccount, childAccount).build(), nil
+			},
+		},
+			newPolicyGroupInput().withEntityType(gcAccounts).withAccounts(parentAccount, childAccount),
+			newPolicyGroupInput().withEntityType(gcAccounts).withAccounts(parentAccount))
 		require.NoError(t, err)
-		require.NotNil(t, resp)
 
 		// Child should NOT be removed because parent is still present
 		assert.Empty(t, capturedReqs, "Should not remove child account when parent is still present")
@@ -1514,30 +1078,18 @@ func TestPolicyGroup_Update(t *testing.T) {
 
 	t.Run("removes child account when parent is also removed", func(t *testing.T) {
 		var capturedReqs []pulumiapi.UpdatePolicyGroupRequest
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, reqs []pulumiapi.UpdatePolicyGroupRequest) error {
-					capturedReqs = append(capturedReqs, reqs...)
-					return nil
-				},
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					return newMockPolicyGroup().withAccounts().build(), nil
-				},
+		_, err := update(t, &PolicyGroupClientMock{
+			batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, reqs []pulumiapi.UpdatePolicyGroupRequest) error {
+				capturedReqs = append(capturedReqs, reqs...)
+				return nil
 			},
-		}
-
-		req := &pulumirpc.UpdateRequest{
-			Id:  testPolicyGroupID,
-			Urn: testPolicyGroupURN,
-			Olds: newPolicyGroupInput().withEntityType(gcAccounts).
-				withAccounts(parentAccount, childAccount).
-				buildStruct(t),
-			News: newPolicyGroupInput().withEntityType(gcAccounts).buildStruct(t),
-		}
-
-		resp, err := provider.Update(req)
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				return newMockPolicyGroup().withAccounts().build(), nil
+			},
+		},
+			newPolicyGroupInput().withEntityType(gcAccounts).withAccounts(parentAccount, childAccount),
+			newPolicyGroupInput().withEntityType(gcAccounts))
 		require.NoError(t, err)
-		require.NotNil(t, resp)
 
 		require.Len(t, capturedReqs, 2)
 
@@ -1552,115 +1104,66 @@ func TestPolicyGroup_Update(t *testing.T) {
 
 	t.Run("no changes when inputs are identical", func(t *testing.T) {
 		var batchUpdateCalled bool
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, _ []pulumiapi.UpdatePolicyGroupRequest) error {
-					batchUpdateCalled = true
-					return nil
-				},
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					return newMockPolicyGroup().withStacks(stack1).build(), nil
-				},
+		_, err := update(t, &PolicyGroupClientMock{
+			batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, _ []pulumiapi.UpdatePolicyGroupRequest) error {
+				batchUpdateCalled = true
+				return nil
 			},
-		}
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				return newMockPolicyGroup().withStacks(stack1).build(), nil
+			},
+		}, newPolicyGroupInput().withStacks(stack1), newPolicyGroupInput().withStacks(stack1))
+		require.NoError(t, err)
 
-		inputs := newPolicyGroupInput().withStacks(stack1).buildStruct(t)
-		req := &pulumirpc.UpdateRequest{
-			Id:   testPolicyGroupID,
-			Urn:  testPolicyGroupURN,
-			Olds: inputs,
-			News: inputs,
-		}
+		assert.False(t, batchUpdateCalled, "BatchUpdatePolicyGroup should not be called when there are no changes")
+	})
 
-		resp, err := provider.Update(req)
+	t.Run("previews without calling the API", func(t *testing.T) {
+		resp, err := (&PolicyGroup{}).Update(config.WithMockClient(t.Context(), &PolicyGroupClientMock{}),
+			infer.UpdateRequest[PolicyGroupInput, PolicyGroupState]{
+				ID:     testPolicyGroupID,
+				Inputs: newPolicyGroupInput().withStacks(stack1, stack2).input(),
+				State:  newPolicyGroupInput().withStacks(stack1).state(),
+				DryRun: true,
+			})
 		require.NoError(t, err)
-		require.NotNil(t, resp)
 
-		assert.False(t, batchUpdateCalled, "BatchUpdatePolicyGroup should not be called when there are no changes")
+		assert.Equal(t, newPolicyGroupInput().withStacks(stack1, stack2).state(), resp.Output)
 	})
 
 	t.Run("returns error from BatchUpdatePolicyGroup", func(t *testing.T) {
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, _ []pulumiapi.UpdatePolicyGroupRequest) error {
-					return fmt.Errorf("API error")
-				},
+		_, err := update(t, &PolicyGroupClientMock{
+			batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, _ []pulumiapi.UpdatePolicyGroupRequest) error {
+				return fmt.Errorf("API error")
 			},
-		}
-
-		req := &pulumirpc.UpdateRequest{
-			Id:   testPolicyGroupID,
-			Urn:  testPolicyGroupURN,
-			Olds: newPolicyGroupInput().buildStruct(t),
-			News: newPolicyGroupInput().withStacks(stack1).buildStruct(t),
-		}
+		}, newPolicyGroupInput(), newPolicyGroupInput().withStacks(stack1))
 
-		_, err := provider.Update(req)
-		assert.ErrorContains(t, err, "failed to update policy group: API error")
+		var initErr infer.ResourceInitFailedError
+		require.ErrorAs(t, err, &initErr)
+		assert.Equal(t, []string{"failed to update policy group: API error"}, initErr.Reasons)
 	})
 
 	t.Run("returns error from GetPolicyGroup after update", func(t *testing.T) {
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, _ []pulumiapi.UpdatePolicyGroupRequest) error {
-					return nil
-				},
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					return nil, fmt.Errorf("read error")
-				},
+		_, err := update(t, &PolicyGroupClientMock{
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				return nil, fmt.Errorf("read error")
 			},
-		}
-
-		req := &pulumirpc.UpdateRequest{
-			Id:   testPolicyGroupID,
-			Urn:  testPolicyGroupURN,
-			Olds: newPolicyGroupInput().buildStruct(t),
-			News: newPolicyGroupInput().withStacks(stack1).buildStruct(t),
-		}
-
-		_, err := provider.Update(req)
+		}, newPolicyGroupInput(), newPolicyGroupInput().withStacks(stack1))
 		assert.ErrorContains(t, err, "failed to read policy group after update: read error")
 	})
 
 	t.Run("returns updated state from API response", func(t *testing.T) {
-		provider := PulumiServicePolicyGroupResource{
-			Client: &PolicyGroupClientMock{
-				batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, _ []pulumiapi.UpdatePolicyGroupRequest) error {
-					return nil
-				},
-				getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-					// API includes auto-added child account
-					return newMockPolicyGroup().withAccounts(parentAccount, childAccount).build(), nil
-				},
+		resp, err := update(t, &PolicyGroupClientMock{
+			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+				// API includes auto-added child account
+				return newMockPolicyGroup().withAccounts(parentAccount, childAccount).build(), nil
 			},
-		}
-
-		req := &pulumirpc.UpdateRequest{
-			Id:   testPolicyGroupID,
-			Urn:  testPolicyGroupURN,
-			Olds: newPolicyGroupInput().withEntityType(gcAccounts).buildStruct(t),
-			News: newPolicyGroupInput().withEntityType(gcAccounts).withAccounts(parentAccount).buildStruct(t),
-		}
-
-		resp, err := provider.Update(req)
+		},
+			newPolicyGroupInput().withEntityType(gcAccounts),
+			newPolicyGroupInput().withEntityType(gcAccounts).withAccounts(parentAccount))
 		require.NoError(t, err)
-		require.NotNil(t, resp)
-		require.NotNil(t, resp.Properties)
-
-		propsMap := resource.PropertyMap{}
-		for k, v := range resp.Properties.GetFields() {
-			propsMap[resource.PropertyKey(k)] = resource.NewPropertyValue(v.AsInterface())
-		}
 
-		accounts := propsMap[gcAccounts].ArrayValue()
-		require.Len(t, accounts, 2)
-
-		accountNames := make([]string, len(accounts))
-		for i, a := range accounts {
-			accountNames[i] = a.StringValue()
-		}
-		assert.Contains(t, accountNames, parentAccount)
-		assert.Contains(t, accountNames, childAccount)
+		assert.ElementsMatch(t, []string{parentAccount, childAccount}, resp.Output.Accounts)
 	})
 }
 
@@ -1672,50 +1175,47 @@ func TestPolicyGroup_Update_PartialFailureSurfacesActualState(t *testing.T) {
 	// First batched single-op call (remove pp1) succeeds; second (add pp2) fails.
 	// The handler should hit GetPolicyGroup to report the post-failure state.
 	var calls int
-	provider := PulumiServicePolicyGroupResource{
-		Client: &PolicyGroupClientMock{
-			batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, _ []pulumiapi.UpdatePolicyGroupRequest) error {
-				calls++
-				if calls == 1 {
-					return nil
-				}
-				return errors.New("backend reorder/upsert bug fired")
-			},
-			getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
-				// pp1 removed but pp2 not yet added — actual state diverges from desired.
-				return newMockPolicyGroup().withStacks(stack1).build(), nil
-			},
+	client := &PolicyGroupClientMock{
+		batchUpdatePolicyGroupFunc: func(_ context.Context, _, _ string, _ []pulumiapi.UpdatePolicyGroupRequest) error {
+			calls++
+			if calls == 1 {
+				return nil
+			}
+			return errors.New("backend reorder/upsert bug fired")
+		},
+		getPolicyGroupFunc: func() (*pulumiapi.PolicyGroup, error) {
+			// pp1 removed but pp2 not yet added — actual state diverges from desired.
+			return newMockPolicyGroup().withStacks(stack1).build(), nil
 		},
 	}
 
-	req := &pulumirpc.UpdateRequest{
-		Id:   testPolicyGroupID,
-		Urn:  testPolicyGroupURN,
-		Olds: newPolicyGroupInput().withStacks(stack1).withPolicyPacks(pp1).buildStruct(t),
-		News: newPolicyGroupInput().withStacks(stack1).withPolicyPacks(pp2).buildStruct(t),
-	}
+	resp, err := (&PolicyGroup{}).Update(config.WithMockClient(t.Context(), client),
+		infer.UpdateRequest[PolicyGroupInput, PolicyGroupState]{
+			ID:     testPolicyGroupID,
+			Inputs: newPolicyGroupInput().withStacks(stack1).withPolicyPacks(pp2).input(),
+			State:  newPolicyGroupInput().withStacks(stack1).withPolicyPacks(pp1).state(),
+		})
 
-	resp, err := provider.Update(req)
-	require.Error(t, err, "should surface the partial failure")
-	assert.Nil(t, resp)
-	// The error should be wrapped via partialErrorPolicyGroup, which embeds the
-	// actual cloud-side state so the engine can checkpoint correctly.
-	assert.Contains(t, err.Error(), "failed to update policy group")
+	// The failure is partial: the actual cloud-side state is returned with it
+	// so the engine can checkpoint correctly.
+	var initErr infer.ResourceInitFailedError
+	require.ErrorAs(t, err, &initErr, "should surface the partial failure")
+	assert.Contains(t, initErr.Reasons[0], "failed to update policy group")
+	assert.Equal(t, newPolicyGroupState(gcTestOrg, newMockPolicyGroup().withStacks(stack1).build()), resp.Output)
 }
 
-// Test the refactored PolicyGroup serialization with complex policy pack configs
+// Test the PolicyGroup state conversions with complex policy pack configs
 func TestPolicyGroupSerializationConsistency(t *testing.T) {
-	// Create a policy group input with complex config
-	input := PulumiServicePolicyGroupInput{
-		Name:             "test-group",
-		OrganizationName: gcTestOrg,
-		EntityType:       gcStacks,
-		Mode:             gcAudit,
+	// A policy group as reported by the API, with a complex config
+	policyGroup := &pulumiapi.PolicyGroup{
+		Name:       "test-group",
+		EntityType: gcStacks,
+		Mode:       gcAudit,
 		Stacks: []pulumiapi.StackReference{
 			{Name: "stack1", RoutingProject: "project1"},
 			{Name: "stack2", RoutingProject: "project2"},
 		},
-		PolicyPacks: []pulumiapi.PolicyPackMetadata{
+		AppliedPolicyPacks: []pulumiapi.PolicyPackMetadata{
 			{
 				Name:        "aws-compliance",
 				DisplayName: "AWS Compliance Pack",
@@ -1733,29 +1233,24 @@ func TestPolicyGroupSerializationConsistency(t *testing.T) {
 		},
 	}
 
-	// Convert to PropertyMap using refactored method
-	propertyMap := input.ToPropertyMap()
-
-	// Convert back using refactored method
-	roundtripInput := ToPulumiServicePolicyGroupInput(propertyMap)
+	// Convert to state, then back to the API's terms
+	state := newPolicyGroupState(gcTestOrg, policyGroup)
+	spec := state.spec()
 
 	// Verify all fields are preserved correctly
-	assert.Equal(t, input.Name, roundtripInput.Name)
-	assert.Equal(t, input.OrganizationName, roundtripInput.OrganizationName)
-	assert.Equal(t, input.EntityType, roundtripInput.EntityType)
-	assert.Equal(t, input.Mode, roundtripInput.Mode)
-	assert.Equal(t, input.Stacks, roundtripInput.Stacks)
+	assert.Equal(t, policyGroup.Name, spec.Name)
+	assert.Equal(t, gcTestOrg, spec.OrganizationName)
+	assert.Equal(t, policyGroup.EntityType, spec.EntityType)
+	assert.Equal(t, policyGroup.Mode, spec.Mode)
+	assert.Equal(t, policyGroup.Stacks, spec.Stacks)
 
 	// Verify policy pack details including complex config
-	assert.Len(t, roundtripInput.PolicyPacks, 1)
-	pp := roundtripInput.PolicyPacks[0]
-	assert.Equal(t, input.PolicyPacks[0].Name, pp.Name)
-	assert.Equal(t, input.PolicyPacks[0].DisplayName, pp.DisplayName)
-	assert.Equal(t, input.PolicyPacks[0].Version, pp.Version)
-	assert.Equal(t, input.PolicyPacks[0].VersionTag, pp.VersionTag)
-
-	// Verify complex config is preserved
-	originalConfig := input.PolicyPacks[0].Config
-	roundtripConfig := pp.Config
-	assert.Equal(t, originalConfig, roundtripConfig)
+	assert.Equal(t, policyGroup.AppliedPolicyPacks, spec.PolicyPacks)
+
+	// Inputs rendered from the state describe the same group, less the
+	// output-only version
+	input := policyGroupInputFromState(state)
+	inputSpec := input.spec()
+	assert.True(t, policyGroupInputsEqual(spec, inputSpec))
+	assert.Equal(t, 0, inputSpec.PolicyPacks[0].Version)
 }
@@ -0,0 +1,40 @@
+// Copyright 2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+)
+
+type rawInputsKey struct{}
+
+type rawInputsValue struct {
+	olds, news property.Map
+}
+
+// WithRawInputs attaches the untyped old and new inputs of a Diff, Create or
+// Update call to ctx. infer decodes inputs into typed structs, which drops
+// the secret markers of individual map entries and hides the old inputs from
+// Diff; resources that depend on either read them back with rawInputs.
+func WithRawInputs(ctx context.Context, olds, news property.Map) context.Context {
+	return context.WithValue(ctx, rawInputsKey{}, rawInputsValue{olds: olds, news: news})
+}
+
+func rawInputs(ctx context.Context) (olds, news property.Map, ok bool) {
+	v, ok := ctx.Value(rawInputsKey{}).(rawInputsValue)
+	return v.olds, v.news, ok
+}
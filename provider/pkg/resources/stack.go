@@ -17,50 +17,200 @@ package resources
 import (
 	"context"
 	"fmt"
+	"maps"
+	"reflect"
 	"strings"
 
+	p "github.com/pulumi/pulumi-go-provider"
 	"github.com/pulumi/pulumi-go-provider/infer"
 
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
 )
 
+const (
+	gcStackName                 = "stackName"
+	gcForceDestroy              = "forceDestroy"
+	gcAllowOrganizationTransfer = "allowOrganizationTransfer"
+	gcConfig                    = "config"
+	gcTags                      = "tags"
+	gcNotificationSettings      = "notificationSettings"
+	gcOwner                     = "owner"
+)
+
 type Stack struct{}
 
 var (
 	_ infer.CustomCreate[StackInput, StackState] = &Stack{}
 	_ infer.CustomDelete[StackState]             = &Stack{}
 	_ infer.CustomRead[StackInput, StackState]   = &Stack{}
+	_ infer.CustomDiff[StackInput, StackState]   = &Stack{}
+	_ infer.CustomUpdate[StackInput, StackState] = &Stack{}
 )
 
 func (*Stack) Annotate(a infer.Annotator) {
 	a.Describe(
 		&Stack{},
 		"A stack is a collection of resources that share a common lifecycle. "+
-			"Stacks are uniquely identified by their name and the project they belong to.",
+			"Stacks are uniquely identified by their name and the project they belong to.\n\n"+
+			"Besides creating the stack, the resource can link its config to an ESC environment, manage its tags, "+
+			"notification settings and owner, so a stack can be provisioned fully configured in one resource. "+
+			"Only the tags declared in `tags` are managed; tags added elsewhere are left alone.",
 	)
 	a.SetToken("index", "Stack")
 }
 
 type StackInput struct {
-	OrganizationName string `pulumi:"organizationName" provider:"replaceOnChanges"`
-	ProjectName      string `pulumi:"projectName"      provider:"replaceOnChanges"`
-	StackName        string `pulumi:"stackName"        provider:"replaceOnChanges"`
-	ForceDestroy     bool   `pulumi:"forceDestroy,optional" provider:"replaceOnChanges"`
+	OrganizationName          string `pulumi:"organizationName"`
+	ProjectName               string `pulumi:"projectName"`
+	StackName                 string `pulumi:"stackName"`
+	ForceDestroy              bool   `pulumi:"forceDestroy,optional"`
+	AllowOrganizationTransfer bool   `pulumi:"allowOrganizationTransfer,optional"`
+
+	Config               *StackConfig               `pulumi:"config,optional"`
+	Tags                 map[string]string          `pulumi:"tags,optional"`
+	NotificationSettings *StackNotificationSettings `pulumi:"notificationSettings,optional"`
+	Owner                string                     `pulumi:"owner,optional"`
 }
 
 func (i *StackInput) Annotate(a infer.Annotator) {
-	a.Describe(&i.OrganizationName, "The name of the organization.")
-	a.Describe(&i.ProjectName, "The name of the project.")
-	a.Describe(&i.StackName, "The name of the stack.")
+	a.Describe(
+		&i.OrganizationName,
+		"The name of the organization. Changing it replaces the stack unless "+
+			"`allowOrganizationTransfer` is set.",
+	)
+	a.Describe(
+		&i.ProjectName,
+		"The name of the project. Changing it moves the stack to that project in place, keeping its history.",
+	)
+	a.Describe(&i.StackName, "The name of the stack. Changing it renames the stack in place, keeping its history.")
 	a.Describe(
 		&i.ForceDestroy,
 		"Optional. Flag indicating whether to delete the stack even if it still contains resources.",
 	)
+	a.Describe(
+		&i.AllowOrganizationTransfer,
+		"Optional. Flag allowing a change of `organizationName` to transfer the stack, with its history, "+
+			"to the new organization instead of replacing it. The caller must be an admin of both organizations.",
+	)
+	a.Describe(
+		&i.Config,
+		"Optional. Service-managed config for the stack. Removing it unlinks the ESC environment, and the CLI "+
+			"falls back to the local config file.",
+	)
+	a.Describe(&i.Tags, "Optional. Map of tag names to values to set on the stack.")
+	a.Describe(&i.NotificationSettings, "Optional. Which update results notify the stack's subscribers.")
+	a.Describe(&i.Owner, "Optional. The login of the Pulumi Cloud user who owns the stack.")
 }
 
 type StackState struct {
 	StackInput
+	EncryptedConfigSecrets map[string]string `pulumi:"encryptedConfigSecrets,optional" provider:"secret"`
+}
+
+func (s *StackState) Annotate(a infer.Annotator) {
+	a.Describe(
+		&s.EncryptedConfigSecrets,
+		"The values of `config.secrets`, encrypted with the stack's key and base64-encoded, by config key.",
+	)
+}
+
+func (s StackState) identifier() pulumiapi.StackIdentifier {
+	return pulumiapi.StackIdentifier{
+		OrgName:     s.OrganizationName,
+		ProjectName: s.ProjectName,
+		StackName:   s.StackName,
+	}
+}
+
+// Diff renames a stack or moves it between projects in place; only an
+// organization change replaces it, and only when the transfer isn't opted into.
+func (*Stack) Diff(_ context.Context, req infer.DiffRequest[StackInput, StackState]) (infer.DiffResponse, error) {
+	diff := map[string]p.PropertyDiff{}
+	update := func(key string) { diff[key] = p.PropertyDiff{Kind: p.Update, InputDiff: true} }
+	if req.State.OrganizationName != req.Inputs.OrganizationName {
+		if req.Inputs.AllowOrganizationTransfer {
+			update(gcOrganizationName)
+		} else {
+			diff[gcOrganizationName] = p.PropertyDiff{Kind: p.UpdateReplace, InputDiff: true}
+		}
+	}
+	if req.State.ProjectName != req.Inputs.ProjectName {
+		update(gcProjectName)
+	}
+	if req.State.StackName != req.Inputs.StackName {
+		update(gcStackName)
+	}
+	if req.State.ForceDestroy != req.Inputs.ForceDestroy {
+		update(gcForceDestroy)
+	}
+	if req.State.AllowOrganizationTransfer != req.Inputs.AllowOrganizationTransfer {
+		update(gcAllowOrganizationTransfer)
+	}
+	if !req.State.Config.equal(req.Inputs.Config) {
+		update(gcConfig)
+	}
+	if !maps.Equal(req.State.Tags, req.Inputs.Tags) {
+		update(gcTags)
+	}
+	if !reflect.DeepEqual(req.State.NotificationSettings, req.Inputs.NotificationSettings) {
+		update(gcNotificationSettings)
+	}
+	if req.State.Owner != req.Inputs.Owner {
+		update(gcOwner)
+	}
+	return infer.DiffResponse{
+		HasChanges:   len(diff) > 0,
+		DetailedDiff: diff,
+	}, nil
+}
+
+// Update renames the stack within its organization first, then transfers it
+// when the organization changed, then applies the settings that changed. The
+// resource ID keeps the identity the stack was created with; the state tracks
+// where it lives now.
+func (*Stack) Update(
+	ctx context.Context,
+	req infer.UpdateRequest[StackInput, StackState],
+) (infer.UpdateResponse[StackState], error) {
+	if req.DryRun {
+		preview := req.State
+		preview.StackInput = req.Inputs
+		return infer.UpdateResponse[StackState]{Output: preview}, nil
+	}
+	client := config.GetClient(ctx)
+	current := req.State
+	current.ForceDestroy = req.Inputs.ForceDestroy
+	current.AllowOrganizationTransfer = req.Inputs.AllowOrganizationTransfer
+
+	if current.ProjectName != req.Inputs.ProjectName || current.StackName != req.Inputs.StackName {
+		stackID := current.identifier()
+		err := client.RenameStack(ctx, stackID, req.Inputs.ProjectName, req.Inputs.StackName)
+		if err != nil {
+			return infer.UpdateResponse[StackState]{}, fmt.Errorf("error renaming stack %q: %w", stackID, err)
+		}
+		current.ProjectName, current.StackName = req.Inputs.ProjectName, req.Inputs.StackName
+	}
+
+	if current.OrganizationName != req.Inputs.OrganizationName {
+		stackID := current.identifier()
+		if err := client.TransferStack(ctx, stackID, req.Inputs.OrganizationName); err != nil {
+			// A rename that already went through must stay in state.
+			return infer.UpdateResponse[StackState]{Output: current}, infer.ResourceInitFailedError{
+				Reasons: []string{fmt.Sprintf(
+					"error transferring stack %q to organization %q: %v", stackID, req.Inputs.OrganizationName, err,
+				)},
+			}
+		}
+		current.OrganizationName = req.Inputs.OrganizationName
+	}
+
+	if err := applyStackSettings(ctx, &current, req.Inputs); err != nil {
+		return infer.UpdateResponse[StackState]{Output: current}, infer.ResourceInitFailedError{
+			Reasons: []string{fmt.Sprintf("error updating stack %q: %v", current.identifier(), err)},
+		}
+	}
+	return infer.UpdateResponse[StackState]{Output: current}, nil
 }
 
 func (*Stack) Create(
@@ -82,9 +232,25 @@ func (*Stack) Create(
 			"error creating stack %q: %w", stackID, err,
 		)
 	}
+
+	// The settings start from a bare stack, so the state only claims those
+	// that were applied.
+	output := StackState{StackInput: StackInput{
+		OrganizationName:          req.Inputs.OrganizationName,
+		ProjectName:               req.Inputs.ProjectName,
+		StackName:                 req.Inputs.StackName,
+		ForceDestroy:              req.Inputs.ForceDestroy,
+		AllowOrganizationTransfer: req.Inputs.AllowOrganizationTransfer,
+	}}
+	if err := applyStackSettings(ctx, &output, req.Inputs); err != nil {
+		return infer.CreateResponse[StackState]{ID: stackResourceID(stackID), Output: output},
+			infer.ResourceInitFailedError{
+				Reasons: []string{fmt.Sprintf("error configuring stack %q: %v", stackID, err)},
+			}
+	}
 	return infer.CreateResponse[StackState]{
 		ID:     stackResourceID(stackID),
-		Output: StackState{StackInput: req.Inputs},
+		Output: output,
 	}, nil
 }
 
@@ -92,48 +258,61 @@ func (*Stack) Delete(
 	ctx context.Context,
 	req infer.DeleteRequest[StackState],
 ) (infer.DeleteResponse, error) {
-	stackID := pulumiapi.StackIdentifier{
-		OrgName:     req.State.OrganizationName,
-		ProjectName: req.State.ProjectName,
-		StackName:   req.State.StackName,
-	}
-	return infer.DeleteResponse{}, config.GetClient(ctx).DeleteStack(ctx, stackID, req.State.ForceDestroy)
+	return infer.DeleteResponse{}, config.GetClient(ctx).DeleteStack(ctx, req.State.identifier(), req.State.ForceDestroy)
 }
 
 func (*Stack) Read(
 	ctx context.Context,
 	req infer.ReadRequest[StackInput, StackState],
 ) (infer.ReadResponse[StackInput, StackState], error) {
-	orgName, projectName, stackName, err := splitStackResourceID(req.ID)
-	if err != nil {
-		return infer.ReadResponse[StackInput, StackState]{}, err
-	}
-	stackID := pulumiapi.StackIdentifier{
-		OrgName:     orgName,
-		ProjectName: projectName,
-		StackName:   stackName,
+	// After an in-place rename or transfer the ID still names the original
+	// stack, so prefer the identity recorded in state; imports only have the ID.
+	stackID := req.State.identifier()
+	if stackID.OrgName == "" || stackID.ProjectName == "" || stackID.StackName == "" {
+		orgName, projectName, stackName, err := splitStackResourceID(req.ID)
+		if err != nil {
+			return infer.ReadResponse[StackInput, StackState]{}, err
+		}
+		stackID = pulumiapi.StackIdentifier{
+			OrgName:     orgName,
+			ProjectName: projectName,
+			StackName:   stackName,
+		}
 	}
 	exists, err := config.GetClient(ctx).StackExists(ctx, stackID)
 	if err != nil {
 		return infer.ReadResponse[StackInput, StackState]{}, fmt.Errorf(
-			"failure while checking if stack %q exists: %w", req.ID, err,
+			"failure while checking if stack %q exists: %w", stackID, err,
 		)
 	}
 	if !exists {
 		return infer.ReadResponse[StackInput, StackState]{}, nil
 	}
-	inputs := StackInput{
-		OrganizationName: orgName,
-		ProjectName:      projectName,
-		StackName:        stackName,
-		// forceDestroy is a write-only delete-time hint that does not round-trip
-		// through the Pulumi Cloud API; preserve whatever the user configured.
-		ForceDestroy: req.Inputs.ForceDestroy,
+	state := req.State
+	state.StackInput = StackInput{
+		OrganizationName: stackID.OrgName,
+		ProjectName:      stackID.ProjectName,
+		StackName:        stackID.StackName,
+		// forceDestroy and allowOrganizationTransfer are write-only hints that
+		// do not round-trip through the Pulumi Cloud API; preserve whatever the
+		// user configured.
+		ForceDestroy:              req.Inputs.ForceDestroy,
+		AllowOrganizationTransfer: req.Inputs.AllowOrganizationTransfer,
+
+		Config:               req.State.Config,
+		Tags:                 req.State.Tags,
+		NotificationSettings: req.State.NotificationSettings,
+		Owner:                req.State.Owner,
+	}
+	if err := readStackSettings(ctx, stackID, &state); err != nil {
+		return infer.ReadResponse[StackInput, StackState]{}, fmt.Errorf(
+			"failure while reading settings of stack %q: %w", stackID, err,
+		)
 	}
 	return infer.ReadResponse[StackInput, StackState]{
 		ID:     req.ID,
-		Inputs: inputs,
-		State:  StackState{StackInput: inputs},
+		Inputs: state.StackInput,
+		State:  state,
 	}, nil
 }
 
@@ -0,0 +1,222 @@
+// Copyright 2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+// The optional settings a Stack manages beyond its identity. Each is diffed
+// and applied on its own. Dropping config or a tag from the program removes
+// it from the stack; dropping notification settings or the owner leaves the
+// stack's current values as they are, since there is nothing to reset them to.
+
+type StackConfig struct {
+	Environment string            `pulumi:"environment"`
+	Secrets     map[string]string `pulumi:"secrets,optional" provider:"secret"`
+}
+
+func (c *StackConfig) Annotate(a infer.Annotator) {
+	a.Describe(
+		&c.Environment,
+		"The ESC environment the stack reads its configuration from, as `project/environment`, optionally "+
+			"pinned with `@version`. The Pulumi CLI uses it in place of the local `Pulumi.<stack>.yaml`.",
+	)
+	a.Describe(
+		&c.Secrets,
+		"Secret config values to encrypt with the stack's encryption key. The ciphertexts are exposed as "+
+			"`encryptedConfigSecrets`, ready to use as `secure:` values in the stack's config.",
+	)
+}
+
+// equal reports whether c and o configure the stack alike. No secrets and
+// an empty map of them are the same.
+func (c *StackConfig) equal(o *StackConfig) bool {
+	if c == nil || o == nil {
+		return c == o
+	}
+	return c.Environment == o.Environment && maps.Equal(c.Secrets, o.Secrets)
+}
+
+type StackNotificationSettings struct {
+	NotifyUpdateFailure bool `pulumi:"notifyUpdateFailure,optional"`
+	NotifyUpdateSuccess bool `pulumi:"notifyUpdateSuccess,optional"`
+}
+
+func (s *StackNotificationSettings) Annotate(a infer.Annotator) {
+	a.Describe(&s.NotifyUpdateFailure, "Whether to notify the stack's subscribers when an update fails.")
+	a.Describe(&s.NotifyUpdateSuccess, "Whether to notify the stack's subscribers when an update succeeds.")
+}
+
+// applyStackSettings brings the settings recorded in current in line with
+// inputs, updating current as each step lands. Config goes first and
+// ownership last, since handing the stack to another user may take away the
+// caller's access to it.
+func applyStackSettings(ctx context.Context, current *StackState, inputs StackInput) error {
+	client := config.GetClient(ctx)
+	stack := current.identifier()
+
+	if err := applyStackConfig(ctx, client, stack, current, inputs.Config); err != nil {
+		return err
+	}
+
+	tags, err := syncStackTags(ctx, client, stack, current.Tags, inputs.Tags)
+	if len(tags) == 0 {
+		tags = nil
+	}
+	current.Tags = tags
+	if err != nil {
+		return err
+	}
+
+	if settings := inputs.NotificationSettings; settings != nil &&
+		(current.NotificationSettings == nil || *current.NotificationSettings != *settings) {
+		err := client.UpdateStackNotificationSettings(ctx, stack, pulumiapi.StackNotificationSettings{
+			NotifyUpdateFailure: settings.NotifyUpdateFailure,
+			NotifyUpdateSuccess: settings.NotifyUpdateSuccess,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	current.NotificationSettings = inputs.NotificationSettings
+
+	if inputs.Owner != "" && inputs.Owner != current.Owner {
+		if err := client.ReassignStackOwnership(ctx, stack, inputs.Owner); err != nil {
+			return err
+		}
+	}
+	current.Owner = inputs.Owner
+	return nil
+}
+
+// applyStackConfig links or unlinks the stack's ESC environment and
+// re-encrypts the secrets that changed. Dropping config from the program
+// removes the service-managed config, returning the stack to its local file.
+func applyStackConfig(
+	ctx context.Context, client config.Client, stack pulumiapi.StackIdentifier, current *StackState, cfg *StackConfig,
+) error {
+	if cfg == nil {
+		if current.Config != nil {
+			if err := client.DeleteStackConfig(ctx, stack); err != nil {
+				return err
+			}
+		}
+		current.Config, current.EncryptedConfigSecrets = nil, nil
+		return nil
+	}
+
+	applied := &StackConfig{}
+	if current.Config != nil {
+		applied = &StackConfig{Environment: current.Config.Environment, Secrets: maps.Clone(current.Config.Secrets)}
+	}
+	if current.Config == nil || current.Config.Environment != cfg.Environment {
+		err := client.UpdateStackConfig(ctx, stack, pulumiapi.StackConfig{Environment: cfg.Environment})
+		if err != nil {
+			return err
+		}
+		applied.Environment = cfg.Environment
+		current.Config = applied
+	}
+
+	encrypted := maps.Clone(current.EncryptedConfigSecrets)
+	for key := range encrypted {
+		if _, ok := cfg.Secrets[key]; !ok {
+			delete(encrypted, key)
+			delete(applied.Secrets, key)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(cfg.Secrets)) {
+		value := cfg.Secrets[key]
+		if _, ok := encrypted[key]; ok && applied.Secrets[key] == value {
+			continue
+		}
+		ciphertext, err := client.EncryptStackValue(ctx, stack, []byte(value))
+		if err != nil {
+			current.Config, current.EncryptedConfigSecrets = applied, encrypted
+			return fmt.Errorf("failed to encrypt config secret %q: %w", key, err)
+		}
+		if encrypted == nil {
+			encrypted = map[string]string{}
+		}
+		if applied.Secrets == nil {
+			applied.Secrets = map[string]string{}
+		}
+		encrypted[key] = base64.StdEncoding.EncodeToString(ciphertext)
+		applied.Secrets[key] = value
+	}
+	current.Config, current.EncryptedConfigSecrets = applied, encrypted
+	return nil
+}
+
+// readStackSettings refreshes the settings the resource already manages.
+// Settings absent from state are not adopted, so an imported stack doesn't
+// start managing (and on removal, clearing) values nobody declared.
+func readStackSettings(ctx context.Context, stack pulumiapi.StackIdentifier, state *StackState) error {
+	client := config.GetClient(ctx)
+
+	if state.Config != nil {
+		live, err := client.GetStackConfig(ctx, stack)
+		if err != nil {
+			return err
+		}
+		if live == nil {
+			state.Config, state.EncryptedConfigSecrets = nil, nil
+		} else {
+			// Secrets only exist as the ciphertexts in state; keep them.
+			state.Config = &StackConfig{Environment: live.Environment, Secrets: state.Config.Secrets}
+		}
+	}
+
+	if len(state.Tags) > 0 {
+		live, err := client.GetStackTags(ctx, stack)
+		if err != nil {
+			return err
+		}
+		tags := map[string]string{}
+		for name := range state.Tags {
+			if value, ok := live[name]; ok {
+				tags[name] = value
+			}
+		}
+		state.Tags = tags
+	}
+
+	if state.NotificationSettings != nil || state.Owner != "" {
+		metadata, err := client.GetStackMetadata(ctx, stack)
+		if err != nil {
+			return err
+		}
+		if state.NotificationSettings != nil {
+			state.NotificationSettings = &StackNotificationSettings{
+				NotifyUpdateFailure: metadata.NotificationSettings.NotifyUpdateFailure,
+				NotifyUpdateSuccess: metadata.NotificationSettings.NotifyUpdateSuccess,
+			}
+		}
+		if state.Owner != "" {
+			state.Owner = metadata.Owner
+		}
+	}
+	return nil
+}
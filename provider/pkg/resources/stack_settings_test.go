@@ -0,0 +1,276 @@
+// Copyright 2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+// stackSettingsMock keeps one stack's settings in memory and logs each
+// mutating call.
+type stackSettingsMock struct {
+	config.Client
+	calls    []string
+	config   *pulumiapi.StackConfig
+	tags     map[string]string
+	metadata pulumiapi.StackMetadata
+	ownerErr error
+}
+
+func (c *stackSettingsMock) CreateStack(context.Context, pulumiapi.StackIdentifier) error {
+	c.calls = append(c.calls, "CreateStack")
+	return nil
+}
+
+func (c *stackSettingsMock) StackExists(context.Context, pulumiapi.StackIdentifier) (bool, error) {
+	return true, nil
+}
+
+func (c *stackSettingsMock) GetStackConfig(context.Context, pulumiapi.StackIdentifier) (*pulumiapi.StackConfig, error) {
+	return c.config, nil
+}
+
+func (c *stackSettingsMock) UpdateStackConfig(
+	_ context.Context, _ pulumiapi.StackIdentifier, cfg pulumiapi.StackConfig,
+) error {
+	c.calls = append(c.calls, "UpdateStackConfig "+cfg.Environment)
+	c.config = &cfg
+	return nil
+}
+
+func (c *stackSettingsMock) DeleteStackConfig(context.Context, pulumiapi.StackIdentifier) error {
+	c.calls = append(c.calls, "DeleteStackConfig")
+	c.config = nil
+	return nil
+}
+
+func (c *stackSettingsMock) EncryptStackValue(
+	_ context.Context, _ pulumiapi.StackIdentifier, plaintext []byte,
+) ([]byte, error) {
+	c.calls = append(c.calls, "EncryptStackValue "+string(plaintext))
+	return append([]byte("enc:"), plaintext...), nil
+}
+
+func (c *stackSettingsMock) GetStackTags(context.Context, pulumiapi.StackIdentifier) (map[string]string, error) {
+	return c.tags, nil
+}
+
+func (c *stackSettingsMock) CreateStackTag(
+	_ context.Context, _ pulumiapi.StackIdentifier, tag pulumiapi.StackTag,
+) error {
+	c.calls = append(c.calls, "CreateStackTag "+tag.Name)
+	if c.tags == nil {
+		c.tags = map[string]string{}
+	}
+	c.tags[tag.Name] = tag.Value
+	return nil
+}
+
+func (c *stackSettingsMock) DeleteStackTag(_ context.Context, _ pulumiapi.StackIdentifier, name string) error {
+	c.calls = append(c.calls, "DeleteStackTag "+name)
+	delete(c.tags, name)
+	return nil
+}
+
+func (c *stackSettingsMock) GetStackMetadata(
+	context.Context, pulumiapi.StackIdentifier,
+) (*pulumiapi.StackMetadata, error) {
+	c.calls = append(c.calls, "GetStackMetadata")
+	return &c.metadata, nil
+}
+
+func (c *stackSettingsMock) UpdateStackNotificationSettings(
+	_ context.Context, _ pulumiapi.StackIdentifier, settings pulumiapi.StackNotificationSettings,
+) error {
+	c.calls = append(c.calls, "UpdateStackNotificationSettings")
+	c.metadata.NotificationSettings = settings
+	return nil
+}
+
+func (c *stackSettingsMock) ReassignStackOwnership(
+	_ context.Context, _ pulumiapi.StackIdentifier, githubLogin string,
+) error {
+	c.calls = append(c.calls, "ReassignStackOwnership "+githubLogin)
+	if c.ownerErr != nil {
+		return c.ownerErr
+	}
+	c.metadata.Owner = githubLogin
+	return nil
+}
+
+func configuredStack() StackInput {
+	input := stackInput(gcMyOrg, gcMyProject, "dev")
+	input.Config = &StackConfig{
+		Environment: "my-project/dev",
+		Secrets:     map[string]string{"dbPassword": "hunter2"},
+	}
+	input.Tags = map[string]string{"team": "platform"}
+	input.NotificationSettings = &StackNotificationSettings{NotifyUpdateFailure: true}
+	input.Owner = "octocat"
+	return input
+}
+
+func TestStackCreateWithSettings(t *testing.T) {
+	t.Run("applies every setting", func(t *testing.T) {
+		client := &stackSettingsMock{}
+		ctx := config.WithMockClient(context.Background(), client)
+		resp, err := (&Stack{}).Create(ctx, infer.CreateRequest[StackInput]{Inputs: configuredStack()})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"CreateStack",
+			"UpdateStackConfig my-project/dev",
+			"EncryptStackValue hunter2",
+			"CreateStackTag team",
+			"UpdateStackNotificationSettings",
+			"ReassignStackOwnership octocat",
+		}, client.calls)
+		assert.Equal(t, configuredStack(), resp.Output.StackInput)
+		assert.Equal(t, map[string]string{
+			"dbPassword": base64.StdEncoding.EncodeToString([]byte("enc:hunter2")),
+		}, resp.Output.EncryptedConfigSecrets)
+	})
+
+	t.Run("a failed step leaves it out of state", func(t *testing.T) {
+		client := &stackSettingsMock{ownerErr: errors.New("no such user")}
+		ctx := config.WithMockClient(context.Background(), client)
+		resp, err := (&Stack{}).Create(ctx, infer.CreateRequest[StackInput]{Inputs: configuredStack()})
+		var initErr infer.ResourceInitFailedError
+		require.ErrorAs(t, err, &initErr)
+		assert.Equal(t, "my-org/my-project/dev", resp.ID)
+		assert.Empty(t, resp.Output.Owner)
+		assert.Equal(t, configuredStack().Tags, resp.Output.Tags, "earlier steps stay applied")
+	})
+}
+
+func TestStackUpdateSettings(t *testing.T) {
+	created := func(t *testing.T, client *stackSettingsMock) StackState {
+		ctx := config.WithMockClient(context.Background(), client)
+		resp, err := (&Stack{}).Create(ctx, infer.CreateRequest[StackInput]{Inputs: configuredStack()})
+		require.NoError(t, err)
+		client.calls = nil
+		return resp.Output
+	}
+	update := func(t *testing.T, client *stackSettingsMock, olds StackState, news StackInput) StackState {
+		ctx := config.WithMockClient(context.Background(), client)
+		resp, err := (&Stack{}).Update(ctx, infer.UpdateRequest[StackInput, StackState]{
+			ID: "my-org/my-project/dev", State: olds, Inputs: news,
+		})
+		require.NoError(t, err)
+		return resp.Output
+	}
+
+	t.Run("only changed settings are applied", func(t *testing.T) {
+		client := &stackSettingsMock{}
+		olds := created(t, client)
+		news := configuredStack()
+		news.Config.Secrets = map[string]string{"dbPassword": "hunter2", "apiKey": "s3cret"}
+		news.Tags = map[string]string{"env": "dev"}
+
+		out := update(t, client, olds, news)
+		assert.Equal(t, []string{
+			"EncryptStackValue s3cret",
+			"DeleteStackTag team",
+			"CreateStackTag env",
+		}, client.calls)
+		assert.Equal(t, news, out.StackInput)
+		assert.Len(t, out.EncryptedConfigSecrets, 2)
+	})
+
+	t.Run("dropping config unlinks it, dropping the owner keeps it", func(t *testing.T) {
+		client := &stackSettingsMock{}
+		olds := created(t, client)
+		news := configuredStack()
+		news.Config, news.Owner = nil, ""
+
+		out := update(t, client, olds, news)
+		assert.Equal(t, []string{"DeleteStackConfig"}, client.calls)
+		assert.Nil(t, out.Config)
+		assert.Nil(t, out.EncryptedConfigSecrets)
+		assert.Equal(t, "octocat", client.metadata.Owner)
+	})
+}
+
+func TestStackSettingsDiff(t *testing.T) {
+	olds := StackState{StackInput: configuredStack()}
+	for key, mutate := range map[string]func(*StackInput){
+		gcConfig:               func(i *StackInput) { i.Config.Secrets = map[string]string{"dbPassword": "rotated"} },
+		gcTags:                 func(i *StackInput) { i.Tags = nil },
+		gcNotificationSettings: func(i *StackInput) { i.NotificationSettings.NotifyUpdateSuccess = true },
+		gcOwner:                func(i *StackInput) { i.Owner = "hubot" },
+	} {
+		t.Run(key, func(t *testing.T) {
+			news := configuredStack()
+			mutate(&news)
+			resp, err := (&Stack{}).Diff(t.Context(), infer.DiffRequest[StackInput, StackState]{State: olds, Inputs: news})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]p.PropertyDiff{key: {Kind: p.Update, InputDiff: true}}, resp.DetailedDiff)
+		})
+	}
+
+	t.Run("empty secrets", func(t *testing.T) {
+		olds := StackState{StackInput: configuredStack()}
+		olds.Config = &StackConfig{Environment: olds.Config.Environment}
+		news := configuredStack()
+		news.Config = &StackConfig{Environment: news.Config.Environment, Secrets: map[string]string{}}
+		resp, err := (&Stack{}).Diff(t.Context(), infer.DiffRequest[StackInput, StackState]{State: olds, Inputs: news})
+		require.NoError(t, err)
+		assert.False(t, resp.HasChanges)
+	})
+}
+
+func TestStackReadSettings(t *testing.T) {
+	t.Run("refreshes managed settings", func(t *testing.T) {
+		client := &stackSettingsMock{
+			config:   &pulumiapi.StackConfig{Environment: "my-project/changed"},
+			tags:     map[string]string{"team": "other", "unmanaged": "x"},
+			metadata: pulumiapi.StackMetadata{Owner: "hubot"},
+		}
+		ctx := config.WithMockClient(context.Background(), client)
+		olds := StackState{StackInput: configuredStack()}
+		resp, err := (&Stack{}).Read(ctx, infer.ReadRequest[StackInput, StackState]{
+			ID: "my-org/my-project/dev", Inputs: olds.StackInput, State: olds,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "my-project/changed", resp.State.Config.Environment)
+		assert.Equal(t, olds.Config.Secrets, resp.State.Config.Secrets)
+		assert.Equal(t, map[string]string{"team": "other"}, resp.State.Tags)
+		assert.Equal(t, &StackNotificationSettings{}, resp.State.NotificationSettings)
+		assert.Equal(t, "hubot", resp.Inputs.Owner)
+	})
+
+	t.Run("import does not adopt settings", func(t *testing.T) {
+		client := &stackSettingsMock{
+			config: &pulumiapi.StackConfig{Environment: "my-project/dev"},
+			tags:   map[string]string{"team": "platform"},
+		}
+		ctx := config.WithMockClient(context.Background(), client)
+		resp, err := (&Stack{}).Read(ctx, infer.ReadRequest[StackInput, StackState]{ID: "my-org/my-project/dev"})
+		require.NoError(t, err)
+		assert.Equal(t, stackInput(gcMyOrg, gcMyProject, "dev"), resp.Inputs)
+		assert.Empty(t, client.calls)
+	})
+}
@@ -102,21 +102,13 @@ func (*StackTags) Create(
 		StackName:   req.Inputs.Stack,
 	}
 
-	// Create tags in sorted order so partial failures are deterministic.
-	created := map[string]string{}
-	for _, name := range slices.Sorted(maps.Keys(req.Inputs.Tags)) {
-		value := req.Inputs.Tags[name]
-		if err := client.CreateStackTag(ctx, stackIdentifier, pulumiapi.StackTag{Name: name, Value: value}); err != nil {
-			partial := req.Inputs
-			partial.Tags = created
-			return infer.CreateResponse[StackTagsState]{
-					ID:     id,
-					Output: partial,
-				}, infer.ResourceInitFailedError{
-					Reasons: []string{fmt.Sprintf("failed to create tag %q: %s", name, err.Error())},
-				}
-		}
-		created[name] = value
+	if created, err := syncStackTags(ctx, client, stackIdentifier, nil, req.Inputs.Tags); err != nil {
+		partial := req.Inputs
+		partial.Tags = created
+		return infer.CreateResponse[StackTagsState]{
+			ID:     id,
+			Output: partial,
+		}, infer.ResourceInitFailedError{Reasons: []string{err.Error()}}
 	}
 
 	return infer.CreateResponse[StackTagsState]{
@@ -140,61 +132,64 @@ func (*StackTags) Update(
 		StackName:   req.Inputs.Stack,
 	}
 
-	// Compute the add/remove/modify sets. Pulumi Cloud tags are immutable per
-	// key, so a value change is a delete + create.
+	if currentTags, err := syncStackTags(ctx, client, stackIdentifier, req.State.Tags, req.Inputs.Tags); err != nil {
+		// Return the live tag set as the resource state so Pulumi knows
+		// exactly which tags exist.
+		partial := req.Inputs
+		partial.Tags = currentTags
+		return infer.UpdateResponse[StackTagsState]{Output: partial},
+			infer.ResourceInitFailedError{Reasons: []string{err.Error()}}
+	}
+
+	return infer.UpdateResponse[StackTagsState]{Output: req.Inputs}, nil
+}
+
+// syncStackTags moves the managed tags of stack from olds to news, leaving
+// every other tag alone. Pulumi Cloud tags are immutable per key, so a value
+// change is a delete + create. It returns the managed tags that exist when it
+// stops, so a failure part way through still reports which ones were applied.
+func syncStackTags(
+	ctx context.Context, client pulumiapi.StackTagClient, stack pulumiapi.StackIdentifier, olds, news map[string]string,
+) (map[string]string, error) {
 	tagsToDelete := []string{}
 	tagsToCreate := map[string]string{}
 
-	for oldName, oldValue := range req.State.Tags {
-		if newValue, exists := req.Inputs.Tags[oldName]; !exists {
+	for oldName, oldValue := range olds {
+		if newValue, exists := news[oldName]; !exists {
 			tagsToDelete = append(tagsToDelete, oldName)
 		} else if newValue != oldValue {
 			tagsToDelete = append(tagsToDelete, oldName)
 			tagsToCreate[oldName] = newValue
 		}
 	}
-	for newName, newValue := range req.Inputs.Tags {
-		if _, exists := req.State.Tags[newName]; !exists {
+	for newName, newValue := range news {
+		if _, exists := olds[newName]; !exists {
 			tagsToCreate[newName] = newValue
 		}
 	}
 
-	// Track the live tag set as we mutate it. If any API call fails we return
-	// this as the resource state so Pulumi knows exactly which tags exist.
-	currentTags := maps.Clone(req.State.Tags)
+	currentTags := maps.Clone(olds)
 	if currentTags == nil {
 		currentTags = map[string]string{}
 	}
 
-	partialOutput := func() StackTagsState {
-		state := req.Inputs
-		state.Tags = maps.Clone(currentTags)
-		return state
-	}
-
+	// Apply changes in sorted order so partial failures are deterministic.
 	sort.Strings(tagsToDelete)
 	for _, tagName := range tagsToDelete {
-		if err := client.DeleteStackTag(ctx, stackIdentifier, tagName); err != nil {
-			return infer.UpdateResponse[StackTagsState]{Output: partialOutput()},
-				infer.ResourceInitFailedError{
-					Reasons: []string{fmt.Sprintf("failed to delete tag %q: %s", tagName, err.Error())},
-				}
+		if err := client.DeleteStackTag(ctx, stack, tagName); err != nil {
+			return currentTags, fmt.Errorf("failed to delete tag %q: %w", tagName, err)
 		}
 		delete(currentTags, tagName)
 	}
 
 	for _, name := range slices.Sorted(maps.Keys(tagsToCreate)) {
 		value := tagsToCreate[name]
-		if err := client.CreateStackTag(ctx, stackIdentifier, pulumiapi.StackTag{Name: name, Value: value}); err != nil {
-			return infer.UpdateResponse[StackTagsState]{Output: partialOutput()},
-				infer.ResourceInitFailedError{
-					Reasons: []string{fmt.Sprintf("failed to create tag %q: %s", name, err.Error())},
-				}
+		if err := client.CreateStackTag(ctx, stack, pulumiapi.StackTag{Name: name, Value: value}); err != nil {
+			return currentTags, fmt.Errorf("failed to create tag %q: %w", name, err)
 		}
 		currentTags[name] = value
 	}
-
-	return infer.UpdateResponse[StackTagsState]{Output: req.Inputs}, nil
+	return currentTags, nil
 }
 
 func (*StackTags) Delete(
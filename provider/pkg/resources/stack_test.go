@@ -15,14 +15,54 @@
 package resources
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
 )
 
+type StackClientMock struct {
+	config.Client
+	renames     []stackRename
+	transfers   []string
+	renameErr   error
+	transferErr error
+	exists      map[pulumiapi.StackIdentifier]bool
+}
+
+type stackRename struct {
+	From                pulumiapi.StackIdentifier
+	NewProject, NewName string
+}
+
+func (c *StackClientMock) RenameStack(
+	_ context.Context, stack pulumiapi.StackIdentifier, newProject, newName string,
+) error {
+	c.renames = append(c.renames, stackRename{From: stack, NewProject: newProject, NewName: newName})
+	return c.renameErr
+}
+
+func (c *StackClientMock) TransferStack(_ context.Context, stack pulumiapi.StackIdentifier, toOrg string) error {
+	c.transfers = append(c.transfers, stack.String()+" -> "+toOrg)
+	return c.transferErr
+}
+
+func (c *StackClientMock) StackExists(_ context.Context, stack pulumiapi.StackIdentifier) (bool, error) {
+	return c.exists[stack], nil
+}
+
+func stackInput(org, project, name string) StackInput {
+	return StackInput{OrganizationName: org, ProjectName: project, StackName: name}
+}
+
 func TestStackResourceID(t *testing.T) {
 	id := stackResourceID(pulumiapi.StackIdentifier{
 		OrgName:     gcMyOrg,
@@ -51,3 +91,130 @@ func TestSplitStackResourceID(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestStackDiff(t *testing.T) {
+	olds := StackState{StackInput: stackInput(gcMyOrg, gcMyProject, "dev")}
+	diff := func(t *testing.T, news StackInput) infer.DiffResponse {
+		resp, err := (&Stack{}).Diff(t.Context(), infer.DiffRequest[StackInput, StackState]{State: olds, Inputs: news})
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("no changes", func(t *testing.T) {
+		assert.False(t, diff(t, olds.StackInput).HasChanges)
+	})
+
+	t.Run("rename and project move update in place", func(t *testing.T) {
+		resp := diff(t, stackInput(gcMyOrg, "other-project", "prod"))
+		assert.True(t, resp.HasChanges)
+		assert.Equal(t, p.Update, resp.DetailedDiff[gcProjectName].Kind)
+		assert.Equal(t, p.Update, resp.DetailedDiff[gcStackName].Kind)
+	})
+
+	t.Run("organization change replaces without opt-in", func(t *testing.T) {
+		resp := diff(t, stackInput("other-org", gcMyProject, "dev"))
+		assert.Equal(t, p.UpdateReplace, resp.DetailedDiff[gcOrganizationName].Kind)
+	})
+
+	t.Run("organization change updates with opt-in", func(t *testing.T) {
+		news := stackInput("other-org", gcMyProject, "dev")
+		news.AllowOrganizationTransfer = true
+		resp := diff(t, news)
+		assert.Equal(t, p.Update, resp.DetailedDiff[gcOrganizationName].Kind)
+		assert.Equal(t, p.Update, resp.DetailedDiff[gcAllowOrganizationTransfer].Kind)
+	})
+
+	t.Run("forceDestroy updates in place", func(t *testing.T) {
+		news := olds.StackInput
+		news.ForceDestroy = true
+		resp := diff(t, news)
+		assert.Equal(t, map[string]p.PropertyDiff{gcForceDestroy: {Kind: p.Update, InputDiff: true}}, resp.DetailedDiff)
+
+		toggled := olds
+		toggled.ForceDestroy = true
+		resp, err := (&Stack{}).Diff(t.Context(), infer.DiffRequest[StackInput, StackState]{
+			State: toggled, Inputs: olds.StackInput,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, p.Update, resp.DetailedDiff[gcForceDestroy].Kind)
+	})
+}
+
+func TestStackUpdate(t *testing.T) {
+	olds := StackState{StackInput: stackInput(gcMyOrg, gcMyProject, "dev")}
+	update := func(client *StackClientMock, news StackInput) (infer.UpdateResponse[StackState], error) {
+		ctx := config.WithMockClient(context.Background(), client)
+		return (&Stack{}).Update(ctx, infer.UpdateRequest[StackInput, StackState]{
+			ID:     "my-org/my-project/dev",
+			State:  olds,
+			Inputs: news,
+		})
+	}
+
+	t.Run("rename", func(t *testing.T) {
+		client := &StackClientMock{}
+		news := stackInput(gcMyOrg, "other-project", "prod")
+		resp, err := update(client, news)
+		require.NoError(t, err)
+		assert.Equal(t, []stackRename{{
+			From: olds.identifier(), NewProject: "other-project", NewName: "prod",
+		}}, client.renames)
+		assert.Empty(t, client.transfers)
+		assert.Equal(t, news, resp.Output.StackInput)
+	})
+
+	t.Run("transfer after rename", func(t *testing.T) {
+		client := &StackClientMock{}
+		news := stackInput("other-org", gcMyProject, "prod")
+		news.AllowOrganizationTransfer = true
+		resp, err := update(client, news)
+		require.NoError(t, err)
+		require.Len(t, client.renames, 1)
+		assert.Equal(t, []string{"my-org/my-project/prod -> other-org"}, client.transfers)
+		assert.Equal(t, news, resp.Output.StackInput)
+	})
+
+	t.Run("failed transfer keeps the rename", func(t *testing.T) {
+		client := &StackClientMock{transferErr: errors.New("forbidden")}
+		news := stackInput("other-org", gcMyProject, "prod")
+		news.AllowOrganizationTransfer = true
+		resp, err := update(client, news)
+		var initErr infer.ResourceInitFailedError
+		require.ErrorAs(t, err, &initErr)
+		assert.Equal(t, gcMyOrg, resp.Output.OrganizationName, "the transfer did not happen")
+		assert.Equal(t, "prod", resp.Output.StackName, "the rename did")
+	})
+
+	t.Run("failed rename changes nothing", func(t *testing.T) {
+		client := &StackClientMock{renameErr: errors.New("conflict")}
+		_, err := update(client, stackInput(gcMyOrg, gcMyProject, "prod"))
+		require.ErrorContains(t, err, "conflict")
+		assert.Empty(t, client.transfers)
+	})
+}
+
+func TestStackRead(t *testing.T) {
+	renamed := stackInput(gcMyOrg, gcMyProject, "prod")
+	client := &StackClientMock{exists: map[pulumiapi.StackIdentifier]bool{
+		{OrgName: gcMyOrg, ProjectName: gcMyProject, StackName: "prod"}: true,
+		{OrgName: gcMyOrg, ProjectName: gcMyProject, StackName: "dev"}:  true,
+	}}
+	ctx := config.WithMockClient(context.Background(), client)
+
+	t.Run("state identity wins over the original ID", func(t *testing.T) {
+		resp, err := (&Stack{}).Read(ctx, infer.ReadRequest[StackInput, StackState]{
+			ID:     "my-org/my-project/gone",
+			Inputs: renamed,
+			State:  StackState{StackInput: renamed},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "my-org/my-project/gone", resp.ID)
+		assert.Equal(t, renamed, resp.State.StackInput)
+	})
+
+	t.Run("import reads the ID", func(t *testing.T) {
+		resp, err := (&Stack{}).Read(ctx, infer.ReadRequest[StackInput, StackState]{ID: "my-org/my-project/dev"})
+		require.NoError(t, err)
+		assert.Equal(t, stackInput(gcMyOrg, gcMyProject, "dev"), resp.Inputs)
+	})
+}
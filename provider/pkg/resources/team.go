@@ -11,20 +11,58 @@ import (
 	"github.com/pulumi/pulumi-go-provider/infer"
 
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/util"
 )
 
 const (
 	teamTypeGitHub = "github"
 	teamTypePulumi = "pulumi"
+
+	gcGitHubTeamID       = "githubTeamId"
+	gcGitHubTeamSlug     = "githubTeamSlug"
+	gcGitHubOrganization = "githubOrganization"
+	gcMembershipMode     = "membershipMode"
+	gcMembers            = "members"
 )
 
+// TeamMembershipMode is how a Team manages its members.
+type TeamMembershipMode string
+
+const (
+	TeamMembershipAuthoritative TeamMembershipMode = "authoritative"
+	TeamMembershipAdditive      TeamMembershipMode = "additive"
+	TeamMembershipIgnore        TeamMembershipMode = "ignore"
+)
+
+func (TeamMembershipMode) Values() []infer.EnumValue[TeamMembershipMode] {
+	return []infer.EnumValue[TeamMembershipMode]{
+		{
+			Name:        "Authoritative",
+			Value:       TeamMembershipAuthoritative,
+			Description: "`members` is the exact list of members: anyone else is removed from the team.",
+		},
+		{
+			Name:  "Additive",
+			Value: TeamMembershipAdditive,
+			Description: "`members` are kept in the team; members added by other means, such as `TeamMember` " +
+				"resources, are left alone.",
+		},
+		{
+			Name:        "Ignore",
+			Value:       TeamMembershipIgnore,
+			Description: "Membership is not managed: `members` is ignored and only reports the team's members.",
+		},
+	}
+}
+
 type Team struct{}
 
 var (
 	_ infer.CustomCreate[TeamInput, TeamState] = &Team{}
 	_ infer.CustomCheck[TeamInput]             = &Team{}
 	_ infer.CustomDelete[TeamState]            = &Team{}
+	_ infer.CustomDiff[TeamInput, TeamState]   = &Team{}
 	_ infer.CustomRead[TeamInput, TeamState]   = &Team{}
 	_ infer.CustomUpdate[TeamInput, TeamState] = &Team{}
 )
@@ -33,17 +71,23 @@ func (t *Team) Annotate(a infer.Annotator) {
 	a.Describe(
 		t,
 		"The Pulumi Cloud offers role-based access control (RBAC) using teams. Teams allow organization admins "+
-			"to assign a set of stack permissions to a group of users.",
+			"to assign a set of stack permissions to a group of users.\n\n"+
+			"`membershipMode` sets how `members` is applied. Use `additive` or `ignore` when some members are "+
+			"managed by `TeamMember` resources, possibly in other stacks. The members of `github` teams are "+
+			"synced from GitHub, so their membership is always `ignore`d.",
 	)
 }
 
 type TeamCore struct {
-	OrganizationName string   `pulumi:"organizationName"      provider:"replaceOnChanges"`
-	Type             string   `pulumi:"teamType"              provider:"replaceOnChanges"`
-	Name             *string  `pulumi:"name,optional"         provider:"replaceOnChanges"`
-	DisplayName      *string  `pulumi:"displayName,optional"`
-	Description      *string  `pulumi:"description,optional"`
-	GitHubTeamID     *float64 `pulumi:"githubTeamId,optional"`
+	OrganizationName   string              `pulumi:"organizationName"            provider:"replaceOnChanges"`
+	Type               string              `pulumi:"teamType"                    provider:"replaceOnChanges"`
+	Name               *string             `pulumi:"name,optional"               provider:"replaceOnChanges"`
+	DisplayName        *string             `pulumi:"displayName,optional"`
+	Description        *string             `pulumi:"description,optional"`
+	GitHubTeamID       *float64            `pulumi:"githubTeamId,optional"`
+	GitHubTeamSlug     *string             `pulumi:"githubTeamSlug,optional"     provider:"replaceOnChanges"`
+	GitHubOrganization *string             `pulumi:"githubOrganization,optional"`
+	MembershipMode     *TeamMembershipMode `pulumi:"membershipMode,optional"`
 }
 
 func (t *TeamCore) Annotate(a infer.Annotator) {
@@ -55,8 +99,32 @@ func (t *TeamCore) Annotate(a infer.Annotator) {
 	a.Describe(
 		&t.GitHubTeamID,
 		`The GitHub ID of the team to mirror. Must be in the same GitHub organization that the Pulumi org is `+
-			`backed by. Required for "github" teams.`,
+			`backed by. Required for "github" teams, unless githubTeamSlug is set.`,
+	)
+	a.Describe(
+		&t.GitHubTeamSlug,
+		"The slug of the GitHub team to mirror, looked up in `githubOrganization`. Resolves githubTeamId when "+
+			"that is not set.",
 	)
+	a.Describe(
+		&t.GitHubOrganization,
+		"The GitHub organization `githubTeamSlug` is looked up in. Defaults to `organizationName`.",
+	)
+	a.Describe(&t.MembershipMode, "How `members` is applied. Defaults to `authoritative` for \"pulumi\" teams and "+
+		"`ignore` for \"github\" teams, which only support `ignore`.")
+}
+
+// membershipMode returns the team's membership mode, defaulted for teams
+// whose state predates it.
+func (t *TeamCore) membershipMode() TeamMembershipMode {
+	switch {
+	case t.Type == teamTypeGitHub:
+		return TeamMembershipIgnore
+	case t.MembershipMode == nil:
+		return TeamMembershipAuthoritative
+	default:
+		return *t.MembershipMode
+	}
 }
 
 type TeamInput struct {
@@ -74,7 +142,51 @@ type TeamState struct {
 }
 
 func (t *TeamState) Annotate(a infer.Annotator) {
-	a.Describe(&t.Members, "List of Pulumi Cloud usernames of team members.")
+	a.Describe(&t.Members, "List of Pulumi Cloud usernames of team members. With `additive` membership, only "+
+		"the listed members that are in the team.")
+}
+
+// stateMembers returns the members a team's state records: every member of
+// the team, or with additive membership the managed ones it contains.
+func stateMembers(mode TeamMembershipMode, managed []string, team *pulumiapi.Team) []string {
+	members := []string{}
+	for _, m := range team.Members {
+		if mode != TeamMembershipAdditive || slices.Contains(managed, m.GithubLogin) { //nolint:govet
+			members = append(members, m.GithubLogin)
+		}
+	}
+	// Sort the members so the order is deterministic
+	slices.Sort(members) //nolint:govet // inline analyzer limitation on generic slices.Sort; not a code defect.
+	return members
+}
+
+// membershipChanges returns the members to add to and remove from the team
+// for it to match desired. previous are the members managed so far, which
+// additive membership removes once they are no longer desired.
+func membershipChanges(
+	mode TeamMembershipMode, desired, previous []string, team *pulumiapi.Team,
+) (add, remove []string) {
+	if mode == TeamMembershipIgnore {
+		return nil, nil
+	}
+	actual := make([]string, 0, len(team.Members))
+	for _, m := range team.Members {
+		actual = append(actual, m.GithubLogin)
+	}
+	for _, member := range desired {
+		if !slices.Contains(actual, member) { //nolint:govet
+			add = append(add, member)
+		}
+	}
+	for _, member := range actual {
+		if slices.Contains(desired, member) { //nolint:govet
+			continue
+		}
+		if mode == TeamMembershipAuthoritative || slices.Contains(previous, member) { //nolint:govet
+			remove = append(remove, member)
+		}
+	}
+	return add, remove
 }
 
 func (*Team) Create(ctx context.Context, req infer.CreateRequest[TeamInput]) (infer.CreateResponse[TeamState], error) {
@@ -89,13 +201,17 @@ func (*Team) Create(ctx context.Context, req infer.CreateRequest[TeamInput]) (in
 		}, nil
 	}
 	client := config.GetClient(ctx)
+	githubTeamID, err := resolveGitHubTeamID(ctx, client, req.Inputs.TeamCore)
+	if err != nil {
+		return infer.CreateResponse[TeamState]{}, err
+	}
 	team, err := client.CreateTeam(ctx,
 		req.Inputs.OrganizationName,
 		util.OrZero(req.Inputs.Name),
 		req.Inputs.Type,
 		util.OrZero(req.Inputs.DisplayName),
 		util.OrZero(req.Inputs.Description),
-		int64(util.OrZero(req.Inputs.GitHubTeamID)),
+		int64(util.OrZero(githubTeamID)),
 	)
 	if err != nil {
 		return infer.CreateResponse[TeamState]{}, fmt.Errorf(
@@ -108,20 +224,16 @@ func (*Team) Create(ctx context.Context, req infer.CreateRequest[TeamInput]) (in
 	// We have now created a teamUrn.  It is very important to ensure that from this point on, any other error
 	// below returns the ID using the `pulumirpc.ErrorResourceInitFailed` error details annotation.  Otherwise,
 	// we leak a teamUrn resource. We ensure that we wrap any errors in a partial error and return that to the RPC.
-
-	members := []string{}
-	for _, memberToAdd := range req.Inputs.Members {
-		err = client.AddMemberToTeam(ctx, req.Inputs.OrganizationName, util.OrZero(req.Inputs.Name), memberToAdd)
-		if err != nil {
-			return infer.CreateResponse[TeamState]{
-				ID: teamURN,
-				Output: TeamState{
-					TeamCore: req.Inputs.TeamCore,
-					Members:  members,
-				},
-			}, infer.ResourceInitFailedError{Reasons: []string{err.Error()}}
+	core := req.Inputs.TeamCore
+	core.GitHubTeamID = githubTeamID
+	mode := core.membershipMode()
+
+	var failures []string
+	add, remove := membershipChanges(mode, req.Inputs.Members, nil, team)
+	if len(add) > 0 || len(remove) > 0 {
+		if err := client.UpdateTeamMembers(ctx, req.Inputs.OrganizationName, team.Name, add, remove); err != nil {
+			failures = append(failures, err.Error())
 		}
-		members = append(members, memberToAdd)
 	}
 
 	// Outputs should be the result of a GetTeam call, so we can return the full teamUrn object with fidelity
@@ -129,34 +241,44 @@ func (*Team) Create(ctx context.Context, req infer.CreateRequest[TeamInput]) (in
 	team, err = client.GetTeam(ctx, req.Inputs.OrganizationName, team.Name)
 	if err != nil {
 		return infer.CreateResponse[TeamState]{
-			ID: teamURN,
-			Output: TeamState{
-				TeamCore: req.Inputs.TeamCore,
-				Members:  members,
-			},
-		}, infer.ResourceInitFailedError{Reasons: []string{err.Error()}}
+			ID:     teamURN,
+			Output: TeamState{TeamCore: core, Members: []string{}},
+		}, infer.ResourceInitFailedError{Reasons: append(failures, err.Error())}
 	}
-	members = members[:0]
-	for _, v := range team.Members {
-		members = append(members, v.GithubLogin)
+
+	core.Description = util.OrNil(team.Description)
+	core.DisplayName = util.OrNil(team.DisplayName)
+	core.Name = &team.Name
+	core.Type = team.Type
+	state := TeamState{TeamCore: core, Members: stateMembers(mode, req.Inputs.Members, team)}
+	if len(failures) > 0 {
+		return infer.CreateResponse[TeamState]{ID: teamURN, Output: state},
+			infer.ResourceInitFailedError{Reasons: failures}
 	}
-	// Sort the members so the order is deterministic
-	slices.Sort(members) //nolint:govet // inline analyzer limitation on generic slices.Sort; not a code defect.
+	return infer.CreateResponse[TeamState]{ID: teamURN, Output: state}, nil
+}
 
-	return infer.CreateResponse[TeamState]{
-		ID: teamURN,
-		Output: TeamState{
-			Members: members,
-			TeamCore: TeamCore{
-				Description:      util.OrNil(team.Description),
-				DisplayName:      util.OrNil(team.DisplayName),
-				Name:             &team.Name,
-				Type:             team.Type,
-				OrganizationName: req.Inputs.OrganizationName,
-				GitHubTeamID:     req.Inputs.GitHubTeamID,
-			},
-		},
-	}, nil
+// resolveGitHubTeamID returns the GitHub ID of the team to mirror, looking it
+// up by slug when only that is set.
+func resolveGitHubTeamID(ctx context.Context, client config.Client, team TeamCore) (*float64, error) {
+	if team.Type != teamTypeGitHub || team.GitHubTeamID != nil || team.GitHubTeamSlug == nil {
+		return team.GitHubTeamID, nil
+	}
+	githubOrg := team.OrganizationName
+	if team.GitHubOrganization != nil {
+		githubOrg = *team.GitHubOrganization
+	}
+	githubTeams, err := client.ListGitHubOrganizationTeams(ctx, githubOrg)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range githubTeams {
+		if t.Slug == *team.GitHubTeamSlug {
+			id := float64(t.ID)
+			return &id, nil
+		}
+	}
+	return nil, fmt.Errorf("GitHub organization %q has no team with slug %q", githubOrg, *team.GitHubTeamSlug)
 }
 
 func (*Team) Check(ctx context.Context, req infer.CheckRequest) (infer.CheckResponse[TeamInput], error) {
@@ -171,10 +293,10 @@ func (*Team) Check(ctx context.Context, req infer.CheckRequest) (infer.CheckResp
 		})
 	}
 
-	if i.Type == teamTypeGitHub && i.GitHubTeamID == nil {
+	if i.Type == teamTypeGitHub && i.GitHubTeamID == nil && i.GitHubTeamSlug == nil {
 		checkFailures = append(checkFailures, p.CheckFailure{
-			Reason:   "teams with teamType 'github' require a githubTeamId",
-			Property: "githubTeamId",
+			Reason:   "teams with teamType 'github' require a githubTeamId or githubTeamSlug",
+			Property: gcGitHubTeamID,
 		})
 	}
 
@@ -185,9 +307,20 @@ func (*Team) Check(ctx context.Context, req infer.CheckRequest) (infer.CheckResp
 		})
 	}
 
+	if i.Type == teamTypeGitHub && i.MembershipMode != nil && *i.MembershipMode != TeamMembershipIgnore {
+		checkFailures = append(checkFailures, p.CheckFailure{
+			Reason:   "the members of teams with teamType 'github' are synced from GitHub; membershipMode must be 'ignore'",
+			Property: gcMembershipMode,
+		})
+	}
+
 	if i.DisplayName == nil {
 		i.DisplayName = i.Name
 	}
+	if i.MembershipMode == nil {
+		mode := i.membershipMode()
+		i.MembershipMode = &mode
+	}
 	if i.Members == nil {
 		i.Members = []string{}
 	}
@@ -199,6 +332,54 @@ func (*Team) Check(ctx context.Context, req infer.CheckRequest) (infer.CheckResp
 	}, nil
 }
 
+// Diff ignores members unless the team manages them, so a team whose members
+// change elsewhere does not show a perpetual diff.
+func (*Team) Diff(_ context.Context, req infer.DiffRequest[TeamInput, TeamState]) (infer.DiffResponse, error) {
+	diff := map[string]p.PropertyDiff{}
+	replace := func(key string) { diff[key] = p.PropertyDiff{Kind: p.UpdateReplace, InputDiff: true} }
+	update := func(key string) { diff[key] = p.PropertyDiff{Kind: p.Update, InputDiff: true} }
+
+	if req.State.OrganizationName != req.Inputs.OrganizationName {
+		replace(gcOrganizationName)
+	}
+	if req.State.Type != req.Inputs.Type {
+		replace("teamType")
+	}
+	if util.OrZero(req.State.Name) != util.OrZero(req.Inputs.Name) {
+		replace(gcName)
+	}
+	if util.OrZero(req.State.GitHubTeamSlug) != util.OrZero(req.Inputs.GitHubTeamSlug) {
+		replace(gcGitHubTeamSlug)
+	}
+	// The organization only matters to the team a slug resolves to.
+	if util.OrZero(req.State.GitHubOrganization) != util.OrZero(req.Inputs.GitHubOrganization) {
+		if req.Inputs.GitHubTeamSlug != nil {
+			replace(gcGitHubOrganization)
+		} else {
+			update(gcGitHubOrganization)
+		}
+	}
+	if util.OrZero(req.State.DisplayName) != util.OrZero(req.Inputs.DisplayName) {
+		update(gcDisplayName)
+	}
+	if util.OrZero(req.State.Description) != util.OrZero(req.Inputs.Description) {
+		update(gcDescription)
+	}
+	// A githubTeamId resolved from the slug is only in the state.
+	if (req.Inputs.GitHubTeamID != nil || req.Inputs.GitHubTeamSlug == nil) &&
+		util.OrZero(req.State.GitHubTeamID) != util.OrZero(req.Inputs.GitHubTeamID) {
+		update(gcGitHubTeamID)
+	}
+	mode := req.Inputs.membershipMode()
+	if req.State.membershipMode() != mode {
+		update(gcMembershipMode)
+	}
+	if mode != TeamMembershipIgnore && !slices.Equal(req.State.Members, req.Inputs.Members) { //nolint:govet
+		update(gcMembers)
+	}
+	return infer.DiffResponse{HasChanges: len(diff) > 0, DetailedDiff: diff}, nil
+}
+
 func (*Team) Delete(ctx context.Context, req infer.DeleteRequest[TeamState]) (infer.DeleteResponse, error) {
 	client := config.GetClient(ctx)
 	return infer.DeleteResponse{}, client.DeleteTeam(ctx, req.State.OrganizationName, util.OrZero(req.State.Name))
@@ -223,29 +404,33 @@ func (*Team) Read(
 	}
 
 	core := TeamCore{
-		OrganizationName: orgName,
-		Type:             team.Type,
-		Name:             &team.Name,
-		DisplayName:      util.OrNil(team.DisplayName),
-		Description:      util.OrNil(team.Description),
-		GitHubTeamID:     req.Inputs.GitHubTeamID,
+		OrganizationName:   orgName,
+		Type:               team.Type,
+		Name:               &team.Name,
+		DisplayName:        util.OrNil(team.DisplayName),
+		Description:        util.OrNil(team.Description),
+		GitHubTeamID:       req.Inputs.GitHubTeamID,
+		GitHubTeamSlug:     req.Inputs.GitHubTeamSlug,
+		GitHubOrganization: req.Inputs.GitHubOrganization,
+		MembershipMode:     req.Inputs.MembershipMode,
+	}
+	stateCore := core
+	if stateCore.GitHubTeamID == nil {
+		stateCore.GitHubTeamID = req.State.GitHubTeamID
 	}
 
-	members := []string{}
-	for _, m := range team.Members {
-		members = append(members, m.GithubLogin)
+	mode := core.membershipMode()
+	inputs := TeamInput{TeamCore: core, Members: req.Inputs.Members}
+	if mode == TeamMembershipAuthoritative {
+		inputs.Members = stateMembers(mode, nil, team)
 	}
-	slices.Sort(members) //nolint:govet // inline analyzer limitation on generic slices.Sort; not a code defect.
 
 	return infer.ReadResponse[TeamInput, TeamState]{
-		ID: req.ID,
-		Inputs: TeamInput{
-			TeamCore: core,
-			Members:  members,
-		},
+		ID:     req.ID,
+		Inputs: inputs,
 		State: TeamState{
-			TeamCore: core,
-			Members:  members,
+			TeamCore: stateCore,
+			Members:  stateMembers(mode, req.State.Members, team),
 		},
 	}, nil
 }
@@ -254,17 +439,22 @@ func (*Team) Update(
 	ctx context.Context,
 	req infer.UpdateRequest[TeamInput, TeamState],
 ) (infer.UpdateResponse[TeamState], error) {
+	core := req.Inputs.TeamCore
+	if core.GitHubTeamID == nil {
+		core.GitHubTeamID = req.State.GitHubTeamID
+	}
+	mode := core.membershipMode()
 	if req.DryRun {
-		return infer.UpdateResponse[TeamState]{
-			Output: TeamState{
-				TeamCore: req.Inputs.TeamCore,
-				Members:  req.Inputs.Members,
-			},
-		}, nil
+		members := req.Inputs.Members
+		if mode == TeamMembershipIgnore {
+			members = req.State.Members
+		}
+		return infer.UpdateResponse[TeamState]{Output: TeamState{TeamCore: core, Members: members}}, nil
 	}
 	client := config.GetClient(ctx)
 
-	if req.State.Description != req.Inputs.Description || req.State.DisplayName != req.Inputs.DisplayName {
+	if util.OrZero(req.State.Description) != util.OrZero(req.Inputs.Description) ||
+		util.OrZero(req.State.DisplayName) != util.OrZero(req.Inputs.DisplayName) {
 		err := client.UpdateTeam(
 			ctx,
 			req.Inputs.OrganizationName,
@@ -277,75 +467,37 @@ func (*Team) Update(
 		}
 	}
 
-	// github teams can't manage membership.
-	members := make([]string, len(req.State.Members))
-	copy(members, req.State.Members)
-
-	// slices.* generic calls below: govet's inline analyzer can't infer type params; suppressed.
-	if !slices.Equal(req.Inputs.Members, req.State.Members) && req.Inputs.Type != teamTypeGitHub { //nolint:govet
-		for i := len(req.State.Members) - 1; i >= 0; i-- {
-			usernameToDelete := req.State.Members[i]
-			if !slices.Contains(req.Inputs.Members, usernameToDelete) { //nolint:govet
-				err := client.DeleteMemberFromTeam(
-					ctx,
-					req.Inputs.OrganizationName,
-					util.OrZero(req.Inputs.Name),
-					usernameToDelete,
-				)
-				if err != nil {
-					slices.Sort(members) //nolint:govet
-					// We have failed to delete a member, but we may
-					// have still done something. Report on what we
-					// did.
-					return infer.UpdateResponse[TeamState]{
-						Output: TeamState{
-							TeamCore: req.Inputs.TeamCore,
-							Members:  members,
-						},
-					}, infer.ResourceInitFailedError{Reasons: []string{err.Error()}}
-				}
-				// Remove this user from our running list of members
-				if len(members) == 1 {
-					// If there is only one member left, we zero out our list
-					members = members[:0]
-				} else {
-					// Swap the current element with our last element, then pop the last element.
-					members[i] = members[len(members)-1]
-					members = members[:len(members)-1]
-				}
-			}
-		}
+	if mode == TeamMembershipIgnore {
+		return infer.UpdateResponse[TeamState]{Output: TeamState{TeamCore: core, Members: req.State.Members}}, nil
+	}
 
-		for _, usernameToAdd := range req.Inputs.Members {
-			if !slices.Contains(req.State.Members, usernameToAdd) { //nolint:govet
-				err := client.AddMemberToTeam(
-					ctx,
-					req.Inputs.OrganizationName,
-					util.OrZero(req.Inputs.Name),
-					usernameToAdd,
-				)
-				if err != nil {
-					slices.Sort(members) //nolint:govet
-					return infer.UpdateResponse[TeamState]{
-						Output: TeamState{
-							TeamCore: req.Inputs.TeamCore,
-							Members:  members,
-						},
-					}, infer.ResourceInitFailedError{Reasons: []string{err.Error()}}
-				}
-				members = append(members, usernameToAdd)
-			}
-		}
-		slices.Sort(members) //nolint:govet
-		req.Inputs.Members = members
+	// Diff against the team's actual members, so only real changes are sent.
+	teamName := util.OrZero(req.Inputs.Name)
+	team, err := client.GetTeam(ctx, req.Inputs.OrganizationName, teamName)
+	if err != nil {
+		return infer.UpdateResponse[TeamState]{}, err
+	}
+	add, remove := membershipChanges(mode, req.Inputs.Members, req.State.Members, team)
+	if len(add) == 0 && len(remove) == 0 {
+		return infer.UpdateResponse[TeamState]{
+			Output: TeamState{TeamCore: core, Members: stateMembers(mode, req.Inputs.Members, team)},
+		}, nil
 	}
 
-	return infer.UpdateResponse[TeamState]{
-		Output: TeamState{
-			TeamCore: req.Inputs.TeamCore,
-			Members:  req.Inputs.Members,
-		},
-	}, nil
+	var failures []string
+	if err := client.UpdateTeamMembers(ctx, req.Inputs.OrganizationName, teamName, add, remove); err != nil {
+		failures = append(failures, err.Error())
+	}
+	// We may have failed part way through: report the membership we ended up with.
+	if team, err = client.GetTeam(ctx, req.Inputs.OrganizationName, teamName); err != nil {
+		return infer.UpdateResponse[TeamState]{Output: TeamState{TeamCore: core, Members: req.State.Members}},
+			infer.ResourceInitFailedError{Reasons: append(failures, err.Error())}
+	}
+	state := TeamState{TeamCore: core, Members: stateMembers(mode, req.Inputs.Members, team)}
+	if len(failures) > 0 {
+		return infer.UpdateResponse[TeamState]{Output: state}, infer.ResourceInitFailedError{Reasons: failures}
+	}
+	return infer.UpdateResponse[TeamState]{Output: state}, nil
 }
 
 func splitSingleSlashString(id string) (string, string, error) {
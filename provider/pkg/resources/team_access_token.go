@@ -19,30 +19,42 @@ import (
 	"fmt"
 	"strings"
 
+	p "github.com/pulumi/pulumi-go-provider"
 	"github.com/pulumi/pulumi-go-provider/infer"
 	"github.com/pulumi/pulumi/sdk/v3/go/property"
 
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/util"
 )
 
 const (
 	gcTeamName = "teamName"
 )
 
-type TeamAccessToken struct{}
+type TeamAccessToken struct {
+	// now overrides the clock rotations come due by.
+	now tokenClock
+}
 
 var (
+	_ infer.CustomCheck[TeamAccessTokenInput]                        = &TeamAccessToken{}
 	_ infer.CustomCreate[TeamAccessTokenInput, TeamAccessTokenState] = &TeamAccessToken{}
+	_ infer.CustomDiff[TeamAccessTokenInput, TeamAccessTokenState]   = &TeamAccessToken{}
+	_ infer.CustomUpdate[TeamAccessTokenInput, TeamAccessTokenState] = &TeamAccessToken{}
 	_ infer.CustomDelete[TeamAccessTokenState]                       = &TeamAccessToken{}
 	_ infer.CustomRead[TeamAccessTokenInput, TeamAccessTokenState]   = &TeamAccessToken{}
 	_ infer.CustomStateMigrations[TeamAccessTokenState]              = &TeamAccessToken{}
 )
 
-func (*TeamAccessToken) Annotate(a infer.Annotator) {
+func (t *TeamAccessToken) Annotate(a infer.Annotator) {
 	a.Describe(
-		&TeamAccessToken{},
+		t,
 		"The Pulumi Cloud allows users to create access tokens scoped to team. "+
-			"Team access tokens is a resource to create them and assign them to a team",
+			"Team access tokens is a resource to create them and assign them to a team.\n\n"+
+			"With `ttl` and `rotation`, a successor token is issued ahead of expiry and `value` changes to it, "+
+			"while the previous token stays valid for `keepPrevious` so its consumers can move over. "+
+			"Token names are unique, so a successor is named after `name` with the rotation time appended.",
 	)
 }
 
@@ -51,6 +63,7 @@ type TeamAccessTokenInput struct {
 	OrganizationName string  `pulumi:"organizationName" provider:"replaceOnChanges"`
 	TeamName         string  `pulumi:"teamName"         provider:"replaceOnChanges"`
 	Description      *string `pulumi:"description,optional" provider:"replaceOnChanges"`
+	TokenExpiry
 }
 
 func (i *TeamAccessTokenInput) Annotate(a infer.Annotator) {
@@ -63,13 +76,25 @@ func (i *TeamAccessTokenInput) Annotate(a infer.Annotator) {
 type TeamAccessTokenState struct {
 	TeamAccessTokenInput
 	Value string `pulumi:"value" provider:"secret"`
+	TokenRotationState
 }
 
 func (s *TeamAccessTokenState) Annotate(a infer.Annotator) {
 	a.Describe(&s.Value, "The token's value.")
 }
 
-func (*TeamAccessToken) Create(
+func (*TeamAccessToken) Check(
+	ctx context.Context, req infer.CheckRequest,
+) (infer.CheckResponse[TeamAccessTokenInput], error) {
+	i, failures, err := infer.DefaultCheck[TeamAccessTokenInput](ctx, req.NewInputs)
+	if err != nil {
+		return infer.CheckResponse[TeamAccessTokenInput]{}, err
+	}
+	failures = append(failures, i.check()...)
+	return infer.CheckResponse[TeamAccessTokenInput]{Inputs: i, Failures: failures}, nil
+}
+
+func (t *TeamAccessToken) Create(
 	ctx context.Context,
 	req infer.CreateRequest[TeamAccessTokenInput],
 ) (infer.CreateResponse[TeamAccessTokenState], error) {
@@ -78,39 +103,99 @@ func (*TeamAccessToken) Create(
 			Output: TeamAccessTokenState{TeamAccessTokenInput: req.Inputs},
 		}, nil
 	}
-	desc := ""
-	if req.Inputs.Description != nil {
-		desc = *req.Inputs.Description
-	}
-	token, err := config.GetClient(ctx).CreateTeamAccessToken(
-		ctx, req.Inputs.Name, req.Inputs.OrganizationName, req.Inputs.TeamName, desc,
-	)
+	token, err := t.rotation(ctx, req.Inputs, req.Inputs.Name).issue(req.Inputs.expiresAt(t.now.now()))
 	if err != nil {
 		return infer.CreateResponse[TeamAccessTokenState]{}, fmt.Errorf(
 			"error creating team access token %q: %w", req.Inputs.Name, err,
 		)
 	}
+	inputs := req.Inputs
+	inputs.TokenExpiry = req.Inputs.issued(token.Expires)
 	return infer.CreateResponse[TeamAccessTokenState]{
 		ID: teamAccessTokenID(req.Inputs.OrganizationName, req.Inputs.TeamName, req.Inputs.Name, token.ID),
 		Output: TeamAccessTokenState{
-			TeamAccessTokenInput: req.Inputs,
+			TeamAccessTokenInput: inputs,
 			Value:                token.TokenValue,
+			TokenRotationState:   TokenRotationState{TokenID: token.ID},
 		},
 	}, nil
 }
 
-func (*TeamAccessToken) Delete(
+func (t *TeamAccessToken) Diff(
+	_ context.Context,
+	req infer.DiffRequest[TeamAccessTokenInput, TeamAccessTokenState],
+) (infer.DiffResponse, error) {
+	diff := map[string]p.PropertyDiff{}
+	for key, changed := range map[string]bool{
+		gcName:             req.State.Name != req.Inputs.Name,
+		gcOrganizationName: req.State.OrganizationName != req.Inputs.OrganizationName,
+		gcTeamName:         req.State.TeamName != req.Inputs.TeamName,
+		gcDescription:      util.OrZero(req.State.Description) != util.OrZero(req.Inputs.Description),
+	} {
+		if changed {
+			diff[key] = p.PropertyDiff{Kind: p.UpdateReplace, InputDiff: true}
+		}
+	}
+	diffTokenExpiry(diff, req.State.TokenExpiry, req.State.TokenRotationState, req.Inputs.TokenExpiry, t.now.now())
+	return infer.DiffResponse{HasChanges: len(diff) > 0, DetailedDiff: diff}, nil
+}
+
+// Update rotates the token when it is due; other changes replace it.
+func (t *TeamAccessToken) Update(
+	ctx context.Context,
+	req infer.UpdateRequest[TeamAccessTokenInput, TeamAccessTokenState],
+) (infer.UpdateResponse[TeamAccessTokenState], error) {
+	if req.DryRun {
+		output := req.State
+		output.Rotation = req.Inputs.Rotation
+		return infer.UpdateResponse[TeamAccessTokenState]{Output: output}, nil
+	}
+	_, _, _, tokenID, err := splitTeamAccessTokenID(req.ID)
+	if err != nil {
+		return infer.UpdateResponse[TeamAccessTokenState]{}, err
+	}
+	now := t.now.now()
+	result, err := t.rotation(ctx, req.Inputs, successorName(req.Inputs.Name, now)).apply(
+		req.State.TokenExpiry, req.State.current(tokenID), req.Inputs.TokenExpiry, now)
+	output := TeamAccessTokenState{
+		TeamAccessTokenInput: req.Inputs,
+		Value:                req.State.Value,
+		TokenRotationState:   result.state,
+	}
+	output.TokenExpiry = result.expiry
+	if result.value != nil {
+		output.Value = *result.value
+	}
+	return infer.UpdateResponse[TeamAccessTokenState]{Output: output}, tokenUpdateError(err)
+}
+
+// rotation issues tokens named name.
+func (*TeamAccessToken) rotation(ctx context.Context, inputs TeamAccessTokenInput, name string) tokenRotation {
+	client := config.GetClient(ctx)
+	return tokenRotation{
+		issue: func(expires int64) (*pulumiapi.AccessToken, error) {
+			return client.CreateTeamAccessToken(ctx, name, inputs.OrganizationName, inputs.TeamName,
+				util.OrZero(inputs.Description), expires)
+		},
+		delete: func(tokenID string) error {
+			return client.DeleteTeamAccessToken(ctx, tokenID, inputs.OrganizationName, inputs.TeamName)
+		},
+	}
+}
+
+func (t *TeamAccessToken) Delete(
 	ctx context.Context,
 	req infer.DeleteRequest[TeamAccessTokenState],
 ) (infer.DeleteResponse, error) {
-	orgName, teamName, _, tokenID, err := splitTeamAccessTokenID(req.ID)
+	orgName, teamName, name, tokenID, err := splitTeamAccessTokenID(req.ID)
 	if err != nil {
 		return infer.DeleteResponse{}, err
 	}
-	return infer.DeleteResponse{}, config.GetClient(ctx).DeleteTeamAccessToken(ctx, tokenID, orgName, teamName)
+	inputs := TeamAccessTokenInput{OrganizationName: orgName, TeamName: teamName}
+	return infer.DeleteResponse{}, t.rotation(ctx, inputs, name).deleteTokens(req.State.current(tokenID))
 }
 
-func (*TeamAccessToken) Read(
+func (t *TeamAccessToken) Read(
 	ctx context.Context,
 	req infer.ReadRequest[TeamAccessTokenInput, TeamAccessTokenState],
 ) (infer.ReadResponse[TeamAccessTokenInput, TeamAccessTokenState], error) {
@@ -119,7 +204,8 @@ func (*TeamAccessToken) Read(
 		return infer.ReadResponse[TeamAccessTokenInput, TeamAccessTokenState]{}, err
 	}
 
-	token, err := config.GetClient(ctx).GetTeamAccessToken(ctx, tokenID, orgName, teamName)
+	rotation := req.State.current(tokenID)
+	token, err := config.GetClient(ctx).GetTeamAccessToken(ctx, rotation.TokenID, orgName, teamName)
 	if err != nil {
 		return infer.ReadResponse[TeamAccessTokenInput, TeamAccessTokenState]{}, err
 	}
@@ -127,21 +213,26 @@ func (*TeamAccessToken) Read(
 		return infer.ReadResponse[TeamAccessTokenInput, TeamAccessTokenState]{}, nil
 	}
 
+	expiryInputs, expiryState := readTokenExpiry(req.Inputs.TokenExpiry, *token)
 	inputs := TeamAccessTokenInput{
 		Name:             tokenName,
 		OrganizationName: orgName,
 		TeamName:         teamName,
 		Description:      stringPtrIfNonEmpty(token.Description),
+		TokenExpiry:      expiryInputs,
 	}
+	state := TeamAccessTokenState{
+		TeamAccessTokenInput: inputs,
+		// Token values aren't retrievable from the API after creation; carry
+		// the existing secret from state so refresh does not erase it.
+		Value:              req.State.Value,
+		TokenRotationState: rotation.read(*token, t.now.now()),
+	}
+	state.TokenExpiry = expiryState
 	return infer.ReadResponse[TeamAccessTokenInput, TeamAccessTokenState]{
 		ID:     req.ID,
 		Inputs: inputs,
-		State: TeamAccessTokenState{
-			TeamAccessTokenInput: inputs,
-			// Token values aren't retrievable from the API after creation; carry
-			// the existing secret from state so refresh does not erase it.
-			Value: req.State.Value,
-		},
+		State:  state,
 	}, nil
 }
 
@@ -0,0 +1,148 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+)
+
+type TeamMember struct{}
+
+var (
+	_ infer.CustomCreate[TeamMemberInput, TeamMemberState] = &TeamMember{}
+	_ infer.CustomDelete[TeamMemberState]                  = &TeamMember{}
+	_ infer.CustomRead[TeamMemberInput, TeamMemberState]   = &TeamMember{}
+)
+
+func (*TeamMember) Annotate(a infer.Annotator) {
+	a.Describe(
+		&TeamMember{},
+		"A single member of a Pulumi Cloud team. Use it with a `Team` whose `membershipMode` is `additive` "+
+			"or `ignore`, so that members can be managed from several stacks; an `authoritative` team "+
+			"removes members it does not list.",
+	)
+}
+
+type TeamMemberInput struct {
+	OrganizationName string `pulumi:"organizationName" provider:"replaceOnChanges"`
+	TeamName         string `pulumi:"teamName"         provider:"replaceOnChanges"`
+	Username         string `pulumi:"username"         provider:"replaceOnChanges"`
+}
+
+func (i *TeamMemberInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.OrganizationName, "The Pulumi Cloud organization name.")
+	a.Describe(&i.TeamName, "The team name.")
+	a.Describe(&i.Username, "The Pulumi Cloud username of the member.")
+}
+
+type TeamMemberState struct {
+	TeamMemberInput
+}
+
+func (*TeamMember) Create(
+	ctx context.Context,
+	req infer.CreateRequest[TeamMemberInput],
+) (infer.CreateResponse[TeamMemberState], error) {
+	id := teamMemberID(req.Inputs.OrganizationName, req.Inputs.TeamName, req.Inputs.Username)
+	out := TeamMemberState{TeamMemberInput: req.Inputs}
+	if req.DryRun {
+		return infer.CreateResponse[TeamMemberState]{ID: id, Output: out}, nil
+	}
+
+	client := config.GetClient(ctx)
+	if err := client.AddMemberToTeam(
+		ctx,
+		req.Inputs.OrganizationName,
+		req.Inputs.TeamName,
+		req.Inputs.Username,
+	); err != nil {
+		return infer.CreateResponse[TeamMemberState]{}, err
+	}
+	return infer.CreateResponse[TeamMemberState]{ID: id, Output: out}, nil
+}
+
+func (*TeamMember) Delete(
+	ctx context.Context,
+	req infer.DeleteRequest[TeamMemberState],
+) (infer.DeleteResponse, error) {
+	client := config.GetClient(ctx)
+	return infer.DeleteResponse{}, client.DeleteMemberFromTeam(
+		ctx,
+		req.State.OrganizationName,
+		req.State.TeamName,
+		req.State.Username,
+	)
+}
+
+func (*TeamMember) Read(
+	ctx context.Context,
+	req infer.ReadRequest[TeamMemberInput, TeamMemberState],
+) (infer.ReadResponse[TeamMemberInput, TeamMemberState], error) {
+	orgName, teamName, username, err := splitTeamMemberID(req.ID)
+	if err != nil {
+		return infer.ReadResponse[TeamMemberInput, TeamMemberState]{}, err
+	}
+
+	client := config.GetClient(ctx)
+	team, err := client.GetTeam(ctx, orgName, teamName)
+	if err != nil {
+		return infer.ReadResponse[TeamMemberInput, TeamMemberState]{}, fmt.Errorf(
+			"failed to read team member (%q): %w",
+			req.ID,
+			err,
+		)
+	}
+	// The member is gone if either the team or their membership is.
+	if team == nil {
+		return infer.ReadResponse[TeamMemberInput, TeamMemberState]{}, nil
+	}
+	for _, m := range team.Members {
+		if m.GithubLogin != username {
+			continue
+		}
+		in := TeamMemberInput{
+			OrganizationName: orgName,
+			TeamName:         teamName,
+			Username:         username,
+		}
+		return infer.ReadResponse[TeamMemberInput, TeamMemberState]{
+			ID:     req.ID,
+			Inputs: in,
+			State:  TeamMemberState{TeamMemberInput: in},
+		}, nil
+	}
+	return infer.ReadResponse[TeamMemberInput, TeamMemberState]{}, nil
+}
+
+func teamMemberID(org, team, username string) string {
+	return fmt.Sprintf("%s/%s/%s", org, team, username)
+}
+
+func splitTeamMemberID(id string) (string, string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf(
+			"%q is invalid, must be in the format: organization/team/username",
+			id,
+		)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+func TestTeamMemberRead(t *testing.T) {
+	read := func(team *pulumiapi.Team) infer.ReadResponse[TeamMemberInput, TeamMemberState] {
+		ctx := config.WithMockClient(context.Background(), &TeamClientMock{
+			getTeamFunc: func(context.Context, string, string) (*pulumiapi.Team, error) { return team, nil },
+		})
+		resp, err := (&TeamMember{}).Read(ctx, infer.ReadRequest[TeamMemberInput, TeamMemberState]{
+			ID: "abc/test/member1",
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("member", func(t *testing.T) {
+		resp := read(&pulumiapi.Team{Name: "test", Members: []pulumiapi.TeamMember{{GithubLogin: gcMember1}}})
+		assert.Equal(t, "abc/test/member1", resp.ID)
+		assert.Equal(t, TeamMemberInput{
+			OrganizationName: gcABC,
+			TeamName:         "test",
+			Username:         gcMember1,
+		}, resp.Inputs)
+	})
+
+	t.Run("no longer a member", func(t *testing.T) {
+		resp := read(&pulumiapi.Team{Name: "test", Members: []pulumiapi.TeamMember{{GithubLogin: gcMember2}}})
+		assert.Equal(t, "", resp.ID)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		assert.Equal(t, "", read(nil).ID)
+	})
+}
+
+func TestSplitTeamMemberID(t *testing.T) {
+	org, team, username, err := splitTeamMemberID("abc/test/member1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{gcABC, "test", gcMember1}, []string{org, team, username})
+
+	for _, id := range []string{"abc/test", "abc//member1", "abc/test/member1/extra"} {
+		_, _, _, err := splitTeamMemberID(id)
+		assert.Error(t, err, id)
+	}
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	"testing"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
 
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
@@ -128,4 +132,214 @@ func TestTeam(t *testing.T) {
 	})
 }
 
+// teamMembersClientMock keeps a single team's members, recording each
+// batch of membership changes.
+type teamMembersClientMock struct {
+	config.Client
+	members   []string
+	added     []string
+	removed   []string
+	githubOrg string
+}
+
+func (c *teamMembersClientMock) CreateTeam(
+	_ context.Context, _, teamName, teamType, _, _ string, _ int64,
+) (*pulumiapi.Team, error) {
+	return c.team(teamName, teamType), nil
+}
+
+func (c *teamMembersClientMock) GetTeam(_ context.Context, _ string, teamName string) (*pulumiapi.Team, error) {
+	return c.team(teamName, teamTypePulumi), nil
+}
+
+func (c *teamMembersClientMock) UpdateTeamMembers(_ context.Context, _, _ string, add, remove []string) error {
+	c.added = append(c.added, add...)
+	c.removed = append(c.removed, remove...)
+	for _, member := range remove {
+		c.members = slices.DeleteFunc(c.members, func(m string) bool { return m == member })
+	}
+	c.members = append(c.members, add...)
+	return nil
+}
+
+func (c *teamMembersClientMock) ListGitHubOrganizationTeams(
+	_ context.Context, githubOrg string,
+) ([]pulumiapi.GitHubTeam, error) {
+	c.githubOrg = githubOrg
+	return []pulumiapi.GitHubTeam{{ID: 7, Name: "Platform", Slug: "platform"}}, nil
+}
+
+func (c *teamMembersClientMock) team(name, teamType string) *pulumiapi.Team {
+	team := &pulumiapi.Team{Name: name, Type: teamType}
+	for _, m := range c.members {
+		team.Members = append(team.Members, pulumiapi.TeamMember{GithubLogin: m})
+	}
+	return team
+}
+
+func teamInput(mode TeamMembershipMode, members ...string) TeamInput {
+	return TeamInput{
+		TeamCore: TeamCore{
+			OrganizationName: gcABC,
+			Type:             teamTypePulumi,
+			Name:             ref("test"),
+			MembershipMode:   &mode,
+		},
+		Members: members,
+	}
+}
+
+func TestTeamCheck(t *testing.T) {
+	check := func(inputs map[string]property.Value) infer.CheckResponse[TeamInput] {
+		resp, err := (&Team{}).Check(context.Background(), infer.CheckRequest{NewInputs: property.NewMap(inputs)})
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("defaults membershipMode", func(t *testing.T) {
+		resp := check(map[string]property.Value{
+			gcOrganizationName: property.New(gcABC),
+			"teamType":         property.New(teamTypePulumi),
+			gcName:             property.New("test"),
+		})
+		assert.Empty(t, resp.Failures)
+		assert.Equal(t, ref(TeamMembershipAuthoritative), resp.Inputs.MembershipMode)
+
+		resp = check(map[string]property.Value{
+			gcOrganizationName: property.New(gcABC),
+			"teamType":         property.New(teamTypeGitHub),
+			gcGitHubTeamSlug:   property.New("platform"),
+		})
+		assert.Empty(t, resp.Failures)
+		assert.Equal(t, ref(TeamMembershipIgnore), resp.Inputs.MembershipMode)
+	})
+
+	t.Run("github teams", func(t *testing.T) {
+		resp := check(map[string]property.Value{
+			gcOrganizationName: property.New(gcABC),
+			"teamType":         property.New(teamTypeGitHub),
+			gcMembershipMode:   property.New(string(TeamMembershipAdditive)),
+		})
+		var properties []string
+		for _, f := range resp.Failures {
+			properties = append(properties, f.Property)
+		}
+		assert.ElementsMatch(t, []string{gcGitHubTeamID, gcMembershipMode}, properties)
+	})
+}
+
+func TestTeamDiffMembers(t *testing.T) {
+	diff := func(mode TeamMembershipMode) infer.DiffResponse {
+		state := TeamState{TeamCore: teamInput(mode).TeamCore, Members: []string{gcMember1}}
+		resp, err := (&Team{}).Diff(context.Background(), infer.DiffRequest[TeamInput, TeamState]{
+			State:  state,
+			Inputs: teamInput(mode, gcMember1, gcMember2),
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	assert.Contains(t, diff(TeamMembershipAuthoritative).DetailedDiff, gcMembers)
+	assert.Contains(t, diff(TeamMembershipAdditive).DetailedDiff, gcMembers)
+	assert.False(t, diff(TeamMembershipIgnore).HasChanges)
+}
+
+func TestTeamUpdateMembers(t *testing.T) {
+	update := func(
+		client *teamMembersClientMock, mode TeamMembershipMode, previous []string, members ...string,
+	) TeamState {
+		ctx := config.WithMockClient(context.Background(), client)
+		state := TeamState{TeamCore: teamInput(mode).TeamCore, Members: previous}
+		resp, err := (&Team{}).Update(ctx, infer.UpdateRequest[TeamInput, TeamState]{
+			ID: "abc/test", State: state, Inputs: teamInput(mode, members...),
+		})
+		require.NoError(t, err)
+		return resp.Output
+	}
+
+	t.Run("authoritative", func(t *testing.T) {
+		client := &teamMembersClientMock{members: []string{gcMember1, "outsider"}}
+		state := update(client, TeamMembershipAuthoritative, []string{gcMember1}, gcMember1, gcMember2)
+		assert.Equal(t, []string{gcMember2}, client.added)
+		assert.Equal(t, []string{"outsider"}, client.removed)
+		assert.Equal(t, []string{gcMember1, gcMember2}, state.Members)
+	})
+
+	t.Run("additive", func(t *testing.T) {
+		client := &teamMembersClientMock{members: []string{gcMember1, "outsider"}}
+		state := update(client, TeamMembershipAdditive, []string{gcMember1}, gcMember2)
+		assert.Equal(t, []string{gcMember2}, client.added)
+		assert.Equal(t, []string{gcMember1}, client.removed)
+		assert.Equal(t, []string{gcMember2}, state.Members)
+		assert.Contains(t, client.members, "outsider")
+	})
+
+	t.Run("ignore", func(t *testing.T) {
+		client := &teamMembersClientMock{members: []string{"outsider"}}
+		state := update(client, TeamMembershipIgnore, []string{"outsider"}, gcMember1)
+		assert.Empty(t, client.added)
+		assert.Empty(t, client.removed)
+		assert.Equal(t, []string{"outsider"}, state.Members)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		client := &teamMembersClientMock{members: []string{gcMember1}}
+		update(client, TeamMembershipAuthoritative, []string{gcMember1}, gcMember1)
+		assert.Empty(t, client.added)
+		assert.Empty(t, client.removed)
+	})
+}
+
+func TestTeamCreateResolvesGitHubTeamSlug(t *testing.T) {
+	client := &teamMembersClientMock{}
+	ctx := config.WithMockClient(context.Background(), client)
+	resp, err := (&Team{}).Create(ctx, infer.CreateRequest[TeamInput]{Inputs: TeamInput{
+		TeamCore: TeamCore{
+			OrganizationName: gcABC,
+			Type:             teamTypeGitHub,
+			GitHubTeamSlug:   ref("platform"),
+		},
+		Members: []string{gcMember1},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, ref(7.0), resp.Output.GitHubTeamID)
+	assert.Equal(t, gcABC, client.githubOrg, "githubOrganization defaults to organizationName")
+	assert.Empty(t, client.added)
+
+	client = &teamMembersClientMock{}
+	ctx = config.WithMockClient(context.Background(), client)
+	_, err = (&Team{}).Create(ctx, infer.CreateRequest[TeamInput]{Inputs: TeamInput{
+		TeamCore: TeamCore{
+			OrganizationName: gcABC,
+			Type:             teamTypeGitHub,
+			GitHubTeamSlug:   ref("unknown"),
+		},
+	}})
+	assert.ErrorContains(t, err, `no team with slug "unknown"`)
+}
+
+func TestTeamCreateResolvesGitHubTeamSlugInGitHubOrganization(t *testing.T) {
+	create := func(slug string) (*teamMembersClientMock, infer.CreateResponse[TeamState], error) {
+		client := &teamMembersClientMock{}
+		ctx := config.WithMockClient(context.Background(), client)
+		resp, err := (&Team{}).Create(ctx, infer.CreateRequest[TeamInput]{Inputs: TeamInput{
+			TeamCore: TeamCore{
+				OrganizationName:   gcABC,
+				Type:               teamTypeGitHub,
+				GitHubTeamSlug:     ref(slug),
+				GitHubOrganization: ref("abc-on-github"),
+			},
+		}})
+		return client, resp, err
+	}
+
+	client, resp, err := create("platform")
+	require.NoError(t, err)
+	assert.Equal(t, "abc-on-github", client.githubOrg)
+	assert.Equal(t, ref(7.0), resp.Output.GitHubTeamID)
+
+	_, _, err = create("unknown")
+	assert.EqualError(t, err, `GitHub organization "abc-on-github" has no team with slug "unknown"`)
+}
+
 func ref[T any](v T) *T { return &v }
@@ -0,0 +1,300 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/util"
+)
+
+const (
+	gcKinds         = "kinds"
+	gcUnusedForDays = "unusedForDays"
+	gcNoExpiry      = "noExpiry"
+	gcFindings      = "findings"
+)
+
+// tokenCreatedLayouts are the formats Pulumi Cloud reports token creation
+// times in.
+var tokenCreatedLayouts = []string{"2006-01-02 15:04:05.000", time.RFC3339}
+
+type TokenHygienePolicy struct {
+	now tokenClock
+}
+
+var (
+	_ infer.CustomCheck[TokenHygienePolicyInput]                           = &TokenHygienePolicy{}
+	_ infer.CustomCreate[TokenHygienePolicyInput, TokenHygienePolicyState] = &TokenHygienePolicy{}
+	_ infer.CustomDiff[TokenHygienePolicyInput, TokenHygienePolicyState]   = &TokenHygienePolicy{}
+	_ infer.CustomUpdate[TokenHygienePolicyInput, TokenHygienePolicyState] = &TokenHygienePolicy{}
+	_ infer.CustomRead[TokenHygienePolicyInput, TokenHygienePolicyState]   = &TokenHygienePolicy{}
+	_ infer.CustomDelete[TokenHygienePolicyState]                          = &TokenHygienePolicy{}
+)
+
+func (t *TokenHygienePolicy) Annotate(a infer.Annotator) {
+	a.Describe(t, "Revokes an organization's stale access tokens on every `pulumi up`: tokens unused for "+
+		"`unusedForDays` days, and tokens that never expire when `noExpiry` is set. "+
+		"Until `dryRun` is set to `false`, matching tokens are only reported in `findings`.\n\n"+
+		"The policy covers organization and team tokens, and the personal tokens of the user the provider "+
+		"authenticates as. List the provider's own token in `exclude` before turning off `dryRun`, or it may "+
+		"revoke itself. "+
+		"Deleting the policy stops revocations; revoked tokens are not restored.")
+	a.SetToken("index", "TokenHygienePolicy")
+}
+
+type TokenHygienePolicyInput struct {
+	OrganizationName string   `pulumi:"organizationName"`
+	Kinds            []string `pulumi:"kinds,optional"`
+	UnusedForDays    *int     `pulumi:"unusedForDays,optional"`
+	NoExpiry         *bool    `pulumi:"noExpiry,optional"`
+	Exclude          []string `pulumi:"exclude,optional"`
+	DryRun           *bool    `pulumi:"dryRun,optional"`
+}
+
+func (i *TokenHygienePolicyInput) Annotate(a infer.Annotator) {
+	a.Describe(&i.OrganizationName, "The organization whose tokens the policy applies to.")
+	a.Describe(&i.Kinds, "Which kinds of tokens the policy applies to: `organization`, `team` and `personal`. "+
+		"Defaults to all of them.")
+	a.Describe(&i.UnusedForDays, "Revoke tokens not used for this many days. A token that was never used "+
+		"counts from its creation.")
+	a.Describe(&i.NoExpiry, "Revoke tokens that never expire.")
+	a.Describe(&i.Exclude, "IDs or names of tokens the policy never revokes.")
+	a.Describe(&i.DryRun, "Only report matching tokens in `findings`, without revoking them. Defaults to `true`, "+
+		"so that the findings can be reviewed before any token is revoked.")
+	a.SetDefault(&i.DryRun, true)
+}
+
+type TokenHygieneFinding struct {
+	Kind     string  `pulumi:"kind"`
+	TokenID  string  `pulumi:"tokenId"`
+	Name     string  `pulumi:"name"`
+	TeamName *string `pulumi:"teamName,optional"`
+	Reason   string  `pulumi:"reason"`
+	Revoked  bool    `pulumi:"revoked"`
+}
+
+func (f *TokenHygieneFinding) Annotate(a infer.Annotator) {
+	a.Describe(&f.Kind, "The kind of token: `organization`, `team` or `personal`.")
+	a.Describe(&f.TokenID, "The token's ID.")
+	a.Describe(&f.Name, "The token's name.")
+	a.Describe(&f.TeamName, "The team a team token belongs to.")
+	a.Describe(&f.Reason, "Why the token matched the policy.")
+	a.Describe(&f.Revoked, "Whether the token was revoked. False in dry-run mode or when revoking failed.")
+}
+
+type TokenHygienePolicyState struct {
+	TokenHygienePolicyInput
+	Findings []TokenHygieneFinding `pulumi:"findings"`
+	LastRun  string                `pulumi:"lastRun"`
+}
+
+func (s *TokenHygienePolicyState) Annotate(a infer.Annotator) {
+	a.Describe(&s.Findings, "The tokens that matched the policy on its last run.")
+	a.Describe(&s.LastRun, "When the policy last ran, in RFC 3339 format.")
+}
+
+func (*TokenHygienePolicy) Check(
+	ctx context.Context, req infer.CheckRequest,
+) (infer.CheckResponse[TokenHygienePolicyInput], error) {
+	i, failures, err := infer.DefaultCheck[TokenHygienePolicyInput](ctx, req.NewInputs)
+	if err != nil {
+		return infer.CheckResponse[TokenHygienePolicyInput]{}, err
+	}
+	if i.UnusedForDays == nil && (i.NoExpiry == nil || !*i.NoExpiry) {
+		failures = append(failures, p.CheckFailure{
+			Property: gcUnusedForDays,
+			Reason:   "at least one of unusedForDays or noExpiry must be set",
+		})
+	}
+	if i.UnusedForDays != nil && *i.UnusedForDays < 1 {
+		failures = append(failures, p.CheckFailure{
+			Property: gcUnusedForDays,
+			Reason:   "unusedForDays must be at least 1",
+		})
+	}
+	for _, kind := range i.Kinds {
+		if !slices.Contains(pulumiapi.TokenKinds, kind) {
+			failures = append(failures, p.CheckFailure{
+				Property: gcKinds,
+				Reason:   fmt.Sprintf("kinds must be among %v, got %q", pulumiapi.TokenKinds, kind),
+			})
+		}
+	}
+	return infer.CheckResponse[TokenHygienePolicyInput]{Inputs: i, Failures: failures}, nil
+}
+
+// Diff always reports findings as changed, so the policy runs on every update.
+// A policy moved to another organization is replaced.
+func (*TokenHygienePolicy) Diff(
+	_ context.Context,
+	req infer.DiffRequest[TokenHygienePolicyInput, TokenHygienePolicyState],
+) (infer.DiffResponse, error) {
+	diff := map[string]p.PropertyDiff{gcFindings: {Kind: p.Update}}
+	if req.State.OrganizationName != req.Inputs.OrganizationName {
+		diff[gcOrganizationName] = p.PropertyDiff{Kind: p.UpdateReplace, InputDiff: true}
+	}
+	return infer.DiffResponse{HasChanges: true, DetailedDiff: diff}, nil
+}
+
+func (t *TokenHygienePolicy) Create(
+	ctx context.Context,
+	req infer.CreateRequest[TokenHygienePolicyInput],
+) (infer.CreateResponse[TokenHygienePolicyState], error) {
+	if req.DryRun {
+		return infer.CreateResponse[TokenHygienePolicyState]{
+			Output: TokenHygienePolicyState{TokenHygienePolicyInput: req.Inputs},
+		}, nil
+	}
+	state, err := t.run(ctx, req.Inputs)
+	if state == nil {
+		return infer.CreateResponse[TokenHygienePolicyState]{}, err
+	}
+	// Several policies may cover the same organization; the resource name
+	// tells them apart.
+	id := fmt.Sprintf("%s/%s", req.Inputs.OrganizationName, req.Name)
+	return infer.CreateResponse[TokenHygienePolicyState]{ID: id, Output: *state}, err
+}
+
+func (t *TokenHygienePolicy) Update(
+	ctx context.Context,
+	req infer.UpdateRequest[TokenHygienePolicyInput, TokenHygienePolicyState],
+) (infer.UpdateResponse[TokenHygienePolicyState], error) {
+	if req.DryRun {
+		output := req.State
+		output.TokenHygienePolicyInput = req.Inputs
+		return infer.UpdateResponse[TokenHygienePolicyState]{Output: output}, nil
+	}
+	state, err := t.run(ctx, req.Inputs)
+	if state == nil {
+		return infer.UpdateResponse[TokenHygienePolicyState]{}, err
+	}
+	return infer.UpdateResponse[TokenHygienePolicyState]{Output: *state}, err
+}
+
+// run revokes every token matching the policy, unless it is a dry run. Once
+// the tokens are listed, the findings are returned even when revoking some
+// fails, together with an infer.ResourceInitFailedError, so the next update
+// tries again.
+func (t *TokenHygienePolicy) run(ctx context.Context, input TokenHygienePolicyInput) (*TokenHygienePolicyState, error) {
+	client := config.GetClient(ctx)
+	tokens, err := client.ListTokenInventory(ctx, input.OrganizationName, input.Kinds)
+	if err != nil {
+		return nil, err
+	}
+	now := t.now.now()
+	state := &TokenHygienePolicyState{
+		TokenHygienePolicyInput: input,
+		Findings:                []TokenHygieneFinding{},
+		LastRun:                 now.UTC().Format(time.RFC3339),
+	}
+	var failures []string
+	for _, token := range tokens {
+		reason := input.match(token, now)
+		if reason == "" {
+			continue
+		}
+		finding := TokenHygieneFinding{
+			Kind:     token.Kind,
+			TokenID:  token.ID,
+			Name:     token.Name,
+			TeamName: stringPtrIfNonEmpty(token.TeamName),
+			Reason:   reason,
+		}
+		if input.revokes() {
+			if err := client.RevokeInventoryToken(ctx, input.OrganizationName, token); err != nil {
+				failures = append(failures, err.Error())
+			} else {
+				finding.Revoked = true
+			}
+		}
+		state.Findings = append(state.Findings, finding)
+	}
+	if len(failures) > 0 {
+		return state, infer.ResourceInitFailedError{Reasons: failures}
+	}
+	return state, nil
+}
+
+// revokes reports whether the policy revokes the tokens it matches, rather
+// than only reporting them.
+func (i *TokenHygienePolicyInput) revokes() bool {
+	return i.DryRun != nil && !*i.DryRun
+}
+
+// match returns why the token violates the policy, or "" if it does not or
+// is excluded.
+func (i *TokenHygienePolicyInput) match(token pulumiapi.InventoryToken, now time.Time) string {
+	if slices.Contains(i.Exclude, token.ID) || (token.Name != "" && slices.Contains(i.Exclude, token.Name)) {
+		return ""
+	}
+	var reasons []string
+	if i.UnusedForDays != nil {
+		cutoff := now.Add(-time.Duration(*i.UnusedForDays) * 24 * time.Hour)
+		if token.LastUsed != 0 {
+			if time.Unix(token.LastUsed, 0).Before(cutoff) {
+				reasons = append(reasons, fmt.Sprintf("unused for more than %d days", *i.UnusedForDays))
+			}
+		} else if created, ok := parseTokenCreated(token.Created); ok && created.Before(cutoff) {
+			reasons = append(reasons, fmt.Sprintf("never used, created more than %d days ago", *i.UnusedForDays))
+		}
+	}
+	if util.OrZero(i.NoExpiry) && token.Expires == 0 {
+		reasons = append(reasons, "never expires")
+	}
+	return strings.Join(reasons, "; ")
+}
+
+func parseTokenCreated(created string) (time.Time, bool) {
+	for _, layout := range tokenCreatedLayouts {
+		if t, err := time.Parse(layout, created); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (*TokenHygienePolicy) Read(
+	_ context.Context,
+	req infer.ReadRequest[TokenHygienePolicyInput, TokenHygienePolicyState],
+) (infer.ReadResponse[TokenHygienePolicyInput, TokenHygienePolicyState], error) {
+	if req.State.OrganizationName == "" {
+		return infer.ReadResponse[TokenHygienePolicyInput, TokenHygienePolicyState]{},
+			fmt.Errorf("importing a TokenHygienePolicy is not supported; declare it to apply the policy")
+	}
+	// The policy has no remote state: the findings of its last run stand.
+	return infer.ReadResponse[TokenHygienePolicyInput, TokenHygienePolicyState]{
+		ID:     req.ID,
+		Inputs: req.Inputs,
+		State:  req.State,
+	}, nil
+}
+
+// Delete only forgets the policy; revoked tokens stay revoked.
+func (*TokenHygienePolicy) Delete(
+	context.Context,
+	infer.DeleteRequest[TokenHygienePolicyState],
+) (infer.DeleteResponse, error) {
+	return infer.DeleteResponse{}, nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+type tokenInventoryClientMock struct {
+	config.Client
+	tokens    []pulumiapi.InventoryToken
+	revoked   []string
+	revokeErr error
+}
+
+func (c *tokenInventoryClientMock) ListTokenInventory(
+	context.Context, string, []string,
+) ([]pulumiapi.InventoryToken, error) {
+	return c.tokens, nil
+}
+
+func (c *tokenInventoryClientMock) RevokeInventoryToken(
+	_ context.Context, _ string, token pulumiapi.InventoryToken,
+) error {
+	if c.revokeErr != nil {
+		return c.revokeErr
+	}
+	c.revoked = append(c.revoked, token.ID)
+	return nil
+}
+
+func TestTokenHygienePolicyCheck(t *testing.T) {
+	check := func(t *testing.T, inputs map[string]property.Value) []string {
+		t.Helper()
+		inputs["organizationName"] = property.New(gcMyOrg)
+		resp, err := (&TokenHygienePolicy{}).Check(context.Background(), infer.CheckRequest{
+			NewInputs: property.NewMap(inputs),
+		})
+		require.NoError(t, err)
+		var reasons []string
+		for _, f := range resp.Failures {
+			reasons = append(reasons, f.Reason)
+		}
+		return reasons
+	}
+
+	assert.Empty(t, check(t, map[string]property.Value{"unusedForDays": property.New(90.0)}))
+	resp, err := (&TokenHygienePolicy{}).Check(context.Background(), infer.CheckRequest{
+		NewInputs: property.NewMap(map[string]property.Value{
+			"organizationName": property.New(gcMyOrg),
+			"unusedForDays":    property.New(90.0),
+		}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ptr(true), resp.Inputs.DryRun, "dryRun defaults to true")
+	assert.Equal(t, []string{"at least one of unusedForDays or noExpiry must be set"},
+		check(t, map[string]property.Value{"noExpiry": property.New(false)}))
+	assert.Equal(t, []string{"unusedForDays must be at least 1"},
+		check(t, map[string]property.Value{"unusedForDays": property.New(0.0)}))
+	assert.Equal(t, []string{`kinds must be among [organization team personal], got "robot"`},
+		check(t, map[string]property.Value{
+			"noExpiry": property.New(true),
+			"kinds":    property.New([]property.Value{property.New("robot")}),
+		}))
+}
+
+func TestTokenHygienePolicyRun(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) int64 { return now.Add(-time.Duration(days) * 24 * time.Hour).Unix() }
+	tokens := []pulumiapi.InventoryToken{
+		{
+			AccessTokenSummary: pulumiapi.AccessTokenSummary{ID: "stale", Name: "ci", LastUsed: daysAgo(100)},
+			Kind:               pulumiapi.TokenKindOrganization,
+		},
+		{
+			AccessTokenSummary: pulumiapi.AccessTokenSummary{ID: "fresh", LastUsed: daysAgo(1), Expires: daysAgo(-30)},
+			Kind:               pulumiapi.TokenKindTeam,
+			TeamName:           "ops",
+		},
+		{
+			AccessTokenSummary: pulumiapi.AccessTokenSummary{ID: "idle", Created: "2026-01-01 00:00:00.000"},
+			Kind:               pulumiapi.TokenKindPersonal,
+		},
+		{
+			AccessTokenSummary: pulumiapi.AccessTokenSummary{ID: "provider", Name: "pulumi", LastUsed: daysAgo(200)},
+			Kind:               pulumiapi.TokenKindPersonal,
+		},
+	}
+	policy := &TokenHygienePolicy{now: func() time.Time { return now }}
+	unusedForDays, yes, no := 90, true, false
+	input := TokenHygienePolicyInput{
+		OrganizationName: gcMyOrg,
+		UnusedForDays:    &unusedForDays,
+		Exclude:          []string{"pulumi"},
+		DryRun:           &no,
+	}
+
+	t.Run("revokes stale tokens", func(t *testing.T) {
+		client := &tokenInventoryClientMock{tokens: tokens}
+		ctx := config.WithMockClient(context.Background(), client)
+
+		resp, err := policy.Create(ctx, infer.CreateRequest[TokenHygienePolicyInput]{Name: "stale-tokens", Inputs: input})
+		require.NoError(t, err)
+		assert.Equal(t, "my-org/stale-tokens", resp.ID)
+		assert.Equal(t, []string{"stale", "idle"}, client.revoked)
+		assert.Equal(t, []TokenHygieneFinding{
+			{Kind: "organization", TokenID: "stale", Name: "ci", Reason: "unused for more than 90 days", Revoked: true},
+			{Kind: "personal", TokenID: "idle", Reason: "never used, created more than 90 days ago", Revoked: true},
+		}, resp.Output.Findings)
+		assert.Equal(t, "2026-06-01T00:00:00Z", resp.Output.LastRun)
+	})
+
+	t.Run("dry run only reports", func(t *testing.T) {
+		client := &tokenInventoryClientMock{tokens: tokens}
+		ctx := config.WithMockClient(context.Background(), client)
+
+		dryRun := input
+		dryRun.UnusedForDays = nil
+		dryRun.NoExpiry = &yes
+		dryRun.DryRun = &yes
+		resp, err := policy.Update(ctx, infer.UpdateRequest[TokenHygienePolicyInput, TokenHygienePolicyState]{
+			ID: "my-org/stale-tokens", Inputs: dryRun,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, client.revoked)
+		require.Len(t, resp.Output.Findings, 2)
+		assert.Equal(t, "stale", resp.Output.Findings[0].TokenID)
+		assert.Equal(t, "never expires", resp.Output.Findings[0].Reason)
+		assert.False(t, resp.Output.Findings[0].Revoked)
+	})
+
+	t.Run("dry run by default", func(t *testing.T) {
+		client := &tokenInventoryClientMock{tokens: tokens}
+		ctx := config.WithMockClient(context.Background(), client)
+
+		unset := input
+		unset.DryRun = nil
+		resp, err := policy.Create(ctx, infer.CreateRequest[TokenHygienePolicyInput]{Name: "stale-tokens", Inputs: unset})
+		require.NoError(t, err)
+		assert.Empty(t, client.revoked)
+		assert.Len(t, resp.Output.Findings, 2)
+	})
+
+	t.Run("records revoke failures", func(t *testing.T) {
+		client := &tokenInventoryClientMock{tokens: tokens, revokeErr: errors.New("forbidden")}
+		ctx := config.WithMockClient(context.Background(), client)
+
+		resp, err := policy.Create(ctx, infer.CreateRequest[TokenHygienePolicyInput]{Inputs: input})
+		var initErr infer.ResourceInitFailedError
+		require.ErrorAs(t, err, &initErr)
+		assert.Equal(t, []string{"forbidden", "forbidden"}, initErr.Reasons)
+		require.Len(t, resp.Output.Findings, 2)
+		assert.False(t, resp.Output.Findings[0].Revoked)
+	})
+}
+
+func TestTokenHygienePolicyDiffAlwaysRuns(t *testing.T) {
+	resp, err := (&TokenHygienePolicy{}).Diff(context.Background(),
+		infer.DiffRequest[TokenHygienePolicyInput, TokenHygienePolicyState]{})
+	require.NoError(t, err)
+	assert.True(t, resp.HasChanges)
+}
+
+func TestTokenHygienePolicyDiffReplacesOnOrganization(t *testing.T) {
+	resp, err := (&TokenHygienePolicy{}).Diff(context.Background(),
+		infer.DiffRequest[TokenHygienePolicyInput, TokenHygienePolicyState]{
+			State:  TokenHygienePolicyState{TokenHygienePolicyInput: TokenHygienePolicyInput{OrganizationName: gcMyOrg}},
+			Inputs: TokenHygienePolicyInput{OrganizationName: "other-org"},
+		})
+	require.NoError(t, err)
+	assert.Equal(t, p.UpdateReplace, resp.DetailedDiff[gcOrganizationName].Kind)
+	assert.Equal(t, p.Update, resp.DetailedDiff[gcFindings].Kind)
+}
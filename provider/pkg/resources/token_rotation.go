@@ -0,0 +1,368 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+const (
+	gcExpires         = "expires"
+	gcTTL             = "ttl"
+	gcRotation        = "rotation"
+	gcPreviousTokenID = "previousTokenId"
+
+	tokenStatusActive  = "active"
+	tokenStatusExpired = "expired"
+
+	// defaultKeepPrevious is how long a rotated-out token stays valid when
+	// the rotation doesn't say.
+	defaultKeepPrevious = 24 * time.Hour
+)
+
+// tokenClock returns the current time; nil uses time.Now. Tests override it
+// to make a rotation come due.
+type tokenClock func() time.Time
+
+func (c tokenClock) now() time.Time {
+	if c == nil {
+		return time.Now()
+	}
+	return c()
+}
+
+// TokenExpiry is the expiry and rotation policy shared by access tokens.
+type TokenExpiry struct {
+	Expires  *string        `pulumi:"expires,optional"  provider:"replaceOnChanges"`
+	TTL      *string        `pulumi:"ttl,optional"      provider:"replaceOnChanges"`
+	Rotation *TokenRotation `pulumi:"rotation,optional"`
+}
+
+func (e *TokenExpiry) Annotate(a infer.Annotator) {
+	a.Describe(&e.Expires, "When the token expires, in RFC 3339 format, e.g. `2026-12-31T00:00:00Z`. "+
+		"Only one of `expires` and `ttl` may be set; without either the token never expires. "+
+		"As an output, the expiry of the token currently issued.")
+	a.Describe(&e.TTL, "How long the token is valid after it is issued, e.g. `90d` or `720h`. "+
+		"Unlike `expires`, every token issued by a `rotation` gets a fresh expiry.")
+	a.Describe(&e.Rotation, "Issues a successor token ahead of expiry, on the first `pulumi up` within "+
+		"`rotateBefore` of it. Requires `ttl`.")
+}
+
+// TokenRotation issues a successor of an expiring token, keeping the
+// previous token valid for an overlap window so its consumers can move over.
+type TokenRotation struct {
+	RotateBefore string  `pulumi:"rotateBefore"`
+	KeepPrevious *string `pulumi:"keepPrevious,optional"`
+}
+
+func (r *TokenRotation) Annotate(a infer.Annotator) {
+	a.Describe(&r.RotateBefore, "How long before expiry the token is rotated, e.g. `14d`.")
+	a.Describe(&r.KeepPrevious, "How long the previous token stays valid after a rotation, e.g. `48h`. "+
+		"It is deleted by the first `pulumi up` after that. Defaults to `24h`.")
+}
+
+// TokenRotationState tracks the token currently issued and, during an
+// overlap window, the one it replaced.
+type TokenRotationState struct {
+	TokenID                  string  `pulumi:"tokenId,optional"`
+	LastUsed                 *string `pulumi:"lastUsed,optional"`
+	PreviousTokenID          *string `pulumi:"previousTokenId,optional"`
+	PreviousTokenDeleteAfter *string `pulumi:"previousTokenDeleteAfter,optional"`
+	Status                   *string `pulumi:"status,optional"`
+}
+
+func (s *TokenRotationState) Annotate(a infer.Annotator) {
+	a.Describe(&s.TokenID, "The ID of the token currently issued, which changes on rotation.")
+	a.Describe(&s.LastUsed, "When the token was last used, in RFC 3339 format, as of the last refresh.")
+	a.Describe(&s.PreviousTokenID, "The ID of the token replaced by the last rotation, while it stays valid.")
+	a.Describe(&s.PreviousTokenDeleteAfter, "When the previous token is due for deletion, in RFC 3339 format.")
+	a.Describe(&s.Status, "Whether the token is `active` or `expired`, as of the last refresh.")
+}
+
+// parseTokenDuration parses a Go duration, or a whole number of days such
+// as `90d`.
+func parseTokenDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// formatUnix formats a Unix timestamp of the API, nil when it is zero.
+func formatUnix(seconds int64) *string {
+	if seconds == 0 {
+		return nil
+	}
+	formatted := time.Unix(seconds, 0).UTC().Format(time.RFC3339)
+	return &formatted
+}
+
+func (e *TokenExpiry) check() []p.CheckFailure {
+	var failures []p.CheckFailure
+	fail := func(property, reason string) {
+		failures = append(failures, p.CheckFailure{Property: property, Reason: reason})
+	}
+	if e.Expires != nil && e.TTL != nil {
+		fail(gcTTL, "only one of `expires` and `ttl` may be set")
+	}
+	if e.Expires != nil {
+		if _, err := time.Parse(time.RFC3339, *e.Expires); err != nil {
+			fail(gcExpires, fmt.Sprintf("`expires` must be an RFC 3339 timestamp: %v", err))
+		}
+	}
+	var ttl time.Duration
+	if e.TTL != nil {
+		var err error
+		if ttl, err = parseTokenDuration(*e.TTL); err != nil || ttl <= 0 {
+			fail(gcTTL, fmt.Sprintf("`ttl` must be a positive duration such as `90d`, got %q", *e.TTL))
+		}
+	}
+	if e.Rotation == nil {
+		return failures
+	}
+	if e.TTL == nil {
+		fail(gcRotation, "`rotation` requires `ttl`: a successor of a token with a fixed `expires` "+
+			"would expire with it")
+	}
+	before, err := parseTokenDuration(e.Rotation.RotateBefore)
+	switch {
+	case err != nil || before <= 0:
+		fail(gcRotation+".rotateBefore", fmt.Sprintf("`rotateBefore` must be a positive duration such as `14d`, "+
+			"got %q", e.Rotation.RotateBefore))
+	case ttl > 0 && before >= ttl:
+		fail(gcRotation+".rotateBefore", "`rotateBefore` must be shorter than `ttl`")
+	}
+	if e.Rotation.KeepPrevious != nil {
+		if keep, err := parseTokenDuration(*e.Rotation.KeepPrevious); err != nil || keep < 0 {
+			fail(gcRotation+".keepPrevious", fmt.Sprintf("`keepPrevious` must be a duration such as `48h`, "+
+				"got %q", *e.Rotation.KeepPrevious))
+		}
+	}
+	return failures
+}
+
+// expiresAt returns when a token issued at now expires, as a Unix timestamp,
+// or zero for a token that never expires. The policy must have passed check.
+func (e *TokenExpiry) expiresAt(now time.Time) int64 {
+	switch {
+	case e.Expires != nil:
+		expires, _ := time.Parse(time.RFC3339, *e.Expires)
+		return expires.Unix()
+	case e.TTL != nil:
+		ttl, _ := parseTokenDuration(*e.TTL)
+		return now.Add(ttl).Unix()
+	default:
+		return 0
+	}
+}
+
+// issued returns the policy as recorded in state for a token expiring at
+// expires: the requested policy with its actual expiry.
+func (e TokenExpiry) issued(expires int64) TokenExpiry {
+	e.Expires = formatUnix(expires)
+	return e
+}
+
+// requestedExpires returns the `expires` input behind a recorded policy.
+func (e *TokenExpiry) requestedExpires() *string {
+	if e.TTL != nil {
+		return nil
+	}
+	return e.Expires
+}
+
+// rotationDue reports whether the token recorded in olds, expiring at its
+// Expires, is due for rotation under the news policy.
+func (e *TokenExpiry) rotationDue(olds TokenExpiry, now time.Time) bool {
+	if e.Rotation == nil || e.TTL == nil || olds.Expires == nil {
+		return false
+	}
+	expires, err := time.Parse(time.RFC3339, *olds.Expires)
+	if err != nil {
+		return false
+	}
+	before, err := parseTokenDuration(e.Rotation.RotateBefore)
+	return err == nil && !now.Before(expires.Add(-before))
+}
+
+func (s *TokenRotationState) previousDue(now time.Time) bool {
+	if s.PreviousTokenID == nil || s.PreviousTokenDeleteAfter == nil {
+		return false
+	}
+	deleteAfter, err := time.Parse(time.RFC3339, *s.PreviousTokenDeleteAfter)
+	return err != nil || !now.Before(deleteAfter)
+}
+
+// diffTokenExpiry adds the changes of the expiry policy to diff, together
+// with a rotation or deletion of the previous token that has come due.
+func diffTokenExpiry(
+	diff map[string]p.PropertyDiff, olds TokenExpiry, state TokenRotationState, news TokenExpiry, now time.Time,
+) {
+	if !reflect.DeepEqual(olds.requestedExpires(), news.Expires) {
+		diff[gcExpires] = p.PropertyDiff{Kind: p.UpdateReplace, InputDiff: true}
+	}
+	if !reflect.DeepEqual(olds.TTL, news.TTL) {
+		diff[gcTTL] = p.PropertyDiff{Kind: p.UpdateReplace, InputDiff: true}
+	}
+	if !reflect.DeepEqual(olds.Rotation, news.Rotation) {
+		diff[gcRotation] = p.PropertyDiff{Kind: p.Update, InputDiff: true}
+	}
+	if news.rotationDue(olds, now) {
+		diff[gcValue] = p.PropertyDiff{Kind: p.Update}
+	}
+	if state.previousDue(now) {
+		diff[gcPreviousTokenID] = p.PropertyDiff{Kind: p.Delete}
+	}
+}
+
+// tokenRotation issues and deletes the tokens of one token resource.
+type tokenRotation struct {
+	issue  func(expires int64) (*pulumiapi.AccessToken, error)
+	delete func(tokenID string) error
+}
+
+// rotated is the outcome of tokenRotation.apply.
+type rotated struct {
+	expiry TokenExpiry
+	state  TokenRotationState
+	// value is the successor's value, or nil when the token was not rotated.
+	value *string
+}
+
+// apply deletes the previous token once its overlap window has ended, and
+// issues a successor when the current token is due for rotation. On error,
+// the progress made so far is returned with it.
+func (r tokenRotation) apply(olds TokenExpiry, state TokenRotationState, news TokenExpiry, now time.Time) (
+	rotated, error,
+) {
+	result := rotated{expiry: news, state: state}
+	result.expiry.Expires = olds.Expires
+
+	due := news.rotationDue(olds, now)
+	// A token replaced by an earlier rotation can't outlive a new one.
+	if state.previousDue(now) || due && state.PreviousTokenID != nil {
+		if err := r.deleteIfExists(*state.PreviousTokenID); err != nil {
+			return result, err
+		}
+		result.state.PreviousTokenID = nil
+		result.state.PreviousTokenDeleteAfter = nil
+	}
+	if !due {
+		return result, nil
+	}
+
+	token, err := r.issue(news.expiresAt(now))
+	if err != nil {
+		return result, err
+	}
+	keep := defaultKeepPrevious
+	if news.Rotation.KeepPrevious != nil {
+		keep, _ = parseTokenDuration(*news.Rotation.KeepPrevious)
+	}
+	previous := result.state.TokenID
+	result.expiry = news.issued(token.Expires)
+	result.state = TokenRotationState{TokenID: token.ID}
+	result.value = &token.TokenValue
+	deleteAfter := now.Add(keep).UTC().Format(time.RFC3339)
+	result.state.PreviousTokenID = &previous
+	result.state.PreviousTokenDeleteAfter = &deleteAfter
+	if keep > 0 {
+		return result, nil
+	}
+	if err := r.deleteIfExists(previous); err != nil {
+		return result, err
+	}
+	result.state.PreviousTokenID = nil
+	result.state.PreviousTokenDeleteAfter = nil
+	return result, nil
+}
+
+// deleteIfExists deletes a token that may have expired and been removed
+// already.
+func (r tokenRotation) deleteIfExists(tokenID string) error {
+	if err := r.delete(tokenID); err != nil && pulumiapi.GetErrorStatusCode(err) != http.StatusNotFound {
+		return err
+	}
+	return nil
+}
+
+// deleteTokens deletes the token currently issued and a previous one still
+// in its overlap window.
+func (r tokenRotation) deleteTokens(state TokenRotationState) error {
+	if state.PreviousTokenID != nil {
+		if err := r.deleteIfExists(*state.PreviousTokenID); err != nil {
+			return err
+		}
+	}
+	return r.delete(state.TokenID)
+}
+
+// readTokenExpiry returns the expiry policy of a token read from Pulumi
+// Cloud, as inputs and as state. The policy itself can't be read back, so
+// it is kept from inputs; an imported token gets its expiry as `expires`.
+func readTokenExpiry(inputs TokenExpiry, token pulumiapi.AccessToken) (TokenExpiry, TokenExpiry) {
+	if inputs.Expires == nil && inputs.TTL == nil {
+		inputs.Expires = formatUnix(token.Expires)
+	}
+	return inputs, inputs.issued(token.Expires)
+}
+
+// read refreshes the rotation state from the token currently issued.
+func (s TokenRotationState) read(token pulumiapi.AccessToken, now time.Time) TokenRotationState {
+	s.LastUsed = formatUnix(token.LastUsed)
+	status := tokenStatusActive
+	if token.Expires != 0 && !now.Before(time.Unix(token.Expires, 0)) {
+		status = tokenStatusExpired
+	}
+	s.Status = &status
+	return s
+}
+
+// tokenUpdateError wraps a failed rotation, so the next update retries it.
+func tokenUpdateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return infer.ResourceInitFailedError{Reasons: []string{err.Error()}}
+}
+
+// current returns the state with the ID of the token currently issued,
+// which states from before rotation only record in the resource ID.
+func (s TokenRotationState) current(tokenID string) TokenRotationState {
+	if s.TokenID == "" {
+		s.TokenID = tokenID
+	}
+	return s
+}
+
+// successorName names the successor of a rotated token, since token names
+// are unique within an organization.
+func successorName(name string, now time.Time) string {
+	return fmt.Sprintf("%s-%s", name, now.UTC().Format("20060102150405"))
+}
@@ -0,0 +1,218 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+)
+
+// orgTokenClientMock issues org tokens tok-1, tok-2, ... and records the
+// names they were issued under and the tokens deleted. It reads back the
+// tokens in existing.
+type orgTokenClientMock struct {
+	config.Client
+	issued   []string
+	expires  []int64
+	deleted  []string
+	existing []pulumiapi.AccessToken
+}
+
+func (c *orgTokenClientMock) GetOrgAccessToken(_ context.Context, tokenID, _ string) (*pulumiapi.AccessToken, error) {
+	for _, token := range c.existing {
+		if token.ID == tokenID {
+			return &token, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *orgTokenClientMock) CreateOrgAccessToken(
+	_ context.Context, name, _, _ string, _ bool, expires int64,
+) (*pulumiapi.AccessToken, error) {
+	c.issued = append(c.issued, name)
+	c.expires = append(c.expires, expires)
+	id := fmt.Sprintf("tok-%d", len(c.issued))
+	return &pulumiapi.AccessToken{ID: id, Name: name, TokenValue: "value-" + id, Expires: expires}, nil
+}
+
+func (c *orgTokenClientMock) DeleteOrgAccessToken(_ context.Context, tokenID, _ string) error {
+	c.deleted = append(c.deleted, tokenID)
+	return nil
+}
+
+var tokenEpoch = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func clockAt(t time.Time) tokenClock {
+	return func() time.Time { return t }
+}
+
+func rotatingTokenInput() OrgAccessTokenInput {
+	ttl, keep := "30d", "48h"
+	return OrgAccessTokenInput{
+		Name:             "ci",
+		OrganizationName: gcMyOrg,
+		TokenExpiry: TokenExpiry{
+			TTL:      &ttl,
+			Rotation: &TokenRotation{RotateBefore: "7d", KeepPrevious: &keep},
+		},
+	}
+}
+
+func TestTokenExpiryCheck(t *testing.T) {
+	str := func(s string) *string { return &s }
+	for name, tc := range map[string]struct {
+		expiry   TokenExpiry
+		property string
+	}{
+		"valid ttl":         {expiry: TokenExpiry{TTL: str("90d")}},
+		"valid expires":     {expiry: TokenExpiry{Expires: str("2026-12-31T00:00:00Z")}},
+		"expires and ttl":   {expiry: TokenExpiry{Expires: str("2026-12-31T00:00:00Z"), TTL: str("90d")}, property: gcTTL},
+		"malformed expires": {expiry: TokenExpiry{Expires: str("tomorrow")}, property: gcExpires},
+		"malformed ttl":     {expiry: TokenExpiry{TTL: str("90 days")}, property: gcTTL},
+		"rotation without ttl": {
+			expiry:   TokenExpiry{Rotation: &TokenRotation{RotateBefore: "7d"}},
+			property: gcRotation,
+		},
+		"rotation after expiry": {
+			expiry:   TokenExpiry{TTL: str("7d"), Rotation: &TokenRotation{RotateBefore: "7d"}},
+			property: "rotation.rotateBefore",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			failures := tc.expiry.check()
+			if tc.property == "" {
+				assert.Empty(t, failures)
+				return
+			}
+			require.Len(t, failures, 1)
+			assert.Equal(t, tc.property, failures[0].Property)
+		})
+	}
+}
+
+func TestOrgAccessTokenRotation(t *testing.T) {
+	client := &orgTokenClientMock{}
+	ctx := config.WithMockClient(context.Background(), client)
+
+	token := &OrgAccessToken{now: clockAt(tokenEpoch)}
+	created, err := token.Create(ctx, infer.CreateRequest[OrgAccessTokenInput]{Inputs: rotatingTokenInput()})
+	require.NoError(t, err)
+	assert.Equal(t, "my-org/ci/tok-1", created.ID)
+	assert.Equal(t, []int64{tokenEpoch.Add(30 * 24 * time.Hour).Unix()}, client.expires)
+	assert.Equal(t, "2026-01-31T00:00:00Z", *created.Output.Expires)
+	assert.Equal(t, "tok-1", created.Output.TokenID)
+
+	diff := func(at time.Time, state OrgAccessTokenState) infer.DiffResponse {
+		resp, err := (&OrgAccessToken{now: clockAt(at)}).Diff(ctx,
+			infer.DiffRequest[OrgAccessTokenInput, OrgAccessTokenState]{
+				ID: created.ID, State: state, Inputs: rotatingTokenInput(),
+			})
+		require.NoError(t, err)
+		return resp
+	}
+	update := func(at time.Time, state OrgAccessTokenState) OrgAccessTokenState {
+		resp, err := (&OrgAccessToken{now: clockAt(at)}).Update(ctx,
+			infer.UpdateRequest[OrgAccessTokenInput, OrgAccessTokenState]{
+				ID: created.ID, State: state, Inputs: rotatingTokenInput(),
+			})
+		require.NoError(t, err)
+		return resp.Output
+	}
+
+	t.Run("no changes before the rotation window", func(t *testing.T) {
+		assert.False(t, diff(tokenEpoch.Add(20*24*time.Hour), created.Output).HasChanges)
+	})
+
+	rotateAt := tokenEpoch.Add(24 * 24 * time.Hour)
+	rotated := created.Output
+	t.Run("rotates within the window", func(t *testing.T) {
+		resp := diff(rotateAt, created.Output)
+		assert.True(t, resp.HasChanges)
+		assert.Equal(t, p.Update, resp.DetailedDiff[gcValue].Kind)
+
+		rotated = update(rotateAt, created.Output)
+		assert.Equal(t, []string{"ci", "ci-20260125000000"}, client.issued)
+		assert.Empty(t, client.deleted, "the previous token stays valid")
+		assert.Equal(t, "tok-2", rotated.TokenID)
+		assert.Equal(t, "value-tok-2", rotated.Value)
+		assert.Equal(t, "2026-02-24T00:00:00Z", *rotated.Expires)
+		assert.Equal(t, "ci", rotated.Name)
+		require.NotNil(t, rotated.PreviousTokenID)
+		assert.Equal(t, "tok-1", *rotated.PreviousTokenID)
+		assert.Equal(t, "2026-01-27T00:00:00Z", *rotated.PreviousTokenDeleteAfter)
+	})
+
+	t.Run("deletes the previous token after the overlap window", func(t *testing.T) {
+		assert.False(t, diff(rotateAt.Add(time.Hour), rotated).HasChanges)
+
+		later := rotateAt.Add(48 * time.Hour)
+		resp := diff(later, rotated)
+		assert.Equal(t, p.Delete, resp.DetailedDiff[gcPreviousTokenID].Kind)
+		retired := update(later, rotated)
+		assert.Equal(t, []string{"tok-1"}, client.deleted)
+		assert.Nil(t, retired.PreviousTokenID)
+		assert.Equal(t, "tok-2", retired.TokenID)
+		assert.Equal(t, "value-tok-2", retired.Value)
+	})
+
+	t.Run("changing the ttl replaces the token", func(t *testing.T) {
+		news := rotatingTokenInput()
+		ttl := "60d"
+		news.TTL = &ttl
+		resp, err := token.Diff(ctx, infer.DiffRequest[OrgAccessTokenInput, OrgAccessTokenState]{
+			ID: created.ID, State: created.Output, Inputs: news,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, p.UpdateReplace, resp.DetailedDiff[gcTTL].Kind)
+		assert.NotContains(t, resp.DetailedDiff, gcExpires, "the recorded expiry is not an input")
+	})
+
+	t.Run("delete removes both tokens", func(t *testing.T) {
+		client.deleted = nil
+		_, err := token.Delete(ctx, infer.DeleteRequest[OrgAccessTokenState]{ID: created.ID, State: rotated})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"tok-1", "tok-2"}, client.deleted)
+	})
+}
+
+func TestOrgAccessTokenReadStatus(t *testing.T) {
+	expires := tokenEpoch.Add(30 * 24 * time.Hour)
+	ctx := config.WithMockClient(context.Background(), &orgTokenClientMock{existing: []pulumiapi.AccessToken{
+		{ID: "tok-1", Name: "ci", Expires: expires.Unix()},
+	}})
+	read := func(at time.Time) infer.ReadResponse[OrgAccessTokenInput, OrgAccessTokenState] {
+		resp, err := (&OrgAccessToken{now: clockAt(at)}).Read(ctx,
+			infer.ReadRequest[OrgAccessTokenInput, OrgAccessTokenState]{ID: "my-org/ci/tok-1"})
+		require.NoError(t, err)
+		return resp
+	}
+
+	assert.Equal(t, ptr(tokenStatusActive), read(tokenEpoch).State.Status)
+	expired := read(expires.Add(time.Hour))
+	assert.Equal(t, "my-org/ci/tok-1", expired.ID, "an expired token is not gone")
+	assert.Equal(t, ptr(tokenStatusExpired), expired.State.Status)
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/util"
 )
 
 type TTLSchedule struct{}
@@ -47,6 +48,7 @@ type TTLScheduleInput struct {
 	Stack              string `pulumi:"stack"        provider:"replaceOnChanges"`
 	Timestamp          string `pulumi:"timestamp"    provider:"replaceOnChanges"`
 	DeleteAfterDestroy *bool  `pulumi:"deleteAfterDestroy,optional"`
+	Paused             *bool  `pulumi:"paused,optional"`
 }
 
 func (i *TTLScheduleInput) Annotate(a infer.Annotator) {
@@ -59,6 +61,8 @@ func (i *TTLScheduleInput) Annotate(a infer.Annotator) {
 		"True if the stack and all associated history and settings should be deleted.",
 	)
 	a.SetDefault(&i.DeleteAfterDestroy, false)
+	a.Describe(&i.Paused, "Whether the schedule is paused. A paused schedule does not run until it is resumed.")
+	a.SetDefault(&i.Paused, false)
 }
 
 type TTLScheduleState struct {
@@ -108,13 +112,16 @@ func (*TTLSchedule) Create(
 	if err != nil {
 		return infer.CreateResponse[TTLScheduleState]{}, fmt.Errorf("error creating TTL schedule: %w", err)
 	}
-	return infer.CreateResponse[TTLScheduleState]{
-		ID: stackScheduleID(stack, "ttl", *scheduleID),
-		Output: TTLScheduleState{
-			TTLScheduleInput: req.Inputs,
-			ScheduleID:       *scheduleID,
-		},
-	}, nil
+	id := stackScheduleID(stack, "ttl", *scheduleID)
+	state := TTLScheduleState{
+		TTLScheduleInput: req.Inputs,
+		ScheduleID:       *scheduleID,
+	}
+	if err := setStackSchedulePaused(ctx, stack, *scheduleID, nil, req.Inputs.Paused); err != nil {
+		state.Paused = nil
+		return infer.CreateResponse[TTLScheduleState]{ID: id, Output: state}, err
+	}
+	return infer.CreateResponse[TTLScheduleState]{ID: id, Output: state}, nil
 }
 
 func (*TTLSchedule) Update(
@@ -140,12 +147,15 @@ func (*TTLSchedule) Update(
 	if err != nil {
 		return infer.UpdateResponse[TTLScheduleState]{}, fmt.Errorf("error updating TTL schedule: %w", err)
 	}
-	return infer.UpdateResponse[TTLScheduleState]{
-		Output: TTLScheduleState{
-			TTLScheduleInput: req.Inputs,
-			ScheduleID:       *scheduleID,
-		},
-	}, nil
+	state := TTLScheduleState{
+		TTLScheduleInput: req.Inputs,
+		ScheduleID:       *scheduleID,
+	}
+	if err := setStackSchedulePaused(ctx, stack, *scheduleID, req.State.Paused, req.Inputs.Paused); err != nil {
+		state.Paused = req.State.Paused
+		return infer.UpdateResponse[TTLScheduleState]{Output: state}, err
+	}
+	return infer.UpdateResponse[TTLScheduleState]{Output: state}, nil
 }
 
 func (*TTLSchedule) Delete(
@@ -196,6 +206,7 @@ func (*TTLSchedule) Read(
 		Stack:              stack.StackName,
 		Timestamp:          parsed.UTC().Format(time.RFC3339),
 		DeleteAfterDestroy: &deleteAfterDestroy,
+		Paused:             &resp.Paused,
 	}
 	return infer.ReadResponse[TTLScheduleInput, TTLScheduleState]{
 		ID:     req.ID,
@@ -224,6 +235,28 @@ func (i TTLScheduleInput) toAPI() (pulumiapi.StackIdentifier, time.Time, bool, e
 	return stack, ts, deleteAfterDestroy, nil
 }
 
+// setStackSchedulePaused pauses or resumes the schedule when paused, the
+// desired state, differs from wasPaused. A failure is reported as an
+// infer.ResourceInitFailedError, since the schedule itself is in place.
+func setStackSchedulePaused(
+	ctx context.Context, stack pulumiapi.StackIdentifier, scheduleID string, wasPaused, paused *bool,
+) error {
+	client := config.GetClient(ctx)
+	var err error
+	switch want := util.OrZero(paused); {
+	case want == util.OrZero(wasPaused):
+		return nil
+	case want:
+		err = client.PauseStackSchedule(ctx, stack, scheduleID)
+	default:
+		err = client.ResumeStackSchedule(ctx, stack, scheduleID)
+	}
+	if err != nil {
+		return infer.ResourceInitFailedError{Reasons: []string{err.Error()}}
+	}
+	return nil
+}
+
 func stackScheduleID(stack pulumiapi.StackIdentifier, scheduleType, scheduleID string) string {
 	return fmt.Sprintf("%s/%s/%s/%s/%s", stack.OrgName, stack.ProjectName, stack.StackName, scheduleType, scheduleID)
 }
@@ -17,6 +17,7 @@ package resources
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 
 	p "github.com/pulumi/pulumi-go-provider"
@@ -24,6 +25,7 @@ import (
 
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
 	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/util"
 )
 
 // defaultWebhookGroups maps a webhook scope (organization/stack/environment)
@@ -314,7 +316,12 @@ func (*Webhook) Annotate(a infer.Annotator) {
 			"webhook can then be used to emit some notification, start running integration tests, or even "+
 			"update additional stacks.\n\n### Import\n\nPulumi webhooks can be imported using the `id`, which "+
 			"for webhooks is `{org}/{project}/{stack}/{webhook-name}` e.g.,\n\n```sh\n $ pulumi import "+
-			"pulumiservice:index:Webhook my_webhook my-org/my-project/my-stack/4b0d0671\n```\n\n")
+			"pulumiservice:index:Webhook my_webhook my-org/my-project/my-stack/4b0d0671\n```\n\n"+
+			"### Delivery health\n\nWith `verifyOnCreate`, the webhook is sent a test event once created, and "+
+			"whenever `payloadUrl` or `secret` changes; the operation fails unless the payload URL answers with a "+
+			"2xx status. `lastDeliveryStatus`, `lastDeliveryTime` and `recentFailureCount` describe the webhook's "+
+			"recent deliveries and are refreshed by `pulumi refresh`. Changing `redeliverFailed` sends every "+
+			"recent event that was never delivered successfully again.\n\n")
 	a.SetToken("index", "Webhook")
 }
 
@@ -330,6 +337,8 @@ type WebhookInput struct {
 	Format           *WebhookFormat   `pulumi:"format,optional"`
 	Filters          []WebhookFilters `pulumi:"filters,optional"`
 	Groups           []WebhookGroup   `pulumi:"groups,optional"`
+	VerifyOnCreate   *bool            `pulumi:"verifyOnCreate,optional"`
+	RedeliverFailed  []any            `pulumi:"redeliverFailed,optional"`
 }
 
 func (i *WebhookInput) Annotate(a infer.Annotator) {
@@ -361,6 +370,10 @@ func (i *WebhookInput) Annotate(a infer.Annotator) {
 		"Optional set of filter groups to apply to the webhook. See [webhook docs]"+
 			"(https://www.pulumi.com/docs/intro/pulumi-service/webhooks/#groups) for more information.",
 	)
+	a.Describe(&i.VerifyOnCreate, "Send the webhook a test event when it is created, and when `payloadUrl` or "+
+		"`secret` changes, and fail unless the payload URL answers with a 2xx status. Defaults to `false`.")
+	a.Describe(&i.RedeliverFailed, "Arbitrary values that, when changed, send every recent event that was "+
+		"never delivered successfully to the webhook again.")
 }
 
 type WebhookState struct {
@@ -374,6 +387,48 @@ type WebhookState struct {
 	// embedded WebhookInput.Format directly.
 	Format WebhookFormat `pulumi:"format"`
 	Name   string        `pulumi:"name"`
+	WebhookDeliveryHealth
+}
+
+// WebhookDeliveryHealth summarizes a webhook's recent deliveries.
+type WebhookDeliveryHealth struct {
+	LastDeliveryStatus *int    `pulumi:"lastDeliveryStatus,optional"`
+	LastDeliveryTime   *string `pulumi:"lastDeliveryTime,optional"`
+	RecentFailureCount int     `pulumi:"recentFailureCount"`
+}
+
+func (h *WebhookDeliveryHealth) Annotate(a infer.Annotator) {
+	a.Describe(&h.LastDeliveryStatus, "The HTTP status the payload URL answered the latest delivery with, "+
+		"as last observed.")
+	a.Describe(&h.LastDeliveryTime, "When the latest delivery was sent, in RFC 3339 format.")
+	a.Describe(&h.RecentFailureCount, "How many of the recent deliveries Pulumi Cloud keeps were not answered "+
+		"with a 2xx status.")
+}
+
+// newWebhookDeliveryHealth summarizes deliveries, in any order.
+func newWebhookDeliveryHealth(deliveries []pulumiapi.WebhookDelivery) WebhookDeliveryHealth {
+	var health WebhookDeliveryHealth
+	var last *pulumiapi.WebhookDelivery
+	for i, d := range deliveries {
+		if !d.Succeeded() {
+			health.RecentFailureCount++
+		}
+		if last == nil || d.Timestamp > last.Timestamp {
+			last = &deliveries[i]
+		}
+	}
+	if last != nil {
+		status := int(last.ResponseCode)
+		health.LastDeliveryStatus = &status
+		health.LastDeliveryTime = formatUnix(last.Timestamp)
+	}
+	return health
+}
+
+// unverified reports whether the latest delivery was not accepted, or no
+// delivery has been observed at all.
+func (h WebhookDeliveryHealth) unverified() bool {
+	return h.LastDeliveryStatus == nil || *h.LastDeliveryStatus < 200 || *h.LastDeliveryStatus >= 300
 }
 
 func (s *WebhookState) Annotate(a infer.Annotator) {
@@ -458,27 +513,33 @@ func (*Webhook) Create(
 		)
 	}
 
-	return infer.CreateResponse[WebhookState]{
-		ID: generateWebhookID(req.Inputs, webhook.Name),
-		Output: WebhookState{
-			WebhookInput: req.Inputs,
-			Format:       *req.Inputs.Format,
-			Name:         webhook.Name,
-		},
-	}, nil
+	id := generateWebhookID(req.Inputs, webhook.Name)
+	state := WebhookState{
+		WebhookInput: req.Inputs,
+		Format:       *req.Inputs.Format,
+		Name:         webhook.Name,
+	}
+	if util.OrZero(req.Inputs.VerifyOnCreate) {
+		health, err := checkWebhookDeliveries(ctx, id, true, false)
+		state.WebhookDeliveryHealth = health
+		if err != nil {
+			return infer.CreateResponse[WebhookState]{ID: id, Output: state}, err
+		}
+	}
+	return infer.CreateResponse[WebhookState]{ID: id, Output: state}, nil
 }
 
 func (*Webhook) Update(
 	ctx context.Context, req infer.UpdateRequest[WebhookInput, WebhookState],
 ) (infer.UpdateResponse[WebhookState], error) {
+	state := WebhookState{
+		WebhookInput:          req.Inputs,
+		Format:                *req.Inputs.Format,
+		Name:                  req.State.Name,
+		WebhookDeliveryHealth: req.State.WebhookDeliveryHealth,
+	}
 	if req.DryRun {
-		return infer.UpdateResponse[WebhookState]{
-			Output: WebhookState{
-				WebhookInput: req.Inputs,
-				Format:       *req.Inputs.Format,
-				Name:         req.State.Name,
-			},
-		}, nil
+		return infer.UpdateResponse[WebhookState]{Output: state}, nil
 	}
 
 	updateReq := pulumiapi.UpdateWebhookRequest{
@@ -490,13 +551,102 @@ func (*Webhook) Update(
 			"error updating webhook %q: %w", req.State.Name, err,
 		)
 	}
-	return infer.UpdateResponse[WebhookState]{
-		Output: WebhookState{
-			WebhookInput: req.Inputs,
-			Format:       *req.Inputs.Format,
-			Name:         req.State.Name,
-		},
-	}, nil
+
+	// A failed verification is retried on the next update, since the create
+	// or update that ran it is otherwise recorded as done.
+	verify := util.OrZero(req.Inputs.VerifyOnCreate) && (req.State.unverified() ||
+		req.State.PayloadURL != req.Inputs.PayloadURL || !reflect.DeepEqual(req.State.Secret, req.Inputs.Secret))
+	redeliver := !reflect.DeepEqual(req.State.RedeliverFailed, req.Inputs.RedeliverFailed)
+	if verify || redeliver {
+		health, err := checkWebhookDeliveries(ctx, req.ID, verify, redeliver)
+		state.WebhookDeliveryHealth = health
+		if err != nil {
+			return infer.UpdateResponse[WebhookState]{Output: state}, err
+		}
+	}
+	return infer.UpdateResponse[WebhookState]{Output: state}, nil
+}
+
+// checkWebhookDeliveries pings the webhook when verify is set, and sends
+// recent events that were never delivered successfully again when redeliver
+// is. It returns the resulting delivery health, with an
+// infer.ResourceInitFailedError when the ping or a redelivery cannot be sent
+// or is not accepted.
+func checkWebhookDeliveries(ctx context.Context, id string, verify, redeliver bool) (WebhookDeliveryHealth, error) {
+	hookID, err := splitWebhookID(id)
+	if err != nil {
+		return WebhookDeliveryHealth{}, err
+	}
+	client := config.GetClient(ctx)
+	var failures []string
+	if redeliver {
+		failures = append(failures, redeliverFailedWebhookEvents(ctx, client, *hookID)...)
+	}
+	pingSent := true
+	if verify {
+		delivery, err := client.PingWebhook(ctx, hookID.organizationName, hookID.projectName, hookID.stackName,
+			hookID.environmentName, hookID.webhookName)
+		switch {
+		case err != nil:
+			pingSent = false
+			failures = append(failures, fmt.Sprintf("sending a test delivery to webhook %q: %s", id, err))
+		case !delivery.Succeeded():
+			failures = append(failures, fmt.Sprintf("webhook %q rejected the test delivery with HTTP %d: %s",
+				id, delivery.ResponseCode, delivery.ResponseBody))
+		}
+	}
+
+	deliveries, err := client.ListWebhookDeliveries(ctx, hookID.organizationName, hookID.projectName,
+		hookID.stackName, hookID.environmentName, hookID.webhookName)
+	if err != nil {
+		failures = append(failures, err.Error())
+	}
+	health := newWebhookDeliveryHealth(deliveries)
+	if !pingSent {
+		// Earlier deliveries do not verify the webhook: leave it unverified
+		// so the next update pings it again.
+		health.LastDeliveryStatus = nil
+		health.LastDeliveryTime = nil
+	}
+	if len(failures) > 0 {
+		return health, infer.ResourceInitFailedError{Reasons: failures}
+	}
+	return health, nil
+}
+
+// redeliverFailedWebhookEvents sends again every recent event none of whose
+// deliveries succeeded, and describes the ones that fail again.
+func redeliverFailedWebhookEvents(ctx context.Context, client config.Client, hookID webhookID) []string {
+	deliveries, err := client.ListWebhookDeliveries(ctx, hookID.organizationName, hookID.projectName,
+		hookID.stackName, hookID.environmentName, hookID.webhookName)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	// Deliveries share the ID of the event they carry.
+	var events []string
+	succeeded := map[string]bool{}
+	for _, d := range deliveries {
+		if _, seen := succeeded[d.ID]; !seen {
+			events = append(events, d.ID)
+		}
+		succeeded[d.ID] = succeeded[d.ID] || d.Succeeded()
+	}
+	var failures []string
+	for _, event := range events {
+		if succeeded[event] {
+			continue
+		}
+		delivery, err := client.RedeliverWebhookEvent(ctx, hookID.organizationName, hookID.projectName,
+			hookID.stackName, hookID.environmentName, hookID.webhookName, event)
+		switch {
+		case err != nil:
+			failures = append(failures, err.Error())
+		case !delivery.Succeeded():
+			failures = append(failures, fmt.Sprintf("redelivering event %s returned HTTP %d", event,
+				delivery.ResponseCode))
+		}
+	}
+	return failures
 }
 
 func (*Webhook) Delete(
@@ -555,16 +705,34 @@ func (*Webhook) Read(
 		Groups:           toWebhookGroups(webhook.Groups),
 		// The API never returns the plaintext secret. Preserve the value
 		// previously persisted in state so refresh does not erase it.
-		Secret: req.State.Secret,
+		Secret:          req.State.Secret,
+		VerifyOnCreate:  req.State.VerifyOnCreate,
+		RedeliverFailed: req.State.RedeliverFailed,
+	}
+
+	deliveries, err := config.GetClient(ctx).ListWebhookDeliveries(
+		ctx,
+		hookID.organizationName,
+		hookID.projectName,
+		hookID.stackName,
+		hookID.environmentName,
+		hookID.webhookName,
+	)
+	// Delivery health is best-effort: keep the last observed health rather
+	// than fail the refresh of a webhook that reads fine.
+	health := req.State.WebhookDeliveryHealth
+	if err == nil {
+		health = newWebhookDeliveryHealth(deliveries)
 	}
 
 	return infer.ReadResponse[WebhookInput, WebhookState]{
 		ID:     req.ID,
 		Inputs: inputs,
 		State: WebhookState{
-			WebhookInput: inputs,
-			Format:       format,
-			Name:         webhook.Name,
+			WebhookInput:          inputs,
+			Format:                format,
+			Name:                  webhook.Name,
+			WebhookDeliveryHealth: health,
 		},
 	}, nil
 }
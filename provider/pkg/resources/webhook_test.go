@@ -15,10 +15,17 @@
 package resources
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi-go-provider/infer"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/config"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/pulumiapi"
 )
 
 const (
@@ -112,3 +119,171 @@ func TestSplitWebhookID(t *testing.T) {
 		}
 	})
 }
+
+// webhookClientMock answers pings with pingCode, or fails them with pingErr,
+// and redeliveries with 200, recording both as deliveries. Listing
+// deliveries fails with listErr when set.
+type webhookClientMock struct {
+	config.Client
+	pingCode    int64
+	pingErr     error
+	listErr     error
+	deliveries  []pulumiapi.WebhookDelivery
+	redelivered []string
+}
+
+func (c *webhookClientMock) CreateWebhook(
+	context.Context, pulumiapi.WebhookRequest,
+) (*pulumiapi.Webhook, error) {
+	return &pulumiapi.Webhook{Name: "hook-1"}, nil
+}
+
+func (c *webhookClientMock) UpdateWebhook(
+	context.Context, pulumiapi.UpdateWebhookRequest,
+) (*pulumiapi.Webhook, error) {
+	return &pulumiapi.Webhook{Name: "hook-1"}, nil
+}
+
+func (c *webhookClientMock) GetWebhook(
+	context.Context, string, *string, *string, *string, string,
+) (*pulumiapi.Webhook, error) {
+	return &pulumiapi.Webhook{Name: "hook-1", Format: "raw"}, nil
+}
+
+func (c *webhookClientMock) PingWebhook(
+	context.Context, string, *string, *string, *string, string,
+) (*pulumiapi.WebhookDelivery, error) {
+	if c.pingErr != nil {
+		return nil, c.pingErr
+	}
+	delivery := pulumiapi.WebhookDelivery{ID: "ping", ResponseCode: c.pingCode, Timestamp: 100}
+	c.deliveries = append(c.deliveries, delivery)
+	return &delivery, nil
+}
+
+func (c *webhookClientMock) ListWebhookDeliveries(
+	context.Context, string, *string, *string, *string, string,
+) ([]pulumiapi.WebhookDelivery, error) {
+	if c.listErr != nil {
+		return nil, c.listErr
+	}
+	return c.deliveries, nil
+}
+
+func (c *webhookClientMock) RedeliverWebhookEvent(
+	_ context.Context, _ string, _, _, _ *string, _, event string,
+) (*pulumiapi.WebhookDelivery, error) {
+	c.redelivered = append(c.redelivered, event)
+	delivery := pulumiapi.WebhookDelivery{ID: event, ResponseCode: 200, Timestamp: 200}
+	c.deliveries = append(c.deliveries, delivery)
+	return &delivery, nil
+}
+
+func webhookInput() WebhookInput {
+	format := WebhookFormatRaw
+	return WebhookInput{
+		Active:           true,
+		DisplayName:      "alerts",
+		PayloadURL:       "https://example.com/hook",
+		OrganizationName: gcMyOrg,
+		Format:           &format,
+		VerifyOnCreate:   ptr(true),
+	}
+}
+
+func TestWebhookVerifyOnCreate(t *testing.T) {
+	t.Run("accepted", func(t *testing.T) {
+		ctx := config.WithMockClient(context.Background(), &webhookClientMock{pingCode: 204})
+		resp, err := (&Webhook{}).Create(ctx, infer.CreateRequest[WebhookInput]{Inputs: webhookInput()})
+		require.NoError(t, err)
+		assert.Equal(t, ptr(204), resp.Output.LastDeliveryStatus)
+		assert.Equal(t, 0, resp.Output.RecentFailureCount)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		ctx := config.WithMockClient(context.Background(), &webhookClientMock{pingCode: 404})
+		resp, err := (&Webhook{}).Create(ctx, infer.CreateRequest[WebhookInput]{Inputs: webhookInput()})
+		var initErr infer.ResourceInitFailedError
+		require.ErrorAs(t, err, &initErr)
+		assert.Contains(t, initErr.Reasons[0], "rejected the test delivery with HTTP 404")
+		assert.Equal(t, "my-org/hook-1", resp.ID)
+		assert.Equal(t, 1, resp.Output.RecentFailureCount)
+	})
+
+	t.Run("ping not sent", func(t *testing.T) {
+		ctx := config.WithMockClient(context.Background(), &webhookClientMock{
+			pingErr:    errors.New("connection reset"),
+			deliveries: []pulumiapi.WebhookDelivery{{ID: "ev-1", ResponseCode: 200, Timestamp: 10}},
+		})
+		resp, err := (&Webhook{}).Create(ctx, infer.CreateRequest[WebhookInput]{Inputs: webhookInput()})
+		var initErr infer.ResourceInitFailedError
+		require.ErrorAs(t, err, &initErr)
+		assert.Contains(t, initErr.Reasons[0], "connection reset")
+		assert.Equal(t, "my-org/hook-1", resp.ID)
+		assert.Nil(t, resp.Output.LastDeliveryStatus)
+		assert.True(t, resp.Output.unverified())
+	})
+
+	t.Run("retried after a rejection", func(t *testing.T) {
+		client := &webhookClientMock{pingCode: 200}
+		ctx := config.WithMockClient(context.Background(), client)
+		state := WebhookState{WebhookInput: webhookInput(), Name: "hook-1"}
+		state.LastDeliveryStatus = ptr(404)
+		resp, err := (&Webhook{}).Update(ctx, infer.UpdateRequest[WebhookInput, WebhookState]{
+			ID: "my-org/hook-1", State: state, Inputs: webhookInput(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, ptr(200), resp.Output.LastDeliveryStatus)
+	})
+}
+
+func TestWebhookRedeliverFailed(t *testing.T) {
+	client := &webhookClientMock{deliveries: []pulumiapi.WebhookDelivery{
+		{ID: "ev-1", ResponseCode: 500, Timestamp: 10},
+		{ID: "ev-1", ResponseCode: 200, Timestamp: 20},
+		{ID: "ev-2", ResponseCode: 502, Timestamp: 30},
+		{ID: "ev-3", ResponseCode: 200, Timestamp: 40},
+	}}
+	ctx := config.WithMockClient(context.Background(), client)
+
+	news := webhookInput()
+	news.RedeliverFailed = []any{"1"}
+	state := WebhookState{WebhookInput: webhookInput(), Name: "hook-1"}
+	state.LastDeliveryStatus = ptr(200)
+	resp, err := (&Webhook{}).Update(ctx, infer.UpdateRequest[WebhookInput, WebhookState]{
+		ID: "my-org/hook-1", State: state, Inputs: news,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ev-2"}, client.redelivered)
+	assert.Equal(t, ptr(200), resp.Output.LastDeliveryStatus)
+	assert.Equal(t, 2, resp.Output.RecentFailureCount)
+}
+
+func TestWebhookReadRefreshesDeliveryHealth(t *testing.T) {
+	ctx := config.WithMockClient(context.Background(), &webhookClientMock{
+		deliveries: []pulumiapi.WebhookDelivery{
+			{ID: "ev-2", ResponseCode: 503, Timestamp: 1767225600},
+			{ID: "ev-1", ResponseCode: 200, Timestamp: 1767139200},
+		},
+	})
+	resp, err := (&Webhook{}).Read(ctx, infer.ReadRequest[WebhookInput, WebhookState]{ID: "my-org/hook-1"})
+	require.NoError(t, err)
+	assert.Equal(t, WebhookDeliveryHealth{
+		LastDeliveryStatus: ptr(503),
+		LastDeliveryTime:   ptr("2026-01-01T00:00:00Z"),
+		RecentFailureCount: 1,
+	}, resp.State.WebhookDeliveryHealth)
+}
+
+func TestWebhookReadKeepsDeliveryHealthOnError(t *testing.T) {
+	ctx := config.WithMockClient(context.Background(), &webhookClientMock{listErr: errors.New("unavailable")})
+	state := WebhookState{Name: "hook-1"}
+	state.LastDeliveryStatus = ptr(200)
+	state.RecentFailureCount = 2
+	resp, err := (&Webhook{}).Read(ctx, infer.ReadRequest[WebhookInput, WebhookState]{
+		ID: "my-org/hook-1", State: state,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "my-org/hook-1", resp.ID)
+	assert.Equal(t, state.WebhookDeliveryHealth, resp.State.WebhookDeliveryHealth)
+}
@@ -0,0 +1,157 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+)
+
+// Functions builds one invoke handler per metadata.functions entry. Like
+// Resources, operation IDs resolve lazily so a broken entry surfaces via
+// BuildSchema or at the first call rather than failing provider startup.
+func Functions(spec *Spec, metadata *Metadata) map[string]*Function {
+	out := make(map[string]*Function, len(metadata.Functions))
+	for key, fm := range metadata.Functions {
+		token := key
+		if fm.Token != "" {
+			token = fm.Token
+		}
+		out[token] = &Function{
+			meta: fm,
+			spec: spec,
+			// A shim Resource carrying the function's renames lets the
+			// read-side helpers (buildURL, roundTrip) serve both paths.
+			res: &Resource{meta: fm.resourceMeta(), spec: spec},
+		}
+	}
+	return out
+}
+
+// Function is a metadata-driven data source: one GET operation whose path
+// and query parameters are the inputs and whose response body is the result.
+type Function struct {
+	meta FunctionMeta
+	spec *Spec
+	res  *Resource
+}
+
+// Invoke issues the function's GET and returns the response projected onto
+// the schema's outputs: Outputs/OutputsExclude filtering and secret marking
+// match what BuildSchema advertises for the same entry.
+func (f *Function) Invoke(ctx context.Context, req p.InvokeRequest) (p.InvokeResponse, error) {
+	op, err := f.res.resolveOp("operation", f.meta.Operation)
+	if err != nil {
+		return p.InvokeResponse{}, err
+	}
+	if op == nil {
+		return p.InvokeResponse{}, fmt.Errorf("rest: %s: operation is required", req.Token)
+	}
+	if op.Method != http.MethodGet {
+		return p.InvokeResponse{}, fmt.Errorf("rest: %s: operation %q is %s, functions require GET",
+			req.Token, op.ID, op.Method)
+	}
+
+	var failures []p.CheckFailure
+	for _, param := range op.Parameters {
+		if param.In != inPath && !(param.In == inQuery && param.Required) {
+			continue
+		}
+		name := pulumiName(param.Name, f.meta.Renames)
+		if v, ok := req.Args.GetOk(name); !ok || v.IsNull() {
+			failures = append(failures, p.CheckFailure{
+				Property: name,
+				Reason:   fmt.Sprintf("missing required argument %q", name),
+			})
+		}
+	}
+	if len(failures) > 0 {
+		return p.InvokeResponse{Failures: failures}, nil
+	}
+
+	u, err := f.buildURL(op, req.Args)
+	if err != nil {
+		return p.InvokeResponse{}, err
+	}
+	_, state, err := f.res.roundTrip(ctx, op, u, nil, "")
+	if err != nil {
+		return p.InvokeResponse{}, err
+	}
+	ret, err := f.projectOutputs(op, state)
+	if err != nil {
+		return p.InvokeResponse{}, err
+	}
+	return p.InvokeResponse{Return: ret}, nil
+}
+
+// buildURL substitutes path params and appends every query param present in
+// args. Resource URLs never carry a query string, so this extends the shared
+// path substitution rather than living in it.
+func (f *Function) buildURL(op *Operation, args property.Map) (string, error) {
+	base, err := f.res.buildURL(op, args)
+	if err != nil {
+		return "", err
+	}
+	query := url.Values{}
+	for _, param := range op.Parameters {
+		if param.In != inQuery {
+			continue
+		}
+		v, ok := args.GetOk(pulumiName(param.Name, f.meta.Renames))
+		if !ok || v.IsNull() || v.IsComputed() {
+			continue
+		}
+		if v.IsArray() {
+			for _, item := range v.AsArray().All {
+				query.Add(param.Name, propertyValueToString(item))
+			}
+			continue
+		}
+		query.Set(param.Name, propertyValueToString(v))
+	}
+	if len(query) == 0 {
+		return base, nil
+	}
+	return base + "?" + query.Encode(), nil
+}
+
+// projectOutputs keeps only the fields the schema declares as outputs and
+// marks secret ones, so the invoke result and the schema never disagree.
+func (f *Function) projectOutputs(op *Operation, state property.Map) (property.Map, error) {
+	if op.ResponseRef == "" {
+		return state, nil
+	}
+	outputs, _, err := responseOutputs(f.spec, op, f.meta.resourceMeta(), false)
+	if err != nil {
+		return property.Map{}, fmt.Errorf("rest: outputs for %s: %w", op.ID, err)
+	}
+	out := make(map[string]property.Value, len(outputs))
+	for k, v := range state.AllStable {
+		ps, ok := outputs[k]
+		if !ok {
+			continue
+		}
+		if ps.Secret {
+			v = v.WithSecret(true)
+		}
+		out[k] = v
+	}
+	return property.NewMap(out), nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+)
+
+const (
+	getThingToken = "pulumiservice:api:getThing"
+	kindKey       = "kind"
+	secretKey     = "secretValue"
+)
+
+// thingFunctionSpec declares a GET with a path param, a required query param,
+// an optional repeated query param, and a response carrying an "id", a
+// secret-looking field, and a field the tests exclude.
+const thingFunctionSpec = `{
+  "openapi": "3.0.0",
+  "components": {"schemas": {
+    "Thing": {"type": "object", "required": ["id", "name"], "properties": {
+      "id": {"type": "string"},
+      "name": {"type": "string"},
+      "secretValue": {"type": "string"},
+      "internal": {"type": "string"}
+    }}
+  }},
+  "paths": {
+    "/things/{org}": {
+      "get": {
+        "operationId": "GetThing",
+        "description": "Gets a thing.",
+        "parameters": [
+          {"name": "org", "in": "path", "required": true, "schema": {"type": "string"}},
+          {"name": "kind", "in": "query", "required": true, "schema": {"type": "string"}},
+          {"name": "tag", "in": "query", "schema": {"type": "array"}}
+        ],
+        "responses": {"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Thing"}}}}}
+      },
+      "post": {
+        "operationId": "CreateThing",
+        "parameters": [{"name": "org", "in": "path", "required": true, "schema": {"type": "string"}}],
+        "responses": {"204": {"description": "ok"}}
+      }
+    }
+  }
+}`
+
+func thingFunctionFixtures(t *testing.T) (*Spec, *Metadata) {
+	t.Helper()
+	spec, err := ParseSpec([]byte(thingFunctionSpec))
+	if err != nil {
+		t.Fatalf("parse synthetic spec: %v", err)
+	}
+	return spec, &Metadata{Functions: map[string]FunctionMeta{
+		getThingToken: {Operation: getThingOp, OutputsExclude: []string{"internal"}},
+	}}
+}
+
+// TestBuildSchemaEmitsFunctions pins the function shape: path and query
+// params as inputs (path and required query params required), response
+// fields as outputs with "id" kept, the denylist applied, and secrets marked.
+func TestBuildSchemaEmitsFunctions(t *testing.T) {
+	spec, meta := thingFunctionFixtures(t)
+	pkg, err := BuildSchema(spec, meta, "pulumiservice")
+	if err != nil {
+		t.Fatalf("BuildSchema: %v", err)
+	}
+	fs, ok := pkg.Functions[getThingToken]
+	if !ok {
+		t.Fatalf("function %q missing from package", getThingToken)
+	}
+	if fs.Description != "Gets a thing." {
+		t.Errorf("description = %q, want the operation's", fs.Description)
+	}
+	for _, name := range []string{orgKey, kindKey, "tag"} {
+		if _, ok := fs.Inputs.Properties[name]; !ok {
+			t.Errorf("input %q missing", name)
+		}
+	}
+	if want := []string{kindKey, orgKey}; !slices.Equal(fs.Inputs.Required, want) {
+		t.Errorf("required inputs = %v, want %v", fs.Inputs.Required, want)
+	}
+	if _, ok := fs.Outputs.Properties["id"]; !ok {
+		t.Error(`output "id" missing; only resources reserve it`)
+	}
+	if _, ok := fs.Outputs.Properties["internal"]; ok {
+		t.Error(`output "internal" should be dropped by outputsExclude`)
+	}
+	if !fs.Outputs.Properties[secretKey].Secret {
+		t.Errorf("output %q should be secret", secretKey)
+	}
+	if want := []string{"id", nameKey}; !slices.Equal(fs.Outputs.Required, want) {
+		t.Errorf("required outputs = %v, want %v", fs.Outputs.Required, want)
+	}
+}
+
+func TestBuildSchemaRejectsNonGetFunction(t *testing.T) {
+	spec, _ := thingFunctionFixtures(t)
+	meta := &Metadata{Functions: map[string]FunctionMeta{
+		getThingToken: {Operation: createThingOp},
+	}}
+	_, err := BuildSchema(spec, meta, "pulumiservice")
+	if err == nil || !strings.Contains(err.Error(), "functions require GET") {
+		t.Fatalf("want a GET-only error, got %v", err)
+	}
+}
+
+// TestFunctionInvoke covers the round trip: path and query substitution
+// (repeated params expanded), output filtering, and secret marking.
+func TestFunctionInvoke(t *testing.T) {
+	spec, meta := thingFunctionFixtures(t)
+	fn := Functions(spec, meta)[getThingToken]
+
+	var rawQuery string
+	mock := &mockTransport{responseFn: func(req *http.Request) mockResponse {
+		rawQuery = req.URL.RawQuery
+		return mockResponse{status: 200, body: `{"id":"t-1","name":"thing","secretValue":"s3cr3t","internal":"x"}`}
+	}}
+	ctx := WithTransport(t.Context(), mock)
+
+	resp, err := fn.Invoke(ctx, p.InvokeRequest{
+		Token: getThingToken,
+		Args: property.NewMap(map[string]property.Value{
+			orgKey:  property.New(acmeVal),
+			kindKey: property.New("widget"),
+			"tag":   property.New(property.NewArray([]property.Value{property.New("a"), property.New("b")})),
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+	if len(resp.Failures) > 0 {
+		t.Fatalf("unexpected failures: %v", resp.Failures)
+	}
+	if want := []string{getThingsAcme}; !slices.Equal(mock.calls, want) {
+		t.Errorf("calls = %v, want %v", mock.calls, want)
+	}
+	if want := "kind=widget&tag=a&tag=b"; rawQuery != want {
+		t.Errorf("query = %q, want %q", rawQuery, want)
+	}
+	if got := resp.Return.Get("id"); got.AsString() != "t-1" {
+		t.Errorf("id = %v, want t-1", got)
+	}
+	if _, ok := resp.Return.GetOk("internal"); ok {
+		t.Error(`"internal" should be filtered from the result`)
+	}
+	if !resp.Return.Get(secretKey).Secret() {
+		t.Errorf("%q should come back secret", secretKey)
+	}
+}
+
+// TestFunctionInvokeReportsMissingArgs: absent required inputs surface as
+// check failures and never reach the wire.
+func TestFunctionInvokeReportsMissingArgs(t *testing.T) {
+	spec, meta := thingFunctionFixtures(t)
+	fn := Functions(spec, meta)[getThingToken]
+	mock := &mockTransport{}
+	ctx := WithTransport(t.Context(), mock)
+
+	resp, err := fn.Invoke(ctx, p.InvokeRequest{Token: getThingToken, Args: property.NewMap(nil)})
+	if err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+	var got []string
+	for _, f := range resp.Failures {
+		got = append(got, f.Property)
+	}
+	slices.Sort(got)
+	if want := []string{kindKey, orgKey}; !slices.Equal(got, want) {
+		t.Errorf("failures = %v, want %v", got, want)
+	}
+	if len(mock.calls) > 0 {
+		t.Errorf("no request should be sent; got %v", mock.calls)
+	}
+}
+
+// TestFunctionInvokeAppliesRenames runs getTeam from the committed metadata:
+// the resource-style rename (name ↔ teamName) drives the path.
+func TestFunctionInvokeAppliesRenames(t *testing.T) {
+	spec, meta := loadFixtures(t)
+	fm, ok := meta.Functions["pulumiservice:api:getTeam"]
+	if !ok {
+		t.Fatal("metadata.json lost its getTeam function")
+	}
+	fn := Functions(spec, meta)[fm.Token]
+	mock := &mockTransport{responses: map[string]mockResponse{
+		"GET /api/orgs/test-org/teams/infra": {status: 200, body: `{"name":"infra","description":"infra team"}`},
+	}}
+	ctx := WithTransport(t.Context(), mock)
+
+	resp, err := fn.Invoke(ctx, p.InvokeRequest{
+		Token: "pulumiservice:api/teams:getTeam",
+		Args:  propMap(map[string]any{orgNameKey: testOrgName, nameKey: infraVal}),
+	})
+	if err != nil {
+		t.Fatalf("Invoke: %v", err)
+	}
+	if got := resp.Return.Get(descriptionKey); got.AsString() != infraTeamVal {
+		t.Errorf("description = %v, want %q", got, infraTeamVal)
+	}
+}
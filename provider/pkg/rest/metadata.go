@@ -29,8 +29,48 @@ type Metadata struct {
 	// Resources keyed by fully-qualified Pulumi token ("pkg:module:Name").
 	Resources map[string]ResourceMeta `json:"resources"`
 
-	// Functions keyed by fully-qualified Pulumi token. Reserved for future use.
-	Functions map[string]ResourceMeta `json:"functions,omitempty"`
+	// Functions keyed by fully-qualified Pulumi token ("pkg:module:getName").
+	Functions map[string]FunctionMeta `json:"functions,omitempty"`
+}
+
+// FunctionMeta describes one Pulumi function (data source) derived from a
+// single GET operation: path and query parameters become the inputs, the
+// response body becomes the result.
+type FunctionMeta struct {
+	// Operation is the GET operationId the function invokes.
+	Operation string `json:"operation"`
+
+	// Token overrides the user-facing Pulumi token, as on ResourceMeta.
+	Token string `json:"token,omitempty"`
+
+	// Fields holds Pulumi-only per-field overrides (Pulumi-side keys). Only
+	// Secret and Description apply to functions.
+	Fields map[string]FieldMeta `json:"fields,omitempty"`
+
+	// Renames maps Pulumi-side field names to OpenAPI-side names.
+	Renames map[string]string `json:"renames,omitempty"`
+
+	// Outputs is an allowlist of response fields returned by the function.
+	// Empty means return all.
+	Outputs []string `json:"outputs,omitempty"`
+
+	// OutputsExclude is a denylist. Outputs wins if both are set.
+	OutputsExclude []string `json:"outputsExclude,omitempty"`
+
+	// Description overrides the generated function description; empty
+	// falls back to the operation's description.
+	Description string `json:"description,omitempty"`
+}
+
+// resourceMeta projects the fields the shared resource helpers consult
+// (renames, output filtering, field overrides).
+func (fm FunctionMeta) resourceMeta() ResourceMeta {
+	return ResourceMeta{
+		Fields:         fm.Fields,
+		Renames:        fm.Renames,
+		Outputs:        fm.Outputs,
+		OutputsExclude: fm.OutputsExclude,
+	}
 }
 
 // ResourceMeta describes one Pulumi resource derived from OpenAPI operations.
@@ -93,6 +133,19 @@ type ResourceMeta struct {
 	// survive regen. See UpdateEnvelopeMeta.
 	UpdateEnvelope *UpdateEnvelopeMeta `json:"updateEnvelope,omitempty"`
 
+	// RetryPost marks this resource's POST operations as safe to retry on
+	// transient failures. Transports retry idempotent verbs on their own;
+	// set this only for POSTs the service handles idempotently (e.g.
+	// add-if-absent membership edges), never for ones that mint a new
+	// object per call.
+	RetryPost bool `json:"retryPost,omitempty"`
+
+	// Poll, when set, marks create/update as asynchronous: the op returns
+	// once the work is accepted, and the runtime polls a status op until it
+	// reports a terminal state before reading the resource back. See
+	// PollMeta.
+	Poll *PollMeta `json:"poll,omitempty"`
+
 	// TODO
 	// Examples are PCL snippets rendered as `## Example Usage` blocks.
 	// SDK codegen runs `pulumi convert` per target language at gen time.
@@ -146,6 +199,35 @@ type UpdateEnvelopeMeta struct {
 	NewField string `json:"newField"`
 }
 
+// PollMeta describes how to wait for an asynchronous create or update (an
+// insights scan, a deployment, an environment rotation) to finish. The
+// status op's path parameters come from the inputs merged with the
+// triggering response, so a run ID the create returns can address the
+// status endpoint. A 404 from the status op counts as still pending: the
+// run may not be visible yet.
+type PollMeta struct {
+	// Operation is the GET operationId that reports the status.
+	Operation string `json:"operation"`
+
+	// StatusField locates the status in the op's JSON response, wire-side,
+	// as a JSONPath-style path: "status", "$.status", "latest.status" or
+	// "runs[0].status".
+	StatusField string `json:"statusField"`
+
+	// Success and Failure list the terminal status values; any other value
+	// is pending. Comparison is case-insensitive.
+	Success []string `json:"success"`
+	Failure []string `json:"failure,omitempty"`
+
+	// Interval and Timeout are Go durations ("5s", "15m"); empty falls back
+	// to poll.DefaultInterval and poll.DefaultTimeout.
+	Interval string `json:"interval,omitempty"`
+	Timeout  string `json:"timeout,omitempty"`
+
+	// On limits polling to "create" or "update"; empty polls after both.
+	On []string `json:"on,omitempty"`
+}
+
 // Operations names the operationIds for each CRUD verb.
 type Operations struct {
 	Create string `json:"create,omitempty"`
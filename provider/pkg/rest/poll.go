@@ -0,0 +1,140 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/poll"
+)
+
+// awaitTerminal polls meta.Poll's status op after verb ("create" or
+// "update") until it reports a terminal status. A no-op for resources
+// without poll metadata or whose On list excludes verb. src supplies the
+// status op's path parameters.
+func (r *Resource) awaitTerminal(ctx context.Context, verb string, src property.Map) error {
+	pm := r.meta.Poll
+	if pm == nil || (len(pm.On) > 0 && !slices.Contains(pm.On, verb)) {
+		return nil
+	}
+	op, err := r.resolveOp("poll", pm.Operation)
+	if err != nil {
+		return err
+	}
+	if op == nil {
+		return fmt.Errorf("rest: poll.operation is required")
+	}
+	opts, err := pm.options()
+	if err != nil {
+		return err
+	}
+	states := poll.States{Success: pm.Success, Failure: pm.Failure}
+	_, err = poll.Status(ctx, opts, states, func(ctx context.Context) (string, error) {
+		body, _, err := r.execAndDecode(ctx, op, src)
+		if IsNotFound(err) {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if len(body) == 0 {
+			return "", nil
+		}
+		var doc any
+		if err := json.Unmarshal(body, &doc); err != nil {
+			return "", fmt.Errorf("rest: decode %s response: %w", op.ID, err)
+		}
+		v, _ := lookupField(doc, pm.StatusField)
+		return statusString(v), nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: waiting for %s: %w", verb, op.ID, err)
+	}
+	return nil
+}
+
+func (pm *PollMeta) options() (poll.Options, error) {
+	var opts poll.Options
+	var err error
+	if pm.Interval != "" {
+		if opts.Interval, err = time.ParseDuration(pm.Interval); err != nil {
+			return poll.Options{}, fmt.Errorf("rest: poll.interval: %w", err)
+		}
+	}
+	if pm.Timeout != "" {
+		if opts.Timeout, err = time.ParseDuration(pm.Timeout); err != nil {
+			return poll.Options{}, fmt.Errorf("rest: poll.timeout: %w", err)
+		}
+	}
+	return opts, nil
+}
+
+// lookupField walks doc along a JSONPath-style path: dot-separated keys,
+// each optionally followed by [n] indexes, with an optional leading "$".
+// Reports false when any step is missing or of the wrong shape.
+func lookupField(doc any, path string) (any, bool) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if path == "" {
+		return doc, true
+	}
+	cur := doc
+	for _, step := range strings.Split(path, ".") {
+		key, indexes, _ := strings.Cut(step, "[")
+		if key != "" {
+			m, ok := cur.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if cur, ok = m[key]; !ok {
+				return nil, false
+			}
+		}
+		if indexes == "" {
+			continue
+		}
+		for _, idx := range strings.Split(strings.TrimSuffix(indexes, "]"), "][") {
+			n, err := strconv.Atoi(idx)
+			arr, ok := cur.([]any)
+			if err != nil || !ok || n < 0 || n >= len(arr) {
+				return nil, false
+			}
+			cur = arr[n]
+		}
+	}
+	return cur, true
+}
+
+// statusString renders a status value for comparison; services report
+// statuses as strings, but a bare boolean or number compares by its JSON
+// spelling.
+func statusString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
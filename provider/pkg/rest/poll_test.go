@@ -0,0 +1,194 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	p "github.com/pulumi/pulumi-go-provider"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/poll"
+)
+
+const (
+	postRunsAcme = "POST /runs/acme"
+	getRunAcme   = "GET /runs/acme/run-1"
+)
+
+// pollSpec is an async create: StartRun accepts the work and returns a run
+// ID, GetRun reports its progress under latest.status.
+func pollSpec(t *testing.T) *Spec {
+	t.Helper()
+	const specJSON = `{
+	  "openapi": "3.0.0",
+	  "components": {"schemas": {
+	    "Body": {"type": "object", "properties": {"value": {"type": "string"}}},
+	    "Run":  {"type": "object", "properties": {"id": {"type": "string"}, "value": {"type": "string"}}}
+	  }},
+	  "paths": {
+	    "/runs/{org}": {
+	      "post": {
+	        "operationId": "StartRun",
+	        "parameters": [{"name": "org", "in": "path", "required": true, "schema": {"type": "string"}}],
+	        "requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Body"}}}},
+	        "responses": {"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Run"}}}}}
+	      }
+	    },
+	    "/runs/{org}/{id}": {
+	      "get": {
+	        "operationId": "GetRun",
+	        "parameters": [
+	          {"name": "org", "in": "path", "required": true, "schema": {"type": "string"}},
+	          {"name": "id",  "in": "path", "required": true, "schema": {"type": "string"}}
+	        ],
+	        "responses": {"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Run"}}}}}
+	      }
+	    }
+	  }
+	}`
+	spec, err := ParseSpec([]byte(specJSON))
+	if err != nil {
+		t.Fatalf("parse synthetic spec: %v", err)
+	}
+	return spec
+}
+
+func pollResource(t *testing.T, timeout string) *Resource {
+	return &Resource{
+		spec: pollSpec(t),
+		meta: ResourceMeta{
+			Operations: Operations{Create: "StartRun", Read: "GetRun"},
+			IDFormat:   "{org}/{id}",
+			Poll: &PollMeta{
+				Operation:   "GetRun",
+				StatusField: "$.latest.status",
+				Success:     []string{"succeeded"},
+				Failure:     []string{"failed"},
+				Interval:    "1ms",
+				Timeout:     timeout,
+			},
+		},
+	}
+}
+
+// runMock answers StartRun with run-1 and GetRun with each status in turn,
+// repeating the last one; "404" answers not-found.
+func runMock(statuses ...string) *mockTransport {
+	polls := 0
+	return &mockTransport{responseFn: func(req *http.Request) mockResponse {
+		if req.Method == http.MethodPost {
+			return mockResponse{status: 200, body: `{"id":"run-1"}`}
+		}
+		status := statuses[min(polls, len(statuses)-1)]
+		polls++
+		if status == "404" {
+			return mockResponse{status: 404, body: `{}`}
+		}
+		return mockResponse{status: 200, body: `{"id":"run-1","value":"v","latest":{"status":"` + status + `"}}`}
+	}}
+}
+
+// TestCreatePollsUntilTerminal: an async create isn't done until the status
+// op says so, and read-after-create only runs once it has.
+func TestCreatePollsUntilTerminal(t *testing.T) {
+	r := pollResource(t, "")
+	mock := runMock("404", "running", "succeeded")
+	SetTransportResolver(func(_ context.Context) (Transport, error) { return mock, nil })
+
+	resp, err := r.Create(t.Context(), p.CreateRequest{Properties: propMap(map[string]any{orgKey: acmeVal})})
+	if err != nil {
+		t.Fatalf("create: %v\n  calls: %v", err, mock.calls)
+	}
+	want := []string{postRunsAcme, getRunAcme, getRunAcme, getRunAcme, getRunAcme}
+	if strings.Join(mock.calls, ",") != strings.Join(want, ",") {
+		t.Errorf("calls = %v, want %v", mock.calls, want)
+	}
+	if resp.ID != "acme/run-1" {
+		t.Errorf("ID = %q, want acme/run-1", resp.ID)
+	}
+}
+
+// TestCreatePollFailureIsPartial: a failed run still exists server-side, so
+// the engine must record it rather than leak it.
+func TestCreatePollFailureIsPartial(t *testing.T) {
+	r := pollResource(t, "")
+	mock := runMock("running", "failed")
+	SetTransportResolver(func(_ context.Context) (Transport, error) { return mock, nil })
+
+	resp, err := r.Create(t.Context(), p.CreateRequest{Properties: propMap(map[string]any{orgKey: acmeVal})})
+	var failed *poll.FailedError
+	if !errors.As(err, &failed) || failed.Status != "failed" {
+		t.Fatalf("expected a FailedError, got: %v", err)
+	}
+	if resp.ID != "acme/run-1" || resp.PartialState == nil {
+		t.Errorf("expected a partial create for acme/run-1, got ID %q partial %v", resp.ID, resp.PartialState)
+	}
+}
+
+func TestCreatePollTimeout(t *testing.T) {
+	r := pollResource(t, "20ms")
+	mock := runMock("running")
+	SetTransportResolver(func(_ context.Context) (Transport, error) { return mock, nil })
+
+	_, err := r.Create(t.Context(), p.CreateRequest{Properties: propMap(map[string]any{orgKey: acmeVal})})
+	var timeout *poll.TimeoutError
+	if !errors.As(err, &timeout) || timeout.Last != "running" {
+		t.Fatalf("expected a TimeoutError with last status running, got: %v", err)
+	}
+}
+
+// TestPollOnSkipsOtherVerbs: On scopes polling to the listed verbs.
+func TestPollOnSkipsOtherVerbs(t *testing.T) {
+	r := pollResource(t, "")
+	r.meta.Poll.On = []string{"update"}
+	mock := runMock("running")
+	SetTransportResolver(func(_ context.Context) (Transport, error) { return mock, nil })
+
+	if _, err := r.Create(t.Context(), p.CreateRequest{Properties: propMap(map[string]any{orgKey: acmeVal})}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if len(mock.calls) != 2 {
+		t.Errorf("expected create and read only, got: %v", mock.calls)
+	}
+}
+
+func TestLookupField(t *testing.T) {
+	doc := map[string]any{
+		"status": "a",
+		"latest": map[string]any{"status": "b"},
+		"runs":   []any{map[string]any{"status": "c"}, []any{"d"}},
+	}
+	for path, want := range map[string]any{
+		"status":           "a",
+		"$.status":         "a",
+		"latest.status":    "b",
+		"$.runs[0].status": "c",
+		"runs[1][0]":       "d",
+	} {
+		got, ok := lookupField(doc, path)
+		if !ok || got != want {
+			t.Errorf("lookupField(%q) = %v, %v; want %v", path, got, ok, want)
+		}
+	}
+	for _, path := range []string{"missing", "status.deeper", "runs[5].status", "runs[x]", "latest[0]"} {
+		if got, ok := lookupField(doc, path); ok {
+			t.Errorf("lookupField(%q) = %v; want a miss", path, got)
+		}
+	}
+}
@@ -333,8 +333,9 @@ func deleteKind(replace bool) p.DiffKind {
 	return p.Delete
 }
 
-// Create executes the create operation, then fires the read op (when
-// declared) and merges its response in. Many Pulumi Cloud create endpoints
+// Create executes the create operation, waits out asynchronous work when
+// poll metadata is declared, then fires the read op (when declared) and
+// merges its response in. Many Pulumi Cloud create endpoints
 // return a sparse body, so without read-after-create downstream resources
 // referencing read-only outputs would fail to converge until refresh.
 func (r *Resource) Create(ctx context.Context, req p.CreateRequest) (p.CreateResponse, error) {
@@ -366,7 +367,7 @@ func (r *Resource) Create(ctx context.Context, req p.CreateRequest) (p.CreateRes
 		if v, ok := req.Properties.GetOk("yaml"); ok && v.IsString() && v.AsString() != "" {
 			_, updState, err := r.execAndDecode(ctx, updOp, req.Properties)
 			if err != nil {
-				return p.CreateResponse{}, fmt.Errorf("create: post-create yaml apply: %w", err)
+				return r.partialCreate(state, req.Properties, fmt.Errorf("create: post-create yaml apply: %w", err))
 			}
 			state = mergeMaps(updState, state)
 		}
@@ -374,8 +375,11 @@ func (r *Resource) Create(ctx context.Context, req p.CreateRequest) (p.CreateRes
 	// Path-parameter values must come from inputs, not state: create endpoints
 	// often return sparse bodies that don't echo path params back.
 	source := mergeMaps(req.Properties, state)
+	if err := r.awaitTerminal(ctx, "create", source); err != nil {
+		return r.partialCreate(state, req.Properties, err)
+	}
 	if fetched, ok, err := r.fetchState(ctx, source, state); err != nil {
-		return p.CreateResponse{}, fmt.Errorf("create: read-after-create: %w", err)
+		return r.partialCreate(state, req.Properties, fmt.Errorf("create: read-after-create: %w", err))
 	} else if ok {
 		state = fetched
 	}
@@ -386,6 +390,22 @@ func (r *Resource) Create(ctx context.Context, req p.CreateRequest) (p.CreateRes
 	return p.CreateResponse{ID: id, Properties: state}, nil
 }
 
+// partialCreate reports a create whose object already exists server-side but
+// whose follow-up calls failed (a Cancel included): the engine records it
+// with the state gathered so far instead of leaking it, and the next update
+// finishes initializing it. Without an ID there is nothing to record.
+func (r *Resource) partialCreate(state, inputs property.Map, err error) (p.CreateResponse, error) {
+	id, idErr := r.synthesizeID(state, inputs)
+	if idErr != nil {
+		return p.CreateResponse{}, err
+	}
+	return p.CreateResponse{
+		ID:           id,
+		Properties:   state,
+		PartialState: &p.InitializationFailed{Reasons: []string{err.Error()}},
+	}, err
+}
+
 // checkAlreadyExists is the requireImport pre-flight: a 200 from read fails
 // with an "import this resource" error; 404 means proceed; other errors
 // propagate. Resources without a read op opt out.
@@ -736,8 +756,19 @@ func (r *Resource) Update(ctx context.Context, req p.UpdateRequest) (p.UpdateRes
 	}
 
 	readURLSrc := mergeMaps(req.State, state, req.OldInputs, req.Inputs)
+	if err := r.awaitTerminal(ctx, "update", mergeMaps(req.Inputs, state, req.State)); err != nil {
+		return p.UpdateResponse{
+			Properties:   mergeMaps(state, req.State),
+			PartialState: &p.InitializationFailed{Reasons: []string{err.Error()}},
+		}, err
+	}
 	if fetched, ok, err := r.fetchState(ctx, readURLSrc, req.State); err != nil {
-		return p.UpdateResponse{}, fmt.Errorf("update: read-after-update: %w", err)
+		// The update landed; keep what it returned rather than the stale
+		// prior state.
+		return p.UpdateResponse{
+			Properties:   mergeMaps(state, req.State),
+			PartialState: &p.InitializationFailed{Reasons: []string{err.Error()}},
+		}, fmt.Errorf("update: read-after-update: %w", err)
 	} else if ok {
 		state = fetched
 	} else {
@@ -867,10 +898,18 @@ func (r *Resource) execAndDecodeSplit(
 func (r *Resource) roundTrip(
 	ctx context.Context, op *Operation, url string, body io.Reader, contentType string,
 ) ([]byte, property.Map, error) {
+	// Fail before touching the network once the call (or the provider) is
+	// canceled; the cause says which.
+	if ctx.Err() != nil {
+		return nil, property.Map{}, fmt.Errorf("rest: %s: %w", op.ID, context.Cause(ctx))
+	}
 	transport, err := resolveTransport(ctx)
 	if err != nil {
 		return nil, property.Map{}, err
 	}
+	if r.meta.RetryPost && op.Method == http.MethodPost {
+		ctx = withRetryOptIn(ctx)
+	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, op.Method, url, body)
 	if err != nil {
@@ -18,12 +18,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
 
@@ -2179,3 +2181,124 @@ func TestReadKeepsResponseOnlyItemFieldsOutOfInputs(t *testing.T) {
 		}
 	}
 }
+
+// cancelThingSpec is a create/read/update resource for the partial-state
+// tests.
+const cancelThingSpec = `{
+  "openapi": "3.0.0",
+  "components": {"schemas": {
+    "Body": {"type": "object", "properties": {"name": {"type": "string"}}},
+    "Read": {"type": "object", "properties": {"id": {"type": "string"}, "name": {"type": "string"}}}
+  }},
+  "paths": {
+    "/things/{org}": {
+      "post": {
+        "operationId": "CreateThing",
+        "parameters": [{"name": "org", "in": "path", "required": true, "schema": {"type": "string"}}],
+        "requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Body"}}}},
+        "responses": {"200": {"content": {"application/json": {
+          "schema": {"type": "object", "properties": {"id": {"type": "string"}}}
+        }}}}
+      }
+    },
+    "/things/{org}/{id}": {
+      "get": {
+        "operationId": "GetThing",
+        "parameters": [
+          {"name": "org", "in": "path", "required": true, "schema": {"type": "string"}},
+          {"name": "id",  "in": "path", "required": true, "schema": {"type": "string"}}
+        ],
+        "responses": {"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Read"}}}}}
+      },
+      "patch": {
+        "operationId": "UpdateThing",
+        "parameters": [
+          {"name": "org", "in": "path", "required": true, "schema": {"type": "string"}},
+          {"name": "id",  "in": "path", "required": true, "schema": {"type": "string"}}
+        ],
+        "requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Body"}}}},
+        "responses": {"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Read"}}}}}
+      }
+    }
+  }
+}`
+
+// TestCreateCanceledAfterCreateReturnsPartialState: a cancellation landing
+// between the create call and read-after-create must not leak the object —
+// Create reports it as partial state under its ID, and the read never
+// reaches the transport.
+func TestCreateCanceledAfterCreateReturnsPartialState(t *testing.T) {
+	spec, err := ParseSpec([]byte(cancelThingSpec))
+	if err != nil {
+		t.Fatalf("parse synthetic spec: %v", err)
+	}
+	r := &Resource{
+		spec: spec,
+		meta: ResourceMeta{
+			Operations: Operations{Create: createThingOp, Read: getThingOp},
+			IDFormat:   orgIDFormat,
+		},
+	}
+	ctx, cancel := context.WithCancelCause(t.Context())
+	canceled := errors.New("canceled by test")
+	mock := &mockTransport{responseFn: func(_ *http.Request) mockResponse {
+		cancel(canceled)
+		return mockResponse{status: 200, body: `{"id":"thing-1"}`}
+	}}
+	ctx = WithTransport(ctx, mock)
+
+	resp, err := r.Create(ctx, p.CreateRequest{
+		Properties: propMap(map[string]any{orgKey: acmeVal, nameKey: fooVal}),
+	})
+	if !errors.Is(err, canceled) {
+		t.Fatalf("err = %v, want the cancellation cause", err)
+	}
+	if want := []string{postThingsAcme}; !slices.Equal(mock.calls, want) {
+		t.Errorf("calls = %v, want only the create", mock.calls)
+	}
+	if resp.PartialState == nil {
+		t.Fatal("want partial state so the created object is recorded")
+	}
+	if resp.ID != "acme/thing-1" {
+		t.Errorf("ID = %q, want acme/thing-1", resp.ID)
+	}
+}
+
+// TestUpdateReadFailureKeepsUpdateResponse: when read-after-update fails the
+// update has already landed, so its response (over prior state) comes back
+// as partial state instead of being dropped.
+func TestUpdateReadFailureKeepsUpdateResponse(t *testing.T) {
+	spec, err := ParseSpec([]byte(cancelThingSpec))
+	if err != nil {
+		t.Fatalf("parse synthetic spec: %v", err)
+	}
+	r := &Resource{
+		spec: spec,
+		meta: ResourceMeta{
+			Operations: Operations{Create: createThingOp, Read: getThingOp, Update: "UpdateThing"},
+			IDFormat:   orgIDFormat,
+		},
+	}
+	mock := &mockTransport{responses: map[string]mockResponse{
+		"PATCH /things/acme/thing-1": {status: 200, body: `{"id":"thing-1","name":"bar"}`},
+		getThingPath:                 {status: 503, body: "unavailable"},
+	}}
+	ctx := WithTransport(t.Context(), mock)
+
+	prior := propMap(map[string]any{orgKey: acmeVal, "id": "thing-1", nameKey: fooVal})
+	resp, err := r.Update(ctx, p.UpdateRequest{
+		ID:        "acme/thing-1",
+		State:     prior,
+		OldInputs: prior,
+		Inputs:    propMap(map[string]any{orgKey: acmeVal, "id": "thing-1", nameKey: "bar"}),
+	})
+	if err == nil {
+		t.Fatal("want the read-after-update error")
+	}
+	if resp.PartialState == nil {
+		t.Fatal("want partial state carrying the update response")
+	}
+	if got := resp.Properties.Get(nameKey).AsString(); got != "bar" {
+		t.Errorf("name = %q, want the updated value", got)
+	}
+}
@@ -16,6 +16,7 @@ package rest
 
 import (
 	"fmt"
+	"net/http"
 	"slices"
 	"strings"
 
@@ -45,6 +46,18 @@ func BuildSchema(spec *Spec, metadata *Metadata, pkg string) (*schema.PackageSpe
 		}
 		out.Resources[token] = *rs
 	}
+	for key, fm := range metadata.Functions {
+		token := key
+		if fm.Token != "" {
+			token = fm.Token
+		}
+		fs, err := buildFunction(spec, fm)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", token, err))
+			continue
+		}
+		out.Functions[token] = *fs
+	}
 
 	if len(errs) > 0 {
 		return nil, fmt.Errorf("rest: build schema:\n  - %s", strings.Join(errs, "\n  - "))
@@ -202,6 +215,76 @@ func buildResource(spec *Spec, _ *Metadata, _ string, rm ResourceMeta) (*schema.
 	return rs, nil
 }
 
+// buildFunction generates the schema for a data source: inputs are the GET
+// op's path and query params, outputs its response body under the same
+// renames and allowlist/denylist rules resources use.
+func buildFunction(spec *Spec, fm FunctionMeta) (*schema.FunctionSpec, error) {
+	if fm.Operation == "" {
+		return nil, fmt.Errorf("operation is required")
+	}
+	op, ok := spec.Op(fm.Operation)
+	if !ok {
+		return nil, fmt.Errorf("operation %q not found in spec", fm.Operation)
+	}
+	if op.Method != http.MethodGet {
+		return nil, fmt.Errorf("operation %q is %s; functions require GET", fm.Operation, op.Method)
+	}
+
+	inputs := map[string]schema.PropertySpec{}
+	required := map[string]bool{}
+	for _, pp := range op.Parameters {
+		if pp.In != inPath && pp.In != inQuery {
+			continue
+		}
+		name := pulumiName(pp.Name, fm.Renames)
+		ps := schema.PropertySpec{
+			TypeSpec:    schema.TypeSpec{Type: defaultParamType(pp.SchemaType)},
+			Description: pp.Description,
+		}
+		applyFieldMeta(&ps, fm.Fields[name], false)
+		inputs[name] = ps
+		if pp.Required || pp.In == inPath {
+			required[name] = true
+		}
+	}
+
+	outputs, requiredOutputs, err := responseOutputs(spec, op, fm.resourceMeta(), false)
+	if err != nil {
+		return nil, fmt.Errorf("outputs: %w", err)
+	}
+	if outputs == nil {
+		outputs = map[string]schema.PropertySpec{}
+	}
+
+	for fieldName := range fm.Fields {
+		_, inInputs := inputs[fieldName]
+		_, inOutputs := outputs[fieldName]
+		if !inInputs && !inOutputs {
+			return nil, fmt.Errorf("metadata.fields[%q] does not match any input or output field", fieldName)
+		}
+	}
+
+	desc := fm.Description
+	if desc == "" {
+		desc = op.Description
+	}
+
+	fs := &schema.FunctionSpec{
+		Description: desc,
+		Inputs: &schema.ObjectTypeSpec{
+			Type:       "object",
+			Properties: inputs,
+			Required:   sortedKeys(required),
+		},
+		Outputs: &schema.ObjectTypeSpec{
+			Type:       "object",
+			Properties: outputs,
+			Required:   requiredOutputs,
+		},
+	}
+	return fs, nil
+}
+
 // buildAttachmentResource generates the schema for an attachment resource:
 // inputs are the mutation op's parent path params plus the edge fields (the
 // AddField's object schema), all replace-on-change. Outputs mirror inputs,
@@ -469,6 +552,12 @@ func mergePathParamsAsInputs(inputs map[string]schema.PropertySpec, required *[]
 // operationOutputs builds the State output PropertySpec map from an op's
 // response body, applying the metadata allowlist or denylist.
 func operationOutputs(spec *Spec, op *Operation, rm ResourceMeta) (map[string]schema.PropertySpec, []string, error) {
+	return responseOutputs(spec, op, rm, true)
+}
+
+// responseOutputs is operationOutputs with the "id" reservation optional:
+// functions have no synthesized ID, so they return the field as-is.
+func responseOutputs(spec *Spec, op *Operation, rm ResourceMeta, reserveID bool) (map[string]schema.PropertySpec, []string, error) {
 	if op == nil || op.ResponseRef == "" {
 		return nil, nil, nil
 	}
@@ -486,7 +575,7 @@ func operationOutputs(spec *Spec, op *Operation, rm ResourceMeta) (map[string]sc
 	for k, p := range bodyProps {
 		name := pulumiName(k, rm.Renames)
 		// Pulumi reserves "id" for the synthesized resource ID; skip it.
-		if name == "id" {
+		if reserveID && name == "id" {
 			continue
 		}
 		if len(allowlist) > 0 {
@@ -37,6 +37,9 @@ func TestBuildSchemaSucceeds(t *testing.T) {
 	if got, want := len(pkg.Resources), len(meta.Resources); got != want {
 		t.Errorf("Resources: got %d, want %d (one per metadata entry)", got, want)
 	}
+	if got, want := len(pkg.Functions), len(meta.Functions); got != want {
+		t.Errorf("Functions: got %d, want %d (one per metadata entry)", got, want)
+	}
 }
 
 // TestPathParamsAreInputOnly pins that purely-path-param fields appear only
@@ -41,6 +41,22 @@ func WithTransport(ctx context.Context, t Transport) context.Context {
 	return context.WithValue(ctx, transportCtxKey{}, t)
 }
 
+type retryOptInCtxKey struct{}
+
+// withRetryOptIn marks ctx as carrying a request its resource's metadata
+// declared safe to retry (ResourceMeta.RetryPost).
+func withRetryOptIn(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryOptInCtxKey{}, true)
+}
+
+// RetryOptIn reports whether the request sent under ctx was declared safe to
+// retry despite its non-idempotent method. Transports that retry consult
+// this; the rest engine itself never retries.
+func RetryOptIn(ctx context.Context) bool {
+	v, _ := ctx.Value(retryOptInCtxKey{}).(bool)
+	return v
+}
+
 var (
 	resolverMu sync.RWMutex
 	resolver   TransportResolver
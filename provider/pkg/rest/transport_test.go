@@ -16,7 +16,12 @@ package rest
 
 import (
 	"context"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
+
+	p "github.com/pulumi/pulumi-go-provider"
 )
 
 // TestWithTransport_BeatsGlobalResolver: a ctx-scoped transport wins over the
@@ -70,3 +75,60 @@ func TestResolveTransport_NoTransportReturnsError(t *testing.T) {
 		t.Fatalf("expected error with no transport configured")
 	}
 }
+
+// optInRecorder records, per request, whether the rest engine flagged it as
+// retry-safe.
+type optInRecorder struct {
+	optIns map[string]bool
+}
+
+func (o *optInRecorder) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	o.optIns[req.Method+" "+req.URL.Path] = RetryOptIn(ctx)
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+}
+
+// TestRoundTripFlagsRetryPostOptIn: RetryPost marks only the resource's POSTs
+// as retry-safe; other verbs are left to the transport's idempotency rules,
+// and resources without the flag never opt in.
+func TestRoundTripFlagsRetryPostOptIn(t *testing.T) {
+	const specJSON = `{
+	  "openapi": "3.0.0",
+	  "paths": {
+	    "/things/{org}": {
+	      "post": {
+	        "operationId": "CreateThing",
+	        "parameters": [{"name": "org", "in": "path", "required": true, "schema": {"type": "string"}}],
+	        "responses": {"204": {"description": "no content"}}
+	      },
+	      "get": {
+	        "operationId": "GetThing",
+	        "parameters": [{"name": "org", "in": "path", "required": true, "schema": {"type": "string"}}],
+	        "responses": {"200": {"description": "ok"}}
+	      }
+	    }
+	  }
+	}`
+	spec, err := ParseSpec([]byte(specJSON))
+	if err != nil {
+		t.Fatalf("parse synthetic spec: %v", err)
+	}
+	for _, retryPost := range []bool{true, false} {
+		rec := &optInRecorder{optIns: map[string]bool{}}
+		r := &Resource{spec: spec, meta: ResourceMeta{
+			Operations: Operations{Create: createThingOp, Read: getThingOp},
+			RetryPost:  retryPost,
+		}}
+		_, err := r.Create(WithTransport(t.Context(), rec), p.CreateRequest{
+			Properties: propMap(map[string]any{orgKey: acmeVal}),
+		})
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if got := rec.optIns[postThingsAcme]; got != retryPost {
+			t.Errorf("retryPost=%v: POST opt-in = %v", retryPost, got)
+		}
+		if rec.optIns[getThingsAcme] {
+			t.Errorf("retryPost=%v: GET must not carry the POST opt-in", retryPost)
+		}
+	}
+}
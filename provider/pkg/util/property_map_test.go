@@ -1,112 +0,0 @@
-package util
-
-import (
-	"testing"
-
-	"github.com/stretchr/testify/assert"
-
-	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
-)
-
-// Repeated test-only literals, hoisted so goconst stops flagging them.
-const (
-	exampleVal = "example"
-	keptVal    = "kept"
-)
-
-func TestToPropertyMap(t *testing.T) {
-	type A struct {
-		IntField      int      `pulumi:"int_field"`
-		FloatField    float64  `pulumi:"float_field"`
-		StringField   string   `pulumi:"string_field"`
-		FloatPtrField *float64 `pulumi:"floatptr_field"`
-		IntPtrField   *int64   `json:"intptr_field"`
-	}
-	intvalue := int64(1)
-	floatValue := 2.5
-	a := A{
-		IntField:      int(intvalue),
-		FloatField:    floatValue,
-		StringField:   exampleVal,
-		FloatPtrField: &floatValue,
-		IntPtrField:   &intvalue,
-	}
-	propertyMap := ToPropertyMap(a)
-	expected := map[string]interface{}{
-		// NewPropertyValue() auto converts ints to float64
-		"int_field":    float64(1),
-		"float_field":  float64(2.5),
-		"string_field": exampleVal,
-	}
-	for key, value := range expected {
-		actualValue := propertyMap[resource.PropertyKey(key)].V
-		assert.Equal(t, value, actualValue, key)
-	}
-}
-
-func TestFromPropertyMap(t *testing.T) {
-	type A struct {
-		IntField      int      `pulumi:"int_field"`
-		FloatField    float64  `pulumi:"float_field"`
-		StringField   string   `pulumi:"string_field"`
-		FloatPtrField *float64 `pulumi:"floatptr_field"`
-		IntPtrField   *int64   `pulumi:"intptr_field"`
-	}
-	t.Run("Unmarshals Props Properly", func(t *testing.T) {
-		intValue := int64(1)
-		floatValue := 2.5
-		want := A{
-			IntField:      int(intValue),
-			FloatField:    floatValue,
-			StringField:   exampleVal,
-			FloatPtrField: &floatValue,
-			IntPtrField:   &intValue,
-		}
-		propertyMap := resource.PropertyMap{}
-		expected := map[string]any{
-			// NewPropertyValue() auto converts ints to float64
-			"int_field":      float64(1),
-			"float_field":    float64(2.5),
-			"string_field":   exampleVal,
-			"floatptr_field": &floatValue,
-			"intptr_field":   &intValue,
-		}
-		for key, value := range expected {
-			propertyMap[resource.PropertyKey(key)] = resource.NewPropertyValue(value)
-		}
-		got := A{}
-		err := FromPropertyMap(propertyMap, &got)
-		assert.NoError(t, err)
-		assert.Equal(t, want, got)
-	})
-
-	t.Run("Skips Missing Keys", func(t *testing.T) {
-		propertyMap := resource.PropertyMap{}
-		// property map is empty, no fields should be set
-		want := A{}
-
-		got := A{}
-		err := FromPropertyMap(propertyMap, &got)
-
-		// no error should be returned, even though struct has pulumi tags
-		// that aren't present in propertyMap
-		assert.NoError(t, err)
-		assert.Equal(t, want, got)
-	})
-
-	t.Run("Replaces map fields rather than merging into existing values", func(t *testing.T) {
-		type B struct {
-			Tags map[string]string `pulumi:"tags"`
-		}
-		// A struct that already has a populated map — the incoming property
-		// map has a smaller set of keys. Decoding must not leave the stale
-		// "kept" key behind.
-		got := B{Tags: map[string]string{keptVal: "old", "removed": "old"}}
-		propertyMap := resource.PropertyMap{
-			"tags": resource.NewPropertyValue(map[string]any{keptVal: "new"}),
-		}
-		err := FromPropertyMap(propertyMap, &got)
-		assert.NoError(t, err)
-		assert.Equal(t, map[string]string{keptVal: "new"}, got.Tags)
-	})
-}
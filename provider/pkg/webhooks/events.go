@@ -0,0 +1,140 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"encoding/json"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
+)
+
+// Kind is the kind of event a delivery describes, as found in the
+// HeaderKind header.
+type Kind string
+
+const (
+	KindPing            Kind = "ping"
+	KindStack           Kind = "stack"
+	KindStackUpdate     Kind = "stack_update"
+	KindDeployment      Kind = "deployment"
+	KindEnvironment     Kind = "environment"
+	KindPolicyViolation Kind = "policy_violation"
+)
+
+// Event is a decoded webhook payload: one of the *Event types of this
+// package.
+type Event interface {
+	Kind() Kind
+}
+
+// Identity is a Pulumi Cloud user or organization.
+type Identity struct {
+	Name        string `json:"name"`
+	GitHubLogin string `json:"githubLogin"`
+	AvatarURL   string `json:"avatarUrl"`
+}
+
+// Envelope holds the fields every event but a ping carries: who caused the
+// event and in which organization.
+type Envelope struct {
+	User         Identity `json:"user"`
+	Organization Identity `json:"organization"`
+}
+
+// PingEvent is the test event sent when a webhook is pinged.
+type PingEvent struct {
+	Message string `json:"message"`
+}
+
+// StackEvent reports that a stack was created or deleted.
+type StackEvent struct {
+	Envelope
+	// Action is `created` or `deleted`.
+	Action      string `json:"action"`
+	ProjectName string `json:"projectName"`
+	StackName   string `json:"stackName"`
+}
+
+// StackUpdateEvent reports that an update, preview, refresh or destroy of a
+// stack finished.
+type StackUpdateEvent struct {
+	Envelope
+	ProjectName string                  `json:"projectName"`
+	StackName   string                  `json:"stackName"`
+	UpdateURL   string                  `json:"updateUrl"`
+	UpdateKind  apitype.AppUpdateKind   `json:"kind"`
+	Result      apitype.AppUpdateResult `json:"result"`
+	// ResourceChanges counts resources by operation, e.g. `create` or `same`.
+	ResourceChanges map[string]int `json:"resourceChanges,omitempty"`
+}
+
+// DeploymentEvent reports a Pulumi Deployments run changing status.
+type DeploymentEvent struct {
+	Envelope
+	ProjectName   string                  `json:"projectName"`
+	StackName     string                  `json:"stackName"`
+	DeploymentID  string                  `json:"id"`
+	Version       int64                   `json:"version"`
+	Operation     apitype.PulumiOperation `json:"operation"`
+	Status        apitype.JobStatus       `json:"status"`
+	DeploymentURL string                  `json:"deploymentUrl"`
+}
+
+// EnvironmentEvent reports a change to an ESC environment: its creation or
+// deletion, a new or retracted revision, a tag change or a secret rotation.
+type EnvironmentEvent struct {
+	Envelope
+	ProjectName     string `json:"projectName"`
+	EnvironmentName string `json:"environmentName"`
+	// Action is the webhook filter the event matches without its
+	// `environment_` prefix, e.g. `revision_created` or `rotation_failed`.
+	Action   string `json:"action"`
+	Revision *int64 `json:"revision,omitempty"`
+	// Tag is the revision or environment tag of a tag event.
+	Tag *string `json:"tag,omitempty"`
+	// Rotation is the outcome of a rotation event.
+	Rotation *apitype.SecretRotationEvent `json:"rotation,omitempty"`
+}
+
+// PolicyViolationEvent reports a resource violating a policy during an
+// update or preview.
+type PolicyViolationEvent struct {
+	Envelope
+	ProjectName       string `json:"projectName"`
+	StackName         string `json:"stackName"`
+	UpdateURL         string `json:"updateUrl"`
+	PolicyPack        string `json:"policyPack"`
+	PolicyPackVersion string `json:"policyPackTag"`
+	PolicyName        string `json:"policyName"`
+	// EnforcementLevel is `advisory` or `mandatory`.
+	EnforcementLevel string `json:"enforcementLevel"`
+	ResourceURN      string `json:"resourceUrn"`
+	Message          string `json:"message"`
+}
+
+// UnknownEvent is an event of a kind this package does not decode. Payload
+// is the raw JSON body.
+type UnknownEvent struct {
+	EventKind Kind
+	Payload   json.RawMessage
+}
+
+func (*PingEvent) Kind() Kind            { return KindPing }
+func (*StackEvent) Kind() Kind           { return KindStack }
+func (*StackUpdateEvent) Kind() Kind     { return KindStackUpdate }
+func (*DeploymentEvent) Kind() Kind      { return KindDeployment }
+func (*EnvironmentEvent) Kind() Kind     { return KindEnvironment }
+func (*PolicyViolationEvent) Kind() Kind { return KindPolicyViolation }
+func (e *UnknownEvent) Kind() Kind       { return e.EventKind }
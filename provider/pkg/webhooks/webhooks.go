@@ -0,0 +1,138 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhooks verifies and decodes the payloads Pulumi Cloud sends to
+// webhooks in the `raw` format.
+//
+// A receiver typically checks and decodes a request in one call:
+//
+//	event, err := webhooks.ParseRequest(r, secret)
+//	if err != nil {
+//		http.Error(w, err.Error(), http.StatusBadRequest)
+//		return
+//	}
+//	switch e := event.(type) {
+//	case *webhooks.StackUpdateEvent:
+//		...
+//	}
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Headers Pulumi Cloud sets on every delivery.
+const (
+	// HeaderID identifies the delivery. A redelivery keeps the ID.
+	HeaderID = "Pulumi-Webhook-ID"
+	// HeaderKind is the kind of event the payload describes.
+	HeaderKind = "Pulumi-Webhook-Kind"
+	// HeaderSignature is the hex-encoded HMAC-SHA256 of the body, keyed with
+	// the webhook's secret. It is only set when the webhook has a secret.
+	HeaderSignature = "Pulumi-Webhook-Signature"
+)
+
+// maxPayloadBytes bounds the body ParseRequest reads.
+const maxPayloadBytes = 1 << 20
+
+var (
+	// ErrMissingSignature is returned when a secret is configured but the
+	// request carries no signature.
+	ErrMissingSignature = errors.New("webhook request is not signed")
+	// ErrInvalidSignature is returned when the signature does not match the
+	// body and secret.
+	ErrInvalidSignature = errors.New("webhook signature does not match the payload")
+)
+
+// Sign returns the signature Pulumi Cloud sends for body when the webhook
+// has the given secret.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks signature, as found in the HeaderSignature header, against
+// the body and the webhook's secret.
+func Verify(secret, body []byte, signature string) error {
+	if signature == "" {
+		return ErrMissingSignature
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// ParseRequest reads a delivery, verifies its signature when secret is not
+// empty, and decodes it according to its HeaderKind. Receivers should always
+// pass the webhook's secret when it has one: an empty secret skips
+// verification.
+func ParseRequest(r *http.Request, secret []byte) (Event, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading webhook payload: %w", err)
+	}
+	if len(body) > maxPayloadBytes {
+		return nil, fmt.Errorf("webhook payload exceeds %d bytes", maxPayloadBytes)
+	}
+	if len(secret) > 0 {
+		if err := Verify(secret, body, r.Header.Get(HeaderSignature)); err != nil {
+			return nil, err
+		}
+	}
+	return Parse(r.Header.Get(HeaderKind), body)
+}
+
+// Parse decodes a payload of the given kind. Kinds this package does not
+// know about decode to an *UnknownEvent rather than failing, so receivers
+// keep working when Pulumi Cloud adds new ones.
+func Parse(kind string, body []byte) (Event, error) {
+	var event Event
+	switch Kind(kind) {
+	case KindPing:
+		event = &PingEvent{}
+	case KindStack:
+		event = &StackEvent{}
+	case KindStackUpdate:
+		event = &StackUpdateEvent{}
+	case KindDeployment:
+		event = &DeploymentEvent{}
+	case KindEnvironment:
+		event = &EnvironmentEvent{}
+	case KindPolicyViolation:
+		event = &PolicyViolationEvent{}
+	case "":
+		return nil, fmt.Errorf("webhook request has no %s header", HeaderKind)
+	default:
+		return &UnknownEvent{EventKind: Kind(kind), Payload: json.RawMessage(body)}, nil
+	}
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, fmt.Errorf("decoding %s webhook payload: %w", kind, err)
+	}
+	return event, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/apitype"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/webhooks"
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/webhooks/webhookstest"
+)
+
+var secret = []byte("s3cret")
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"message":"hi"}`)
+	signature := webhooks.Sign(secret, body)
+
+	assert.NoError(t, webhooks.Verify(secret, body, signature))
+	assert.ErrorIs(t, webhooks.Verify([]byte("other"), body, signature), webhooks.ErrInvalidSignature)
+	assert.ErrorIs(t, webhooks.Verify(secret, []byte(`{"message":"ho"}`), signature), webhooks.ErrInvalidSignature)
+	assert.ErrorIs(t, webhooks.Verify(secret, body, "not-hex"), webhooks.ErrInvalidSignature)
+	assert.ErrorIs(t, webhooks.Verify(secret, body, ""), webhooks.ErrMissingSignature)
+}
+
+func TestParseRequest(t *testing.T) {
+	acme := webhooks.Envelope{
+		User: webhooks.Identity{Name: "Jane Doe", GitHubLogin: "jdoe", AvatarURL: "https://example.com/jdoe.png"},
+		Organization: webhooks.Identity{
+			Name: "acme", GitHubLogin: "acme", AvatarURL: "https://example.com/acme.png",
+		},
+	}
+	revision, postRotation := int64(12), int64(12)
+	created := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	completed := created.Add(5 * time.Second)
+
+	for kind, want := range map[webhooks.Kind]webhooks.Event{
+		webhooks.KindPing: &webhooks.PingEvent{Message: "Just a friendly ping from Pulumi Cloud"},
+		webhooks.KindStack: &webhooks.StackEvent{
+			Envelope: acme, Action: "created", ProjectName: "website", StackName: "prod",
+		},
+		webhooks.KindStackUpdate: &webhooks.StackUpdateEvent{
+			Envelope:        acme,
+			ProjectName:     "website",
+			StackName:       "prod",
+			UpdateURL:       "https://app.pulumi.com/acme/website/prod/updates/42",
+			UpdateKind:      apitype.AppUpdateKindUpdate,
+			Result:          apitype.AppUpdateResultSucceeded,
+			ResourceChanges: map[string]int{"create": 1, "update": 2, "same": 14},
+		},
+		webhooks.KindDeployment: &webhooks.DeploymentEvent{
+			Envelope:      acme,
+			ProjectName:   "website",
+			StackName:     "prod",
+			DeploymentID:  "c1b1e52c-3c0f-4a1a-9b5d-5e1e1e5a4f11",
+			Version:       7,
+			Operation:     apitype.PulumiOperationUpdate,
+			Status:        apitype.JobStatusFailed,
+			DeploymentURL: "https://app.pulumi.com/acme/website/prod/deployments/7",
+		},
+		webhooks.KindEnvironment: &webhooks.EnvironmentEvent{
+			Envelope:        acme,
+			ProjectName:     "infra",
+			EnvironmentName: "prod",
+			Action:          "rotation_succeeded",
+			Revision:        &revision,
+			Rotation: &apitype.SecretRotationEvent{
+				ID:                   "rot-1",
+				EnvironmentID:        "env-1",
+				CreatedAt:            created,
+				PreRotationRevision:  11,
+				PostRotationRevision: &postRotation,
+				UserID:               "user-1",
+				CompletedAt:          &completed,
+				Status:               "succeeded",
+				Rotations: []apitype.SecretRotation{
+					{ID: "sr-1", EnvironmentPath: "aws.creds", Status: "succeeded"},
+				},
+			},
+		},
+		webhooks.KindPolicyViolation: &webhooks.PolicyViolationEvent{
+			Envelope:          acme,
+			ProjectName:       "website",
+			StackName:         "prod",
+			UpdateURL:         "https://app.pulumi.com/acme/website/prod/previews/5f7c",
+			PolicyPack:        "aws-guard",
+			PolicyPackVersion: "1.2.0",
+			PolicyName:        "s3-no-public-read",
+			EnforcementLevel:  "mandatory",
+			ResourceURN:       "urn:pulumi:prod::website::aws:s3/bucket:Bucket::site",
+			Message:           "Bucket must not be publicly readable.",
+		},
+	} {
+		t.Run(string(kind), func(t *testing.T) {
+			r := webhookstest.NewRequest(kind, webhookstest.Payload(kind), secret)
+			got, err := webhooks.ParseRequest(r, secret)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+			assert.Equal(t, kind, got.Kind())
+		})
+	}
+}
+
+func TestParseRequestRejectsTampering(t *testing.T) {
+	r := webhookstest.NewRequest(webhooks.KindStack, webhookstest.Payload(webhooks.KindStack), []byte("other"))
+	_, err := webhooks.ParseRequest(r, secret)
+	assert.ErrorIs(t, err, webhooks.ErrInvalidSignature)
+
+	r = webhookstest.NewRequest(webhooks.KindStack, webhookstest.Payload(webhooks.KindStack), nil)
+	_, err = webhooks.ParseRequest(r, secret)
+	assert.ErrorIs(t, err, webhooks.ErrMissingSignature)
+}
+
+func TestParse(t *testing.T) {
+	t.Run("unknown kinds are kept raw", func(t *testing.T) {
+		got, err := webhooks.Parse("drift_detection", []byte(`{"projectName":"website"}`))
+		require.NoError(t, err)
+		unknown, ok := got.(*webhooks.UnknownEvent)
+		require.True(t, ok)
+		assert.Equal(t, webhooks.Kind("drift_detection"), unknown.Kind())
+		assert.JSONEq(t, `{"projectName":"website"}`, string(unknown.Payload))
+	})
+
+	t.Run("a missing kind is an error", func(t *testing.T) {
+		_, err := webhooks.Parse("", []byte(`{}`))
+		assert.ErrorContains(t, err, webhooks.HeaderKind)
+	})
+
+	t.Run("malformed payloads are errors", func(t *testing.T) {
+		_, err := webhooks.Parse(string(webhooks.KindStack), []byte(`{"stackName": 1}`))
+		assert.ErrorContains(t, err, "decoding stack webhook payload")
+	})
+
+	t.Run("oversized payloads are rejected", func(t *testing.T) {
+		body := []byte(`{"message":"` + strings.Repeat("a", 1<<20) + `"}`)
+		_, err := webhooks.ParseRequest(webhookstest.NewRequest(webhooks.KindPing, body, nil), nil)
+		assert.ErrorContains(t, err, "exceeds")
+	})
+}
@@ -0,0 +1,54 @@
+// Copyright 2016-2026, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhookstest provides sample Pulumi Cloud webhook deliveries for
+// testing receivers built on the webhooks package.
+package webhookstest
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/pulumi/pulumi-pulumiservice/provider/pkg/webhooks"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// Payload returns the sample payload of the given kind. It panics if there is
+// none, so it is only meant for tests.
+func Payload(kind webhooks.Kind) []byte {
+	payload, err := fixtures.ReadFile(fmt.Sprintf("fixtures/%s.json", kind))
+	if err != nil {
+		panic(fmt.Sprintf("no sample payload for webhook kind %q", kind))
+	}
+	return payload
+}
+
+// NewRequest returns a delivery of body, as Pulumi Cloud would send it to a
+// webhook of kind with the given secret. An empty secret leaves the request
+// unsigned.
+func NewRequest(kind webhooks.Kind, body, secret []byte) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set(webhooks.HeaderID, "0b9d7c4e-2f4a-4c4e-9a6b-8b0c3f1d2e5a")
+	r.Header.Set(webhooks.HeaderKind, string(kind))
+	if len(secret) > 0 {
+		r.Header.Set(webhooks.HeaderSignature, webhooks.Sign(secret, body))
+	}
+	return r
+}
@@ -23,6 +23,11 @@
 //
 // To exclude a derived token, add it to the top-level `_excluded`
 // array in metadata.json.
+//
+// Functions are opt-in: every resource whose read op is a GET yields a
+// get<Type> function candidate, but only candidates with an entry (even an
+// empty `{}`) under `functions` are written. The scaffolder fills in the
+// operation, token, and renames; the rest is hand-curated like resources.
 package main
 
 import (
@@ -153,13 +158,14 @@ var moduleAliases = map[string]string{
 	"teams/tokens":       "tokens",
 }
 
-// metadataDoc mirrors metadata.json. Resources serialize via RawMessage so
-// the scaffolder doesn't drop fields it doesn't recognize.
+// metadataDoc mirrors metadata.json. Resources and functions serialize via
+// RawMessage so the scaffolder doesn't drop fields it doesn't recognize.
 type metadataDoc struct {
 	Package   string                     `json:"package,omitempty"`
 	Note      string                     `json:"_note,omitempty"`
 	Excluded  []string                   `json:"_excluded,omitempty"`
 	Resources map[string]json.RawMessage `json:"resources"`
+	Functions map[string]json.RawMessage `json:"functions,omitempty"`
 }
 
 // unmappedFieldSet holds update-body wire fields no input can populate,
@@ -299,6 +305,27 @@ func main() {
 		}
 	}
 
+	// Function pass: refresh opted-in get<Type> entries from their resource's
+	// read op. Runs after the resource pass so renames reflect hand overrides.
+	fnCandidates := deriveFunctions(parsedSpec, candidates, excluded, doc, modules)
+	fnUpdated := 0
+	var fnOrphans []string
+	for _, key := range slices.Sorted(maps.Keys(doc.Functions)) {
+		fd, ok := fnCandidates[key]
+		if !ok {
+			fnOrphans = append(fnOrphans, key)
+			continue
+		}
+		merged, changed, err := mergeFunction(doc.Functions[key], fd)
+		if err != nil {
+			fail("merge function %s: %v", key, err)
+		}
+		if changed {
+			fnUpdated++
+		}
+		doc.Functions[key] = merged
+	}
+
 	// Tokens that survived in metadata.json but didn't make it into candidates
 	// are reported as orphans — typically a spec change or heuristic miss.
 	var orphans []string
@@ -327,6 +354,14 @@ func main() {
 	fmt.Fprintf(os.Stderr, "  skipped (no Create+Read|Delete): %d\n", len(stats.skipped))
 	fmt.Fprintf(os.Stderr, "  attachment resources emitted: %d new, %d updated (add/remove pairs skipped: %d)\n",
 		attachAdded, attachChanged, attachSkipped)
+	fmt.Fprintf(os.Stderr, "  functions: %d opted in (%d updated) of %d derivable; add an empty functions entry to opt in\n",
+		len(doc.Functions), fnUpdated, len(fnCandidates))
+	if len(fnOrphans) > 0 {
+		fmt.Fprintf(os.Stderr, "  function orphans (not derived from spec; kept as written): %d\n", len(fnOrphans))
+		for _, o := range fnOrphans {
+			fmt.Fprintf(os.Stderr, "    %s\n", o)
+		}
+	}
 	if len(orphans) > 0 {
 		fmt.Fprintf(os.Stderr, "  orphans (in metadata.json, not derived from spec): %d\n", len(orphans))
 		for _, o := range orphans {
@@ -1668,10 +1703,27 @@ func writeMetadata(path string, doc *metadataDoc) error {
 		b.Write(ex)
 		b.WriteString(",\n")
 	}
-	tokens := slices.Collect(maps.Keys(doc.Resources))
+	b.WriteString("  \"resources\": ")
+	if err := writeEntries(&b, doc.Resources); err != nil {
+		return err
+	}
+	if len(doc.Functions) > 0 {
+		b.WriteString(",\n  \"functions\": ")
+		if err := writeEntries(&b, doc.Functions); err != nil {
+			return err
+		}
+	}
+	b.WriteString("\n}\n")
+
+	return atomicWriteFile(path, []byte(b.String()), 0o600)
+}
+
+// writeEntries writes a token-keyed object, one sorted entry per line group.
+func writeEntries(b *strings.Builder, entries map[string]json.RawMessage) error {
+	tokens := slices.Collect(maps.Keys(entries))
 	sort.Strings(tokens)
 
-	b.WriteString("  \"resources\": {")
+	b.WriteString("{")
 	for i, tok := range tokens {
 		if i > 0 {
 			b.WriteByte(',')
@@ -1683,7 +1735,7 @@ func writeMetadata(path string, doc *metadataDoc) error {
 		}
 		b.Write(kEnc)
 		b.WriteString(": ")
-		entryEnc, err := indentJSON(doc.Resources[tok], "    ")
+		entryEnc, err := indentJSON(entries[tok], "    ")
 		if err != nil {
 			return err
 		}
@@ -1692,9 +1744,108 @@ func writeMetadata(path string, doc *metadataDoc) error {
 	if len(tokens) > 0 {
 		b.WriteString("\n  ")
 	}
-	b.WriteString("}\n}\n")
+	b.WriteString("}")
+	return nil
+}
 
-	return atomicWriteFile(path, []byte(b.String()), 0o600)
+// functionDerivation is the auto-derived part of one functions entry.
+type functionDerivation struct {
+	Operation string
+	Token     string
+	Renames   map[string]string
+}
+
+// deriveFunctions proposes a get<Type> function for every non-excluded
+// resource whose read op is a GET. The function reuses the resource's
+// renames (including hand overrides) so its inputs and outputs carry the
+// same names as the resource's, and mirrors its module placement.
+func deriveFunctions(
+	spec *rest.Spec,
+	candidates map[string]derivedOps,
+	excluded map[string]bool,
+	doc *metadataDoc,
+	modules map[string]moduleAssignment,
+) map[string]functionDerivation {
+	out := map[string]functionDerivation{}
+	for tok, ops := range candidates {
+		if excluded[tok] {
+			continue
+		}
+		read := opOrNil(spec, ops.Read)
+		if read == nil || read.Method != http.MethodGet {
+			continue
+		}
+		key := functionKey(tok)
+		if excluded[key] {
+			continue
+		}
+		var entry struct {
+			Token   string            `json:"token"`
+			Renames map[string]string `json:"renames"`
+		}
+		if raw, ok := doc.Resources[tok]; ok {
+			if err := json.Unmarshal(raw, &entry); err != nil {
+				fail("parse %s: %v", tok, err)
+			}
+		}
+		resTok := entry.Token
+		if resTok == "" {
+			resTok = deriveToken(doc.Package, tok, modules[tok])
+		}
+		out[key] = functionDerivation{
+			Operation: ops.Read,
+			Token:     functionKey(resTok),
+			Renames:   entry.Renames,
+		}
+	}
+	return out
+}
+
+// functionKey turns a resource token into its get<Type> function token
+// ("pkg:api/teams:Team" → "pkg:api/teams:getTeam").
+func functionKey(resourceToken string) string {
+	i := strings.LastIndex(resourceToken, ":")
+	return resourceToken[:i+1] + "get" + resourceToken[i+1:]
+}
+
+// mergeFunction layers a functionDerivation onto an existing functions entry.
+// Like mergeOperations, `operation` is replaced wholesale and everything else
+// is written only when absent (renames merge key-wise, hand edits winning).
+func mergeFunction(existing json.RawMessage, d functionDerivation) (json.RawMessage, bool, error) {
+	var entry map[string]any
+	if len(existing) > 0 {
+		if err := json.Unmarshal(existing, &entry); err != nil {
+			return nil, false, err
+		}
+	}
+	if entry == nil {
+		entry = map[string]any{}
+	}
+
+	prev, _ := entry["operation"].(string)
+	changed := prev != d.Operation
+	entry["operation"] = d.Operation
+
+	if len(d.Renames) > 0 {
+		merged := map[string]any{}
+		for k, v := range d.Renames {
+			merged[k] = v
+		}
+		if existing, ok := entry["renames"].(map[string]any); ok {
+			maps.Copy(merged, existing)
+		}
+		entry["renames"] = merged
+	}
+	if _, has := entry["token"]; !has && d.Token != "" {
+		entry["token"] = d.Token
+		changed = true
+	}
+
+	encoded, err := encodeStable(entry)
+	if err != nil {
+		return nil, false, err
+	}
+	return encoded, changed, nil
 }
 
 // atomicWriteFile writes via "<path>.tmp" + os.Rename so a Ctrl-C, OOM, or
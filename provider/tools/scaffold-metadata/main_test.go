@@ -88,6 +88,63 @@ func TestMergeAttachmentPreservesPinnedIDFormat(t *testing.T) {
 	}
 }
 
+// TestMergeFunction checks the opt-in functions merge: an empty entry gets
+// the derived operation, token, and renames; hand-edited renames and a pinned
+// token survive, and an identical re-merge reports no change.
+func TestMergeFunction(t *testing.T) {
+	d := functionDerivation{
+		Operation: "GetTeam",
+		Token:     "pulumiservice:api/teams:getTeam",
+		Renames:   map[string]string{nameFieldKey: "teamName"},
+	}
+
+	enc, changed, err := mergeFunction(json.RawMessage(`{}`), d)
+	if err != nil {
+		t.Fatalf("merge (new): %v", err)
+	}
+	if !changed {
+		t.Error("filling an empty entry must report changed=true")
+	}
+	if _, changed, err := mergeFunction(enc, d); err != nil {
+		t.Fatalf("merge (idempotent): %v", err)
+	} else if changed {
+		t.Error("an identical re-merge must report changed=false")
+	}
+
+	pinned := json.RawMessage(`{"token":"pulumiservice:api:getTeam","renames":{"name":"displayName"},"description":"hand"}`)
+	enc, _, err = mergeFunction(pinned, d)
+	if err != nil {
+		t.Fatalf("merge (pinned): %v", err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(enc, &out); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got := out["token"]; got != "pulumiservice:api:getTeam" {
+		t.Errorf("pinned token must be preserved, got %v", got)
+	}
+	if got := out["renames"].(map[string]any)[nameFieldKey]; got != "displayName" {
+		t.Errorf("hand-edited rename must win, got %v", got)
+	}
+	if got := out["operation"]; got != "GetTeam" {
+		t.Errorf("operation = %v, want GetTeam", got)
+	}
+	if got := out["description"]; got != "hand" {
+		t.Errorf("hand-curated description dropped, got %v", got)
+	}
+}
+
+func TestFunctionKey(t *testing.T) {
+	for in, want := range map[string]string{
+		"pulumiservice:api:Gate":       "pulumiservice:api:getGate",
+		"pulumiservice:api/teams:Team": "pulumiservice:api/teams:getTeam",
+	} {
+		if got := functionKey(in); got != want {
+			t.Errorf("functionKey(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
 const (
 	updateEnvelopeThingOp = "UpdateEnvelopeThing"
 	updateThingUnionOp    = "UpdateThingUnion"